@@ -0,0 +1,154 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/bitcoin"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ethereum"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+)
+
+// fixture is a generated sample secret.
+type fixture struct {
+	Kind   string `json:"kind"`
+	Secret string `json:"secret"`
+	// Address is the address controlled by a private key or mnemonic, if it has one.
+	Address string `json:"address,omitempty"`
+}
+
+// generator derives fixtures deterministically from a seed: the n-th fixture of a kind only depends on the seed, the
+// kind and n. Nothing generated here is, or can become, a funded key by accident of randomness, because anyone can
+// regenerate it from the public seed.
+type generator struct {
+	seed string
+}
+
+// bytes returns the counter-th 32 bytes of the stream of the n-th fixture of kind.
+func (g generator) bytes(kind string, n, counter int) []byte {
+	h := sha256.New()
+	h.Write([]byte(g.seed))
+	for _, v := range []int{n, counter} {
+		h.Write(binary.BigEndian.AppendUint64([]byte("/"+kind+"/"), uint64(v)))
+	}
+	return h.Sum(nil)
+}
+
+// privateKey returns a valid secp256k1 private key for the n-th fixture of kind.
+func (g generator) privateKey(kind string, n int) []byte {
+	for counter := 0; ; counter++ {
+		if key := g.bytes(kind, n, counter); secp256k1.ValidPrivateKey(key) {
+			return key
+		}
+	}
+}
+
+func (g generator) eth(n int) (fixture, error) {
+	key := g.privateKey("eth", n)
+	address, err := ethereum.AddressFromPrivateKey(key)
+	if err != nil {
+		return fixture{}, err
+	}
+	return fixture{Kind: "eth", Secret: "0x" + hex.EncodeToString(key), Address: address}, nil
+}
+
+// wif returns a compressed mainnet WIF (starting with K or L) with its P2PKH address.
+func (g generator) wif(n int) (fixture, error) {
+	key := g.privateKey("wif", n)
+	payload := append([]byte{bitcoin.MainnetWIFVersion}, key...)
+	payload = append(payload, 0x01)
+	pub, err := secp256k1.CompressedPublicKey(key)
+	if err != nil {
+		return fixture{}, err
+	}
+	return fixture{
+		Kind:    "wif",
+		Secret:  bitcoin.EncodeBase58Check(payload),
+		Address: bitcoin.P2PKHAddress(pub, bitcoin.MainnetP2PKHVersion),
+	}, nil
+}
+
+// mnemonicWords are the first 64 words of the BIP-39 English word list. With 12 words, the checksum bits always fall
+// into the low bits of the last word, so mnemonics made of these words alone can still carry a valid checksum.
+var mnemonicWords = strings.Fields(`
+	abandon ability able about above absent absorb abstract absurd abuse access accident account accuse achieve acid
+	acoustic acquire across act action actor actress actual adapt add addict address adjust admit adult advance
+	advice aerobic affair afford afraid again age agent agree ahead aim air airport aisle alarm album
+	alcohol alert alien all alley allow almost alone alpha already also alter always amateur amazing among`)
+
+// mnemonic returns a 12-word BIP-39 mnemonic with a valid checksum.
+func (g generator) mnemonic(n int) (fixture, error) {
+	// 12 words of 11 bits hold 128 bits of entropy and a 4-bit checksum. Only the low 6 bits of every word are used,
+	// so that the words stay within mnemonicWords.
+	stream := g.bytes("mnemonic", n, 0)
+	indexes := make([]int, 12)
+	for i := range indexes[:11] {
+		indexes[i] = int(stream[i]) % len(mnemonicWords)
+	}
+	// The last word holds the last 7 bits of entropy, of which the top 5 are zero, and the checksum.
+	indexes[11] = int(stream[11]%4) << 4
+
+	var bits uint64
+	var entropy []byte
+	nbits := 0
+	for _, idx := range indexes {
+		bits = bits<<11 | uint64(idx)
+		nbits += 11
+		for nbits >= 8 && len(entropy) < 16 {
+			nbits -= 8
+			entropy = append(entropy, byte(bits>>nbits))
+		}
+	}
+	sum := sha256.Sum256(entropy)
+	indexes[11] |= int(sum[0] >> 4)
+
+	words := make([]string, len(indexes))
+	for i, idx := range indexes {
+		words[i] = mnemonicWords[idx]
+	}
+	return fixture{Kind: "mnemonic", Secret: strings.Join(words, " ")}, nil
+}
+
+// coze returns a token in the format of Coze personal access tokens. It is not a real token.
+func (g generator) coze(n int) (fixture, error) {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	var b strings.Builder
+	b.WriteString("pat_")
+	for counter := 0; b.Len() < 4+64; counter++ {
+		for _, c := range g.bytes("coze", n, counter) {
+			if b.Len() == 4+64 {
+				break
+			}
+			b.WriteByte(alphabet[int(c)%len(alphabet)])
+		}
+	}
+	return fixture{Kind: "coze", Secret: b.String()}, nil
+}
+
+// kinds are the fixture kinds that can be generated, by name.
+var kinds = map[string]func(generator, int) (fixture, error){
+	"eth":      generator.eth,
+	"wif":      generator.wif,
+	"mnemonic": generator.mnemonic,
+	"coze":     generator.coze,
+}
+
+// generate returns count fixtures of kind.
+func generate(seed, kind string, count int) ([]fixture, error) {
+	gen, ok := kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown fixture kind %q", kind)
+	}
+	fixtures := make([]fixture, 0, count)
+	for n := 0; n < count; n++ {
+		f, err := gen(generator{seed: seed}, n)
+		if err != nil {
+			return nil, fmt.Errorf("error generating %s fixture %d: %w", kind, n, err)
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/bitcoin"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ethereum"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+)
+
+func TestGenerate_Deterministic(t *testing.T) {
+	for _, kind := range kindNames() {
+		first, err := generate("seed", kind, 3)
+		require.NoError(t, err)
+		second, err := generate("seed", kind, 3)
+		require.NoError(t, err)
+		other, err := generate("other seed", kind, 3)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second, kind)
+		assert.NotEqual(t, first, other, kind)
+		assert.NotEqual(t, first[0], first[1], kind)
+	}
+}
+
+func TestGenerate_Valid(t *testing.T) {
+	const count = 20
+
+	eth, err := generate("seed", "eth", count)
+	require.NoError(t, err)
+	for _, f := range eth {
+		key, err := hex.DecodeString(strings.TrimPrefix(f.Secret, "0x"))
+		require.NoError(t, err)
+		assert.True(t, secp256k1.ValidPrivateKey(key), f.Secret)
+		address, err := ethereum.AddressFromPrivateKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, address, f.Address)
+	}
+
+	wifs, err := generate("seed", "wif", count)
+	require.NoError(t, err)
+	for _, f := range wifs {
+		wif, err := bitcoin.DecodeWIF(f.Secret)
+		require.NoError(t, err, f.Secret)
+		assert.Equal(t, byte(bitcoin.MainnetWIFVersion), wif.Version)
+		assert.True(t, wif.Compressed)
+		pub, err := wif.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, bitcoin.P2PKHAddress(pub, bitcoin.MainnetP2PKHVersion), f.Address)
+	}
+
+	mnemonics, err := generate("seed", "mnemonic", count)
+	require.NoError(t, err)
+	for _, f := range mnemonics {
+		assert.True(t, validMnemonic(f.Secret), f.Secret)
+	}
+
+	cozePat := regexp.MustCompile(`^(?:pat|sat)_[A-Za-z0-9]{64}$`)
+	tokens, err := generate("seed", "coze", count)
+	require.NoError(t, err)
+	for _, f := range tokens {
+		assert.Regexp(t, cozePat, f.Secret)
+	}
+}
+
+func TestValidMnemonic(t *testing.T) {
+	assert.True(t, validMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"))
+	assert.False(t, validMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+}
+
+// validMnemonic checks the BIP-39 checksum of a 12-word mnemonic of words from mnemonicWords.
+func validMnemonic(mnemonic string) bool {
+	words := strings.Fields(mnemonic)
+	if len(words) != 12 {
+		return false
+	}
+	var entropy []byte
+	var bits uint64
+	nbits := 0
+	for _, word := range words {
+		idx := slices.Index(mnemonicWords, word)
+		if idx < 0 {
+			return false
+		}
+		bits = bits<<11 | uint64(idx)
+		nbits += 11
+		for nbits >= 8 && len(entropy) < 16 {
+			nbits -= 8
+			entropy = append(entropy, byte(bits>>nbits))
+		}
+	}
+	sum := sha256.Sum256(entropy)
+	return bits&0xf == uint64(sum[0]>>4)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	// CLI flags and arguments
+	app = kingpin.New("genfixtures", "Generate deterministic sample secrets for tests and docs. They are structurally valid, but are not real credentials and control no funds.")
+
+	kind   = app.Arg("kind", "Kind of fixture to generate.").Required().Enum(kindNames()...)
+	count  = app.Flag("count", "Number of fixtures to generate.").Default("1").Int()
+	seed   = app.Flag("seed", "Seed the fixtures are derived from. The same seed always generates the same fixtures.").Default("trufflehog-fixtures").String()
+	asJSON = app.Flag("json", "Print the fixtures as JSON lines.").Bool()
+)
+
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	fixtures, err := generate(*seed, *kind, *count)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, f := range fixtures {
+		switch {
+		case *asJSON:
+			_ = enc.Encode(f)
+		case f.Address != "":
+			fmt.Printf("%s\t%s\n", f.Secret, f.Address)
+		default:
+			fmt.Println(f.Secret)
+		}
+	}
+}
+
+func kindNames() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
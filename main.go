@@ -2,18 +2,22 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/fatih/color"
@@ -23,6 +27,7 @@ import (
 	"github.com/mattn/go-isatty"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/admin"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/analyzer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/simple"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cleantemp"
@@ -30,13 +35,20 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitcoinwif"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/log"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/manifest"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/quarantine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/ruleset"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scandiff"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scantrace"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/sampling"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/tui"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/updater"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/verificationcache"
@@ -51,13 +63,24 @@ var (
 	debug               = cli.Flag("debug", "Run in debug mode.").Hidden().Bool()
 	trace               = cli.Flag("trace", "Run in trace mode.").Hidden().Bool()
 	profile             = cli.Flag("profile", "Enables profiling and sets a pprof and fgprof server on :18066.").Bool()
+	adminAddr           = cli.Flag("admin-addr", "Serve pprof, live scan progress and detector metrics on this localhost address, e.g. 127.0.0.1:18067.").String()
 	localDev            = cli.Flag("local-dev", "Hidden feature to disable overseer for local dev.").Hidden().Bool()
 	jsonOut             = cli.Flag("json", "Output in JSON format.").Short('j').Bool()
 	jsonLegacy          = cli.Flag("json-legacy", "Use the pre-v3.0 JSON format. Only works with git, gitlab, and github sources.").Bool()
 	gitHubActionsFormat = cli.Flag("github-actions", "Output in GitHub Actions format.").Bool()
 	concurrency         = cli.Flag("concurrency", "Number of concurrent workers.").Default(strconv.Itoa(runtime.NumCPU())).Int()
+	concurrentUnits     = cli.Flag("concurrent-units", "Number of source units, like repositories or files, to chunk concurrently per source. Defaults to --concurrency.").Int()
+	unitChunkBuffer     = cli.Flag("unit-chunk-buffer", "Number of chunks a single source unit may read ahead of the scanner. It only sets read-ahead and adds no parallelism: how many files of a unit are chunked at once is up to its source.").Default("64").Int()
+	prioritizeUnits     = cli.Flag("prioritize-units", "Chunk env and config files first, and vendored code last, within and across source units so findings surface early in long scans.").Bool()
 	noVerification      = cli.Flag("no-verification", "Don't verify the results.").Bool()
-	onlyVerified        = cli.Flag("only-verified", "Only output verified results.").Hidden().Bool()
+	replaySessions      = cli.Flag("replay-session-cookies", "Verify session cookies and bearer tokens by requesting the site they were issued by with them. This acts as the logged-in user.").Bool()
+	probeDatastoreAuth  = cli.Flag("probe-datastore-auth", "Verify Redis and Memcached passwords by connecting to the server they were found with and authenticating. No other command is sent.").Bool()
+	deepVerify          = cli.Flag("deep-verify", "Run expensive checks, like balance lookups across all EVM chains, for verified results on a bounded queue. Results are reported once their checks complete.").Bool()
+	deepVerifyWorkers   = cli.Flag("deep-verify-workers", "Number of concurrent deep verifications.").Default("4").Int()
+	deepVerifyQueueSize = cli.Flag("deep-verify-queue-size", "Number of results that may wait for deep verification. Results that do not fit are reported without it.").Default("256").Int()
+	deepVerifyTimeout   = cli.Flag("deep-verify-timeout", "Maximum time to spend deep verifying a result.").Default("30s").Duration()
+	deepVerifyFollowUp  = cli.Flag("deep-verify-follow-up", "Report results right away and again, as follow-ups, once their deep verification completes.").Bool()
+	onlyVerified        = cli.Flag("only-verified", "Only output verified results.").Bool()
 	results             = cli.Flag("results", "Specifies which type(s) of results to output: verified (confirmed valid by API), unknown (verification failed due to error), unverified (detected but not verified), filtered_unverified (unverified but would have been filtered out). Defaults to verified,unverified,unknown.").String()
 	noColor             = cli.Flag("no-color", "Disable colorized output").Bool()
 	noColour            = cli.Flag("no-colour", "Alias for --no-color").Hidden().Bool()
@@ -65,12 +88,37 @@ var (
 	allowVerificationOverlap   = cli.Flag("allow-verification-overlap", "Allow verification of similar credentials across detectors").Bool()
 	filterUnverified           = cli.Flag("filter-unverified", "Only output first unverified result per chunk per detector if there are more than one results.").Bool()
 	filterEntropy              = cli.Flag("filter-entropy", "Filter unverified results with Shannon entropy. Start with 3.0.").Float64()
+	includeIndeterminate       = cli.Flag("include-indeterminate", "With --only-verified, also output results whose verification failed due to an error.").Bool()
+	minConfidence              = cli.Flag("min-confidence", "Only output results with at least this confidence: low, medium, or high.").Enum("low", "medium", "high")
 	scanEntireChunk            = cli.Flag("scan-entire-chunk", "Scan the entire chunk for secrets.").Hidden().Default("false").Bool()
 	maxDecodeDepth             = cli.Flag("max-decode-depth", "Maximum depth of iterative decoding. Each decoder's output is fed back through all decoders, up to this limit. 1 = single pass, 2+ = chained decoding (e.g., base64 inside utf16).").Default("5").Int()
 	compareDetectionStrategies = cli.Flag("compare-detection-strategies", "Compare different detection strategies for matching spans").Hidden().Default("false").Bool()
 	configFilename             = cli.Flag("config", "Path to configuration file.").ExistingFile()
+	rulesetURL                 = cli.Flag("ruleset-url", "HTTPS URL of a signed ruleset, a configuration file of custom detectors and candidate rules without sources, to load in addition to --config. Its Ed25519 signature is fetched from the same URL with .sig appended.").String()
+	rulesetPublicKey           = cli.Flag("ruleset-public-key", "Base64 encoded Ed25519 public key that verifies the signature of --ruleset-url. Can be provided with environment variable TRUFFLEHOG_RULESET_PUBLIC_KEY.").Envar("TRUFFLEHOG_RULESET_PUBLIC_KEY").String()
+	rulesetCacheDir            = cli.Flag("ruleset-cache-dir", "Directory to keep the last verified --ruleset-url in. It is used if the ruleset cannot be fetched.").String()
+	sanitizeSeedPhrases        = cli.Flag("sanitize-seed-phrases", "Never output full seed phrases: replace them with their first word and a SHA-256 hash in all results.").Bool()
+	hashSecrets                = cli.Flag("hash-secrets", "Replace raw secrets in all outputs with their HMAC-SHA256 under the key given by --hash-key.").Bool()
+	hashKey                    = cli.Flag("hash-key", "Key for --hash-secrets. Can be provided with environment variable TRUFFLEHOG_HASH_KEY.").Envar("TRUFFLEHOG_HASH_KEY").String()
+	quarantineFile             = cli.Flag("quarantine", "Copy the files containing verified secrets into an OpenPGP encrypted evidence bundle at the provided path, with a manifest of their hashes and locations.").String()
+	quarantineRecipients       = cli.Flag("quarantine-recipients", "Path to armored OpenPGP public keys to encrypt the --quarantine bundle to.").ExistingFile()
+	quarantinePassphrase       = cli.Flag("quarantine-passphrase", "Passphrase to encrypt the --quarantine bundle with, if no recipients are given. Can be provided with environment variable TRUFFLEHOG_QUARANTINE_PASSPHRASE.").Envar("TRUFFLEHOG_QUARANTINE_PASSPHRASE").String()
+	zeroizeSecrets             = cli.Flag("zeroize-secrets", "Wipe raw secrets from memory once results are output, and keep only hashes of secrets in the scanner's caches.").Bool()
+	minKeywordLength           = cli.Flag("min-keyword-length", "Ignore detector keywords shorter than this, unless they are allowed with --allow-short-keyword. 0 disables the minimum.").Default("4").Int()
+	allowShortKeywords         = cli.Flag("allow-short-keyword", "Detector keyword to dispatch on even if it is shorter than --min-keyword-length. Can be repeated.").Strings()
+	keywordPacks               = cli.Flag("keyword-pack", "Also dispatch detectors that support it on the keywords of this language pack. zh: Chinese secret labels such as 密钥, 私钥 and 令牌. Can be repeated.").Enums(detectors.KeywordPackChinese)
+	canaryListFilename         = cli.Flag("canary-list", "Path to a file of known canary secrets or addresses, one per line. Matching results are tagged as suspected_canary.").ExistingFile()
+	deterministic              = cli.Flag("deterministic", "Make scan reports reproducible for CI: disable verification and sort results. Combine with --scan-time to also pin expiry checks.").Bool()
+	sortResults                = cli.Flag("sort-results", "Output results in a deterministic order once the scan finishes instead of as they are found.").Bool()
+	scanTime                   = cli.Flag("scan-time", "Evaluate time-dependent checks, like whether a credential has expired, at this time instead of now. RFC 3339 or Unix seconds. Can be provided with environment variable SOURCE_DATE_EPOCH.").Envar("SOURCE_DATE_EPOCH").String()
+	expectedOwnersFilename     = cli.Flag("expected-owners", "Path to a file of identities your organization owns, one '[detector:]field pattern [label]' per line. Verified credentials whose identity matches are tagged our_credential, others third_party_credential.").ExistingFile()
+	ownedWalletsFilename       = cli.Flag("owned-wallets", "Path to a file of your organization's wallet addresses, one per line. Keys that control one of them are raised to critical and tagged as owned_asset.").ExistingFile()
+	wifNetworks                = cli.Flag("wif-network", "Also detect WIF private keys of this network with the BitcoinWIF detector, which only detects Bitcoin mainnet keys by default. The network is reported in the extra data of each result. One of testnet, litecoin or dogecoin. Can be repeated.").Strings()
+	otelTraces                 = cli.Flag("otel-traces", "Export the scan as OpenTelemetry traces over OTLP/HTTP, with spans for the scan, its sources, units and batches of chunks, and an event for each finding. The exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables.").Bool()
+	otelEndpoint               = cli.Flag("otel-endpoint", "URL of the OTLP/HTTP collector to send --otel-traces to, e.g. http://localhost:4318. Defaults to OTEL_EXPORTER_OTLP_ENDPOINT.").String()
 	// rules = cli.Flag("rules", "Path to file with custom rules.").String()
 	printAvgDetectorTime = cli.Flag("print-avg-detector-time", "Print the average time spent on each detector.").Bool()
+	debugFindings        = cli.Flag("debug-findings", "Record on each result the keywords that triggered its detector and the pattern and capture group that matched it. Useful for reporting false positives.").Bool()
 	noUpdate             = cli.Flag("no-update", "Don't check for updates.").Bool()
 	fail                 = cli.Flag("fail", "Exit with code 183 if results are found.").Bool()
 	failOnScanErrors     = cli.Flag("fail-on-scan-errors", "Exit with non-zero error code if an error occurs during the scan.").Bool()
@@ -82,9 +130,19 @@ var (
 	archiveTimeout       = cli.Flag("archive-timeout", "Maximum time to spend extracting an archive.").Duration()
 	includeDetectors     = cli.Flag("include-detectors", "Comma separated list of detector types to include. Protobuf name or IDs may be used, as well as ranges.").Default("all").String()
 	excludeDetectors     = cli.Flag("exclude-detectors", "Comma separated list of detector types to exclude. Protobuf name or IDs may be used, as well as ranges. IDs defined here take precedence over the include list.").String()
+	maxScanBytes         = cli.Flag("max-scan-bytes", "Stop the scan with partial results after scanning this many bytes. (Byte units eg. 512MB, 2GB)").Bytes()
+	maxFindings          = cli.Flag("max-findings", "Report at most this many findings. A scan that finds more stops with partial results.").Uint64()
+	maxScanDuration      = cli.Flag("max-scan-duration", "Stop the scan with partial results after this much wall-clock time (e.g., 10m).").Duration()
+	shutdownTimeout      = cli.Flag("shutdown-timeout", "On SIGINT or SIGTERM, time allowed to scan the data already read and flush the results before exiting. A second signal exits immediately.").Default("30s").Duration()
+	checkpointFile       = cli.Flag("checkpoint", "When the scan is interrupted, write a checkpoint of the progress of its sources to the provided path.").String()
+	resumeFile           = cli.Flag("resume", "Resume an interrupted scan from a checkpoint written by --checkpoint.").ExistingFile()
 	jobReportFile        = cli.Flag("output-report", "Write a scan report to the provided path.").Hidden().OpenFile(os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	manifestFile         = cli.Flag("output-manifest", "Write a JSON manifest of the scan to the provided path: the trufflehog version, the detectors and their versions, the verification policy, the allowlists applied and the source units attempted.").String()
 
 	noVerificationCache = cli.Flag("no-verification-cache", "Disable verification caching").Bool()
+	resolveRotatedAfter = cli.Flag("resolve-rotated-after", "Report a credential that was verified live before with the resolved_rotated finding state once it fails verification as invalid this many consecutive times, at least --reverify-interval apart. Network errors don't count. Meant for long-running scans like filesystem --watch, or scheduled scans with --rotation-state. 0 disables it.").Default("0").Int()
+	reverifyInterval    = cli.Flag("reverify-interval", "With --resolve-rotated-after, how long the cached verification of a live credential is used before it is verified again when found again.").Default("1h").Duration()
+	rotationState       = cli.Flag("rotation-state", "With --resolve-rotated-after, file to keep the verification state of live credentials in across scans. It holds hashes of the credentials, not the credentials.").String()
 
 	// Add feature flags
 	forceSkipBinaries  = cli.Flag("force-skip-binaries", "Force skipping binaries.").Bool()
@@ -163,6 +221,8 @@ var (
 	filesystemScanIncludePaths    = filesystemScan.Flag("include-paths", "Path to file with newline separated regexes for files to include in scan.").Short('i').String()
 	filesystemScanExcludePaths    = filesystemScan.Flag("exclude-paths", "Path to file with newline separated regexes for files to exclude in scan.").Short('x').String()
 	filesystemScanMaxSymlinkDepth = filesystemScan.Flag("max-symlink-depth", "Maximum depth to follow symlinks during filesystem scan.").Short('s').Int32()
+	filesystemWatch               = filesystemScan.Flag("watch", "Keep running and scan files as they are created or modified, instead of scanning the paths once.").Bool()
+	filesystemWatchDebounce       = filesystemScan.Flag("watch-debounce", "With --watch, how long a file must go without changes before it is scanned.").Default("2s").Duration()
 
 	s3Scan              = cli.Command("s3", "Find credentials in S3 buckets.")
 	s3ScanKey           = s3Scan.Flag("key", "S3 key used to authenticate. Can be provided with environment variable AWS_ACCESS_KEY_ID.").Envar("AWS_ACCESS_KEY_ID").String()
@@ -173,6 +233,10 @@ var (
 	s3ScanBuckets       = s3Scan.Flag("bucket", "Name of S3 bucket to scan. You can repeat this flag. Incompatible with --ignore-bucket.").Strings()
 	s3ScanIgnoreBuckets = s3Scan.Flag("ignore-bucket", "Name of S3 bucket to ignore. You can repeat this flag. Incompatible with --bucket.").Strings()
 	s3ScanMaxObjectSize = s3Scan.Flag("max-object-size", "Maximum size of objects to scan. Objects larger than this will be skipped. (Byte units eg. 512B, 2KB, 4MB)").Default("250MB").Bytes()
+	s3ScanSample        = s3Scan.Flag("sample", "Scan a sample of each bucket instead of all objects, for buckets too large to scan fully: the newest objects and small text files fully, and a share of the rest. The coverage is recorded in --output-manifest.").Bool()
+	s3ScanSampleNewest  = s3Scan.Flag("sample-newest", "Percentage of the most recently modified objects of each bucket to scan fully with --sample.").Default("10").Float64()
+	s3ScanSampleSmall   = s3Scan.Flag("sample-small-size", "Size up to which text files are scanned fully with --sample. (Byte units eg. 512B, 2KB, 4MB)").Default("1MB").Bytes()
+	s3ScanSampleRate    = s3Scan.Flag("sample-rate", "Percentage of the remaining objects to scan with --sample.").Default("1").Float64()
 
 	gcsScan           = cli.Command("gcs", "Find credentials in GCS buckets.")
 	gcsProjectID      = gcsScan.Flag("project-id", "GCS project ID used to authenticate. Can NOT be used with unauth scan. Can be provided with environment variable GOOGLE_CLOUD_PROJECT.").Envar("GOOGLE_CLOUD_PROJECT").String()
@@ -270,12 +334,42 @@ var (
 	huggingfaceIncludeDiscussions = huggingfaceScan.Flag("include-discussions", "Include discussions in scan.").Bool()
 	huggingfaceIncludePrs         = huggingfaceScan.Flag("include-prs", "Include pull requests in scan.").Bool()
 
-	stdinInputScan = cli.Command("stdin", "Find credentials from stdin.")
-	multiScanScan  = cli.Command("multi-scan", "Find credentials in multiple sources defined in configuration.")
+	stdinInputScan      = cli.Command("stdin", "Find credentials from stdin.")
+	stdinInputStream    = stdinInputScan.Flag("stream", "Scan the input line by line as it arrives, for never-ending pipelines like kubectl logs -f.").Bool()
+	stdinInputIdleFlush = stdinInputScan.Flag("stream-idle-flush", "With --stream, how long to wait for more input before scanning the buffered lines.").Default("1s").Duration()
+
+	scanCmd           = cli.Command("scan", "Find credentials in changes before they are committed.")
+	stagedScan        = scanCmd.Command("staged", "Find credentials in the staged changes of a git repository, for pre-commit hooks. Only added lines are reported. Combine with --no-verification for the lowest latency.")
+	stagedScanRepo    = stagedScan.Flag("repo", "Path to the git repository.").Default(".").ExistingDir()
+	stagedScanContext = stagedScan.Flag("context", "Number of unchanged lines scanned around each change, for detectors that look at the lines around a secret.").Default("3").Int()
+
+	multiScanScan = cli.Command("multi-scan", "Find credentials in multiple sources defined in configuration.")
 
 	jsonEnumeratorScan  = cli.Command("json-enumerator", "Find credentials from a JSON enumerator input.")
 	jsonEnumeratorPaths = jsonEnumeratorScan.Arg("path", "Path to JSON enumerator file to scan.").Strings()
 
+	webScan             = cli.Command("web", "Crawl a web application and find credentials in its pages, JS bundles, source maps and configuration endpoints.")
+	webScanURLs         = webScan.Flag("url", "URL to crawl. You can repeat this flag.").Required().Strings()
+	webScanDepth        = webScan.Flag("depth", "How many links deep to follow pages.").Default("1").Int()
+	webScanMaxRequests  = webScan.Flag("max-requests", "Maximum number of requests per URL.").Default("200").Int()
+	webScanAllowedHosts = webScan.Flag("allowed-host", "Additional host that may be fetched, like a CDN serving the scripts. You can repeat this flag.").Strings()
+	webScanIgnoreRobots = webScan.Flag("ignore-robots", "Fetch pages even if robots.txt disallows it.").Bool()
+
+	ciArtifactsScan              = cli.Command("ci-artifacts", "Find credentials in the job logs and artifacts of recent GitHub Actions workflow runs and Bitbucket Pipelines.")
+	ciArtifactsGitHubRepos       = ciArtifactsScan.Flag("github-repo", "GitHub repository to scan, as owner/repo. You can repeat this flag.").Strings()
+	ciArtifactsGitHubToken       = ciArtifactsScan.Flag("github-token", "GitHub token with read access to Actions. Can be provided with environment variable GITHUB_TOKEN.").Envar("GITHUB_TOKEN").String()
+	ciArtifactsGitHubEndpoint    = ciArtifactsScan.Flag("github-endpoint", "GitHub API endpoint.").Default("https://api.github.com").String()
+	ciArtifactsBitbucketRepos    = ciArtifactsScan.Flag("bitbucket-repo", "Bitbucket repository to scan, as workspace/repo. You can repeat this flag.").Strings()
+	ciArtifactsBitbucketToken    = ciArtifactsScan.Flag("bitbucket-token", "Bitbucket access token, or username:app_password. Can be provided with environment variable BITBUCKET_TOKEN.").Envar("BITBUCKET_TOKEN").String()
+	ciArtifactsBitbucketEndpoint = ciArtifactsScan.Flag("bitbucket-endpoint", "Bitbucket API endpoint.").Default("https://api.bitbucket.org/2.0").String()
+	ciArtifactsMaxRuns           = ciArtifactsScan.Flag("max-runs", "Number of most recent workflow runs or pipelines to scan per repository.").Default("10").Int()
+
+	diffCmd  = cli.Command("diff", "Compare the secrets in two git refs, two Docker images or two reports, and report the secrets added, removed and persisting between them. With --fail, exit with code 183 if any secret was added.")
+	diffKind = diffCmd.Flag("kind", "Kind of the inputs: report (files written with --json), git (refs of --repo, comparing the files at each ref, not their history) or docker (images).").Default("report").Enum("report", "git", "docker")
+	diffRepo = diffCmd.Flag("repo", "Git repository of the refs to compare with --kind=git. https://, file://, or ssh:// schema expected.").Default("file://.").String()
+	diffOld  = diffCmd.Arg("old", "The old input, e.g. the ref v1.2.").Required().String()
+	diffNew  = diffCmd.Arg("new", "The new input, e.g. the ref v1.3.").Required().String()
+
 	analyzeCmd = analyzer.Command(cli)
 	usingTUI   = false
 )
@@ -444,7 +538,18 @@ func run(state overseer.State, logSync func() error) {
 	killSignal := make(chan os.Signal, 1)
 	signal.Notify(killSignal, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	go func() {
-		<-killSignal
+		sig := <-killSignal
+		// Let a running scan finish the data it already read and flush its
+		// results, unless a second signal arrives or it takes too long.
+		if sig != syscall.SIGQUIT && interruptScan(ctx, fmt.Sprintf("received %s", sig)) {
+			logger.Info("Received signal, finishing the scan with partial results. Send the signal again to exit immediately.",
+				"timeout", shutdownTimeout.String())
+			select {
+			case <-killSignal:
+			case <-time.After(*shutdownTimeout):
+				logger.Info("timed out finishing the scan")
+			}
+		}
 		logger.Info("Received signal, shutting down.")
 		cancel(fmt.Errorf("canceling context due to signal"))
 
@@ -494,6 +599,10 @@ func run(state overseer.State, logSync func() error) {
 		feature.ForceSkipArchives.Store(true)
 	}
 
+	if *prioritizeUnits {
+		feature.PrioritizeFiles.Store(true)
+	}
+
 	if gitCloneTimeout != nil {
 		feature.GitCloneTimeoutDuration.Store(int64(*gitCloneTimeout))
 	}
@@ -528,6 +637,21 @@ func run(state overseer.State, logSync func() error) {
 		}
 	}
 
+	// Parse --ruleset-url flag.
+	var rs *ruleset.Ruleset
+	if *rulesetURL != "" {
+		key, err := ruleset.ParsePublicKey(*rulesetPublicKey)
+		if err != nil {
+			logFatal(err, "failed to configure ruleset")
+		}
+		rs, err = ruleset.Load(ctx, ruleset.Options{URL: *rulesetURL, PublicKey: key, CacheDir: *rulesetCacheDir})
+		if err != nil {
+			logFatal(err, "failed to load ruleset")
+		}
+		logger.Info("loaded ruleset", "url", *rulesetURL, "sha256", rs.SHA256, "cached", rs.Cached, "detectors", len(rs.Detectors))
+		conf.Add(rs.Config)
+	}
+
 	if *detectorTimeout != 0 {
 		logger.Info("Setting detector timeout", "timeout", detectorTimeout.String())
 		engine.SetDetectorTimeout(*detectorTimeout)
@@ -561,17 +685,106 @@ func run(state overseer.State, logSync func() error) {
 	}
 
 	// Parse --results flag.
-	if *onlyVerified {
-		r := "verified"
-		results = &r
-	}
 	parsedResults, err := parseResults(results)
 	if err != nil {
 		logFatal(err, "failed to configure results flag")
 	}
 
+	// Parse --min-confidence flag.
+	var parsedMinConfidence detectors.Confidence
+	if *minConfidence != "" {
+		parsedMinConfidence, _ = detectors.ParseConfidence(*minConfidence)
+	}
+
+	// Parse --canary-list flag.
+	var canaryList *detectors.CanaryList
+	if *canaryListFilename != "" {
+		f, err := os.Open(*canaryListFilename)
+		if err != nil {
+			logFatal(err, "failed to open canary list")
+		}
+		canaryList, err = detectors.ReadCanaryList(f)
+		_ = f.Close()
+		if err != nil {
+			logFatal(err, "failed to parse canary list")
+		}
+	}
+
+	// Parse --owned-wallets flag.
+	var ownedWallets *detectors.OwnedWallets
+	if *ownedWalletsFilename != "" {
+		f, err := os.Open(*ownedWalletsFilename)
+		if err != nil {
+			logFatal(err, "failed to open owned wallets list")
+		}
+		ownedWallets, err = detectors.ReadOwnedWallets(f)
+		_ = f.Close()
+		if err != nil {
+			logFatal(err, "failed to parse owned wallets list")
+		}
+	}
+
+	// Parse --expected-owners flag.
+	var expectedOwners *detectors.ExpectedOwners
+	if *expectedOwnersFilename != "" {
+		f, err := os.Open(*expectedOwnersFilename)
+		if err != nil {
+			logFatal(err, "failed to open expected owners list")
+		}
+		expectedOwners, err = detectors.ReadExpectedOwners(f)
+		_ = f.Close()
+		if err != nil {
+			logFatal(err, "failed to parse expected owners list")
+		}
+	}
+
+	// Parse --scan-time flag.
+	if *scanTime != "" {
+		t, err := detectors.ParseScanTime(*scanTime)
+		if err != nil {
+			logFatal(err, "failed to parse scan time")
+		}
+		detectors.SetScanTime(t)
+	}
+
+	// Parse --hash-secrets flag.
+	var secretHasher *detectors.SecretHasher
+	if *hashSecrets {
+		if *hashKey == "" {
+			logFatal(fmt.Errorf("--hash-key or TRUFFLEHOG_HASH_KEY is required"), "failed to configure secret hashing")
+		}
+		if *jsonLegacy {
+			logFatal(fmt.Errorf("--hash-secrets cannot be used with --json-legacy, which outputs the diff containing the secret"), "failed to configure secret hashing")
+		}
+		secretHasher = detectors.NewSecretHasher([]byte(*hashKey))
+	}
+
+	// Parse --wif-network flag.
+	defaultDetectors := defaults.DefaultDetectors()
+	if len(*wifNetworks) > 0 {
+		networks := make([]bitcoinwif.Network, 0, len(*wifNetworks))
+		for _, name := range *wifNetworks {
+			n, err := bitcoinwif.ParseNetwork(name)
+			if err != nil {
+				logFatal(err, "failed to configure --wif-network")
+			}
+			networks = append(networks, n)
+		}
+		for i, d := range defaultDetectors {
+			if _, ok := d.(*bitcoinwif.Scanner); ok {
+				defaultDetectors[i] = bitcoinwif.New(bitcoinwif.WithNetworks(networks...))
+			}
+		}
+	}
+
 	verificationCacheMetrics := verificationcache.InMemoryMetrics{}
 
+	// The engine applies its default minimum when none is set, so 0 is passed on as a negative minimum.
+	keywordMinimum := *minKeywordLength
+	if keywordMinimum <= 0 {
+		keywordMinimum = -1
+	}
+
 	engConf := engine.Config{
 		Concurrency:       *concurrency,
 		ConfiguredSources: conf.Sources,
@@ -579,8 +792,10 @@ func run(state overseer.State, logSync func() error) {
 		// default detectors, which can be further filtered by the
 		// user. The filters are applied by the engine and are only
 		// subtractive.
-		Detectors:                append(defaults.DefaultDetectors(), conf.Detectors...),
-		Verify:                   !*noVerification,
+		Detectors:                append(defaultDetectors, conf.Detectors...),
+		Verify:                   !*noVerification && !*deterministic,
+		ReplaySessionCookies:     *replaySessions,
+		ProbeDatastoreAuth:       *probeDatastoreAuth,
 		IncludeDetectors:         *includeDetectors,
 		ExcludeDetectors:         *excludeDetectors,
 		CustomVerifiersOnly:      *customVerifiersOnly,
@@ -588,18 +803,91 @@ func run(state overseer.State, logSync func() error) {
 		Dispatcher:               engine.NewPrinterDispatcher(printer),
 		FilterUnverified:         *filterUnverified,
 		FilterEntropy:            *filterEntropy,
+		CandidateRules:           conf.CandidateRules,
+		CanaryList:               canaryList,
+		OwnedWallets:             ownedWallets,
+		ExpectedOwners:           expectedOwners,
+		Limits:                   engine.ScanLimits{MaxBytes: uint64(*maxScanBytes), MaxFindings: *maxFindings, MaxDuration: *maxScanDuration},
+		SanitizeSeedPhrases:      *sanitizeSeedPhrases,
+		SecretHasher:             secretHasher,
+		ZeroizeSecrets:           *zeroizeSecrets,
 		VerificationOverlap:      *allowVerificationOverlap,
 		Results:                  parsedResults,
+		OnlyVerified:             *onlyVerified,
+		IncludeIndeterminate:     *includeIndeterminate,
+		MinConfidence:            parsedMinConfidence,
 		PrintAvgDetectorTime:     *printAvgDetectorTime,
+		DebugFindings:            *debugFindings,
 		ShouldScanEntireChunk:    *scanEntireChunk,
+		MinKeywordLength:         keywordMinimum,
+		AllowedShortKeywords:     *allowShortKeywords,
+		KeywordPacks:             *keywordPacks,
 		MaxDecodeDepth:           *maxDecodeDepth,
+		SortResults:              *sortResults || *deterministic,
 		VerificationCacheMetrics: &verificationCacheMetrics,
+		ResolveRotatedAfter:      *resolveRotatedAfter,
+		RotationStateFile:        *rotationState,
+		ReverifyInterval:         *reverifyInterval,
 	}
 
 	if !*noVerificationCache {
 		engConf.VerificationResultCache = simple.NewCache[detectors.Result]()
 	}
 
+	// Parse --quarantine flag.
+	var evidence *quarantine.Bundle
+	if *quarantineFile != "" {
+		if *hashSecrets {
+			logFatal(fmt.Errorf("--quarantine cannot be used with --hash-secrets, as the evidence contains the raw secrets"), "failed to configure quarantine")
+		}
+		var quarantineConf quarantine.Config
+		if *quarantineRecipients != "" {
+			f, err := os.Open(*quarantineRecipients)
+			if err != nil {
+				logFatal(err, "failed to open quarantine recipients")
+			}
+			quarantineConf.Recipients, err = quarantine.ReadRecipients(f)
+			_ = f.Close()
+			if err != nil {
+				logFatal(err, "failed to parse quarantine recipients")
+			}
+		} else if *quarantinePassphrase != "" {
+			quarantineConf.Passphrase = []byte(*quarantinePassphrase)
+		} else {
+			logFatal(fmt.Errorf("--quarantine-recipients or --quarantine-passphrase is required"), "failed to configure quarantine")
+		}
+		f, err := os.OpenFile(*quarantineFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			logFatal(err, "failed to create quarantine bundle")
+		}
+		evidence, err = quarantine.NewBundle(f, quarantineConf)
+		if err != nil {
+			logFatal(err, "failed to create quarantine bundle")
+		}
+		defer f.Close()
+		engConf.Dispatcher = quarantine.NewDispatcher(engConf.Dispatcher, evidence)
+	}
+	closeEvidence := func() {
+		if evidence == nil {
+			return
+		}
+		if err := evidence.Close(); err != nil {
+			logger.Error(err, "error writing quarantine bundle", "path", *quarantineFile)
+			return
+		}
+		logger.Info("quarantine bundle written", "path", *quarantineFile, "evidence", evidence.Len())
+	}
+
+	if *deepVerify {
+		engConf.DeepVerification = engine.DeepVerification{
+			Enabled:   true,
+			Workers:   *deepVerifyWorkers,
+			QueueSize: *deepVerifyQueueSize,
+			Timeout:   *deepVerifyTimeout,
+			FollowUp:  *deepVerifyFollowUp,
+		}
+	}
+
 	// Check that there are no sources defined for non-scan subcommands. If
 	// there are, return an error as it is ambiguous what the user is
 	// trying to do.
@@ -610,14 +898,66 @@ func run(state overseer.State, logSync func() error) {
 		)
 	}
 
+	// Parse --output-manifest flag.
+	var (
+		scanManifest *manifest.Manifest
+		unitRecorder *manifest.UnitRecorder
+	)
+	if *manifestFile != "" {
+		scanManifest, err = newScanManifest(cmd, conf, rs, engConf)
+		if err != nil {
+			logFatal(err, "failed to create scan manifest")
+		}
+		unitRecorder = manifest.NewUnitRecorder()
+	}
+
+	if cmd == diffCmd.FullCommand() {
+		d, err := runDiff(ctx, engConf)
+		closeEvidence()
+		if err != nil {
+			logFatal(err, "error comparing scans")
+		}
+		if *jsonOut {
+			err = d.WriteJSON(os.Stdout)
+		} else {
+			err = d.WriteText(os.Stdout)
+		}
+		if err != nil {
+			logFatal(err, "error writing scan diff")
+		}
+		logger.Info("finished comparing scans",
+			"added_secrets", len(d.Added),
+			"removed_secrets", len(d.Removed),
+			"persisting_secrets", len(d.Persisting),
+		)
+		if len(d.Added) > 0 && *fail {
+			logger.V(2).Info("exiting with code 183 because secrets were added")
+			syncLogs(logSync)
+			os.Exit(183)
+		}
+		return
+	}
+
 	if *compareDetectionStrategies {
-		if err := compareScans(ctx, cmd, engConf); err != nil {
+		err := compareScans(ctx, cmd, engConf)
+		closeEvidence()
+		if err != nil {
 			logFatal(err, "error comparing detection strategies")
 		}
 		return
 	}
 
-	metrics, err := runSingleScan(ctx, cmd, engConf)
+	metrics, err := runSingleScan(ctx, cmd, engConf, unitRecorder)
+	// Preserve the evidence found so far even if the scan failed.
+	closeEvidence()
+	if scanManifest != nil {
+		finishScanManifest(scanManifest, metrics, unitRecorder, err)
+		if err := scanManifest.WriteFile(*manifestFile); err != nil {
+			logger.Error(err, "error writing scan manifest", "path", *manifestFile)
+		} else {
+			logger.Info("scan manifest written", "path", *manifestFile)
+		}
+	}
 	if err != nil {
 		logFatal(err, "error running scan")
 	}
@@ -645,7 +985,20 @@ func run(state overseer.State, logSync func() error) {
 		"scan_duration", metrics.ScanDuration.String(),
 		"trufflehog_version", version.BuildVersion,
 		"verification_caching", verificationCacheMetricsSnapshot,
+		"candidate_rule_hits", metrics.CandidateRuleHits,
+		"suppressed_findings", metrics.SuppressedFindings,
+		"partial_results", metrics.Truncated,
 	)
+	if metrics.Truncated {
+		logger.Info("scan stopped early, results are partial", "reason", metrics.TruncationReason)
+	}
+	if metrics.sampling != nil {
+		logger.Info("scanned a sample of the source",
+			"objects_listed", metrics.sampling.ObjectsListed,
+			"objects_selected", metrics.sampling.ObjectsSelected,
+			"byte_coverage", metrics.sampling.ByteCoverage,
+		)
+	}
 
 	if metrics.hasFoundResults && *fail {
 		logger.V(2).Info("exiting with code 183 because results were found")
@@ -668,14 +1021,14 @@ func compareScans(ctx context.Context, cmd string, cfg engine.Config) error {
 		defer wg.Done()
 		// Run scan with entire chunk span calculator.
 		cfg.ShouldScanEntireChunk = true
-		entireMetrics, err = runSingleScan(ctx, cmd, cfg)
+		entireMetrics, err = runSingleScan(ctx, cmd, cfg, nil)
 		if err != nil {
 			ctx.Logger().Error(err, "error running scan with entire chunk span calculator")
 		}
 	}()
 
 	// Run scan with max-length span calculator.
-	maxLengthMetrics, err = runSingleScan(ctx, cmd, cfg)
+	maxLengthMetrics, err = runSingleScan(ctx, cmd, cfg, nil)
 	if err != nil {
 		return fmt.Errorf("error running scan with custom span calculator: %v", err)
 	}
@@ -702,12 +1055,141 @@ func compareMetrics(customMetrics, entireMetrics engine.Metrics) error {
 	return nil
 }
 
+// runDiff reads or scans the two inputs of the diff command and compares the secrets found in them.
+func runDiff(ctx context.Context, cfg engine.Config) (scandiff.Diff, error) {
+	var sets [2]*scandiff.Set
+	for i, input := range []string{*diffOld, *diffNew} {
+		set, err := diffInput(ctx, cfg, input)
+		if err != nil {
+			return scandiff.Diff{}, fmt.Errorf("%s: %w", input, err)
+		}
+		sets[i] = set
+	}
+	return scandiff.Compare(sets[0], sets[1]), nil
+}
+
+// diffInput returns the secrets in an input of the diff command: the secrets in a report, or the secrets found by
+// scanning the tree at a git ref or a Docker image.
+func diffInput(ctx context.Context, cfg engine.Config, input string) (*scandiff.Set, error) {
+	if *diffKind == "report" {
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return scandiff.ReadReport(f)
+	}
+
+	set := scandiff.NewSet()
+	cfg.Dispatcher = set
+	cfg.SourceManager = sources.NewManager(
+		sources.WithConcurrentSources(cfg.Concurrency),
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+	eng, err := engine.NewEngine(ctx, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing engine: %v", err)
+	}
+	eng.Start(ctx)
+	defer func() {
+		if err := cleantemp.CleanTempArtifacts(ctx); err != nil {
+			ctx.Logger().Error(err, "error cleaning temp artifacts")
+		}
+	}()
+
+	var ref sources.JobProgressRef
+	switch *diffKind {
+	case "git":
+		ref, err = eng.ScanGitTree(ctx, sources.GitConfig{URI: *diffRepo, HeadRef: input})
+	case "docker":
+		ref, err = eng.ScanDocker(ctx, sources.DockerConfig{Images: []string{input}, UseDockerKeychain: true})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %v", *diffKind, err)
+	}
+	if err := eng.Finish(ctx); err != nil {
+		return nil, fmt.Errorf("engine failed to finish execution: %v", err)
+	}
+
+	// A scan that missed data would report the secrets in it as added or removed.
+	if m := eng.GetMetrics(); m.Truncated {
+		return nil, fmt.Errorf("scan stopped early: %s", m.TruncationReason)
+	}
+	if errs := ref.Snapshot().Errors; len(errs) > 0 {
+		return nil, fmt.Errorf("encountered errors during scan: %w", errors.Join(errs...))
+	}
+	return set, nil
+}
+
 type metrics struct {
 	engine.Metrics
 	hasFoundResults bool
+	// detectors are the detectors the scan ran.
+	detectors []detectors.Detector
+	// sampling is the coverage of a sampled scan, nil if the scan wasn't sampled.
+	sampling *sampling.Coverage
+}
+
+var (
+	// runningScanMu guards runningScan, the engine of the scan in progress, if any.
+	runningScanMu sync.Mutex
+	runningScan   *engine.Engine
+)
+
+// interruptScan gracefully interrupts the scan in progress and reports whether
+// there was one.
+func interruptScan(ctx context.Context, reason string) bool {
+	runningScanMu.Lock()
+	defer runningScanMu.Unlock()
+	if runningScan == nil {
+		return false
+	}
+	runningScan.Interrupt(ctx, reason)
+	return true
 }
 
-func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics, error) {
+func setRunningScan(eng *engine.Engine) {
+	runningScanMu.Lock()
+	defer runningScanMu.Unlock()
+	runningScan = eng
+}
+
+// adminSnapshot reports the state of a running scan to the admin endpoint.
+func adminSnapshot(eng *engine.Engine, mgr *sources.SourceManager) admin.Snapshot {
+	m := eng.GetMetrics()
+	snap := admin.Snapshot{
+		ChunksScanned:     m.ChunksScanned,
+		BytesScanned:      m.BytesScanned,
+		VerifiedSecrets:   m.VerifiedSecretsFound,
+		UnverifiedSecrets: m.UnverifiedSecretsFound,
+		DetectorAvgTime:   m.AvgDetectorTime,
+	}
+	for _, ref := range mgr.Jobs() {
+		jm := ref.Snapshot()
+		job := admin.Job{
+			Source:          ref.SourceName,
+			TotalUnits:      jm.TotalUnits,
+			FinishedUnits:   jm.FinishedUnits,
+			TotalChunks:     jm.TotalChunks,
+			DoneEnumerating: jm.DoneEnumerating,
+			Percent:         jm.SourcePercent,
+			Message:         jm.SourceMessage,
+			Errors:          len(jm.Errors),
+		}
+		select {
+		case <-ref.Done():
+			job.Done = true
+		default:
+		}
+		snap.Jobs = append(snap.Jobs, job)
+	}
+	return snap
+}
+
+// runSingleScan runs the scan of the given command. If units is not nil, the source units the scan attempts are
+// recorded in it.
+func runSingleScan(ctx context.Context, cmd string, cfg engine.Config, units *manifest.UnitRecorder) (metrics, error) {
 	var scanMetrics metrics
 
 	// Setup job report writer if provided
@@ -716,8 +1198,12 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		jobReportWriter = *jobReportFile
 	}
 
+	// reportWritten is closed once the job report is written, so that an
+	// interrupted scan does not exit with a truncated report.
+	reportWritten := make(chan struct{})
 	handleFinishedMetrics := func(ctx context.Context, finishedMetrics <-chan sources.UnitMetrics, jobReportWriter io.WriteCloser) {
 		go func() {
+			defer close(reportWritten)
 			defer func() {
 				jobReportWriter.Close()
 				if namer, ok := jobReportWriter.(interface{ Name() string }); ok {
@@ -745,17 +1231,56 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	}
 
 	const defaultOutputBufferSize = 64
+	unitConcurrency := cfg.Concurrency
+	if *concurrentUnits > 0 {
+		unitConcurrency = *concurrentUnits
+	}
 	opts := []func(*sources.SourceManager){
 		sources.WithConcurrentSources(cfg.Concurrency),
-		sources.WithConcurrentUnits(cfg.Concurrency),
+		sources.WithConcurrentUnits(unitConcurrency),
 		sources.WithSourceUnits(),
 		sources.WithBufferedOutput(defaultOutputBufferSize),
 	}
+	if *unitChunkBuffer > 0 {
+		opts = append(opts, sources.WithUnitChunkBuffer(*unitChunkBuffer))
+	}
+	if *prioritizeUnits {
+		opts = append(opts, sources.WithUnitPriority(sources.DefaultUnitPriority))
+	}
 
 	if jobReportWriter != nil {
 		unitHook, finishedMetrics := sources.NewUnitHook(ctx)
 		opts = append(opts, sources.WithReportHook(unitHook))
 		handleFinishedMetrics(ctx, finishedMetrics, jobReportWriter)
+	} else {
+		close(reportWritten)
+	}
+
+	if units != nil {
+		opts = append(opts, sources.WithReportHook(units))
+	}
+
+	if *otelTraces {
+		tracer, err := scantrace.New(ctx, scantrace.Config{Endpoint: *otelEndpoint, Command: cmd})
+		if err != nil {
+			return scanMetrics, err
+		}
+		// Closed once the engine has finished, so that all findings are exported.
+		defer func() {
+			if err := tracer.Close(ctx); err != nil {
+				ctx.Logger().Error(err, "error exporting scan traces")
+			}
+		}()
+		opts = append(opts, sources.WithReportHook(tracer))
+		cfg.Dispatcher = scantrace.NewDispatcher(cfg.Dispatcher, tracer)
+	}
+
+	if *resumeFile != "" {
+		checkpoint, err := sources.ReadCheckpoint(*resumeFile)
+		if err != nil {
+			return scanMetrics, fmt.Errorf("error reading checkpoint: %v", err)
+		}
+		opts = append(opts, sources.WithCheckpoint(checkpoint))
 	}
 
 	cfg.SourceManager = sources.NewManager(opts...)
@@ -764,7 +1289,20 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	if err != nil {
 		return scanMetrics, fmt.Errorf("error initializing engine: %v", err)
 	}
+
+	if *adminAddr != "" {
+		adminServer, err := admin.Listen(ctx, *adminAddr, func() admin.Snapshot {
+			return adminSnapshot(eng, cfg.SourceManager)
+		})
+		if err != nil {
+			return scanMetrics, err
+		}
+		defer adminServer.Close()
+	}
+
 	eng.Start(ctx)
+	setRunningScan(eng)
+	defer setRunningScan(nil)
 
 	persistGitRepo := *gitNoCleanup || *githubNoCleanup || *gitlabNoCleanup
 	gitCloneTempPath := ""
@@ -787,7 +1325,10 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		}
 	}()
 
-	var refs []sources.JobProgressRef
+	var (
+		refs    []sources.JobProgressRef
+		sampler *sampling.Sampler
+	)
 	switch cmd {
 	case gitScan.FullCommand():
 
@@ -937,6 +1478,14 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 			ExcludePathsFile: *filesystemScanExcludePaths,
 			MaxSymlinkDepth:  *filesystemScanMaxSymlinkDepth,
 		}
+		if *filesystemWatch {
+			if ref, err := eng.WatchFileSystem(ctx, cfg, *filesystemWatchDebounce); err != nil {
+				return scanMetrics, fmt.Errorf("failed to watch filesystem: %v", err)
+			} else {
+				refs = []sources.JobProgressRef{ref}
+			}
+			break
+		}
 		if ref, err := eng.ScanFileSystem(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan filesystem: %v", err)
 		} else {
@@ -953,6 +1502,14 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 			CloudCred:     *s3ScanCloudEnv,
 			MaxObjectSize: int64(*s3ScanMaxObjectSize),
 		}
+		if *s3ScanSample {
+			sampler = sampling.New(sampling.Config{
+				NewestPercent: *s3ScanSampleNewest,
+				SmallFileSize: int64(*s3ScanSampleSmall),
+				SampleRate:    *s3ScanSampleRate,
+			})
+			cfg.Sampler = sampler
+		}
 		if ref, err := eng.ScanS3(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan S3: %v", err)
 		} else {
@@ -1143,12 +1700,27 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 			refs = rs
 		}
 	case stdinInputScan.FullCommand():
-		cfg := sources.StdinConfig{}
+		cfg := sources.StdinConfig{
+			Stream:    *stdinInputStream,
+			IdleFlush: *stdinInputIdleFlush,
+		}
 		if ref, err := eng.ScanStdinInput(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan stdin input: %v", err)
 		} else {
 			refs = []sources.JobProgressRef{ref}
 		}
+	case stagedScan.FullCommand():
+		// Block the commit if any secret is staged.
+		*fail = true
+		cfg := sources.StagedConfig{
+			Path:         *stagedScanRepo,
+			ContextLines: *stagedScanContext,
+		}
+		if ref, err := eng.ScanStaged(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan staged changes: %v", err)
+		} else {
+			refs = []sources.JobProgressRef{ref}
+		}
 	case jsonEnumeratorScan.FullCommand():
 		cfg := sources.JSONEnumeratorConfig{Paths: *jsonEnumeratorPaths}
 		if ref, err := eng.ScanJSONEnumeratorInput(ctx, cfg); err != nil {
@@ -1156,6 +1728,34 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		} else {
 			refs = []sources.JobProgressRef{ref}
 		}
+	case webScan.FullCommand():
+		cfg := sources.WebConfig{
+			URLs:         *webScanURLs,
+			MaxDepth:     *webScanDepth,
+			MaxRequests:  *webScanMaxRequests,
+			AllowedHosts: *webScanAllowedHosts,
+			IgnoreRobots: *webScanIgnoreRobots,
+		}
+		if ref, err := eng.ScanWeb(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan web: %v", err)
+		} else {
+			refs = []sources.JobProgressRef{ref}
+		}
+	case ciArtifactsScan.FullCommand():
+		cfg := sources.CIArtifactsConfig{
+			GitHubRepos:       *ciArtifactsGitHubRepos,
+			GitHubToken:       *ciArtifactsGitHubToken,
+			GitHubEndpoint:    *ciArtifactsGitHubEndpoint,
+			BitbucketRepos:    *ciArtifactsBitbucketRepos,
+			BitbucketToken:    *ciArtifactsBitbucketToken,
+			BitbucketEndpoint: *ciArtifactsBitbucketEndpoint,
+			MaxRuns:           *ciArtifactsMaxRuns,
+		}
+		if ref, err := eng.ScanCIArtifacts(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan CI artifacts: %v", err)
+		} else {
+			refs = []sources.JobProgressRef{ref}
+		}
 	default:
 		return scanMetrics, fmt.Errorf("invalid command: %s", cmd)
 	}
@@ -1164,11 +1764,25 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	if err = eng.Finish(ctx); err != nil {
 		return scanMetrics, fmt.Errorf("engine failed to finish execution: %v", err)
 	}
+	<-reportWritten
+
+	// All chunks the interrupted sources produced have been scanned, so their
+	// resume information is sound.
+	if eng.Interrupted() && *checkpointFile != "" {
+		if err := sources.WriteCheckpoint(*checkpointFile, cfg.SourceManager.Checkpoint()); err != nil {
+			ctx.Logger().Error(err, "error writing checkpoint")
+		} else {
+			ctx.Logger().Info("checkpoint written, resume the scan with --resume", "path", *checkpointFile)
+		}
+	}
 
 	// Print any non-fatal errors reported during the scan.
 	var retErr error
 	for _, ref := range refs {
-		if errs := ref.Snapshot().Errors; len(errs) > 0 {
+		// Sources cancelled by a scan limit or an interruption report the
+		// cancellation as an error.
+		errs := slices.DeleteFunc(ref.Snapshot().Errors, eng.StoppedEarly)
+		if len(errs) > 0 {
 			if *failOnScanErrors {
 				retErr = fmt.Errorf("encountered errors during scan")
 			}
@@ -1188,7 +1802,105 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		printAverageDetectorTime(eng)
 	}
 
-	return metrics{Metrics: eng.GetMetrics(), hasFoundResults: eng.HasFoundResults()}, retErr
+	return metrics{Metrics: eng.GetMetrics(), hasFoundResults: eng.HasFoundResults(), detectors: eng.Detectors(), sampling: sampler.Coverage()}, retErr
+}
+
+// newScanManifest creates the manifest of a scan with the given configuration, before it starts.
+func newScanManifest(cmd string, conf *config.Config, rs *ruleset.Ruleset, engConf engine.Config) (*manifest.Manifest, error) {
+	m := manifest.New(version.BuildVersion, cmd)
+
+	if *configFilename != "" {
+		f, err := manifest.ReadFile("config", *configFilename)
+		if err != nil {
+			return nil, err
+		}
+		m.Config = &f
+	}
+	if rs != nil {
+		m.Ruleset = &manifest.File{Kind: "ruleset", Path: *rulesetURL, SHA256: rs.SHA256}
+	}
+
+	m.Verification = manifest.Verification{
+		Enabled:              engConf.Verify,
+		CustomEndpointsOnly:  engConf.CustomVerifiersOnly,
+		ReplaySessionCookies: engConf.ReplaySessionCookies,
+		ProbeDatastoreAuth:   engConf.ProbeDatastoreAuth,
+		DeepVerification:     engConf.DeepVerification.Enabled,
+		Cache:                engConf.VerificationResultCache != nil,
+	}
+	if engConf.ResolveRotatedAfter > 0 {
+		m.Verification.ResolveRotatedAfter = engConf.ResolveRotatedAfter
+		m.Verification.ReverifyInterval = engConf.ReverifyInterval.String()
+	}
+	for id, verify := range engConf.DetectorVerificationOverrides {
+		if m.Verification.Overrides == nil {
+			m.Verification.Overrides = make(map[string]bool)
+		}
+		m.Verification.Overrides[id.String()] = verify
+	}
+	for name := range engConf.VerifierEndpoints {
+		m.Verification.CustomEndpoints = append(m.Verification.CustomEndpoints, name)
+	}
+	slices.Sort(m.Verification.CustomEndpoints)
+
+	resultTypes := []string{"verified", "unverified", "unknown"}
+	if len(engConf.Results) > 0 {
+		resultTypes = slices.Sorted(maps.Keys(engConf.Results))
+	}
+	m.ResultFilters = manifest.ResultFilters{
+		Results:              resultTypes,
+		OnlyVerified:         engConf.OnlyVerified,
+		IncludeIndeterminate: engConf.IncludeIndeterminate,
+		MinConfidence:        *minConfidence,
+		FilterUnverified:     engConf.FilterUnverified,
+		FilterEntropy:        engConf.FilterEntropy,
+		IncludeDetectors:     engConf.IncludeDetectors,
+		ExcludeDetectors:     engConf.ExcludeDetectors,
+	}
+
+	// The path filters of the other commands are empty.
+	allowlists := []struct{ kind, path string }{
+		{"canary_list", *canaryListFilename},
+		{"owned_wallets", *ownedWalletsFilename},
+		{"expected_owners", *expectedOwnersFilename},
+		{"include_paths", *gitScanIncludePaths},
+		{"exclude_paths", *gitScanExcludePaths},
+		{"include_paths", *githubScanIncludePaths},
+		{"exclude_paths", *githubScanExcludePaths},
+		{"include_paths", *gitlabScanIncludePaths},
+		{"exclude_paths", *gitlabScanExcludePaths},
+		{"include_paths", *filesystemScanIncludePaths},
+		{"exclude_paths", *filesystemScanExcludePaths},
+	}
+	for _, a := range allowlists {
+		if err := m.AddAllowlist(a.kind, a.path); err != nil {
+			return nil, err
+		}
+	}
+	if conf.CandidateRules != nil && m.Config != nil {
+		// Candidate rules are part of the configuration file, whose hash is recorded above.
+		m.Allowlists = append(m.Allowlists, manifest.File{Kind: "candidate_rules", Path: m.Config.Path, SHA256: m.Config.SHA256})
+	}
+	return m, nil
+}
+
+// finishScanManifest records the outcome of a scan in its manifest. scanErr is the error the scan failed with, if any.
+func finishScanManifest(m *manifest.Manifest, scanMetrics metrics, units *manifest.UnitRecorder, scanErr error) {
+	m.FinishedAt = time.Now().UTC()
+	m.Detectors = manifest.DescribeDetectors(scanMetrics.detectors)
+	m.SourceUnits = units.SourceUnits()
+	m.Sampling = scanMetrics.sampling
+	m.Findings = manifest.Findings{
+		Verified:   scanMetrics.VerifiedSecretsFound,
+		Unverified: scanMetrics.UnverifiedSecretsFound,
+		Suppressed: scanMetrics.SuppressedFindings,
+	}
+	switch {
+	case scanErr != nil:
+		m.Partial, m.PartialReason = true, scanErr.Error()
+	case scanMetrics.Truncated:
+		m.Partial, m.PartialReason = true, scanMetrics.TruncationReason
+	}
 }
 
 // parseResults ensures that users provide valid CSV input to `--results`.
@@ -0,0 +1,243 @@
+// Package admin serves a localhost endpoint to inspect a running scan: pprof profiles, the progress of the scan and
+// the metrics of the detectors. A scan that runs for hours and seems stuck can be diagnosed without killing it.
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// sampleInterval is how often the counters are sampled to compute the current throughput.
+const sampleInterval = 10 * time.Second
+
+// Job is the progress of a source run.
+type Job struct {
+	Source string `json:"source"`
+	// TotalUnits is the number of units the source found so far, and FinishedUnits the number of units it is done
+	// chunking.
+	TotalUnits    uint64 `json:"total_units"`
+	FinishedUnits uint64 `json:"finished_units"`
+	TotalChunks   uint64 `json:"total_chunks"`
+	// DoneEnumerating is set once the source found all its units, so TotalUnits is final.
+	DoneEnumerating bool   `json:"done_enumerating"`
+	Done            bool   `json:"done"`
+	Percent         int64  `json:"percent,omitempty"`
+	Message         string `json:"message,omitempty"`
+	Errors          int    `json:"errors"`
+}
+
+// Snapshot is the state of the scan at a point in time.
+type Snapshot struct {
+	ChunksScanned     uint64
+	BytesScanned      uint64
+	VerifiedSecrets   uint64
+	UnverifiedSecrets uint64
+	Jobs              []Job
+	// DetectorAvgTime is the average time each detector took to process a chunk.
+	DetectorAvgTime map[string]time.Duration
+}
+
+// Progress is the progress of the scan as reported by the endpoint.
+type Progress struct {
+	StartTime time.Time `json:"start_time"`
+	Elapsed   string    `json:"elapsed"`
+
+	UnitsTotal     uint64 `json:"units_total"`
+	UnitsFinished  uint64 `json:"units_finished"`
+	UnitsRemaining uint64 `json:"units_remaining"`
+	// UnitsFinal is set once every source found all its units, until then more units may be remaining.
+	UnitsFinal bool `json:"units_final"`
+
+	ChunksScanned     uint64 `json:"chunks_scanned"`
+	BytesScanned      uint64 `json:"bytes_scanned"`
+	VerifiedSecrets   uint64 `json:"verified_secrets"`
+	UnverifiedSecrets uint64 `json:"unverified_secrets"`
+	// ChunksPerSecond and BytesPerSecond are the throughput over the last sample interval; a throughput that drops
+	// to zero while units remain points at a stalled source or detector.
+	ChunksPerSecond    float64 `json:"chunks_per_second"`
+	BytesPerSecond     float64 `json:"bytes_per_second"`
+	AvgChunksPerSecond float64 `json:"avg_chunks_per_second"`
+
+	Jobs []Job `json:"jobs"`
+}
+
+// Server is the admin endpoint of a scan.
+type Server struct {
+	snapshot func() Snapshot
+	start    time.Time
+	srv      *http.Server
+	ln       net.Listener
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	mu sync.Mutex
+	// last and lastTime are the previous sample of the counters, and rate the throughput since the one before.
+	last     Snapshot
+	lastTime time.Time
+	rate     [2]float64
+}
+
+// Listen starts serving the admin endpoint on addr, which must be a loopback address: the endpoint exposes the
+// process' memory through its profiles and is not authenticated. snapshot is called on every request and must be
+// safe for concurrent use.
+func Listen(ctx context.Context, addr string, snapshot func() Snapshot) (*Server, error) {
+	if err := checkLoopback(addr); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on admin address: %w", err)
+	}
+
+	now := time.Now()
+	s := &Server{
+		snapshot: snapshot,
+		start:    now,
+		ln:       ln,
+		done:     make(chan struct{}),
+		lastTime: now,
+	}
+	s.srv = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ctx.Logger().Error(err, "error serving admin endpoint")
+		}
+	}()
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(sampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sample(time.Now())
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	ctx.Logger().Info("serving admin endpoint", "addr", ln.Addr().String(),
+		"paths", "/debug/pprof/ /progress /detectors /metrics")
+	return s, nil
+}
+
+// Addr returns the address the endpoint listens on.
+func (s *Server) Addr() string { return s.ln.Addr().String() }
+
+// Close stops serving the endpoint.
+func (s *Server) Close() error {
+	close(s.done)
+	err := s.srv.Close()
+	s.wg.Wait()
+	return err
+}
+
+// Handler returns the handler of the endpoint.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/progress", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, s.progress(time.Now()))
+	})
+	mux.HandleFunc("/detectors", func(w http.ResponseWriter, _ *http.Request) {
+		avg := make(map[string]string)
+		for name, d := range s.snapshot().DetectorAvgTime {
+			avg[name] = d.String()
+		}
+		writeJSON(w, map[string]any{"avg_detector_time": avg})
+	})
+	return mux
+}
+
+// sample records the counters to compute the throughput since the previous sample.
+func (s *Server) sample(now time.Time) {
+	snap := s.snapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elapsed := now.Sub(s.lastTime).Seconds(); elapsed > 0 {
+		s.rate = [2]float64{
+			float64(snap.ChunksScanned-s.last.ChunksScanned) / elapsed,
+			float64(snap.BytesScanned-s.last.BytesScanned) / elapsed,
+		}
+	}
+	s.last, s.lastTime = snap, now
+}
+
+func (s *Server) progress(now time.Time) Progress {
+	snap := s.snapshot()
+	s.mu.Lock()
+	rate := s.rate
+	s.mu.Unlock()
+
+	elapsed := now.Sub(s.start)
+	p := Progress{
+		StartTime:         s.start,
+		Elapsed:           elapsed.Truncate(time.Second).String(),
+		UnitsFinal:        len(snap.Jobs) > 0,
+		ChunksScanned:     snap.ChunksScanned,
+		BytesScanned:      snap.BytesScanned,
+		VerifiedSecrets:   snap.VerifiedSecrets,
+		UnverifiedSecrets: snap.UnverifiedSecrets,
+		ChunksPerSecond:   rate[0],
+		BytesPerSecond:    rate[1],
+		Jobs:              snap.Jobs,
+	}
+	if p.Jobs == nil {
+		p.Jobs = []Job{}
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		p.AvgChunksPerSecond = float64(snap.ChunksScanned) / seconds
+	}
+	for _, job := range snap.Jobs {
+		p.UnitsTotal += job.TotalUnits
+		p.UnitsFinished += job.FinishedUnits
+		if !job.DoneEnumerating && !job.Done {
+			p.UnitsFinal = false
+		}
+	}
+	if p.UnitsTotal > p.UnitsFinished {
+		p.UnitsRemaining = p.UnitsTotal - p.UnitsFinished
+	}
+	return p
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// checkLoopback returns an error unless addr is a host:port address on a loopback interface.
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid admin address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("admin address %q must be on localhost, e.g. 127.0.0.1:18067", addr)
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestCheckLoopback(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:18067", "localhost:0", "[::1]:18067"} {
+		assert.NoError(t, checkLoopback(addr), addr)
+	}
+	for _, addr := range []string{":18067", "0.0.0.0:18067", "192.168.1.10:18067", "127.0.0.1"} {
+		assert.Error(t, checkLoopback(addr), addr)
+	}
+}
+
+func TestServer_Progress(t *testing.T) {
+	snap := Snapshot{
+		ChunksScanned: 100,
+		BytesScanned:  1000,
+		Jobs: []Job{
+			{Source: "git", TotalUnits: 10, FinishedUnits: 4, DoneEnumerating: true},
+			{Source: "s3", TotalUnits: 5, FinishedUnits: 5, Done: true},
+		},
+	}
+	start := time.Now()
+	s := &Server{snapshot: func() Snapshot { return snap }, start: start, lastTime: start}
+
+	p := s.progress(start.Add(10 * time.Second))
+	assert.Equal(t, uint64(15), p.UnitsTotal)
+	assert.Equal(t, uint64(9), p.UnitsFinished)
+	assert.Equal(t, uint64(6), p.UnitsRemaining)
+	assert.True(t, p.UnitsFinal)
+	assert.InDelta(t, 10, p.AvgChunksPerSecond, 0.001)
+	// No sample was taken yet.
+	assert.Zero(t, p.ChunksPerSecond)
+
+	s.sample(start.Add(10 * time.Second))
+	snap.ChunksScanned, snap.BytesScanned = 150, 1500
+	snap.Jobs[0].DoneEnumerating = false
+	s.sample(start.Add(20 * time.Second))
+
+	p = s.progress(start.Add(20 * time.Second))
+	assert.InDelta(t, 5, p.ChunksPerSecond, 0.001)
+	assert.InDelta(t, 50, p.BytesPerSecond, 0.001)
+	assert.False(t, p.UnitsFinal)
+}
+
+func TestListen(t *testing.T) {
+	ctx := context.Background()
+	_, err := Listen(ctx, "0.0.0.0:0", func() Snapshot { return Snapshot{} })
+	require.Error(t, err)
+
+	s, err := Listen(ctx, "127.0.0.1:0", func() Snapshot {
+		return Snapshot{ChunksScanned: 3, DetectorAvgTime: map[string]time.Duration{"AWS": time.Millisecond}}
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	get := func(path string) *http.Response {
+		res, err := http.Get("http://" + s.Addr() + path)
+		require.NoError(t, err)
+		t.Cleanup(func() { res.Body.Close() })
+		return res
+	}
+
+	res := get("/progress")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var p Progress
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&p))
+	assert.Equal(t, uint64(3), p.ChunksScanned)
+
+	res = get("/detectors")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var detectors map[string]map[string]string
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&detectors))
+	assert.Equal(t, "1ms", detectors["avg_detector_time"]["AWS"])
+
+	assert.Equal(t, http.StatusOK, get("/debug/pprof/").StatusCode)
+	assert.Equal(t, http.StatusOK, get("/metrics").StatusCode)
+}
@@ -0,0 +1,115 @@
+// Package browserstore recognizes the SQLite databases in which browsers keep saved passwords and cookies.
+//
+// Backups of whole home directories, CI caches and container images now and then carry a browser profile, and with
+// it the Login Data and Cookies databases of Chrome and other Chromium browsers, or the cookies.sqlite and key4.db
+// of Firefox. Their values are encrypted with keys that are often stored right next to them, or not encrypted at
+// all, as with Firefox cookies: whoever can read the backup can sign in as the user. No detector pattern matches
+// these binary files, so this package identifies them by their schema and counts their entries, without decoding
+// any of the stored values.
+package browserstore
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Store is a browser database holding credentials.
+type Store struct {
+	// Browser is the browser family that writes the database: chromium or firefox.
+	Browser string
+	// Kind is what the database holds: passwords, cookies or password_keys.
+	Kind string
+	// Table is the table holding the entries.
+	Table string
+	// Entries is the number of rows in Table.
+	Entries int
+}
+
+// Kinds of stores.
+const (
+	KindPasswords = "passwords"
+	KindCookies   = "cookies"
+	// KindPasswordKeys is the Firefox key database, key4.db, which holds the key that decrypts the passwords saved in
+	// logins.json.
+	KindPasswordKeys = "password_keys"
+)
+
+// signature identifies a store by a table and the columns it declares.
+type signature struct {
+	browser, kind string
+	table         string
+	columns       []string
+	// with is another table the database must have.
+	with string
+}
+
+var signatures = []signature{
+	// Chromium "Login Data" and "Login Data For Account".
+	{browser: "chromium", kind: KindPasswords, table: "logins", columns: []string{"origin_url", "username_value", "password_value"}, with: "meta"},
+	// Chromium "Cookies", in "Network/Cookies" since Chrome 96.
+	{browser: "chromium", kind: KindCookies, table: "cookies", columns: []string{"host_key", "encrypted_value"}, with: "meta"},
+	{browser: "firefox", kind: KindCookies, table: "moz_cookies", columns: []string{"host", "value"}},
+	// signons.sqlite, which held the passwords until Firefox 32.
+	{browser: "firefox", kind: KindPasswords, table: "moz_logins", columns: []string{"hostname", "encryptedPassword"}},
+	{browser: "firefox", kind: KindPasswordKeys, table: "nssPrivate", columns: []string{"a11"}, with: "metaData"},
+}
+
+// Sniff reports whether a file starts like a SQLite database.
+func Sniff(head []byte) bool {
+	return bytes.HasPrefix(head, []byte(Magic))
+}
+
+// Identify returns the store a SQLite database is, or nil if it is none. An error is returned if data is not a
+// readable SQLite database.
+func Identify(data []byte) (*Store, error) {
+	db, err := openDatabase(data)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := db.tables()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]table, len(tables))
+	for _, t := range tables {
+		byName[strings.ToLower(t.name)] = t
+	}
+
+	for _, sig := range signatures {
+		t, ok := byName[strings.ToLower(sig.table)]
+		if !ok || !declares(t.sql, sig.columns) {
+			continue
+		}
+		if _, ok := byName[strings.ToLower(sig.with)]; sig.with != "" && !ok {
+			continue
+		}
+		entries, err := db.countRows(t.rootPage)
+		if err != nil {
+			return nil, err
+		}
+		return &Store{Browser: sig.browser, Kind: sig.kind, Table: t.name, Entries: entries}, nil
+	}
+	return nil, nil
+}
+
+// declares reports whether a CREATE TABLE statement declares all the given columns.
+func declares(sql string, columns []string) bool {
+	declared := make(map[string]struct{})
+	open := strings.IndexByte(sql, '(')
+	if open < 0 {
+		return false
+	}
+	for _, def := range strings.Split(sql[open+1:], ",") {
+		fields := strings.Fields(def)
+		if len(fields) == 0 {
+			continue
+		}
+		declared[strings.ToLower(strings.Trim(fields[0], "\"`[]"))] = struct{}{}
+	}
+	for _, col := range columns {
+		if _, ok := declared[strings.ToLower(col)]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package browserstore
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The databases in testdata use 512 byte pages, so the schema of the logins table spills onto overflow pages and
+// the cookies table spans several levels of b-tree pages.
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	return data
+}
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		file string
+		want *Store
+	}{
+		{file: "login_data.sqlite", want: &Store{Browser: "chromium", Kind: KindPasswords, Table: "logins", Entries: 3}},
+		{file: "cookies.sqlite", want: &Store{Browser: "firefox", Kind: KindCookies, Table: "moz_cookies", Entries: 200}},
+		// A logins table without the columns of a browser's.
+		{file: "other.sqlite", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data := readTestdata(t, tt.file)
+			assert.True(t, Sniff(data))
+
+			got, err := Identify(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentify_Corrupt(t *testing.T) {
+	data := readTestdata(t, "cookies.sqlite")
+
+	_, err := Identify(data[:len(data)/2])
+	assert.Error(t, err)
+
+	_, err = Identify([]byte("SQLite format 2\x00"))
+	assert.Error(t, err)
+	assert.False(t, Sniff([]byte("SQLite format 2\x00")))
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+	stores := []Store{
+		{Browser: "chromium", Kind: KindPasswords, Table: "logins", Entries: 3},
+		{Browser: "firefox", Kind: KindPasswordKeys, Table: "nssPrivate", Entries: 0},
+	}
+	text := "leading text\n" + stores[0].Render() + stores[1].Render() + HeaderPrefix + "firefox cookies\n"
+	assert.Equal(t, stores, ParseRendered(text))
+	assert.Equal(t, "# browser store: chromium passwords\ntable = \"logins\"\nentries = 3\n", stores[0].Render())
+}
+
+func TestReadVarint(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want int64
+		n    int
+	}{
+		{in: []byte{0x05}, want: 5, n: 1},
+		{in: []byte{0x81, 0x00}, want: 128, n: 2},
+		{in: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, want: -1, n: 9},
+		{in: []byte{0x81}, want: 0, n: 0},
+	}
+	for _, tt := range tests {
+		got, n := readVarint(tt.in)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.n, n)
+	}
+}
@@ -0,0 +1,62 @@
+package browserstore
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderPrefix starts the first line of a rendered store, followed by the browser and the kind of store.
+const HeaderPrefix = "# browser store: "
+
+// Render writes a store as a header line followed by its table and entry count. Nothing stored in the database is
+// written.
+func (s Store) Render() string {
+	return HeaderPrefix + s.Browser + " " + s.Kind + "\n" +
+		"table = " + strconv.Quote(s.Table) + "\n" +
+		"entries = " + strconv.Itoa(s.Entries) + "\n"
+}
+
+// ParseRendered reads back the stores written by Render. Lines that are not part of a rendered store are ignored.
+func ParseRendered(text string) []Store {
+	var stores []Store
+	var store *Store
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if header, ok := strings.CutPrefix(line, HeaderPrefix); ok {
+			store = nil
+			browser, kind, ok := strings.Cut(header, " ")
+			if !ok || browser == "" || kind == "" {
+				continue
+			}
+			stores = append(stores, Store{Browser: browser, Kind: kind, Entries: -1})
+			store = &stores[len(stores)-1]
+			continue
+		}
+		if store == nil {
+			continue
+		}
+		name, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "table":
+			if table, err := strconv.Unquote(value); err == nil {
+				store.Table = table
+			}
+		case "entries":
+			if entries, err := strconv.Atoi(value); err == nil {
+				store.Entries = entries
+			}
+		}
+	}
+
+	// Stores whose lines were cut off are dropped.
+	complete := stores[:0]
+	for _, s := range stores {
+		if s.Table != "" && s.Entries >= 0 {
+			complete = append(complete, s)
+		}
+	}
+	return complete
+}
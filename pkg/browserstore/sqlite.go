@@ -0,0 +1,318 @@
+package browserstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Magic is the header string every SQLite 3 database file starts with.
+const Magic = "SQLite format 3\x00"
+
+const (
+	headerSize = 100
+	// maxTreeDepth bounds the b-tree walks; a database with a billion rows is about five levels deep.
+	maxTreeDepth = 32
+	// maxOverflowPages bounds the overflow chains read for schema records.
+	maxOverflowPages = 64
+
+	pageInteriorTable = 0x05
+	pageLeafTable     = 0x0d
+)
+
+var errCorrupt = errors.New("corrupt sqlite database")
+
+// database is a read-only view of the b-trees of a SQLite database held in memory. Only table b-trees are read, and
+// only the schema table's records are decoded; the rows of other tables are merely counted.
+type database struct {
+	data     []byte
+	pageSize int
+	// usable is the page size without the bytes reserved at the end of every page, e.g. by encryption extensions.
+	usable int
+}
+
+// table is a table declared in the schema table.
+type table struct {
+	name     string
+	rootPage int
+	sql      string
+}
+
+func openDatabase(data []byte) (*database, error) {
+	if len(data) < headerSize || string(data[:len(Magic)]) != Magic {
+		return nil, errors.New("not a sqlite database")
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("%w: invalid page size %d", errCorrupt, pageSize)
+	}
+	usable := pageSize - int(data[20])
+	if usable < 480 {
+		return nil, fmt.Errorf("%w: invalid reserved space", errCorrupt)
+	}
+	return &database{data: data, pageSize: pageSize, usable: usable}, nil
+}
+
+// page returns the page with the given 1-indexed number and the offset of its b-tree header, which follows the
+// database header on the first page.
+func (db *database) page(n int) ([]byte, int, error) {
+	if n < 1 || n*db.pageSize > len(db.data) {
+		return nil, 0, fmt.Errorf("%w: page %d out of range", errCorrupt, n)
+	}
+	page := db.data[(n-1)*db.pageSize : n*db.pageSize]
+	if n == 1 {
+		return page, headerSize, nil
+	}
+	return page, 0, nil
+}
+
+// walk calls leaf for every leaf page of the table b-tree rooted at the given page, with the page and the offsets
+// of its cells.
+func (db *database) walk(root int, leaf func(page []byte, cells []int) error) error {
+	visited := make(map[int]struct{})
+	var visit func(n, depth int) error
+	visit = func(n, depth int) error {
+		if depth > maxTreeDepth {
+			return fmt.Errorf("%w: b-tree too deep", errCorrupt)
+		}
+		if _, ok := visited[n]; ok {
+			return fmt.Errorf("%w: page %d referenced twice", errCorrupt, n)
+		}
+		visited[n] = struct{}{}
+
+		page, hdr, err := db.page(n)
+		if err != nil {
+			return err
+		}
+		if hdr+12 > len(page) {
+			return fmt.Errorf("%w: truncated page %d", errCorrupt, n)
+		}
+		kind := page[hdr]
+		numCells := int(binary.BigEndian.Uint16(page[hdr+3 : hdr+5]))
+		hdrSize := 8
+		if kind == pageInteriorTable {
+			hdrSize = 12
+		}
+		if hdr+hdrSize+2*numCells > len(page) {
+			return fmt.Errorf("%w: too many cells on page %d", errCorrupt, n)
+		}
+		cells := make([]int, numCells)
+		for i := range cells {
+			p := hdr + hdrSize + 2*i
+			cells[i] = int(binary.BigEndian.Uint16(page[p : p+2]))
+			if cells[i] >= len(page) {
+				return fmt.Errorf("%w: cell out of range on page %d", errCorrupt, n)
+			}
+		}
+
+		switch kind {
+		case pageLeafTable:
+			return leaf(page, cells)
+		case pageInteriorTable:
+			for _, c := range cells {
+				if c+4 > len(page) {
+					return fmt.Errorf("%w: cell out of range on page %d", errCorrupt, n)
+				}
+				if err := visit(int(binary.BigEndian.Uint32(page[c:c+4])), depth+1); err != nil {
+					return err
+				}
+			}
+			return visit(int(binary.BigEndian.Uint32(page[hdr+8:hdr+12])), depth+1)
+		default:
+			return fmt.Errorf("%w: page %d is not a table b-tree page", errCorrupt, n)
+		}
+	}
+	return visit(root, 0)
+}
+
+// countRows returns the number of rows of the table b-tree rooted at the given page.
+func (db *database) countRows(root int) (int, error) {
+	rows := 0
+	err := db.walk(root, func(_ []byte, cells []int) error {
+		rows += len(cells)
+		return nil
+	})
+	return rows, err
+}
+
+// tables reads the tables declared in the schema table, which is rooted at the first page.
+func (db *database) tables() ([]table, error) {
+	var tables []table
+	err := db.walk(1, func(page []byte, cells []int) error {
+		for _, c := range cells {
+			payload, err := db.payload(page, c)
+			if err != nil {
+				return err
+			}
+			// The columns of the schema table are type, name, tbl_name, rootpage and sql.
+			values, err := decodeRecord(payload)
+			if err != nil {
+				return err
+			}
+			if len(values) < 5 || values[0] != "table" {
+				continue
+			}
+			root, ok := values[3].(int64)
+			if !ok {
+				continue
+			}
+			name, _ := values[1].(string)
+			sql, _ := values[4].(string)
+			tables = append(tables, table{name: name, rootPage: int(root), sql: sql})
+		}
+		return nil
+	})
+	return tables, err
+}
+
+// payload returns the payload of the table leaf cell at offset c of page, following its overflow pages.
+func (db *database) payload(page []byte, c int) ([]byte, error) {
+	size, n := readVarint(page[c:])
+	if n == 0 {
+		return nil, errCorrupt
+	}
+	c += n
+	if _, n = readVarint(page[c:]); n == 0 { // rowid
+		return nil, errCorrupt
+	}
+	c += n
+	if size < 0 || size > int64(len(db.data)) {
+		return nil, fmt.Errorf("%w: invalid payload size", errCorrupt)
+	}
+
+	total := int(size)
+	local := db.localPayload(total)
+	if c+local > len(page) {
+		return nil, fmt.Errorf("%w: payload out of range", errCorrupt)
+	}
+	payload := append([]byte(nil), page[c:c+local]...)
+	if local == total {
+		return payload, nil
+	}
+
+	if c+local+4 > len(page) {
+		return nil, fmt.Errorf("%w: payload out of range", errCorrupt)
+	}
+	next := int(binary.BigEndian.Uint32(page[c+local : c+local+4]))
+	for i := 0; len(payload) < total; i++ {
+		if i == maxOverflowPages {
+			return nil, fmt.Errorf("%w: overflow chain too long", errCorrupt)
+		}
+		overflow, _, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		content := overflow[4:db.usable]
+		payload = append(payload, content[:min(len(content), total-len(payload))]...)
+		next = int(binary.BigEndian.Uint32(overflow[:4]))
+	}
+	return payload, nil
+}
+
+// localPayload returns how many bytes of a table leaf cell's payload are stored on the page itself.
+func (db *database) localPayload(total int) int {
+	maxLocal := db.usable - 35
+	if total <= maxLocal {
+		return total
+	}
+	minLocal := (db.usable-12)*32/255 - 23
+	local := minLocal + (total-minLocal)%(db.usable-4)
+	if local > maxLocal {
+		return minLocal
+	}
+	return local
+}
+
+// decodeRecord decodes a record into its values: nil, int64, float64 as raw bits, string or []byte.
+func decodeRecord(record []byte) ([]any, error) {
+	hdrSize, n := readVarint(record)
+	if n == 0 || hdrSize < int64(n) || hdrSize > int64(len(record)) {
+		return nil, fmt.Errorf("%w: invalid record header", errCorrupt)
+	}
+	var types []int64
+	for p := n; p < int(hdrSize); {
+		t, n := readVarint(record[p:int(hdrSize)])
+		if n == 0 {
+			return nil, fmt.Errorf("%w: invalid record header", errCorrupt)
+		}
+		types = append(types, t)
+		p += n
+	}
+
+	values := make([]any, 0, len(types))
+	body := record[hdrSize:]
+	for _, t := range types {
+		size := serialSize(t)
+		if size < 0 || size > len(body) {
+			return nil, fmt.Errorf("%w: record value out of range", errCorrupt)
+		}
+		v := body[:size]
+		body = body[size:]
+		switch {
+		case t == 0:
+			values = append(values, nil)
+		case t >= 1 && t <= 6:
+			values = append(values, decodeInt(v))
+		case t == 7:
+			values = append(values, binary.BigEndian.Uint64(v))
+		case t == 8 || t == 9:
+			values = append(values, t-8)
+		case t >= 12 && t%2 == 0:
+			values = append(values, v)
+		case t >= 13:
+			values = append(values, string(v))
+		default:
+			return nil, fmt.Errorf("%w: invalid serial type %d", errCorrupt, t)
+		}
+	}
+	return values, nil
+}
+
+// serialSize returns the size of a value with the given serial type, or -1 if the type is invalid.
+func serialSize(t int64) int {
+	switch {
+	case t >= 0 && t <= 4:
+		return [...]int{0, 1, 2, 3, 4}[t]
+	case t == 5:
+		return 6
+	case t == 6 || t == 7:
+		return 8
+	case t == 8 || t == 9:
+		return 0
+	case t >= 12 && t < 1<<31:
+		return int((t - 12) / 2)
+	default:
+		return -1
+	}
+}
+
+// decodeInt decodes a big-endian two's complement integer.
+func decodeInt(b []byte) int64 {
+	var v int64
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+// readVarint decodes a SQLite varint, which is big-endian unlike the varints of encoding/binary, and returns its
+// value and length, or a length of 0 if b is too short.
+func readVarint(b []byte) (int64, int) {
+	var v int64
+	for i := 0; i < 9 && i < len(b); i++ {
+		if i == 8 {
+			return v<<8 | int64(b[i]), 9
+		}
+		v = v<<7 | int64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,235 @@
+// Package bitcoin contains the encodings needed to turn a leaked Bitcoin private key into the addresses it controls:
+// Base58Check, WIF decoding, and P2PKH and P2WPKH address derivation. It also derives keys from BIP39 mnemonics
+// along BIP32 paths, which most other chains use as well.
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // RIPEMD-160 is part of the address format.
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+)
+
+const (
+	// MainnetWIFVersion is the version byte of mainnet WIF private keys.
+	MainnetWIFVersion = 0x80
+	// MainnetP2PKHVersion is the version byte of mainnet pay-to-pubkey-hash addresses (starting with "1").
+	MainnetP2PKHVersion = 0x00
+	// MainnetHRP is the human-readable part of mainnet segwit addresses (starting with "bc1").
+	MainnetHRP = "bc"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	ErrInvalidBase58   = errors.New("invalid base58 string")
+	ErrInvalidChecksum = errors.New("invalid base58check checksum")
+	ErrInvalidWIF      = errors.New("invalid WIF private key")
+)
+
+// WIF is a decoded Wallet Import Format private key.
+type WIF struct {
+	// Version is the network version byte, e.g. MainnetWIFVersion.
+	Version byte
+	// Key is the 32-byte secp256k1 private key.
+	Key []byte
+	// Compressed reports whether the key is used with a compressed public key.
+	Compressed bool
+}
+
+// DecodeWIF decodes and validates a WIF private key.
+func DecodeWIF(s string) (*WIF, error) {
+	payload, err := DecodeBase58Check(s)
+	if err != nil {
+		return nil, err
+	}
+	var wif WIF
+	switch {
+	case len(payload) == 33:
+	case len(payload) == 34 && payload[33] == 0x01:
+		wif.Compressed = true
+	default:
+		return nil, ErrInvalidWIF
+	}
+	wif.Version = payload[0]
+	wif.Key = payload[1:33]
+	if !secp256k1.ValidPrivateKey(wif.Key) {
+		return nil, ErrInvalidWIF
+	}
+	return &wif, nil
+}
+
+// PublicKey returns the serialized public key of the WIF, in the form its addresses are derived from.
+func (w *WIF) PublicKey() ([]byte, error) {
+	if w.Compressed {
+		return secp256k1.CompressedPublicKey(w.Key)
+	}
+	return secp256k1.PublicKey(w.Key)
+}
+
+// Hash160 returns RIPEMD160(SHA256(data)).
+func Hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
+
+// P2PKHAddress returns the pay-to-pubkey-hash address of a serialized public key.
+func P2PKHAddress(publicKey []byte, version byte) string {
+	return EncodeBase58Check(append([]byte{version}, Hash160(publicKey)...))
+}
+
+// P2WPKHAddress returns the pay-to-witness-pubkey-hash address of a compressed public key. Segwit only allows
+// compressed keys, so keys of uncompressed WIFs have no such address.
+func P2WPKHAddress(compressedPublicKey []byte, hrp string) (string, error) {
+	if len(compressedPublicKey) != 33 {
+		return "", errors.New("P2WPKH addresses require a compressed public key")
+	}
+	return SegwitAddress(hrp, 0, Hash160(compressedPublicKey)), nil
+}
+
+// SegwitAddress encodes a witness program as a version 0 segwit address (BIP 173). Later versions use bech32m
+// and are not supported.
+func SegwitAddress(hrp string, version byte, program []byte) string {
+	return encodeBech32(hrp, append([]byte{version}, convertBits(program, 8, 5)...))
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func encodeBech32(hrp string, data []byte) string {
+	values := append(bech32HRPExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var out strings.Builder
+	out.WriteString(hrp)
+	out.WriteByte('1')
+	for _, v := range data {
+		out.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		out.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return out.String()
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// convertBits regroups bits, e.g. bytes into the 5-bit groups of bech32, padding the last group with zeros.
+func convertBits(data []byte, fromBits, toBits uint) []byte {
+	var acc, bits uint
+	maxv := uint(1)<<toBits - 1
+	var out []byte
+	for _, b := range data {
+		acc = acc<<fromBits | uint(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte(acc<<(toBits-bits)&maxv))
+	}
+	return out
+}
+
+// EncodeBase58Check encodes a payload with a 4-byte double-SHA256 checksum.
+func EncodeBase58Check(payload []byte) string {
+	return EncodeBase58(append(payload[:len(payload):len(payload)], checksum(payload)...))
+}
+
+// DecodeBase58Check decodes a Base58Check string and returns the payload without the checksum.
+func DecodeBase58Check(s string) ([]byte, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 5 {
+		return nil, ErrInvalidChecksum
+	}
+	payload, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return nil, ErrInvalidChecksum
+	}
+	return payload, nil
+}
+
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+// EncodeBase58 encodes bytes using the Bitcoin alphabet. Leading zero bytes are encoded as '1'.
+func EncodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, '1')
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a string in the Bitcoin Base58 alphabet.
+func DecodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		idx := bytes.IndexByte([]byte(base58Alphabet), s[i])
+		if idx < 0 {
+			return nil, ErrInvalidBase58
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
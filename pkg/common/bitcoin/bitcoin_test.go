@@ -0,0 +1,145 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeWIF(t *testing.T) {
+	tests := []struct {
+		name       string
+		wif        string
+		compressed bool
+		address    string
+	}{
+		{
+			name:       "compressed",
+			wif:        "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn",
+			compressed: true,
+			address:    "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		},
+		{
+			name:       "uncompressed",
+			wif:        "5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf",
+			compressed: false,
+			address:    "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wif, err := DecodeWIF(tt.wif)
+			require.NoError(t, err)
+			assert.Equal(t, byte(MainnetWIFVersion), wif.Version)
+			assert.Equal(t, tt.compressed, wif.Compressed)
+			assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001", hex.EncodeToString(wif.Key))
+
+			pub, err := wif.PublicKey()
+			require.NoError(t, err)
+			assert.Equal(t, tt.address, P2PKHAddress(pub, MainnetP2PKHVersion))
+		})
+	}
+}
+
+func TestP2WPKHAddress(t *testing.T) {
+	// The example of BIP 173.
+	pub, err := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	require.NoError(t, err)
+	address, err := P2WPKHAddress(pub, MainnetHRP)
+	require.NoError(t, err)
+	assert.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", address)
+
+	wif, err := DecodeWIF("5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf")
+	require.NoError(t, err)
+	uncompressed, err := wif.PublicKey()
+	require.NoError(t, err)
+	_, err = P2WPKHAddress(uncompressed, MainnetHRP)
+	assert.Error(t, err)
+}
+
+func TestDecodeWIF_Invalid(t *testing.T) {
+	// Last character changed, so the checksum no longer matches.
+	_, err := DecodeWIF("KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWo")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	_, err = DecodeWIF("KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoW0")
+	assert.ErrorIs(t, err, ErrInvalidBase58)
+
+	// A valid Base58Check string that is an address rather than a key.
+	_, err = DecodeWIF("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH")
+	assert.ErrorIs(t, err, ErrInvalidWIF)
+}
+
+func TestBase58_RoundTrip(t *testing.T) {
+	for _, b := range [][]byte{{}, {0}, {0, 0, 1}, {0xff, 0x00, 0x10}} {
+		got, err := DecodeBase58(EncodeBase58(b))
+		require.NoError(t, err)
+		assert.Equal(t, b, got)
+	}
+}
+
+func FuzzDecodeBase58(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"1",
+		"111z",
+		"KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn",
+		"5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf",
+		"1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		"KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoW0",
+		"KwDiBf89QgGbjEhKnhXJuH7Lrci",
+		"KwDiBf89QgGbjEhKnhXJuH7Lrcié",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := DecodeBase58(s)
+		if err != nil {
+			return
+		}
+		if got := EncodeBase58(b); got != s {
+			t.Errorf("EncodeBase58(DecodeBase58(%q)) = %q", s, got)
+		}
+
+		if payload, err := DecodeBase58Check(s); err == nil {
+			if got := EncodeBase58Check(payload); got != s {
+				t.Errorf("EncodeBase58Check(DecodeBase58Check(%q)) = %q", s, got)
+			}
+		}
+
+		if wif, err := DecodeWIF(s); err == nil {
+			if _, err := wif.PublicKey(); err != nil {
+				t.Errorf("PublicKey() of decoded WIF %q: %v", s, err)
+			}
+		}
+	})
+}
+
+func TestDerivePrivateKey(t *testing.T) {
+	// BIP32 test vector 1.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path []uint32
+		want string
+	}{
+		{path: nil, want: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{path: []uint32{HardenedKeyStart}, want: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{path: []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2, 1000000000}, want: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tt := range tests {
+		key, err := DerivePrivateKey(seed, tt.path...)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, hex.EncodeToString(key))
+	}
+}
+
+func TestMnemonicSeed(t *testing.T) {
+	// BIP39 test vector.
+	seed, err := MnemonicSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+}
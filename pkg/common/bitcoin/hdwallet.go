@@ -0,0 +1,65 @@
+package bitcoin
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"encoding/binary"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+)
+
+// HardenedKeyStart is the first index of hardened child keys, written as i' or iH in derivation paths.
+const HardenedKeyStart = 0x80000000
+
+// MnemonicSeed returns the BIP39 seed of a mnemonic sentence and an optional passphrase. The words are not checked
+// against a wordlist; any sentence yields a seed.
+func MnemonicSeed(mnemonic, passphrase string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key(sha512.New, mnemonic, []byte("mnemonic"+passphrase), 2048, 64)
+}
+
+// DerivePrivateKey derives the private key at path from a BIP39 seed, following BIP32. Indexes at or above
+// HardenedKeyStart derive hardened children.
+func DerivePrivateKey(seed []byte, path ...uint32) ([]byte, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+	if !secp256k1.ValidPrivateKey(key) {
+		return nil, secp256k1.ErrInvalidPrivateKey
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= HardenedKeyStart {
+			data = append([]byte{0x00}, key...)
+		} else {
+			pub, err := secp256k1.CompressedPublicKey(key)
+			if err != nil {
+				return nil, err
+			}
+			data = pub
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		// A child whose tweak or key is out of range is invalid. BIP32 skips to the next index, which is too rare
+		// to matter here.
+		child, err := secp256k1.AddPrivateKeys(sum[:32], key)
+		if err != nil {
+			return nil, err
+		}
+		key, chainCode = child, sum[32:]
+	}
+	return key, nil
+}
+
+// Bech32Address encodes data as a bech32 string (BIP 173) without a witness version, the format of Cosmos SDK
+// account addresses.
+func Bech32Address(hrp string, data []byte) string {
+	return encodeBech32(hrp, convertBits(data, 8, 5))
+}
@@ -0,0 +1,19 @@
+package ethereum
+
+// Chain is an EVM-compatible chain, on which a key has the same address as on Ethereum mainnet.
+type Chain struct {
+	Name string
+	// RPC is a free JSON-RPC endpoint of the chain that does not require an API key.
+	RPC string
+}
+
+// Chains are the EVM-compatible chains with the most value locked, where a leaked key is most likely to hold funds.
+var Chains = []Chain{
+	{Name: "ethereum", RPC: PublicRPC},
+	{Name: "bsc", RPC: "https://bsc-rpc.publicnode.com"},
+	{Name: "polygon", RPC: "https://polygon-bor-rpc.publicnode.com"},
+	{Name: "arbitrum", RPC: "https://arbitrum-one-rpc.publicnode.com"},
+	{Name: "optimism", RPC: "https://optimism-rpc.publicnode.com"},
+	{Name: "base", RPC: "https://base-rpc.publicnode.com"},
+	{Name: "avalanche", RPC: "https://avalanche-c-chain-rpc.publicnode.com"},
+}
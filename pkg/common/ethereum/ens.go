@@ -0,0 +1,126 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	// PublicRPC is a free Ethereum mainnet JSON-RPC endpoint that does not require an API key.
+	PublicRPC = "https://ethereum-rpc.publicnode.com"
+
+	ensRegistry = "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e"
+
+	// Function selectors: resolver(bytes32), name(bytes32) and addr(bytes32).
+	selectorResolver = "0178b8bf"
+	selectorName     = "691f3431"
+	selectorAddr     = "3b3b57de"
+)
+
+var errMalformedResult = errors.New("malformed eth_call result")
+
+// NameHash implements the ENS namehash algorithm (EIP-137).
+func NameHash(name string) []byte {
+	node := make([]byte, 32)
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = Keccak256(node, Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// ReverseENS looks up the primary ENS name of an address through a mainnet JSON-RPC endpoint. It returns an empty
+// string if there is none. The name is only returned if it resolves back to the same address, as reverse records can
+// be set to arbitrary names.
+func ReverseENS(ctx context.Context, client *http.Client, rpcURL, address string) (string, error) {
+	address = strings.ToLower(strings.TrimPrefix(address, "0x"))
+	reverseNode := NameHash(address + ".addr.reverse")
+
+	resolver, err := resolverOf(ctx, client, rpcURL, reverseNode)
+	if err != nil || resolver == "" {
+		return "", err
+	}
+	result, err := ethCall(ctx, client, rpcURL, resolver, selectorName, reverseNode)
+	if err != nil {
+		return "", err
+	}
+	name, err := decodeString(result)
+	if err != nil || name == "" {
+		return "", err
+	}
+
+	// Forward check: name -> resolver -> addr must be the address we started from.
+	node := NameHash(name)
+	resolver, err = resolverOf(ctx, client, rpcURL, node)
+	if err != nil || resolver == "" {
+		return "", err
+	}
+	result, err = ethCall(ctx, client, rpcURL, resolver, selectorAddr, node)
+	if err != nil {
+		return "", err
+	}
+	forward, err := decodeAddress(result)
+	if err != nil || strings.TrimPrefix(forward, "0x") != address {
+		return "", err
+	}
+	return name, nil
+}
+
+// resolverOf returns the resolver contract of an ENS node, or an empty string if none is set.
+func resolverOf(ctx context.Context, client *http.Client, rpcURL string, node []byte) (string, error) {
+	result, err := ethCall(ctx, client, rpcURL, ensRegistry, selectorResolver, node)
+	if err != nil {
+		return "", err
+	}
+	resolver, err := decodeAddress(result)
+	if err != nil || resolver == "0x0000000000000000000000000000000000000000" {
+		return "", err
+	}
+	return resolver, nil
+}
+
+// ethCall calls a view function that takes a single bytes32 argument on the latest block.
+func ethCall(ctx context.Context, client *http.Client, rpcURL, to, selector string, arg []byte) ([]byte, error) {
+	call := map[string]string{"to": to, "data": "0x" + selector + hex.EncodeToString(arg)}
+	result, err := rpcCall(ctx, client, rpcURL, "eth_call", call, "latest")
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(result, "0x"))
+}
+
+// decodeAddress decodes an ABI-encoded address (right-aligned in a 32-byte word).
+func decodeAddress(result []byte) (string, error) {
+	if len(result) < 32 {
+		return "", errMalformedResult
+	}
+	return "0x" + hex.EncodeToString(result[12:32]), nil
+}
+
+// decodeString decodes an ABI-encoded dynamic string: offset, length, data.
+func decodeString(result []byte) (string, error) {
+	if len(result) == 0 {
+		return "", nil
+	}
+	if len(result) < 64 {
+		return "", errMalformedResult
+	}
+	// The offset and length come from the response, so bounds are checked without adding to them to avoid overflow.
+	size := uint64(len(result))
+	offset := binary.BigEndian.Uint64(result[24:32])
+	if offset > size-32 {
+		return "", errMalformedResult
+	}
+	length := binary.BigEndian.Uint64(result[offset+24 : offset+32])
+	if length > size-32-offset {
+		return "", errMalformedResult
+	}
+	return string(result[offset+32 : offset+32+length]), nil
+}
@@ -0,0 +1,51 @@
+// Package ethereum contains helpers shared by the detectors that find Ethereum private keys: deriving the address of
+// a key, and attributing that address to a known entity through a bundled label list or its ENS reverse record.
+package ethereum
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+)
+
+// labels maps lowercase addresses of exchanges, protocols and other well-known accounts to a display name.
+//
+//go:embed labels.json
+var labelsJSON []byte
+
+var labels = func() map[string]string {
+	m := make(map[string]string)
+	if err := json.Unmarshal(labelsJSON, &m); err != nil {
+		panic(err)
+	}
+	return m
+}()
+
+// Keccak256 returns the legacy Keccak-256 hash (as used by Ethereum) of the concatenation of data.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// AddressFromPrivateKey returns the lowercase, 0x-prefixed address controlled by a secp256k1 private key.
+func AddressFromPrivateKey(privateKey []byte) (string, error) {
+	pub, err := secp256k1.PublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(Keccak256(pub[1:])[12:]), nil
+}
+
+// KnownLabel returns the bundled label of an address, if it belongs to a known exchange, protocol or person.
+func KnownLabel(address string) (string, bool) {
+	label, ok := labels[strings.ToLower(address)]
+	return label, ok
+}
@@ -0,0 +1,147 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressFromPrivateKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{
+			key:  "0000000000000000000000000000000000000000000000000000000000000001",
+			want: "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf",
+		},
+		{
+			key:  "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			want: "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23",
+		},
+	}
+
+	for _, tt := range tests {
+		key, err := hex.DecodeString(tt.key)
+		require.NoError(t, err)
+		got, err := AddressFromPrivateKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestNameHash(t *testing.T) {
+	assert.Equal(t, strings.Repeat("0", 64), hex.EncodeToString(NameHash("")))
+	assert.Equal(t, "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", hex.EncodeToString(NameHash("eth")))
+	assert.Equal(t, "de9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", hex.EncodeToString(NameHash("foo.eth")))
+}
+
+func TestKnownLabel(t *testing.T) {
+	label, ok := KnownLabel("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	assert.True(t, ok)
+	assert.Equal(t, "Wrapped Ether", label)
+
+	_, ok = KnownLabel("0x7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+	assert.False(t, ok)
+}
+
+// fakeENS answers eth_call requests for the ENS registry and a single resolver that maps name to forwardAddr.
+func fakeENS(t *testing.T, name, forwardAddr string) *httptest.Server {
+	const resolver = "0x4976fb03c32e5b8cfe2b6ccb31c09ba78ebaba41"
+	word := func(b []byte) string {
+		padded := make([]byte, 32)
+		copy(padded[32-len(b):], b)
+		return hex.EncodeToString(padded)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(req.Params[0], &call))
+
+		var result string
+		switch selector := call.Data[2:10]; selector {
+		case selectorResolver:
+			addr, _ := hex.DecodeString(resolver[2:])
+			result = word(addr)
+		case selectorName:
+			result = word([]byte{32}) + word([]byte{byte(len(name))}) + hex.EncodeToString([]byte(name))
+			result += strings.Repeat("0", 64-len(name)*2%64)
+		case selectorAddr:
+			addr, _ := hex.DecodeString(forwardAddr[2:])
+			result = word(addr)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"jsonrpc": "2.0", "result": "0x" + result})
+	}))
+}
+
+func TestReverseENS(t *testing.T) {
+	const address = "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+
+	t.Run("forward record matches", func(t *testing.T) {
+		server := fakeENS(t, "vitalik.eth", address)
+		defer server.Close()
+
+		name, err := ReverseENS(context.Background(), server.Client(), server.URL, address)
+		require.NoError(t, err)
+		assert.Equal(t, "vitalik.eth", name)
+	})
+
+	t.Run("forward record does not match", func(t *testing.T) {
+		server := fakeENS(t, "vitalik.eth", "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+		defer server.Close()
+
+		name, err := ReverseENS(context.Background(), server.Client(), server.URL, address)
+		require.NoError(t, err)
+		assert.Empty(t, name)
+	})
+}
+
+func TestDecodeString(t *testing.T) {
+	word := func(n uint64) string {
+		return fmt.Sprintf("%064x", n)
+	}
+	tests := []struct {
+		name    string
+		result  string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", result: ""},
+		{name: "valid", result: word(32) + word(11) + hex.EncodeToString([]byte("vitalik.eth")) + strings.Repeat("00", 21), want: "vitalik.eth"},
+		{name: "too short", result: word(32), wantErr: true},
+		{name: "offset out of range", result: word(64) + word(0), wantErr: true},
+		{name: "overflowing offset", result: word(math.MaxUint64-16) + word(0), wantErr: true},
+		{name: "length out of range", result: word(32) + word(33) + strings.Repeat("00", 32), wantErr: true},
+		{name: "overflowing length", result: word(32) + word(math.MaxUint64-32) + strings.Repeat("00", 32), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := hex.DecodeString(tt.result)
+			require.NoError(t, err)
+
+			got, err := decodeString(result)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errMalformedResult)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+type rpcResponse struct {
+	Result string `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// rpcCall performs a JSON-RPC request whose result is a single string, such as a hex quantity or hex data.
+func rpcCall(ctx context.Context, client *http.Client, rpcURL, method string, params ...any) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+
+	var rpcRes rpcResponse
+	if err := json.NewDecoder(res.Body).Decode(&rpcRes); err != nil {
+		return "", err
+	}
+	if rpcRes.Error != nil {
+		return "", fmt.Errorf("%s failed: %s", method, rpcRes.Error.Message)
+	}
+	return rpcRes.Result, nil
+}
+
+// Balance returns the balance in wei of an address on the latest block.
+func Balance(ctx context.Context, client *http.Client, rpcURL, address string) (*big.Int, error) {
+	result, err := rpcCall(ctx, client, rpcURL, "eth_getBalance", address, "latest")
+	if err != nil {
+		return nil, err
+	}
+	return parseQuantity(result)
+}
+
+// TransactionCount returns the nonce of an address on the latest block, i.e. the number of transactions it has sent.
+func TransactionCount(ctx context.Context, client *http.Client, rpcURL, address string) (uint64, error) {
+	result, err := rpcCall(ctx, client, rpcURL, "eth_getTransactionCount", address, "latest")
+	if err != nil {
+		return 0, err
+	}
+	n, err := parseQuantity(result)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("transaction count out of range: %s", result)
+	}
+	return n.Uint64(), nil
+}
+
+// parseQuantity parses a hex-encoded JSON-RPC quantity such as "0x1a".
+func parseQuantity(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("malformed quantity %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,123 @@
+// Package secp256k1 derives public keys on the secp256k1 curve used by Bitcoin, Ethereum, Filecoin and most other
+// blockchains. It only exists so that detectors can turn a leaked private key into an address and look it up; it is
+// not constant-time and must not be used for signing.
+package secp256k1
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// p is the prime of the underlying field, n the order of the base point G.
+	p, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	n, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+
+	ErrInvalidPrivateKey = errors.New("invalid secp256k1 private key")
+)
+
+// point is an affine point on the curve. A nil x denotes the point at infinity.
+type point struct {
+	x, y *big.Int
+}
+
+// ValidPrivateKey reports whether priv is a 32-byte scalar in [1, n-1].
+func ValidPrivateKey(priv []byte) bool {
+	if len(priv) != 32 {
+		return false
+	}
+	k := new(big.Int).SetBytes(priv)
+	return k.Sign() > 0 && k.Cmp(n) < 0
+}
+
+// PublicKey returns the 65-byte uncompressed public key (0x04 || X || Y) of priv.
+func PublicKey(priv []byte) ([]byte, error) {
+	pub, err := publicPoint(priv)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 65)
+	out[0] = 0x04
+	pub.x.FillBytes(out[1:33])
+	pub.y.FillBytes(out[33:])
+	return out, nil
+}
+
+// CompressedPublicKey returns the 33-byte compressed public key (0x02/0x03 || X) of priv.
+func CompressedPublicKey(priv []byte) ([]byte, error) {
+	pub, err := publicPoint(priv)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 33)
+	out[0] = 0x02 + byte(pub.y.Bit(0))
+	pub.x.FillBytes(out[1:])
+	return out, nil
+}
+
+// AddPrivateKeys returns the private key (a + b) mod n, as used by hierarchical key derivation.
+func AddPrivateKeys(a, b []byte) ([]byte, error) {
+	if !ValidPrivateKey(a) || !ValidPrivateKey(b) {
+		return nil, ErrInvalidPrivateKey
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(a), new(big.Int).SetBytes(b))
+	if sum.Mod(sum, n).Sign() == 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return sum.FillBytes(make([]byte, 32)), nil
+}
+
+func publicPoint(priv []byte) (point, error) {
+	if !ValidPrivateKey(priv) {
+		return point{}, ErrInvalidPrivateKey
+	}
+	k := new(big.Int).SetBytes(priv)
+
+	// Plain double-and-add; speed and side channels do not matter for address derivation.
+	result := point{}
+	addend := point{x: gx, y: gy}
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = add(result, addend)
+		}
+		addend = add(addend, addend)
+	}
+	return result, nil
+}
+
+func add(a, b point) point {
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+
+	var lambda *big.Int
+	if a.x.Cmp(b.x) == 0 {
+		// a = -b
+		if sum := new(big.Int).Add(a.y, b.y); sum.Mod(sum, p).Sign() == 0 {
+			return point{}
+		}
+		// lambda = 3x^2 / 2y
+		num := new(big.Int).Mul(a.x, a.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(a.y, 1)
+		lambda = num.Mul(num, den.ModInverse(den, p))
+	} else {
+		// lambda = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(b.y, a.y)
+		den := new(big.Int).Sub(b.x, a.x)
+		den.Mod(den, p)
+		lambda = num.Mul(num, den.ModInverse(den, p))
+	}
+	lambda.Mod(lambda, p)
+
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, p)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, lambda).Sub(y, a.y).Mod(y, p)
+	return point{x: x, y: y}
+}
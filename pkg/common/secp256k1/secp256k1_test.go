@@ -0,0 +1,77 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		priv       string
+		compressed string
+	}{
+		{
+			name:       "generator",
+			priv:       "0000000000000000000000000000000000000000000000000000000000000001",
+			compressed: "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		},
+		{
+			name:       "bip32 test vector 1 master key",
+			priv:       "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			compressed: "0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv, err := hex.DecodeString(tt.priv)
+			require.NoError(t, err)
+
+			compressed, err := CompressedPublicKey(priv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.compressed, hex.EncodeToString(compressed))
+
+			uncompressed, err := PublicKey(priv)
+			require.NoError(t, err)
+			assert.Len(t, uncompressed, 65)
+			assert.Equal(t, compressed[1:], uncompressed[1:33])
+		})
+	}
+}
+
+func TestValidPrivateKey(t *testing.T) {
+	assert.False(t, ValidPrivateKey(make([]byte, 32)))
+	assert.False(t, ValidPrivateKey([]byte{1}))
+
+	order, _ := hex.DecodeString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	assert.False(t, ValidPrivateKey(order))
+
+	_, err := PublicKey(order)
+	assert.ErrorIs(t, err, ErrInvalidPrivateKey)
+}
+
+func TestAddPrivateKeys(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+	two := make([]byte, 32)
+	two[31] = 2
+	three := make([]byte, 32)
+	three[31] = 3
+
+	sum, err := AddPrivateKeys(one, two)
+	require.NoError(t, err)
+	assert.Equal(t, three, sum)
+
+	// n-1 + 2 wraps around to 1.
+	orderMinusOne, _ := hex.DecodeString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140")
+	sum, err = AddPrivateKeys(orderMinusOne, two)
+	require.NoError(t, err)
+	assert.Equal(t, one, sum)
+
+	_, err = AddPrivateKeys(orderMinusOne, one)
+	assert.ErrorIs(t, err, ErrInvalidPrivateKey)
+}
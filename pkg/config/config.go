@@ -3,9 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/custom_detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/watchlist"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/protoyaml"
@@ -23,8 +25,9 @@ import (
 
 // Config holds user supplied configuration.
 type Config struct {
-	Sources   []sources.ConfiguredSource
-	Detectors []detectors.Detector
+	Sources        []sources.ConfiguredSource
+	Detectors      []detectors.Detector
+	CandidateRules *detectors.CandidateRules
 }
 
 // Read parses a given filename into a Config.
@@ -54,6 +57,17 @@ func NewYAML(input []byte) (*Config, error) {
 		detectorConfigs = append(detectorConfigs, detector)
 	}
 
+	// Report occurrences of watched addresses and account IDs.
+	if watched := watchlist.New(inputYAML.GetWatchlist()); watched != nil {
+		detectorConfigs = append(detectorConfigs, watched)
+	}
+
+	// Compile the candidate ignore and report rules.
+	candidateRules, err := detectors.NewCandidateRules(inputYAML.GetCandidateRules())
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert to configured sources.
 	var sourceConfigs []sources.ConfiguredSource
 	for _, pbSource := range inputYAML.Sources {
@@ -67,11 +81,27 @@ func NewYAML(input []byte) (*Config, error) {
 	}
 
 	return &Config{
-		Detectors: detectorConfigs,
-		Sources:   sourceConfigs,
+		Detectors:      detectorConfigs,
+		Sources:        sourceConfigs,
+		CandidateRules: candidateRules,
 	}, nil
 }
 
+// Add adds the detectors and candidate rules of other, e.g. of a ruleset, to c. The sources of other are ignored.
+func (c *Config) Add(other *Config) {
+	c.Detectors = append(c.Detectors, other.Detectors...)
+	switch {
+	case other.CandidateRules == nil:
+	case c.CandidateRules == nil:
+		c.CandidateRules = other.CandidateRules
+	default:
+		c.CandidateRules = &detectors.CandidateRules{
+			Ignore: append(slices.Clip(c.CandidateRules.Ignore), other.CandidateRules.Ignore...),
+			Report: append(slices.Clip(c.CandidateRules.Report), other.CandidateRules.Report...),
+		}
+	}
+}
+
 // instantiateSourceFromType creates a concrete implementation of
 // sources.Source for the provided type.
 func instantiateSourceFromType(sourceType string) (sources.Source, error) {
@@ -35,6 +35,7 @@ type CustomRegexWebhook struct {
 var _ detectors.Detector = (*CustomRegexWebhook)(nil)
 var _ detectors.CustomFalsePositiveChecker = (*CustomRegexWebhook)(nil)
 var _ detectors.MaxSecretSizeProvider = (*CustomRegexWebhook)(nil)
+var _ detectors.PatternProvider = (*CustomRegexWebhook)(nil)
 
 // NewWebhookCustomRegex initializes and validates a CustomRegexWebhook. An
 // unexported type is intentionally returned here to ensure the values have
@@ -215,6 +216,18 @@ func (c *CustomRegexWebhook) MaxSecretSize() int64 {
 	return 1000
 }
 
+// Patterns returns the detector's regexes by name. They are compiled on every
+// call, which is fine since it is only used to debug findings.
+func (c *CustomRegexWebhook) Patterns() map[string]detectors.Pattern {
+	patterns := make(map[string]detectors.Pattern, len(c.GetRegex()))
+	for name, regex := range c.GetRegex() {
+		if compiled, err := regexp.Compile(regex); err == nil {
+			patterns[name] = compiled
+		}
+	}
+	return patterns
+}
+
 func (c *CustomRegexWebhook) createResults(ctx context.Context, match map[string][]string, verify bool, results chan<- detectors.Result) error {
 	if common.IsDone(ctx) {
 		// TODO: Log we're possibly leaving out results.
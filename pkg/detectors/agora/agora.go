@@ -2,7 +2,9 @@ package agora
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -16,21 +18,35 @@ import (
 type Scanner struct {
 	detectors.DefaultMultiPartCredentialProvider
 	client *http.Client
+	// apiURL overrides the Agora REST API in tests.
+	apiURL string
 }
 
 const agoraURL = "https://api.agora.io"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector         = (*Scanner)(nil)
+	_ detectors.MetadataProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
 	keyPat    = regexp.MustCompile(detectors.PrefixRegex([]string{"agora", "key", "token"}) + `\b([a-z0-9]{32})\b`)
 	secretPat = regexp.MustCompile(detectors.PrefixRegex([]string{"agora", "secret"}) + `\b([a-z0-9]{32})\b`)
+
+	// The App ID and App Certificate of a project, with which servers sign the tokens that let users join channels.
+	appIDPat   = regexp.MustCompile(detectors.PrefixRegex([]string{"appid", "app_id", "app-id", "app id"}) + `\b([a-f0-9]{32})\b`)
+	appCertPat = regexp.MustCompile(detectors.PrefixRegex([]string{"certificate", "app_cert", "appcert"}) + `\b([a-f0-9]{32})\b`)
 )
 
+// project is a project of the account, as listed by the console REST API.
+type project struct {
+	Name      string `json:"name"`
+	VendorKey string `json:"vendor_key"` // App ID
+	SignKey   string `json:"sign_key"`   // App Certificate
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -44,6 +60,13 @@ func (s Scanner) getClient() *http.Client {
 	return defaultClient
 }
 
+func (s Scanner) getAPIURL() string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return agoraURL
+}
+
 // FromData will find and optionally verify Agora secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -51,6 +74,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 	secretMatches := secretPat.FindAllStringSubmatch(dataStr, -1)
 
+	// The projects of verified customer credentials, which verify App Certificates.
+	var projects []project
+
 	for _, match := range matches {
 		resMatch := strings.TrimSpace(match[1])
 
@@ -72,39 +98,104 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 			if verify {
 				client := s.getClient()
-				isVerified, verificationErr := verifyAgora(ctx, client, resMatch, resSecret)
+				isVerified, accountProjects, verificationErr := verifyAgora(ctx, client, s.getAPIURL(), resMatch, resSecret)
 				s1.Verified = isVerified
 				s1.SetVerificationError(verificationErr, resMatch)
+				if isVerified && len(accountProjects) > 0 {
+					names := make([]string, 0, len(accountProjects))
+					for _, p := range accountProjects {
+						names = append(names, p.Name)
+					}
+					s1.ExtraData = map[string]string{"projects": strings.Join(names, ", ")}
+					projects = append(projects, accountProjects...)
+				}
 			}
 
 			results = append(results, s1)
 		}
 	}
 
-	return results, nil
+	return append(results, appCertificateResults(dataStr, verify, projects)...), nil
 }
 
-func verifyAgora(ctx context.Context, client *http.Client, resMatch, resSecret string) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agoraURL+"/dev/v1/projects", nil)
+// appCertificateResults finds App ID and App Certificate pairs. An App Certificate only signs tokens locally, so it
+// cannot be verified by itself; a pair is verified if it belongs to a project of verified customer credentials found
+// in the same data.
+func appCertificateResults(dataStr string, verify bool, projects []project) []detectors.Result {
+	appIDs := make(map[string]struct{})
+	for _, match := range appIDPat.FindAllStringSubmatch(dataStr, -1) {
+		appIDs[match[1]] = struct{}{}
+	}
+	certs := make(map[string]struct{})
+	for _, match := range appCertPat.FindAllStringSubmatch(dataStr, -1) {
+		certs[match[1]] = struct{}{}
+	}
+
+	var results []detectors.Result
+	for _, appID := range detectors.SortedKeys(appIDs) {
+		for _, cert := range detectors.SortedKeys(certs) {
+			if appID == cert {
+				continue
+			}
+
+			s1 := detectors.Result{
+				DetectorType: detector_typepb.DetectorType_Agora,
+				Raw:          []byte(cert),
+				RawV2:        []byte(appID + cert),
+				Redacted:     appID,
+				ExtraData: map[string]string{
+					"credential_type": "app_certificate",
+					"app_id":          appID,
+				},
+			}
+
+			if verify {
+				for _, p := range projects {
+					if p.VendorKey == appID && p.SignKey == cert {
+						s1.Verified = true
+						s1.ExtraData["project"] = p.Name
+						break
+					}
+				}
+			}
+
+			results = append(results, s1)
+		}
+	}
+	return results
+}
+
+// verifyAgora lists the projects of the account with the customer ID and secret.
+func verifyAgora(ctx context.Context, client *http.Client, apiURL, resMatch, resSecret string) (bool, []project, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/dev/v1/projects", nil)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	req.SetBasicAuth(resSecret, resMatch)
 	res, err := client.Do(req)
 
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
-	defer res.Body.Close()
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
 
 	// https://docs.agora.io/en/voice-calling/reference/agora-console-rest-api#get-all-projects
 	switch res.StatusCode {
 	case http.StatusOK, http.StatusCreated:
-		return true, nil
+		var body struct {
+			Projects []project `json:"projects"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return true, nil, nil
+		}
+		return true, body.Projects, nil
 	case http.StatusUnauthorized, http.StatusForbidden:
-		return false, nil
+		return false, nil, nil
 	default:
-		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 	}
 }
 
@@ -115,3 +206,9 @@ func (s Scanner) Type() detector_typepb.DetectorType {
 func (s Scanner) Description() string {
 	return "Agora is a real-time engagement platform providing APIs for voice, video, and messaging. Agora API keys can be used to access and manage these services."
 }
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCommunications} }
@@ -150,9 +150,14 @@ func TestAgora_FromChunk(t *testing.T) {
 				if gotErr != wantErr {
 					t.Fatalf("wantVerificationError = %v, verification error = %v", tt.want[i].VerificationError(), got[i].VerificationError())
 				}
+				if got[i].Verified && got[i].ExtraData["projects"] == "" {
+					t.Fatalf("no projects listed for verified credentials: \n %+v", got[i])
+				}
 			}
 			ignoreOpts := cmpopts.IgnoreFields(detectors.Result{}, "Raw", "RawV2", "verificationError")
-			if diff := cmp.Diff(got, tt.want, ignoreOpts); diff != "" {
+			// The projects of the test account may change; their presence is checked above.
+			ignoreProjects := cmpopts.IgnoreMapEntries(func(k, _ string) bool { return k == "projects" })
+			if diff := cmp.Diff(got, tt.want, ignoreOpts, ignoreProjects, cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Agora.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
 			}
 		})
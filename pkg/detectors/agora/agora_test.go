@@ -2,9 +2,13 @@ package agora
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -120,3 +124,62 @@ func TestAgora_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestAgora_Verify(t *testing.T) {
+	const (
+		customerID     = "6p77f9gjhxx9mwdj86of7y7820bh49vw"
+		customerSecret = "qi6txx6vd0qzn6j01xj9rr6clyejvjw5"
+		appID          = "4f1e7c2a9b8d4e6f8a0b1c2d3e4f5a6b"
+		appCertificate = "9c8b7a6f5e4d3c2b1a0f9e8d7c6b5a49"
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if r.URL.Path != "/dev/v1/projects" || user != customerSecret || pass != customerID {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"projects":[{"id":"Q1w2","name":"live-classes","vendor_key":%q,"sign_key":%q,"status":1}]}`, appID, appCertificate)
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), apiURL: server.URL}
+
+	input := fmt.Sprintf(`AGORA_CUSTOMER_KEY=%s
+AGORA_CUSTOMER_SECRET=%s
+AGORA_APP_ID=%s
+AGORA_APP_CERTIFICATE=%s
+`, customerID, customerSecret, appID, appCertificate)
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+
+	var customer, certificate *detectors.Result
+	for i, r := range results {
+		switch string(r.RawV2) {
+		case customerID + customerSecret:
+			customer = &results[i]
+		case appID + appCertificate:
+			certificate = &results[i]
+		}
+	}
+	require.NotNil(t, customer)
+	assert.True(t, customer.Verified)
+	assert.Equal(t, map[string]string{"projects": "live-classes"}, customer.ExtraData)
+
+	require.NotNil(t, certificate)
+	assert.True(t, certificate.Verified)
+	assert.Equal(t, map[string]string{
+		"credential_type": "app_certificate",
+		"app_id":          appID,
+		"project":         "live-classes",
+	}, certificate.ExtraData)
+
+	// Without verified customer credentials, an App Certificate cannot be verified.
+	results, err = d.FromData(context.Background(), true, []byte(fmt.Sprintf("AGORA_APP_ID=%s\nAGORA_APP_CERTIFICATE=%s\n", appID, appCertificate)))
+	require.NoError(t, err)
+	for _, r := range results {
+		if string(r.RawV2) == appID+appCertificate {
+			assert.False(t, r.Verified)
+			assert.NoError(t, r.VerificationError())
+		}
+	}
+}
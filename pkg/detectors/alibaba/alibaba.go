@@ -38,7 +38,8 @@ const alibabaURL = "https://ecs.aliyuncs.com"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector         = (*Scanner)(nil)
+	_ detectors.MetadataProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -89,6 +90,12 @@ func (s Scanner) Description() string {
 	return "Alibaba Cloud is a cloud computing service that provides a suite of cloud computing services including data storage, relational databases, big-data processing, and content delivery networks (CDNs). Alibaba Cloud API keys can be used to access and manage these services."
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
 func randString(n int) string {
 	const alphanum = "0123456789abcdefghijklmnopqrstuvwxyz"
 	var bytes = make([]byte, n)
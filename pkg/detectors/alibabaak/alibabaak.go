@@ -37,7 +37,8 @@ const alibabaURL = "https://ecs.aliyuncs.com"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector         = (*Scanner)(nil)
+	_ detectors.MetadataProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -86,6 +87,12 @@ func (s Scanner) Description() string {
 	return "aliyun only ak"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
 func randString(n int) string {
 	const alphanum = "0123456789abcdefghijklmnopqrstuvwxyz"
 	var bytes = make([]byte, n)
@@ -30,7 +30,8 @@ const alibabaURL = "https://ecs.aliyuncs.com"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector         = (*Scanner)(nil)
+	_ detectors.MetadataProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -50,6 +51,12 @@ func (s Scanner) Description() string {
 	return "alibaba ak dm"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
 func randString(n int) string {
 	const alphanum = "0123456789abcdefghijklmnopqrstuvwxyz"
 	var bytes = make([]byte, n)
@@ -0,0 +1,234 @@
+package alipay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// gateway 仅用于测试, 默认为支付宝开放平台网关
+	gateway string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.StartOffsetProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 应用私钥: PEM 格式, 或 SDK 配置中常见的不带头尾的 base64 (PKCS#1 / PKCS#8)
+	pemKeyPat  = regexp.MustCompile(`-----BEGIN (?:RSA )?PRIVATE KEY-----[A-Za-z0-9+/=\s\\"]{800,}?-----END (?:RSA )?PRIVATE KEY-----`)
+	bareKeyPat = regexp.MustCompile(`\bMII[A-Za-z0-9+/]{800,}={0,2}`)
+	// 私钥附近必须出现支付宝相关的上下文, 否则交给通用的 privatekey detector
+	contextPat = regexp.MustCompile(`(?i)alipay|支付宝`)
+	// 应用 ID 为 2 开头的 16 位数字
+	appIDPat = regexp.MustCompile(`(?i)app[_-]?id["'\s:=]+["']?(20\d{14})\b`)
+)
+
+const (
+	defaultGateway = "https://openapi.alipay.com/gateway.do"
+	// 在私钥前后查找支付宝上下文的字节数
+	contextWindow = 1024
+	// 4096 位 RSA 私钥的 PEM 约 3.3KB
+	maxKeySize = 4096
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"alipay", "支付宝"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxKeySize + contextWindow }
+
+// StartOffset implements detectors.StartOffsetProvider, 私钥可能出现在 alipay 关键字之前
+func (s Scanner) StartOffset() int64 { return maxKeySize + contextWindow }
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Alipay app private keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var appID string
+	if m := appIDPat.FindStringSubmatch(dataStr); m != nil {
+		appID = m[1]
+	}
+
+	seen := make(map[string]struct{})
+	for _, pat := range []*regexp.Regexp{pemKeyPat, bareKeyPat} {
+		for _, loc := range pat.FindAllStringIndex(dataStr, -1) {
+			window := dataStr[max(loc[0]-contextWindow, 0):min(loc[1]+contextWindow, len(dataStr))]
+			if !contextPat.MatchString(window) {
+				continue
+			}
+			key, format, ok := detectors.ParseRSAPrivateKey(dataStr[loc[0]:loc[1]])
+			if !ok {
+				continue
+			}
+			// PEM 和 base64 两种形式可能匹配到同一个私钥
+			fingerprint := base64.StdEncoding.EncodeToString(key.N.Bytes())
+			if _, ok := seen[fingerprint]; ok {
+				continue
+			}
+			seen[fingerprint] = struct{}{}
+
+			raw := dataStr[loc[0]:loc[1]]
+			s1 := detectors.Result{
+				DetectorType: detector_typepb.DetectorType_AlipayPrivateKey,
+				Raw:          []byte(raw),
+				Redacted:     raw[:min(len(raw), 40)] + "...",
+				ExtraData: map[string]string{
+					"format": format,
+					"bits":   strconv.Itoa(key.N.BitLen()),
+				},
+				Severity: detectors.SeverityCritical,
+			}
+			if appID != "" {
+				s1.ExtraData["app_id"] = appID
+			}
+
+			// 没有应用 ID 无法调用开放平台接口
+			if verify && appID != "" {
+				gateway := s.gateway
+				if gateway == "" {
+					gateway = defaultGateway
+				}
+				isVerified, verificationErr := verifyKey(ctx, s.getClient(), gateway, appID, key)
+				s1.Verified = isVerified
+				s1.SetVerificationError(verificationErr, raw)
+			}
+
+			results = append(results, s1)
+		}
+	}
+
+	return results, nil
+}
+
+// verifyKey 用私钥签名一次 alipay.trade.query 请求, 查询一个不存在的订单. 网关先校验签名, 签名通过时返回
+// 交易不存在或无权限, 签名不通过时返回 isv.invalid-signature.
+// docs: https://opendocs.alipay.com/open-v3/common/sign
+func verifyKey(ctx context.Context, client *http.Client, gateway, appID string, key *rsa.PrivateKey) (bool, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, err
+	}
+	params := map[string]string{
+		"app_id":      appID,
+		"method":      "alipay.trade.query",
+		"format":      "JSON",
+		"charset":     "utf-8",
+		"sign_type":   "RSA2",
+		"timestamp":   time.Now().In(time.FixedZone("CST", 8*3600)).Format("2006-01-02 15:04:05"),
+		"version":     "1.0",
+		"biz_content": fmt.Sprintf(`{"out_trade_no":"trufflehog%s"}`, hex.EncodeToString(nonce)),
+	}
+	sign, err := signParams(params, key)
+	if err != nil {
+		return false, err
+	}
+
+	form := url.Values{}
+	for k, v := range params {
+		form.Set(k, v)
+	}
+	form.Set("sign", sign)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gateway+"?charset=utf-8", strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+
+	var body struct {
+		Response struct {
+			Code    string `json:"code"`
+			SubCode string `json:"sub_code"`
+		} `json:"alipay_trade_query_response"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, err
+	}
+
+	switch {
+	case body.Response.SubCode == "isv.invalid-signature", body.Response.SubCode == "isv.invalid-app-id",
+		body.Response.SubCode == "isv.app-unonline":
+		return false, nil
+	// 10000 成功, 40004 业务失败 (订单不存在), 40006 权限不足: 都说明签名已通过校验
+	case body.Response.Code == "10000", body.Response.Code == "40004", body.Response.Code == "40006":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unexpected response code %s %s", body.Response.Code, body.Response.SubCode)
+	}
+}
+
+// signParams 按参数名排序后拼接为 k=v&k=v, 使用 SHA256WithRSA 签名
+func signParams(params map[string]string, key *rsa.PrivateKey) (string, error) {
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+params[k])
+	}
+
+	digest := sha256.Sum256([]byte(strings.Join(pairs, "&")))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_AlipayPrivateKey
+}
+
+func (s Scanner) Description() string {
+	return "Alipay is a Chinese payment platform. The application private key of an Alipay app signs all Open Platform API calls, which allows creating and refunding payments and transferring funds on behalf of the merchant."
+}
@@ -0,0 +1,120 @@
+package alipay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+func newKey(t *testing.T) (*rsa.PrivateKey, string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), base64.StdEncoding.EncodeToString(der)
+}
+
+func TestAlipay_Pattern(t *testing.T) {
+	_, pemKey, bareKey := newKey(t)
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "valid pattern - bare key in SDK config",
+			input: "alipay.app-id=2021003123456789\nalipay.merchant-private-key=" + bareKey + "\n",
+			want:  1,
+		},
+		{
+			name:  "valid pattern - PEM key",
+			input: "# 支付宝应用私钥\n" + pemKey,
+			want:  1,
+		},
+		{
+			name:  "valid pattern - PEM and bare forms of the same key",
+			input: "alipay:\n  private_key: " + bareKey + "\n" + pemKey,
+			want:  1,
+		},
+		{
+			name:  "invalid pattern - key far from alipay context",
+			input: "alipay\n" + strings.Repeat("x", 2*contextWindow) + "\n" + pemKey,
+			want:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+			assert.Len(t, results, test.want)
+		})
+	}
+}
+
+func TestAlipay_Verify(t *testing.T) {
+	key, _, bareKey := newKey(t)
+
+	// 网关用应用公钥校验签名
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		var pairs []string
+		for k := range r.PostForm {
+			if k != "sign" {
+				pairs = append(pairs, k+"="+r.PostForm.Get(k))
+			}
+		}
+		sort.Strings(pairs)
+		digest := sha256.Sum256([]byte(strings.Join(pairs, "&")))
+		sig, _ := base64.StdEncoding.DecodeString(r.PostForm.Get("sign"))
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig) != nil {
+			_, _ = w.Write([]byte(`{"alipay_trade_query_response":{"code":"40002","msg":"Invalid Arguments","sub_code":"isv.invalid-signature"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"alipay_trade_query_response":{"code":"40004","msg":"Business Failed","sub_code":"ACQ.TRADE_NOT_EXIST"}}`))
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), gateway: server.URL}
+	input := "alipay.app-id=2021003123456789\nalipay.merchant-private-key=" + bareKey
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+	assert.Equal(t, map[string]string{"app_id": "2021003123456789", "format": "pkcs8", "bits": "2048"}, results[0].ExtraData)
+
+	// 其他应用的私钥签名无法通过校验
+	_, _, otherKey := newKey(t)
+	results, err = d.FromData(context.Background(), true, []byte("alipay.app-id=2021003123456789\nalipay.merchant-private-key="+otherKey))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
@@ -0,0 +1,258 @@
+package ansiblevault
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.PasswordDecrypter = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+
+var (
+	// 文件头: $ANSIBLE_VAULT;1.1;AES256 或带 vault id 的 $ANSIBLE_VAULT;1.2;AES256;prod
+	// 内联形式出现在 YAML 中: db_password: !vault |
+	//                              $ANSIBLE_VAULT;1.1;AES256
+	headerPat = regexp.MustCompile(`\$ANSIBLE_VAULT;(1\.[012]);(AES256)(?:;([\w.-]+))?`)
+	// 内联 vault 前面的变量名
+	inlineVarPat = regexp.MustCompile(`([\w.-]+)\s*:\s*!vault\s*\|[-+]?\s*$`)
+	hexLinePat   = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
+
+const (
+	// Ansible 使用固定的 PBKDF2 迭代次数
+	pbkdf2Iterations = 10000
+	// 每个 vault 最多尝试的密码数量
+	maxPasswordAttempts = 10
+	// 加密后的正文上限, 整个 vars 文件加密时可能较大
+	maxVaultSize = 256 * 1024
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"$ANSIBLE_VAULT"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxVaultSize }
+
+// FromData will find Ansible Vault files and inline vault strings in a given set of bytes, and optionally try to
+// decrypt them with passwords found in the same data.
+func (s Scanner) FromData(_ context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	var passwords []string
+	if verify {
+		passwords = detectors.CollectPasswords("", data)
+	}
+
+	for _, v := range findVaults(string(data)) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_AnsibleVault,
+			Raw:          []byte(v.key()),
+			Redacted:     "$ANSIBLE_VAULT;" + v.version + ";" + v.cipher + " salt=" + v.key()[:16],
+			// 加密内容泄露后可以离线爆破, 弱密码的 vault 等同于明文
+			Severity: detectors.SeverityMedium,
+			ExtraData: map[string]string{
+				"encrypted": "true",
+				"version":   v.version,
+				"cipher":    v.cipher,
+				"format":    "file",
+				"data_size": strconv.Itoa(len(v.cipherText)),
+			},
+		}
+		if v.vaultID != "" {
+			s1.ExtraData["vault_id"] = v.vaultID
+		}
+		if v.variable != "" {
+			s1.ExtraData["format"] = "inline"
+			s1.ExtraData["variable"] = v.variable
+		}
+
+		if verify {
+			v.tryPasswords(&s1, passwords)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// TryDecrypt tries to decrypt the vault behind result with passwords collected from the rest of the source unit,
+// such as a .vault_pass file or a vault_password variable in CI configuration.
+func (s Scanner) TryDecrypt(_ context.Context, data []byte, result *detectors.Result, candidates []string) bool {
+	// FromData 已经尝试过同一段数据中的密码
+	tried := detectors.CollectPasswords("", data)
+	candidates = slices.DeleteFunc(slices.Clone(candidates), func(c string) bool {
+		return slices.Contains(tried, c)
+	})
+	if len(candidates) == 0 {
+		return false
+	}
+
+	for _, v := range findVaults(string(data)) {
+		if v.key() == string(result.Raw) {
+			return v.tryPasswords(result, candidates)
+		}
+	}
+	return false
+}
+
+type vault struct {
+	version    string
+	cipher     string
+	vaultID    string
+	variable   string
+	salt       []byte
+	hmac       []byte
+	cipherText []byte
+}
+
+// key 以 salt 标识 vault, 每次加密都会生成新的随机 salt
+func (v vault) key() string {
+	return hex.EncodeToString(v.salt)
+}
+
+// findVaults 查找数据中的 vault, 按 salt 去重
+func findVaults(dataStr string) []vault {
+	var vaults []vault
+	seen := make(map[string]struct{})
+
+	for _, idx := range headerPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		v := vault{version: dataStr[idx[2]:idx[3]], cipher: dataStr[idx[4]:idx[5]]}
+		if idx[6] >= 0 {
+			v.vaultID = dataStr[idx[6]:idx[7]]
+		}
+
+		// 正文是从下一行开始的十六进制行, 内联形式带缩进
+		body := collectHexLines(dataStr[idx[1]:])
+		if !v.parseBody(body) {
+			continue
+		}
+		if _, ok := seen[v.key()]; ok {
+			continue
+		}
+		seen[v.key()] = struct{}{}
+
+		// 内联 vault 的变量名在文件头的前一行或同一行
+		lineStart := strings.LastIndexByte(dataStr[:idx[0]], '\n')
+		before := dataStr[max(0, lineStart-256):idx[0]]
+		if m := inlineVarPat.FindStringSubmatch(strings.TrimRight(before, " \t\r\n")); m != nil {
+			v.variable = m[1]
+		}
+		vaults = append(vaults, v)
+	}
+	return vaults
+}
+
+// collectHexLines 收集文件头之后连续的十六进制行
+func collectHexLines(rest string) string {
+	var b strings.Builder
+	lines := strings.Split(rest, "\n")
+	// 第一项是文件头所在行的剩余部分
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" || !hexLinePat.MatchString(line) {
+			break
+		}
+		b.WriteString(line)
+		if b.Len() > 2*maxVaultSize {
+			break
+		}
+	}
+	return b.String()
+}
+
+// parseBody 解码正文: hex(hex(salt) \n hex(hmac) \n hex(ciphertext))
+func (v *vault) parseBody(body string) bool {
+	inner, err := hex.DecodeString(body)
+	if err != nil {
+		return false
+	}
+	parts := bytes.Split(inner, []byte("\n"))
+	if len(parts) != 3 {
+		return false
+	}
+	if v.salt, err = hex.DecodeString(string(parts[0])); err != nil || len(v.salt) < 8 {
+		return false
+	}
+	if v.hmac, err = hex.DecodeString(string(parts[1])); err != nil || len(v.hmac) != sha256.Size {
+		return false
+	}
+	if v.cipherText, err = hex.DecodeString(string(parts[2])); err != nil || len(v.cipherText) == 0 {
+		return false
+	}
+	return len(v.cipherText)%aes.BlockSize == 0
+}
+
+// tryPasswords 依次尝试候选密码, 成功时记录解密结果
+func (v vault) tryPasswords(result *detectors.Result, passwords []string) bool {
+	for i, password := range passwords {
+		if i >= maxPasswordAttempts {
+			break
+		}
+		if plainText, ok := v.decrypt(password); ok {
+			// 成功解密即说明 vault 和密码一起泄露了
+			result.Verified = true
+			result.Severity = detectors.SeverityHigh
+			result.ExtraData["decrypted"] = "true"
+			result.ExtraData["plaintext_size"] = strconv.Itoa(len(plainText))
+			return true
+		}
+	}
+	return false
+}
+
+// decrypt 按 Ansible VaultAES256 解密: PBKDF2-SHA256 派生 80 字节, 依次为 AES 密钥, HMAC 密钥和 CTR 初始计数器,
+// 先校验密文的 HMAC-SHA256 再用 AES-256-CTR 解密并去除 PKCS#7 填充
+func (v vault) decrypt(password string) ([]byte, bool) {
+	derived, err := pbkdf2.Key(sha256.New, password, v.salt, pbkdf2Iterations, 2*32+aes.BlockSize)
+	if err != nil {
+		return nil, false
+	}
+	aesKey, hmacKey, iv := derived[:32], derived[32:64], derived[64:]
+
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(v.cipherText)
+	if !hmac.Equal(mac.Sum(nil), v.hmac) {
+		return nil, false
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, false
+	}
+	plainText := make([]byte, len(v.cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(plainText, v.cipherText)
+
+	pad := int(plainText[len(plainText)-1])
+	if pad == 0 || pad > aes.BlockSize || pad > len(plainText) {
+		return nil, false
+	}
+	return plainText[:len(plainText)-pad], true
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_AnsibleVault
+}
+
+func (s Scanner) Description() string {
+	return "Ansible Vault encrypts variables and files in playbooks with a shared password. A leaked vault can be brute-forced offline, and together with its password it reveals the secrets inside, typically infrastructure credentials."
+}
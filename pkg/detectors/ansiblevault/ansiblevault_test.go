@@ -0,0 +1,140 @@
+package ansiblevault
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testPassword = "correct-horse-battery"
+	// ansible-vault encrypt_string 's3cr3t-db-p4ss'
+	inlineSalt  = "7f3a9c2e5b8d1f4a6c0e2b9d7a5f3c1e8b6d4f2a0c9e7b5d3f1a8c6e4b2d0f9a"
+	inlineVault = `db_password: !vault |
+          $ANSIBLE_VAULT;1.1;AES256
+          37663361396332653562386431663461366330653262396437613566336331653862366434663261
+          3063396537623564336631613863366534623264306639610a306462383663313466653263663838
+          62393435623864626232663739333136373132306565636332366664613663303035353137376263
+          3135396635666265390a343236613536393235363433626530653830353636346538303535663236
+          3737
+db_user: app
+`
+	// ansible-vault encrypt --vault-id prod@prompt group_vars/prod/vault.yml
+	fileSalt  = "2b4d6f8a0c1e3b5d7f9a1c3e5b7d9f0a2c4e6b8d0f1a3c5e7b9d2f4a6c8e0b1d"
+	fileVault = `$ANSIBLE_VAULT;1.2;AES256;prod
+32623464366638613063316533623564376639613163336535623764396630613263346536623864
+3066316133633565376239643266346136633865306231640a386437333061623932396530366261
+31363430336361666532346336393633643935643831366337343339633739366334383464393233
+6330383834316637330a643565666163363430616232326635663466346337336539336534633162
+35393033613661613430366531373839323536316135386433363333333135333739633964396266
+39356634396465306134336566383935643166613332623234636131646531346532323438303133
+61666631376131663465656363343433383039363335346239393933383838653839396439646633
+63323862306339626534
+`
+)
+
+func TestAnsibleVault_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - inline vault string",
+			input: inlineVault,
+			want:  []string{inlineSalt},
+		},
+		{
+			name:  "valid pattern - vault file with vault id",
+			input: fileVault,
+			want:  []string{fileSalt},
+		},
+		{
+			name:  "invalid pattern - truncated body",
+			input: fileVault[:200],
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - header only",
+			input: "# files starting with $ANSIBLE_VAULT;1.1;AES256 are encrypted",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, string(r.Raw))
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestAnsibleVault_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(inlineVault))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "inline", results[0].ExtraData["format"])
+	assert.Equal(t, "db_password", results[0].ExtraData["variable"])
+	assert.False(t, results[0].Verified)
+
+	results, err = Scanner{}.FromData(context.Background(), false, []byte(fileVault))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "file", results[0].ExtraData["format"])
+	assert.Equal(t, "prod", results[0].ExtraData["vault_id"])
+	assert.Equal(t, "1.2", results[0].ExtraData["version"])
+}
+
+func TestAnsibleVault_Decrypt(t *testing.T) {
+	ctx := context.Background()
+
+	// 密码出现在同一段数据中
+	data := []byte("ansible_vault_password: " + testPassword + "\n" + inlineVault)
+	results, err := Scanner{}.FromData(ctx, true, data)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, "14", results[0].ExtraData["plaintext_size"])
+	assert.Equal(t, detectors.SeverityHigh, results[0].Severity)
+
+	// 密码来自同一源单元中的其他文件, 如 .vault_pass
+	results, err = Scanner{}.FromData(ctx, true, []byte(fileVault))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.False(t, results[0].Verified)
+
+	assert.False(t, Scanner{}.TryDecrypt(ctx, []byte(fileVault), &results[0], []string{"wrong-password"}))
+	assert.True(t, Scanner{}.TryDecrypt(ctx, []byte(fileVault), &results[0], []string{"wrong-password", testPassword}))
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, "true", results[0].ExtraData["decrypted"])
+}
+
+func TestParseBody_Invalid(t *testing.T) {
+	var v vault
+	assert.False(t, v.parseBody(strings.Repeat("zz", 40)))
+	assert.False(t, v.parseBody("6162630a646566"))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
@@ -60,13 +61,14 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 			isAdminKey := isAdminKey(keyMatch)
 			var isVerified bool
+			var orgID string
 			var err error
 
 			if isAdminKey {
-				isVerified, err = verifyAnthropicKey(ctx, client, adminKeyEndpoint, keyMatch)
+				isVerified, orgID, err = verifyAnthropicKey(ctx, client, adminKeyEndpoint, keyMatch)
 				s1.ExtraData["Type"] = "Admin Key"
 			} else if !isAdminKey {
-				isVerified, err = verifyAnthropicKey(ctx, client, apiKeyEndpoint, keyMatch)
+				isVerified, orgID, err = verifyAnthropicKey(ctx, client, apiKeyEndpoint, keyMatch)
 				s1.ExtraData["Type"] = "API Key"
 			} else {
 				return nil, errors.New("unknown key type detected for anthropic")
@@ -77,7 +79,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 			if s1.Verified {
 				s1.AnalysisInfo = map[string]string{
-					"key": keyMatch,
+					"key":      keyMatch,
+					"is_admin": strconv.FormatBool(isAdminKey),
+				}
+				if orgID != "" {
+					s1.AnalysisInfo["organization_id"] = orgID
 				}
 			}
 		}
@@ -96,11 +102,13 @@ Endpoints:
   - For api keys: https://docs.anthropic.com/en/api/models-list
 
   - For admin keys:  https://docs.anthropic.com/en/api/admin-api/apikeys/list-api-keys
+
+It also returns the ID of the organization the key belongs to, which the API reports in a response header.
 */
-func verifyAnthropicKey(ctx context.Context, client *http.Client, endpoint, key string) (bool, error) {
+func verifyAnthropicKey(ctx context.Context, client *http.Client, endpoint, key string) (bool, string, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
-		return false, nil
+		return false, "", nil
 	}
 
 	req.Header.Set("x-api-key", key)
@@ -109,20 +117,20 @@ func verifyAnthropicKey(ctx context.Context, client *http.Client, endpoint, key
 
 	res, err := client.Do(req)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 	defer res.Body.Close()
 
 	switch res.StatusCode {
 	case http.StatusOK:
-		return true, nil
+		return true, res.Header.Get("anthropic-organization-id"), nil
 
 	case http.StatusNotFound, http.StatusUnauthorized:
 		// 404 is returned if api key is disabled or not found
-		return false, nil
+		return false, "", nil
 
 	default:
-		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+		return false, "", fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 	}
 }
 
@@ -0,0 +1,237 @@
+package arweavewallet
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Arweave 钱包是 4096 位 RSA 私钥的 JWK: {"kty":"RSA","n":"...","e":"AQAB","d":"...","p":"...",...}
+	// 字段顺序不固定, 以私钥指数 d 为锚点在附近查找其它字段
+	dPat   = regexp.MustCompile(`"d"\s*:\s*"([A-Za-z0-9_-]{600,700})"`)
+	ktyPat = regexp.MustCompile(`"kty"\s*:\s*"RSA"`)
+	nPat   = regexp.MustCompile(`"n"\s*:\s*"([A-Za-z0-9_-]{600,700})"`)
+	ePat   = regexp.MustCompile(`"e"\s*:\s*"([A-Za-z0-9_-]{1,8})"`)
+	pPat   = regexp.MustCompile(`"p"\s*:\s*"([A-Za-z0-9_-]{300,350})"`)
+	qPat   = regexp.MustCompile(`"q"\s*:\s*"([A-Za-z0-9_-]{300,350})"`)
+)
+
+const (
+	// 一个完整的 JWK 钱包大约 3.2KB
+	fieldWindow = 4096
+	// Arweave 只使用 4096 位的 RSA 密钥
+	modulusBits = 4096
+
+	gatewayURL = "https://arweave.net"
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{`"kty"`}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Arweave JWK wallets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 用于去重
+	seen := make(map[string]struct{})
+
+	for _, idx := range dPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		d := dataStr[idx[2]:idx[3]]
+		window := dataStr[max(0, idx[0]-fieldWindow):min(len(dataStr), idx[1]+fieldWindow)]
+		if !ktyPat.MatchString(window) {
+			continue
+		}
+		nMatch := nPat.FindStringSubmatch(window)
+		if nMatch == nil {
+			continue
+		}
+		n := nMatch[1]
+
+		if !isValidKey(window, n, d) {
+			continue
+		}
+
+		address, err := walletAddress(n)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_ArweaveWallet,
+			Raw:          []byte(address),
+			RawV2:        []byte(address + ":" + d),
+			Redacted:     address,
+			ExtraData: map[string]string{
+				"address": address,
+			},
+		}
+
+		if verify {
+			isVerified, balance, verificationErr := verifyAddress(ctx, s.getClient(), address)
+			s1.Verified = isVerified
+			if balance != "" {
+				s1.ExtraData["balance_winston"] = balance
+			}
+			s1.SetVerificationError(verificationErr, d)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// isValidKey 校验 JWK 是否为 4096 位的 RSA 私钥; 如果包含素因子, 还会校验私钥的一致性
+func isValidKey(window, n, d string) bool {
+	modulus, err := decodeInt(n)
+	if err != nil || modulus.BitLen() != modulusBits {
+		return false
+	}
+	exponent, err := decodeInt(d)
+	if err != nil || exponent.Sign() <= 0 || exponent.Cmp(modulus) >= 0 {
+		return false
+	}
+
+	eMatch := ePat.FindStringSubmatch(window)
+	pMatch := pPat.FindStringSubmatch(window)
+	qMatch := qPat.FindStringSubmatch(window)
+	if eMatch == nil || pMatch == nil || qMatch == nil {
+		return true
+	}
+
+	e, err := decodeInt(eMatch[1])
+	if err != nil || !e.IsInt64() {
+		return false
+	}
+	p, err := decodeInt(pMatch[1])
+	if err != nil {
+		return false
+	}
+	q, err := decodeInt(qMatch[1])
+	if err != nil {
+		return false
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: modulus, E: int(e.Int64())},
+		D:         exponent,
+		Primes:    []*big.Int{p, q},
+	}
+	return key.Validate() == nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// walletAddress 钱包地址是公钥模数 n 的 SHA-256, 使用 base64url 编码
+func walletAddress(n string) (string, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(modulus)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// verifyAddress 通过网关查询钱包的余额和最后一笔交易, 有交易或余额即说明钱包被使用过
+func verifyAddress(ctx context.Context, client *http.Client, address string) (bool, string, error) {
+	balance, err := gatewayGet(ctx, client, "/wallet/"+address+"/balance")
+	if err != nil {
+		return false, "", err
+	}
+	lastTx, err := gatewayGet(ctx, client, "/wallet/"+address+"/last_tx")
+	if err != nil {
+		return false, balance, err
+	}
+
+	hasBalance := balance != "" && strings.Trim(balance, "0") != ""
+	return hasBalance || lastTx != "", balance, nil
+}
+
+func gatewayGet(ctx context.Context, client *http.Client, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gatewayURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_ArweaveWallet
+}
+
+func (s Scanner) Description() string {
+	return "Arweave wallets are 4096-bit RSA keys stored as JWK files. A leaked wallet gives full control over its AR balance and allows signing permanent uploads on its behalf."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("Arweave wallet")
+}
@@ -0,0 +1,89 @@
+package arweavewallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// 测试用的 4096 位 RSA 密钥, 没有任何资产
+const (
+	testN       = "sWHaSKLFFhgNOi_UGv87d8hDwA4B2rxcmpV7vkG-3_vd-INZxQzkBiMuI6garkGTieB8xjsxQmW8YLDzkCXAnFSzJWT80td_WTNlVFIT9jPm3NRJ4-_s7hTJ1GPQTbB1Yg6_d_09aKBB_We8XO9bTbhHM3M4XBX6zQL-uugsR-QkASZN9moAZZk_hqk86Z1gK5fEcAnUuNFGGjZm1-92yJoGOWskpSCJaTxZC-KPiTd96HAHZ2QC8YkgcjYYyarfda-OJD0Gc-IItuxT7MbHu9Vu2SHjh7rOF0ufGNIYsl5nCH18rJJF5klYCkGkZiIwLpUHbtErL2QWqnAw4Y5K9qPL0bFDnos84EcWYvheb6ocuQtytNLcVCFPLVdzTcNVI9kD7GkQzPk4j3DuwsInCd95nNkAEbMfFEThakmhjwc6o_FjekBMEZ9BuP1fS6m5C3qsoxaQuN6jlLOajzpUIVryCMz8UBM40hTbFeZrUUaRCDZKSknlzoYpUEN_QcgROzdmNPJQvFY-eUacRrftfDHKfUgDokJRisPbYI9I8UJooA0mmJWiU5yZhJGy1aYDIzIMUp0oWjFdHtvpPVS7gJy-aEvn5hMiHiH4mJF0_xNqRYSeevqd6nFXLjgjgXi8yb-pOnLLgi236AAhMoyzvIzd8d9fwotYxpUaM22S7rE"
+	testD       = "HMCOwF1Z8vXdFwRvrhyXCBh3GudqV4lWvVvjnfhMN0Q9O64W3AD16STEstTp1jsc7ilsMDmiB3A0M7wbDs9_xBdanoIbFRYHN29ESoGvaKYLEtp6S681SXYnLP0s45rpvAZF9O4giRgI-klZV_sYYqWvLODNTuflKlwE7Ej7_H83ctRBqOKPw-guSuad0ARJdWd5WOvPBlItucv9qZzQNT36Kk56tyVqAJvelzWXLJfJGecm3BRzAbHHh5vLMs02wX2-XZNiu1_1J6lxBR1HfOPgvuNmXTbjdxUz6bMMe8UfkFm-3r4rBGP5GFiBjGiYRMS1Wroe9OSVY_Uhhz4oWkLTy-Jm56jaiStP1LkcOBXZ8Gv5Id_fMTalvaNsbLqX14nGmEkNBnecI3a_xaXRvEQ25ljbUPlHNYtOiVxkGX8TcCn9acN88-nLGhx_1Bc3tFkWRa6XSvWVA-leZQhFjtHRSoZN3B1_FPB88LjIQ3MpMz1LrfM9y-1rUf1S7c_H_odJQkXVHPE4-x4rhq4H8Vem6VZwdZtcX-MlMrLkn8AHu8n1LMlq0RqNYDYeuoI3Jnsweq1Qps5cyhmdSfYmmWJZtRt26Wgm6LrNDY1IeLiNx_mdv0qJq8RNMyGUyg2WxW58arKeFq7zmQ85kAy7UrBUESD8-C8bxch-G86Xi90"
+	testP       = "wV1IvNxOf8cNhOgipJOIWoF1CX3-Td0GxrvFduMAIpHO6cspgjpRT55I2pal7u4BhUDlNPBYMfqS7HCb79kt9Y6wR-c0CF1sis_s46xLFIkNLM2QfdINwSVOJbkygIe-OTByMtW_r8lMdGEUNYJ76LF-Dy4S3WWPktn18b9e1nyciLXFrqShaItgRp9OWzFr0u7ccczwNmiuYcUf3ZGJQSSnInlU9qOZeKEwQtqiQDGNK7a3WuTvYFbqj9gSgZjfaIBR5KGTfjbJTgcNOZBvj-dxQUW0iIxscP_f9N1QYfiB_snBXj7vthHsx6VF777xIU5XrEZW8tztZlkDhIJvVw"
+	testQ       = "6tdAJdvssRwUT37DmBbuGubz9Dr9AgsoGeVoFxLFCVA1l2EBLmEWBe8gFJE3gxwQYOSTTlOXLHSdC-bAvJmnk9SBCd28NTdEvVPYTc1NxQc6mqJ-g4O1uLzGjpiM7l4Pt6WsflTfHDH81ER6eu3KzR77VQtRQm5J0FAQ4A3Xpfzc-HwBr4EkiSlwHOpF8kzKnV0cT1B0shgpGcn1yxvPUv8aTQAMlu542S4r4fG0wrorYUie2sBwKoMlCG_WRqzAE16oabt4IDnY_e26bIt3wHfyqn7mx3NlzpP_8outTvHmBvSaYj-R1v_9Uk2HGjx0MQHRpjdYv6Un9GoWX4A1Nw"
+	testAddress = "1BjS9UUTMYpgXuchYVOzy4kTgnOwyBExm36nNkoB2wo"
+)
+
+func TestArweaveWallet_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid wallet file",
+			input: `{"kty":"RSA","n":"` + testN + `","e":"AQAB","d":"` + testD + `","p":"` + testP + `","q":"` + testQ + `"}`,
+			want:  []string{testAddress + ":" + testD},
+		},
+		{
+			name:  "valid wallet without primes, different field order",
+			input: `const wallet = {"d":"` + testD + `","e":"AQAB","kty":"RSA","n":"` + testN + `"}`,
+			want:  []string{testAddress + ":" + testD},
+		},
+		{
+			name:  "invalid wallet - primes do not match",
+			input: `{"kty":"RSA","n":"` + testN + `","e":"AQAB","d":"` + testD + `","p":"` + testQ + `","q":"` + testQ + `"}`,
+			want:  nil,
+		},
+		{
+			name:  "invalid wallet - public key only",
+			input: `{"kty":"RSA","n":"` + testN + `","e":"AQAB"}`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package azuredevopspersonalaccesstoken
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
@@ -15,37 +18,93 @@ import (
 
 type Scanner struct {
 	client *http.Client
+	// baseURL overrides the Azure DevOps API in tests.
+	baseURL string
 	detectors.DefaultMultiPartCredentialProvider
 }
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
 
+const defaultBaseURL = "https://dev.azure.com"
+
 var (
 	defaultClient = common.SaneHttpClient()
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"azure"}) + `\b([0-9a-z]{52})\b`)
 	orgPat = regexp.MustCompile(detectors.PrefixRegex([]string{"azure"}) + `\b([0-9a-zA-Z][0-9a-zA-Z-]{5,48}[0-9a-zA-Z])\b`)
+
+	// Legacy PATs are 52 lowercase base32 characters. Without the "azure" keyword in front of them, they are only
+	// matched next to the URL of an organization.
+	bareKeyPat = regexp.MustCompile(`\b([a-z2-7]{52})\b`)
+	// PATs issued since 2024 are 84 characters, with the JQQJ9 signature of Azure keys and the AZDO service tag.
+	caskKeyPat = regexp.MustCompile(`\b([A-Za-z0-9]{52}JQQJ9[A-Za-z0-9]{19}AZDO[A-Za-z0-9]{4})\b`)
+	// Organization URLs: https://dev.azure.com/<org> and the legacy https://<org>.visualstudio.com.
+	orgURLPat = regexp.MustCompile(`(?i)\b(?:dev\.azure\.com/([a-z0-9][a-z0-9-]{0,48}[a-z0-9]?)|([a-z0-9][a-z0-9-]{0,48}[a-z0-9]?)\.visualstudio\.com)\b`)
 )
 
+// nonOrgHosts are visualstudio.com hosts that are not organizations, e.g. app.vssps.visualstudio.com.
+var nonOrgHosts = map[string]struct{}{
+	"vssps":       {},
+	"code":        {},
+	"marketplace": {},
+	"docs":        {},
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"azure"}
+	return []string{"azure", "visualstudio.com", "azdo"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getBaseURL() string {
+	if s.baseURL != "" {
+		return s.baseURL
+	}
+	return defaultBaseURL
 }
 
 // FromData will find and optionally verify AzureDevopsPersonalAccessToken secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-	orgMatches := orgPat.FindAllStringSubmatch(dataStr, -1)
+	// Organizations named by URL are preferred over any word that follows "azure".
+	uniqueOrgs := make(map[string]struct{})
+	for _, match := range orgURLPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueOrgs[strings.ToLower(match[1]+match[2])] = struct{}{}
+	}
+	for host := range nonOrgHosts {
+		delete(uniqueOrgs, host)
+	}
+	orgs := detectors.SortedKeys(uniqueOrgs)
+	if len(orgs) == 0 {
+		for _, orgMatch := range orgPat.FindAllStringSubmatch(dataStr, -1) {
+			orgs = append(orgs, strings.TrimSpace(orgMatch[1]))
+		}
+	}
 
-	for _, match := range matches {
-		resMatch := strings.TrimSpace(match[1])
-		for _, orgMatch := range orgMatches {
-			resOrgMatch := strings.TrimSpace(orgMatch[1])
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[strings.TrimSpace(match[1])] = struct{}{}
+	}
+	for _, match := range caskKeyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+	if len(uniqueOrgs) > 0 {
+		for _, match := range bareKeyPat.FindAllStringSubmatch(dataStr, -1) {
+			uniqueKeys[match[1]] = struct{}{}
+		}
+	}
 
+	for _, resMatch := range detectors.SortedKeys(uniqueKeys) {
+		for _, resOrgMatch := range orgs {
 			s1 := detectors.Result{
 				DetectorType: detector_typepb.DetectorType_AzureDevopsPersonalAccessToken,
 				Raw:          []byte(resMatch),
@@ -53,30 +112,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 
 			if verify {
-				client := s.client
-				if client == nil {
-					client = defaultClient
-				}
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://dev.azure.com/"+resOrgMatch+"/_apis/projects", nil)
-				if err != nil {
-					continue
-				}
-				req.SetBasicAuth("", resMatch)
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					hasVerifiedRes, _ := common.ResponseContainsSubstring(res.Body, "lastUpdateTime")
-					if res.StatusCode >= 200 && res.StatusCode < 300 && hasVerifiedRes {
-						s1.Verified = true
-					} else if res.StatusCode == 401 {
-						// The secret is determinately not verified (nothing to do)
-					} else {
-						err = fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
-						s1.SetVerificationError(err, resMatch)
-					}
-				} else {
-					s1.SetVerificationError(err, resMatch)
-				}
+				isVerified, extraData, verificationErr := verifyPAT(ctx, s.getClient(), s.getBaseURL(), resOrgMatch, resMatch)
+				s1.Verified = isVerified
+				s1.ExtraData = extraData
+				s1.SetVerificationError(verificationErr, resMatch)
 			}
 
 			results = append(results, s1)
@@ -86,6 +125,104 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+// scopeProbes are read-only requests that succeed only if the PAT has the scope. The Azure DevOps API does not report
+// the scopes of a PAT to the PAT itself, so the reported scopes are those that were observed to work. {project} is
+// replaced by a project of the organization.
+var scopeProbes = []struct {
+	scope string
+	path  string
+}{
+	{"vso.code", "/_apis/git/repositories?api-version=7.0"},
+	{"vso.build", "/{project}/_apis/build/definitions?$top=1&api-version=7.0"},
+	{"vso.release", "/{project}/_apis/release/definitions?$top=1&api-version=7.0"},
+	{"vso.variablegroups_read", "/{project}/_apis/distributedtask/variablegroups?$top=1&api-version=7.0"},
+	{"vso.work", "/{project}/_apis/wit/queries?$depth=0&api-version=7.0"},
+}
+
+// verifyPAT lists the projects of the organization with the PAT. For a valid PAT, it reports the organization, the
+// user the PAT belongs to, the projects and the scopes observed to work.
+func verifyPAT(ctx context.Context, client *http.Client, baseURL, org, token string) (bool, map[string]string, error) {
+	orgURL := baseURL + "/" + url.PathEscape(org)
+
+	var projects struct {
+		Value []struct {
+			Name           string `json:"name"`
+			LastUpdateTime string `json:"lastUpdateTime"`
+		} `json:"value"`
+	}
+	status, err := getJSON(ctx, client, orgURL+"/_apis/projects?api-version=7.0", token, &projects)
+	if err != nil {
+		return false, nil, err
+	}
+	switch {
+	case status >= 200 && status < 300 && len(projects.Value) > 0 && projects.Value[0].LastUpdateTime != "":
+	case status == http.StatusUnauthorized:
+		// The secret is determinately not verified (nothing to do)
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", status)
+	}
+
+	extraData := map[string]string{"organization": org}
+	names := make([]string, 0, len(projects.Value))
+	for _, p := range projects.Value {
+		names = append(names, p.Name)
+	}
+	if len(names) > 10 {
+		names = append(names[:10], fmt.Sprintf("(%d more)", len(names)-10))
+	}
+	extraData["projects"] = strings.Join(names, ", ")
+
+	var connection struct {
+		AuthenticatedUser struct {
+			ProviderDisplayName string `json:"providerDisplayName"`
+		} `json:"authenticatedUser"`
+	}
+	if status, err := getJSON(ctx, client, orgURL+"/_apis/connectionData", token, &connection); err == nil && status == http.StatusOK {
+		if user := connection.AuthenticatedUser.ProviderDisplayName; user != "" {
+			extraData["user"] = user
+		}
+	}
+
+	scopes := []string{"vso.project"}
+	project := url.PathEscape(projects.Value[0].Name)
+	for _, probe := range scopeProbes {
+		path := strings.ReplaceAll(probe.path, "{project}", project)
+		if status, err := getJSON(ctx, client, orgURL+path, token, nil); err == nil && status == http.StatusOK {
+			scopes = append(scopes, probe.scope)
+		}
+	}
+	extraData["scopes"] = strings.Join(scopes, ", ")
+
+	return true, extraData, nil
+}
+
+// getJSON requests endpoint with the PAT and decodes a successful JSON response into v, if not nil.
+func getJSON(ctx context.Context, client *http.Client, endpoint, token string, v any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth("", token)
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	// An invalid PAT may be redirected to a sign-in page, which is not JSON.
+	if v != nil && res.StatusCode >= 200 && res.StatusCode < 300 {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil && res.StatusCode == http.StatusOK {
+			return res.StatusCode, err
+		}
+	}
+	return res.StatusCode, nil
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_AzureDevopsPersonalAccessToken
 }
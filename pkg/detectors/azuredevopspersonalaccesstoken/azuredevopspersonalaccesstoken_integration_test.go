@@ -6,6 +6,7 @@ package azuredevopspersonalaccesstoken
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,6 +56,7 @@ func TestAzureDevopsPersonalAccessToken_FromChunk(t *testing.T) {
 					DetectorType: detector_typepb.DetectorType_AzureDevopsPersonalAccessToken,
 					Verified:     true,
 					RawV2:        []byte(secret + org),
+					ExtraData:    map[string]string{"organization": org},
 				},
 			},
 			wantErr:             false,
@@ -144,9 +146,16 @@ func TestAzureDevopsPersonalAccessToken_FromChunk(t *testing.T) {
 				if (got[i].VerificationError() != nil) != tt.wantVerificationErr {
 					t.Fatalf("wantVerificationError = %v, verification error = %v", tt.wantVerificationErr, got[i].VerificationError())
 				}
+				if got[i].Verified && !strings.HasPrefix(got[i].ExtraData["scopes"], "vso.project") {
+					t.Fatalf("no scopes reported for verified PAT: \n %+v", got[i])
+				}
 			}
 			ignoreOpts := cmpopts.IgnoreFields(detectors.Result{}, "Raw", "RawV2", "verificationError")
-			if diff := cmp.Diff(got, tt.want, ignoreOpts); diff != "" {
+			// The projects, user and scopes of the test PAT may change; the scopes are checked above.
+			ignoreAccount := cmpopts.IgnoreMapEntries(func(k, _ string) bool {
+				return k == "projects" || k == "user" || k == "scopes"
+			})
+			if diff := cmp.Diff(got, tt.want, ignoreOpts, ignoreAccount); diff != "" {
 				t.Errorf("AzureDevopsPersonalAccessToken.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
 			}
 		})
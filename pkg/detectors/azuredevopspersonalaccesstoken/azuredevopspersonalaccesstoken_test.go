@@ -2,9 +2,14 @@ package azuredevopspersonalaccesstoken
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -46,6 +51,23 @@ func TestAzureDevopsPersonalAccessToken_Pattern(t *testing.T) {
 				"h0wpgbusyba8acyaec1uxxcbxlucgr490c6nvrvd8rylfocwkpg5AQAAABAAA",
 			},
 		},
+		{
+			name: "organization URL",
+			input: `
+					git remote add origin https://contoso@dev.azure.com/contoso/Web/_git/frontend
+					git config http.extraheader "AUTHORIZATION: basic $(echo -n :hqwpgbusyba2acyaec7uxxcbxlucgr4p6c6nvrvdzrylfocwkpg5 | base64)"
+					`,
+			want: []string{"hqwpgbusyba2acyaec7uxxcbxlucgr4p6c6nvrvdzrylfocwkpg5contoso"},
+		},
+		{
+			name: "84 character PAT",
+			input: `
+					variables:
+					  AZDO_ORG_URL: https://fabrikam.visualstudio.com/
+					  AZDO_PAT: 4fWqg1ZsT2aPbR7mYcXd0NeLh9KjVu3Io8BnCx6Ez5Ay1GtHs2DkJQQJ99BGACAAAAAAAAAAAAASAZDO3b1x
+					`,
+			want: []string{"4fWqg1ZsT2aPbR7mYcXd0NeLh9KjVu3Io8BnCx6Ez5Ay1GtHs2DkJQQJ99BGACAAAAAAAAAAAAASAZDO3b1xfabrikam"},
+		},
 		{
 			name: "invalid pattern",
 			input: `
@@ -93,3 +115,44 @@ func TestAzureDevopsPersonalAccessToken_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureDevopsPersonalAccessToken_Verify(t *testing.T) {
+	const pat = "hqwpgbusyba2acyaec7uxxcbxlucgr4p6c6nvrvdzrylfocwkpg5"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, _ := r.BasicAuth(); password != pat {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/contoso/_apis/projects":
+			fmt.Fprint(w, `{"count":2,"value":[{"name":"Web","lastUpdateTime":"2024-03-01T10:00:00Z"},{"name":"Infra","lastUpdateTime":"2024-02-01T10:00:00Z"}]}`)
+		case "/contoso/_apis/connectionData":
+			fmt.Fprint(w, `{"authenticatedUser":{"providerDisplayName":"Build Agent"}}`)
+		case "/contoso/_apis/git/repositories", "/contoso/Web/_apis/build/definitions":
+			fmt.Fprint(w, `{"count":0,"value":[]}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), baseURL: server.URL}
+	input := "https://dev.azure.com/contoso/Web/_git/frontend " + pat
+
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, map[string]string{
+		"organization": "contoso",
+		"projects":     "Web, Infra",
+		"user":         "Build Agent",
+		"scopes":       "vso.project, vso.code, vso.build",
+	}, results[0].ExtraData)
+
+	results, err = d.FromData(context.Background(), true, []byte(strings.Replace(input, "hqwp", "aqwp", 1)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
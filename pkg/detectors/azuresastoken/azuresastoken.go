@@ -81,6 +81,9 @@ UrlLoop:
 				Raw:          []byte(url),
 				RawV2:        []byte(url + key),
 			}
+			if expiresAt, ok := detectors.SignedURLExpiry(key); ok {
+				s1.SetExpiresAt(expiresAt)
+			}
 
 			if verify {
 				if invalidStorageAccounts.Exists(storageAccount) {
@@ -17,6 +17,9 @@ import (
 type Scanner struct {
 	detectors.DefaultMultiPartCredentialProvider
 	client *http.Client
+	// bosURL 和 iamURL 仅用于测试, 默认为北京区域的 BOS 和 IAM 地址
+	bosURL string
+	iamURL string
 }
 
 type Credentials struct {
@@ -34,9 +37,17 @@ type BaiduResp struct {
 
 const BaiduURL = "http://bcc.bj.baidubce.com/v2/zone"
 
+// AccessKeyRemediation is the remediation of a leaked Baidu AI Cloud access key, shared with the baidu2 detector.
+const AccessKeyRemediation = "Disable and delete the access key in the Baidu AI Cloud console " +
+	"(https://console.bce.baidu.com/iam/#/iam/accesslist), create a new one for the services that used it, and " +
+	"review the audit logs for requests made with the old key."
+
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.RemediationProvider = (*Scanner)(nil)
+	_ detectors.PatternProvider     = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -47,44 +58,59 @@ var (
 
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
+// 关键词匹配不区分大小写, 每个关键词只需声明一种写法
 func (s Scanner) Keywords() []string {
 	return []string{
 		"ak",
 		"ALTAK",
-		"AccessKey",
 		// AK（Access Key ID）
-		"AccessKeyId",
-		"access_key_id",
-		"AccessKeyID",
-		"ACCESS_KEY_ID",
-		"Access_Key_Id",
-		"access-key-id",
+		"AccessKey",
+		"access_key",
+		"access-key",
 		"secret_id", // 腾讯云使用 SecretId 作为 AK
 		"SecretId",
-		"AWS_ACCESS_KEY_ID",
 		"AWS_ACCESS_KEY",
-		"ACCESS_KEY",
 		// SK（Secret Access Key）
 		"AccessKeySecret",
 		"access_key_secret",
-		"AccessKeySECRET",
-		"ACCESS_KEY_SECRET",
-		"Access_Key_Secret",
 		"access-key-secret",
 		"secret_key", // 华为云、MinIO 等通用
 		"SecretKey",
-		"secretkey",
-		"SECRET_KEY",
 		"AWS_SECRET_ACCESS_KEY",
 		"AWS_SECRET_KEY",
 		"AWS_SESSION_TOKEN", // 如果包含临时凭证的话
 	}
 }
 
+// KeywordOptions 让 ak 只作为完整单词匹配, 避免 break、speak 等单词误触发;
+// ALTAK 是百度 AK 的固定前缀, 只匹配大写
+func (s Scanner) KeywordOptions() map[string]detectors.KeywordOption {
+	return map[string]detectors.KeywordOption{
+		"ak":    detectors.KeywordWholeWord,
+		"ALTAK": detectors.KeywordCaseSensitive,
+	}
+}
+
 func (s Scanner) Description() string {
 	return "baidu cloud ak/sk"
 }
 
+// Patterns implements detectors.PatternProvider.
+func (s Scanner) Patterns() map[string]detectors.Pattern {
+	return map[string]detectors.Pattern{"keyPat": keyPat, "idPat": idPat}
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return AccessKeyRemediation
+}
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -92,6 +118,20 @@ func (s Scanner) getClient() *http.Client {
 	return defaultClient
 }
 
+func (s Scanner) getBOSURL() string {
+	if s.bosURL != "" {
+		return s.bosURL
+	}
+	return defaultBOSURL
+}
+
+func (s Scanner) getIAMURL() string {
+	if s.iamURL != "" {
+		return s.iamURL
+	}
+	return defaultIAMURL
+}
+
 // FromData will find and optionally verify baidu secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -116,6 +156,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				isVerified, verificationErr := verifyBaidu(ctx, client, resIdMatch, resMatch)
 				s1.Verified = isVerified
 				s1.SetVerificationError(verificationErr, resMatch)
+				if isVerified {
+					s1.ExtraData = enrichBaidu(ctx, client, s.getBOSURL(), s.getIAMURL(), resIdMatch, resMatch)
+				}
 			}
 
 			results = append(results, s1)
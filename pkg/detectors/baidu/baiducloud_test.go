@@ -1,8 +1,12 @@
 package baidu
 
 import (
+	"context"
 	"fmt"
 	"github.com/baidubce/bce-sdk-go/services/bcc"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -36,3 +40,31 @@ func TestYuQue_Pattern(t *testing.T) {
 		fmt.Println("list zone success: ", result)
 	}
 }
+
+func TestEnrichBaidu(t *testing.T) {
+	const ak, sk = "ALTAKx8dJfRkq2Ls9Wm4Pn7Vc", "6c0f2a9e8b7d4c1f9a3e5b2d8c7f1a4e"
+
+	newServer := func(status int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := bceAuthorization(ak, sk, r.Host, r.URL.EscapedPath(), r.Header.Get("x-bce-date"))
+			if r.Header.Get("Authorization") != want {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+	}
+	bos := newServer(http.StatusOK, `{"owner":{"id":"7d3ce7fa6b8b4b8b9b7e5a5f0e3c2d1a","displayName":"PASSPORT:1234567"},"buckets":[{"name":"a"},{"name":"b"}]}`)
+	defer bos.Close()
+	iam := newServer(http.StatusForbidden, `{"code":"AccessDenied"}`)
+	defer iam.Close()
+
+	got := enrichBaidu(context.Background(), bos.Client(), bos.URL, iam.URL, ak, sk)
+	assert.Equal(t, map[string]string{
+		"account_id":       "7d3ce7fa6b8b4b8b9b7e5a5f0e3c2d1a",
+		"account_name":     "PASSPORT:1234567",
+		"bos_bucket_count": "2",
+		"permissions":      "bcc:read,bos:list",
+	}, got)
+}
@@ -0,0 +1,121 @@
+package baidu
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBOSURL = "https://bj.bcebos.com"
+	defaultIAMURL = "https://iam.bj.baidubce.com"
+)
+
+type bosListBucketsResponse struct {
+	Owner struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	} `json:"owner"`
+	Buckets []struct {
+		Name string `json:"name"`
+	} `json:"buckets"`
+}
+
+type iamListUsersResponse struct {
+	Users []struct {
+		Name string `json:"name"`
+	} `json:"users"`
+}
+
+// enrichBaidu 在验证通过后调用只读接口补充账号信息: BOS 列举存储桶返回账号 ID 和存储桶数量,
+// IAM 列举子用户说明密钥能否管理账号权限. 接口无权限或调用失败只影响补充信息, 不影响验证结果
+func enrichBaidu(ctx context.Context, client *http.Client, bosURL, iamURL, ak, sk string) map[string]string {
+	extraData := map[string]string{}
+	// 验证使用的 BCC 查询可用区接口已经调用成功
+	permissions := []string{"bcc:read"}
+
+	var buckets bosListBucketsResponse
+	if ok, err := getJSON(ctx, client, bosURL+"/", ak, sk, &buckets); err == nil && ok {
+		if buckets.Owner.ID != "" {
+			extraData["account_id"] = buckets.Owner.ID
+		}
+		if buckets.Owner.DisplayName != "" {
+			extraData["account_name"] = buckets.Owner.DisplayName
+		}
+		extraData["bos_bucket_count"] = strconv.Itoa(len(buckets.Buckets))
+		permissions = append(permissions, "bos:list")
+	}
+
+	var users iamListUsersResponse
+	if ok, err := getJSON(ctx, client, iamURL+"/v1/user", ak, sk, &users); err == nil && ok {
+		extraData["iam_user_count"] = strconv.Itoa(len(users.Users))
+		permissions = append(permissions, "iam:read")
+	}
+
+	extraData["permissions"] = strings.Join(permissions, ",")
+	return extraData
+}
+
+// getJSON 发送签名的 GET 请求并解析响应, 没有权限时返回 false
+func getJSON(ctx context.Context, client *http.Client, rawURL, ak, sk string, v any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, err
+	}
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05Z")
+	req.Header.Set("x-bce-date", timestamp)
+	req.Header.Set("Authorization", bceAuthorization(ak, sk, req.URL.Host, req.URL.EscapedPath(), timestamp))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, json.NewDecoder(res.Body).Decode(v)
+	case http.StatusForbidden:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+// bceAuthorization 计算 GET 请求的 bce-auth-v1 签名, 签名头只包含 host 和 x-bce-date.
+// docs: https://cloud.baidu.com/doc/Reference/s/njwvz1yfu
+func bceAuthorization(ak, sk, host, path, timestamp string) string {
+	const signedHeaders = "host;x-bce-date"
+	authStringPrefix := fmt.Sprintf("bce-auth-v1/%s/%s/1800", ak, timestamp)
+	signingKey := hex.EncodeToString(hmacSHA256([]byte(sk), authStringPrefix))
+
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := http.MethodGet + "\n" +
+		path + "\n" +
+		"\n" +
+		"host:" + url.QueryEscape(host) + "\n" +
+		"x-bce-date:" + url.QueryEscape(timestamp)
+	signature := hex.EncodeToString(hmacSHA256([]byte(signingKey), canonicalRequest))
+
+	return authStringPrefix + "/" + signedHeaders + "/" + signature
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
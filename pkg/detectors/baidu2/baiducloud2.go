@@ -4,6 +4,7 @@ import (
 	"github.com/baidubce/bce-sdk-go/services/bcc"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/baidu"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"net/http"
 	"strings"
@@ -36,7 +37,10 @@ const BaiduURL = "http://bcc.bj.baidubce.com/v2/zone"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.RemediationProvider = (*Scanner)(nil)
+	_ detectors.PatternProvider     = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -47,44 +51,59 @@ var (
 
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
+// 关键词匹配不区分大小写, 每个关键词只需声明一种写法
 func (s Scanner) Keywords() []string {
 	return []string{
 		"ak",
 		"ALTAK",
-		"AccessKey",
 		// AK（Access Key ID）
-		"AccessKeyId",
-		"access_key_id",
-		"AccessKeyID",
-		"ACCESS_KEY_ID",
-		"Access_Key_Id",
-		"access-key-id",
+		"AccessKey",
+		"access_key",
+		"access-key",
 		"secret_id", // 腾讯云使用 SecretId 作为 AK
 		"SecretId",
-		"AWS_ACCESS_KEY_ID",
 		"AWS_ACCESS_KEY",
-		"ACCESS_KEY",
 		// SK（Secret Access Key）
 		"AccessKeySecret",
 		"access_key_secret",
-		"AccessKeySECRET",
-		"ACCESS_KEY_SECRET",
-		"Access_Key_Secret",
 		"access-key-secret",
 		"secret_key", // 华为云、MinIO 等通用
 		"SecretKey",
-		"secretkey",
-		"SECRET_KEY",
 		"AWS_SECRET_ACCESS_KEY",
 		"AWS_SECRET_KEY",
 		"AWS_SESSION_TOKEN", // 如果包含临时凭证的话
 	}
 }
 
+// KeywordOptions 让 ak 只作为完整单词匹配, 避免 break、speak 等单词误触发;
+// ALTAK 是百度 AK 的固定前缀, 只匹配大写
+func (s Scanner) KeywordOptions() map[string]detectors.KeywordOption {
+	return map[string]detectors.KeywordOption{
+		"ak":    detectors.KeywordWholeWord,
+		"ALTAK": detectors.KeywordCaseSensitive,
+	}
+}
+
 func (s Scanner) Description() string {
 	return "baidu cloud ak/sk"
 }
 
+// Patterns implements detectors.PatternProvider.
+func (s Scanner) Patterns() map[string]detectors.Pattern {
+	return map[string]detectors.Pattern{"keyPat": keyPat, "idPat": idPat}
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return baidu.AccessKeyRemediation
+}
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -30,7 +30,9 @@ const BaiduApiKeyURL = "https://qianfan.baidubce.com/v2/app/conversation"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.RemediationProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -47,6 +49,18 @@ func (s Scanner) Description() string {
 	return "baidu api key(qianfan)"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagAI, detectors.TagChinaCloud} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return "Delete the API key in the Baidu AI Cloud console (https://console.bce.baidu.com/iam/#/iam/apikey/list) " +
+		"and create a new one limited to the Qianfan apps and models it needs."
+}
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -61,7 +61,9 @@ const BaiLianURL = "https://dashscope.aliyuncs.com/compatible-mode/v1/chat/compl
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.ContextHintProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -75,10 +77,21 @@ func (s Scanner) Keywords() []string {
 	return []string{"sk-", "DASHSCOPE","sk-sp-"}
 }
 
+// ContextHints implements detectors.ContextHintProvider, sk- 加 32 位十六进制的格式和 DeepSeek 相同
+func (s Scanner) ContextHints() []string {
+	return []string{"dashscope", "bailian", "qwen", "tongyi", "百炼", "通义"}
+}
+
 func (s Scanner) Description() string {
 	return "aliyun bailian sk token"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagAI, detectors.TagChinaCloud} }
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -0,0 +1,151 @@
+package bitbucketaccesstoken
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitbucketapppassword"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// apiURL 用于测试时替换 Bitbucket API 地址
+	apiURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// workspace, project 和 repository access token 以 ATCTT3xFfGN0 开头, 末尾是 = 和 8 位十六进制校验值
+	keyPat = regexp.MustCompile(`\b(ATCTT3xFfGN0[A-Za-z0-9_-]{100,250}=[A-Fa-f0-9]{8})\b`)
+	// token 所属的 workspace: bitbucket.org/<workspace>/<repo> 形式的仓库地址, 或 BITBUCKET_WORKSPACE 这样的变量
+	repoURLPat   = regexp.MustCompile(`bitbucket\.org[/:]([A-Za-z0-9_.-]{1,62})/[A-Za-z0-9_.-]+`)
+	workspacePat = regexp.MustCompile(`(?i)\bbitbucket[_.-]?workspace["']?\s*[:=]\s*["']?([A-Za-z0-9_.-]{1,62})`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"ATCTT3xFfGN0"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getAPIURL() string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return bitbucketapppassword.APIURL
+}
+
+// FromData will find and optionally verify Bitbucket workspace, project and repository access tokens in a given set
+// of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueTokens := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueTokens[match[1]] = struct{}{}
+	}
+	if len(uniqueTokens) == 0 {
+		return nil, nil
+	}
+
+	uniqueWorkspaces := make(map[string]struct{})
+	for _, pat := range []*regexp.Regexp{workspacePat, repoURLPat} {
+		for _, match := range pat.FindAllStringSubmatch(dataStr, -1) {
+			uniqueWorkspaces[strings.ToLower(match[1])] = struct{}{}
+		}
+	}
+	workspaces := detectors.SortedKeys(uniqueWorkspaces)
+
+	for _, token := range detectors.SortedKeys(uniqueTokens) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_BitbucketAccessToken,
+			Raw:          []byte(token),
+			Redacted:     token[:16] + "...",
+		}
+
+		if verify {
+			isVerified, extraData, verificationErr := s.verify(ctx, token, workspaces)
+			s1.Verified = isVerified
+			s1.ExtraData = extraData
+			s1.SetVerificationError(verificationErr, token)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// verify checks the token against the profile endpoint. Access tokens often lack the scope to read the profile of
+// their bot account; their workspace is then taken from the repositories of the workspaces named in the data that
+// the token can list.
+func (s Scanner) verify(ctx context.Context, token string, workspaces []string) (bool, map[string]string, error) {
+	client, apiURL := s.getClient(), s.getAPIURL()
+	authorization := "Bearer " + token
+
+	verified, account, err := bitbucketapppassword.VerifyAuthorization(ctx, client, apiURL, authorization)
+	if err != nil || !verified {
+		return verified, nil, err
+	}
+	if len(account.Workspaces) == 0 {
+		for _, workspace := range workspaces {
+			if canListRepositories(ctx, client, apiURL, authorization, workspace) {
+				account.Workspaces = append(account.Workspaces, workspace)
+			}
+		}
+	}
+	return true, account.ExtraData(), nil
+}
+
+// canListRepositories reports whether the token can list the repositories of a workspace.
+func canListRepositories(ctx context.Context, client *http.Client, apiURL, authorization, workspace string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/2.0/repositories/"+url.PathEscape(workspace)+"?pagelen=1", http.NoBody)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", authorization)
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	return res.StatusCode == http.StatusOK
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_BitbucketAccessToken
+}
+
+func (s Scanner) Description() string {
+	return "Bitbucket workspace, project and repository access tokens authenticate to the Bitbucket API as the bot account of the token, with the scopes it was created with."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagSupplyChain} }
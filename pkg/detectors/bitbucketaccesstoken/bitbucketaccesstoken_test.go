@@ -0,0 +1,105 @@
+package bitbucketaccesstoken
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+var (
+	validToken   = "ATCTT3xFfGN0" + strings.Repeat("Xq7Lm2Vb9_Kd4-Rt", 11) + "=5E0C7A1F"
+	invalidToken = "ATCTT3xFfGN0" + strings.Repeat("Aa0", 20) + "=5E0C7A1F"
+)
+
+func TestBitbucketAccessToken_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "pipeline variable",
+			input: `git clone https://x-token-auth:` + validToken + `@bitbucket.org/acme/api.git
+export BITBUCKET_TOKEN="` + validToken + `"`,
+			want: []string{validToken},
+		},
+		{
+			name:  "too short",
+			input: `BITBUCKET_TOKEN=` + invalidToken,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, string(r.Raw))
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestBitbucketAccessToken_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "pullrequest, repository:write")
+		switch r.URL.Path {
+		case "/2.0/user":
+			// The token lacks the account scope.
+			w.WriteHeader(http.StatusForbidden)
+		case "/2.0/repositories/acme":
+			fmt.Fprint(w, `{"values":[{"full_name":"acme/api"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), apiURL: server.URL}
+
+	results, err := d.FromData(context.Background(), true, []byte(`BITBUCKET_WORKSPACE: other
+git remote add origin git@bitbucket.org:acme/api.git
+BITBUCKET_TOKEN=`+validToken))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, map[string]string{
+		"scopes":     "pullrequest, repository:write",
+		"workspaces": "acme",
+	}, results[0].ExtraData)
+
+	results, err = d.FromData(context.Background(), true, []byte("BITBUCKET_TOKEN="+strings.Replace(validToken, "Xq7", "Yq7", 1)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
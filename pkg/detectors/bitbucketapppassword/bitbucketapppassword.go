@@ -3,10 +3,12 @@ package bitbucketapppassword
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -34,7 +36,8 @@ func (s Scanner) Description() string {
 	return "Bitbucket is a Git repository hosting service by Atlassian. Bitbucket App Passwords are used to authenticate to the Bitbucket API."
 }
 
-const bitbucketAPIUserURL = "https://api.bitbucket.org/2.0/user"
+// APIURL is the base URL of the Bitbucket Cloud API.
+const APIURL = "https://api.bitbucket.org"
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -88,11 +91,17 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 			if client == nil {
 				client = defaultClient
 			}
-			var vErr error
-			result.Verified, vErr = verifyCredential(ctx, client, username, password)
+			var (
+				account *Account
+				vErr    error
+			)
+			result.Verified, account, vErr = verifyCredential(ctx, client, username, password)
 			if vErr != nil {
 				result.SetVerificationError(vErr, username, password)
 			}
+			if account != nil {
+				result.ExtraData = account.ExtraData()
+			}
 		}
 		results = append(results, result)
 	}
@@ -101,18 +110,48 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) ([]dete
 }
 
 // verifyCredential checks if a given username and app password are valid by making a request to the Bitbucket API.
-func verifyCredential(ctx context.Context, client *http.Client, username, password string) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bitbucketAPIUserURL, nil)
-	if err != nil {
-		return false, err
-	}
-	req.Header.Add("Accept", "application/json")
+func verifyCredential(ctx context.Context, client *http.Client, username, password string) (bool, *Account, error) {
 	auth := base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%s:%s", username, password))
-	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", auth))
+	return VerifyAuthorization(ctx, client, APIURL, "Basic "+auth)
+}
+
+// Account describes what a Bitbucket credential grants access to.
+type Account struct {
+	// Username and DisplayName are those of the account the credential authenticates as. For access tokens, this is
+	// the bot account of the token.
+	Username    string
+	DisplayName string
+	// Scopes are the scopes granted to the credential.
+	Scopes []string
+	// Workspaces are the slugs of the workspaces the account has access to.
+	Workspaces []string
+}
+
+// ExtraData returns the account as result extra data.
+func (a *Account) ExtraData() map[string]string {
+	extraData := make(map[string]string)
+	if a.Username != "" {
+		extraData["username"] = a.Username
+	}
+	if a.DisplayName != "" {
+		extraData["display_name"] = a.DisplayName
+	}
+	if len(a.Scopes) > 0 {
+		extraData["scopes"] = strings.Join(a.Scopes, ", ")
+	}
+	if len(a.Workspaces) > 0 {
+		extraData["workspaces"] = strings.Join(a.Workspaces, ", ")
+	}
+	return extraData
+}
 
-	res, err := client.Do(req)
+// VerifyAuthorization checks if the Authorization header value of an app password or access token is valid by
+// requesting the profile of its account from the Bitbucket API at apiURL, and returns the account if it is. The
+// account is also returned for credentials that lack the scope to read the profile, with only the scopes known.
+func VerifyAuthorization(ctx context.Context, client *http.Client, apiURL, authorization string) (bool, *Account, error) {
+	res, err := get(ctx, client, apiURL+"/2.0/user", authorization)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	defer func() {
 		_, _ = io.Copy(io.Discard, res.Body)
@@ -122,10 +161,76 @@ func verifyCredential(ctx context.Context, client *http.Client, username, passwo
 	switch res.StatusCode {
 	case http.StatusOK, http.StatusForbidden:
 		// A 403 can indicate a valid credential with insufficient scope, which is still a finding.
-		return true, nil
+		account := &Account{Scopes: scopes(res.Header.Get("X-OAuth-Scopes"))}
+		if res.StatusCode == http.StatusOK {
+			var user struct {
+				Username    string `json:"username"`
+				DisplayName string `json:"display_name"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&user); err == nil {
+				account.Username, account.DisplayName = user.Username, user.DisplayName
+			}
+			account.Workspaces = workspaces(ctx, client, apiURL, authorization)
+		}
+		return true, account, nil
 	case http.StatusUnauthorized:
-		return false, nil
+		return false, nil, nil
 	default:
-		return false, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+		return false, nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+}
+
+// workspaces returns the workspaces the account of a verified credential has access to, or nil if the credential
+// cannot list them.
+func workspaces(ctx context.Context, client *http.Client, apiURL, authorization string) []string {
+	res, err := get(ctx, client, apiURL+"/2.0/user/permissions/workspaces?pagelen=100", authorization)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var page struct {
+		Values []struct {
+			Workspace struct {
+				Slug string `json:"slug"`
+			} `json:"workspace"`
+		} `json:"values"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return nil
+	}
+	var slugs []string
+	for _, v := range page.Values {
+		if v.Workspace.Slug != "" {
+			slugs = append(slugs, v.Workspace.Slug)
+		}
+	}
+	return slugs
+}
+
+func get(ctx context.Context, client *http.Client, url, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", authorization)
+	return client.Do(req)
+}
+
+// scopes splits the comma separated scopes of the X-OAuth-Scopes header.
+func scopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
 	}
+	return scopes
 }
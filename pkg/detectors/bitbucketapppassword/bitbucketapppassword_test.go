@@ -2,9 +2,13 @@ package bitbucketapppassword
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -106,3 +110,42 @@ func TestBitbucketAppPassword_FromData(t *testing.T) {
 		})
 	}
 }
+
+func TestBitbucketAppPassword_VerifyAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != "myuser" || password != "ATBB123abcDEF456ghiJKL789mnoPQR" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "account, repository")
+		switch r.URL.Path {
+		case "/2.0/user":
+			fmt.Fprint(w, `{"username":"myuser","display_name":"My User"}`)
+		case "/2.0/user/permissions/workspaces":
+			fmt.Fprint(w, `{"values":[{"permission":"owner","workspace":{"slug":"acme"}},{"permission":"member","workspace":{"slug":"acme-labs"}}]}`)
+		}
+	}))
+	defer server.Close()
+
+	basic := func(user, password string) string {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.SetBasicAuth(user, password)
+		return req.Header.Get("Authorization")
+	}
+
+	verified, account, err := VerifyAuthorization(context.Background(), server.Client(), server.URL, basic("myuser", "ATBB123abcDEF456ghiJKL789mnoPQR"))
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{
+		"username":     "myuser",
+		"display_name": "My User",
+		"scopes":       "account, repository",
+		"workspaces":   "acme, acme-labs",
+	}, account.ExtraData())
+
+	verified, account, err = VerifyAuthorization(context.Background(), server.Client(), server.URL, basic("myuser", "ATBBzyxwvUT987srqPON654mlkJIH"))
+	require.NoError(t, err)
+	assert.False(t, verified)
+	assert.Nil(t, account)
+}
@@ -4,22 +4,139 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/bitcoin"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 )
 
 type Scanner struct {
 	client *http.Client
+	// apiURL 是 Esplora 兼容的区块链 API 地址, 为空时使用各网络默认的 API
+	apiURL string
+	// networks 是除主网外还要检测的网络, 为空时只检测主网私钥
+	networks []network
+	// wifPat 匹配主网和 networks 的私钥, 为空时使用 mainnetWIFPat
+	wifPat *regexp.Regexp
 }
 
+// Network 是可以选择检测的使用 WIF 格式私钥的网络
+type Network string
+
+const (
+	NetworkTestnet  Network = "testnet"
+	NetworkLitecoin Network = "litecoin"
+	NetworkDogecoin Network = "dogecoin"
+)
+
+// ParseNetwork 将网络名称转换为 Network, 不支持的网络返回错误
+func ParseNetwork(name string) (Network, error) {
+	n := Network(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := optionalNetworks[n]; !ok {
+		return "", fmt.Errorf("%q is not a supported WIF network, expected one of %s, %s or %s", name, NetworkTestnet, NetworkLitecoin, NetworkDogecoin)
+	}
+	return n, nil
+}
+
+// New 创建 Scanner, 默认只检测主网私钥
+func New(opts ...func(*Scanner)) *Scanner {
+	scanner := &Scanner{}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+
+	return scanner
+}
+
+// WithNetworks 在主网之外还检测这些网络的私钥, 结果的 ExtraData 中 network 为私钥所属的网络.
+// 测试网和山寨币的私钥默认不检测: 它们大多是没有价值的测试数据, 且 Litecoin 与 Dogecoin 未压缩私钥的首字符相同.
+// 不支持的网络会被忽略, 用户输入的网络名称应先用 ParseNetwork 校验
+func WithNetworks(networks ...Network) func(*Scanner) {
+	return func(s *Scanner) {
+		s.networks = nil
+		uncompressed, compressed := mainnet.uncompressed, mainnet.compressed
+		for _, name := range networks {
+			n, ok := optionalNetworks[name]
+			if !ok {
+				continue
+			}
+			s.networks = append(s.networks, n)
+			uncompressed += n.uncompressed
+			compressed += n.compressed
+		}
+		s.wifPat = regexp.MustCompile(fmt.Sprintf(`\b([%s][1-9A-HJ-NP-Za-km-z]{50}|[%s][1-9A-HJ-NP-Za-km-z]{51})\b`, uncompressed, compressed))
+	}
+}
+
+// network 是一个使用 Bitcoin WIF 格式私钥和地址格式的网络
+type network struct {
+	name string
+	// uncompressed 和 compressed 是未压缩 (51 位) 和压缩 (52 位) 私钥可能的首字符
+	uncompressed, compressed string
+	wifVersion               byte
+	p2pkhVersion             byte
+	// hrp 是 segwit 地址的前缀, 为空时网络不支持 segwit
+	hrp string
+	// apiURL 是 Esplora 兼容的 API 地址, 为空时不验证
+	apiURL string
+	// keywords 是启用网络时额外的关键词
+	keywords []string
+}
+
+var (
+	mainnet = network{
+		name:         "mainnet",
+		uncompressed: "5",
+		compressed:   "KL",
+		wifVersion:   bitcoin.MainnetWIFVersion,
+		p2pkhVersion: bitcoin.MainnetP2PKHVersion,
+		hrp:          bitcoin.MainnetHRP,
+		apiURL:       "https://mempool.space/api",
+	}
+
+	optionalNetworks = map[Network]network{
+		NetworkTestnet: {
+			name:         string(NetworkTestnet),
+			uncompressed: "9",
+			compressed:   "c",
+			wifVersion:   0xef,
+			p2pkhVersion: 0x6f,
+			hrp:          "tb",
+			apiURL:       "https://mempool.space/testnet/api",
+			keywords:     []string{"testnet", "regtest"},
+		},
+		NetworkLitecoin: {
+			name:         string(NetworkLitecoin),
+			uncompressed: "6",
+			compressed:   "T",
+			wifVersion:   0xb0,
+			p2pkhVersion: 0x30,
+			hrp:          "ltc",
+			apiURL:       "https://litecoinspace.org/api",
+			keywords:     []string{"litecoin", "ltc_"},
+		},
+		// Dogecoin 没有 segwit, 也没有公共的 Esplora API, 只报告私钥和地址
+		NetworkDogecoin: {
+			name:         string(NetworkDogecoin),
+			uncompressed: "6",
+			compressed:   "Q",
+			wifVersion:   0x9e,
+			p2pkhVersion: 0x1e,
+			keywords:     []string{"dogecoin", "doge_"},
+		},
+	}
+)
+
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -33,26 +150,39 @@ var (
 
 // Keywords are used for efficiently pre-filtering chunks.
 func (s Scanner) Keywords() []string {
-	return []string{
-		// 常见的私钥相关关键词
+	keywords := []string{
+		// 常见的私钥相关关键词, 关键词匹配不区分大小写
 		"wif",
-		"WIF",
 		"private_key",
 		"privatekey",
 		"private-key",
-		"PRIVATE_KEY",
 		"btc_private",
 		"bitcoin_private",
 		"wallet_import",
 		"secret_key",
 		"secretkey",
 	}
+	for _, n := range s.networks {
+		keywords = append(keywords, n.keywords...)
+	}
+	return keywords
 }
 
 func (s Scanner) Description() string {
 	return "Bitcoin WIF (Wallet Import Format) is a standard format for encoding Bitcoin private keys. These keys provide full control over the associated Bitcoin address and can be used to transfer all funds."
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("Bitcoin wallet")
+}
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -60,6 +190,40 @@ func (s Scanner) getClient() *http.Client {
 	return defaultClient
 }
 
+func (s Scanner) getAPIURL(n network) string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return n.apiURL
+}
+
+func (s Scanner) getWIFPat() *regexp.Regexp {
+	if s.wifPat != nil {
+		return s.wifPat
+	}
+	return mainnetWIFPat
+}
+
+// findNetwork 返回私钥所属的已启用网络. 主网私钥只检查格式; 其他网络的私钥需要解码, 按版本字节区分首字符相同的网络
+func (s Scanner) findNetwork(wif string) (network, bool) {
+	if isValidWIF(wif) {
+		return mainnet, true
+	}
+	if len(s.networks) == 0 {
+		return network{}, false
+	}
+	decoded, err := bitcoin.DecodeWIF(wif)
+	if err != nil {
+		return network{}, false
+	}
+	for _, n := range s.networks {
+		if decoded.Version == n.wifVersion {
+			return n, true
+		}
+	}
+	return network{}, false
+}
+
 // isValidWIF 验证 WIF 格式是否正确
 func isValidWIF(wif string) bool {
 	// 主网未压缩: 以 5 开头，长度 51
@@ -83,14 +247,16 @@ type addressResponse struct {
 	MempoolStats struct {
 		FundedTxoSum int64 `json:"funded_txo_sum"`
 		SpentTxoSum  int64 `json:"spent_txo_sum"`
+		TxCount      int64 `json:"tx_count"`
 	} `json:"mempool_stats"`
 }
 
-// FromData will find and optionally verify Bitcoin WIF private keys in a given set of bytes.
+// FromData will find and optionally verify Bitcoin WIF private keys in a given set of bytes. A key is verified when
+// one of its addresses holds a balance or has transactions.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := mainnetWIFPat.FindAllStringSubmatch(dataStr, -1)
+	matches := s.getWIFPat().FindAllStringSubmatch(dataStr, -1)
 
 	for _, match := range matches {
 		if len(match) < 2 {
@@ -100,7 +266,8 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		wif := strings.TrimSpace(match[1])
 
 		// 验证 WIF 格式
-		if !isValidWIF(wif) {
+		n, ok := s.findNetwork(wif)
+		if !ok {
 			continue
 		}
 
@@ -109,11 +276,12 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			DetectorType: detector_typepb.DetectorType_BitcoinWIF,
 			Raw:          []byte(wif),
 			Redacted:     wif[:8] + "..." + wif[len(wif)-4:], // 只显示前8位和后4位
+			ExtraData:    map[string]string{"network": n.name},
 		}
 
 		if verify {
 			client := s.getClient()
-			isVerified, extraData, verificationErr := verifyBitcoinWIF(ctx, client, wif)
+			isVerified, extraData, verificationErr := verifyBitcoinWIF(ctx, client, s.getAPIURL(n), n, wif)
 			s1.Verified = isVerified
 			s1.ExtraData = extraData
 			s1.SetVerificationError(verificationErr, wif)
@@ -125,83 +293,128 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-// verifyBitcoinWIF 验证 Bitcoin WIF 私钥
-// 通过将 WIF 转换为地址，然后查询区块链 API 来验证
-func verifyBitcoinWIF(ctx context.Context, client *http.Client, wif string) (bool, map[string]string, error) {
-	// 由于直接验证 WIF 需要加密库来派生地址
-	// 这里我们只验证格式是否正确，并标记为潜在有效
-	// 在实际部署中，可以集成 btcd 或其他库来派生地址并查询余额
+// verifyBitcoinWIF 派生私钥对应的地址, 并查询地址的余额和交易历史.
+// 格式正确的私钥随处可以生成, 只有控制着有余额或有过交易的地址时才算验证通过
+func verifyBitcoinWIF(ctx context.Context, client *http.Client, apiURL string, n network, wif string) (bool, map[string]string, error) {
+	extraData := map[string]string{"network": n.name}
 
-	extraData := make(map[string]string)
+	decoded, err := bitcoin.DecodeWIF(wif)
+	if err != nil {
+		return false, extraData, err
+	}
+	pub, err := decoded.PublicKey()
+	if err != nil {
+		return false, extraData, err
+	}
 
-	// 根据 WIF 格式判断类型
-	if len(wif) == 51 && wif[0] == '5' {
-		extraData["format"] = "uncompressed"
-		extraData["network"] = "mainnet"
-	} else if len(wif) == 52 && (wif[0] == 'K' || wif[0] == 'L') {
+	// 未压缩私钥只有 P2PKH 地址, 压缩私钥还可以用于 P2WPKH (bc1q...) 地址
+	addresses := []string{bitcoin.P2PKHAddress(pub, n.p2pkhVersion)}
+	extraData["address"] = addresses[0]
+	extraData["format"] = "uncompressed"
+	if decoded.Compressed {
 		extraData["format"] = "compressed"
-		extraData["network"] = "mainnet"
+	}
+	if decoded.Compressed && n.hrp != "" {
+		segwit, err := bitcoin.P2WPKHAddress(pub, n.hrp)
+		if err != nil {
+			return false, extraData, err
+		}
+		addresses = append(addresses, segwit)
+		extraData["p2wpkh_address"] = segwit
+	}
+	if apiURL == "" {
+		return false, extraData, nil
 	}
 
-	// 注意：真正的验证需要：
-	// 1. 解码 WIF 获取私钥
-	// 2. 从私钥派生公钥
-	// 3. 从公钥派生地址
-	// 4. 查询区块链 API 检查地址是否有交易历史或余额
-	//
-	// 由于这需要额外的加密库依赖，这里我们只做格式验证
-	// 如果格式正确，我们认为这是一个有效的 WIF 格式私钥
+	var total addressStats
+	var used []string
+	for _, address := range addresses {
+		stats, err := fetchAddressStats(ctx, client, apiURL, address)
+		if err != nil {
+			return false, extraData, err
+		}
+		if stats.TxCount > 0 || stats.MempoolTxCount > 0 {
+			used = append(used, address)
+		}
+		total.add(stats)
+	}
+
+	confirmedBalance := total.FundedTxoSum - total.SpentTxoSum
+	unconfirmedBalance := total.MempoolFundedTxoSum - total.MempoolSpentTxoSum
+	totalBalance := confirmedBalance + unconfirmedBalance
+	extraData["confirmed_balance_sat"] = fmt.Sprintf("%d", confirmedBalance)
+	extraData["unconfirmed_balance_sat"] = fmt.Sprintf("%d", unconfirmedBalance)
+	extraData["total_balance_sat"] = fmt.Sprintf("%d", totalBalance)
+	extraData["tx_count"] = fmt.Sprintf("%d", total.TxCount)
+	extraData["received_sat"] = fmt.Sprintf("%d", total.FundedTxoSum)
+	if len(used) > 0 {
+		extraData["used_addresses"] = strings.Join(used, ",")
+	}
 
-	// 格式验证通过即认为是有效的 WIF
+	// 余额为 0 的地址也可能是刚被转空的生产私钥, 通过交易历史区分从未使用和已使用
+	switch {
+	case totalBalance > 0:
+		extraData["history_status"] = "funded"
+	case total.TxCount > 0 || total.MempoolTxCount > 0:
+		extraData["history_status"] = "drained"
+	default:
+		extraData["history_status"] = "never_used"
+		return false, extraData, nil
+	}
 	return true, extraData, nil
 }
 
-// verifyAddressOnChain 查询地址在区块链上的状态 (可选功能)
-func verifyAddressOnChain(ctx context.Context, client *http.Client, address string) (bool, map[string]string, error) {
-	extraData := make(map[string]string)
+// addressStats 是 Esplora /address/:address 响应中的统计, 已确认和未确认的交易分开计数
+type addressStats struct {
+	FundedTxoSum        int64
+	SpentTxoSum         int64
+	TxCount             int64
+	MempoolFundedTxoSum int64
+	MempoolSpentTxoSum  int64
+	MempoolTxCount      int64
+}
 
-	// 使用 mempool.space API 查询地址信息
-	url := fmt.Sprintf("https://mempool.space/api/address/%s", address)
+func (a *addressStats) add(b addressStats) {
+	a.FundedTxoSum += b.FundedTxoSum
+	a.SpentTxoSum += b.SpentTxoSum
+	a.TxCount += b.TxCount
+	a.MempoolFundedTxoSum += b.MempoolFundedTxoSum
+	a.MempoolSpentTxoSum += b.MempoolSpentTxoSum
+	a.MempoolTxCount += b.MempoolTxCount
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchAddressStats 查询地址在区块链上的余额和交易历史
+func fetchAddressStats(ctx context.Context, client *http.Client, apiURL, address string) (addressStats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/address/"+address, nil)
 	if err != nil {
-		return false, extraData, err
+		return addressStats{}, err
 	}
 
-	//req.Header.Set("User-Agent", "TruffleHog")
-
 	res, err := client.Do(req)
 	if err != nil {
-		return false, extraData, err
+		return addressStats{}, err
 	}
-	defer res.Body.Close()
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
 
 	if res.StatusCode != http.StatusOK {
-		return false, extraData, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+		return addressStats{}, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 	}
 
 	var addrResp addressResponse
 	if err := json.NewDecoder(res.Body).Decode(&addrResp); err != nil {
-		return false, extraData, err
+		return addressStats{}, err
 	}
-
-	// 计算余额
-	confirmedBalance := addrResp.ChainStats.FundedTxoSum - addrResp.ChainStats.SpentTxoSum
-	unconfirmedBalance := addrResp.MempoolStats.FundedTxoSum - addrResp.MempoolStats.SpentTxoSum
-	totalBalance := confirmedBalance + unconfirmedBalance
-
-	extraData["address"] = address
-	extraData["confirmed_balance_sat"] = fmt.Sprintf("%d", confirmedBalance)
-	extraData["unconfirmed_balance_sat"] = fmt.Sprintf("%d", unconfirmedBalance)
-	extraData["total_balance_sat"] = fmt.Sprintf("%d", totalBalance)
-	extraData["tx_count"] = fmt.Sprintf("%d", addrResp.ChainStats.TxCount)
-
-	// 如果有任何交易历史或余额，则认为是活跃的私钥
-	if addrResp.ChainStats.TxCount > 0 || totalBalance > 0 {
-		return true, extraData, nil
-	}
-
-	return false, extraData, nil
+	return addressStats{
+		FundedTxoSum:        addrResp.ChainStats.FundedTxoSum,
+		SpentTxoSum:         addrResp.ChainStats.SpentTxoSum,
+		TxCount:             addrResp.ChainStats.TxCount,
+		MempoolFundedTxoSum: addrResp.MempoolStats.FundedTxoSum,
+		MempoolSpentTxoSum:  addrResp.MempoolStats.SpentTxoSum,
+		MempoolTxCount:      addrResp.MempoolStats.TxCount,
+	}, nil
 }
 
 func (s Scanner) Type() detector_typepb.DetectorType {
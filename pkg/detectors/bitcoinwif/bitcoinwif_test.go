@@ -1,7 +1,11 @@
 package bitcoinwif
 
 import (
+	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -170,6 +174,87 @@ func TestBitcoinWIF_FromChunk(t *testing.T) {
 	}
 }
 
+func TestBitcoinWIF_Verify(t *testing.T) {
+	const (
+		p2pkh  = "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+		p2wpkh = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+		unused = `{"chain_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0}}`
+	)
+
+	tests := []struct {
+		name         string
+		stats        map[string]string
+		wantStatus   string
+		wantVerified bool
+		wantErr      bool
+	}{
+		{
+			name: "funded legacy address",
+			stats: map[string]string{
+				p2pkh:  `{"chain_stats":{"funded_txo_sum":150000,"spent_txo_sum":50000,"tx_count":3},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0}}`,
+				p2wpkh: unused,
+			},
+			wantStatus:   "funded",
+			wantVerified: true,
+		},
+		{
+			name: "drained segwit address",
+			stats: map[string]string{
+				p2pkh:  unused,
+				p2wpkh: `{"chain_stats":{"funded_txo_sum":80000,"spent_txo_sum":80000,"tx_count":2},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0}}`,
+			},
+			wantStatus:   "drained",
+			wantVerified: true,
+		},
+		{
+			name:       "never used",
+			stats:      map[string]string{p2pkh: unused, p2wpkh: unused},
+			wantStatus: "never_used",
+		},
+		{
+			name:    "api error",
+			stats:   map[string]string{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := tt.stats[strings.TrimPrefix(r.URL.Path, "/address/")]
+				if !ok {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			d := Scanner{client: server.Client(), apiURL: server.URL}
+			results, err := d.FromData(context.Background(), true, []byte("wif: "+validCompressedWIFK))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("got %d results, want 1", len(results))
+			}
+			r := results[0]
+			if r.Verified != tt.wantVerified {
+				t.Errorf("Verified = %v, want %v", r.Verified, tt.wantVerified)
+			}
+			if (r.VerificationError() != nil) != tt.wantErr {
+				t.Errorf("VerificationError() = %v, wantErr %v", r.VerificationError(), tt.wantErr)
+			}
+			if r.ExtraData["address"] != p2pkh || r.ExtraData["p2wpkh_address"] != p2wpkh {
+				t.Errorf("addresses = %q, %q", r.ExtraData["address"], r.ExtraData["p2wpkh_address"])
+			}
+			if r.ExtraData["history_status"] != tt.wantStatus {
+				t.Errorf("history_status = %q, want %q", r.ExtraData["history_status"], tt.wantStatus)
+			}
+		})
+	}
+}
+
 func TestBitcoinWIF_Type(t *testing.T) {
 	d := Scanner{}
 	if d.Type().String() != "BitcoinWIF" {
@@ -251,3 +336,135 @@ func TestIsValidWIF(t *testing.T) {
 		})
 	}
 }
+
+// FuzzBitcoinWIF_FromData 确保畸形输入 (非法 Base58 字符、unicode、截断的 chunk) 不会导致 panic
+func FuzzBitcoinWIF_FromData(f *testing.F) {
+	for _, seed := range []string{
+		"private_key: " + validUncompressedWIF,
+		"wif: " + validCompressedWIFL,
+		"key1: " + validCompressedWIFK + " key2: " + validUncompressedWIF,
+		"wif: " + testnetWIF,
+		"wif: " + invalidWIF,
+		"wif: " + validCompressedWIFK[:30],
+		"私钥 wif：" + validCompressedWIFL + " ",
+		"wif: 0OIl" + validCompressedWIFL[4:],
+	} {
+		f.Add([]byte(seed))
+	}
+
+	d := Scanner{}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		results, err := d.FromData(context.Background(), false, data)
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		for _, r := range results {
+			if !bytes.Contains(data, r.Raw) {
+				t.Errorf("FromData() returned %q, which is not in the input", r.Raw)
+			}
+		}
+	})
+}
+
+func TestBitcoinWIF_Networks(t *testing.T) {
+	// 与 validUncompressedWIF 相同的私钥在其他网络上的编码
+	const (
+		testnetUncompressedWIF  = "91gGn1HgSap6CbU12F6z3pJri26xzp7Ay1VW6NHCoEayNXwRpu2"
+		testnetCompressedWIF    = "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofSWj1fx"
+		litecoinUncompressedWIF = "6uDNfQ1fknCphurZuj12xcY51qJj3T21Pk2iivwjAxAYHHxwEEr"
+		litecoinCompressedWIF   = "T3TccUZx4EXBZaHnFiP9eTr8igDEZoqSjNvbA56Z8vV74oyAcjTK"
+		dogecoinUncompressedWIF = "6JDyVDw6R82kH9PsbHq3nqk8XnDoLmrFEEknLEZbHA3ZQ8cSuqc"
+		dogecoinCompressedWIF   = "QP2GKa5kuU2i2G3xJMH5KL9NErbVYGxMoRiF5trrJJvHzrJ2Ebp7"
+	)
+	input := strings.Join([]string{
+		"wif: " + validCompressedWIFK,
+		"wif: " + testnetUncompressedWIF,
+		"wif: " + testnetCompressedWIF,
+		"wif: " + litecoinUncompressedWIF,
+		"wif: " + litecoinCompressedWIF,
+		"wif: " + dogecoinUncompressedWIF,
+		"wif: " + dogecoinCompressedWIF,
+	}, "\n")
+
+	networks := func(d *Scanner) map[string]string {
+		results, err := d.FromData(context.Background(), false, []byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string, len(results))
+		for _, r := range results {
+			got[string(r.Raw)] = r.ExtraData["network"]
+		}
+		return got
+	}
+
+	// 默认只检测主网私钥
+	if diff := cmp.Diff(map[string]string{validCompressedWIFK: "mainnet"}, networks(New())); diff != "" {
+		t.Errorf("default networks diff: (-want +got)\n%s", diff)
+	}
+
+	want := map[string]string{
+		validCompressedWIFK:     "mainnet",
+		litecoinUncompressedWIF: "litecoin",
+		litecoinCompressedWIF:   "litecoin",
+		dogecoinUncompressedWIF: "dogecoin",
+		dogecoinCompressedWIF:   "dogecoin",
+	}
+	if diff := cmp.Diff(want, networks(New(WithNetworks(NetworkLitecoin, NetworkDogecoin)))); diff != "" {
+		t.Errorf("litecoin and dogecoin diff: (-want +got)\n%s", diff)
+	}
+
+	want = map[string]string{
+		validCompressedWIFK:    "mainnet",
+		testnetUncompressedWIF: "testnet",
+		testnetCompressedWIF:   "testnet",
+	}
+	if diff := cmp.Diff(want, networks(New(WithNetworks(NetworkTestnet)))); diff != "" {
+		t.Errorf("testnet diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestParseNetwork(t *testing.T) {
+	n, err := ParseNetwork(" Litecoin ")
+	if err != nil || n != NetworkLitecoin {
+		t.Errorf("ParseNetwork() = %q, %v, want %q", n, err, NetworkLitecoin)
+	}
+	if _, err := ParseNetwork("mainnet"); err == nil {
+		t.Error("ParseNetwork(mainnet) returned no error")
+	}
+	// Unsupported networks passed to WithNetworks are ignored.
+	if got := len(New(WithNetworks("bitcoin-cash")).networks); got != 0 {
+		t.Errorf("WithNetworks(bitcoin-cash) enabled %d networks", got)
+	}
+}
+
+func TestBitcoinWIF_VerifyLitecoin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/address/") {
+		case "Lf2SXRzFwowWvG4TZ3Wcir3hpp1D6zsqGn":
+			_, _ = w.Write([]byte(`{"chain_stats":{"funded_txo_sum":2500000,"spent_txo_sum":0,"tx_count":1},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0}}`))
+		case "ltc1qmy63mjadtw8nhzl69ukdepwzsyvv4yexsu9lwa":
+			_, _ = w.Write([]byte(`{"chain_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":0,"tx_count":0}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	d := New(WithNetworks(NetworkLitecoin))
+	d.client, d.apiURL = server.Client(), server.URL
+	results, err := d.FromData(context.Background(), true, []byte("litecoin wif: T3TccUZx4EXBZaHnFiP9eTr8igDEZoqSjNvbA56Z8vV74oyAcjTK"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if !r.Verified || r.VerificationError() != nil {
+		t.Errorf("Verified = %v, VerificationError() = %v", r.Verified, r.VerificationError())
+	}
+	if r.ExtraData["network"] != "litecoin" || r.ExtraData["total_balance_sat"] != "2500000" {
+		t.Errorf("ExtraData = %v", r.ExtraData)
+	}
+}
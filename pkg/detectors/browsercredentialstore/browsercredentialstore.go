@@ -0,0 +1,59 @@
+package browsercredentialstore
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/browserstore"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// 只匹配 browserstore handler 输出的摘要, 数据库文件本身是二进制, 无法按格式识别
+func (s Scanner) Keywords() []string {
+	return []string{strings.TrimSpace(browserstore.HeaderPrefix)}
+}
+
+// FromData will find the browser databases of saved passwords and cookies summarized in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	for _, store := range browserstore.ParseRendered(string(data)) {
+		// 空的数据库不包含任何凭证, 例如从未登录过的浏览器配置
+		if store.Entries == 0 {
+			continue
+		}
+		label := store.Browser + " " + store.Kind
+		entries := strconv.Itoa(store.Entries)
+
+		results = append(results, detectors.Result{
+			DetectorType: detector_typepb.DetectorType_BrowserCredentialStore,
+			Raw:          []byte(label),
+			RawV2:        []byte(label + ":" + store.Table + ":" + entries),
+			Redacted:     label + " (" + entries + " entries)",
+			// 不读取条目内容: 加密密钥常与数据库一起备份, Firefox 的 cookie 更是明文保存
+			Severity: detectors.SeverityCritical,
+			ExtraData: map[string]string{
+				"browser": store.Browser,
+				"store":   store.Kind,
+				"table":   store.Table,
+				"entries": entries,
+			},
+		})
+	}
+
+	return results, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_BrowserCredentialStore
+}
+
+func (s Scanner) Description() string {
+	return "Browser databases of saved passwords and cookies, such as the Login Data and Cookies files of Chromium browsers and the cookies.sqlite and key4.db files of Firefox, found in backups of home directories. Anyone who can read them can recover the saved passwords, whose keys are often backed up alongside, and replay the session cookies."
+}
@@ -0,0 +1,79 @@
+package browsercredentialstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// 与 browserstore handler 输出的格式相同
+const renderedStores = `# browser store: chromium passwords
+table = "logins"
+entries = 12
+# browser store: firefox cookies
+table = "moz_cookies"
+entries = 0
+`
+
+func TestBrowserCredentialStore_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern",
+			input: renderedStores,
+			want:  []string{"chromium passwords (12 entries)"},
+		},
+		{
+			name:  "invalid pattern - header only",
+			input: "# browser store: chromium passwords\n",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Redacted)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestBrowserCredentialStore_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(renderedStores))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, detectors.SeverityCritical, results[0].Severity)
+	assert.Equal(t, map[string]string{
+		"browser": "chromium",
+		"store":   "passwords",
+		"table":   "logins",
+		"entries": "12",
+	}, results[0].ExtraData)
+}
@@ -19,6 +19,10 @@ type Scanner struct{}
 
 type APIResponse struct {
 	Scopes []string `json:"scopes"`
+	User   *struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"user"`
 }
 
 func (s Scanner) Version() int { return 1 }
@@ -34,6 +38,8 @@ var (
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"buildkite"}) + `\b([a-z0-9]{40})\b`)
 )
 
+const accessTokenURL = "https://api.buildkite.com/v2/access-token"
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -82,10 +88,15 @@ func (s Scanner) Description() string {
 	return "Buildkite is a platform for running fast, secure, and scalable continuous integration pipelines. Buildkite API tokens can be used to access and modify pipeline data and configurations."
 }
 
+// VerifyBuildKite checks an API access token and returns its scopes and, when reported, the user it belongs to.
 func VerifyBuildKite(ctx context.Context, client *http.Client, secret string) (map[string]string, bool, error) {
+	return verifyBuildKite(ctx, client, accessTokenURL, secret)
+}
+
+func verifyBuildKite(ctx context.Context, client *http.Client, endpoint, secret string) (map[string]string, bool, error) {
 	// create a request
 	// api doc: https://buildkite.com/docs/apis/rest-api/access-token#get-the-current-token
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.buildkite.com/v2/access-token", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, false, err
 	}
@@ -113,6 +124,14 @@ func VerifyBuildKite(ctx context.Context, client *http.Client, secret string) (m
 		extraData := make(map[string]string)
 
 		extraData["scopes"] = strings.Join(response.Scopes, ", ")
+		if response.User != nil {
+			if response.User.Name != "" {
+				extraData["user"] = response.User.Name
+			}
+			if response.User.Email != "" {
+				extraData["email"] = response.User.Email
+			}
+		}
 		return extraData, true, nil
 	case http.StatusUnauthorized:
 		return nil, false, nil
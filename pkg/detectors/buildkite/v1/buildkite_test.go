@@ -2,9 +2,13 @@ package buildkite
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -120,3 +124,28 @@ func TestBuildKite_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildKite_Verify(t *testing.T) {
+	const token = "hqlh73m51jtho0jh12wcf2758c8fcdbv05z023ly"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"uuid":"b63254c0-3271-4a98-8270-7cfbd6c2f14e","scopes":["read_builds","read_user"],"user":{"name":"Jane Doe","email":"jdoe@example.com"}}`)
+	}))
+	defer server.Close()
+
+	extraData, verified, err := verifyBuildKite(context.Background(), server.Client(), server.URL, token)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{
+		"scopes": "read_builds, read_user",
+		"user":   "Jane Doe",
+		"email":  "jdoe@example.com",
+	}, extraData)
+
+	_, verified, err = verifyBuildKite(context.Background(), server.Client(), server.URL, "0000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
@@ -25,12 +25,14 @@ var (
 
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
 	keyPat = regexp.MustCompile(`\b(bkua_[a-z0-9]{40})\b`)
+	// Agent tokens have no prefix and are usually set through BUILDKITE_AGENT_TOKEN or the agent config.
+	agentTokenPat = regexp.MustCompile(`(?i)buildkite[_-]?agent[_-]?token["'\s:=]+["']?([A-Za-z0-9]{40,64})\b`)
 )
 
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"bkua_"}
+	return []string{"bkua_", "buildkite_agent_token", "buildkite-agent-token", "buildkiteagenttoken"}
 }
 
 // FromData will find and optionally verify Buildkite secrets in a given set of bytes.
@@ -64,6 +66,20 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		results = append(results, s1)
 	}
 
+	// Agent tokens are only accepted by the agent registration endpoint, which registers a new agent,
+	// so they are reported without verification.
+	uniqueAgentTokens := make(map[string]struct{})
+	for _, match := range agentTokenPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueAgentTokens[match[1]] = struct{}{}
+	}
+	for _, token := range detectors.SortedKeys(uniqueAgentTokens) {
+		results = append(results, detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Buildkite,
+			Raw:          []byte(token),
+			ExtraData:    map[string]string{"token_type": "agent"},
+		})
+	}
+
 	return results, nil
 }
 
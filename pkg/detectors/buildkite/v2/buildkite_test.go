@@ -58,6 +58,14 @@ func TestBuildKiteV2_Pattern(t *testing.T) {
 			`,
 			want: []string{"bkua_j8cqyoaodi7z1fzo8u5albtyw4x9gh83yx1m6ien"},
 		},
+		{
+			name: "valid pattern - agent token",
+			input: `
+				env:
+				  BUILDKITE_AGENT_TOKEN: "Xk2Lp9Rv4Mw8Nz3Bc6Hj1Tf5Gs0YdQeWrTy7Ua2Ib4Oc6Pd8"
+			`,
+			want: []string{"Xk2Lp9Rv4Mw8Nz3Bc6Hj1Tf5Gs0YdQeWrTy7Ua2Ib4Oc6Pd8"},
+		},
 		{
 			name: "invalid pattern",
 			input: `
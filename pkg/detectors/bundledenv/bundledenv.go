@@ -0,0 +1,162 @@
+package bundledenv
+
+import (
+	"context"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alchemy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cozetoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethereumprivatekey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/infura"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+
+var (
+	// 构建时 webpack DefinePlugin, Vite, Next.js 等会把环境变量的值直接写进打包产物, 常见形式:
+	// {"NODE_ENV":"production","REACT_APP_API_KEY":"..."} 或压缩后的 {BASE_URL:"/",VITE_API_KEY:"..."}
+	// 嵌入 JSON 字符串时引号可能被转义
+	envEntryPat = regexp.MustCompile(`\\?["']?\b([A-Z][A-Z0-9]*(?:_[A-Z0-9]+)+)\\?["']?\s*:\s*\\?["']([^"'\\\s]{8,512})\\?["']`)
+	// 打包产物的特征, 没有框架前缀的变量名只在出现这些特征时才报告
+	bundlePat = regexp.MustCompile(`process\.env|import\.meta\.env|"NODE_ENV"|NODE_ENV:|__webpack_require__|webpackChunk|__NEXT_DATA__|BASE_URL:`)
+	// 变量名中表示密钥的部分
+	secretNamePat = regexp.MustCompile(`(?:^|_)(?:PRIVATE|SECRET|TOKEN|PASSWORD|PASSWD|PWD|MNEMONIC|SEED|CREDENTIALS?|KEY|APIKEY|DSN)(?:_|$)`)
+	// 按设计公开的值, 如 Stripe publishable key, reCAPTCHA site key, 公钥和客户端 ID
+	publicNamePat = regexp.MustCompile(`PUBLISHABLE|PUBLIC_KEY|PUBKEY|SITE_KEY|SITEKEY|CLIENT_ID|APP_ID|MEASUREMENT_ID`)
+	// 助记词, 私钥等泄露后可直接转移资产的变量名
+	criticalNamePat = regexp.MustCompile(`PRIVATE|MNEMONIC|SEED|SECRET`)
+	// 占位符和未替换的模板
+	placeholderPat = regexp.MustCompile(`(?i)^(?:undefined|null|true|false|production|development)$|your[_-]|<[a-z_]+>|\$\{|%[A-Z_]+%|^x{8,}$|^\*+$|changeme`)
+)
+
+// frameworkPrefixes 是各框架约定注入到浏览器端代码的环境变量前缀
+var frameworkPrefixes = []struct {
+	prefix    string
+	framework string
+}{
+	{"REACT_APP_", "create-react-app"},
+	{"NEXT_PUBLIC_", "next.js"},
+	{"VITE_", "vite"},
+	{"VUE_APP_", "vue-cli"},
+	{"NUXT_PUBLIC_", "nuxt"},
+	{"NUXT_ENV_", "nuxt"},
+	{"GATSBY_", "gatsby"},
+	{"EXPO_PUBLIC_", "expo"},
+}
+
+// downstream 是取值可以交给其他 detector 识别和验证的密钥类型
+var downstream = detectors.Downstream{
+	ethereumprivatekey.Scanner{},
+	infura.Scanner{},
+	alchemy.Scanner{},
+	cozetoken.Scanner{},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+func (s Scanner) Keywords() []string {
+	keywords := []string{"process.env", "import.meta.env", "NODE_ENV"}
+	for _, p := range frameworkPrefixes {
+		keywords = append(keywords, p.prefix)
+	}
+	return keywords
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return 1024 }
+
+// FromData will find environment variables inlined into JavaScript bundles in a given set of bytes. Values that
+// another detector recognizes are reported and verified by that detector, annotated with the variable name.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	inBundle := bundlePat.MatchString(dataStr)
+
+	seen := make(map[string]struct{})
+	for _, match := range envEntryPat.FindAllStringSubmatch(dataStr, -1) {
+		name, value := match[1], match[2]
+		framework := frameworkOf(name)
+		if framework == "" && !inBundle {
+			continue
+		}
+		if placeholderPat.MatchString(value) || strings.Contains(value, "://") {
+			continue
+		}
+		if _, ok := seen[name+"="+value]; ok {
+			continue
+		}
+		seen[name+"="+value] = struct{}{}
+
+		extraData := map[string]string{"env_var": name}
+		if framework != "" {
+			extraData["framework"] = framework
+		}
+
+		// 变量名不像密钥的值也交给其他 detector, 如 REACT_APP_INFURA_ID 中的 Infura 项目 ID
+		if routed := s.route(ctx, verify, name, value); len(routed) > 0 {
+			for _, r := range routed {
+				if r.ExtraData == nil {
+					r.ExtraData = make(map[string]string)
+				}
+				for k, v := range extraData {
+					r.ExtraData[k] = v
+				}
+				results = append(results, r)
+			}
+			continue
+		}
+		if !isSecretName(name) {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_BundledEnvSecret,
+			Raw:          []byte(value),
+			RawV2:        []byte(name + "=" + value),
+			// 打包进前端代码的值对所有访问者可见
+			Severity:  detectors.SeverityHigh,
+			ExtraData: extraData,
+		}
+		if criticalNamePat.MatchString(name) {
+			s1.Severity = detectors.SeverityCritical
+		}
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// route 把变量还原成 NAME="value" 交给其他 detector, 变量名为依赖上下文关键词的 detector 提供上下文
+func (s Scanner) route(ctx context.Context, verify bool, name, value string) []detectors.Result {
+	return downstream.FromData(ctx, verify, []byte(name+`="`+value+`"`))
+}
+
+func frameworkOf(name string) string {
+	for _, p := range frameworkPrefixes {
+		if strings.HasPrefix(name, p.prefix) {
+			return p.framework
+		}
+	}
+	return ""
+}
+
+func isSecretName(name string) bool {
+	for _, p := range frameworkPrefixes {
+		name = strings.TrimPrefix(name, p.prefix)
+	}
+	return secretNamePat.MatchString(name) && !publicNamePat.MatchString(name)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_BundledEnvSecret
+}
+
+func (s Scanner) Description() string {
+	return "Environment variables that a bundler such as webpack, Vite or Next.js inlined into frontend JavaScript at build time. Every visitor of the site can read them, so secrets among them, like API keys or wallet private keys, are exposed publicly."
+}
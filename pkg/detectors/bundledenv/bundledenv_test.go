@@ -0,0 +1,105 @@
+package bundledenv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+const ethPrivateKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+var (
+	craBundle = `(this.webpackJsonpapp=this.webpackJsonpapp||[]).push([[0],{12:function(e,t,n){"use strict";` +
+		`var r={NODE_ENV:"production",PUBLIC_URL:"",REACT_APP_API_URL:"https://api.example.com",REACT_APP_API_SECRET:"sk9Fq2LmZx7RtP4vWc8NbY3k",` +
+		`REACT_APP_STRIPE_PUBLISHABLE_KEY:"pk_live_51HxYz2KqLmN8pQrStUvWxYz"};t.a=r}}]);`
+	dappBundle = `var e={"NODE_ENV":"production","PRIVATE_KEY":"` + ethPrivateKey + `","INFURA_URL":"https://mainnet.infura.io/v3/"};` +
+		`const t=new ethers.Wallet(e.PRIVATE_KEY);`
+	viteBundle = `const o={BASE_URL:"/",MODE:"production",DEV:!1,PROD:!0,SSR:!1,VITE_INFURA_ID:"9aa3d95b3bc440fa88ea12eaa4456161",VITE_SENTRY_DSN:"undefined"};`
+)
+
+func TestBundledEnv_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - create-react-app env object",
+			input: craBundle,
+			want:  []string{"BundledEnvSecret REACT_APP_API_SECRET=sk9Fq2LmZx7RtP4vWc8NbY3k"},
+		},
+		{
+			name:  "valid pattern - private key routed to the Ethereum detector",
+			input: dappBundle,
+			want:  []string{"EthereumPrivateKey PRIVATE_KEY"},
+		},
+		{
+			name:  "valid pattern - Infura project ID routed to the Infura detector",
+			input: viteBundle,
+			want:  []string{"Infura VITE_INFURA_ID"},
+		},
+		{
+			name:  "invalid pattern - no bundle markers",
+			input: `{"SERVICE_NAME":"api","API_SECRET":"sk9Fq2LmZx7RtP4vWc8NbY3k","process":1}` + "\nREACT_APP_X: 1",
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - placeholders",
+			input: `process.env={REACT_APP_API_KEY:"your-api-key",REACT_APP_TOKEN:"${TOKEN_FROM_CI}"}`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				name := detector_typepb.DetectorType_name[int32(r.DetectorType)]
+				if r.DetectorType == detector_typepb.DetectorType_BundledEnvSecret {
+					got = append(got, name+" "+string(r.RawV2))
+				} else {
+					got = append(got, name+" "+r.ExtraData["env_var"])
+				}
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestBundledEnv_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(craBundle))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]string{
+		"env_var":   "REACT_APP_API_SECRET",
+		"framework": "create-react-app",
+	}, results[0].ExtraData)
+	assert.Equal(t, detectors.SeverityCritical, results[0].Severity)
+
+	results, err = Scanner{}.FromData(context.Background(), false, []byte(dappBundle))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, ethPrivateKey, string(results[0].Raw))
+	assert.Equal(t, "PRIVATE_KEY", results[0].ExtraData["env_var"])
+}
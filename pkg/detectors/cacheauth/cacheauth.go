@@ -0,0 +1,401 @@
+package cacheauth
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	// probe 验证时连接密码所属的服务器并只发送认证命令, 需要通过 --probe-datastore-auth 显式开启
+	probe bool
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.AuthProbeVerifier = (*Scanner)(nil)
+
+const (
+	serviceRedis     = "redis"
+	serviceMemcached = "memcached"
+
+	defaultRedisPort     = "6379"
+	defaultMemcachedPort = "11211"
+
+	probeTimeout = 5 * time.Second
+)
+
+var (
+	// 脚本中的 AUTH 命令, 例如 printf "AUTH s3cret\r\n" | nc host 6379, AUTH 之后可以带 ACL 用户名;
+	// 只匹配行首, 引号或管道之后以及 redis-cli 参数中的 AUTH, 不匹配 "the AUTH command" 这样的文字说明
+	authCmdPat = regexp.MustCompile("(?m)(?:^[ \\t]*|[\"'`(]|\\|[ \\t]*|redis-cli\\b[^\\n]*?[ \\t])AUTH[ \\t]+(?:([A-Za-z0-9_.-]{1,64})[ \\t]+)?[\"']?([^\\s\"'`\\\\;|&]{4,128})")
+	// redis-cli 交互记录, 提示符中带有服务器地址, 例如 10.0.0.5:6379> auth s3cret
+	promptPat = regexp.MustCompile(`(?mi)^([A-Za-z0-9.-]+):([0-9]{1,5})> auth[ \t]+(?:([A-Za-z0-9_.-]{1,64})[ \t]+)?([^\s"'\\]{4,128})`)
+	// redis-cli -a / --pass 参数
+	cliPat = regexp.MustCompile(`redis-cli\b[^\n]*?[ \t](?:-a|--pass)[ \t]+["']?([^\s"'\\;|&]{4,128})`)
+	// redis.conf 的 requirepass / masterauth 指令, 以及 redis-server --requirepass 参数
+	confPat = regexp.MustCompile(`(?m)(?:^[ \t]*|--)(requirepass|masterauth)[ \t]+["']?([^\s"'\\;|&#]{4,128})`)
+	// 带密码的 redis:// 和 memcached:// URL; 带用户名的 redis:// URL 由 Redis 检测器处理, 这里只处理 redis://:password@host
+	urlPat = regexp.MustCompile(`\b(rediss?|memcached?)://([A-Za-z0-9_.-]{0,64}):([^\s@/"'\\]{4,128})@([A-Za-z0-9.-]+)(?::([0-9]{1,5}))?`)
+
+	// 同一行中的服务器地址: redis-cli -h host -p port --user user, nc / telnet host port
+	cliHostPat = regexp.MustCompile(`(?:^|\s)-h[ \t]+([A-Za-z0-9.-]+)`)
+	cliPortPat = regexp.MustCompile(`(?:^|\s)-p[ \t]+([0-9]{1,5})`)
+	cliUserPat = regexp.MustCompile(`(?:^|\s)--user[ \t]+([A-Za-z0-9_.-]{1,64})`)
+	netcatPat  = regexp.MustCompile(`\b(?:nc|ncat|netcat|telnet)[ \t]+(?:-[A-Za-z]+[ \t]+)*([A-Za-z0-9.-]+)[ \t]+([0-9]{1,5})\b`)
+	// 配置或环境变量中的服务器地址, 例如 REDIS_HOST=cache.internal, REDIS_ADDR=10.0.0.3:6380, redis_port: 6380
+	envHostPat = regexp.MustCompile(`(?i)\bredis[_.-]?(?:host|server|addr)["']?[ \t]*[:=][ \t]*["']?([A-Za-z0-9.-]+)(?::([0-9]{1,5}))?`)
+	envPortPat = regexp.MustCompile(`(?i)\bredis[_.-]?port["']?[ \t]*[:=][ \t]*["']?([0-9]{1,5})`)
+	// masterauth 是主节点的密码, 主节点由 replicaof / slaveof 指令给出
+	replicaOfPat = regexp.MustCompile(`(?m)^[ \t]*(?:replicaof|slaveof)[ \t]+([A-Za-z0-9.-]+)[ \t]+([0-9]{1,5})`)
+)
+
+// 文档和模板中常见的占位密码
+var placeholders = map[string]struct{}{
+	"foobared":      {},
+	"password":      {},
+	"yourpassword":  {},
+	"your_password": {},
+	"changeme":      {},
+	"mypassword":    {},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"redis", "requirepass", "masterauth", "memcache"}
+}
+
+// SetAuthProbe implements detectors.AuthProbeVerifier.
+func (s *Scanner) SetAuthProbe(enabled bool) {
+	s.probe = enabled
+}
+
+type candidate struct {
+	service  string
+	source   string
+	user     string
+	password string
+	// host 是 host:port 形式的服务器地址, 未知时为空
+	host string
+	tls  bool
+	raw  string
+}
+
+// FromData will find Redis and Memcached passwords in AUTH commands, redis-cli arguments, redis.conf directives and
+// connection URLs in a given set of bytes. Verification connects to the server the password was found with and only
+// authenticates, and only when enabled with SetAuthProbe.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	envHost := envTarget(dataStr)
+
+	var candidates []candidate
+	if strings.Contains(strings.ToLower(dataStr), "redis") {
+		for _, m := range authCmdPat.FindAllStringSubmatchIndex(dataStr, -1) {
+			line := lineAt(dataStr, m[4])
+			if promptPat.MatchString(line) {
+				continue
+			}
+			c := candidate{service: serviceRedis, source: "AUTH command", password: dataStr[m[4]:m[5]]}
+			if m[2] >= 0 {
+				c.user = dataStr[m[2]:m[3]]
+			}
+			c.host = lineTarget(line, envHost)
+			candidates = append(candidates, c)
+		}
+	}
+	for _, m := range promptPat.FindAllStringSubmatch(dataStr, -1) {
+		candidates = append(candidates, candidate{
+			service:  serviceRedis,
+			source:   "redis-cli",
+			user:     m[3],
+			password: m[4],
+			host:     net.JoinHostPort(m[1], m[2]),
+		})
+	}
+	for _, m := range cliPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		line := lineAt(dataStr, m[2])
+		c := candidate{service: serviceRedis, source: "redis-cli", password: dataStr[m[2]:m[3]], host: lineTarget(line, envHost)}
+		if user := cliUserPat.FindStringSubmatch(line); user != nil {
+			c.user = user[1]
+		}
+		candidates = append(candidates, c)
+	}
+	for _, m := range confPat.FindAllStringSubmatch(dataStr, -1) {
+		c := candidate{service: serviceRedis, source: m[1], password: m[2], host: envHost}
+		if m[1] == "masterauth" {
+			c.host = ""
+			if master := replicaOfPat.FindStringSubmatch(dataStr); master != nil {
+				c.host = net.JoinHostPort(master[1], master[2])
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	for _, m := range urlPat.FindAllStringSubmatch(dataStr, -1) {
+		c := candidate{source: "url", user: m[2], password: m[3], raw: m[0]}
+		port := m[5]
+		switch scheme := m[1]; scheme {
+		case "redis", "rediss":
+			if c.user != "" {
+				continue
+			}
+			c.service, c.tls = serviceRedis, scheme == "rediss"
+			if port == "" {
+				port = defaultRedisPort
+			}
+		default:
+			c.service = serviceMemcached
+			if port == "" {
+				port = defaultMemcachedPort
+			}
+		}
+		c.host = net.JoinHostPort(m[4], port)
+		candidates = append(candidates, c)
+	}
+
+	seen := make(map[string]struct{})
+	for _, c := range candidates {
+		if isPlaceholder(c.password) {
+			continue
+		}
+		key := c.service + "\x00" + c.host + "\x00" + c.user + "\x00" + c.password
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_CacheAuth,
+			Raw:          []byte(c.password),
+			Redacted:     c.source + " " + redact(c.password),
+			ExtraData: map[string]string{
+				"service": c.service,
+				"source":  c.source,
+			},
+		}
+		if c.raw != "" {
+			s1.Redacted = strings.Replace(c.raw, ":"+c.password+"@", ":"+redact(c.password)+"@", 1)
+		}
+		if c.host != "" {
+			s1.RawV2 = []byte(c.host + "\x00" + c.user + "\x00" + c.password)
+			s1.ExtraData["host"] = c.host
+		}
+		if c.user != "" {
+			s1.ExtraData["username"] = c.user
+		}
+		s1.SetPrimarySecretValue(c.password)
+
+		if verify && s.probe && c.host != "" {
+			verificationErr := probe(ctx, c, &s1)
+			s1.SetVerificationError(verificationErr, c.password)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// probe connects to the server of a candidate and authenticates with its password, recording in the result whether
+// the server is reachable from the scanner on a public address. No command other than the authentication is sent.
+func probe(ctx context.Context, c candidate, r *detectors.Result) error {
+	dialer := &net.Dialer{Timeout: probeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.host)
+	if err != nil {
+		r.ExtraData["internet_reachable"] = "false"
+		return err
+	}
+	defer conn.Close()
+
+	public := false
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		public = isPublicIP(addr.IP)
+	}
+	r.ExtraData["internet_reachable"] = strconv.FormatBool(public)
+
+	_ = conn.SetDeadline(time.Now().Add(probeTimeout))
+	if c.tls {
+		host, _, _ := net.SplitHostPort(c.host)
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+
+	var authRequired bool
+	switch c.service {
+	case serviceMemcached:
+		r.Verified, authRequired, err = authMemcached(conn, c.user, c.password)
+	default:
+		r.Verified, authRequired, err = authRedis(conn, c.user, c.password)
+	}
+	if err == nil && !authRequired {
+		r.ExtraData["auth_required"] = "false"
+	}
+	return err
+}
+
+// authRedis sends a single AUTH command. authRequired is false if the server has no password set, in which case the
+// password is not verified but the server is open to anyone who can reach it.
+func authRedis(conn net.Conn, user, password string) (verified, authRequired bool, err error) {
+	args := []string{"AUTH", password}
+	if user != "" {
+		args = []string{"AUTH", user, password}
+	}
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(conn, cmd.String()); err != nil {
+		return false, false, err
+	}
+
+	reply, err := bufio.NewReaderSize(io.LimitReader(conn, 1024), 1024).ReadString('\n')
+	if err != nil {
+		return false, false, err
+	}
+	reply = strings.TrimSpace(reply)
+	lower := strings.ToLower(reply)
+	switch {
+	case reply == "+OK":
+		return true, true, nil
+	case strings.HasPrefix(reply, "-WRONGPASS"), strings.Contains(lower, "invalid password"), strings.Contains(lower, "invalid username-password"):
+		return false, true, nil
+	case strings.Contains(lower, "without any password configured"), strings.Contains(lower, "no password is set"):
+		return false, false, nil
+	default:
+		return false, true, fmt.Errorf("unexpected reply to AUTH: %q", reply)
+	}
+}
+
+// memcached 二进制协议的 SASL 认证请求和响应状态
+const (
+	memcachedRequest       = 0x80
+	memcachedResponse      = 0x81
+	memcachedOpSASLAuth    = 0x21
+	memcachedStatusOK      = 0x0000
+	memcachedStatusAuthErr = 0x0020
+	memcachedStatusUnknown = 0x0081
+)
+
+// authMemcached sends a single SASL PLAIN authentication request over the binary protocol. authRequired is false if
+// the server does not support SASL, i.e. it accepts commands without authentication.
+func authMemcached(conn net.Conn, user, password string) (verified, authRequired bool, err error) {
+	const mechanism = "PLAIN"
+	value := "\x00" + user + "\x00" + password
+
+	req := make([]byte, 24, 24+len(mechanism)+len(value))
+	req[0] = memcachedRequest
+	req[1] = memcachedOpSASLAuth
+	binary.BigEndian.PutUint16(req[2:4], uint16(len(mechanism)))
+	binary.BigEndian.PutUint32(req[8:12], uint32(len(mechanism)+len(value)))
+	req = append(req, mechanism...)
+	req = append(req, value...)
+	if _, err := conn.Write(req); err != nil {
+		return false, false, err
+	}
+
+	var resp [24]byte
+	if _, err := io.ReadFull(conn, resp[:]); err != nil {
+		return false, false, err
+	}
+	if resp[0] != memcachedResponse {
+		return false, false, errors.New("server does not speak the memcached binary protocol")
+	}
+	switch status := binary.BigEndian.Uint16(resp[6:8]); status {
+	case memcachedStatusOK:
+		return true, true, nil
+	case memcachedStatusAuthErr:
+		return false, true, nil
+	case memcachedStatusUnknown:
+		return false, false, nil
+	default:
+		return false, true, fmt.Errorf("unexpected memcached status 0x%04x", status)
+	}
+}
+
+// lineTarget returns the server named on a command line, e.g. by redis-cli -h or nc, or the server from the
+// environment otherwise.
+func lineTarget(line, envHost string) string {
+	if m := netcatPat.FindStringSubmatch(line); m != nil {
+		return net.JoinHostPort(m[1], m[2])
+	}
+	if m := cliHostPat.FindStringSubmatch(line); m != nil {
+		port := defaultRedisPort
+		if p := cliPortPat.FindStringSubmatch(line); p != nil {
+			port = p[1]
+		}
+		return net.JoinHostPort(m[1], port)
+	}
+	return envHost
+}
+
+// envTarget returns the Redis server named by REDIS_HOST style settings in the data, if any.
+func envTarget(data string) string {
+	m := envHostPat.FindStringSubmatch(data)
+	if m == nil {
+		return ""
+	}
+	port := m[2]
+	if port == "" {
+		port = defaultRedisPort
+		if p := envPortPat.FindStringSubmatch(data); p != nil {
+			port = p[1]
+		}
+	}
+	return net.JoinHostPort(m[1], port)
+}
+
+func lineAt(data string, pos int) string {
+	start := strings.LastIndexByte(data[:pos], '\n') + 1
+	end := strings.IndexByte(data[pos:], '\n')
+	if end < 0 {
+		return data[start:]
+	}
+	return data[start : pos+end]
+}
+
+func isPlaceholder(password string) bool {
+	if strings.Trim(password, "*") == "" || strings.ContainsAny(password[:1], "$<%{") {
+		return true
+	}
+	_, ok := placeholders[strings.ToLower(password)]
+	return ok
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast())
+}
+
+func redact(password string) string {
+	if len(password) <= 4 {
+		return "****"
+	}
+	return password[:2] + strings.Repeat("*", len(password)-2)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_CacheAuth
+}
+
+func (s Scanner) Description() string {
+	return "Redis and Memcached passwords found in AUTH commands, redis-cli arguments, redis.conf directives and connection URLs. They grant access to the data held by the cache."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagInfrastructure} }
@@ -0,0 +1,116 @@
+package detectors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// canaryDustSat is the most an address may have received, in satoshis, for an emptied wallet to look like a bait
+// wallet that was only ever seeded with dust.
+const canaryDustSat = 10000
+
+// knownCanaries are secrets and addresses that are published on purpose, so finding them is never an incident.
+// Sweeper bots watch all of them and drain any funds sent there within seconds.
+var knownCanaries = map[string]string{
+	// Default Hardhat and Anvil accounts, derived from the "test test ... junk" mnemonic.
+	"test test test test test test test test test test test junk":        "Hardhat/Anvil default mnemonic",
+	"0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80": "Hardhat/Anvil default account #0",
+	"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266":                         "Hardhat/Anvil default account #0",
+	"0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d": "Hardhat/Anvil default account #1",
+	"0x70997970c51812dc3a010c7d01b50e0d17dc79c8":                         "Hardhat/Anvil default account #1",
+	"0x5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a": "Hardhat/Anvil default account #2",
+	"0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc":                         "Hardhat/Anvil default account #2",
+	// Private key 1, the first key every brute-force tool tries.
+	"0x0000000000000000000000000000000000000000000000000000000000000001": "private key 1",
+	"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf":                         "private key 1",
+	"kwdibf89qggbjehknhxjuh7lrcivrzi3qyjgd9m7rfu73svhnown":               "private key 1",
+	"1bggz9tcn4rm9kbzdn7kprqz87sz26samh":                                 "private key 1",
+}
+
+// CanaryList recognizes findings that are likely honeypots or canaries: secrets that were published on purpose to
+// detect whoever uses them. Such findings are tagged rather than dropped, so they can be triaged without an incident
+// response. A nil *CanaryList is valid and only uses the built-in list and heuristics.
+type CanaryList struct {
+	// entries maps lowercase secrets or addresses to the reason they are listed.
+	entries map[string]string
+}
+
+// ReadCanaryList parses a canary list. Each line holds a secret or address, optionally followed by whitespace and a
+// description. Blank lines and lines starting with '#' are ignored.
+func ReadCanaryList(r io.Reader) (*CanaryList, error) {
+	list := &CanaryList{entries: make(map[string]string)}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value, description, _ := strings.Cut(line, " ")
+		if description = strings.TrimSpace(description); description == "" {
+			description = fmt.Sprintf("canary list line %d", lineNum)
+		}
+		list.entries[strings.ToLower(value)] = description
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// lookup returns the reason a secret or address is listed.
+func (l *CanaryList) lookup(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	value = strings.ToLower(value)
+	if reason, ok := knownCanaries[value]; ok {
+		return reason, true
+	}
+	if l != nil {
+		if reason, ok := l.entries[value]; ok {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// Check reports whether the result looks like a canary, and why.
+func (l *CanaryList) Check(res *Result) (string, bool) {
+	// Detectors that can recognize canaries themselves, such as AWS canarytokens.
+	if res.ExtraData["is_canary"] == "true" {
+		return "recognized by detector", true
+	}
+
+	for _, value := range []string{string(res.Raw), string(res.RawV2), res.ExtraData["address"]} {
+		if reason, ok := l.lookup(value); ok {
+			return reason, true
+		}
+	}
+
+	// Bait wallets are seeded with dust so they look used; a real wallet that was drained usually received more.
+	if res.ExtraData["total_balance_sat"] == "0" {
+		received, err := strconv.ParseInt(res.ExtraData["received_sat"], 10, 64)
+		if err == nil && received > 0 && received <= canaryDustSat {
+			return "empty wallet that only ever received dust", true
+		}
+	}
+	return "", false
+}
+
+// Tag marks the result as a suspected canary if it looks like one, and lowers its severity accordingly. It returns
+// whether the result was tagged.
+func (l *CanaryList) Tag(res *Result) bool {
+	reason, ok := l.Check(res)
+	if !ok {
+		return false
+	}
+	res.CloneExtraData()
+	res.ExtraData["suspected_canary"] = "true"
+	res.ExtraData["canary_reason"] = reason
+	res.Severity = SeverityLow
+	return true
+}
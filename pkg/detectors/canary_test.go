@@ -0,0 +1,86 @@
+package detectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCanaryList(t *testing.T) {
+	input := `
+# Canary wallets seeded by the security team.
+0xAbC0000000000000000000000000000000000001 honeypot wallet
+AKIAIOSFODNN7CANARY1
+`
+	list, err := ReadCanaryList(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"0xabc0000000000000000000000000000000000001": "honeypot wallet",
+		"akiaiosfodnn7canary1":                       "canary list line 4",
+	}, list.entries)
+}
+
+func TestCanaryList_Check(t *testing.T) {
+	list, err := ReadCanaryList(strings.NewReader("0xabc0000000000000000000000000000000000001 honeypot wallet\n"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		list   *CanaryList
+		result Result
+		want   string
+	}{
+		{
+			name:   "detector flagged canary",
+			result: Result{Raw: []byte("AKIA"), ExtraData: map[string]string{"is_canary": "true"}},
+			want:   "recognized by detector",
+		},
+		{
+			name:   "built-in key",
+			result: Result{Raw: []byte("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")},
+			want:   "Hardhat/Anvil default account #0",
+		},
+		{
+			name:   "configured address",
+			list:   list,
+			result: Result{Raw: []byte("secret"), ExtraData: map[string]string{"address": "0xABC0000000000000000000000000000000000001"}},
+			want:   "honeypot wallet",
+		},
+		{
+			name:   "configured address without list",
+			result: Result{Raw: []byte("secret"), ExtraData: map[string]string{"address": "0xabc0000000000000000000000000000000000001"}},
+		},
+		{
+			name:   "dust-only wallet",
+			result: Result{Raw: []byte("secret"), ExtraData: map[string]string{"total_balance_sat": "0", "received_sat": "546"}},
+			want:   "empty wallet that only ever received dust",
+		},
+		{
+			name:   "drained wallet",
+			result: Result{Raw: []byte("secret"), ExtraData: map[string]string{"total_balance_sat": "0", "received_sat": "150000000"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := tt.list.Check(&tt.result)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, reason)
+		})
+	}
+}
+
+func TestCanaryList_Tag(t *testing.T) {
+	var list *CanaryList
+	res := Result{Raw: []byte("test test test test test test test test test test test junk"), Severity: SeverityCritical}
+	assert.True(t, list.Tag(&res))
+	assert.Equal(t, "true", res.ExtraData["suspected_canary"])
+	assert.Equal(t, "Hardhat/Anvil default mnemonic", res.ExtraData["canary_reason"])
+	assert.Equal(t, SeverityLow, res.Severity)
+
+	res = Result{Raw: []byte("not a canary")}
+	assert.False(t, list.Tag(&res))
+	assert.Nil(t, res.ExtraData)
+}
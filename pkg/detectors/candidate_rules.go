@@ -0,0 +1,188 @@
+package detectors
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
+)
+
+// CandidateRule matches raw secret candidates by regular expression or literal substring.
+type CandidateRule struct {
+	Name     string
+	regexes  []*regexp.Regexp
+	literals [][]byte
+
+	hits atomic.Uint64
+}
+
+// NewCandidateRule validates and compiles a CandidateRule from its configuration.
+func NewCandidateRule(pbRule *configpb.CandidateRule) (*CandidateRule, error) {
+	if pbRule.GetName() == "" {
+		return nil, fmt.Errorf("candidate rule name is required")
+	}
+	if len(pbRule.GetRegex()) == 0 && len(pbRule.GetLiteral()) == 0 {
+		return nil, fmt.Errorf("candidate rule %q: at least one regex or literal is required", pbRule.GetName())
+	}
+
+	rule := &CandidateRule{Name: pbRule.GetName()}
+	for _, pattern := range pbRule.GetRegex() {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("candidate rule %q: invalid regex %q: %w", pbRule.GetName(), pattern, err)
+		}
+		rule.regexes = append(rule.regexes, re)
+	}
+	for _, literal := range pbRule.GetLiteral() {
+		if literal == "" {
+			return nil, fmt.Errorf("candidate rule %q: empty literal", pbRule.GetName())
+		}
+		rule.literals = append(rule.literals, []byte(literal))
+	}
+
+	return rule, nil
+}
+
+// Matches reports whether data matches any of the rule's regexes or literals.
+func (r *CandidateRule) Matches(data []byte) bool {
+	for _, literal := range r.literals {
+		if bytes.Contains(data, literal) {
+			return true
+		}
+	}
+	for _, re := range r.regexes {
+		if re.Match(data) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CandidateRule) matchesResult(res *Result) bool {
+	return (len(res.Raw) > 0 && r.Matches(res.Raw)) || (len(res.RawV2) > 0 && r.Matches(res.RawV2))
+}
+
+// CandidateRules holds the globally configured ignore and report rules. A nil
+// *CandidateRules is valid and matches nothing.
+type CandidateRules struct {
+	Ignore []*CandidateRule
+	Report []*CandidateRule
+}
+
+// NewCandidateRules compiles the ignore and report rules from their configuration.
+// It returns nil if no rules are configured.
+func NewCandidateRules(pbRules *configpb.CandidateRules) (*CandidateRules, error) {
+	if len(pbRules.GetIgnore()) == 0 && len(pbRules.GetReport()) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{})
+	compile := func(pbRules []*configpb.CandidateRule) ([]*CandidateRule, error) {
+		rules := make([]*CandidateRule, 0, len(pbRules))
+		for _, pbRule := range pbRules {
+			rule, err := NewCandidateRule(pbRule)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[rule.Name]; ok {
+				return nil, fmt.Errorf("duplicate candidate rule name %q", rule.Name)
+			}
+			seen[rule.Name] = struct{}{}
+			rules = append(rules, rule)
+		}
+		return rules, nil
+	}
+
+	ignore, err := compile(pbRules.GetIgnore())
+	if err != nil {
+		return nil, err
+	}
+	report, err := compile(pbRules.GetReport())
+	if err != nil {
+		return nil, err
+	}
+	return &CandidateRules{Ignore: ignore, Report: report}, nil
+}
+
+// MayIgnore is a cheap pre-check reporting whether any ignore rule matches
+// somewhere in data. If it returns false, no candidate found in data can be ignored.
+func (c *CandidateRules) MayIgnore(data []byte) bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.Ignore {
+		if rule.Matches(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ignored reports whether the result would be dropped by Apply. It does not
+// record any hits.
+func (c *CandidateRules) Ignored(res *Result) bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.Report {
+		if rule.matchesResult(res) {
+			return false
+		}
+	}
+	for _, rule := range c.Ignore {
+		if rule.matchesResult(res) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply evaluates the rules against each result. Results matching an ignore rule
+// are dropped, and results matching a report rule are marked with the rule's name
+// so they are reported regardless of result filtering.
+//
+// Precedence: report rules > ignore rules
+func (c *CandidateRules) Apply(results []Result) []Result {
+	if c == nil {
+		return results
+	}
+
+	kept := results[:0]
+outer:
+	for _, res := range results {
+		for _, rule := range c.Report {
+			if rule.matchesResult(&res) {
+				rule.hits.Add(1)
+				res.MatchedReportRule = rule.Name
+				kept = append(kept, res)
+				continue outer
+			}
+		}
+		for _, rule := range c.Ignore {
+			if rule.matchesResult(&res) {
+				rule.hits.Add(1)
+				continue outer
+			}
+		}
+		kept = append(kept, res)
+	}
+	return kept
+}
+
+// Hits returns the number of candidates matched by each rule, keyed by rule name.
+func (c *CandidateRules) Hits() map[string]uint64 {
+	if c == nil {
+		return nil
+	}
+
+	hits := make(map[string]uint64, len(c.Ignore)+len(c.Report))
+	for _, rule := range c.Ignore {
+		hits[rule.Name] = rule.hits.Load()
+	}
+	for _, rule := range c.Report {
+		hits[rule.Name] = rule.hits.Load()
+	}
+	return hits
+}
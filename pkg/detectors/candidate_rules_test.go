@@ -0,0 +1,97 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
+)
+
+func TestNewCandidateRules(t *testing.T) {
+	t.Run("no rules configured", func(t *testing.T) {
+		rules, err := NewCandidateRules(nil)
+		require.NoError(t, err)
+		assert.Nil(t, rules)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, err := NewCandidateRules(&configpb.CandidateRules{
+			Ignore: []*configpb.CandidateRule{{Literal: []string{"EXAMPLE"}}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing matchers", func(t *testing.T) {
+		_, err := NewCandidateRules(&configpb.CandidateRules{
+			Ignore: []*configpb.CandidateRule{{Name: "empty"}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		_, err := NewCandidateRules(&configpb.CandidateRules{
+			Report: []*configpb.CandidateRule{{Name: "bad", Regex: []string{"("}}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := NewCandidateRules(&configpb.CandidateRules{
+			Ignore: []*configpb.CandidateRule{{Name: "dup", Literal: []string{"a"}}},
+			Report: []*configpb.CandidateRule{{Name: "dup", Literal: []string{"b"}}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestCandidateRules_Apply(t *testing.T) {
+	rules, err := NewCandidateRules(&configpb.CandidateRules{
+		Ignore: []*configpb.CandidateRule{
+			{Name: "docs-samples", Literal: []string{"EXAMPLE"}},
+			{Name: "dummy-values", Regex: []string{`^dummy_[a-z]+$`}},
+		},
+		Report: []*configpb.CandidateRule{
+			{Name: "internal-tokens", Regex: []string{`^acme_[0-9a-f]{8}`}},
+		},
+	})
+	require.NoError(t, err)
+
+	results := []Result{
+		{Raw: []byte("AKIAIOSFODNNEXAMPLE")},
+		{Raw: []byte("dummy_secret")},
+		{Raw: []byte("acme_deadbeefEXAMPLE")},
+		{Raw: []byte("real-secret")},
+		{Raw: []byte("id"), RawV2: []byte("id:dummy_value")},
+	}
+
+	assert.True(t, rules.MayIgnore([]byte("key = AKIAIOSFODNNEXAMPLE")))
+	assert.False(t, rules.MayIgnore([]byte("key = real-secret")))
+	assert.True(t, rules.Ignored(&results[0]))
+	assert.False(t, rules.Ignored(&results[2]), "report rules take precedence over ignore rules")
+
+	got := rules.Apply(results)
+	require.Len(t, got, 3)
+	assert.Equal(t, "acme_deadbeefEXAMPLE", string(got[0].Raw))
+	assert.Equal(t, "internal-tokens", got[0].MatchedReportRule)
+	assert.Equal(t, "real-secret", string(got[1].Raw))
+	assert.Empty(t, got[1].MatchedReportRule)
+	assert.Equal(t, "id", string(got[2].Raw))
+
+	assert.Equal(t, map[string]uint64{
+		"docs-samples":    1,
+		"dummy-values":    1,
+		"internal-tokens": 1,
+	}, rules.Hits())
+}
+
+func TestCandidateRules_Nil(t *testing.T) {
+	var rules *CandidateRules
+	results := []Result{{Raw: []byte("secret")}}
+
+	assert.False(t, rules.MayIgnore([]byte("secret")))
+	assert.False(t, rules.Ignored(&results[0]))
+	assert.Equal(t, results, rules.Apply(results))
+	assert.Nil(t, rules.Hits())
+}
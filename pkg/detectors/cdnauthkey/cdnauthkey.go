@@ -0,0 +1,283 @@
+package cdnauthkey
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// 加速域名来自扫描内容, 不允许访问内网地址
+	defaultClient = detectors.DetectorHttpClientWithNoLocalAddresses
+
+	// auth_key, cdn_auth_key, url_auth_key, sign_key, primary_key 等, 阿里云 6-32 位, 腾讯云 6-40 位字母和数字
+	keyPat = regexp.MustCompile(`(?i)\b(?:cdn[_-]?)?(?:url[_-]?)?(?:auth|sign(?:ing)?|primary|secondary)[_-]?(?:key|secret)["']?\s*[:=]\s*["']?([A-Za-z0-9]{6,40})\b`)
+	// auth_key 等名称很常见, 只在提到 CDN 的内容中报告
+	cdnPat = regexp.MustCompile(`(?i)cdn|kunlun|dnsv1`)
+
+	aliyunPat  = regexp.MustCompile(`(?i)aliyun|alibaba|alicdn|kunlun`)
+	tencentPat = regexp.MustCompile(`(?i)tencent|qcloud|dnsv1|cdntip`)
+	// auth_type: A, sign_type = "TypeB"
+	authTypePat = regexp.MustCompile(`(?i)(?:auth|sign)[_-]?type["']?\s*[:=]\s*["']?(?:type[_-]?)?([abcd])\b`)
+	// cdn_domain = "static.example.com", cdn_host: https://img.example.com
+	domainPat = regexp.MustCompile(`(?i)(?:cdn[_-]?)?(?:domain|host|base[_-]?url)["']?\s*[:=]\s*["']?((?:https?://)?[a-z0-9.-]+\.[a-z0-9-]+(?::[0-9]{1,5})?)`)
+
+	// B 类型的时间戳为北京时间
+	beijing = time.FixedZone("CST", 8*60*60)
+)
+
+const (
+	providerAliyun  = "aliyun"
+	providerTencent = "tencent"
+
+	// probePath 是用于验证的路径, 只关心鉴权是否通过, 不需要文件存在
+	probePath = "/"
+)
+
+// signer 生成某种鉴权方式的 URL 路径和参数
+type signer struct {
+	provider string
+	authType string
+	sign     func(path, key string, now time.Time) string
+}
+
+// signers 是各厂商支持的鉴权方式, rand 和 uid 固定为 0
+var signers = []signer{
+	{providerAliyun, "A", func(path, key string, now time.Time) string {
+		return signTypeA("auth_key", path, key, now)
+	}},
+	{providerAliyun, "B", signTypeB},
+	{providerAliyun, "C", signTypeC},
+	{providerTencent, "A", func(path, key string, now time.Time) string {
+		return signTypeA("sign", path, key, now)
+	}},
+	{providerTencent, "B", signTypeB},
+	{providerTencent, "C", signTypeC},
+	{providerTencent, "D", signTypeD},
+}
+
+// signTypeA: path?auth_key=timestamp-rand-uid-md5(path-timestamp-rand-uid-key)
+func signTypeA(param, path, key string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	hash := md5Hex(path + "-" + ts + "-0-0-" + key)
+	return path + "?" + param + "=" + ts + "-0-0-" + hash
+}
+
+// signTypeB: /YYYYMMDDHHMM/md5(key+YYYYMMDDHHMM+path)/path
+func signTypeB(path, key string, now time.Time) string {
+	ts := now.In(beijing).Format("200601021504")
+	return "/" + ts + "/" + md5Hex(key+ts+path) + path
+}
+
+// signTypeC: /md5(key+path+hex(timestamp))/hex(timestamp)/path
+func signTypeC(path, key string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 16)
+	return "/" + md5Hex(key+path+ts) + "/" + ts + path
+}
+
+// signTypeD: path?sign=md5(key+path+timestamp)&t=timestamp
+func signTypeD(path, key string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	return path + "?sign=" + md5Hex(key+path+ts) + "&t=" + ts
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"auth_key", "auth-key", "authkey", "sign_key", "primary_key", "secondary_key"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Aliyun and Tencent Cloud CDN URL signing keys in a given set of bytes.
+// Keys are verified against the accelerated domain found in the same data, if there is one.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	if !cdnPat.MatchString(dataStr) {
+		return nil, nil
+	}
+
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+	if len(uniqueKeys) == 0 {
+		return nil, nil
+	}
+
+	provider := findProvider(dataStr)
+	var authType string
+	if match := authTypePat.FindStringSubmatch(dataStr); match != nil {
+		authType = strings.ToUpper(match[1])
+	}
+	domains := findDomains(dataStr)
+
+	for _, key := range detectors.SortedKeys(uniqueKeys) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_CDNAuthKey,
+			Raw:          []byte(key),
+			ExtraData:    map[string]string{},
+		}
+		if provider != "" {
+			s1.ExtraData["provider"] = provider
+		}
+		if authType != "" {
+			s1.ExtraData["auth_type"] = authType
+		}
+
+		// 没有加速域名时无法验证
+		if verify && len(domains) > 0 {
+			s.verify(ctx, &s1, key, provider, authType, domains)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+func findProvider(data string) string {
+	aliyun, tencent := aliyunPat.MatchString(data), tencentPat.MatchString(data)
+	switch {
+	case aliyun && !tencent:
+		return providerAliyun
+	case tencent && !aliyun:
+		return providerTencent
+	default:
+		return ""
+	}
+}
+
+// findDomains returns the base URLs of the domains in data, with https as the default scheme.
+func findDomains(data string) []string {
+	var domains []string
+	seen := make(map[string]struct{})
+	for _, match := range domainPat.FindAllStringSubmatch(data, -1) {
+		domain := strings.ToLower(match[1])
+		if !strings.HasPrefix(domain, "http://") && !strings.HasPrefix(domain, "https://") {
+			domain = "https://" + domain
+		}
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+		seen[domain] = struct{}{}
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// candidateSigners returns the signers for the provider and type, or all of them if they are unknown.
+func candidateSigners(provider, authType string) []signer {
+	var candidates []signer
+	for _, sg := range signers {
+		if provider != "" && sg.provider != provider {
+			continue
+		}
+		if authType != "" && sg.authType != authType {
+			continue
+		}
+		candidates = append(candidates, sg)
+	}
+	return candidates
+}
+
+func (s Scanner) verify(ctx context.Context, result *detectors.Result, key, provider, authType string, domains []string) {
+	client := s.getClient()
+	candidates := candidateSigners(provider, authType)
+
+	var lastErr error
+	for _, domain := range domains {
+		// 未签名的请求必须被拒绝, 否则该路径没有开启 URL 鉴权, 无法判断密钥是否正确
+		status, err := probe(ctx, client, domain+probePath)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if status != http.StatusForbidden {
+			lastErr = fmt.Errorf("URL authentication is not enabled on %s (unsigned request returned status %d)", domain, status)
+			continue
+		}
+		lastErr = nil
+
+		now := time.Now()
+		for _, sg := range candidates {
+			status, err := probe(ctx, client, domain+sg.sign(probePath, key, now))
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			// 签名错误时返回 403, 签名正确时返回文件内容或 404
+			if status != http.StatusForbidden && status < http.StatusInternalServerError {
+				result.Verified = true
+				result.RawV2 = []byte(domain + "/" + key)
+				result.ExtraData["domain"] = domain
+				result.ExtraData["provider"] = sg.provider
+				result.ExtraData["auth_type"] = sg.authType
+				return
+			}
+		}
+	}
+	result.SetVerificationError(lastErr, key)
+}
+
+func probe(ctx context.Context, client *http.Client, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	// 只需要状态码
+	req.Header.Set("Range", "bytes=0-0")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+		_ = res.Body.Close()
+	}()
+	return res.StatusCode, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_CDNAuthKey
+}
+
+func (s Scanner) Description() string {
+	return "Aliyun and Tencent Cloud CDN URL signing keys sign the URLs of protected content. Anyone with a key can create valid URLs for any file on the domain, bypassing hotlink protection, and can fill the CDN cache with arbitrary requests."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
@@ -0,0 +1,153 @@
+package cdnauthkey
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	validKey   = "Xq7mB2kP9tR4wZ1n"
+	invalidKey = "a8D3fG6hJ1kL4zX7"
+)
+
+func TestCDNAuthKey_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "aliyun terraform",
+			input: `resource "alicloud_cdn_domain_config" "auth" {
+  domain_name   = "static.example.com"
+  function_name = "aliauth"
+  function_args {
+    arg_name  = "auth_type"
+    arg_value = "type_a"
+  }
+}
+cdn_auth_key = "` + validKey + `"`,
+			want: []string{validKey},
+		},
+		{
+			name: "tencent yaml",
+			input: `tencent_cdn:
+  domain: img.example.com
+  auth_type: TypeD
+  primary_key: ` + validKey + `
+  secondary_key: ` + invalidKey,
+			want: []string{validKey, invalidKey},
+		},
+		{
+			name:  "invalid pattern - no cdn context",
+			input: `auth_key = "` + validKey + `"`,
+		},
+		{
+			name:  "invalid pattern - too short",
+			input: "cdn:\n  auth_key: abc12",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestCDNAuthKey_Provider(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte("aliyun cdn\nauth_type: B\nauth_key: "+validKey))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "aliyun", results[0].ExtraData["provider"])
+	assert.Equal(t, "B", results[0].ExtraData["auth_type"])
+}
+
+// aliyunTypeA checks URLs signed with Aliyun's type A authentication.
+func aliyunTypeA(key string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Query().Get("auth_key"), "-")
+		if len(parts) != 4 || parts[3] != md5Hex(r.URL.Path+"-"+parts[0]+"-"+parts[1]+"-"+parts[2]+"-"+key) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func TestCDNAuthKey_Verify(t *testing.T) {
+	server := httptest.NewServer(aliyunTypeA(validKey))
+	defer server.Close()
+
+	d := Scanner{client: server.Client()}
+	input := "aliyun cdn\ncdn_domain: " + server.URL + "\nprimary_key: " + validKey + "\nsecondary_key: " + invalidKey
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	byKey := make(map[string]detectors.Result)
+	for _, r := range results {
+		byKey[string(r.Raw)] = r
+	}
+
+	valid := byKey[validKey]
+	assert.True(t, valid.Verified)
+	assert.NoError(t, valid.VerificationError())
+	assert.Equal(t, "A", valid.ExtraData["auth_type"])
+	assert.Equal(t, server.URL, valid.ExtraData["domain"])
+
+	invalid := byKey[invalidKey]
+	assert.False(t, invalid.Verified)
+	assert.NoError(t, invalid.VerificationError())
+}
+
+func TestCDNAuthKey_VerifyWithoutAuthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client()}
+	input := "cdn_domain: " + server.URL + "\nauth_key: " + validKey
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.Error(t, results[0].VerificationError())
+}
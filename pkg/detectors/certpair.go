@@ -0,0 +1,80 @@
+package detectors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// pemBlockPat matches PEM certificates and unencrypted private keys, including those indented in YAML block scalars
+// and those whose newlines are escaped in JSON or HCL strings.
+var pemBlockPat = regexp.MustCompile(`-----BEGIN ((?:RSA |EC )?PRIVATE KEY|CERTIFICATE)-----(?:\\n|[\sA-Za-z0-9+/=])+?-----END (?:(?:RSA |EC )?PRIVATE KEY|CERTIFICATE)-----`)
+
+// CertKeyPair is a certificate found together with its private key.
+type CertKeyPair struct {
+	Cert *x509.Certificate
+	// CertPEM and KeyPEM are the normalized PEM encodings of the certificate and the key.
+	CertPEM string
+	KeyPEM  string
+}
+
+// FindCertKeyPairs returns the certificates in data whose private key is also in data. Keys are matched to
+// certificates by their public key, so a pair proves that whoever holds the data can authenticate as the
+// certificate's subject.
+func FindCertKeyPairs(data string) []CertKeyPair {
+	var certs []*pem.Block
+	var keys []string
+	seen := make(map[string]struct{})
+	for _, match := range pemBlockPat.FindAllString(data, -1) {
+		block, normalized := normalizePEM(match)
+		if block == nil {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		if block.Type == "CERTIFICATE" {
+			certs = append(certs, block)
+		} else {
+			keys = append(keys, normalized)
+		}
+	}
+	if len(certs) == 0 || len(keys) == 0 {
+		return nil
+	}
+
+	var pairs []CertKeyPair
+	for _, block := range certs {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certPEM := string(pem.EncodeToMemory(block))
+		for _, key := range keys {
+			if _, err := tls.X509KeyPair([]byte(certPEM), []byte(key)); err == nil {
+				pairs = append(pairs, CertKeyPair{Cert: cert, CertPEM: certPEM, KeyPEM: key})
+				break
+			}
+		}
+	}
+	return pairs
+}
+
+// normalizePEM decodes a PEM block whose lines may be indented or joined by escaped newlines, and returns it with
+// its canonical encoding.
+func normalizePEM(s string) (*pem.Block, string) {
+	s = strings.ReplaceAll(s, `\n`, "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	block, _ := pem.Decode([]byte(strings.Join(lines, "\n")))
+	if block == nil {
+		return nil, ""
+	}
+	return block, string(pem.EncodeToMemory(block))
+}
@@ -0,0 +1,79 @@
+package detectors
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCertKeyPairs(t *testing.T) {
+	certPEM, keyPEM := testCertKeyPair(t, "etcd-client")
+	_, otherKeyPEM := testCertKeyPair(t, "other")
+
+	indent := func(s, prefix string) string {
+		return prefix + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n"+prefix)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{name: "pem files", data: certPEM + keyPEM, want: true},
+		{
+			name: "yaml block scalars",
+			data: "etcd_client_cert: |\n" + indent(certPEM, "  ") + "\netcd_client_key: |\n" + indent(keyPEM, "  ") + "\n",
+			want: true,
+		},
+		{
+			name: "escaped newlines",
+			data: `{"cert": "` + strings.ReplaceAll(certPEM, "\n", `\n`) + `", "key": "` + strings.ReplaceAll(keyPEM, "\n", `\n`) + `"}`,
+			want: true,
+		},
+		{name: "key of another certificate", data: certPEM + otherKeyPEM},
+		{name: "certificate only", data: certPEM},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pairs := FindCertKeyPairs(test.data)
+			if !test.want {
+				assert.Empty(t, pairs)
+				return
+			}
+			require.Len(t, pairs, 1)
+			assert.Equal(t, "etcd-client", pairs[0].Cert.Subject.CommonName)
+			assert.Equal(t, certPEM, pairs[0].CertPEM)
+			assert.Equal(t, keyPEM, pairs[0].KeyPEM)
+		})
+	}
+}
+
+// testCertKeyPair returns a self-signed certificate and its private key, PEM encoded.
+func testCertKeyPair(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
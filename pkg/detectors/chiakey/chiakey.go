@@ -0,0 +1,146 @@
+package chiakey
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+var _ detectors.ContextHintProvider = (*Scanner)(nil)
+
+var (
+	// BLS12-381 的群阶 r, 私钥必须在 [1, r-1] 之内
+	blsOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	// chia keys show --show-mnemonic-seed 的输出:
+	// Master private key (m): <64 hex>
+	// Mnemonic seed (24 secret words):
+	// <24 words>
+	masterKeyPat = regexp.MustCompile(`(?i)master private key \(m\):\s*(?:0x)?([0-9a-f]{64})\b`)
+	mnemonicPat  = regexp.MustCompile(`(?i)mnemonic seed \(24 secret words\):\s*((?:[a-z]{3,8}\s+){23}[a-z]{3,8})\b`)
+
+	// 配置或脚本中与 chia 相关的私钥和助记词
+	contextKeyPat      = regexp.MustCompile(`(?i)chia[\w.-]{0,20}?(?:secret|private|master)[_-]?(?:key|sk)["'\s:=]+(?:0x)?([0-9a-f]{64})\b`)
+	contextMnemonicPat = regexp.MustCompile(`(?i)chia[\w.-]{0,20}?(?:mnemonic|seed)(?:[_-]?phrase)?["'\s:=]+((?:[a-z]{3,8} ){23}[a-z]{3,8})\b`)
+
+	fingerprintPat = regexp.MustCompile(`(?i)fingerprint:\s*([0-9]{5,10})\b`)
+	addressPat     = regexp.MustCompile(`\b(xch1[02-9ac-hj-np-z]{58})\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"chia", "master private key", "24 secret words"}
+}
+
+// ContextHints implements detectors.ContextHintProvider, 主私钥和以太坊私钥同为 64 位十六进制
+func (s Scanner) ContextHints() []string {
+	return []string{"chia", "xch1"}
+}
+
+// FromData will find Chia master private keys and mnemonic seeds in a given set of bytes.
+// BLS 公钥的推导需要 BLS12-381 的实现, 无法得到钱包地址, 因此只做结构校验, 不做在线验证.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// chia keys show 的输出中会带有指纹和第一个钱包地址
+	extraData := map[string]string{}
+	if m := fingerprintPat.FindStringSubmatch(dataStr); m != nil {
+		extraData["fingerprint"] = m[1]
+	}
+	if m := addressPat.FindStringSubmatch(dataStr); m != nil {
+		extraData["first_wallet_address"] = m[1]
+	}
+
+	// 用于去重
+	seen := make(map[string]struct{})
+	newResult := func(kind, secret, redacted string) {
+		if _, ok := seen[secret]; ok {
+			return
+		}
+		seen[secret] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_ChiaKey,
+			Raw:          []byte(secret),
+			Redacted:     redacted,
+			ExtraData:    map[string]string{"kind": kind},
+		}
+		for k, v := range extraData {
+			s1.ExtraData[k] = v
+		}
+		results = append(results, s1)
+	}
+
+	for _, pat := range []*regexp.Regexp{masterKeyPat, contextKeyPat} {
+		for _, match := range pat.FindAllStringSubmatch(dataStr, -1) {
+			key := strings.ToLower(match[1])
+			if !isValidKey(key) {
+				continue
+			}
+			newResult("master_private_key", key, key[:8]+"...")
+		}
+	}
+
+	for _, pat := range []*regexp.Regexp{mnemonicPat, contextMnemonicPat} {
+		for _, match := range pat.FindAllStringSubmatch(dataStr, -1) {
+			words := strings.Fields(strings.ToLower(match[1]))
+			if !isValidMnemonic(words) {
+				continue
+			}
+			newResult("mnemonic", strings.Join(words, " "), words[0]+" ...")
+		}
+	}
+
+	return results, nil
+}
+
+// isValidKey 私钥必须是 BLS12-381 群阶之内的非零标量
+func isValidKey(key string) bool {
+	k, ok := new(big.Int).SetString(key, 16)
+	return ok && k.Sign() > 0 && k.Cmp(blsOrder) < 0
+}
+
+// isValidMnemonic 排除占位符, 例如同一个单词重复 24 次
+func isValidMnemonic(words []string) bool {
+	if len(words) != 24 {
+		return false
+	}
+	unique := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		unique[w] = struct{}{}
+	}
+	return len(unique) >= 12
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_ChiaKey
+}
+
+func (s Scanner) Description() string {
+	return "Chia master private keys and 24-word mnemonic seeds. Either one restores the full wallet, including all XCH, NFTs and plot farming rewards."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// 助记词和主私钥派生出同一个钱包, NFT 和 farming 奖励地址也需要迁移
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("Chia wallet, including its NFTs,") +
+		" Point the farming rewards of any plots at an address of the new wallet."
+}
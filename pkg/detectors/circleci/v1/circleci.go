@@ -2,6 +2,7 @@ package circleci
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,6 +29,8 @@ var (
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"circle"}) + `([a-fA-F0-9]{40})`)
 )
 
+const meURL = "https://circleci.com/api/v2/me"
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -73,8 +76,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 		if verify {
 			// https://circleci.com/docs/api/#authentication
-			isVerified, verificationErr := VerifyCircleCIToken(ctx, s.getClient(), token)
+			isVerified, identity, verificationErr := VerifyCircleCIToken(ctx, s.getClient(), token)
 			result.Verified = isVerified
+			for k, v := range identity {
+				result.ExtraData[k] = v
+			}
 			result.SetVerificationError(verificationErr, token)
 		}
 
@@ -84,10 +90,21 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
-func VerifyCircleCIToken(ctx context.Context, client *http.Client, token string) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://circleci.com/api/v2/me", http.NoBody)
+// VerifyCircleCIToken checks the token against the /me endpoint and returns the identity of its owner.
+func VerifyCircleCIToken(ctx context.Context, client *http.Client, token string) (bool, map[string]string, error) {
+	return verifyToken(ctx, client, meURL, token)
+}
+
+type meResponse struct {
+	ID    string `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+func verifyToken(ctx context.Context, client *http.Client, endpoint, token string) (bool, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	req.Header.Add("Accept", "application/json;")
@@ -95,7 +112,7 @@ func VerifyCircleCIToken(ctx context.Context, client *http.Client, token string)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	defer func() {
@@ -105,10 +122,25 @@ func VerifyCircleCIToken(ctx context.Context, client *http.Client, token string)
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		return true, nil
+		var me meResponse
+		if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+			return false, nil, err
+		}
+
+		identity := map[string]string{}
+		if me.Login != "" {
+			identity["login"] = me.Login
+		}
+		if me.Name != "" {
+			identity["name"] = me.Name
+		}
+		if me.ID != "" {
+			identity["user_id"] = me.ID
+		}
+		return true, identity, nil
 	case http.StatusUnauthorized:
-		return false, nil
+		return false, nil, nil
 	default:
-		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return false, nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 }
@@ -49,6 +49,7 @@ func TestCircleCI_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Circle,
 					Verified:     true,
+					ExtraData:    map[string]string{"Version": "1", "login": "", "name": "", "user_id": ""},
 				},
 			},
 			wantErr: false,
@@ -65,6 +66,7 @@ func TestCircleCI_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Circle,
 					Verified:     false,
+					ExtraData:    map[string]string{"Version": "1"},
 				},
 			},
 			wantErr: false,
@@ -97,6 +99,12 @@ func TestCircleCI_FromChunk(t *testing.T) {
 					t.Fatal("no raw secret present")
 				}
 				got[i].Raw = nil
+				// The identity of the test account may change, so only its keys are compared.
+				for k := range got[i].ExtraData {
+					if k != "Version" {
+						got[i].ExtraData[k] = ""
+					}
+				}
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("CircleCI.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
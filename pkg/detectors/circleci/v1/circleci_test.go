@@ -2,9 +2,14 @@ package circleci
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
@@ -89,3 +94,29 @@ func TestCircleCI_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestCircleCI_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Circle-Token") != secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"You must log in first."}`)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Jane Doe","login":"jdoe","id":"4d3c2b1a-0000-4000-8000-123456789abc"}`)
+	}))
+	defer server.Close()
+
+	verified, identity, err := verifyToken(context.Background(), server.Client(), server.URL, secret)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{
+		"login":   "jdoe",
+		"name":    "Jane Doe",
+		"user_id": "4d3c2b1a-0000-4000-8000-123456789abc",
+	}, identity)
+
+	verified, identity, err = verifyToken(context.Background(), server.Client(), server.URL, "0000000000000000000000000000000000000000")
+	require.NoError(t, err)
+	assert.False(t, verified)
+	assert.Nil(t, identity)
+}
@@ -71,8 +71,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			isVerified, verificationErr := v1.VerifyCircleCIToken(ctx, s.getClient(), token)
+			isVerified, identity, verificationErr := v1.VerifyCircleCIToken(ctx, s.getClient(), token)
 			result.Verified = isVerified
+			for k, v := range identity {
+				result.ExtraData[k] = v
+			}
 			result.SetVerificationError(verificationErr, token)
 		}
 
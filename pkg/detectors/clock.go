@@ -0,0 +1,43 @@
+package detectors
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// scanTime pins the clock returned by Now. Nil means the wall clock.
+var scanTime atomic.Pointer[time.Time]
+
+// SetScanTime pins the time at which the scan is considered to run, so that decisions depending on the current
+// time, such as whether a credential has expired, are reproducible. A zero time restores the wall clock. This
+// should be called before any scans are started.
+func SetScanTime(t time.Time) {
+	if t.IsZero() {
+		scanTime.Store(nil)
+		return
+	}
+	scanTime.Store(&t)
+}
+
+// Now returns the pinned scan time, if any, or the current time. Detectors use it for offline decisions that
+// depend on the current time; verification requests use the wall clock regardless.
+func Now() time.Time {
+	if t := scanTime.Load(); t != nil {
+		return *t
+	}
+	return time.Now()
+}
+
+// ParseScanTime parses a scan time given as an RFC 3339 timestamp or, like SOURCE_DATE_EPOCH, as Unix seconds.
+func ParseScanTime(s string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid scan time %q, expected RFC 3339 or Unix seconds", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,32 @@
+package detectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanTime(t *testing.T) {
+	pinned := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	SetScanTime(pinned)
+	t.Cleanup(func() { SetScanTime(time.Time{}) })
+	assert.Equal(t, pinned, Now())
+
+	SetScanTime(time.Time{})
+	assert.WithinDuration(t, time.Now(), Now(), time.Minute)
+}
+
+func TestParseScanTime(t *testing.T) {
+	got, err := ParseScanTime("1709251200")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = ParseScanTime("2024-03-01T08:00:00+08:00")
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = ParseScanTime("yesterday")
+	assert.Error(t, err)
+}
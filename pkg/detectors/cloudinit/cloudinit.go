@@ -0,0 +1,358 @@
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	regexp "github.com/wasilibs/go-re2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	aws_access_keys "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/aws/access_keys"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethereumprivatekey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gcp"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+
+var (
+	// Terraform 的 user_data / user_data_base64, CloudFormation 的 UserData, Azure 的 custom_data,
+	// 以及 aws ec2 describe-instance-attribute 输出的 {"UserData": {"Value": "..."}} 中的 base64 值
+	encodedUserDataPat = regexp.MustCompile(`(?i)["']?\b(?:user[_-]?data(?:_base64)?|custom[_-]?data)["']?\s*[:=]\s*(?:\{\s*"Value"\s*:\s*)?["']?([A-Za-z0-9+/]{24,}={0,2})`)
+	// Terraform heredoc 形式: user_data = <<-EOF 或 user_data = base64encode(<<EOF
+	heredocUserDataPat = regexp.MustCompile(`(?i)\b(?:user_data(?:_base64)?|custom_data)\s*=\s*(?:base64(?:gzip|encode)\()?<<-?([A-Z_]+)\r?\n`)
+	// 直接出现的 cloud-config 文档, 如仓库中的 user-data 文件或 MIME 多部分消息中的一部分
+	cloudConfigPat = regexp.MustCompile(`(?m)^#cloud-config[ \t]*$`)
+	pemKeyPat      = regexp.MustCompile(`-----BEGIN[ A-Z0-9]*PRIVATE KEY-----[\s\S]*?-----END[ A-Z0-9]*PRIVATE KEY-----`)
+)
+
+const (
+	// maxDepth 限制 base64, gzip, MIME 嵌套解码的层数
+	maxDepth = 4
+	// maxDecodedSize 单个解码结果的大小上限, 避免 gzip 炸弹
+	maxDecodedSize = 1 << 20
+)
+
+// downstream 是在解码后的 user-data 中查找的密钥类型
+var downstream = detectors.Downstream{
+	aws_access_keys.New(),
+	gcp.Scanner{},
+	ethereumprivatekey.Scanner{},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+func (s Scanner) Keywords() []string {
+	return []string{"user_data", "userdata", "user-data", "custom_data", "customdata", "#cloud-config"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return 64 * 1024 }
+
+// document 是从 user-data 中还原出的一段文本
+type document struct {
+	text string
+	// origin 描述文本的来源及解码过程, 如 "user-data: base64, gzip"
+	origin string
+	// decoded 表示文本经过了解码, 其他 detector 在原始数据中看不到它
+	decoded bool
+}
+
+// writeFile 对应 cloud-config 的 write_files 条目
+type writeFile struct {
+	Path        string `yaml:"path"`
+	Content     string `yaml:"content"`
+	Encoding    string `yaml:"encoding"`
+	Owner       string `yaml:"owner"`
+	Permissions string `yaml:"permissions"`
+}
+
+type cloudConfig struct {
+	WriteFiles []writeFile `yaml:"write_files"`
+}
+
+// FromData will find secrets in cloud-init user-data in a given set of bytes. User-data is decoded recursively
+// (base64, gzip, MIME multi-part), private keys written by write_files are reported by this detector, and other
+// credentials are reported and verified by the detector that recognizes them.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var docs []document
+	for _, match := range encodedUserDataPat.FindAllStringSubmatch(dataStr, -1) {
+		if decoded, ok := decodeBase64(match[1]); ok {
+			docs = expand(docs, decoded, "user-data: base64", true, 1)
+		}
+	}
+	for _, match := range heredocUserDataPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		if body, ok := heredocBody(dataStr[match[1]:], dataStr[match[2]:match[3]]); ok {
+			docs = expand(docs, []byte(body), "user-data: terraform heredoc", false, 1)
+		}
+	}
+	if loc := cloudConfigPat.FindStringIndex(dataStr); loc != nil {
+		docs = append(docs, document{text: dataStr[loc[0]:], origin: "cloud-config"})
+	}
+
+	seen := make(map[string]struct{})
+	add := func(r detectors.Result) {
+		key := r.DetectorType.String() + "\x00" + string(r.Raw) + "\x00" + string(r.RawV2)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		results = append(results, r)
+	}
+
+	for _, doc := range docs {
+		extraData := map[string]string{"user_data_source": doc.origin}
+		// 只有解码得到的内容需要交给其他 detector, 明文内容它们会直接扫描到
+		if doc.decoded {
+			for _, r := range s.route(ctx, verify, doc.text, extraData) {
+				add(r)
+			}
+		}
+		if !cloudConfigPat.MatchString(doc.text) {
+			continue
+		}
+
+		var cfg cloudConfig
+		if err := yaml.Unmarshal([]byte(doc.text), &cfg); err != nil {
+			continue
+		}
+		for _, f := range cfg.WriteFiles {
+			content, decoded, ok := decodeWriteFile(f)
+			if !ok {
+				continue
+			}
+			fileData := map[string]string{"user_data_source": doc.origin, "path": f.Path}
+			if f.Permissions != "" {
+				fileData["permissions"] = f.Permissions
+			}
+			if f.Owner != "" {
+				fileData["owner"] = f.Owner
+			}
+
+			for _, key := range pemKeyPat.FindAllString(content, -1) {
+				s1 := detectors.Result{
+					DetectorType: detector_typepb.DetectorType_CloudInitUserData,
+					Raw:          []byte(key),
+					RawV2:        []byte(f.Path + ":" + key),
+					Redacted:     f.Path,
+					// user-data 对实例内所有进程可读 (169.254.169.254), 也常被提交进 IaC 仓库
+					Severity:  detectors.SeverityHigh,
+					ExtraData: copyMap(fileData),
+				}
+				add(s1)
+			}
+			if decoded || doc.decoded {
+				for _, r := range s.route(ctx, verify, content, fileData) {
+					add(r)
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// route 把解码后的文本交给其他 detector, 结果附带 user-data 的来源
+func (s Scanner) route(ctx context.Context, verify bool, text string, extraData map[string]string) []detectors.Result {
+	results := downstream.FromData(ctx, verify, []byte(text))
+	for i := range results {
+		if results[i].ExtraData == nil {
+			results[i].ExtraData = make(map[string]string)
+		}
+		for k, v := range extraData {
+			results[i].ExtraData[k] = v
+		}
+	}
+	return results
+}
+
+// expand 递归解码 user-data: gzip, MIME 多部分消息和 base64, 直到得到文本
+func expand(docs []document, data []byte, origin string, decoded bool, depth int) []document {
+	if depth > maxDepth || len(data) == 0 {
+		return docs
+	}
+	if isGzip(data) {
+		if out, ok := gunzip(data); ok {
+			return expand(docs, out, origin+", gzip", true, depth+1)
+		}
+		return docs
+	}
+	if parts, ok := multipartParts(data); ok {
+		for _, part := range parts {
+			docs = expand(docs, part.data, origin+", multipart", decoded || part.decoded, depth+1)
+		}
+		return docs
+	}
+	// base64 文本只会按行折断, 不含空格
+	if !bytes.ContainsAny(bytes.TrimSpace(data), " \t") {
+		if out, ok := decodeBase64(string(data)); ok {
+			return expand(docs, out, origin+", base64", true, depth+1)
+		}
+	}
+	if !utf8.Valid(data) {
+		return docs
+	}
+	return append(docs, document{text: string(data), origin: origin, decoded: decoded})
+}
+
+// decodeWriteFile 按 write_files 的 encoding 字段解码文件内容
+func decodeWriteFile(f writeFile) (content string, decoded bool, ok bool) {
+	data := []byte(f.Content)
+	switch strings.ToLower(f.Encoding) {
+	case "", "text/plain":
+		return f.Content, false, true
+	case "b64", "base64":
+		out, ok := decodeBase64(f.Content)
+		return string(out), true, ok
+	case "gz", "gzip":
+		out, ok := gunzip(data)
+		return string(out), true, ok
+	case "gz+b64", "gz+base64", "gzip+b64", "gzip+base64":
+		raw, ok := decodeBase64(f.Content)
+		if !ok {
+			return "", false, false
+		}
+		out, ok := gunzip(raw)
+		return string(out), true, ok
+	default:
+		return "", false, false
+	}
+}
+
+// heredocBody 返回 heredoc 从开头到结束标记之间的内容
+func heredocBody(rest, tag string) (string, bool) {
+	offset := 0
+	for offset < len(rest) {
+		end := strings.IndexByte(rest[offset:], '\n')
+		line := rest[offset:]
+		if end >= 0 {
+			line = rest[offset : offset+end]
+		}
+		if strings.TrimSpace(line) == tag {
+			return rest[:offset], true
+		}
+		if end < 0 {
+			break
+		}
+		offset += end + 1
+	}
+	return "", false
+}
+
+// decodeBase64 解码 base64 文本, 结果必须是 gzip 数据或可打印文本
+func decodeBase64(s string) ([]byte, bool) {
+	s = strings.Join(strings.Fields(strings.ReplaceAll(s, `\n`, "")), "")
+	if len(s) < 16 {
+		return nil, false
+	}
+	out, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		if out, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "=")); err != nil {
+			return nil, false
+		}
+	}
+	if isGzip(out) || (utf8.Valid(out) && isPrintable(out)) {
+		return out, true
+	}
+	return nil, false
+}
+
+func isGzip(data []byte) bool {
+	return len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+func gunzip(data []byte) ([]byte, bool) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, maxDecodedSize))
+	if err != nil && len(out) == 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// mimePart 是 MIME 多部分消息中的一部分
+type mimePart struct {
+	data    []byte
+	decoded bool
+}
+
+// multipartParts 拆分 cloud-init 的 MIME 多部分 user-data, 按 Content-Transfer-Encoding 解码每一部分
+func multipartParts(data []byte) ([]mimePart, bool) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("multipart/")) {
+		return nil, false
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return nil, false
+	}
+
+	var parts []mimePart
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		body, err := io.ReadAll(io.LimitReader(part, maxDecodedSize))
+		if err != nil {
+			continue
+		}
+		p := mimePart{data: body}
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+			out, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+			if err != nil {
+				continue
+			}
+			p = mimePart{data: out, decoded: true}
+		}
+		parts = append(parts, p)
+	}
+	return parts, len(parts) > 0
+}
+
+func isPrintable(data []byte) bool {
+	for _, r := range string(data) {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_CloudInitUserData
+}
+
+func (s Scanner) Description() string {
+	return "cloud-init user-data passed to virtual machines by Terraform, CloudFormation or cloud consoles, often base64 or gzip encoded. Any process on the instance can read it from the metadata service, so private keys and credentials written through it are widely exposed."
+}
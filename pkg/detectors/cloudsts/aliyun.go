@@ -0,0 +1,115 @@
+package cloudsts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const aliyunEndpoint = "https://sts.aliyuncs.com"
+
+type aliyunResponse struct {
+	AccountId    string `json:"AccountId"`
+	Arn          string `json:"Arn"`
+	IdentityType string `json:"IdentityType"`
+	Code         string `json:"Code"`
+	Message      string `json:"Message"`
+}
+
+// verifyAliyun 调用 STS GetCallerIdentity, 该接口不需要任何权限, 只要凭证有效就会返回调用者身份
+func verifyAliyun(ctx context.Context, client *http.Client, cred credential) (bool, map[string]string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, nil, err
+	}
+	params := map[string]string{
+		"Action":           "GetCallerIdentity",
+		"Version":          "2015-04-01",
+		"Format":           "JSON",
+		"AccessKeyId":      cred.id,
+		"SecurityToken":    cred.token,
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureVersion": "1.0",
+		"SignatureNonce":   hex.EncodeToString(nonce),
+		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	query := aliyunCanonicalQuery(params)
+	query += "&Signature=" + aliyunPercentEncode(aliyunSign(http.MethodGet, query, cred.secret))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aliyunEndpoint+"/?"+query, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	var body aliyunResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK:
+		return true, map[string]string{
+			"status":        "valid",
+			"account_id":    body.AccountId,
+			"arn":           body.Arn,
+			"identity_type": body.IdentityType,
+		}, nil
+	case body.Code == "InvalidSecurityToken.Expired":
+		return false, map[string]string{"status": "expired"}, nil
+	case strings.HasPrefix(body.Code, "InvalidSecurityToken"),
+		strings.HasPrefix(body.Code, "InvalidAccessKeyId"),
+		body.Code == "SignatureDoesNotMatch":
+		return false, map[string]string{"status": "invalid"}, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d: %s", res.StatusCode, body.Code)
+	}
+}
+
+// aliyunCanonicalQuery 按参数名排序并编码, 作为 RPC 签名 v1 的规范化请求字符串
+func aliyunCanonicalQuery(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, aliyunPercentEncode(k)+"="+aliyunPercentEncode(params[k]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+func aliyunSign(method, canonicalQuery, secret string) string {
+	stringToSign := method + "&" + aliyunPercentEncode("/") + "&" + aliyunPercentEncode(canonicalQuery)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// aliyunPercentEncode 按 RFC 3986 编码, 与 url.QueryEscape 的区别在于空格, 星号和波浪号
+func aliyunPercentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	return strings.ReplaceAll(s, "%7E", "~")
+}
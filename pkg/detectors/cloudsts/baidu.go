@@ -0,0 +1,80 @@
+package cloudsts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	baiduHost = "bcc.bj.baidubce.com"
+	baiduPath = "/v2/zone"
+)
+
+type baiduError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// verifyBaidu 调用 BCC 查询可用区接口, 临时凭证的 sessionToken 通过 x-bce-security-token 头传递
+func verifyBaidu(ctx context.Context, client *http.Client, cred credential) (bool, map[string]string, error) {
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05Z")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+baiduHost+baiduPath, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("x-bce-date", timestamp)
+	req.Header.Set("x-bce-security-token", cred.token)
+	req.Header.Set("Authorization", baiduAuthorization(cred.id, cred.secret, timestamp))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode == http.StatusOK {
+		return true, map[string]string{"status": "valid"}, nil
+	}
+
+	var body baiduError
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	switch body.Code {
+	case "AccessDenied":
+		// 签名通过, 只是角色没有 BCC 权限
+		return true, map[string]string{"status": "valid"}, nil
+	case "SessionTokenExpired":
+		return false, map[string]string{"status": "expired"}, nil
+	case "InvalidSessionToken", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return false, map[string]string{"status": "invalid"}, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d: %s", res.StatusCode, body.Code)
+	}
+}
+
+// baiduAuthorization 计算 bce-auth-v1 签名, 签名头只包含 host 和 x-bce-date
+func baiduAuthorization(ak, sk, timestamp string) string {
+	const signedHeaders = "host;x-bce-date"
+	authStringPrefix := fmt.Sprintf("bce-auth-v1/%s/%s/1800", ak, timestamp)
+	signingKey := hex.EncodeToString(hmacSHA256([]byte(sk), authStringPrefix))
+
+	canonicalRequest := http.MethodGet + "\n" +
+		baiduPath + "\n" +
+		"\n" +
+		"host:" + baiduHost + "\n" +
+		"x-bce-date:" + url.QueryEscape(timestamp)
+	signature := hex.EncodeToString(hmacSHA256([]byte(signingKey), canonicalRequest))
+
+	return authStringPrefix + "/" + signedHeaders + "/" + signature
+}
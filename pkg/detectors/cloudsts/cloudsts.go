@@ -0,0 +1,197 @@
+package cloudsts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.KeywordPackProvider = (*Scanner)(nil)
+
+var defaultClient = common.SaneHttpClient()
+
+// provider 描述一种云厂商的 STS 临时凭证: AccessKeyId, AccessKeySecret 和 SecurityToken 三元组
+type provider struct {
+	name      string
+	idPat     *regexp.Regexp
+	secretPat *regexp.Regexp
+	tokenPat  *regexp.Regexp
+	verify    func(ctx context.Context, client *http.Client, cred credential) (bool, map[string]string, error)
+}
+
+type credential struct {
+	id, secret, token string
+}
+
+var (
+	providers = []provider{
+		{
+			// 阿里云: AccessKeyId 以 STS. 开头, SecurityToken 以 CAIS 开头
+			name:      "aliyun",
+			idPat:     regexp.MustCompile(`\b(STS\.[A-Za-z0-9]{16,40})\b`),
+			secretPat: regexp.MustCompile(`(?i)(?:access_?key_?secret|` + detectors.ChineseKeyLabelPattern + `)["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([A-Za-z0-9]{30,50})\b`),
+			tokenPat:  regexp.MustCompile(`\b(CAIS[A-Za-z0-9+/]{100,}={0,2})`),
+			verify:    verifyAliyun,
+		},
+		{
+			// 腾讯云: TmpSecretId 以 AKID 开头, 比永久密钥更长
+			name:      "tencent",
+			idPat:     regexp.MustCompile(`\b(AKID[A-Za-z0-9_-]{32,100})\b`),
+			secretPat: regexp.MustCompile(`(?i)(?:(?:tmp_?)?secret_?key|` + detectors.ChineseKeyLabelPattern + `)["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([A-Za-z0-9+/=]{32,64})`),
+			tokenPat:  regexp.MustCompile(`(?i)(?:(?:session_?|security_?|x-tc-)?token|令牌)["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([A-Za-z0-9_\-+/=.]{100,})`),
+			verify:    verifyTencent,
+		},
+		{
+			// 百度智能云: accessKeyId 和 secretAccessKey 都是 32 位十六进制, 需要 sessionToken 上下文
+			name:      "baidu",
+			idPat:     regexp.MustCompile(`(?i)access_?key_?id["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([a-f0-9]{32})\b`),
+			secretPat: regexp.MustCompile(`(?i)(?:secret_?access_?key|` + detectors.ChineseKeyLabelPattern + `)["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([a-f0-9]{32})\b`),
+			tokenPat:  regexp.MustCompile(`(?i)(?:session_?token|令牌)["'\s:=` + detectors.FullWidthSeparators + `]+["'“]?([A-Za-z0-9+/=_-]{100,})`),
+			verify:    verifyBaidu,
+		},
+	}
+
+	// STS 接口返回的过期时间字段: Expiration (阿里云/百度 ISO8601), ExpiredTime (腾讯云 Unix 时间戳)
+	expirationPat = regexp.MustCompile(`(?i)["']?(?:expiration|expired_?time|expire_?time)["']?\s*[:=]\s*["']?(\d{10}|\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"STS.", "CAIS", "TmpSecret", "tmp_secret", "sessionToken", "session_token", "SecurityToken", "security_token"}
+}
+
+// KeywordPacks implements detectors.KeywordPackProvider, 中文配置中的 STS 凭证常以 密钥/令牌 标注
+func (s Scanner) KeywordPacks() []string {
+	return []string{detectors.KeywordPackChinese}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Aliyun, Tencent Cloud and Baidu Cloud STS credentials in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var expiresAt time.Time
+	if m := expirationPat.FindStringSubmatch(dataStr); m != nil {
+		expiresAt, _ = ParseExpiration(m[1])
+	}
+
+	for _, p := range providers {
+		// 临时凭证必须三者齐全, 缺少 SecurityToken 的交给永久密钥的 detector 处理
+		tokens := uniqueMatches(p.tokenPat, dataStr)
+		if len(tokens) == 0 {
+			continue
+		}
+		ids := uniqueMatches(p.idPat, dataStr)
+		secrets := uniqueMatches(p.secretPat, dataStr)
+
+		for _, id := range ids {
+			for _, secret := range secrets {
+				for _, token := range tokens {
+					cred := credential{id: id, secret: secret, token: token}
+					s1 := detectors.Result{
+						DetectorType: detector_typepb.DetectorType_CloudSTS,
+						Raw:          []byte(id),
+						RawV2:        []byte(id + ":" + secret),
+						Redacted:     id,
+						// 临时凭证有效期很短, 但在有效期内和永久密钥一样危险
+						Severity:  detectors.SeverityHigh,
+						ExtraData: map[string]string{"provider": p.name},
+					}
+					if !expiresAt.IsZero() {
+						s1.SetExpiresAt(expiresAt)
+						if detectors.Now().After(expiresAt) {
+							s1.ExtraData["status"] = "expired"
+						}
+					}
+
+					if verify {
+						isVerified, extraData, verificationErr := p.verify(ctx, s.getClient(), cred)
+						s1.Verified = isVerified
+						for k, v := range extraData {
+							s1.ExtraData[k] = v
+						}
+						if isVerified && !expiresAt.IsZero() {
+							s1.ExtraData["remaining"] = time.Until(expiresAt).Truncate(time.Second).String()
+						}
+						s1.SetVerificationError(verificationErr, secret, token)
+					}
+
+					results = append(results, s1)
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// VerifyCredential 验证指定云厂商 ("aliyun", "tencent" 或 "baidu") 的 STS 临时凭证, 供从其他格式中提取出三元组的 detector 复用
+func VerifyCredential(ctx context.Context, client *http.Client, providerName, id, secret, token string) (bool, map[string]string, error) {
+	for _, p := range providers {
+		if p.name == providerName {
+			return p.verify(ctx, client, credential{id: id, secret: secret, token: token})
+		}
+	}
+	return false, nil, fmt.Errorf("unknown STS provider %q", providerName)
+}
+
+func uniqueMatches(pat *regexp.Regexp, data string) []string {
+	var out []string
+	seen := make(map[string]struct{})
+	for _, m := range pat.FindAllStringSubmatch(data, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// ParseExpiration 解析 Unix 时间戳或 ISO8601 格式的过期时间, 其他 detector 也用它解析 STS 凭证的有效期
+func ParseExpiration(s string) (time.Time, bool) {
+	if len(s) == 10 && !strings.Contains(s, "-") {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(sec, 0), true
+	}
+	s = strings.Replace(s, " ", "T", 1)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_CloudSTS
+}
+
+func (s Scanner) Description() string {
+	return "Temporary STS credentials (AccessKeyId, AccessKeySecret and SecurityToken) issued by Aliyun, Tencent Cloud or Baidu Cloud when assuming a RAM/CAM role. They are short-lived, but until they expire they carry all permissions of the assumed role."
+}
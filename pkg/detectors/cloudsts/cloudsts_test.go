@@ -0,0 +1,155 @@
+package cloudsts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+var (
+	aliyunInput = `{
+		"RequestId": "6894B13B-6D71-4EF5-88FA-F32781734A7F",
+		"Credentials": {
+			"SecurityToken": "CAISu8jzPde0IgxLd6GncfBAepfJBd0Kh8oOOL8dKLzdocJ2isAjIhKtJ0RlgLKOmxgJTeKdNnFRIBXuDL7DxtpYlSXpfKtHF4vUCsMehGAkWvj7FAc9QeWJKY40",
+			"AccessKeyId": "STS.uvSwMFLZDe1f8rESQedUStPKR0Cs",
+			"AccessKeySecret": "Ty4Qwb8DwkNhFdnXsiVpzz63FfkCzJ",
+			"Expiration": "2015-04-09T11:52:19Z"
+		}
+	}`
+
+	tencentInput = `{
+		"Credentials": {
+			"Token": "EEtfjgVvVqE1SkHbn88HxjSI6bWHtP3fS2qHx6kwXoIIXGvOoNZYW2mZp0zVZomHFwUbbYrEqmSM9wCZ7Uw9xfogoEmvnEN5N1aE6PwZPf1Qh6yYTWmE4lBYOvfZ8UzDzV8fUkkibjL5",
+			"TmpSecretId": "AKIDr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAIxNKu8iS2G8NPRVdD53X83RZJzz",
+			"TmpSecretKey": "zzgEOzdmenCkhvMdgaKjIg8xNbe3nNyjOq9wMxEhh2FD"
+		},
+		"ExpiredTime": 1543914376,
+		"Expiration": "2018-12-04T09:06:16Z"
+	}`
+
+	baiduInput = `{
+		"accessKeyId": "e4fb440034d6608697a8d41bed440e50",
+		"secretAccessKey": "454f31af3176813e02ea68ef786e4d3c",
+		"sessionToken": "CueQpBenQtYh5Xj8TPQxjq4i9DoV8gz4FkQ1okTBGzvAmwufUxbvJDCTbyvHNsG9eh6Yo4gfqrc5XlrWi0B26R08qzjI6GKFSufrdZSlB5er8bOfZqfM2oeq",
+		"createTime": "2017-11-22T11:22:12Z",
+		"expiration": "2017-11-22T12:22:12Z"
+	}`
+)
+
+func TestCloudSTS_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "aliyun AssumeRole response",
+			input: aliyunInput,
+			want:  []string{"STS.uvSwMFLZDe1f8rESQedUStPKR0Cs:Ty4Qwb8DwkNhFdnXsiVpzz63FfkCzJ"},
+		},
+		{
+			name:  "tencent AssumeRole response",
+			input: tencentInput,
+			want:  []string{"AKIDr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAIxNKu8iS2G8NPRVdD53X83RZJzz:zzgEOzdmenCkhvMdgaKjIg8xNbe3nNyjOq9wMxEhh2FD"},
+		},
+		{
+			name:  "baidu sts credential",
+			input: baiduInput,
+			want:  []string{"e4fb440034d6608697a8d41bed440e50:454f31af3176813e02ea68ef786e4d3c"},
+		},
+		{
+			name: "tencent credential in a Chinese document",
+			input: `
+				临时密钥 TmpSecretId：AKIDr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAIxNKu8iS2G8NPRVdD53X83RZJzz
+				密钥：“zzgEOzdmenCkhvMdgaKjIg8xNbe3nNyjOq9wMxEhh2FD”
+				令牌：EEtfjgVvVqE1SkHbn88HxjSI6bWHtP3fS2qHx6kwXoIIXGvOoNZYW2mZp0zVZomHFwUbbYrEqmSM9wCZ7Uw9xfogoEmvnEN5N1aE6PwZPf1Qh6yYTWmE4lBYOvfZ8UzDzV8fUkkibjL5
+			`,
+			want: []string{"AKIDr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAIxNKu8iS2G8NPRVdD53X83RZJzz:zzgEOzdmenCkhvMdgaKjIg8xNbe3nNyjOq9wMxEhh2FD"},
+		},
+		{
+			name: "invalid pattern - missing security token",
+			input: `
+				AccessKeyId: STS.uvSwMFLZDe1f8rESQedUStPKR0Cs
+				AccessKeySecret: Ty4Qwb8DwkNhFdnXsiVpzz63FfkCzJ
+				SecurityToken: <redacted>
+			`,
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestCloudSTS_Expiration(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(tencentInput))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "tencent", results[0].ExtraData["provider"])
+	assert.Equal(t, "2018-12-04T09:06:16Z", results[0].ExtraData["expires_at"])
+	assert.Equal(t, "expired", results[0].ExtraData["status"])
+
+	for _, s := range []string{"1543914376", "2018-12-04T09:06:16Z", "2018-12-04 17:06:16+08:00", "2018-12-04T17:06:16.000+0800"} {
+		got, ok := ParseExpiration(s)
+		require.True(t, ok, s)
+		assert.True(t, got.Equal(time.Unix(1543914376, 0)), s)
+	}
+}
+
+func TestAliyunSign(t *testing.T) {
+	// Example from the Aliyun RPC signature documentation.
+	params := map[string]string{
+		"AccessKeyId":      "testid",
+		"Action":           "DescribeRegions",
+		"Format":           "XML",
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureNonce":   "3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf",
+		"SignatureVersion": "1.0",
+		"Timestamp":        "2016-02-23T12:46:24Z",
+		"Version":          "2014-05-26",
+	}
+	assert.Equal(t, "OLeaidS1JvxuMvnyHOwuJ+uX5qY=", aliyunSign("GET", aliyunCanonicalQuery(params), "testsecret"))
+}
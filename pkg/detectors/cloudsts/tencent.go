@@ -0,0 +1,127 @@
+package cloudsts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tencentHost    = "sts.tencentcloudapi.com"
+	tencentService = "sts"
+)
+
+type tencentResponse struct {
+	Response struct {
+		Arn       string `json:"Arn"`
+		AccountId string `json:"AccountId"`
+		UserId    string `json:"UserId"`
+		Type      string `json:"Type"`
+		Error     *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+	} `json:"Response"`
+}
+
+// verifyTencent 调用 STS GetCallerIdentity, 临时凭证的 Token 通过 X-TC-Token 头传递
+func verifyTencent(ctx context.Context, client *http.Client, cred credential) (bool, map[string]string, error) {
+	const payload = "{}"
+	now := time.Now().UTC()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+tencentHost, strings.NewReader(payload))
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-TC-Action", "GetCallerIdentity")
+	req.Header.Set("X-TC-Version", "2018-08-13")
+	req.Header.Set("X-TC-Region", "ap-guangzhou")
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set("X-TC-Token", cred.token)
+	req.Header.Set("Authorization", tencentAuthorization(cred.id, cred.secret, payload, now))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	var body tencentResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, nil, err
+	}
+
+	// 腾讯云 API 3.0 无论成功与否都返回 200, 错误信息在 Response.Error 中
+	if e := body.Response.Error; e != nil {
+		switch {
+		case e.Code == "AuthFailure.TokenFailure" && strings.Contains(strings.ToLower(e.Message), "expire"):
+			return false, map[string]string{"status": "expired"}, nil
+		case strings.HasPrefix(e.Code, "AuthFailure"):
+			return false, map[string]string{"status": "invalid"}, nil
+		default:
+			return false, nil, fmt.Errorf("unexpected error code %s", e.Code)
+		}
+	}
+	return true, map[string]string{
+		"status":     "valid",
+		"account_id": body.Response.AccountId,
+		"arn":        body.Response.Arn,
+	}, nil
+}
+
+// tencentAuthorization 计算 TC3-HMAC-SHA256 签名
+func tencentAuthorization(secretID, secretKey, payload string, now time.Time) string {
+	const signedHeaders = "content-type;host"
+	date := now.Format("2006-01-02")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:application/json; charset=utf-8\nhost:" + tencentHost + "\n",
+		signedHeaders,
+		sha256Hex(payload),
+	}, "\n")
+
+	credentialScope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(now.Unix(), 10),
+		credentialScope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, tencentService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		secretID, credentialScope, signedHeaders, signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,327 @@
+package codesigning
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+	"golang.org/x/crypto/pkcs12"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.PasswordDecrypter = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// base64 编码的 PKCS#12 (PFX/p12) 文件, CI 中常以 BUILD_CERTIFICATE_BASE64, CSC_LINK 等变量保存.
+	// DER 开头固定为 30 82 LL LL 02 01 03 30 82, 即 SEQUENCE, version 3, SEQUENCE
+	pkcs12Pat = regexp.MustCompile(`\bMI[I-L][A-Za-z0-9+/]{2}[AQgw]IBAzCC[A-Za-z0-9+/]{200,}={0,2}`)
+	// 以 password, pass 或 passphrase 结尾的变量赋值, 如 MATCH_PASSWORD: xxx, storePassword "xxx"
+	passwordPat = regexp.MustCompile(`(?i)\b([a-z][a-z0-9_.-]*?pass(?:word|phrase)?)["']?\s*(?:[:=]\s*["']?|["'])([^\s"'` + "`" + `,;]{4,64})`)
+	// signtool sign /f cert.pfx /p password
+	signtoolPat = regexp.MustCompile(`(?i)\bsigntool(?:\.exe)?\s+sign\b[^\n]*?\s[/-]p\s+("[^"\n]{4,64}"|[^\s"]{4,64})`)
+)
+
+const (
+	platformAndroid      = "android"
+	platformApple        = "apple"
+	platformAuthenticode = "authenticode"
+	platformCertificate  = "certificate"
+)
+
+// signingPasswords 以规范化后的变量名为键, 规范化规则见 normalizeName
+var signingPasswords = map[string]string{
+	"keystorepassword":                    platformAndroid,
+	"keystorepass":                        platformAndroid,
+	"androidkeystorepassword":             platformAndroid,
+	"signingstorepassword":                platformAndroid,
+	"signingkeypassword":                  platformAndroid,
+	"androidinjectedsigningstorepassword": platformAndroid,
+	"androidinjectedsigningkeypassword":   platformAndroid,
+	"matchpassword":                       platformApple,
+	"p12password":                         platformApple,
+	"iosp12password":                      platformApple,
+	"applecertificatepassword":            platformApple,
+	"pfxpassword":                         platformAuthenticode,
+	"csckeypassword":                      platformAuthenticode,
+	"wincsckeypassword":                   platformAuthenticode,
+	"windowscertificatepassword":          platformAuthenticode,
+	"codesigningpassword":                 platformAuthenticode,
+	"certificatepassword":                 platformCertificate,
+	"buildcertificatepassword":            platformCertificate,
+	"signingcertificatepassword":          platformCertificate,
+}
+
+// gradleSigningPasswords 只在 Gradle signingConfigs 中才是签名密码
+var gradleSigningPasswords = map[string]struct{}{
+	"storepassword": {},
+	"keypassword":   {},
+}
+
+const (
+	// 每个 PKCS#12 文件最多尝试的密码数量, 每次尝试都要做 PBKDF
+	maxPasswordAttempts = 10
+	// base64 编码的证书链通常只有几 KB
+	maxPKCS12Size = 64 * 1024
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{
+		"ibazcc", "keystore", "signing", "match_password", "matchpassword", "p12_", "p12p", "pfx_", "pfxp",
+		"csc_key", "certificate_password", "certificatepassword", "signtool",
+	}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxPKCS12Size }
+
+// FromData will find code-signing certificates (Authenticode PFX, Apple p12) and the passwords of signing keys and
+// keystores in CI configs and build files in a given set of bytes. Certificates that open with an empty password or
+// a password found in the same data are reported with their subject and expiry.
+func (s Scanner) FromData(_ context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	passwords := findPasswords(dataStr)
+
+	// 先尝试空密码, 再尝试同一段数据中的签名密码和其他密码
+	candidates := []string{""}
+	for _, p := range passwords {
+		candidates = append(candidates, p.value)
+	}
+	for _, c := range detectors.CollectPasswords("", data) {
+		if !slices.Contains(candidates, c) {
+			candidates = append(candidates, c)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for _, match := range pkcs12Pat.FindAllString(dataStr, -1) {
+		if _, ok := seen[match]; ok {
+			continue
+		}
+		seen[match] = struct{}{}
+		der, err := base64.StdEncoding.DecodeString(match)
+		if err != nil {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_CodeSigning,
+			Raw:          []byte(match),
+			Redacted:     "PKCS#12 " + match[:16],
+			ExtraData: map[string]string{
+				"format":    "pkcs12",
+				"data_size": strconv.Itoa(len(der)),
+			},
+		}
+		// 打开 PKCS#12 不需要访问网络, 与 verify 无关
+		tryPasswords(&s1, der, candidates)
+
+		results = append(results, s1)
+	}
+
+	for _, p := range passwords {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_CodeSigning,
+			Raw:          []byte(p.value),
+			RawV2:        []byte(p.name + "=" + p.value),
+			Redacted:     p.name,
+			ExtraData: map[string]string{
+				"format":   "password",
+				"variable": p.name,
+				"platform": p.platform,
+			},
+		}
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// TryDecrypt tries to open the PKCS#12 file behind result with passwords collected from the rest of the source unit,
+// such as a keystore.properties file or a .env file next to the CI config.
+func (s Scanner) TryDecrypt(_ context.Context, data []byte, result *detectors.Result, candidates []string) bool {
+	if result.ExtraData["format"] != "pkcs12" || result.ExtraData["opened"] == "true" {
+		return false
+	}
+	der, err := base64.StdEncoding.DecodeString(string(result.Raw))
+	if err != nil {
+		return false
+	}
+	return tryPasswords(result, der, candidates)
+}
+
+// signingPassword 是一个签名密钥或 keystore 的密码
+type signingPassword struct {
+	name     string
+	value    string
+	platform string
+}
+
+func findPasswords(dataStr string) []signingPassword {
+	var passwords []signingPassword
+	seen := make(map[string]struct{})
+	add := func(name, value, platform string) {
+		value = strings.Trim(value, `"`)
+		if !isPlaintext(value) {
+			return
+		}
+		if _, ok := seen[name+"="+value]; ok {
+			return
+		}
+		seen[name+"="+value] = struct{}{}
+		passwords = append(passwords, signingPassword{name: name, value: value, platform: platform})
+	}
+
+	gradle := strings.Contains(dataStr, "signingConfigs")
+	for _, m := range passwordPat.FindAllStringSubmatch(dataStr, -1) {
+		normalized := normalizeName(m[1])
+		if platform, ok := signingPasswords[normalized]; ok {
+			add(m[1], m[2], platform)
+			continue
+		}
+		if _, ok := gradleSigningPasswords[normalized]; ok && gradle {
+			add(m[1], m[2], platformAndroid)
+		}
+	}
+	for _, m := range signtoolPat.FindAllStringSubmatch(dataStr, -1) {
+		add("signtool /p", m[1], platformAuthenticode)
+	}
+	return passwords
+}
+
+// normalizeName 忽略大小写和分隔符, MATCH_PASSWORD, match-password 和 matchPassword 都规范化为 matchpassword
+func normalizeName(name string) string {
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(name))
+}
+
+// isPlaintext 排除变量引用和从其他位置读取密码的表达式, 如 ${{ secrets.P12_PASSWORD }},
+// $KEYSTORE_PASSWORD, System.getenv("...") 和 keystoreProperties['storePassword']
+func isPlaintext(value string) bool {
+	if len(value) < 4 || strings.ContainsAny(value, "$({[%<") {
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "password", "changeit", "changeme", "xxxx", "none", "null", "true", "false":
+		return false
+	}
+	return true
+}
+
+// tryPasswords 依次尝试候选密码, 成功时记录证书信息
+func tryPasswords(result *detectors.Result, der []byte, passwords []string) bool {
+	for i, password := range passwords {
+		if i >= maxPasswordAttempts {
+			break
+		}
+		blocks, err := pkcs12.ToPEM(der, password)
+		if err != nil {
+			continue
+		}
+		// 私钥和证书一起泄露, 可以直接用它签名
+		result.Verified = true
+		result.ExtraData["opened"] = "true"
+		if password == "" {
+			result.ExtraData["empty_password"] = "true"
+		}
+		if cert := leafCertificate(blocks); cert != nil {
+			describe(result, cert)
+		}
+		return true
+	}
+	return false
+}
+
+// leafCertificate 返回 PKCS#12 中的签名证书: 优先选择带 codeSigning 用途的证书, 其次是第一个非 CA 证书,
+// 自签名证书通常也带有 CA 标记, 因此最后才选择第一个证书
+func leafCertificate(blocks []*pem.Block) *x509.Certificate {
+	var first, firstLeaf *x509.Certificate
+	for _, block := range blocks {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+			return cert
+		}
+		if first == nil {
+			first = cert
+		}
+		if firstLeaf == nil && !cert.IsCA {
+			firstLeaf = cert
+		}
+	}
+	if firstLeaf != nil {
+		return firstLeaf
+	}
+	return first
+}
+
+func describe(result *detectors.Result, cert *x509.Certificate) {
+	result.Redacted = "PKCS#12 " + cert.Subject.CommonName
+	result.ExtraData["subject"] = cert.Subject.String()
+	result.ExtraData["issuer"] = cert.Issuer.String()
+	result.ExtraData["not_after"] = cert.NotAfter.UTC().Format(time.RFC3339)
+	result.ExtraData["expired"] = boolString(time.Now().After(cert.NotAfter))
+	result.ExtraData["code_signing"] = boolString(slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning))
+	result.ExtraData["platform"] = certificatePlatform(cert)
+}
+
+// appleSubjectPrefixes 是 Apple 签发的开发和分发证书的名称前缀
+var appleSubjectPrefixes = []string{
+	"Apple Development:", "Apple Distribution:", "iPhone Developer:", "iPhone Distribution:",
+	"Mac Developer:", "Developer ID Application:", "Developer ID Installer:", "3rd Party Mac Developer",
+}
+
+func certificatePlatform(cert *x509.Certificate) string {
+	if strings.Contains(cert.Issuer.CommonName, "Apple Worldwide Developer Relations") {
+		return platformApple
+	}
+	for _, prefix := range appleSubjectPrefixes {
+		if strings.HasPrefix(cert.Subject.CommonName, prefix) {
+			return platformApple
+		}
+	}
+	if slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+		return platformAuthenticode
+	}
+	return platformCertificate
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagSupplyChain} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_CodeSigning
+}
+
+func (s Scanner) Description() string {
+	return "Code-signing certificates, such as Authenticode PFX files, Apple p12 bundles and Android keystores, prove that software was published by its owner. A leaked signing key and its password let attackers sign malicious builds as the organization."
+}
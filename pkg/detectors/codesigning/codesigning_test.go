@@ -0,0 +1,162 @@
+package codesigning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	// testPFX is a self-signed "CN=Acme Corp Code Signing" certificate with the codeSigning extended key usage and
+	// its P-256 key, exported with openssl pkcs12 -export -legacy and the password Sign1ng-2024.
+	testPFX = "" +
+		"MIIDwgIBAzCCA4gGCSqGSIb3DQEHAaCCA3kEggN1MIIDcTCCAmcGCSqGSIb3DQEHBqCCAlgwggJUAgEAMIICTQYJKoZIhvcNAQcB" +
+		"MBwGCiqGSIb3DQEMAQYwDgQI8/vDnXi3hmQCAggAgIICIFp+p3siIyVxWsg+HjNBeZtPsUO0WrHIHTETtz/BtAgV9XgK0SoUmp0z" +
+		"B7ZYVKI20KfTNwfx+w6xgLczwqMDY0evuhcsLIiEmZXs16UrYkR84vE8cggakIfiaoFp/MFx8FhkCfgjdUsHgs93g+Kt2w4lRAxx" +
+		"TgW3kQlITZBpMec6ZxF+egWnjkx0qfCTWWl+xm766/C+GZ23tHKveKXHhg6YXzAb4rh8eyyEGii/U47UA/rIjXJUrln+1dxDjkgI" +
+		"4OaBTBDAYXRfxNDRIEv0qHpQcrqmOmyCNaJD+VCqX/ql+e1eb+GlRIs6FtPb2/jf2RcsDXwN6cik6SFq8qb0ABE6QQElcldNh+Lk" +
+		"wXBlClx/pt5c7f8VZ4P5u+LoYmtt8dPukFksKiugr7zAC37v5YMjGq6k/DZVfsc6RQHYN8Oy2PmuCJs2A91hAG5rIUtCKYgPPUZe" +
+		"PEoQV+4637fIdEL/3Z/O01VWl3KqRsdXAzfsB686dCGhCU1CP37LOCgu8ZZzXn4uKumoj1tAGxZnR30+gQIx4ODusNKMTx8TfHLZ" +
+		"e9LrS3zCg3Q2CafcJF+h1a/6TRlDpIlc0sc6nCvH07WBdPoeKuNLkHbp/NcJwz8Zr0e7M2r9qqyxj7Auw0Opk4p9Tt1mTIApZCcE" +
+		"fMbi9QMTXaV84fyVcwEsNODKEf//UfwgmD+q6PAzH2HEntXfPHBK373yuSwQy1IHTQlwCngwggECBgkqhkiG9w0BBwGggfQEgfEw" +
+		"ge4wgesGCyqGSIb3DQEMCgECoIG0MIGxMBwGCiqGSIb3DQEMAQMwDgQIKxh41gv0n5gCAggABIGQyHYHhqICMcuBR/fSPs2tvgB6" +
+		"sbZXxMHUU76e4GOjWHaHWA2nTgCS21XYUVh9Rm1Mj/COToUwFL+ACderzXE37D2UlHQdCu11QLgLf3n4wHPz90At3fSQMgHIFYgG" +
+		"1WooVwL6Y6cpTC9TIs8dJU7ed2vGiBxPeICrzK1HXGwV6yIqeODm58v3pdIwbE/ZekH1MSUwIwYJKoZIhvcNAQkVMRYEFI6bz948" +
+		"95xOTX7/kELLOeejIE7+MDEwITAJBgUrDgMCGgUABBRa/fDHZhRyEnfzIlUWeXup9BXAuQQIgAx9qBSjPEUCAggA"
+	testPFXPassword = "Sign1ng-2024"
+)
+
+func TestCodeSigning_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "github actions",
+			input: `
+env:
+  BUILD_CERTIFICATE_BASE64: ` + testPFX + `
+  P12_PASSWORD: ` + testPFXPassword + `
+  KEYCHAIN_PASSWORD: ${{ secrets.KEYCHAIN_PASSWORD }}
+`,
+			want: []string{testPFX, "P12_PASSWORD=" + testPFXPassword},
+		},
+		{
+			name: "fastlane",
+			input: `export MATCH_PASSWORD="m4tch-r3po-pass"
+export FASTLANE_USER=ci@acme.example
+`,
+			want: []string{"MATCH_PASSWORD=m4tch-r3po-pass"},
+		},
+		{
+			name: "gradle signingConfigs",
+			input: `android {
+    signingConfigs {
+        release {
+            storeFile file("release.jks")
+            storePassword "andr0id-st0re"
+            keyAlias "release"
+            keyPassword = 'andr0id-k3y'
+        }
+        debug {
+            storePassword keystoreProperties['storePassword']
+        }
+    }
+}`,
+			want: []string{"storePassword=andr0id-st0re", "keyPassword=andr0id-k3y"},
+		},
+		{
+			name:  "signtool",
+			input: `signtool sign /fd SHA256 /f build\\acme.pfx /p "Pfx-P@ss1" /tr http://timestamp.digicert.com app.exe`,
+			want:  []string{"signtool /p=Pfx-P@ss1"},
+		},
+		{
+			name:  "invalid pattern - references",
+			input: "CSC_KEY_PASSWORD: ${{ secrets.CSC_KEY_PASSWORD }}\nKEYSTORE_PASSWORD=$KEYSTORE_PASSWORD\n",
+		},
+		{
+			name:  "invalid pattern - store password outside gradle signing config",
+			input: "keystore:\n  path: /etc/ssl/app.jks\nstorePassword: hunter22\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestCodeSigning_OpenPKCS12(t *testing.T) {
+	input := "CSC_LINK=" + testPFX + "\nCSC_KEY_PASSWORD=" + testPFXPassword + "\n"
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	r := results[0]
+	assert.True(t, r.Verified)
+	assert.Equal(t, "PKCS#12 Acme Corp Code Signing", r.Redacted)
+	assert.Equal(t, "CN=Acme Corp Code Signing,O=Acme Corp", r.ExtraData["subject"])
+	assert.Equal(t, "true", r.ExtraData["code_signing"])
+	assert.Equal(t, "false", r.ExtraData["expired"])
+	assert.Equal(t, platformAuthenticode, r.ExtraData["platform"])
+	assert.NotEmpty(t, r.ExtraData["not_after"])
+
+	assert.Equal(t, platformAuthenticode, results[1].ExtraData["platform"])
+}
+
+func TestCodeSigning_TryDecrypt(t *testing.T) {
+	d := Scanner{}
+	data := []byte("BUILD_CERTIFICATE_BASE64: " + testPFX)
+
+	results, err := d.FromData(context.Background(), true, data)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	r := results[0]
+	assert.False(t, r.Verified)
+	assert.Empty(t, r.ExtraData["subject"])
+
+	assert.False(t, d.TryDecrypt(context.Background(), data, &r, []string{"wrong-password"}))
+	assert.True(t, d.TryDecrypt(context.Background(), data, &r, []string{"wrong-password", testPFXPassword}))
+	assert.True(t, r.Verified)
+	assert.Equal(t, "CN=Acme Corp Code Signing,O=Acme Corp", r.ExtraData["subject"])
+}
@@ -0,0 +1,95 @@
+package detectors
+
+import "strings"
+
+// Confidence describes how likely a result is to be a real, usable secret. Unlike Severity it reflects the
+// evidence gathered for this particular result, such as a successful verification.
+type Confidence int
+
+const (
+	ConfidenceUnspecified Confidence = iota
+	ConfidenceLow
+	ConfidenceMedium
+	ConfidenceHigh
+)
+
+var confidenceNames = map[Confidence]string{
+	ConfidenceUnspecified: "unspecified",
+	ConfidenceLow:         "low",
+	ConfidenceMedium:      "medium",
+	ConfidenceHigh:        "high",
+}
+
+func (c Confidence) String() string {
+	if name, ok := confidenceNames[c]; ok {
+		return name
+	}
+	return confidenceNames[ConfidenceUnspecified]
+}
+
+// ParseConfidence converts a confidence name (case-insensitive) into a Confidence. Unknown names return false.
+func ParseConfidence(name string) (Confidence, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for c, n := range confidenceNames {
+		if n == name {
+			return c, true
+		}
+	}
+	return ConfidenceUnspecified, false
+}
+
+// Evidence is one independent signal that a result is a real secret, such as a pattern that matched it. Weight is
+// the probability, between 0 and 1, that the result is real given this signal alone.
+type Evidence struct {
+	Source string
+	Weight float64
+}
+
+// Scores at or above which combined evidence rates a result as high or medium confidence.
+const (
+	highConfidenceScore   = 0.8
+	mediumConfidenceScore = 0.5
+)
+
+// AddEvidence records a signal supporting the result and updates its confidence from all evidence gathered so far.
+// Evidence from a source that was already recorded keeps the higher of the two weights, so a pattern matching the
+// same secret twice does not count twice.
+func (r *Result) AddEvidence(source string, weight float64) {
+	weight = min(max(weight, 0), 1)
+	for i := range r.Evidence {
+		if r.Evidence[i].Source == source {
+			r.Evidence[i].Weight = max(r.Evidence[i].Weight, weight)
+			r.Confidence = ConfidenceFromScore(EvidenceScore(r.Evidence))
+			return
+		}
+	}
+	r.Evidence = append(r.Evidence, Evidence{Source: source, Weight: weight})
+	r.Confidence = ConfidenceFromScore(EvidenceScore(r.Evidence))
+}
+
+// EvidenceScore combines independent evidence into a single probability. Each signal is treated as an independent
+// chance of the result being real (noisy-OR), so agreeing signals raise the score above any one of them alone.
+func EvidenceScore(evidence []Evidence) float64 {
+	if len(evidence) == 0 {
+		return 0
+	}
+	miss := 1.0
+	for _, e := range evidence {
+		miss *= 1 - e.Weight
+	}
+	return 1 - miss
+}
+
+// ConfidenceFromScore maps a combined evidence score to a Confidence.
+func ConfidenceFromScore(score float64) Confidence {
+	switch {
+	case score >= highConfidenceScore:
+		return ConfidenceHigh
+	case score >= mediumConfidenceScore:
+		return ConfidenceMedium
+	case score > 0:
+		return ConfidenceLow
+	default:
+		return ConfidenceUnspecified
+	}
+}
@@ -0,0 +1,32 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult_AddEvidence(t *testing.T) {
+	var r Result
+	r.AddEvidence("prefixed_pattern", 0.4)
+	assert.Equal(t, ConfidenceLow, r.Confidence)
+
+	// The same source only counts once, with its strongest weight.
+	r.AddEvidence("prefixed_pattern", 0.2)
+	assert.Equal(t, []Evidence{{Source: "prefixed_pattern", Weight: 0.4}}, r.Evidence)
+
+	r.AddEvidence("context_pattern", 0.6)
+	assert.InDelta(t, 0.76, EvidenceScore(r.Evidence), 1e-9)
+	assert.Equal(t, ConfidenceMedium, r.Confidence)
+
+	r.AddEvidence("high_entropy", 0.3)
+	assert.Equal(t, ConfidenceHigh, r.Confidence)
+	assert.Len(t, r.Evidence, 3)
+}
+
+func TestConfidenceFromScore(t *testing.T) {
+	assert.Equal(t, ConfidenceUnspecified, ConfidenceFromScore(0))
+	assert.Equal(t, ConfidenceLow, ConfidenceFromScore(0.3))
+	assert.Equal(t, ConfidenceMedium, ConfidenceFromScore(0.5))
+	assert.Equal(t, ConfidenceHigh, ConfidenceFromScore(0.9))
+}
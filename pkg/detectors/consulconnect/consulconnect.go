@@ -0,0 +1,119 @@
+package consulconnect
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+// 证书链和私钥通常只有几 KB
+const maxPEMSize = 16 * 1024
+
+// spiffePathSegments 是 SPIFFE ID 路径中的键与 ExtraData 字段的对应关系
+var spiffePathSegments = map[string]string{
+	"ap":  "partition",
+	"ns":  "namespace",
+	"dc":  "datacenter",
+	"svc": "service",
+	"id":  "agent",
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"-----begin"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxPEMSize }
+
+// FromData will find Consul Connect certificates stored together with their private key in a given set of bytes.
+// Connect identifies services by the SPIFFE ID in their leaf certificate, so a leaf certificate and its key let
+// anyone impersonate the service in the mesh, and the CA's certificate and key let anyone issue leaf certificates.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	for _, pair := range detectors.FindCertKeyPairs(string(data)) {
+		spiffeID, ok := consulSPIFFEID(pair.Cert.URIs)
+		if !ok {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_ConsulConnect,
+			Raw:          []byte(pair.KeyPEM),
+			RawV2:        []byte(spiffeID.String() + "\n" + pair.KeyPEM),
+			Redacted:     spiffeID.String(),
+			ExtraData: map[string]string{
+				"spiffe_id":    spiffeID.String(),
+				"trust_domain": spiffeID.Host,
+				"ca":           strconv.FormatBool(pair.Cert.IsCA),
+			},
+		}
+		for k, v := range parseSPIFFEPath(spiffeID.Path) {
+			s1.ExtraData[k] = v
+		}
+		// 私钥与证书匹配, 不需要访问网络即可确认
+		s1.AddEvidence("key_matches_certificate", 0.9)
+		s1.SetExpiresAt(pair.Cert.NotAfter)
+		if pair.Cert.IsCA {
+			// CA 私钥可以签发网格中任意服务的证书
+			s1.Severity = detectors.SeverityCritical
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// consulSPIFFEID returns the SPIFFE ID of a Consul Connect certificate. Consul's trust domains are <cluster id>.consul.
+func consulSPIFFEID(uris []*url.URL) (*url.URL, bool) {
+	for _, u := range uris {
+		if u.Scheme == "spiffe" && strings.HasSuffix(u.Host, ".consul") {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
+// parseSPIFFEPath extracts the identity from the path of a Consul SPIFFE ID, e.g. /ns/default/dc/dc1/svc/web or
+// /ap/default/ns/default/dc/dc1/svc/web for services and /agent/client/dc/dc1/id/node-1 for agents. The ID of the
+// CA has no path.
+func parseSPIFFEPath(path string) map[string]string {
+	fields := make(map[string]string)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "agent" {
+		fields["agent_type"] = parts[1]
+		parts = parts[2:]
+	}
+	for i := 0; i+1 < len(parts); i += 2 {
+		if name, ok := spiffePathSegments[parts[i]]; ok {
+			fields[name] = parts[i+1]
+		}
+	}
+	return fields
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_ConsulConnect
+}
+
+func (s Scanner) Description() string {
+	return "HashiCorp Consul Connect certificates identify services in the service mesh. A leaf certificate with its private key lets its holder impersonate the service to other services; the CA certificate with its key lets its holder issue certificates for any service."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagInfrastructure} }
@@ -0,0 +1,118 @@
+package consulconnect
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const trustDomain = "11111111-2222-3333-4444-555555555555.consul"
+
+func TestConsulConnect_FromData(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	leafCert, leafKey := testCertificate(t, "spiffe://"+trustDomain+"/ns/default/dc/dc1/svc/web", false)
+	caCert, caKey := testCertificate(t, "spiffe://"+trustDomain, true)
+	otherCert, otherKey := testCertificate(t, "spiffe://example.org/web", false)
+
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "leaf certificate",
+			input: leafCert + leafKey,
+			want: map[string]string{
+				"spiffe_id":    "spiffe://" + trustDomain + "/ns/default/dc/dc1/svc/web",
+				"trust_domain": trustDomain,
+				"ca":           "false",
+				"namespace":    "default",
+				"datacenter":   "dc1",
+				"service":      "web",
+			},
+		},
+		{
+			name: "ca in agent config",
+			input: "connect {\n  ca_config {\n    private_key = \"" + strings.ReplaceAll(caKey, "\n", `\n`) +
+				"\"\n    root_cert = \"" + strings.ReplaceAll(caCert, "\n", `\n`) + "\"\n  }\n}",
+			want: map[string]string{
+				"spiffe_id":    "spiffe://" + trustDomain,
+				"trust_domain": trustDomain,
+				"ca":           "true",
+			},
+		},
+		{
+			name:  "invalid pattern - other trust domain",
+			input: otherCert + otherKey,
+		},
+		{
+			name:  "invalid pattern - key of another certificate",
+			input: leafCert + otherKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+			if test.want == nil {
+				assert.Empty(t, results)
+				return
+			}
+
+			require.Len(t, results, 1)
+			extraData := results[0].ExtraData
+			assert.NotEmpty(t, extraData[detectors.ExpiresAtKey])
+			delete(extraData, detectors.ExpiresAtKey)
+			assert.Equal(t, test.want, extraData)
+			assert.Equal(t, detectors.ConfidenceHigh, results[0].Confidence)
+		})
+	}
+}
+
+// testCertificate returns a self-signed certificate with the given SPIFFE ID and its private key, PEM encoded.
+func testCertificate(t *testing.T, spiffeID string, isCA bool) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	uri, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "web"},
+		URIs:                  []*url.URL{uri},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(72 * time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
@@ -0,0 +1,52 @@
+package detectors
+
+import "bytes"
+
+// CandidateDetectorsKey is the ExtraData key under which the engine lists the detectors that a secret could belong
+// to, when it found the secret with several detectors and the context did not tell them apart.
+const CandidateDetectorsKey = "candidate_detectors"
+
+// ContextScore counts the distinct context hints of the detector that occur in data. Detectors that do not implement
+// ContextHintProvider score 0.
+func ContextScore(d Detector, data []byte) int {
+	provider, ok := d.(ContextHintProvider)
+	if !ok {
+		return 0
+	}
+	return contextScore(provider, bytes.ToLower(data))
+}
+
+func contextScore(provider ContextHintProvider, lowerData []byte) int {
+	score := 0
+	for _, hint := range provider.ContextHints() {
+		if hint != "" && bytes.Contains(lowerData, bytes.ToLower([]byte(hint))) {
+			score++
+		}
+	}
+	return score
+}
+
+// AttributeSecret picks which of several detectors that found the same secret in data the secret belongs to. It
+// returns the index of the detector with the most context hints in data, or false if no detector has any hints in
+// data or several are tied.
+func AttributeSecret(candidates []Detector, data []byte) (int, bool) {
+	lowerData := bytes.ToLower(data)
+	best, bestScore, tied := -1, 0, false
+	for i, d := range candidates {
+		provider, ok := d.(ContextHintProvider)
+		if !ok {
+			continue
+		}
+		score := contextScore(provider, lowerData)
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = i, score, false
+		case score == bestScore && score > 0:
+			tied = true
+		}
+	}
+	if best < 0 || tied {
+		return -1, false
+	}
+	return best, true
+}
@@ -0,0 +1,73 @@
+package detectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type hintedDetector struct {
+	hints []string
+}
+
+func (d hintedDetector) FromData(context.Context, bool, []byte) ([]Result, error) { return nil, nil }
+func (d hintedDetector) Keywords() []string                                       { return []string{"sk-"} }
+func (d hintedDetector) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Generic
+}
+func (d hintedDetector) Description() string    { return "" }
+func (d hintedDetector) ContextHints() []string { return d.hints }
+
+func TestAttributeSecret(t *testing.T) {
+	deepseek := hintedDetector{hints: []string{"deepseek", "api.deepseek.com"}}
+	dashscope := hintedDetector{hints: []string{"DASHSCOPE", "dashscope.aliyuncs.com"}}
+
+	tests := []struct {
+		name       string
+		candidates []Detector
+		data       string
+		want       int
+		wantOK     bool
+	}{
+		{
+			name:       "hint of one detector",
+			candidates: []Detector{deepseek, dashscope},
+			data:       `export DASHSCOPE_API_KEY="sk-0123456789abcdef0123456789abcdef"`,
+			want:       1,
+			wantOK:     true,
+		},
+		{
+			name:       "more hints win",
+			candidates: []Detector{deepseek, dashscope},
+			data:       "# migrated from deepseek\nbase_url: https://dashscope.aliyuncs.com/compatible-mode/v1",
+			want:       1,
+			wantOK:     true,
+		},
+		{
+			name:       "no hints",
+			candidates: []Detector{deepseek, dashscope},
+			data:       `API_KEY=sk-0123456789abcdef0123456789abcdef`,
+			want:       -1,
+		},
+		{
+			name:       "tie",
+			candidates: []Detector{deepseek, dashscope},
+			data:       "deepseek or dashscope",
+			want:       -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AttributeSecret(tt.candidates, []byte(tt.data))
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, 0, ContextScore(hintedDetector{}, []byte("deepseek")))
+	assert.Equal(t, 2, ContextScore(deepseek, []byte("https://API.DEEPSEEK.COM/v1")))
+}
@@ -0,0 +1,277 @@
+package contractdeployer
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ethereum"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// truffle-config.js:
+	// mainnet: { provider: () => new HDWalletProvider("<mnemonic>" | ["<key>"] | { privateKeys, mnemonic, providerOrUrl }, "<url>"), network_id: 1 }
+	hdWalletPat   = regexp.MustCompile(`new\s+HDWalletProvider\s*\(`)
+	networkKeyPat = regexp.MustCompile(`([A-Za-z_][\w-]*)["']?\s*:\s*\{`)
+	networkIDPat  = regexp.MustCompile(`network_id\s*:\s*["']?([0-9]+|\*)`)
+	urlPat        = regexp.MustCompile("[\"'`]((?:https?|wss?)://[^\"'`\\s]+)[\"'`]")
+
+	// 参数中直接写入的助记词和私钥
+	quotedMnemonicPat = regexp.MustCompile("[\"'`]((?:[a-z]{3,8}\\s+){11,23}[a-z]{3,8})[\"'`]")
+	quotedKeyPat      = regexp.MustCompile("[\"'`](?:0x)?([0-9a-fA-F]{64})[\"'`]")
+
+	// brownie-config.yaml 的 wallets.from_key / from_mnemonic, 以及脚本中的 accounts.add / accounts.from_mnemonic
+	brownieKeyPat      = regexp.MustCompile(`(?:from_key|accounts\.add)\s*[:(]\s*["']?(?:0x)?([0-9a-fA-F]{64})\b`)
+	brownieMnemonicPat = regexp.MustCompile(`(?:from_mnemonic|accounts\.from_mnemonic)\s*[:(]\s*["']((?:[a-z]{3,8}\s+){11,23}[a-z]{3,8})["']`)
+	// brownie 的目标网络: networks.default, network.connect("...") 或 --network ...
+	brownieNetworkPat = regexp.MustCompile(`(?:default\s*:\s*|network\.connect\(\s*["']|--network[ =])([\w-]+)`)
+)
+
+const (
+	// HDWalletProvider 调用的参数范围
+	callWindow = 800
+	// 在调用之前查找网络名称的范围
+	lookbehind = 300
+)
+
+// 网络名称或 RPC 地址中的特征
+var (
+	testnetHints = []string{
+		"test", "goerli", "sepolia", "holesky", "rinkeby", "ropsten", "kovan", "mumbai", "amoy", "fuji", "chapel",
+		"development", "develop", "local", "127.0.0.1", "ganache", "hardhat", "anvil", "fork",
+	}
+	mainnetHints = []string{"mainnet", "main", "bsc-dataseed", "polygon-rpc", "arb1", "avax", "matic"}
+	// 主网的 chain ID
+	mainnetIDs = map[string]struct{}{"1": {}, "10": {}, "56": {}, "137": {}, "250": {}, "8453": {}, "42161": {}, "43114": {}}
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"HDWalletProvider", "from_key", "from_mnemonic", "accounts.add"}
+}
+
+// FromData will find smart-contract deployer keys and mnemonics in Truffle and Brownie configs in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 用于去重
+	seen := make(map[string]struct{})
+	addResult := func(tool, kind, secret, network, class string) {
+		if _, ok := seen[secret]; ok {
+			return
+		}
+		seen[secret] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_ContractDeployer,
+			Raw:          []byte(secret),
+			Severity:     severityFor(class),
+			ExtraData: map[string]string{
+				"tool":          tool,
+				"kind":          kind,
+				"network_class": class,
+			},
+		}
+		if network != "" {
+			s1.ExtraData["network"] = network
+		}
+		if kind == "private_key" {
+			s1.Redacted = "0x" + secret[:6] + "..."
+			if address, err := ethAddress(secret); err == nil {
+				s1.ExtraData["address"] = address
+				// 知名协议或交易所地址的部署私钥泄露是安全事件
+				if label, ok := ethereum.KnownLabel(address); ok {
+					s1.ExtraData["known_label"] = label
+					s1.Severity = detectors.SeverityCritical
+				}
+				// ENS 反向解析只是补充信息, 查询失败时忽略
+				if verify {
+					if name, err := ethereum.ReverseENS(ctx, s.getClient(), ethereum.PublicRPC, address); err == nil && name != "" {
+						s1.ExtraData["ens_name"] = name
+					}
+				}
+			}
+		} else {
+			s1.Redacted = strings.Fields(secret)[0] + " ..."
+		}
+		results = append(results, s1)
+	}
+
+	for _, idx := range hdWalletPat.FindAllStringIndex(dataStr, -1) {
+		call := dataStr[idx[1]:min(len(dataStr), idx[1]+callWindow)]
+		// 只看当前调用, 避免把下一个网络的配置算进来
+		if next := hdWalletPat.FindStringIndex(call); next != nil {
+			call = call[:next[0]]
+		}
+		before := dataStr[max(0, idx[0]-lookbehind):idx[0]]
+
+		network, class := truffleNetwork(before, call)
+
+		for _, match := range quotedKeyPat.FindAllStringSubmatch(call, -1) {
+			key := strings.ToLower(match[1])
+			if isValidKey(key) {
+				addResult("truffle", "private_key", key, network, class)
+			}
+		}
+		for _, match := range quotedMnemonicPat.FindAllStringSubmatch(call, -1) {
+			if mnemonic, ok := normalizeMnemonic(match[1]); ok {
+				addResult("truffle", "mnemonic", mnemonic, network, class)
+			}
+		}
+	}
+
+	if brownieKeyPat.MatchString(dataStr) || brownieMnemonicPat.MatchString(dataStr) {
+		var network string
+		if m := brownieNetworkPat.FindStringSubmatch(dataStr); m != nil {
+			network = m[1]
+		}
+		class := classify(network)
+
+		for _, match := range brownieKeyPat.FindAllStringSubmatch(dataStr, -1) {
+			key := strings.ToLower(match[1])
+			if isValidKey(key) {
+				addResult("brownie", "private_key", key, network, class)
+			}
+		}
+		for _, match := range brownieMnemonicPat.FindAllStringSubmatch(dataStr, -1) {
+			if mnemonic, ok := normalizeMnemonic(match[1]); ok {
+				addResult("brownie", "mnemonic", mnemonic, network, class)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// truffleNetwork 返回 HDWalletProvider 所在网络的名称和分类, 依次参考 network_id, 网络名称和 RPC 地址
+func truffleNetwork(before, call string) (string, string) {
+	var network string
+	if matches := networkKeyPat.FindAllStringSubmatch(before, -1); len(matches) > 0 {
+		network = matches[len(matches)-1][1]
+	}
+
+	if m := networkIDPat.FindStringSubmatch(call); m != nil {
+		if _, ok := mainnetIDs[m[1]]; ok {
+			return network, "mainnet"
+		}
+	}
+	if class := classify(network); class != "unknown" {
+		return network, class
+	}
+	if m := urlPat.FindStringSubmatch(call); m != nil {
+		return network, classify(m[1])
+	}
+	return network, "unknown"
+}
+
+// classify 根据网络名称或 RPC 地址判断是主网还是测试网, 测试网的特征优先
+func classify(s string) string {
+	s = strings.ToLower(s)
+	if s == "" {
+		return "unknown"
+	}
+	for _, hint := range testnetHints {
+		if strings.Contains(s, hint) {
+			return "testnet"
+		}
+	}
+	for _, hint := range mainnetHints {
+		if strings.Contains(s, hint) {
+			return "mainnet"
+		}
+	}
+	return "unknown"
+}
+
+// severityFor 主网的部署私钥通常拥有合约的 owner 权限, 泄露后果最严重
+func severityFor(class string) detectors.Severity {
+	switch class {
+	case "mainnet":
+		return detectors.SeverityCritical
+	case "testnet":
+		return detectors.SeverityLow
+	default:
+		return detectors.SeverityHigh
+	}
+}
+
+// isValidKey 排除全 0 和重复字符这类占位符
+func isValidKey(key string) bool {
+	b, err := hex.DecodeString(key)
+	if err != nil || !secp256k1.ValidPrivateKey(b) {
+		return false
+	}
+	return strings.Count(key, key[:1]) != len(key)
+}
+
+// normalizeMnemonic 助记词必须是 12/15/18/21/24 个单词, 且不能是同一个单词的重复
+func normalizeMnemonic(s string) (string, bool) {
+	words := strings.Fields(s)
+	if len(words)%3 != 0 {
+		return "", false
+	}
+	unique := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		unique[w] = struct{}{}
+	}
+	if len(unique) < len(words)/2 {
+		return "", false
+	}
+	return strings.Join(words, " "), true
+}
+
+// ethAddress 推导私钥对应的以太坊地址
+func ethAddress(key string) (string, error) {
+	b, err := hex.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+	return ethereum.AddressFromPrivateKey(b)
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_ContractDeployer
+}
+
+func (s Scanner) Description() string {
+	return "Private keys and mnemonics of smart-contract deployer accounts, passed inline in Truffle or Brownie configs. Mainnet deployer keys usually own the deployed contracts and can upgrade them or drain their funds."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// 部署账户通常是合约的 owner, 除了转移资产还要转移合约的管理权限
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("deployer account") +
+		" Transfer the ownership, admin and upgrade roles of the contracts it deployed to a new account before moving the funds."
+}
@@ -0,0 +1,137 @@
+package contractdeployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testKey      = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testMnemonic = "candy maple cake sugar pudding cream honey rich smooth crumble sweet treat"
+)
+
+var truffleConfig = `
+const HDWalletProvider = require("@truffle/hdwallet-provider");
+
+module.exports = {
+  networks: {
+    sepolia: {
+      provider: () => new HDWalletProvider("` + testMnemonic + `", "https://sepolia.infura.io/v3/0123456789abcdef0123456789abcdef"),
+      network_id: 11155111,
+    },
+    live: {
+      provider: () => new HDWalletProvider({
+        privateKeys: ["0x` + testKey + `"],
+        providerOrUrl: "https://eth.llamarpc.com",
+      }),
+      network_id: 1,
+    },
+  },
+};
+`
+
+func TestContractDeployer_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid truffle config",
+			input: truffleConfig,
+			want:  []string{testMnemonic, testKey},
+		},
+		{
+			name: "valid brownie config",
+			input: `
+networks:
+  default: mainnet
+wallets:
+  from_key: 0x` + testKey + `
+`,
+			want: []string{testKey},
+		},
+		{
+			name:  "valid brownie script",
+			input: `dev = accounts.from_mnemonic("` + testMnemonic + `")`,
+			want:  []string{testMnemonic},
+		},
+		{
+			name:  "invalid truffle config - mnemonic from env",
+			input: `provider: () => new HDWalletProvider(process.env.MNEMONIC, "https://mainnet.infura.io/v3/" + process.env.INFURA_KEY)`,
+			want:  nil,
+		},
+		{
+			name:  "invalid brownie config - placeholder key",
+			input: `from_key: "0x0000000000000000000000000000000000000000000000000000000000000000"`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestContractDeployer_Network(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(truffleConfig))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	byRaw := make(map[string]detectors.Result, len(results))
+	for _, r := range results {
+		byRaw[string(r.Raw)] = r
+	}
+
+	testnet := byRaw[testMnemonic]
+	require.Equal(t, "sepolia", testnet.ExtraData["network"])
+	require.Equal(t, "testnet", testnet.ExtraData["network_class"])
+	require.Equal(t, detectors.SeverityLow, testnet.Severity)
+
+	mainnet := byRaw[testKey]
+	require.Equal(t, "live", mainnet.ExtraData["network"])
+	require.Equal(t, "mainnet", mainnet.ExtraData["network_class"])
+	require.Equal(t, detectors.SeverityCritical, mainnet.Severity)
+	require.Equal(t, "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23", mainnet.ExtraData["address"])
+}
@@ -0,0 +1,114 @@
+package detectors_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/arweavewallet"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/baidu2"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitcoinwif"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/chiakey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/contractdeployer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethereumprivatekey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/filecoinprivatekey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/lndmacaroon"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/metamaskvault"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+const corpusDir = "testdata/corpus"
+
+// corpusDetectors are the detectors whose patterns match hex or base58 strings. Such strings are everywhere in
+// lockfiles, transaction logs, build artifacts and stylesheets, so these detectors are the most prone to false
+// positives. Add new detectors of this kind here.
+var corpusDetectors = []detectors.Detector{
+	&arweavewallet.Scanner{},
+	&baidu2.Scanner{},
+	&bitcoinwif.Scanner{},
+	&chiakey.Scanner{},
+	&contractdeployer.Scanner{},
+	&ethereumprivatekey.Scanner{},
+	&filecoinprivatekey.Scanner{},
+	&lndmacaroon.Scanner{},
+	&metamaskvault.Scanner{},
+}
+
+// corpusTruePositives lists, per corpus file, the raw secrets each detector is expected to find. Any other result
+// is a false positive. Files that are not listed must produce no results at all.
+var corpusTruePositives = map[string]map[detector_typepb.DetectorType][]string{
+	".env.production": {
+		detector_typepb.DetectorType_EthereumPrivateKey: {"0xba46c92ae7a9275e1f8697d9a06d3444b2a0fa704d675d0ecc52cf44a5184516"},
+		detector_typepb.DetectorType_BitcoinWIF:         {"KxFwha6w4dza1mdBs9DrFguHLfzYrgzgfFvZ3JTS1FEeq8z2AYEd"},
+	},
+	"hardhat.config.js": {
+		detector_typepb.DetectorType_EthereumPrivateKey: {"0xc962bcbd8a2f9ea44dffd93ff8d05ed3c77779b6a538da5934d61560a0e99d84"},
+	},
+	"truffle-config.js": {
+		// The mnemonic is passed to HDWalletProvider through a variable, which contractdeployer does not follow.
+		detector_typepb.DetectorType_ContractDeployer: {"dea2243444abfdb9c809e9338264d3353fa61a4000266f0ddbc5602031a96c79"},
+	},
+}
+
+// TestCorpus runs the corpus detectors over every file in testdata/corpus the way the engine does: chunks are
+// dispatched through the Aho-Corasick prefilter and only the matched spans are passed to FromData.
+func TestCorpus(t *testing.T) {
+	core := ahocorasick.NewAhoCorasickCore(corpusDetectors)
+
+	entries, err := os.ReadDir(corpusDir)
+	require.NoError(t, err)
+
+	seen := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		seen[name] = struct{}{}
+
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(corpusDir, name))
+			require.NoError(t, err)
+
+			got := make(map[detector_typepb.DetectorType][]string)
+			for _, match := range core.FindDetectorMatches(data) {
+				for _, chunk := range match.Matches() {
+					results, err := match.FromData(context.Background(), false, chunk)
+					require.NoError(t, err)
+					for _, r := range results {
+						got[r.DetectorType] = appendUnique(got[r.DetectorType], string(r.Raw))
+					}
+				}
+			}
+
+			want := corpusTruePositives[name]
+			for dt := range want {
+				sort.Strings(want[dt])
+			}
+			for dt := range got {
+				sort.Strings(got[dt])
+			}
+			if len(want) == 0 {
+				want = map[detector_typepb.DetectorType][]string{}
+			}
+			assert.Equal(t, want, got, "true positives are missing or false positives were found")
+		})
+	}
+
+	for name := range corpusTruePositives {
+		assert.Contains(t, seen, name, "corpus file with expected true positives does not exist")
+	}
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
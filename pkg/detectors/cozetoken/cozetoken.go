@@ -20,6 +20,7 @@ type Scanner struct {
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -142,3 +143,9 @@ func (s Scanner) Description() string {
 	return "Coze is an AI application development platform. The tokens can be used to access Coze APIs and services."
 }
 
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return "Delete the token on the Coze authorization page (https://www.coze.cn/open/oauth/pats, or " +
+		"https://www.coze.com/open/oauth/pats for coze.com) and create a replacement with only the permissions and " +
+		"workspaces it needs. Service access tokens (sat_) are deleted from the service identity they belong to."
+}
@@ -1,6 +1,7 @@
 package cozetoken
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -125,3 +126,28 @@ func TestCozeToken_Pattern(t *testing.T) {
 	}
 }
 
+func FuzzCozeToken_FromData(f *testing.F) {
+	for _, seed := range []string{
+		"API Token: pat_FzBqdi0lAvXGnM6GgS6hGbeV8JgQxSo6F8Obj5fcTG3Rde3dRC8rV7j4M4SieKQ2",
+		"COZE_TOKEN=sat_mO11PyjC5F82xSCchtx2hvlGn74Htf8z9HyO9Ig2ERlic2j2LXusPR1FhzibuhAG",
+		`{"api_token": "pat_FzBqdi0lAvXGnM6GgS6hGbeV8JgQxSo6F8Obj5fcTG3Rde3dRC8rV7j4M4SieKQ2"}`,
+		"coze pat_FzBqdi0lAvXGnM6GgS6hGbeV8J",
+		"扣子 coze 令牌：pat_FzBqdi0lAvXGnM6GgS6hGbeV8JgQxSo6F8Obj5fcTG3Rde3dRC8rV7j4M4SieKQ2 ",
+		"pat_",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	d := Scanner{}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		results, err := d.FromData(context.Background(), false, data)
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		for _, r := range results {
+			if !bytes.Contains(data, r.Raw) {
+				t.Errorf("FromData() returned %q, which is not in the input", r.Raw)
+			}
+		}
+	})
+}
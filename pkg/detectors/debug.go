@@ -0,0 +1,67 @@
+package detectors
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+)
+
+// PatternProvider is an optional interface that a detector can implement to
+// name the regular expressions it finds secrets with, so that debug findings
+// can tell which pattern and capture group produced a result.
+type PatternProvider interface {
+	Patterns() map[string]Pattern
+}
+
+// Pattern is the part of a compiled regular expression needed to attribute
+// results. Both regexp.Regexp and go-re2's Regexp implement it.
+type Pattern interface {
+	FindAllSubmatchIndex(b []byte, n int) [][]int
+}
+
+// FindingDebug attributes a result to the keyword dispatch and the patterns
+// that produced it. The engine only records it when debug findings are
+// enabled, to help report and tune false positives.
+type FindingDebug struct {
+	// Keywords are the detector's keywords found in the span it scanned.
+	Keywords []string
+	// Patterns are the capture groups that matched part of the secret. It is
+	// empty if the detector does not implement PatternProvider.
+	Patterns []PatternCapture `json:",omitempty"`
+}
+
+// PatternCapture is a capture group of a named pattern that matched part of a
+// secret.
+type PatternCapture struct {
+	Pattern string
+	// Group is the index of the capture group, or 0 for a pattern without
+	// capture groups.
+	Group int
+	// Offset is the position of the capture in the span the detector scanned.
+	Offset int
+}
+
+// AttributePatterns returns the capture groups of patterns in data whose text
+// is part of the result's Raw or RawV2, ordered by offset.
+func AttributePatterns(patterns map[string]Pattern, data []byte, res *Result) []PatternCapture {
+	var captures []PatternCapture
+	for _, name := range SortedKeys(patterns) {
+		for _, loc := range patterns[name].FindAllSubmatchIndex(data, -1) {
+			groups := len(loc) / 2
+			first := min(1, groups-1)
+			for group := first; group < groups; group++ {
+				start, end := loc[2*group], loc[2*group+1]
+				if start < 0 || start == end {
+					continue
+				}
+				capture := data[start:end]
+				if !bytes.Contains(res.Raw, capture) && !bytes.Contains(res.RawV2, capture) {
+					continue
+				}
+				captures = append(captures, PatternCapture{Pattern: name, Group: group, Offset: start})
+			}
+		}
+	}
+	slices.SortStableFunc(captures, func(a, b PatternCapture) int { return cmp.Compare(a.Offset, b.Offset) })
+	return captures
+}
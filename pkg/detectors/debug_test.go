@@ -0,0 +1,25 @@
+package detectors
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributePatterns(t *testing.T) {
+	patterns := map[string]Pattern{
+		"id":     regexp.MustCompile(`\b([a-z0-9]{8})\b`),
+		"secret": regexp.MustCompile(`secret=([A-Z0-9]{8})`),
+		"prefix": regexp.MustCompile(`tok_[a-z]+`),
+		"unused": regexp.MustCompile(`(zzz)`),
+	}
+	data := []byte("id abcd1234 secret=XYZ98765 tok_abc other99")
+	res := &Result{Raw: []byte("abcd1234:XYZ98765"), RawV2: []byte("tok_abc")}
+
+	assert.Equal(t, []PatternCapture{
+		{Pattern: "id", Group: 1, Offset: 3},
+		{Pattern: "secret", Group: 1, Offset: 19},
+		{Pattern: "prefix", Group: 0, Offset: 28},
+	}, AttributePatterns(patterns, data, res))
+}
@@ -21,6 +21,7 @@ type Scanner struct {
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.ContextHintProvider = (*Scanner)(nil)
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -34,6 +35,11 @@ func (s Scanner) Keywords() []string {
 	return []string{"deepseek"}
 }
 
+// ContextHints implements detectors.ContextHintProvider, sk- 加 32 位十六进制的格式和百炼相同
+func (s Scanner) ContextHints() []string {
+	return []string{"deepseek", "api.deepseek.com"}
+}
+
 // FromData will find and optionally verify DeepSeek secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
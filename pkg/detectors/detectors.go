@@ -81,6 +81,28 @@ type MultiPartCredentialProvider interface {
 	MaxCredentialSpan() int64
 }
 
+// KeywordOption changes how the prefilter matches a single keyword. Options
+// can be combined with a bitwise OR.
+type KeywordOption uint8
+
+const (
+	// KeywordCaseSensitive matches the keyword exactly as declared instead of
+	// ignoring case.
+	KeywordCaseSensitive KeywordOption = 1 << iota
+	// KeywordWholeWord only matches the keyword when it is not preceded or
+	// followed by an ASCII letter, digit or underscore.
+	KeywordWholeWord
+)
+
+// KeywordOptionsProvider is an optional interface that a detector can
+// implement to set options for some of its keywords. The map is keyed by the
+// keyword as returned from Keywords. Keywords without options are matched
+// case-insensitively anywhere in the chunk, so detectors only need to declare
+// one case variant of each keyword.
+type KeywordOptionsProvider interface {
+	KeywordOptions() map[string]KeywordOption
+}
+
 // EndpointCustomizer is an optional interface that a detector can implement to
 // support verifying against user-supplied endpoints.
 type EndpointCustomizer interface {
@@ -90,6 +112,68 @@ type EndpointCustomizer interface {
 	UseFoundEndpoints(bool)
 }
 
+// ReplayVerifier is an optional interface that a detector of session cookies
+// or tokens can implement to verify them by replaying them against the site
+// they were issued by. Replaying acts as the logged-in user, so it is only
+// enabled on explicit request.
+type ReplayVerifier interface {
+	SetReplayVerification(bool)
+}
+
+// AuthProbeVerifier is an optional interface that a detector of data store
+// passwords can implement to verify them by connecting to the server they were
+// found with and authenticating, without sending any other command. The
+// servers are taken from scanned data and are often internal, so probing them
+// is only enabled on explicit request.
+type AuthProbeVerifier interface {
+	SetAuthProbe(bool)
+}
+
+// DeepVerifier is an optional interface that a detector can implement for
+// checks that are too expensive to run during verification, such as fanning
+// out balance lookups to many chains or probing the permissions of a key. When
+// deep verification is enabled, the engine runs them for verified results on a
+// bounded queue, separate from the detector workers.
+type DeepVerifier interface {
+	// DeepVerify enriches a verified result, e.g. by adding to its ExtraData
+	// or raising its Severity. The result's ExtraData is a copy that may be
+	// modified.
+	DeepVerify(ctx context.Context, result *Result) error
+}
+
+// ContextHintProvider is an optional interface that a detector can implement
+// when its secrets share a format with other detectors' secrets, such as the
+// sk- prefixed keys of several AI providers. When more than one detector
+// reports the same secret in a chunk, the engine attributes the secret to the
+// detector whose hints occur in the chunk instead of reporting duplicates.
+type ContextHintProvider interface {
+	// ContextHints returns strings, such as API hostnames and environment
+	// variable names, whose presence near a secret indicates that it belongs
+	// to this detector. They are matched case-insensitively.
+	ContextHints() []string
+}
+
+// MetadataProvider is an optional interface that a detector can implement to
+// declare defaults for its results, so that reports and result policies do not
+// need to know about individual detector types.
+type MetadataProvider interface {
+	// DefaultSeverity is assigned to results the detector did not rate itself.
+	DefaultSeverity() Severity
+	// Tags categorize the detector's results, e.g. TagCrypto. They are added to
+	// every result.
+	Tags() []string
+}
+
+// RemediationProvider is an optional interface that a detector can implement to
+// tell triagers how to contain a leak of its secrets, so that reports carry the
+// steps for each credential type instead of relying on tribal knowledge.
+type RemediationProvider interface {
+	// Remediation describes how to contain a leaked secret, e.g. where to
+	// revoke and rotate it. It is assigned to results the detector did not
+	// give a remediation of their own.
+	Remediation() string
+}
+
 type CloudProvider interface {
 	CloudEndpoint() string
 }
@@ -114,6 +198,27 @@ type Result struct {
 	Redacted       string
 	ExtraData      map[string]string
 	StructuredData *detectorspb.StructuredData
+	// Severity ranks the impact of the secret leaking. Detectors may leave it unset, in which case the engine's
+	// result policy assigns one.
+	Severity Severity
+	// Tags categorize the result, e.g. TagCrypto. The engine adds those declared by the detector through
+	// MetadataProvider.
+	Tags []string
+	// Remediation tells triagers how to contain the leak, e.g. where to revoke the secret. Detectors may set one for
+	// a result, otherwise the engine assigns the one declared by the detector through RemediationProvider.
+	Remediation string
+	// Confidence rates how likely the result is to be a real secret. Detectors may leave it unset, in which case the
+	// engine's result policy derives one from the verification outcome.
+	Confidence Confidence
+	// Evidence lists the signals that contributed to Confidence when a detector combines several of them, for
+	// example two patterns matching the same secret. Use AddEvidence to record them.
+	Evidence []Evidence
+	// MatchedReportRule is the name of the configured report rule that matched this result, if any. Such results
+	// are always reported, regardless of result filtering.
+	MatchedReportRule string
+	// Debug attributes the result to the keywords and patterns that produced it. It is only set when debug findings
+	// are enabled.
+	Debug *FindingDebug
 
 	// verificationError should be populated if the verification process itself failed in a way that provides no
 	// information about the verification status of the candidate secret, such as if the verification request timed out.
@@ -173,6 +278,16 @@ func (r *Result) GetPrimarySecretValue() string {
 	return r.primarySecret.Value
 }
 
+// CloneExtraData replaces the result's ExtraData with a copy that may be modified. Post-processing that annotates a
+// result should copy rather than modify in place, as the map may be shared with cached verification results.
+func (r *Result) CloneExtraData() {
+	extraData := make(map[string]string, len(r.ExtraData)+2)
+	for k, v := range r.ExtraData {
+		extraData[k] = v
+	}
+	r.ExtraData = extraData
+}
+
 // redactSecrets replaces all instances of the given secrets with [REDACTED] in the error message.
 func redactSecrets(err error, secrets ...string) error {
 	lastErr := unwrapToLast(err)
@@ -221,6 +336,9 @@ type ResultWithMetadata struct {
 	// ChunkData holds the original pre-decode source chunk data, preserved
 	// for secret storage encryption in the dispatcher.
 	ChunkData []byte
+	// FollowUp is set on a result that was already dispatched and is
+	// dispatched again with the findings of its deep verification.
+	FollowUp bool
 }
 
 // CopyMetadata returns a detector result with included metadata from the source chunk.
@@ -36,7 +36,8 @@ const DoubaoURL = "https://ark.cn-beijing.volces.com/api/v3/chat/completions"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector         = (*Scanner)(nil)
+	_ detectors.MetadataProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -53,6 +54,12 @@ func (s Scanner) Description() string {
 	return "zijie doubao sk token"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagAI, detectors.TagChinaCloud} }
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -0,0 +1,68 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Downstream is a list of detectors that a detector re-runs on a value it extracted, such as decoded cloud-init
+// user-data or an environment variable inlined into a frontend bundle, so that the detector of the service the
+// value belongs to recognizes and verifies it. Each detector keeps its own list in a package variable, which tests
+// replace.
+type Downstream []Detector
+
+// FromData runs the detectors whose keywords occur in data, as the engine's keyword pre-filter would, and returns
+// all their results. A detector that fails is skipped, since the value is still reported by the detector that
+// extracted it.
+func (d Downstream) FromData(ctx context.Context, verify bool, data []byte) []Result {
+	results, _ := d.run(ctx, verify, data)
+	return results
+}
+
+// Verify reports whether any of the detectors verified data. If none did, the verification errors they reported
+// are returned.
+func (d Downstream) Verify(ctx context.Context, data []byte) (bool, error) {
+	results, err := d.run(ctx, true, data)
+	if err != nil {
+		return false, err
+	}
+	var errs []error
+	for _, r := range results {
+		if r.Verified {
+			return true, nil
+		}
+		if verificationErr := r.VerificationError(); verificationErr != nil {
+			errs = append(errs, verificationErr)
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+func (d Downstream) run(ctx context.Context, verify bool, data []byte) ([]Result, error) {
+	lower := strings.ToLower(string(data))
+
+	var results []Result
+	var errs []error
+	for _, detector := range d {
+		if !containsKeyword(lower, detector.Keywords()) {
+			continue
+		}
+		found, err := detector.FromData(ctx, verify, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, found...)
+	}
+	return results, errors.Join(errs...)
+}
+
+func containsKeyword(lower string, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(lower, strings.ToLower(kw)) {
+			return true
+		}
+	}
+	return false
+}
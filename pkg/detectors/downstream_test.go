@@ -0,0 +1,57 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keywordDetector finds its keyword and verifies the value if it is valid.
+type keywordDetector struct {
+	fakeDetector
+	keyword string
+	valid   string
+	err     error
+}
+
+func (d keywordDetector) FromData(_ context.Context, verify bool, data []byte) ([]Result, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return []Result{{Raw: data, Verified: verify && string(data) == d.valid}}, nil
+}
+
+func (d keywordDetector) Keywords() []string { return []string{d.keyword} }
+
+func TestDownstream_FromData(t *testing.T) {
+	d := Downstream{
+		keywordDetector{keyword: "SK_live"},
+		keywordDetector{keyword: "ghp_"},
+		keywordDetector{keyword: "sk_", err: errors.New("boom")},
+	}
+
+	results := d.FromData(context.Background(), false, []byte("sk_live_123"))
+	require.Len(t, results, 1)
+	assert.Equal(t, "sk_live_123", string(results[0].Raw))
+
+	assert.Empty(t, d.FromData(context.Background(), false, []byte("AKIA")))
+}
+
+func TestDownstream_Verify(t *testing.T) {
+	d := Downstream{keywordDetector{keyword: "jdbc", valid: "jdbc:valid"}}
+
+	verified, err := d.Verify(context.Background(), []byte("jdbc:valid"))
+	require.NoError(t, err)
+	assert.True(t, verified)
+
+	verified, err = d.Verify(context.Background(), []byte("jdbc:invalid"))
+	require.NoError(t, err)
+	assert.False(t, verified)
+
+	d = Downstream{keywordDetector{keyword: "jdbc", err: errors.New("boom")}}
+	_, err = d.Verify(context.Background(), []byte("jdbc:valid"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// etcd 地址来自扫描内容, 不允许访问内网地址
+	defaultClient = detectors.DetectorHttpClientWithNoLocalAddresses
+
+	// Bitnami 镜像的 ETCD_ROOT_PASSWORD
+	rootPasswordPat = regexp.MustCompile(`(?i)\betcd[_-]?root[_-]?password["']?\s*[:=]\s*["']?([^\s"'$]{4,128})`)
+	// etcdctl --user root:pass, etcdctl user add root:pass, ETCDCTL_USER=root:pass
+	userPat = regexp.MustCompile(`(?i)(?:\betcdctl\b[^\n]*?\s--user[= ]|\betcdctl\b[^\n]*?\suser\s+add\s+|\betcdctl_user["']?\s*[:=]\s*)["']?([a-z0-9_.-]{1,64}):([^\s"':$]{4,128})`)
+	// ETCDCTL_ENDPOINTS, --endpoints, ETCD_ADVERTISE_CLIENT_URLS 等, 可能以逗号分隔多个地址
+	endpointsPat = regexp.MustCompile(`(?i)(?:endpoints?|client[_-]urls)["']?\s*[:= ]\s*["']?(https?://[a-z0-9.-]+(?::[0-9]{1,5})?)`)
+	// 未标注的地址只认 etcd 的默认客户端端口
+	defaultPortPat = regexp.MustCompile(`(?i)\b(https?://[a-z0-9.-]+:2379)\b`)
+)
+
+// 证书链和私钥通常只有几 KB
+const maxPEMSize = 16 * 1024
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"etcd"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxPEMSize }
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find etcd client certificates stored with their private key and etcd user passwords in a given set
+// of bytes. Passwords are verified against the etcd endpoint found in the same data, if there is one.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	for _, pair := range detectors.FindCertKeyPairs(dataStr) {
+		// kubeadm 的证书: CN=kube-apiserver-etcd-client, 签发者 CN=etcd-ca
+		if pair.Cert.IsCA || !mentionsEtcd(pair.Cert.Subject.String()+" "+pair.Cert.Issuer.String()) {
+			continue
+		}
+		subject := pair.Cert.Subject.CommonName
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Etcd,
+			Raw:          []byte(pair.KeyPEM),
+			RawV2:        []byte(pair.CertPEM + pair.KeyPEM),
+			Redacted:     "client certificate " + subject,
+			ExtraData: map[string]string{
+				"type":    "client_certificate",
+				"subject": subject,
+				"issuer":  pair.Cert.Issuer.CommonName,
+			},
+		}
+		// 私钥与证书匹配, 不需要访问网络即可确认
+		s1.AddEvidence("key_matches_certificate", 0.9)
+		s1.SetExpiresAt(pair.Cert.NotAfter)
+		results = append(results, s1)
+	}
+
+	credentials := make(map[string]struct{})
+	for _, match := range rootPasswordPat.FindAllStringSubmatch(dataStr, -1) {
+		credentials["root:"+match[1]] = struct{}{}
+	}
+	for _, match := range userPat.FindAllStringSubmatch(dataStr, -1) {
+		credentials[match[1]+":"+match[2]] = struct{}{}
+	}
+	if len(credentials) == 0 {
+		return results, nil
+	}
+	endpoints := findEndpoints(dataStr)
+
+	for _, credential := range detectors.SortedKeys(credentials) {
+		user, password, _ := strings.Cut(credential, ":")
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Etcd,
+			Raw:          []byte(password),
+			RawV2:        []byte(credential),
+			Redacted:     user + ":****",
+			ExtraData: map[string]string{
+				"type": "password",
+				"user": user,
+			},
+		}
+		// root 用户拥有全部权限, 包括修改 Kubernetes 的所有对象
+		if user == "root" {
+			s1.Severity = detectors.SeverityCritical
+		}
+
+		// 没有地址时无法验证
+		if verify && len(endpoints) > 0 {
+			s.verify(ctx, &s1, user, password, endpoints)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+func mentionsEtcd(s string) bool {
+	return strings.Contains(strings.ToLower(s), "etcd")
+}
+
+// findEndpoints returns the etcd client endpoints in data, labeled ones first.
+func findEndpoints(data string) []string {
+	var endpoints []string
+	seen := make(map[string]struct{})
+	for _, pat := range []*regexp.Regexp{endpointsPat, defaultPortPat} {
+		for _, match := range pat.FindAllStringSubmatch(data, -1) {
+			endpoint := strings.ToLower(match[1])
+			if _, ok := seen[endpoint]; ok {
+				continue
+			}
+			seen[endpoint] = struct{}{}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func (s Scanner) verify(ctx context.Context, result *detectors.Result, user, password string, endpoints []string) {
+	client := s.getClient()
+
+	// 同一段数据中可能有多个集群的地址, 依次尝试
+	var lastErr error
+	for _, endpoint := range endpoints {
+		isVerified, err := authenticate(ctx, client, endpoint, user, password)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		lastErr = nil
+		if isVerified {
+			result.Verified = true
+			result.ExtraData["endpoint"] = endpoint
+			return
+		}
+	}
+	result.SetVerificationError(lastErr, password)
+}
+
+// authenticate 调用 v3 gRPC gateway 的 Auth/Authenticate, 成功时返回一个 token
+func authenticate(ctx context.Context, client *http.Client, endpoint, user, password string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"name": user, "password": password})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v3/auth/authenticate", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	var authRes struct {
+		Token   string `json:"token"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&authRes); err != nil {
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK && authRes.Token != "":
+		return true, nil
+	case strings.Contains(authRes.Message+authRes.Error, "authentication failed"):
+		// etcdserver: authentication failed, invalid user ID or password
+		return false, nil
+	default:
+		// 未启用认证时无法确认密码, 如 etcdserver: authentication is not enabled
+		msg := strings.TrimSpace(authRes.Message + " " + authRes.Error)
+		return false, fmt.Errorf("unexpected response (status %d): %s", res.StatusCode, msg)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Etcd
+}
+
+func (s Scanner) Description() string {
+	return "etcd is the key-value store behind Kubernetes and many service discovery setups. Client certificates with their keys and user passwords give read and write access to the stored data, which for Kubernetes includes every Secret in the cluster."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagInfrastructure} }
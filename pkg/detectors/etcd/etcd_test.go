@@ -0,0 +1,168 @@
+package etcd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+func TestEtcd_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	clientCert, clientKey := testCertificate(t, "kube-apiserver-etcd-client", "etcd-ca")
+	otherCert, otherKey := testCertificate(t, "web", "web-ca")
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "bitnami root password",
+			input: "environment:\n  - ETCD_ROOT_PASSWORD=Etcd-R00t-2024\n  - ETCD_ADVERTISE_CLIENT_URLS=http://etcd:2379",
+			want:  []string{"root:Etcd-R00t-2024"},
+		},
+		{
+			name: "etcdctl",
+			input: `etcdctl --endpoints=https://10.0.0.5:2379 --user root:s3cr3t-pw get / --prefix
+etcdctl user add app:app-pass-01`,
+			want: []string{"root:s3cr3t-pw", "app:app-pass-01"},
+		},
+		{
+			name:  "etcdctl environment",
+			input: `ETCDCTL_USER="backup:b4ckup-only"`,
+			want:  []string{"backup:b4ckup-only"},
+		},
+		{
+			name:  "client certificate",
+			input: "# etcd client\n" + clientCert + clientKey,
+			want:  []string{clientCert + clientKey},
+		},
+		{
+			name:  "invalid pattern - templated password",
+			input: "ETCD_ROOT_PASSWORD=${ETCD_ROOT_PASSWORD}",
+		},
+		{
+			name:  "invalid pattern - certificate of another service",
+			input: "# etcd\n" + otherCert + otherKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.RawV2)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEtcd_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Name, Password string }
+		if r.URL.Path != "/v3/auth/authenticate" || json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Name == "root" && req.Password == "s3cr3t-pw" {
+			_, _ = w.Write([]byte(`{"header":{"cluster_id":"1"},"token":"abc.123"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"etcdserver: authentication failed, invalid user ID or password","code":3,"message":"etcdserver: authentication failed, invalid user ID or password"}`))
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client()}
+	input := "etcdctl --endpoints=" + server.URL + " --user root:s3cr3t-pw get /\netcdctl --endpoints=" + server.URL + " --user app:wrong-pass get /"
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	byUser := make(map[string]detectors.Result)
+	for _, r := range results {
+		byUser[r.ExtraData["user"]] = r
+	}
+
+	root := byUser["root"]
+	assert.True(t, root.Verified)
+	assert.Equal(t, server.URL, root.ExtraData["endpoint"])
+	assert.Equal(t, detectors.SeverityCritical, root.Severity)
+
+	app := byUser["app"]
+	assert.False(t, app.Verified)
+	assert.NoError(t, app.VerificationError())
+}
+
+// testCertificate returns a certificate for commonName issued by a CA named issuer, and its private key, PEM encoded.
+func testCertificate(t *testing.T, commonName, issuer string) (string, string) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: issuer},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
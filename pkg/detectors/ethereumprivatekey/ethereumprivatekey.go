@@ -3,25 +3,34 @@ package ethereumprivatekey
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
+	"sync"
 
 	regexp "github.com/wasilibs/go-re2"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ethereum"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 )
 
 type Scanner struct {
 	client *http.Client
+	// rpcURL 验证时查询余额和 nonce 的以太坊主网 JSON-RPC 地址, 为空时使用 ethereum.PublicRPC
+	rpcURL string
+	// chains 深度验证时查询的 EVM 链, 为空时使用 ethereum.Chains
+	chains []ethereum.Chain
 }
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+var _ detectors.DeepVerifier = (*Scanner)(nil)
+var _ detectors.ContextHintProvider = (*Scanner)(nil)
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -36,21 +45,47 @@ var (
 
 	// 不带前缀，需要关键词上下文来减少误报
 	// 匹配类似: private_key: abc123..., "privateKey": "abc123..."
-	ethPrivKeyWithContext = regexp.MustCompile(`(?i)(?:private[_\-]?key|secret[_\-]?key|eth[_\-]?(?:private|secret)|wallet[_\-]?(?:key|secret)|signing[_\-]?key|account[_\-]?(?:key|secret)|priv[_\-]?key)["'\s:=]+["']?([a-f0-9]{64})["']?\b`)
+	// 中文文档常写作 私钥：0x… 或 “私钥”＝“…”, 分隔符同时接受全角冒号, 等号, 引号和全角空格
+	ethPrivKeyWithContext = regexp.MustCompile(`(?i)(?:private[_\-]?key|secret[_\-]?key|eth[_\-]?(?:private|secret)|wallet[_\-]?(?:key|secret)|signing[_\-]?key|account[_\-]?(?:key|secret)|priv[_\-]?key|` + detectors.ChineseKeyLabelPattern + `)["'\s:=` + detectors.FullWidthSeparators + `]+["'“‘「]?([a-f0-9]{64})["'”’」]?\b`)
+
+	// 0x + 64位十六进制同样是交易哈希、区块哈希、事件 topic、存储槽等的格式,
+	// 带前缀的匹配需要前文出现私钥相关的词
+	keyContextPat = regexp.MustCompile(`(?i)(?:priv|secret|key|wallet|signer|deployer|account|mnemonic|\bpk\b|\bsk\b|私钥|密钥|秘钥|钱包|助记词)`)
+	// 同一行匹配之前出现哈希相关的词时, 认为是哈希而不是私钥
+	hashContextPat = regexp.MustCompile(`(?i)(?:hash|tx|block|topic|root|proof|digest|checksum|sha\d|keccak|slot|salt|commit|selector|signature|event|bloom|哈希|交易|区块|签名|事件)`)
 )
 
+// keyContextWindow 带前缀的匹配向前查找上下文的字节数
+const keyContextWindow = 128
+
+// 置信度证据来源
+const (
+	evidencePrefixed = "prefixed_pattern"
+	evidenceContext  = "context_pattern"
+	evidenceEntropy  = "high_entropy"
+)
+
+// evidenceWeights 各证据单独成立时私钥为真的概率. 上下文正则要求私钥标签紧邻, 比前文出现关键词的带前缀匹配更可靠;
+// 熵只能排除人为构造的值, 权重最低
+var evidenceWeights = map[string]float64{
+	evidencePrefixed: 0.4,
+	evidenceContext:  0.6,
+	evidenceEntropy:  0.3,
+}
+
+// minKeyEntropy 随机生成的 64 位十六进制私钥的香农熵通常在 3.7 以上 (上限为 4)
+const minKeyEntropy = 3.5
+
 // Keywords are used for efficiently pre-filtering chunks.
 func (s Scanner) Keywords() []string {
 	return []string{
-		// 通用私钥关键词
+		// 通用私钥关键词, 关键词匹配不区分大小写
 		"private_key",
 		"privatekey",
 		"private-key",
-		"PRIVATE_KEY",
 		"secret_key",
 		"secretkey",
 		"secret-key",
-		"SECRET_KEY",
 		// 以太坊特定
 		"eth_private",
 		"eth_secret",
@@ -63,15 +98,36 @@ func (s Scanner) Keywords() []string {
 		"account_secret",
 		"priv_key",
 		"privkey",
+		// 中文文档中的私钥标签
+		"私钥",
+		"密钥",
+		"秘钥",
 		// 0x 前缀 (用于匹配带前缀的私钥)
 		"0x",
 	}
 }
 
+// ContextHints implements detectors.ContextHintProvider, 64 位十六进制也可能是其他链的私钥
+func (s Scanner) ContextHints() []string {
+	return []string{"ethereum", "web3", "ethers", "infura", "alchemy", "hardhat", "foundry", "metamask", "etherscan", "以太坊"}
+}
+
 func (s Scanner) Description() string {
 	return "Ethereum private keys are 256-bit numbers used to sign transactions and prove ownership of Ethereum addresses. They provide full control over the associated account and all its assets across Ethereum and EVM-compatible chains (BSC, Polygon, Arbitrum, etc.)."
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// 同一个私钥在所有 EVM 链上控制同一个地址, 每条链上的资产都要转移
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("address on Ethereum and every EVM chain it was used on")
+}
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
@@ -79,6 +135,87 @@ func (s Scanner) getClient() *http.Client {
 	return defaultClient
 }
 
+func (s Scanner) getRPCURL() string {
+	if s.rpcURL != "" {
+		return s.rpcURL
+	}
+	return ethereum.PublicRPC
+}
+
+// DeepVerify implements detectors.DeepVerifier.
+// 同一私钥在所有 EVM 链上的地址相同, 并发查询各链的余额和 nonce; 任一链上有余额时提升为 Critical
+func (s Scanner) DeepVerify(ctx context.Context, result *detectors.Result) error {
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(string(result.Raw), "0x"))
+	if err != nil {
+		return err
+	}
+	address, err := ethereum.AddressFromPrivateKey(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	chains := s.chains
+	if len(chains) == 0 {
+		chains = ethereum.Chains
+	}
+	type chainState struct {
+		balance *big.Int
+		txCount uint64
+		err     error
+	}
+	states := make([]chainState, len(chains))
+	client := s.getClient()
+	var wg sync.WaitGroup
+	for i, chain := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			st := &states[i]
+			if st.balance, st.err = ethereum.Balance(ctx, client, chain.RPC, address); st.err != nil {
+				return
+			}
+			st.txCount, st.err = ethereum.TransactionCount(ctx, client, chain.RPC, address)
+		}()
+	}
+	wg.Wait()
+
+	var balances, active, failed []string
+	var lastErr error
+	for i, chain := range chains {
+		st := states[i]
+		if st.err != nil {
+			failed = append(failed, chain.Name)
+			lastErr = fmt.Errorf("%s: %w", chain.Name, st.err)
+			continue
+		}
+		if st.balance.Sign() > 0 {
+			balances = append(balances, chain.Name+"="+st.balance.String())
+		}
+		if st.balance.Sign() > 0 || st.txCount > 0 {
+			active = append(active, chain.Name)
+		}
+	}
+	if len(failed) == len(chains) {
+		return fmt.Errorf("all %d chains failed, last error: %w", len(chains), lastErr)
+	}
+
+	if result.ExtraData == nil {
+		result.ExtraData = make(map[string]string)
+	}
+	result.ExtraData["chains_checked"] = fmt.Sprintf("%d", len(chains)-len(failed))
+	if len(active) > 0 {
+		result.ExtraData["chains_active"] = strings.Join(active, ", ")
+	}
+	if len(balances) > 0 {
+		result.ExtraData["chain_balances_wei"] = strings.Join(balances, ", ")
+		result.Severity = detectors.SeverityCritical
+	}
+	if len(failed) > 0 {
+		result.ExtraData["chains_failed"] = strings.Join(failed, ", ")
+	}
+	return nil
+}
+
 // isValidEthPrivateKey 验证以太坊私钥是否有效
 func isValidEthPrivateKey(hexKey string) bool {
 	// 移除 0x 前缀
@@ -162,30 +299,48 @@ func isSimplePattern(hexKey string) bool {
 	return false
 }
 
-// addressBalanceResponse 用于解析 Etherscan API 响应
-type addressBalanceResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Result  string `json:"result"`
+// hasKeyContext 检查带前缀匹配之前的文本是否像私钥赋值而不是哈希
+func hasKeyContext(data string, start int) bool {
+	before := data[max(start-keyContextWindow, 0):start]
+	line := before[strings.LastIndexByte(before, '\n')+1:]
+	if hashContextPat.MatchString(line) {
+		return false
+	}
+	return keyContextPat.MatchString(before)
 }
 
-// FromData will find and optionally verify Ethereum private keys in a given set of bytes.
+// isABIPadded 检测 ABI 编码中左侧补零的 20 字节地址 (如事件 topic 中的地址)
+func isABIPadded(key string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(key, "0x"), strings.Repeat("0", 24))
+}
+
+// FromData will find and optionally verify Ethereum private keys in a given set of bytes. A key is verified when its
+// address holds a balance or has sent transactions on Ethereum mainnet.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	// 用于去重
-	foundKeys := make(map[string]bool)
+	// 用于去重, 同时记录每个私钥被哪些正则匹配到
+	foundKeys := make(map[string][]string)
+	addKey := func(key, source string) {
+		for _, src := range foundKeys[key] {
+			if src == source {
+				return
+			}
+		}
+		foundKeys[key] = append(foundKeys[key], source)
+	}
 
 	// 1. 匹配带 0x 前缀的私钥
-	matchesWithPrefix := ethPrivKeyWithPrefix.FindAllStringSubmatch(dataStr, -1)
+	matchesWithPrefix := ethPrivKeyWithPrefix.FindAllStringSubmatchIndex(dataStr, -1)
 	for _, match := range matchesWithPrefix {
-		if len(match) < 2 {
+		if len(match) < 4 {
 			continue
 		}
-		key := strings.ToLower(match[1])
-		if !foundKeys[key] {
-			foundKeys[key] = true
+		key := strings.ToLower(dataStr[match[2]:match[3]])
+		if !hasKeyContext(dataStr, match[2]) || isABIPadded(key) {
+			continue
 		}
+		addKey(key, evidencePrefixed)
 	}
 
 	// 2. 匹配有上下文关键词的私钥 (不带前缀)
@@ -199,13 +354,12 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		if !strings.HasPrefix(key, "0x") {
 			key = "0x" + key
 		}
-		if !foundKeys[key] {
-			foundKeys[key] = true
-		}
+		addKey(key, evidenceContext)
 	}
 
-	// 处理找到的所有私钥
-	for key := range foundKeys {
+	// 处理找到的所有私钥, 按私钥排序使结果顺序在每次运行中保持一致
+	for _, key := range detectors.SortedKeys(foundKeys) {
+		sources := foundKeys[key]
 		// 验证私钥格式
 		if !isValidEthPrivateKey(key) {
 			continue
@@ -217,13 +371,23 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			Raw:          []byte(key),
 			Redacted:     key[:10] + "..." + key[len(key)-6:], // 显示前10位和后6位
 		}
+		// 两个正则同时匹配到同一个私钥时合并证据, 得到更高的置信度
+		for _, src := range sources {
+			s1.AddEvidence(src, evidenceWeights[src])
+		}
+		if detectors.StringShannonEntropy(strings.TrimPrefix(key, "0x")) >= minKeyEntropy {
+			s1.AddEvidence(evidenceEntropy, evidenceWeights[evidenceEntropy])
+		}
 
 		if verify {
 			client := s.getClient()
-			isVerified, extraData, verificationErr := verifyEthPrivateKey(ctx, client, key)
+			isVerified, extraData, verificationErr := verifyEthPrivateKey(ctx, client, s.getRPCURL(), key)
 			s1.Verified = isVerified
 			s1.ExtraData = extraData
 			s1.SetVerificationError(verificationErr, key)
+			if _, ok := extraData["known_label"]; ok {
+				s1.Severity = detectors.SeverityCritical
+			}
 		}
 
 		results = append(results, s1)
@@ -232,81 +396,72 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-// verifyEthPrivateKey 验证以太坊私钥
-func verifyEthPrivateKey(ctx context.Context, client *http.Client, hexKey string) (bool, map[string]string, error) {
+// verifyEthPrivateKey 派生私钥对应的地址, 并查询地址在主网上的余额和 nonce.
+// 格式正确的私钥随处可以生成, 只有控制着有余额或发出过交易的账户时才算验证通过
+func verifyEthPrivateKey(ctx context.Context, client *http.Client, rpcURL, hexKey string) (bool, map[string]string, error) {
 	extraData := make(map[string]string)
 
-	// 标记为有效的私钥格式
 	extraData["format"] = "ethereum_hex"
 	extraData["length"] = "256-bit"
 	extraData["compatible_chains"] = "Ethereum, BSC, Polygon, Arbitrum, Optimism, Avalanche, Fantom, etc."
 
-	// 注意：完整的验证需要：
-	// 1. 从私钥派生公钥 (需要 secp256k1 库)
-	// 2. 从公钥派生地址 (Keccak256 哈希)
-	// 3. 查询区块链 API 检查地址余额/交易历史
-	//
-	// 由于需要额外的加密库依赖 (如 go-ethereum)，这里只做格式验证
-	// 如果需要完整验证，可以集成 go-ethereum 的 crypto 包
-
-	// 格式验证通过即认为是有效的私钥
-	return true, extraData, nil
-}
-
-// verifyAddressOnChain 查询地址在链上的状态 (可选功能，需要 API key)
-// 这个函数展示了如何使用 Etherscan API 验证地址
-func verifyAddressOnChain(ctx context.Context, client *http.Client, address string, apiKey string) (bool, map[string]string, error) {
-	extraData := make(map[string]string)
-
-	// 使用 Etherscan API 查询余额
-	url := fmt.Sprintf("https://api.etherscan.io/api?module=account&action=balance&address=%s&tag=latest&apikey=%s", address, apiKey)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
 	if err != nil {
 		return false, extraData, err
 	}
-
-	//req.Header.Set("User-Agent", "TruffleHog")
-
-	res, err := client.Do(req)
+	address, err := ethereum.AddressFromPrivateKey(keyBytes)
 	if err != nil {
 		return false, extraData, err
 	}
-	defer res.Body.Close()
+	extraData["address"] = address
 
-	if res.StatusCode != http.StatusOK {
-		return false, extraData, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	// 交易所, 协议合约等知名地址的私钥泄露属于安全事件, 而不只是一个发现
+	if label, ok := ethereum.KnownLabel(address); ok {
+		extraData["known_label"] = label
 	}
-
-	var balanceResp addressBalanceResponse
-	if err := json.NewDecoder(res.Body).Decode(&balanceResp); err != nil {
-		return false, extraData, err
+	// ENS 反向解析只是补充信息, 查询失败不影响验证结果
+	if name, err := ethereum.ReverseENS(ctx, client, rpcURL, address); err == nil && name != "" {
+		extraData["ens_name"] = name
 	}
 
-	if balanceResp.Status != "1" {
-		return false, extraData, fmt.Errorf("API error: %s", balanceResp.Message)
+	// 余额为 0 的地址也可能是刚被转空或轮换的生产私钥, 通过 nonce 区分从未使用和已使用
+	status, err := addressHistory(ctx, client, rpcURL, address, extraData)
+	if err != nil {
+		return false, extraData, err
 	}
+	return status != historyNeverUsed, extraData, nil
+}
 
-	// 解析余额 (单位: wei)
-	balance := new(big.Int)
-	balance.SetString(balanceResp.Result, 10)
-
-	// 转换为 ETH (1 ETH = 10^18 wei)
-	ethBalance := new(big.Float).Quo(
-		new(big.Float).SetInt(balance),
-		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)),
-	)
-
-	extraData["address"] = address
-	extraData["balance_wei"] = balanceResp.Result
-	extraData["balance_eth"] = ethBalance.Text('f', 18)
+// 地址的链上历史状态
+const (
+	historyFunded    = "funded"
+	historyDrained   = "drained"
+	historyNeverUsed = "never_used"
+)
 
-	// 如果有余额，则认为是活跃的私钥
-	if balance.Cmp(big.NewInt(0)) > 0 {
-		return true, extraData, nil
+// addressHistory 查询地址的余额和 nonce, 并把历史状态写入 extraData
+func addressHistory(ctx context.Context, client *http.Client, rpcURL, address string, extraData map[string]string) (string, error) {
+	balance, err := ethereum.Balance(ctx, client, rpcURL, address)
+	if err != nil {
+		return "", err
 	}
-
-	return false, extraData, nil
+	txCount, err := ethereum.TransactionCount(ctx, client, rpcURL, address)
+	if err != nil {
+		return "", err
+	}
+	extraData["balance_wei"] = balance.String()
+	extraData["tx_count"] = fmt.Sprintf("%d", txCount)
+
+	// 没有发出过交易的地址, 余额只能来自转入; 余额和 nonce 都为 0 说明从未使用过
+	status := historyNeverUsed
+	switch {
+	case balance.Sign() > 0:
+		status = historyFunded
+	case txCount > 0:
+		status = historyDrained
+	}
+	extraData["history_status"] = status
+	return status, nil
 }
 
 func (s Scanner) Type() detector_typepb.DetectorType {
@@ -2,10 +2,16 @@ package ethereumprivatekey
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ethereum"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 )
@@ -58,6 +64,26 @@ func TestEthereumPrivateKey_Pattern(t *testing.T) {
 			input: "eth_private: " + validKeyNoPrefix,
 			want:  []string{"0x" + validKeyNoPrefix},
 		},
+		{
+			name:  "valid key with full-width colon",
+			input: "钱包私钥：" + validKeyWithPrefix,
+			want:  []string{validKeyWithPrefix},
+		},
+		{
+			name:  "valid key without prefix after Chinese label",
+			input: "“密钥”＝“" + validKeyNoPrefix + "”",
+			want:  []string{"0x" + validKeyNoPrefix},
+		},
+		{
+			name:  "valid key with ideographic space",
+			input: "私钥　" + validKeyNoPrefix,
+			want:  []string{"0x" + validKeyNoPrefix},
+		},
+		{
+			name:  "transaction hash after Chinese label is not a key",
+			input: "私钥：<已隐藏>\n交易哈希：" + validKeyWithPrefix,
+			want:  nil,
+		},
 		{
 			name:  "invalid key - all zeros",
 			input: "private_key: " + invalidKeyAllZero,
@@ -73,6 +99,16 @@ func TestEthereumPrivateKey_Pattern(t *testing.T) {
 			input: "private_key: " + invalidKeyRepeating,
 			want:  nil,
 		},
+		{
+			name:  "transaction hash is not a key",
+			input: "private_key: <redacted>\ntxHash: " + validKeyWithPrefix,
+			want:  nil,
+		},
+		{
+			name:  "bare 0x value without key context",
+			input: `"data": "` + validKeyWithPrefix + `"`,
+			want:  nil,
+		},
 		{
 			name:  "multiple valid keys",
 			input: "key1: " + validKeyWithPrefix + " key2: " + validKey2,
@@ -192,6 +228,54 @@ func TestEthereumPrivateKey_FromChunk(t *testing.T) {
 	}
 }
 
+func TestEthereumPrivateKey_Evidence(t *testing.T) {
+	ctx := context.Background()
+	d := Scanner{}
+
+	tests := []struct {
+		name           string
+		data           string
+		wantSources    []string
+		wantConfidence detectors.Confidence
+	}{
+		{
+			name:           "prefixed pattern only",
+			data:           "wallet = " + validKeyWithPrefix,
+			wantSources:    []string{evidencePrefixed, evidenceEntropy},
+			wantConfidence: detectors.ConfidenceMedium,
+		},
+		{
+			name:           "both patterns match the same key",
+			data:           "PRIVATE_KEY=" + validKeyNoPrefix + "\nconst wallet = new ethers.Wallet(\"" + validKeyWithPrefix + "\");",
+			wantSources:    []string{evidencePrefixed, evidenceContext, evidenceEntropy},
+			wantConfidence: detectors.ConfidenceHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.FromData(ctx, false, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("FromData() error = %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("FromData() got %d results, want 1", len(got))
+			}
+
+			var sources []string
+			for _, e := range got[0].Evidence {
+				sources = append(sources, e.Source)
+			}
+			if diff := cmp.Diff(tt.wantSources, sources); diff != "" {
+				t.Errorf("Evidence diff: (-want +got)\n%s", diff)
+			}
+			if got[0].Confidence != tt.wantConfidence {
+				t.Errorf("Confidence = %s, want %s", got[0].Confidence, tt.wantConfidence)
+			}
+		})
+	}
+}
+
 func TestIsValidEthPrivateKey(t *testing.T) {
 	tests := []struct {
 		name string
@@ -392,3 +476,174 @@ func TestRealWorldExamples(t *testing.T) {
 		})
 	}
 }
+
+func TestEthereumPrivateKey_DeepVerify(t *testing.T) {
+	// 每条链返回的余额和 nonce, 按 URL 路径区分
+	replies := map[string]map[string]string{
+		"/funded":  {"eth_getBalance": "0x2386f26fc10000", "eth_getTransactionCount": "0x3"},
+		"/drained": {"eth_getBalance": "0x0", "eth_getTransactionCount": "0x1"},
+		"/unused":  {"eth_getBalance": "0x0", "eth_getTransactionCount": "0x0"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"result": reply[req.Method]})
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), chains: []ethereum.Chain{
+		{Name: "funded", RPC: server.URL + "/funded"},
+		{Name: "drained", RPC: server.URL + "/drained"},
+		{Name: "unused", RPC: server.URL + "/unused"},
+		{Name: "down", RPC: server.URL + "/down"},
+	}}
+	result := detectors.Result{Raw: []byte(validKeyWithPrefix), ExtraData: map[string]string{}}
+	if err := d.DeepVerify(context.Background(), &result); err != nil {
+		t.Fatalf("DeepVerify() error = %v", err)
+	}
+	want := map[string]string{
+		"chains_checked":     "3",
+		"chains_active":      "funded, drained",
+		"chain_balances_wei": "funded=10000000000000000",
+		"chains_failed":      "down",
+	}
+	if diff := cmp.Diff(want, result.ExtraData); diff != "" {
+		t.Errorf("DeepVerify() ExtraData diff: (-want +got)\n%s", diff)
+	}
+	if result.Severity != detectors.SeverityCritical {
+		t.Errorf("DeepVerify() severity = %v, want %v", result.Severity, detectors.SeverityCritical)
+	}
+
+	// 所有链都查询失败时返回错误
+	d.chains = []ethereum.Chain{{Name: "down", RPC: server.URL + "/down"}}
+	if err := d.DeepVerify(context.Background(), &detectors.Result{Raw: []byte(validKeyWithPrefix)}); err == nil {
+		t.Error("DeepVerify() expected an error when every chain fails")
+	}
+}
+
+func TestEthereumPrivateKey_Verify(t *testing.T) {
+	// 主网返回的余额和 nonce, 按 URL 路径区分
+	replies := map[string]map[string]string{
+		"/funded":  {"eth_getBalance": "0x2386f26fc10000", "eth_getTransactionCount": "0x0"},
+		"/drained": {"eth_getBalance": "0x0", "eth_getTransactionCount": "0x5"},
+		"/unused":  {"eth_getBalance": "0x0", "eth_getTransactionCount": "0x0"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if _, ok := reply[req.Method]; !ok {
+			// ENS 反向解析的 eth_call
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": -32000, "message": "execution reverted"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"result": reply[req.Method]})
+	}))
+	defer server.Close()
+
+	keyBytes, _ := hex.DecodeString(strings.TrimPrefix(validKeyWithPrefix, "0x"))
+	wantAddress, err := ethereum.AddressFromPrivateKey(keyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path         string
+		wantStatus   string
+		wantVerified bool
+		wantErr      bool
+	}{
+		{path: "/funded", wantStatus: historyFunded, wantVerified: true},
+		{path: "/drained", wantStatus: historyDrained, wantVerified: true},
+		// 格式正确但从未使用过的私钥不算验证通过
+		{path: "/unused", wantStatus: historyNeverUsed},
+		{path: "/down", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			d := Scanner{client: server.Client(), rpcURL: server.URL + tt.path}
+			results, err := d.FromData(context.Background(), true, []byte("private_key = "+validKeyWithPrefix))
+			if err != nil {
+				t.Fatalf("FromData() error = %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("FromData() got %d results, want 1", len(results))
+			}
+			r := results[0]
+			if r.Verified != tt.wantVerified {
+				t.Errorf("Verified = %v, want %v", r.Verified, tt.wantVerified)
+			}
+			if (r.VerificationError() != nil) != tt.wantErr {
+				t.Errorf("VerificationError() = %v, wantErr %v", r.VerificationError(), tt.wantErr)
+			}
+			if r.ExtraData["address"] != wantAddress {
+				t.Errorf("address = %q, want %q", r.ExtraData["address"], wantAddress)
+			}
+			if r.ExtraData["history_status"] != tt.wantStatus {
+				t.Errorf("history_status = %q, want %q", r.ExtraData["history_status"], tt.wantStatus)
+			}
+		})
+	}
+}
+
+// FuzzEthereumPrivateKey_FromData 确保畸形输入 (奇数长度的十六进制、unicode、截断的 chunk) 不会导致 panic
+func FuzzEthereumPrivateKey_FromData(f *testing.F) {
+	for _, seed := range []string{
+		"private_key: " + validKeyWithPrefix,
+		"private_key=" + validKeyNoPrefix,
+		`{"privateKey": "` + validKeyNoPrefix + `"}`,
+		"key1: " + validKeyWithPrefix + " key2: " + validKey2,
+		"private_key: " + invalidKeyTooLarge,
+		"private_key: " + invalidKeyWrongLength,
+		"private_key: " + validKeyWithPrefix[:33],
+		"私钥 private_key：" + validKeyNoPrefix + " ",
+		"0x",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	d := Scanner{}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		results, err := d.FromData(context.Background(), false, data)
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		for _, r := range results {
+			if !isValidEthPrivateKey(string(r.Raw)) {
+				t.Errorf("FromData() returned invalid key %q", r.Raw)
+			}
+		}
+	})
+}
+
+func TestEthereumPrivateKey_FromData_Order(t *testing.T) {
+	input := "private_key: " + validKey2 + "\nprivate_key: " + validKeyWithPrefix
+
+	// 多次运行结果顺序必须一致, 不能依赖 foundKeys 的遍历顺序
+	for i := 0; i < 20; i++ {
+		results, err := Scanner{}.FromData(context.Background(), false, []byte(input))
+		if err != nil {
+			t.Fatalf("FromData() error = %v", err)
+		}
+		got := make([]string, 0, len(results))
+		for _, r := range results {
+			got = append(got, string(r.Raw))
+		}
+		if diff := cmp.Diff([]string{validKeyWithPrefix, validKey2}, got); diff != "" {
+			t.Fatalf("run %d: result order diff: (-want +got)\n%s", i, diff)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package ethvalidatorkeystore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// beaconURL 覆盖 beacon 节点 API 地址, 用于测试
+	beaconURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+// PublicNode 提供的公共 beacon 节点, 无需鉴权
+const defaultBeaconURL = "https://ethereum-beacon-api.publicnode.com"
+
+// keystore 的其他字段与 pubkey 的距离上限
+const fieldWindow = 2048
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// EIP-2335 keystore: {"crypto": {"kdf": {...}, "checksum": {...}, "cipher": {...}}, "pubkey": "<48 字节>", "path": "m/12381/3600/...", "version": 4}
+	// staking-deposit-cli 和各客户端导出的 keystore-m_12381_3600_*.json 都是这个格式
+	pubkeyPat   = regexp.MustCompile(`"pubkey"\s*:\s*"(?:0x)?([0-9a-fA-F]{96})"`)
+	versionPat  = regexp.MustCompile(`"version"\s*:\s*4\b`)
+	kdfPat      = regexp.MustCompile(`(?s)"kdf"\s*:\s*\{.{0,64}?"function"\s*:\s*"(scrypt|pbkdf2)"`)
+	checksumPat = regexp.MustCompile(`(?s)"checksum"\s*:\s*\{.{0,128}?"message"\s*:\s*"([0-9a-fA-F]{64})"`)
+	cipherPat   = regexp.MustCompile(`(?s)"cipher"\s*:\s*\{.{0,256}?"message"\s*:\s*"([0-9a-fA-F]{64})"`)
+	pathPat     = regexp.MustCompile(`"path"\s*:\s*"(m/12381/3600/[0-9/]+)"`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"pubkey"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getBeaconURL() string {
+	if s.beaconURL != "" {
+		return s.beaconURL
+	}
+	return defaultBeaconURL
+}
+
+// FromData will find and optionally verify Ethereum validator keystores (EIP-2335) in a given set of bytes. A keystore
+// is verified when the validator of its public key is pending or active on the beacon chain, i.e. when its key guards
+// staked ether.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	seen := make(map[string]struct{})
+
+	for _, idx := range pubkeyPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		window := dataStr[max(0, idx[0]-fieldWindow):min(len(dataStr), idx[1]+fieldWindow)]
+		if !versionPat.MatchString(window) {
+			continue
+		}
+		kdf := kdfPat.FindStringSubmatch(window)
+		checksum := checksumPat.FindStringSubmatch(window)
+		cipherMessage := cipherPat.FindStringSubmatch(window)
+		if kdf == nil || checksum == nil || cipherMessage == nil {
+			continue
+		}
+
+		// 加密后的私钥, 同一个 keystore 的多个副本只报告一次
+		raw := strings.ToLower(cipherMessage[1])
+		if _, ok := seen[raw]; ok {
+			continue
+		}
+		seen[raw] = struct{}{}
+
+		pubkey := "0x" + strings.ToLower(dataStr[idx[2]:idx[3]])
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_EthValidatorKeystore,
+			Raw:          []byte(raw),
+			RawV2:        []byte(pubkey + ":" + raw),
+			Redacted:     pubkey,
+			ExtraData: map[string]string{
+				"pubkey": pubkey,
+				"kdf":    kdf[1],
+			},
+		}
+		if path := pathPat.FindStringSubmatch(window); path != nil {
+			s1.ExtraData["path"] = path[1]
+		}
+
+		if verify {
+			validator, err := fetchValidator(ctx, s.getClient(), s.getBeaconURL(), pubkey)
+			if err != nil {
+				s1.SetVerificationError(err, raw)
+			} else if validator != nil {
+				s1.ExtraData["validator_index"] = validator.Index
+				s1.ExtraData["validator_status"] = validator.Status
+				s1.ExtraData["balance_gwei"] = validator.Balance
+				s1.Verified = isStaked(validator.Status)
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// isStaked 判断验证者是否还有质押: pending_* 和 active_* 状态的验证者可以被罚没, exited_* 和 withdrawal_* 状态的已经退出
+func isStaked(status string) bool {
+	return strings.HasPrefix(status, "pending_") || strings.HasPrefix(status, "active_")
+}
+
+// validator 是 /eth/v1/beacon/states/{state_id}/validators/{validator_id} 响应中的验证者
+type validator struct {
+	Index   string `json:"index"`
+	Balance string `json:"balance"`
+	Status  string `json:"status"`
+}
+
+// fetchValidator 查询验证者的状态, 公钥没有对应的验证者时返回 nil
+func fetchValidator(ctx context.Context, client *http.Client, beaconURL, pubkey string) (*validator, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, beaconURL+"/eth/v1/beacon/states/head/validators/"+pubkey, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var body struct {
+			Data validator `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		return &body.Data, nil
+	case http.StatusNotFound:
+		// 未存款或存款尚未被 beacon 链处理
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_EthValidatorKeystore
+}
+
+func (s Scanner) Description() string {
+	return "Ethereum validator keystores (EIP-2335) hold the password-encrypted BLS signing key of a proof-of-stake validator. A leaked keystore can be brute-forced offline, and whoever decrypts it can sign with the validator, including conflicting messages that get its stake slashed."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+// keystore 是加密的, 与 MetaMask vault 相同
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// 签名密钥无法更换, 只能退出验证者
+func (s Scanner) Remediation() string {
+	return "Submit a voluntary exit for the validator and let its stake be withdrawn to its withdrawal address; a " +
+		"validator's signing key can't be rotated. Until the exit completes, make sure the key is only loaded by one " +
+		"validator client, and remove the keystore from the source and its history."
+}
@@ -0,0 +1,175 @@
+package ethvalidatorkeystore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	// The pbkdf2 test vector of EIP-2335.
+	testPubkey        = "0x9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+	testCipherMessage = "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+
+	pbkdf2Keystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+
+	// A keystore as written by staking-deposit-cli, on a single line.
+	scryptKeystore = `{"crypto": {"kdf": {"function": "scrypt", "params": {"dklen": 32, "n": 262144, "r": 8, "p": 1, "salt": "0a1f0b6e3b3f2a7b9f4f0c4e0a7d0c6a9b1f5e8e2b1d9c3a4f7e6d5c4b3a2918"}, "message": ""}, "checksum": {"function": "sha256", "params": {}, "message": "3f1c5a2e8b7d6c4f9e0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60"}, "cipher": {"function": "aes-128-ctr", "params": {"iv": "7d4c1a2b3e4f5a6b7c8d9e0f1a2b3c4d"}, "message": "5b9e2f1a3c4d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f7"}}, "description": "", "pubkey": "a1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c", "path": "m/12381/3600/0/0/0", "uuid": "0d5c8a0e-5f7b-4b5a-9f61-2c4c3e0f8f3a", "version": 4, "network": "mainnet"}`
+)
+
+func TestEthValidatorKeystore_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name:  "pbkdf2 keystore",
+			input: pbkdf2Keystore,
+			want:  []map[string]string{{"pubkey": testPubkey, "kdf": "pbkdf2"}},
+		},
+		{
+			name:  "scrypt keystore",
+			input: scryptKeystore,
+			want: []map[string]string{{
+				"pubkey": "0xa1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c",
+				"kdf":    "scrypt",
+				"path":   "m/12381/3600/0/0/0",
+			}},
+		},
+		{
+			name:  "invalid pattern - deposit data",
+			input: `[{"pubkey": "a1d1ad0714035353258038e964ae9675dc0252ee22cea896825c01458e1807bfad2f9969338798548d9858a571f7425c", "withdrawal_credentials": "00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", "amount": 32000000000, "deposit_cli_version": "2.7.0"}]`,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - version 3 keystore",
+			input: strings.Replace(pbkdf2Keystore, `"version": 4`, `"version": 3`, 1),
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []map[string]string
+			for _, r := range results {
+				got = append(got, r.ExtraData)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestEthValidatorKeystore_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantVerified bool
+		wantErr      bool
+		wantStatus   string
+	}{
+		{
+			name:         "active validator",
+			status:       http.StatusOK,
+			body:         `{"execution_optimistic":false,"finalized":false,"data":{"index":"401234","balance":"32004518722","status":"active_ongoing","validator":{"pubkey":"` + testPubkey + `","slashed":false}}}`,
+			wantVerified: true,
+			wantStatus:   "active_ongoing",
+		},
+		{
+			name:       "exited validator",
+			status:     http.StatusOK,
+			body:       `{"data":{"index":"401234","balance":"0","status":"withdrawal_done","validator":{"pubkey":"` + testPubkey + `"}}}`,
+			wantStatus: "withdrawal_done",
+		},
+		{
+			name:   "unknown validator",
+			status: http.StatusNotFound,
+			body:   `{"code":404,"message":"Validator not found"}`,
+		},
+		{
+			name:    "beacon node error",
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/eth/v1/beacon/states/head/validators/"+testPubkey {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			d := Scanner{client: server.Client(), beaconURL: server.URL}
+			results, err := d.FromData(context.Background(), true, []byte(pbkdf2Keystore))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			r := results[0]
+			assert.Equal(t, testCipherMessage, string(r.Raw))
+			assert.Equal(t, tt.wantVerified, r.Verified)
+			assert.Equal(t, tt.wantErr, r.VerificationError() != nil)
+			assert.Equal(t, tt.wantStatus, r.ExtraData["validator_status"])
+		})
+	}
+}
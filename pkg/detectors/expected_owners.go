@@ -0,0 +1,113 @@
+package detectors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// Values of ExtraData["credential_owner"].
+const (
+	// OurCredential marks a verified credential whose identity matches an expected owner: it is rotated internally.
+	OurCredential = "our_credential"
+	// ThirdPartyCredential marks a verified credential whose identity matches no expected owner: it belongs to a
+	// vendor, customer or stranger, who has to be notified instead.
+	ThirdPartyCredential = "third_party_credential"
+)
+
+// ownerRule matches an identity field reported by verification against a pattern.
+type ownerRule struct {
+	// detector is the lowercase detector type the rule applies to, or empty for all detectors.
+	detector string
+	field    string
+	pattern  string
+	label    string
+}
+
+// ExpectedOwners cross-checks the identity that verification returns, such as the account, organization or user ID
+// a credential belongs to, against the identities the organization expects. A nil *ExpectedOwners matches nothing.
+type ExpectedOwners struct {
+	rules []ownerRule
+}
+
+// ReadExpectedOwners parses a list of expected owners. Each line holds an identity field of ExtraData, optionally
+// prefixed with a detector type and a colon, followed by whitespace, a pattern and an optional label. Patterns use
+// path.Match syntax, so "7345*" matches any user ID starting with 7345:
+//
+//	aws:account 123456789012 production
+//	github:company @acme-corp
+//	cozetoken:user_id 7345*
+//
+// Blank lines and lines starting with '#' are ignored.
+func ReadExpectedOwners(r io.Reader) (*ExpectedOwners, error) {
+	owners := &ExpectedOwners{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected an identity field and a pattern", lineNum)
+		}
+		if _, err := path.Match(fields[1], ""); err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNum, fields[1], err)
+		}
+		rule := ownerRule{field: fields[0], pattern: fields[1], label: strings.Join(fields[2:], " ")}
+		if detector, field, ok := strings.Cut(fields[0], ":"); ok {
+			rule.detector, rule.field = strings.ToLower(detector), field
+		}
+		owners.rules = append(owners.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// Check compares the identity of a verified result against the expected owners. It returns the owner classification
+// and the label of the matching rule, or false if the result has no identity to compare.
+func (o *ExpectedOwners) Check(res *Result) (owner string, label string, ok bool) {
+	if o == nil || !res.Verified {
+		return "", "", false
+	}
+	detector := strings.ToLower(detector_typepb.DetectorType_name[int32(res.DetectorType)])
+	for _, rule := range o.rules {
+		if rule.detector != "" && rule.detector != detector {
+			continue
+		}
+		value, present := res.ExtraData[rule.field]
+		if !present || value == "" {
+			continue
+		}
+		ok = true
+		if matched, _ := path.Match(rule.pattern, value); matched {
+			return OurCredential, rule.label, true
+		}
+	}
+	if !ok {
+		return "", "", false
+	}
+	return ThirdPartyCredential, "", true
+}
+
+// Tag records in ExtraData["credential_owner"] whether a verified credential belongs to an expected owner or to a
+// third party. Results without identity information are left untagged. It returns whether the result was tagged.
+func (o *ExpectedOwners) Tag(res *Result) bool {
+	owner, label, ok := o.Check(res)
+	if !ok {
+		return false
+	}
+	res.CloneExtraData()
+	res.ExtraData["credential_owner"] = owner
+	if label != "" {
+		res.ExtraData["credential_owner_label"] = label
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package detectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+func TestExpectedOwners_Tag(t *testing.T) {
+	input := `
+# Our cloud accounts.
+aws:account 123456789012 production
+github:company @acme-corp
+cozetoken:user_id 7345*
+`
+	owners, err := ReadExpectedOwners(strings.NewReader(input))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		res  Result
+		want map[string]string
+	}{
+		{
+			name: "our aws account",
+			res:  Result{DetectorType: detector_typepb.DetectorType_AWS, Verified: true, ExtraData: map[string]string{"account": "123456789012"}},
+			want: map[string]string{"account": "123456789012", "credential_owner": OurCredential, "credential_owner_label": "production"},
+		},
+		{
+			name: "third party aws account",
+			res:  Result{DetectorType: detector_typepb.DetectorType_AWS, Verified: true, ExtraData: map[string]string{"account": "999999999999"}},
+			want: map[string]string{"account": "999999999999", "credential_owner": ThirdPartyCredential},
+		},
+		{
+			name: "our coze user",
+			res:  Result{DetectorType: detector_typepb.DetectorType_CozeToken, Verified: true, ExtraData: map[string]string{"user_id": "7345001"}},
+			want: map[string]string{"user_id": "7345001", "credential_owner": OurCredential},
+		},
+		{
+			name: "field of another detector",
+			res:  Result{DetectorType: detector_typepb.DetectorType_Stripe, Verified: true, ExtraData: map[string]string{"account": "123456789012"}},
+			want: map[string]string{"account": "123456789012"},
+		},
+		{
+			name: "unverified",
+			res:  Result{DetectorType: detector_typepb.DetectorType_AWS, ExtraData: map[string]string{"account": "999999999999"}},
+			want: map[string]string{"account": "999999999999"},
+		},
+		{
+			name: "no identity",
+			res:  Result{DetectorType: detector_typepb.DetectorType_Github, Verified: true, ExtraData: map[string]string{"username": "octocat"}},
+			want: map[string]string{"username": "octocat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extraData := tt.res.ExtraData
+			owners.Tag(&tt.res)
+			assert.Equal(t, tt.want, tt.res.ExtraData)
+			assert.NotContains(t, extraData, "credential_owner", "the original map must not be modified")
+		})
+	}
+
+	var none *ExpectedOwners
+	res := Result{DetectorType: detector_typepb.DetectorType_AWS, Verified: true, ExtraData: map[string]string{"account": "123456789012"}}
+	assert.False(t, none.Tag(&res))
+}
+
+func TestReadExpectedOwners_Invalid(t *testing.T) {
+	_, err := ReadExpectedOwners(strings.NewReader("aws:account\n"))
+	assert.Error(t, err)
+
+	_, err = ReadExpectedOwners(strings.NewReader("aws:account [12\n"))
+	assert.Error(t, err)
+}
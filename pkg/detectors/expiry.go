@@ -0,0 +1,108 @@
+package detectors
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpiresAtKey is the ExtraData key under which detectors report when a credential expires, as an RFC 3339
+// timestamp in UTC. The engine's result policy downgrades results whose credential has already expired.
+const ExpiresAtKey = "expires_at"
+
+// SetExpiresAt records when the result's credential expires.
+func (r *Result) SetExpiresAt(t time.Time) {
+	if r.ExtraData == nil {
+		r.ExtraData = make(map[string]string)
+	}
+	r.ExtraData[ExpiresAtKey] = t.UTC().Format(time.RFC3339)
+}
+
+// ExpiresAt returns when the result's credential expires, if the detector could tell.
+func (r *Result) ExpiresAt() (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, r.ExtraData[ExpiresAtKey])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// JWTExpiry returns the exp claim of a JWT. The signature is not checked.
+func JWTExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == "" {
+		return time.Time{}, false
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil || exp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
+
+// SignedURLExpiry returns when a presigned URL or a signed query string stops being valid. It understands AWS SigV4
+// (X-Amz-Date and X-Amz-Expires), Google Cloud Storage V4 (X-Goog-Date and X-Goog-Expires), the Expires parameter of
+// AWS SigV2, CloudFront and Aliyun OSS URLs (a Unix timestamp), and the se parameter of Azure SAS tokens.
+func SignedURLExpiry(s string) (time.Time, bool) {
+	if _, query, ok := strings.Cut(s, "?"); ok {
+		s = query
+	}
+	query, err := url.ParseQuery(s)
+	if err != nil && len(query) == 0 {
+		return time.Time{}, false
+	}
+	get := func(key string) string {
+		for k, v := range query {
+			if strings.EqualFold(k, key) && len(v) > 0 {
+				return v[0]
+			}
+		}
+		return ""
+	}
+
+	for _, prefix := range []string{"X-Amz-", "X-Goog-"} {
+		date, expires := get(prefix+"Date"), get(prefix+"Expires")
+		if date == "" || expires == "" {
+			continue
+		}
+		signedAt, err := time.Parse("20060102T150405Z", date)
+		if err != nil {
+			return time.Time{}, false
+		}
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return signedAt.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if expires := get("Expires"); expires != "" {
+		seconds, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(seconds, 0), true
+	}
+
+	if se := query.Get("se"); se != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z", "2006-01-02"} {
+			if t, err := time.Parse(layout, se); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
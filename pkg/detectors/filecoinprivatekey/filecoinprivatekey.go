@@ -0,0 +1,254 @@
+package filecoinprivatekey
+
+import (
+	"bytes"
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// lotus wallet export 导出的是十六进制编码的 JSON: {"Type":"secp256k1","PrivateKey":"<base64>"}
+	// 7b2254797065223a22 即 `{"Type":"`
+	hexExportPat = regexp.MustCompile(`(?i)\b(7b2254797065223a22[0-9a-f]{80,240})\b`)
+	// keystore 中未编码的 KeyInfo
+	keyInfoPat = regexp.MustCompile(`\{\s*"Type"\s*:\s*"(secp256k1|bls)"\s*,\s*"PrivateKey"\s*:\s*"([A-Za-z0-9+/]{43}=)"\s*\}`)
+
+	// 地址使用不带填充的小写 base32
+	addressEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+)
+
+// Glif 提供的公共节点, 无需鉴权
+const glifRPC = "https://api.node.glif.io/rpc/v1"
+
+// keyInfo 是 lotus 的钱包私钥格式
+type keyInfo struct {
+	Type       string `json:"Type"`
+	PrivateKey []byte `json:"PrivateKey"`
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"7b2254797065223a22", `"PrivateKey"`}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Filecoin wallet private keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 用于去重
+	seen := make(map[string]struct{})
+
+	var keys []keyInfo
+	for _, match := range hexExportPat.FindAllStringSubmatch(dataStr, -1) {
+		decoded, err := hex.DecodeString(match[1])
+		if err != nil {
+			continue
+		}
+		var ki keyInfo
+		if err := json.Unmarshal(decoded, &ki); err != nil {
+			continue
+		}
+		keys = append(keys, ki)
+	}
+	for _, match := range keyInfoPat.FindAllStringSubmatch(dataStr, -1) {
+		privateKey, err := base64.StdEncoding.DecodeString(match[2])
+		if err != nil {
+			continue
+		}
+		keys = append(keys, keyInfo{Type: match[1], PrivateKey: privateKey})
+	}
+
+	for _, ki := range keys {
+		if !isValidKey(ki) {
+			continue
+		}
+		privateKey := hex.EncodeToString(ki.PrivateKey)
+		if _, ok := seen[privateKey]; ok {
+			continue
+		}
+		seen[privateKey] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_FilecoinPrivateKey,
+			Raw:          []byte(privateKey),
+			Redacted:     privateKey[:8] + "...",
+			ExtraData: map[string]string{
+				"type": ki.Type,
+			},
+		}
+
+		// BLS 公钥的推导需要 BLS12-381 的实现, 只有 secp256k1 私钥可以推导地址
+		if ki.Type == "secp256k1" {
+			address, err := secp256k1Address(ki.PrivateKey)
+			if err != nil {
+				continue
+			}
+			s1.ExtraData["address"] = address
+
+			if verify {
+				isVerified, balance, verificationErr := verifyAddress(ctx, s.getClient(), address)
+				s1.Verified = isVerified
+				if balance != "" {
+					s1.ExtraData["balance_attofil"] = balance
+				}
+				s1.SetVerificationError(verificationErr, privateKey)
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// isValidKey 校验私钥的结构: secp256k1 和 BLS 私钥都是 32 字节
+func isValidKey(ki keyInfo) bool {
+	switch ki.Type {
+	case "secp256k1":
+		return secp256k1.ValidPrivateKey(ki.PrivateKey)
+	case "bls":
+		return len(ki.PrivateKey) == 32 && !bytes.Equal(ki.PrivateKey, make([]byte, 32))
+	default:
+		return false
+	}
+}
+
+// secp256k1Address 推导 f1 地址: blake2b-160(未压缩公钥), 校验和为 blake2b-32(协议号 || payload)
+func secp256k1Address(privateKey []byte) (string, error) {
+	pub, err := secp256k1.PublicKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	payload, err := blake2bSum(pub, 20)
+	if err != nil {
+		return "", err
+	}
+	checksum, err := blake2bSum(append([]byte{0x01}, payload...), 4)
+	if err != nil {
+		return "", err
+	}
+	return "f1" + addressEncoding.EncodeToString(append(payload, checksum...)), nil
+}
+
+func blake2bSum(data []byte, size int) ([]byte, error) {
+	h, err := blake2b.New(size, nil)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// callRPC 调用 Lotus JSON-RPC 接口
+func callRPC(ctx context.Context, client *http.Client, method string, params ...any) (*rpcResponse, error) {
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "method": method, "params": params, "id": 1})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, glifRPC, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+
+	var rpcRes rpcResponse
+	if err := json.NewDecoder(res.Body).Decode(&rpcRes); err != nil {
+		return nil, err
+	}
+	return &rpcRes, nil
+}
+
+// verifyAddress 查询地址在链上是否存在 actor, 存在即说明该钱包被使用过
+func verifyAddress(ctx context.Context, client *http.Client, address string) (bool, string, error) {
+	lookup, err := callRPC(ctx, client, "Filecoin.StateLookupID", address, nil)
+	if err != nil {
+		return false, "", err
+	}
+	if lookup.Error != nil {
+		if strings.Contains(lookup.Error.Message, "not found") {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("unexpected RPC error: %s", lookup.Error.Message)
+	}
+
+	var balance string
+	if res, err := callRPC(ctx, client, "Filecoin.WalletBalance", address); err == nil && res.Error == nil {
+		_ = json.Unmarshal(res.Result, &balance)
+	}
+	return true, balance, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_FilecoinPrivateKey
+}
+
+func (s Scanner) Description() string {
+	return "Filecoin wallet private keys, as exported by lotus. They give full control over the FIL held by the wallet and over any storage deals it signs."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("Filecoin wallet")
+}
@@ -0,0 +1,86 @@
+package filecoinprivatekey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testKey       = "5bd7a8e3fe0e6a1c0b0a2e3cb4e8d3bbd8f5f6c1e3a2b7c4d9e0f1a2b3c4d5e6"
+	testSecp256k1 = "7b2254797065223a22736563703235366b31222c22507269766174654b6579223a225739656f342f344f6168774c43693438744f6a5475396a313973486a6f726645326544786f7250453165593d227d"
+	testBLS       = "7b2254797065223a22626c73222c22507269766174654b6579223a225739656f342f344f6168774c43693438744f6a5475396a313973486a6f726645326544786f7250453165593d227d"
+)
+
+func TestFilecoinPrivateKey_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid lotus secp256k1 export",
+			input: `lotus wallet import <<< ` + testSecp256k1,
+			want:  []string{testKey},
+		},
+		{
+			name:  "valid lotus bls export",
+			input: `FIL_WALLET_KEY=` + testBLS,
+			want:  []string{testKey},
+		},
+		{
+			name:  "valid keystore key info",
+			input: `{"Type":"secp256k1","PrivateKey":"W9eo4/4OahwLCi48tOjTu9j19sHjorfE2eDxorPE1eY="}`,
+			want:  []string{testKey},
+		},
+		{
+			name:  "invalid export - unknown key type",
+			input: `{"Type":"ed25519","PrivateKey":"W9eo4/4OahwLCi48tOjTu9j19sHjorfE2eDxorPE1eY="}`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
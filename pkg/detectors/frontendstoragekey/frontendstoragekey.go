@@ -0,0 +1,304 @@
+package frontendstoragekey
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// ossURL 和 cosURL 仅用于测试, 默认为阿里云 OSS 和腾讯云 COS 的服务地址
+	ossURL string
+	cosURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.StartOffsetProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 前端打包产物中的字符串属性: accessKeySecret:"...", "SecretKey": '...', accesskey = "..."
+	// 压缩后的代码没有换行和空格, 嵌入 JSON 字符串时引号可能被转义
+	propertyPat = regexp.MustCompile(`(?i)\b(access_?key_?id|access_?key_?secret|accessid|accesskey|secret_?id|secret_?key|bucket|region)\\?["']?\s*[:=]\s*\\?["'` + "`" + `]([^"'` + "`" + `\\\s]{1,128})\\?["'` + "`" + `]`)
+	// 浏览器端上传相关的 SDK 和代码特征: ali-oss, cos-js-sdk-v5, plupload/ueditor 直传 OSS 的示例代码, webpack 运行时
+	bundlePat = regexp.MustCompile(`(?i)ali-oss|new OSS\(|cos-js-sdk|new COS\(|OSSAccessKeyId|x-oss-|x-cos-|plupload|ueditor|__webpack_require__|webpackChunk`)
+	// 签发 STS 临时凭证的接口地址, 说明该应用本应使用临时凭证
+	stsEndpointPat = regexp.MustCompile(`(?i)["'` + "`" + `]((?:https?://[^"'` + "`" + `\s]*)?/[^"'` + "`" + `\s]*(?:\bsts\b|assume_?role|tmp_?credential|get_?credential|(?:oss|cos|upload)_?(?:token|policy|signature)|get_?signature)[^"'` + "`" + `\s]*)["'` + "`" + `]`)
+
+	aliyunIDPat      = regexp.MustCompile(`^LTAI[A-Za-z0-9]{12,22}$`)
+	aliyunSecretPat  = regexp.MustCompile(`^[A-Za-z0-9]{30}$`)
+	tencentIDPat     = regexp.MustCompile(`^AKID[A-Za-z0-9]{32}$`)
+	tencentSecretPat = regexp.MustCompile(`^[A-Za-z0-9]{32}$`)
+	ossRegionPat     = regexp.MustCompile(`^(?:oss-)?[a-z]{2}-[a-z0-9-]+$`)
+)
+
+const (
+	defaultOSSRegion = "oss-cn-hangzhou"
+	defaultCOSURL    = "https://service.cos.myqcloud.com"
+	// 在关键字前后查找配置项和打包特征的字节数
+	contextWindow = 4096
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"accessKeySecret", "access_key_secret", "accessid", "SecretKey", "secret_key", "ali-oss", "cos-js-sdk", "OSSAccessKeyId"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return contextWindow }
+
+// StartOffset implements detectors.StartOffsetProvider, AccessKeyId 和 SDK 特征可能出现在关键字之前
+func (s Scanner) StartOffset() int64 { return contextWindow }
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// bundleConfig 是从打包产物中提取的对象存储配置
+type bundleConfig struct {
+	aliyunIDs, aliyunSecrets   []string
+	tencentIDs, tencentSecrets []string
+	bucket, region             string
+}
+
+// FromData will find and optionally verify Aliyun OSS and Tencent Cloud COS keys embedded in frontend bundles in a
+// given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	// 服务端配置中的 AK/SK 由各云厂商的 detector 处理, 这里只关心下发到浏览器的代码
+	if !bundlePat.MatchString(dataStr) {
+		return nil, nil
+	}
+
+	cfg := parseConfig(dataStr)
+	var stsEndpoint string
+	if m := stsEndpointPat.FindStringSubmatch(dataStr); m != nil {
+		stsEndpoint = m[1]
+	}
+
+	newResult := func(provider, id, secret string) detectors.Result {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_FrontendStorageKey,
+			Raw:          []byte(id),
+			RawV2:        []byte(id + ":" + secret),
+			Redacted:     id,
+			// 打包进前端代码的密钥对所有访问者可见
+			Severity:  detectors.SeverityCritical,
+			ExtraData: map[string]string{"provider": provider},
+		}
+		if cfg.bucket != "" {
+			s1.ExtraData["bucket"] = cfg.bucket
+		}
+		if cfg.region != "" {
+			s1.ExtraData["region"] = cfg.region
+		}
+		if stsEndpoint != "" {
+			s1.ExtraData["sts_endpoint"] = stsEndpoint
+		}
+		return s1
+	}
+
+	for _, id := range cfg.aliyunIDs {
+		for _, secret := range cfg.aliyunSecrets {
+			s1 := newResult("aliyun_oss", id, secret)
+			if verify {
+				endpoint := s.ossURL
+				if endpoint == "" {
+					endpoint = ossEndpoint(cfg.region)
+				}
+				isVerified, verificationErr := verifyOSS(ctx, s.getClient(), endpoint, id, secret)
+				s1.Verified = isVerified
+				s1.SetVerificationError(verificationErr, secret)
+			}
+			results = append(results, s1)
+		}
+	}
+
+	for _, id := range cfg.tencentIDs {
+		for _, secret := range cfg.tencentSecrets {
+			s1 := newResult("tencent_cos", id, secret)
+			if verify {
+				endpoint := s.cosURL
+				if endpoint == "" {
+					endpoint = defaultCOSURL
+				}
+				isVerified, verificationErr := verifyCOS(ctx, s.getClient(), endpoint, id, secret)
+				s1.Verified = isVerified
+				s1.SetVerificationError(verificationErr, secret)
+			}
+			results = append(results, s1)
+		}
+	}
+
+	return results, nil
+}
+
+// parseConfig 遍历所有字符串属性, 按属性名和取值格式归类
+func parseConfig(data string) bundleConfig {
+	var cfg bundleConfig
+	seen := make(map[string]struct{})
+	add := func(list *[]string, v string) {
+		if _, ok := seen[v]; ok {
+			return
+		}
+		seen[v] = struct{}{}
+		*list = append(*list, v)
+	}
+
+	for _, m := range propertyPat.FindAllStringSubmatch(data, -1) {
+		name := strings.ReplaceAll(strings.ToLower(m[1]), "_", "")
+		value := m[2]
+		switch name {
+		case "accesskeyid", "accessid", "secretid":
+			switch {
+			case aliyunIDPat.MatchString(value):
+				add(&cfg.aliyunIDs, value)
+			case tencentIDPat.MatchString(value):
+				add(&cfg.tencentIDs, value)
+			}
+		case "accesskeysecret", "accesskey":
+			if aliyunSecretPat.MatchString(value) {
+				add(&cfg.aliyunSecrets, value)
+			}
+		case "secretkey":
+			switch {
+			case tencentSecretPat.MatchString(value):
+				add(&cfg.tencentSecrets, value)
+			case aliyunSecretPat.MatchString(value):
+				add(&cfg.aliyunSecrets, value)
+			}
+		case "bucket":
+			if cfg.bucket == "" {
+				cfg.bucket = value
+			}
+		case "region":
+			if cfg.region == "" {
+				cfg.region = value
+			}
+		}
+	}
+	return cfg
+}
+
+// ossEndpoint 返回 ali-oss 的 region 对应的服务地址, 如 oss-cn-beijing -> https://oss-cn-beijing.aliyuncs.com
+func ossEndpoint(region string) string {
+	if !ossRegionPat.MatchString(region) {
+		region = defaultOSSRegion
+	}
+	if !strings.HasPrefix(region, "oss-") {
+		region = "oss-" + region
+	}
+	return "https://" + region + ".aliyuncs.com"
+}
+
+// errorResponse 是 OSS 和 COS 共用的 XML 错误格式
+type errorResponse struct {
+	Code string `xml:"Code"`
+}
+
+// verifyOSS 用 OSS 签名 V1 调用 ListBuckets (GetService).
+// docs: https://help.aliyun.com/zh/oss/developer-reference/include-signatures-in-the-authorization-header
+func verifyOSS(ctx context.Context, client *http.Client, endpoint, id, secret string) (bool, error) {
+	date := time.Now().UTC().Format(http.TimeFormat)
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte("GET\n\n\n" + date + "\n/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Date", date)
+	req.Header.Set("Authorization", "OSS "+id+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return doRequest(client, req)
+}
+
+// verifyCOS 用 COS 的 q-sign 签名调用 GetService.
+// docs: https://cloud.tencent.com/document/product/436/7778
+func verifyCOS(ctx context.Context, client *http.Client, endpoint, id, secret string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/", nil)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now().Unix()
+	keyTime := strconv.FormatInt(now, 10) + ";" + strconv.FormatInt(now+600, 10)
+	signKey := hmacSHA1Hex(secret, keyTime)
+	httpString := "get\n/\n\nhost=" + url.QueryEscape(strings.ToLower(req.URL.Host)) + "\n"
+	httpStringSum := sha1.Sum([]byte(httpString))
+	stringToSign := "sha1\n" + keyTime + "\n" + hex.EncodeToString(httpStringSum[:]) + "\n"
+	signature := hmacSHA1Hex(signKey, stringToSign)
+
+	req.Header.Set("Authorization", fmt.Sprintf("q-sign-algorithm=sha1&q-ak=%s&q-sign-time=%s&q-key-time=%s&q-header-list=host&q-url-param-list=&q-signature=%s",
+		id, keyTime, keyTime, signature))
+	return doRequest(client, req)
+}
+
+func hmacSHA1Hex(key, message string) string {
+	mac := hmac.New(sha1.New, []byte(key))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func doRequest(client *http.Client, req *http.Request) (bool, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusForbidden:
+		var errRes errorResponse
+		if err := xml.NewDecoder(res.Body).Decode(&errRes); err != nil {
+			return false, err
+		}
+		switch errRes.Code {
+		case "InvalidAccessKeyId", "SignatureDoesNotMatch":
+			return false, nil
+		case "AccessDenied":
+			// 签名通过, 只是没有列举存储桶的权限, 密钥仍然有效
+			return true, nil
+		default:
+			return false, fmt.Errorf("unexpected error code %q", errRes.Code)
+		}
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_FrontendStorageKey
+}
+
+func (s Scanner) Description() string {
+	return "Aliyun OSS and Tencent Cloud COS access keys embedded in frontend JavaScript bundles for direct browser uploads. Every visitor of the site can read them and use them to access the cloud account, usually with read and write access to its storage buckets."
+}
@@ -0,0 +1,148 @@
+package frontendstoragekey
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	aliyunID      = "LTAI5tQ8Zk3nVw7RbXy2Hc9P"
+	aliyunSecret  = "Xy8kPq2RtW9vNm4LsA7dJf3GhB6cZe"
+	tencentID     = "AKIDz8krbsJ5yKBZQpn74WFkmLPx3gnPhESA"
+	tencentSecret = "Gu5t9xGARNpq86cd98joQYCN3Cozk1qA"
+)
+
+var (
+	aliOSSBundle = `(self.webpackChunkapp=self.webpackChunkapp||[]).push([[143],{7621:function(e,t,n){"use strict";var r=n(3720),o=n.n(r);` +
+		`function a(){return o().get("/api/oss/sts")}t.Z=new(o())({region:"oss-cn-beijing",accessKeyId:"` + aliyunID + `",accessKeySecret:"` + aliyunSecret + `",bucket:"static-assets"})}}]);`
+	cosBundle = `var COS=require("cos-js-sdk-v5");var cos=new COS({SecretId:'` + tencentID + `',SecretKey:'` + tencentSecret + `'});` +
+		`cos.putObject({Bucket:'upload-1250000000',Region:'ap-guangzhou',Key:e.name,Body:e})`
+	pluploadBundle = `accessid = '` + aliyunID + `';accesskey = '` + aliyunSecret + `';host = 'https://img.oss-cn-hangzhou.aliyuncs.com';` +
+		`new_multipart_params = {'key': key, 'policy': policyBase64, 'OSSAccessKeyId': accessid, 'success_action_status': '200', 'signature': signature};`
+)
+
+func TestFrontendStorageKey_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - ali-oss client in webpack chunk",
+			input: aliOSSBundle,
+			want:  []string{aliyunID + ":" + aliyunSecret},
+		},
+		{
+			name:  "valid pattern - cos-js-sdk client",
+			input: cosBundle,
+			want:  []string{tencentID + ":" + tencentSecret},
+		},
+		{
+			name:  "valid pattern - plupload direct upload",
+			input: pluploadBundle,
+			want:  []string{aliyunID + ":" + aliyunSecret},
+		},
+		{
+			name:  "invalid pattern - server-side config",
+			input: "aliyun:\n  oss:\n    accessKeyId: \"" + aliyunID + "\"\n    accessKeySecret: \"" + aliyunSecret + "\"\n",
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - STS credentials fetched at runtime",
+			input: `new OSS({region:e.region,accessKeyId:e.AccessKeyId,accessKeySecret:e.AccessKeySecret,stsToken:e.SecurityToken})`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, string(r.RawV2))
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestFrontendStorageKey_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(aliOSSBundle))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]string{
+		"provider":     "aliyun_oss",
+		"bucket":       "static-assets",
+		"region":       "oss-cn-beijing",
+		"sts_endpoint": "/api/oss/sts",
+	}, results[0].ExtraData)
+	assert.Equal(t, detectors.SeverityCritical, results[0].Severity)
+}
+
+func TestFrontendStorageKey_Verify(t *testing.T) {
+	// OSS 用 AccessKeySecret 重新计算签名
+	oss := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mac := hmac.New(sha1.New, []byte(aliyunSecret))
+		mac.Write([]byte("GET\n\n\n" + r.Header.Get("Date") + "\n/"))
+		if r.Header.Get("Authorization") != "OSS "+aliyunID+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>SignatureDoesNotMatch</Code></Error>`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code></Error>`))
+	}))
+	defer oss.Close()
+
+	cos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "q-ak="+tencentID+"&") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<Error><Code>InvalidAccessKeyId</Code></Error>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<ListAllMyBucketsResult></ListAllMyBucketsResult>`))
+	}))
+	defer cos.Close()
+
+	d := Scanner{client: oss.Client(), ossURL: oss.URL, cosURL: cos.URL}
+	for _, input := range []string{aliOSSBundle, cosBundle} {
+		results, err := d.FromData(context.Background(), true, []byte(input))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Verified)
+		assert.NoError(t, results[0].VerificationError())
+	}
+
+	wrongSecret := strings.ReplaceAll(aliOSSBundle, aliyunSecret, strings.ToLower(aliyunSecret))
+	results, err := d.FromData(context.Background(), true, []byte(wrongSecret))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
@@ -2,7 +2,12 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -66,7 +71,16 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			AnalysisInfo: map[string]string{"key": token},
 		}
 
-		if verify {
+		if verify && strings.HasPrefix(token, "ghs_") {
+			// Installation tokens act as a GitHub App installation rather than a user, so /user rejects them.
+			isVerified, repositories, err := s.verifyInstallationToken(ctx, common.SaneHttpClient(), token)
+			s1.Verified = isVerified
+			s1.SetVerificationError(err, token)
+			if isVerified {
+				s1.ExtraData["token_type"] = "installation"
+				s1.ExtraData["repositories"] = strconv.Itoa(repositories)
+			}
+		} else if verify {
 			client := common.SaneHttpClient()
 
 			isVerified, userResponse, headers, err := s.VerifyGithub(ctx, client, token)
@@ -87,6 +101,48 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
+// verifyInstallationToken lists the repositories an installation token can access, and returns their count.
+// docs: https://docs.github.com/en/rest/apps/installations#list-repositories-accessible-to-the-app-installation
+func (s Scanner) verifyInstallationToken(ctx context.Context, client *http.Client, token string) (bool, int, error) {
+	var requestErr error
+	for _, url := range s.Endpoints() {
+		requestErr = nil
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/installation/repositories?per_page=1", nil)
+		if err != nil {
+			continue
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		res, err := client.Do(req)
+		if err != nil {
+			requestErr = err
+			continue
+		}
+		var body struct {
+			TotalCount int `json:"total_count"`
+		}
+		status := res.StatusCode
+		if status == http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&body)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+
+		switch {
+		case status == http.StatusOK && err == nil:
+			return true, body.TotalCount, nil
+		case status == http.StatusUnauthorized:
+			// Installation tokens expire after an hour.
+			continue
+		default:
+			requestErr = fmt.Errorf("unexpected HTTP response status %d", status)
+		}
+	}
+	return false, 0, requestErr
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_Github
 }
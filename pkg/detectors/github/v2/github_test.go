@@ -2,9 +2,13 @@ package github
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
@@ -86,3 +90,26 @@ func TestGithub_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestGithub_VerifyInstallationToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/installation/repositories" || r.Header.Get("Authorization") != "Bearer "+secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_count": 42, "repositories": []}`))
+	}))
+	defer server.Close()
+
+	s := Scanner{}
+	require.NoError(t, s.SetConfiguredEndpoints(server.URL))
+
+	verified, repositories, err := s.verifyInstallationToken(context.Background(), server.Client(), secret)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, 42, repositories)
+
+	verified, _, err = s.verifyInstallationToken(context.Background(), server.Client(), "ghs_expired")
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
@@ -2,6 +2,7 @@ package googlegemini
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -61,10 +62,16 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			isVerified, extraData, verificationErr := s.verify(ctx, resMatch)
+			isVerified, extraData, project, verificationErr := s.verify(ctx, resMatch)
 			s1.Verified = isVerified
 			s1.ExtraData = extraData
 			s1.SetVerificationError(verificationErr)
+			if extraData["active_google_key"] == "true" {
+				s1.AnalysisInfo = map[string]string{"key": resMatch}
+				if project != "" {
+					s1.AnalysisInfo["project"] = project
+				}
+			}
 		}
 
 		results = append(results, s1)
@@ -73,11 +80,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) verify(ctx context.Context, key string) (bool, map[string]string, error) {
+// verify checks whether the key can access Gemini. It also returns the Google Cloud project that owns the key, which
+// Google only reports when the key is rejected for the API.
+func (s Scanner) verify(ctx context.Context, key string) (bool, map[string]string, string, error) {
 	req, err := http.NewRequestWithContext(
 		ctx, http.MethodGet, "https://generativelanguage.googleapis.com/v1/models", http.NoBody)
 	if err != nil {
-		return false, nil, fmt.Errorf("error constructing request: %w", err)
+		return false, nil, "", fmt.Errorf("error constructing request: %w", err)
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-goog-api-key", key)
@@ -85,7 +94,7 @@ func (s Scanner) verify(ctx context.Context, key string) (bool, map[string]strin
 	client := s.getClient()
 	res, err := client.Do(req)
 	if err != nil {
-		return false, nil, fmt.Errorf("error making request: %w", err)
+		return false, nil, "", fmt.Errorf("error making request: %w", err)
 	}
 	defer func() {
 		_, _ = io.Copy(io.Discard, res.Body)
@@ -95,14 +104,36 @@ func (s Scanner) verify(ctx context.Context, key string) (bool, map[string]strin
 	switch res.StatusCode {
 	case http.StatusOK:
 		// Key is valid and has access to gemini
-		return true, map[string]string{"active_google_key": "true"}, nil
+		return true, map[string]string{"active_google_key": "true"}, "", nil
 	case http.StatusForbidden:
 		// Key is valid but does not have access to gemini
-		return false, map[string]string{"active_google_key": "true"}, nil
+		return false, map[string]string{"active_google_key": "true"}, consumerProject(res.Body), nil
 	case http.StatusBadRequest:
 		// Key is invalid (expired, revoked)
-		return false, nil, nil
+		return false, nil, "", nil
 	default:
-		return false, nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+		return false, nil, "", fmt.Errorf("unexpected status code: %d", res.StatusCode)
 	}
 }
+
+type errorResponse struct {
+	Error struct {
+		Details []struct {
+			Metadata map[string]string `json:"metadata"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// consumerProject returns the project, e.g. "projects/123456789", that Google attributes a rejected request to.
+func consumerProject(body io.Reader) string {
+	var errRes errorResponse
+	if err := json.NewDecoder(body).Decode(&errRes); err != nil {
+		return ""
+	}
+	for _, detail := range errRes.Error.Details {
+		if consumer := detail.Metadata["consumer"]; strings.HasPrefix(consumer, "projects/") {
+			return consumer
+		}
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ package googlegemini
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -78,3 +79,25 @@ func TestGoogleGemini_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestGoogleGemini_ConsumerProject(t *testing.T) {
+	body := `{
+  "error": {
+    "code": 403,
+    "status": "PERMISSION_DENIED",
+    "details": [
+      {
+        "@type": "type.googleapis.com/google.rpc.ErrorInfo",
+        "reason": "SERVICE_DISABLED",
+        "metadata": {"consumer": "projects/123456789012", "service": "generativelanguage.googleapis.com"}
+      }
+    ]
+  }
+}`
+	if got := consumerProject(strings.NewReader(body)); got != "projects/123456789012" {
+		t.Errorf("consumerProject() = %q, want %q", got, "projects/123456789012")
+	}
+	if got := consumerProject(strings.NewReader("not json")); got != "" {
+		t.Errorf("consumerProject() = %q, want empty", got)
+	}
+}
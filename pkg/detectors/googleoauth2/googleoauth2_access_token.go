@@ -49,7 +49,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		tokens[matches[1]] = struct{}{}
 	}
 
-	for token := range tokens {
+	for _, token := range detectors.SortedKeys(tokens) {
 		s1 := detectors.Result{
 			DetectorType: s.Type(),
 			Raw:          []byte(token),
@@ -102,7 +102,7 @@ func (s Scanner) verify(ctx context.Context, token string) (bool, map[string]str
 
 		exp, err := strconv.ParseInt(token.Expiry, 10, 64)
 		if err == nil {
-			extraData["expires_at"] = time.Unix(exp, 0).String()
+			extraData[detectors.ExpiresAtKey] = time.Unix(exp, 0).UTC().Format(time.RFC3339)
 		}
 		return true, extraData, nil
 	} else if res.StatusCode == http.StatusBadRequest {
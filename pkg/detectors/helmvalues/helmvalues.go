@@ -0,0 +1,162 @@
+package helmvalues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	aws_access_keys "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/aws/access_keys"
+	github "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/github/v2"
+	gitlab "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gitlab/v2"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/openai"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sendgrid"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/slack"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/stripe"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// helm create 生成的 values.yaml 顶层字段, 用来识别 Helm values 文件
+	helmValuesPat = regexp.MustCompile(`(?m)^(?:replicaCount|imagePullSecrets|nameOverride|fullnameOverride):`)
+	// 键名中含有 password, secret, token 等字样
+	secretKeyPat = regexp.MustCompile(`(?i)(?:passw(?:or)?d|secret|token|api[_-]?key|access[_-]?key)`)
+	// 引用其他 Secret 或描述配置的键, 值不是密钥本身, 如 existingSecret, secretName, tokenTTL, accessKeyId, adminPasswordKey
+	referenceKeyPat = regexp.MustCompile(`(?i)^(?:existing|use|create|enable|auto)|(?:id|name|ref|file|path|mount|mountpath|enabled|annotations|labels|length|policy|ttl|type|selector|provider|passwordkey|tokenkey)$`)
+)
+
+// placeholders 是 chart 中常见的示例值
+var placeholders = map[string]struct{}{
+	"changeme":  {},
+	"change-me": {},
+	"changeit":  {},
+	"password":  {},
+	"secret":    {},
+	"token":     {},
+	"none":      {},
+	"null":      {},
+	"xxxx":      {},
+}
+
+// downstream 是能够识别值格式的 detector. 被它们识别的值由它们直接报告并验证, 本 detector 不重复报告
+var downstream = detectors.Downstream{
+	aws_access_keys.New(),
+	github.Scanner{},
+	gitlab.Scanner{},
+	slack.Scanner{},
+	stripe.Scanner{},
+	openai.Scanner{},
+	sendgrid.Scanner{},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"replicacount", "imagepullsecrets", "nameoverride", "fullnameoverride"}
+}
+
+// FromData will find plaintext secrets under password, secret and token keys of Helm values files in a given set
+// of bytes. Values in a format known to a more specific detector are left to that detector, which also verifies them.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	if !helmValuesPat.Match(data) {
+		return nil, nil
+	}
+
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, nil
+	}
+
+	found := make(map[string]string)
+	collect("", values, found)
+
+	for _, path := range detectors.SortedKeys(found) {
+		value := found[path]
+		if s.routed(ctx, value) {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_HelmValues,
+			Raw:          []byte(value),
+			RawV2:        []byte(path + "=" + value),
+			Redacted:     path,
+			ExtraData:    map[string]string{"key": path},
+		}
+		// 只能根据键名判断, 没有可用于验证的服务
+		s1.AddEvidence("helm values key", 0.6)
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// collect 遍历 values, 记录键名像密钥的字符串值, 以点分路径为键
+func collect(prefix string, node any, found map[string]string) {
+	switch v := node.(type) {
+	case map[string]any:
+		for k, child := range v {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+			if value, ok := child.(string); ok {
+				if isSecretKey(k) && isPlaintext(value) {
+					found[path] = value
+				}
+				continue
+			}
+			collect(path, child, found)
+		}
+	case []any:
+		for i, child := range v {
+			collect(fmt.Sprintf("%s[%d]", prefix, i), child, found)
+		}
+	}
+}
+
+func isSecretKey(key string) bool {
+	return secretKeyPat.MatchString(key) && !referenceKeyPat.MatchString(key)
+}
+
+// isPlaintext 排除空值, 模板表达式, 环境变量引用和示例值
+func isPlaintext(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) < 4 || strings.Contains(value, "{{") || strings.HasPrefix(value, "${") {
+		return false
+	}
+	if strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">") {
+		return false
+	}
+	_, ok := placeholders[strings.ToLower(value)]
+	return !ok
+}
+
+// routed 判断是否有更具体的 detector 能识别该值
+func (s Scanner) routed(ctx context.Context, value string) bool {
+	return len(downstream.FromData(ctx, false, []byte(value))) > 0
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagKubernetes} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_HelmValues
+}
+
+func (s Scanner) Description() string {
+	return "Helm values files configure Kubernetes applications. Passwords, secrets and tokens written into them in plaintext end up in the chart repository and in every rendered release."
+}
@@ -0,0 +1,157 @@
+package helmvalues
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+const valuesYAML = `
+replicaCount: 2
+image:
+  repository: registry.internal/orders
+  pullPolicy: IfNotPresent
+imagePullSecrets:
+  - name: regcred
+postgresql:
+  auth:
+    username: orders
+    password: "Pg-0rders!2024"
+    existingSecret: ""
+    secretKeys:
+      adminPasswordKey: postgres-password
+redis:
+  auth:
+    password: changeme
+    existingSecretPasswordKey: redis-password
+app:
+  jwtSecret: 9f3b2c71e4d84a6c
+  apiToken: "{{ .Values.global.apiToken }}"
+  sessionSecretName: app-session
+  tokenTTL: 3600s
+  webhooks:
+    - url: https://hooks.internal/deploy
+      token: wh-7c1e0d9a
+`
+
+func TestHelmValues_Pattern(t *testing.T) {
+	orig := downstream
+	downstream = detectors.Downstream{}
+	t.Cleanup(func() { downstream = orig })
+
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "values.yaml",
+			input: valuesYAML,
+			want: []string{
+				"postgresql.auth.password=Pg-0rders!2024",
+				"app.jwtSecret=9f3b2c71e4d84a6c",
+				"app.webhooks[0].token=wh-7c1e0d9a",
+			},
+		},
+		{
+			name:  "invalid pattern - not a values file",
+			input: "nameOverride is documented below\ndatabase:\n  password: hunter22\n",
+		},
+		{
+			name:  "invalid pattern - references only",
+			input: "fullnameOverride: \"\"\nauth:\n  existingSecret: db-auth\n  passwordFile: /run/secrets/db\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.RawV2)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+// fakeDetector recognises a single value.
+type fakeDetector struct {
+	value string
+}
+
+func (f fakeDetector) FromData(_ context.Context, _ bool, data []byte) ([]detectors.Result, error) {
+	if string(data) != f.value {
+		return nil, nil
+	}
+	return []detectors.Result{{Raw: data}}, nil
+}
+func (f fakeDetector) Keywords() []string                 { return []string{"wh-"} }
+func (f fakeDetector) Type() detector_typepb.DetectorType { return detector_typepb.DetectorType_Slack }
+func (f fakeDetector) Description() string                { return "" }
+
+func TestHelmValues_Routing(t *testing.T) {
+	orig := downstream
+	downstream = detectors.Downstream{fakeDetector{value: "wh-7c1e0d9a"}}
+	t.Cleanup(func() { downstream = orig })
+
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(valuesYAML))
+	require.NoError(t, err)
+
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.ExtraData["key"])
+		assert.Equal(t, detectors.ConfidenceMedium, r.Confidence)
+	}
+	assert.Equal(t, []string{"app.jwtSecret", "postgresql.auth.password"}, keys)
+}
+
+func TestIsSecretKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"password":                  true,
+		"rootPassword":              true,
+		"client_secret":             true,
+		"apiKey":                    true,
+		"secretAccessKey":           true,
+		"existingSecret":            false,
+		"secretName":                false,
+		"passwordFile":              false,
+		"accessKeyId":               false,
+		"tokenTTL":                  false,
+		"existingSecretPasswordKey": false,
+		"adminPasswordKey":          false,
+		"username":                  false,
+	} {
+		assert.Equal(t, want, isSecretKey(key), key)
+	}
+}
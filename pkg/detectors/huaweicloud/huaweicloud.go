@@ -27,7 +27,9 @@ const HuaweicloudURL = "https://obs.cn-north-4.myhuaweicloud.com"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.KeywordPackProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -71,10 +73,21 @@ func (s Scanner) Keywords() []string {
 	}
 }
 
+// KeywordPacks implements detectors.KeywordPackProvider, 中文文档中的 AK/SK 常以 访问密钥 标注
+func (s Scanner) KeywordPacks() []string {
+	return []string{detectors.KeywordPackChinese}
+}
+
 func (s Scanner) Description() string {
 	return "Huawei cloud ak/sk"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud, detectors.TagChinaCloud} }
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
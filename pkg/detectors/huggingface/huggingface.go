@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
@@ -106,6 +108,10 @@ func (s Scanner) verifyResult(ctx context.Context, apiKey string) (bool, map[str
 			"Email":    whoamiRes.Email,
 			"Token":    tokenInfo,
 		}
+		// A token that can push to model repos is a supply-chain risk: it can replace published weights or code.
+		if writeAccess, ok := whoamiRes.Auth.AccessToken.hasRepoWriteAccess(); ok {
+			extraData["Write Access"] = strconv.FormatBool(writeAccess)
+		}
 
 		// Condense a list of organizations + roles.
 		orgs := make([]string, 0, len(whoamiRes.Organizations))
@@ -147,9 +153,37 @@ type organization struct {
 }
 
 type auth struct {
-	AccessToken struct {
-		DisplayName string `json:"displayName,omitempty"`
-		Role        string `json:"role,omitempty"`
-	} `json:"accessToken,omitempty"`
-	Type string `json:"type,omitempty"`
+	AccessToken accessToken `json:"accessToken,omitempty"`
+	Type        string      `json:"type,omitempty"`
+}
+
+type accessToken struct {
+	DisplayName string `json:"displayName,omitempty"`
+	// Role is "read", "write" or "fineGrained".
+	Role        string `json:"role,omitempty"`
+	FineGrained struct {
+		Global []string `json:"global"`
+		Scoped []struct {
+			Permissions []string `json:"permissions"`
+		} `json:"scoped"`
+	} `json:"fineGrained,omitempty"`
+}
+
+// hasRepoWriteAccess reports whether the token can write to repositories. The second value is false if the token
+// role is unknown, e.g. for api_org tokens.
+func (t accessToken) hasRepoWriteAccess() (bool, bool) {
+	switch t.Role {
+	case "read":
+		return false, true
+	case "write":
+		return true, true
+	case "fineGrained":
+		permissions := slices.Clone(t.FineGrained.Global)
+		for _, scoped := range t.FineGrained.Scoped {
+			permissions = append(permissions, scoped.Permissions...)
+		}
+		return slices.Contains(permissions, "repo.write"), true
+	default:
+		return false, false
+	}
 }
@@ -53,9 +53,10 @@ func TestHuggingface_FromChunk(t *testing.T) {
 					DetectorType: detector_typepb.DetectorType_HuggingFace,
 					Verified:     true,
 					ExtraData: map[string]string{
-						"Email":    "zubair.khan@trufflesec.com",
-						"Token":    "another_one (read)",
-						"Username": "zubairkhan",
+						"Email":        "zubair.khan@trufflesec.com",
+						"Token":        "another_one (read)",
+						"Write Access": "false",
+						"Username":     "zubairkhan",
 					},
 				},
 			},
@@ -165,9 +166,10 @@ func TestHuggingface_FromChunk(t *testing.T) {
 					DetectorType: detector_typepb.DetectorType_HuggingFace,
 					Verified:     true,
 					ExtraData: map[string]string{
-						"Email":    "zubair.khan@trufflesec.com",
-						"Token":    "another_one (read)",
-						"Username": "zubairkhan",
+						"Email":        "zubair.khan@trufflesec.com",
+						"Token":        "another_one (read)",
+						"Write Access": "false",
+						"Username":     "zubairkhan",
 					},
 				},
 			},
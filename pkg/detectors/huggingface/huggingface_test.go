@@ -2,6 +2,7 @@ package huggingface
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -86,3 +87,41 @@ func TestHuggingFace_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestHuggingface_WriteAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		want      bool
+		wantKnown bool
+	}{
+		{name: "read", token: `{"role": "read"}`, want: false, wantKnown: true},
+		{name: "write", token: `{"role": "write"}`, want: true, wantKnown: true},
+		{
+			name:      "fine-grained with repo write",
+			token:     `{"role": "fineGrained", "fineGrained": {"global": ["discussion.write"], "scoped": [{"permissions": ["repo.content.read", "repo.write"]}]}}`,
+			want:      true,
+			wantKnown: true,
+		},
+		{
+			name:      "fine-grained read only",
+			token:     `{"role": "fineGrained", "fineGrained": {"global": ["inference.serverless.write"], "scoped": [{"permissions": ["repo.content.read"]}]}}`,
+			want:      false,
+			wantKnown: true,
+		},
+		{name: "unknown role", token: `{}`, want: false, wantKnown: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var token accessToken
+			if err := json.Unmarshal([]byte(test.token), &token); err != nil {
+				t.Fatal(err)
+			}
+			got, known := token.hasRepoWriteAccess()
+			if got != test.want || known != test.wantKnown {
+				t.Errorf("hasRepoWriteAccess() = (%v, %v), want (%v, %v)", got, known, test.want, test.wantKnown)
+			}
+		})
+	}
+}
@@ -37,7 +37,9 @@ const HunYuanURL = "https://api.hunyuan.cloud.tencent.com/v1/chat/completions"
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
-	_ detectors.Detector = (*Scanner)(nil)
+	_ detectors.Detector            = (*Scanner)(nil)
+	_ detectors.MetadataProvider    = (*Scanner)(nil)
+	_ detectors.ContextHintProvider = (*Scanner)(nil)
 
 	defaultClient = common.SaneHttpClient()
 
@@ -50,10 +52,21 @@ func (s Scanner) Keywords() []string {
 	return []string{"sk-", "hunyuan", "HUNYUAN_API_KEY"}
 }
 
+// ContextHints implements detectors.ContextHintProvider, sk- 加 48 位字母数字的格式和 Moonshot 相同
+func (s Scanner) ContextHints() []string {
+	return []string{"hunyuan", "api.hunyuan.cloud.tencent.com", "混元"}
+}
+
 func (s Scanner) Description() string {
 	return "hunyuan sk token"
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagAI, detectors.TagChinaCloud} }
+
 func (s Scanner) getClient() *http.Client {
 	if s.client != nil {
 		return s.client
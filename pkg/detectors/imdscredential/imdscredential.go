@@ -0,0 +1,298 @@
+package imdscredential
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/aws"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudsts"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var defaultClient = common.SaneHttpClient()
+
+// provider 描述一种云厂商实例元数据服务返回的角色临时凭证 JSON
+type provider struct {
+	name string
+	// endpoint 是返回该 JSON 的元数据服务地址, 帮助定位泄露来源
+	endpoint  string
+	idPat     *regexp.Regexp
+	secretPat *regexp.Regexp
+	tokenPat  *regexp.Regexp
+	// markerPat 区分元数据服务的响应和 STS API 的响应, 后者由 CloudSTS detector 处理
+	markerPat *regexp.Regexp
+}
+
+var (
+	successPat = regexp.MustCompile(`"Code"\s*:\s*"Success"`)
+
+	providers = []provider{
+		{
+			// AWS EC2: http://169.254.169.254/latest/meta-data/iam/security-credentials/<role>
+			// ECS 任务角色的响应 (169.254.170.2) 没有 Code 字段, 但同样使用 Token 而不是 SessionToken
+			name:      "aws",
+			endpoint:  "169.254.169.254",
+			idPat:     regexp.MustCompile(`"AccessKeyId"\s*:\s*"(ASIA[A-Z0-9]{16})"`),
+			secretPat: regexp.MustCompile(`"SecretAccessKey"\s*:\s*"([A-Za-z0-9+/]{40})"`),
+			tokenPat:  regexp.MustCompile(`"Token"\s*:\s*"([A-Za-z0-9+/=]{100,})"`),
+		},
+		{
+			// 阿里云 ECS: http://100.100.100.200/latest/meta-data/ram/security-credentials/<role>
+			name:      "aliyun",
+			endpoint:  "100.100.100.200",
+			idPat:     regexp.MustCompile(`"AccessKeyId"\s*:\s*"(STS\.[A-Za-z0-9]{16,40})"`),
+			secretPat: regexp.MustCompile(`"AccessKeySecret"\s*:\s*"([A-Za-z0-9]{30,50})"`),
+			tokenPat:  regexp.MustCompile(`"SecurityToken"\s*:\s*"(CAIS[A-Za-z0-9+/]{100,}={0,2})"`),
+			markerPat: successPat,
+		},
+		{
+			// 腾讯云 CVM: http://metadata.tencentyun.com/latest/meta-data/cam/security-credentials/<role>
+			name:      "tencent",
+			endpoint:  "metadata.tencentyun.com",
+			idPat:     regexp.MustCompile(`"TmpSecretId"\s*:\s*"(AKID[A-Za-z0-9_-]{32,100})"`),
+			secretPat: regexp.MustCompile(`"TmpSecretKey"\s*:\s*"([A-Za-z0-9+/=]{32,64})"`),
+			tokenPat:  regexp.MustCompile(`"Token"\s*:\s*"([A-Za-z0-9_\-+/=.]{100,})"`),
+			markerPat: successPat,
+		},
+	}
+
+	// 过期时间: Expiration (ISO8601), 腾讯云另有 ExpiredTime (Unix 时间戳)
+	expirationPat = regexp.MustCompile(`"(?:Expiration|ExpiredTime)"\s*:\s*"?(\d{10}|\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`)
+	roleArnPat    = regexp.MustCompile(`"RoleArn"\s*:\s*"(arn:aws[a-z-]*:iam::\d{12}:role/[^"]+)"`)
+	// 日志中通常还记录了请求的 URL, 其中包含角色名
+	rolePat = regexp.MustCompile(`meta-data/(?:iam|ram|cam)/security-credentials/([A-Za-z0-9_+=,.@-]+)`)
+
+	// 嵌在 JSON 日志字段中的响应, 引号和斜杠被转义
+	unescaper = strings.NewReplacer(`\"`, `"`, `\/`, `/`)
+)
+
+const (
+	stsURL = "https://sts.amazonaws.com/"
+
+	// 元数据服务的响应是一个扁平的 JSON 对象, 在这个范围内寻找包含 AccessKeyId 的对象边界
+	maxObjectPrefix = 1024
+	maxObjectSuffix = 8192
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"AccessKeyId", "TmpSecretId"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify role credentials dumped from a cloud instance metadata service in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := unescaper.Replace(string(data))
+
+	var role string
+	if m := rolePat.FindStringSubmatch(dataStr); m != nil {
+		role = m[1]
+	}
+
+	seen := make(map[string]struct{})
+	for _, p := range providers {
+		for _, loc := range p.idPat.FindAllStringSubmatchIndex(dataStr, -1) {
+			id := dataStr[loc[2]:loc[3]]
+			object := enclosingObject(dataStr, loc[0], loc[1])
+			if p.markerPat != nil && !p.markerPat.MatchString(object) {
+				continue
+			}
+			secret := p.secretPat.FindStringSubmatch(object)
+			token := p.tokenPat.FindStringSubmatch(object)
+			if secret == nil || token == nil {
+				continue
+			}
+			if _, ok := seen[id+":"+secret[1]]; ok {
+				continue
+			}
+			seen[id+":"+secret[1]] = struct{}{}
+
+			s1 := detectors.Result{
+				DetectorType: detector_typepb.DetectorType_IMDSCredential,
+				Raw:          []byte(id),
+				RawV2:        []byte(id + ":" + secret[1]),
+				Redacted:     id,
+				ExtraData: map[string]string{
+					"provider":          p.name,
+					"metadata_endpoint": p.endpoint,
+				},
+			}
+			if m := roleArnPat.FindStringSubmatch(object); m != nil {
+				s1.ExtraData["role_arn"] = m[1]
+				s1.ExtraData["metadata_endpoint"] = "169.254.170.2"
+			}
+			if role != "" {
+				s1.ExtraData["role"] = role
+			}
+
+			var expiresAt time.Time
+			if m := expirationPat.FindStringSubmatch(object); m != nil {
+				expiresAt, _ = cloudsts.ParseExpiration(m[1])
+			}
+			if !expiresAt.IsZero() {
+				s1.SetExpiresAt(expiresAt)
+				if detectors.Now().After(expiresAt) {
+					s1.ExtraData["status"] = "expired"
+				}
+			}
+
+			// 元数据服务签发的凭证通常只有几小时有效期, 已经过期的无需再请求
+			if verify && (expiresAt.IsZero() || time.Now().Before(expiresAt)) {
+				var isVerified bool
+				var extraData map[string]string
+				var verificationErr error
+				if p.name == "aws" {
+					isVerified, extraData, verificationErr = verifyAWS(ctx, s.getClient(), stsURL, id, secret[1], token[1])
+				} else {
+					isVerified, extraData, verificationErr = cloudsts.VerifyCredential(ctx, s.getClient(), p.name, id, secret[1], token[1])
+				}
+				s1.Verified = isVerified
+				for k, v := range extraData {
+					s1.ExtraData[k] = v
+				}
+				if isVerified && !expiresAt.IsZero() {
+					s1.ExtraData["remaining"] = time.Until(expiresAt).Truncate(time.Second).String()
+				}
+				s1.SetVerificationError(verificationErr, secret[1], token[1])
+			}
+
+			results = append(results, s1)
+		}
+	}
+
+	return results, nil
+}
+
+// enclosingObject 返回 [start, end) 所在的 JSON 对象, 凭证字段的值中不会出现花括号
+func enclosingObject(data string, start, end int) string {
+	from := max(start-maxObjectPrefix, 0)
+	if i := strings.LastIndexByte(data[from:start], '{'); i >= 0 {
+		from += i
+	}
+	to := min(end+maxObjectSuffix, len(data))
+	if i := strings.IndexByte(data[end:to], '}'); i >= 0 {
+		to = end + i + 1
+	}
+	return data[from:to]
+}
+
+// verifyAWS 使用临时凭证调用 STS GetCallerIdentity, 该接口不需要任何权限
+func verifyAWS(ctx context.Context, client *http.Client, endpoint, id, secret, token string) (bool, map[string]string, error) {
+	const (
+		region  = "us-east-1"
+		service = "sts"
+	)
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false, nil, err
+	}
+	now := time.Now().UTC()
+	datestamp := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+
+	params := url.Values{}
+	params.Add("Action", "GetCallerIdentity")
+	params.Add("Version", "2011-06-15")
+	u.RawQuery = params.Encode()
+
+	// SigV4: https://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
+	canonicalHeaders := "host:" + u.Host + "\n" + "x-amz-date:" + amzDate + "\n" + "x-amz-security-token:" + token + "\n"
+	signedHeaders := "host;x-amz-date;x-amz-security-token"
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", datestamp, region, service)
+	canonicalRequest := http.MethodGet + "\n/\n" + u.RawQuery + "\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + aws.GetHash("")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + credentialScope + "\n" + aws.GetHash(canonicalRequest)
+
+	hash := aws.GetHMAC([]byte("AWS4"+secret), []byte(datestamp))
+	hash = aws.GetHMAC(hash, []byte(region))
+	hash = aws.GetHMAC(hash, []byte(service))
+	hash = aws.GetHMAC(hash, []byte("aws4_request"))
+	signature := hex.EncodeToString(aws.GetHMAC(hash, []byte(stringToSign)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", id, credentialScope, signedHeaders, signature))
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Security-Token", token)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var identity aws.IdentityResponse
+		if err := json.NewDecoder(res.Body).Decode(&identity); err != nil {
+			return false, nil, err
+		}
+		result := identity.GetCallerIdentityResponse.GetCallerIdentityResult
+		return true, map[string]string{
+			"status":         "valid",
+			"account":        result.Account,
+			"arn":            result.Arn,
+			"user_id":        result.UserID,
+			"rotation_guide": "https://howtorotate.com/docs/tutorials/aws/",
+		}, nil
+	case http.StatusForbidden:
+		var body aws.ErrorResponseBody
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return false, nil, fmt.Errorf("couldn't parse the sts response body (%v)", err)
+		}
+		switch {
+		case strings.EqualFold(body.Error.Code, "ExpiredToken"):
+			return false, map[string]string{"status": "expired"}, nil
+		case strings.EqualFold(body.Error.Code, "InvalidClientTokenId"), strings.EqualFold(body.Error.Code, "SignatureDoesNotMatch"):
+			return false, map[string]string{"status": "invalid"}, nil
+		}
+		return false, nil, fmt.Errorf("unexpected error code %s", body.Error.Code)
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_IMDSCredential
+}
+
+func (s Scanner) Description() string {
+	return "Temporary role credentials returned by the instance metadata service of AWS, Aliyun or Tencent Cloud, pasted into logs or tickets. Anyone holding them can act as the instance's role until they expire."
+}
@@ -136,6 +136,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			Raw:          []byte(match),
 			ExtraData:    extraData,
 		}
+		if exp != nil {
+			s1.SetExpiresAt(exp.Time)
+		}
 
 		if verify {
 			isVerified, verificationErr := verifyJWT(ctx, client, tokenParts, parsedToken)
@@ -0,0 +1,136 @@
+package detectors
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// ExtraData keys under which detectors of encrypted material list, comma-separated, the cloud KMS keys that wrap
+// its data key.
+const (
+	AWSKMSKeysKey = "aws_kms_keys"
+	GCPKMSKeysKey = "gcp_kms_keys"
+)
+
+var (
+	awsKMSArnPat = regexp.MustCompile(`^arn:aws[\w-]*:kms:[\w-]+:(\d{12}):`)
+	gcpKMSKeyPat = regexp.MustCompile(`^projects/([\w.:-]+)/locations/`)
+)
+
+// defaultCorrelatedScopes is the number of account scopes remembered for each side of a pair.
+const defaultCorrelatedScopes = 10000
+
+// KeyCorrelator links encrypted material whose data key is wrapped by a cloud KMS key to credentials for the same
+// cloud account found elsewhere in the scan. Either finding alone is contained; together, whoever holds the
+// credentials can likely call the KMS to unwrap the data key and decrypt the material. Whichever of the two is
+// reported second is tagged and raised to critical. It is safe for concurrent use.
+type KeyCorrelator struct {
+	mu sync.Mutex
+	// credentials and encrypted map an account scope, e.g. "aws:123456789012", to a description of the finding.
+	credentials *lru.Cache[string, string]
+	encrypted   *lru.Cache[string, string]
+}
+
+// NewKeyCorrelator creates a KeyCorrelator that remembers the most recently seen account scopes.
+func NewKeyCorrelator() *KeyCorrelator {
+	credentials, _ := lru.New[string, string](defaultCorrelatedScopes)
+	encrypted, _ := lru.New[string, string](defaultCorrelatedScopes)
+	return &KeyCorrelator{credentials: credentials, encrypted: encrypted}
+}
+
+// Tag records the result and, if it completes a pair with an earlier finding, marks it and raises its severity to
+// critical. It returns whether the result was tagged. A nil *KeyCorrelator tags nothing.
+func (c *KeyCorrelator) Tag(res *Result) bool {
+	if c == nil {
+		return false
+	}
+	held := credentialScopes(res)
+	wrapped := wrappingScopes(res)
+	if len(held) == 0 && len(wrapped) == 0 {
+		return false
+	}
+	self := describeResult(res)
+
+	c.mu.Lock()
+	var unlocks, unlockedBy []string
+	for _, scope := range held {
+		if other, ok := c.encrypted.Get(scope); ok {
+			unlocks = append(unlocks, other)
+		}
+		c.credentials.Add(scope, self)
+	}
+	for _, scope := range wrapped {
+		if other, ok := c.credentials.Get(scope); ok {
+			unlockedBy = append(unlockedBy, other)
+		}
+		c.encrypted.Add(scope, self)
+	}
+	c.mu.Unlock()
+
+	if len(unlocks) == 0 && len(unlockedBy) == 0 {
+		return false
+	}
+	res.CloneExtraData()
+	if len(unlocks) > 0 {
+		res.ExtraData["correlated_encrypted_material"] = strings.Join(unlocks, "; ")
+	}
+	if len(unlockedBy) > 0 {
+		res.ExtraData["correlated_credential"] = strings.Join(unlockedBy, "; ")
+	}
+	res.Severity = SeverityCritical
+	return true
+}
+
+// credentialScopes returns the cloud accounts a credential result gives access to.
+func credentialScopes(res *Result) []string {
+	switch res.DetectorType {
+	case detector_typepb.DetectorType_AWS:
+		if account := res.ExtraData["account"]; account != "" {
+			return []string{"aws:" + account}
+		}
+	case detector_typepb.DetectorType_GCP:
+		if project := res.ExtraData["project"]; project != "" {
+			return []string{"gcp:" + project}
+		}
+	}
+	return nil
+}
+
+// wrappingScopes returns the cloud accounts of the KMS keys listed on an encrypted material result.
+func wrappingScopes(res *Result) []string {
+	var scopes []string
+	for _, key := range splitList(res.ExtraData[AWSKMSKeysKey]) {
+		if m := awsKMSArnPat.FindStringSubmatch(key); m != nil {
+			scopes = append(scopes, "aws:"+m[1])
+		}
+	}
+	for _, key := range splitList(res.ExtraData[GCPKMSKeysKey]) {
+		if m := gcpKMSKeyPat.FindStringSubmatch(key); m != nil {
+			scopes = append(scopes, "gcp:"+m[1])
+		}
+	}
+	return scopes
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func describeResult(res *Result) string {
+	name, ok := detector_typepb.DetectorType_name[int32(res.DetectorType)]
+	if !ok {
+		name = res.DetectorType.String()
+	}
+	if res.Redacted != "" {
+		return name + " " + res.Redacted
+	}
+	return name
+}
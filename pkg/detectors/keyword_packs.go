@@ -0,0 +1,37 @@
+package detectors
+
+// KeywordPackChinese is the name of the keyword pack with the Chinese words that label secrets in configs and
+// documents.
+const KeywordPackChinese = "zh"
+
+// ChineseSecretLabels are the Chinese words for secret (密钥, 秘钥), private key (私钥), access key (访问密钥),
+// password (口令) and token (令牌).
+var ChineseSecretLabels = []string{"访问密钥", "密钥", "私钥", "秘钥", "口令", "令牌"}
+
+const (
+	// ChineseKeyLabelPattern matches the ChineseSecretLabels for keys, leaving out passwords and tokens. It is meant to
+	// be used as an alternative to the English field names in context regexes.
+	ChineseKeyLabelPattern = `(?:访问密钥|密钥|私钥|秘钥)`
+	// FullWidthSeparators are the full-width colon, equals sign, quotes, corner brackets and ideographic space used
+	// around values in Chinese documents, e.g. 私钥：“…”. They are meant to be added to the separator character classes
+	// of context regexes.
+	FullWidthSeparators = `：＝“”‘’「」\x{3000}`
+)
+
+// keywordPacks maps the names of keyword packs to their keywords.
+var keywordPacks = map[string][]string{
+	KeywordPackChinese: ChineseSecretLabels,
+}
+
+// KeywordPackProvider is an optional interface that a detector can implement if its context regexes understand the
+// labels of one or more keyword packs. Pack keywords are far more common than provider names, so they are only added
+// to the detector's keywords when the pack is enabled.
+type KeywordPackProvider interface {
+	KeywordPacks() []string
+}
+
+// KeywordPack returns the keywords of the named keyword pack.
+func KeywordPack(name string) ([]string, bool) {
+	keywords, ok := keywordPacks[name]
+	return keywords, ok
+}
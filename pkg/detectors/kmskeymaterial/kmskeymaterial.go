@@ -0,0 +1,268 @@
+package kmskeymaterial
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const (
+	// 明文数据密钥或导入令牌与 KeyId 等上下文字段的最大距离, 足以覆盖一次 API 响应
+	contextWindow = 2048
+	// AWS 的 ImportToken 约 1.5KB, 加上公钥和请求参数
+	maxSecretSize = 16 * 1024
+
+	kindDataKey      = "plaintext_data_key"
+	kindImportToken  = "import_token"
+	kindWrappedKey   = "wrapped_key_material"
+	providerAWS      = "aws"
+	providerAliyun   = "aliyun"
+	providerGCP      = "gcp"
+	encryptedKeyMark = "EncryptedKeyMaterial"
+)
+
+// provider 描述一家云厂商 KMS API 响应的特征
+type provider struct {
+	name string
+	// keyPat 匹配上下文中的密钥标识, 第一个子组为密钥 ID
+	keyPat *regexp.Regexp
+	// markerPat 匹配该厂商特有的响应字段, 用于区分 KeyId 格式相同的厂商
+	markerPat *regexp.Regexp
+}
+
+var (
+	// 按顺序匹配: 阿里云和 AWS 的 KeyId 都可能是 UUID, 阿里云的响应带 KeyVersionId 或 TokenExpireTime
+	providers = []provider{
+		{
+			name:      providerAliyun,
+			keyPat:    regexp.MustCompile(`(acs:kms:[\w-]+:\d+:key/[\w-]+)|["']?KeyId["']?\s*[:=]\s*["']?((?:alias/)?[\w-]{8,})`),
+			markerPat: regexp.MustCompile(`KeyVersionId|TokenExpireTime|acs:kms:|kms\.[\w-]+\.aliyuncs\.com`),
+		},
+		{
+			name:   providerAWS,
+			keyPat: regexp.MustCompile(`(arn:aws[\w-]*:kms:[\w-]+:\d{12}:(?:key|alias)/[\w/-]+)`),
+		},
+		{
+			name:   providerGCP,
+			keyPat: regexp.MustCompile(`(projects/[\w.:-]+/locations/[\w-]+/(?:keyRings/[\w-]+/(?:cryptoKeys/[\w-]+(?:/cryptoKeyVersions/\d+)?|importJobs/[\w-]+)))`),
+		},
+	}
+
+	// GenerateDataKey/Decrypt 响应中的明文数据密钥, 出现在 SDK 调试日志, CLI 输出或结构体打印中
+	plaintextPat = regexp.MustCompile(`(?i)["']?\bplaintext["']?\s*[:=]\s*["']?([A-Za-z0-9+/]{22,88}={0,2})(?:[^A-Za-z0-9+/=]|$)`)
+	// boto3 的调试日志把 bytes 打印为 Python 字面量: 'Plaintext': b'\x8f\x02...'
+	plaintextBytesPat = regexp.MustCompile(`["']Plaintext["']\s*:\s*b'((?:\\.|[^\\'\n]){16,512})'`)
+
+	// GetParametersForImport 的导入令牌 (AWS, 阿里云), 与包装公钥一起用于导入 BYOK 密钥材料
+	importTokenPat = regexp.MustCompile(`["']?ImportToken["']?\s*[:=]\s*["']?([A-Za-z0-9+/]{200,}={0,2})`)
+	// GCP 导入作业的包装密钥材料 (ImportCryptoKeyVersion 请求体)
+	wrappedKeyPat = regexp.MustCompile(`["']?(?:wrappedKey|wrapped_key|rsaAesWrappedKey|rsa_aes_wrapped_key)["']?\s*[:=]\s*["']?([A-Za-z0-9+/_-]{200,}={0,2})`)
+)
+
+// dataKeySizes 是对称数据密钥的合法长度 (字节)
+var dataKeySizes = map[int]bool{16: true, 24: true, 32: true, 64: true}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"plaintext", "importtoken", "wrappedkey", "wrapped_key"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxSecretSize }
+
+// FromData will find plaintext KMS data keys and exported key material in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	seen := make(map[string]struct{})
+	add := func(res detectors.Result) {
+		if _, ok := seen[string(res.Raw)]; ok {
+			return
+		}
+		seen[string(res.Raw)] = struct{}{}
+		results = append(results, res)
+	}
+
+	for _, idx := range plaintextPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		encoded := dataStr[idx[2]:idx[3]]
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || !isDataKey(key) {
+			continue
+		}
+		if res, ok := newResult(dataStr, idx[0], kindDataKey, encoded); ok {
+			res.ExtraData["key_bits"] = strconv.Itoa(len(key) * 8)
+			add(res)
+		}
+	}
+	for _, idx := range plaintextBytesPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		key, ok := decodePythonBytes(dataStr[idx[2]:idx[3]])
+		if !ok || !isDataKey(key) {
+			continue
+		}
+		// 统一以 base64 报告, 与其他 SDK 的输出去重
+		if res, ok := newResult(dataStr, idx[0], kindDataKey, base64.StdEncoding.EncodeToString(key)); ok {
+			res.ExtraData["key_bits"] = strconv.Itoa(len(key) * 8)
+			add(res)
+		}
+	}
+
+	for _, idx := range importTokenPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		res, ok := newResult(dataStr, idx[0], kindImportToken, dataStr[idx[2]:idx[3]])
+		if !ok {
+			continue
+		}
+		// 与导入令牌一起出现的加密密钥材料说明明文材料曾在 KMS 之外生成和处理
+		if strings.Contains(window(dataStr, idx[0]), encryptedKeyMark) {
+			res.ExtraData["encrypted_key_material"] = "true"
+			res.Severity = detectors.SeverityHigh
+		}
+		add(res)
+	}
+
+	for _, idx := range wrappedKeyPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		res, ok := newResult(dataStr, idx[0], kindWrappedKey, dataStr[idx[2]:idx[3]])
+		if !ok || res.ExtraData["provider"] != providerGCP {
+			continue
+		}
+		add(res)
+	}
+
+	return results, nil
+}
+
+// newResult 根据匹配位置附近的上下文识别云厂商和密钥 ID, 无法识别时不报告
+func newResult(dataStr string, pos int, kind, raw string) (detectors.Result, bool) {
+	ctx := window(dataStr, pos)
+	for _, p := range providers {
+		if p.markerPat != nil && !p.markerPat.MatchString(ctx) {
+			continue
+		}
+		keyID := ""
+		if m := p.keyPat.FindStringSubmatch(ctx); m != nil {
+			keyID = firstGroup(m)
+		} else if p.markerPat == nil {
+			// 没有厂商特有字段时, 密钥标识是唯一的判断依据
+			continue
+		}
+
+		extraData := map[string]string{"provider": p.name, "kind": kind}
+		redacted := p.name + " " + strings.ReplaceAll(kind, "_", " ")
+		if keyID != "" {
+			extraData["key_id"] = keyID
+			redacted += " " + keyID
+			// 供 KeyCorrelator 关联同一账号下泄露的云凭证
+			switch {
+			case strings.HasPrefix(keyID, "arn:aws"):
+				extraData[detectors.AWSKMSKeysKey] = keyID
+			case p.name == providerGCP && strings.Contains(keyID, "/cryptoKeys/"):
+				extraData[detectors.GCPKMSKeysKey] = keyID
+			}
+		}
+
+		severity := detectors.SeverityMedium
+		if kind == kindDataKey {
+			// 明文数据密钥可以直接解密它保护的所有数据, 不需要访问 KMS
+			severity = detectors.SeverityCritical
+		}
+		return detectors.Result{
+			DetectorType: detector_typepb.DetectorType_KMSKeyMaterial,
+			Raw:          []byte(raw),
+			Redacted:     redacted,
+			Severity:     severity,
+			ExtraData:    extraData,
+		}, true
+	}
+	return detectors.Result{}, false
+}
+
+// window 返回匹配位置前后 contextWindow 字节的内容
+func window(dataStr string, pos int) string {
+	return dataStr[max(0, pos-contextWindow):min(len(dataStr), pos+contextWindow)]
+}
+
+func firstGroup(m []string) string {
+	for _, g := range m[1:] {
+		if g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+// isDataKey 排除解码后是可打印文本的普通 plaintext 字段
+func isDataKey(key []byte) bool {
+	if !dataKeySizes[len(key)] {
+		return false
+	}
+	for _, b := range key {
+		if b < 0x20 || b > 0x7e {
+			return true
+		}
+	}
+	return false
+}
+
+// decodePythonBytes 解码 Python bytes 字面量的内容
+func decodePythonBytes(s string) ([]byte, bool) {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			out = append(out, s[i])
+			continue
+		}
+		if i+1 >= len(s) {
+			return nil, false
+		}
+		i++
+		switch s[i] {
+		case 'x':
+			if i+2 >= len(s) {
+				return nil, false
+			}
+			v, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, false
+			}
+			out = append(out, byte(v))
+			i += 2
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case '\\', '\'', '"':
+			out = append(out, s[i])
+		default:
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_KMSKeyMaterial
+}
+
+func (s Scanner) Description() string {
+	return "Key material handled outside a cloud KMS: plaintext data keys from GenerateDataKey or Decrypt responses in SDK debug output, and the import tokens and wrapped key material used to bring your own key into AWS, Alibaba Cloud or Google Cloud KMS. A plaintext data key decrypts everything it protects without any access to the KMS."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityCritical }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud} }
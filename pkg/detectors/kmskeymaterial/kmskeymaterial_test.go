@@ -0,0 +1,186 @@
+package kmskeymaterial
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	awsKeyArn   = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	dataKey256  = "iniTMtBaPbnq4gyuOOl4X+vRzs/qmrwLOBXkwJD3xNA="
+	dataKey128  = "1Llgvdsr52TXwtkZ30HSmA=="
+	blob        = "IcaSDzcpS7T4bEdK+pGm/K0XUb9G5PmYrCFcP2b93TftPUj6yYdScBgGkeVmj4h0JP7mYEMfmPutsMVNckX4LZbImYPcdcmDsHiziVDBIVYbIabdRt4Mqg5VjS51p5bjUIIU01X0eEVGEt1AAeXm02b/3eu8QMKz"
+	importToken = "hyE4YUs60g4fr9zAVxyjzWgbmlJKgWCKnNjL1ZgyPQQL0lmCBXaUnMbwJWQgyx3m8e9cgxfYOgm/i3IPMHh8lUGUe/uOYIz2DBtvsQd6HLH6KyJ3kZAcSCNtZcsS/a+nGvWQiAq139u+0iyjjpIHOMGAqrrInpIVFxOTop7q2KMzizyemhF1RyfyA41+A9ZLsXd8pkAZG7GKV85lRF0qx3J3x7g0OucZuqHByiYFCDfyehkFPO+xE/cuZjLSj25Xl1x/Jzrrn55bvSmCwGWWTC2ELFW28zglJdVV4Rbms3G3X2za293teCi+sxbu37zrVto/4gYx03cynb3yzKtExm+6SrMdY3P7ilSAU1xcnkqYm6aLrQLnO+qlZVymw0CCt96U5N8rl1axURrDz7ZEw2D8a4KzVecLvrQwjXehG1+iQQpI2r/UZcn1JZBvCLUTG7hwoxY/rgekZvnO/8QCig0eptwT6fdRqHPLR2AmPZGWu0FA9czVWqEnKKCsX+pzOmDDUFsza1IyBQ301gT7Nrqt1xAYrXwofoMnIR3oF3CJN504"
+	wrappedKey  = "N/IJAU9iheZoPaq08GBbYUklMxHMPlWh0jzF1or2yE9VF99gE941LEf/D6q73zAGxTD8P7IZUutcaz9IOWZ1kUZW8bygr43QUtqVd8ysDu5xAZDMiNg4BzxXLQ19FnLhEIuJ1fAC74F9+zBp7hDZiN4Ogyh3W3F9FS/HgyuVZ1Ze5ZPGtgtULpN9g36MTC/OLXsHpu23zJ48Aem9H0OxaloPZgJfD1ff3Rx9rJLQ+jjbwBNMJz8wifeuID6rm7/70EhMxNJlakYo9iYvZ6DytX63uYLc3LkdMQRcSZn8m0e060gKrYgJHB9SqvWEF17ZByCw9m/KxzMHgtseztjISJPgibYgayW0Z/FvJWK0e0157VW68NvmIcypBujEs7V15cSG4qCE6kNmV/DT"
+
+	awsGenerateDataKey = `$ aws kms generate-data-key --key-id alias/app --key-spec AES_256
+{
+    "CiphertextBlob": "` + blob + `",
+    "Plaintext": "` + dataKey256 + `",
+    "KeyId": "` + awsKeyArn + `"
+}`
+	boto3Debug = `2024-05-02 10:14:03,201 botocore.hooks [DEBUG] Event needs-retry.kms.GenerateDataKey: calling handler
+2024-05-02 10:14:03,202 app.crypto [DEBUG] data key response: {'CiphertextBlob': b'\x01\x02\x02\x00x', 'Plaintext': b'!+\xdb\xc1\x98\x97\x11\x11p\xaa\x93\x19\\+z\xaf\x06En\x85\xb1\xe2\xde\xfd4\xf2*\x8b1\x1e3\x86', 'KeyId': '` + awsKeyArn + `', 'ResponseMetadata': {'HTTPStatusCode': 200}}`
+	aliyunGenerateDataKey = `[DEBUG] kms response: {"KeyVersionId":"2ab1a983-7072-4bbc-a582-584b5bd8****","KeyId":"7906979c-8e06-46a2-be2d-68e3ccbc****","CiphertextBlob":"` + blob + `","Plaintext":"` + dataKey128 + `","RequestId":"086bf5bf-6b85-4b4b-9d03-1d8c7a1b2c3d"}`
+	gcpDecrypt            = `DEBUG:google.cloud.kms: request: name: "projects/acme-prod/locations/global/keyRings/app/cryptoKeys/dek"
+DEBUG:google.cloud.kms: response: plaintext: "` + dataKey256 + `"`
+
+	awsImportParams = `{
+    "KeyId": "` + awsKeyArn + `",
+    "ImportToken": "` + importToken + `",
+    "PublicKey": "MIIBojANBgkqhkiG9w0BAQEFAAOCAY8AMIIBigKCAYEAxmzQ",
+    "ParametersValidTo": "2024-05-03T10:14:03.201000+00:00"
+}`
+	aliyunImportKeyMaterial = `request := kms.CreateImportKeyMaterialRequest()
+request.KeyId = "7906979c-8e06-46a2-be2d-68e3ccbc****"
+// TokenExpireTime: 2024-05-03T10:14:03Z
+request.EncryptedKeyMaterial = "/gGCHS8f5sGOXO1h811t7WLIxaHYvEkv7WPGoi15PhYhTrOqbEBFZr3QCJd+Ynxe/HvH2qo47WB7Tf2p"
+request.ImportToken = "` + importToken + `"`
+	gcpImport = `{
+  "importJob": "projects/acme-prod/locations/global/keyRings/byok/importJobs/job-1",
+  "algorithm": "GOOGLE_SYMMETRIC_ENCRYPTION",
+  "wrappedKey": "` + wrappedKey + `"
+}`
+)
+
+func TestKMSKeyMaterial_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - aws cli generate-data-key",
+			input: awsGenerateDataKey,
+			want:  []string{"aws plaintext data key " + awsKeyArn},
+		},
+		{
+			name:  "valid pattern - boto3 debug output with bytes literal",
+			input: boto3Debug,
+			want:  []string{"aws plaintext data key " + awsKeyArn},
+		},
+		{
+			name:  "valid pattern - aliyun sdk debug output",
+			input: aliyunGenerateDataKey,
+			want:  []string{"aliyun plaintext data key 7906979c-8e06-46a2-be2d-68e3ccbc"},
+		},
+		{
+			name:  "valid pattern - gcp decrypt log",
+			input: gcpDecrypt,
+			want:  []string{"gcp plaintext data key projects/acme-prod/locations/global/keyRings/app/cryptoKeys/dek"},
+		},
+		{
+			name:  "valid pattern - aws get-parameters-for-import",
+			input: awsImportParams,
+			want:  []string{"aws import token " + awsKeyArn},
+		},
+		{
+			name:  "valid pattern - aliyun byok import",
+			input: aliyunImportKeyMaterial,
+			want:  []string{"aliyun import token 7906979c-8e06-46a2-be2d-68e3ccbc"},
+		},
+		{
+			name:  "valid pattern - gcp import job wrapped key",
+			input: gcpImport,
+			want:  []string{"gcp wrapped key material projects/acme-prod/locations/global/keyRings/byok/importJobs/job-1"},
+		},
+		{
+			name:  "invalid pattern - plaintext field holding text",
+			input: `{"KeyId": "` + awsKeyArn + `", "Plaintext": "bm90IGEgZGF0YSBrZXksIGp1c3QgcGxhaW4gdGV4dCE="}`,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - data key without kms context",
+			input: `{"plaintext": "` + dataKey256 + `"}`,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - wrapped key without import job",
+			input: `{"wrappedKey": "` + wrappedKey + `"}`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Redacted)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestKMSKeyMaterial_Results(t *testing.T) {
+	s := Scanner{}
+
+	results, err := s.FromData(context.Background(), false, []byte(awsGenerateDataKey))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, dataKey256, string(results[0].Raw))
+	assert.Equal(t, map[string]string{
+		"provider":              "aws",
+		"kind":                  "plaintext_data_key",
+		"key_id":                awsKeyArn,
+		"key_bits":              "256",
+		detectors.AWSKMSKeysKey: awsKeyArn,
+	}, results[0].ExtraData)
+	assert.Equal(t, detectors.SeverityCritical, results[0].Severity)
+
+	// The same key printed as a Python bytes literal is reported in base64.
+	results, err = s.FromData(context.Background(), false, []byte(boto3Debug))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "ISvbwZiXERFwqpMZXCt6rwZFboWx4t79NPIqizEeM4Y=", string(results[0].Raw))
+
+	results, err = s.FromData(context.Background(), false, []byte(awsImportParams))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, importToken, string(results[0].Raw))
+	assert.Equal(t, detectors.SeverityMedium, results[0].Severity)
+	assert.NotContains(t, results[0].ExtraData, "encrypted_key_material")
+
+	results, err = s.FromData(context.Background(), false, []byte(aliyunImportKeyMaterial))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "true", results[0].ExtraData["encrypted_key_material"])
+	assert.Equal(t, detectors.SeverityHigh, results[0].Severity)
+
+	results, err = s.FromData(context.Background(), false, []byte(gcpDecrypt))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "projects/acme-prod/locations/global/keyRings/app/cryptoKeys/dek", results[0].ExtraData[detectors.GCPKMSKeysKey])
+}
+
+func TestDecodePythonBytes(t *testing.T) {
+	got, ok := decodePythonBytes(`a\x00\\\'\n`)
+	require.True(t, ok)
+	assert.Equal(t, []byte("a\x00\\'\n"), got)
+
+	_, ok = decodePythonBytes(`\x0`)
+	assert.False(t, ok)
+	_, ok = decodePythonBytes(`\q`)
+	assert.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package llmconfigexamplekey
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/llmconfig"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// 只匹配 llmconfig handler 输出的 provider 块, 密钥本身由各厂商的 detector 识别和验证
+func (s Scanner) Keywords() []string {
+	return []string{strings.TrimSpace(llmconfig.HeaderPrefix)}
+}
+
+// FromData will find real-looking API keys in the provider blocks of example configs, like .env.example, in a given
+// set of bytes. Example files are meant to hold placeholders; a key that has the format of its provider's keys and
+// isn't a placeholder was most likely pasted in from a working config.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	seen := make(map[string]struct{})
+	for _, block := range llmconfig.ParseRendered(string(data)) {
+		if !block.Example {
+			continue
+		}
+		for _, f := range block.Fields {
+			if f.Role != llmconfig.RoleAPIKey || !llmconfig.LooksReal(block.Provider, f.Value) {
+				continue
+			}
+			if _, ok := seen[f.Value]; ok {
+				continue
+			}
+			seen[f.Value] = struct{}{}
+
+			label := block.Path + " " + f.Name
+			results = append(results, detectors.Result{
+				DetectorType: detector_typepb.DetectorType_LLMConfigExampleKey,
+				Raw:          []byte(f.Value),
+				RawV2:        []byte(label + "=" + f.Value),
+				Redacted:     label,
+				ExtraData: map[string]string{
+					"provider": block.Provider,
+					"block":    block.Path,
+					"variable": f.Name,
+				},
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+// 示例文件本来就是要提交和分发的, 其中的真实密钥已经泄露
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagAI} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_LLMConfigExampleKey
+}
+
+func (s Scanner) Description() string {
+	return "Real-looking LLM provider API keys, such as OpenAI, DashScope, Coze or Anthropic keys, in example configs like .env.example that are meant to hold placeholders. Example files are committed and copied into every deployment, so a key pasted into one is shared with everyone who can read the repository."
+}
@@ -0,0 +1,92 @@
+package llmconfigexamplekey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// 与 llmconfig handler 输出的格式相同
+const (
+	exampleBlock = `# llm config: providers.qwen
+# provider: dashscope
+# example file
+DASHSCOPE_API_KEY=sk-4f9a2c7e1b8d3065a9e2f7c41d6b0e53
+DASHSCOPE_MODEL=qwen-plus
+`
+	placeholderBlock = `# llm config: COZE
+# provider: coze
+# example file
+COZE_API_KEY=pat_xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+`
+	configBlock = `# llm config: DEEPSEEK
+# provider: deepseek
+DEEPSEEK_API_KEY=sk-8e3b1f6a9c2d4e705b1a3f8c6d9e2b47
+`
+)
+
+func TestLLMConfigExampleKey_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern",
+			input: exampleBlock,
+			want:  []string{"providers.qwen DASHSCOPE_API_KEY"},
+		},
+		{
+			name:  "invalid pattern - placeholder",
+			input: placeholderBlock,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - not an example file",
+			input: configBlock,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Redacted)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestLLMConfigExampleKey_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(exampleBlock))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "sk-4f9a2c7e1b8d3065a9e2f7c41d6b0e53", string(results[0].Raw))
+	assert.Equal(t, map[string]string{
+		"provider": "dashscope",
+		"block":    "providers.qwen",
+		"variable": "DASHSCOPE_API_KEY",
+	}, results[0].ExtraData)
+}
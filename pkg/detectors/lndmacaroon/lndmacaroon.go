@@ -0,0 +1,445 @@
+package lndmacaroon
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+var (
+	// 节点地址来自扫描内容, 不允许访问内网地址; LND 的 REST 接口使用自签名证书
+	defaultClient = detectors.NewDetectorHttpClient(
+		detectors.WithTransport(detectors.NewDetectorTransport(&http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		})),
+		detectors.WithNoLocalIP(),
+	)
+
+	// LND 生成的 macaroon 都是 v2 格式, location 固定为 "lnd":
+	// 02 01 03 'l' 'n' 'd' => 十六进制 0201036c6e64, base64 AgEDbG5k
+	hexPat    = regexp.MustCompile(`(?i)\b(0201036c6e64[0-9a-f]{100,})\b`)
+	base64Pat = regexp.MustCompile(`\b(AgEDbG5k[A-Za-z0-9+/_-]{60,}={0,2})`)
+
+	// lndconnect://host:port?cert=<base64url DER>&macaroon=<base64url>
+	lndconnectPat = regexp.MustCompile(`lndconnect://([a-zA-Z0-9.-]+(?::[0-9]{1,5})?)\?([^\s"'<>]+)`)
+	// 同一配置中的 REST 地址, 例如 LND_REST_URL=https://node.example.com:8080
+	nodeURLPat = regexp.MustCompile(`(?i)(?:lnd|rest)[\w.-]{0,20}?(?:url|host|endpoint|uri)["'\s:=]+["']?(https://[a-zA-Z0-9.-]+(?::[0-9]{1,5})?)`)
+)
+
+const (
+	// lndconnect URI 中的端口一般是 gRPC 端口, REST 接口默认使用 8080
+	grpcPort = "10009"
+	restPort = "8080"
+
+	// macaroon v2 的字段类型
+	fieldEOS        = 0
+	fieldLocation   = 1
+	fieldIdentifier = 2
+	fieldVerifierID = 4
+	fieldSignature  = 6
+
+	// LND macaroon identifier 的版本号, 之后是 protobuf 编码的 MacaroonId
+	lndIDVersion = 3
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"0201036c6e64", "AgEDbG5k", "lndconnect://"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify LND macaroons in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 同一配置中的节点地址, 用于验证
+	var nodeURLs []string
+	for _, match := range nodeURLPat.FindAllStringSubmatch(dataStr, -1) {
+		nodeURLs = append(nodeURLs, match[1])
+	}
+
+	// 用于去重
+	seen := make(map[string]struct{})
+	addResult := func(raw []byte, nodeURL, certificate string) {
+		m, err := parseMacaroon(raw)
+		if err != nil {
+			return
+		}
+		macaroonHex := hex.EncodeToString(raw)
+		if _, ok := seen[macaroonHex]; ok {
+			return
+		}
+		seen[macaroonHex] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_LNDMacaroon,
+			Raw:          []byte(macaroonHex),
+			Redacted:     macaroonHex[:16] + "...",
+			ExtraData: map[string]string{
+				"macaroon_type": m.kind(),
+				"permissions":   strings.Join(m.permissions, ","),
+			},
+		}
+		if len(m.caveats) > 0 {
+			s1.ExtraData["caveats"] = strings.Join(m.caveats, "; ")
+		}
+		if certificate != "" {
+			s1.ExtraData["tls_cert_present"] = "true"
+		}
+
+		candidates := nodeURLs
+		if nodeURL != "" {
+			s1.RawV2 = []byte(nodeURL + ":" + macaroonHex)
+			s1.ExtraData["node"] = nodeURL
+			candidates = append([]string{nodeURL}, nodeURLs...)
+		}
+
+		if verify && len(candidates) > 0 {
+			client := s.getClient()
+			var verificationErr error
+			for _, candidate := range candidates {
+				var extraData map[string]string
+				s1.Verified, extraData, verificationErr = verifyMacaroon(ctx, client, candidate, macaroonHex)
+				if s1.Verified {
+					for k, v := range extraData {
+						s1.ExtraData[k] = v
+					}
+					s1.ExtraData["node"] = candidate
+					break
+				}
+			}
+			s1.SetVerificationError(verificationErr, macaroonHex)
+		}
+
+		results = append(results, s1)
+	}
+
+	for _, match := range lndconnectPat.FindAllStringSubmatch(dataStr, -1) {
+		query, err := url.ParseQuery(match[2])
+		if err != nil {
+			continue
+		}
+		raw, err := decodeBase64(query.Get("macaroon"))
+		if err != nil {
+			continue
+		}
+		addResult(raw, restURL(match[1]), query.Get("cert"))
+	}
+
+	for _, match := range hexPat.FindAllStringSubmatch(dataStr, -1) {
+		raw, err := hex.DecodeString(match[1])
+		if err != nil {
+			continue
+		}
+		addResult(raw, "", "")
+	}
+
+	for _, match := range base64Pat.FindAllStringSubmatch(dataStr, -1) {
+		raw, err := decodeBase64(match[1])
+		if err != nil {
+			continue
+		}
+		addResult(raw, "", "")
+	}
+
+	return results, nil
+}
+
+// restURL 将 lndconnect 中的 host:port 转换为 REST 接口地址
+func restURL(hostPort string) string {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host, port = hostPort, grpcPort
+	}
+	if port == grpcPort {
+		port = restPort
+	}
+	return "https://" + net.JoinHostPort(host, port)
+}
+
+// decodeBase64 兼容标准和 URL 安全的 base64, 以及有无填充
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+type macaroon struct {
+	permissions []string
+	caveats     []string
+}
+
+// kind 根据权限粗略判断 macaroon 的类型
+func (m macaroon) kind() string {
+	switch {
+	case slices.Contains(m.permissions, "macaroon:generate"), slices.Contains(m.permissions, "onchain:write"):
+		return "admin"
+	case slices.ContainsFunc(m.permissions, func(p string) bool { return !strings.HasSuffix(p, ":read") }):
+		return "custom"
+	default:
+		return "readonly"
+	}
+}
+
+var errInvalidMacaroon = errors.New("invalid macaroon")
+
+// parseMacaroon 解析 v2 二进制格式的 macaroon, 返回 LND 的权限和 first-party caveats
+func parseMacaroon(raw []byte) (macaroon, error) {
+	if len(raw) == 0 || raw[0] != 2 {
+		return macaroon{}, errInvalidMacaroon
+	}
+	r := &fieldReader{data: raw[1:]}
+
+	// macaroon 头部: location, identifier
+	header, err := r.section()
+	if err != nil || len(header[fieldIdentifier]) == 0 {
+		return macaroon{}, errInvalidMacaroon
+	}
+	permissions, err := parseLNDIdentifier(header[fieldIdentifier])
+	if err != nil {
+		return macaroon{}, err
+	}
+
+	var m macaroon
+	m.permissions = permissions
+
+	// caveats, 以空的 section 结束
+	for {
+		caveat, err := r.section()
+		if err != nil {
+			return macaroon{}, err
+		}
+		if len(caveat) == 0 {
+			break
+		}
+		// 带 verifier ID 的是 third-party caveat, 不记录
+		if _, ok := caveat[fieldVerifierID]; !ok {
+			m.caveats = append(m.caveats, string(caveat[fieldIdentifier]))
+		}
+	}
+
+	typ, signature, err := r.field()
+	if err != nil || typ != fieldSignature || len(signature) != 32 {
+		return macaroon{}, errInvalidMacaroon
+	}
+	return m, nil
+}
+
+type fieldReader struct {
+	data []byte
+}
+
+// field 读取一个字段: 类型 (varint), 长度 (varint), 数据; EOS 没有长度和数据
+func (r *fieldReader) field() (int, []byte, error) {
+	typ, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		return 0, nil, errInvalidMacaroon
+	}
+	r.data = r.data[n:]
+	if typ == fieldEOS {
+		return fieldEOS, nil, nil
+	}
+
+	length, n := binary.Uvarint(r.data)
+	if n <= 0 || uint64(len(r.data)-n) < length {
+		return 0, nil, errInvalidMacaroon
+	}
+	value := r.data[n : n+int(length)]
+	r.data = r.data[n+int(length):]
+	return int(typ), value, nil
+}
+
+// section 读取字段直到 EOS
+func (r *fieldReader) section() (map[int][]byte, error) {
+	fields := make(map[int][]byte)
+	for {
+		typ, value, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+		if typ == fieldEOS {
+			return fields, nil
+		}
+		if typ != fieldLocation && typ != fieldIdentifier && typ != fieldVerifierID {
+			return nil, errInvalidMacaroon
+		}
+		fields[typ] = value
+	}
+}
+
+// parseLNDIdentifier 解析 MacaroonId { bytes nonce = 1; bytes storageId = 2; repeated Op ops = 3; },
+// 其中 Op { string entity = 1; repeated string actions = 2; }
+func parseLNDIdentifier(id []byte) ([]string, error) {
+	if len(id) == 0 || id[0] != lndIDVersion {
+		return nil, errInvalidMacaroon
+	}
+
+	var permissions []string
+	err := forEachField(id[1:], func(num protowire.Number, value []byte) error {
+		if num != 3 {
+			return nil
+		}
+		var entity string
+		var actions []string
+		err := forEachField(value, func(num protowire.Number, value []byte) error {
+			switch num {
+			case 1:
+				entity = string(value)
+			case 2:
+				actions = append(actions, string(value))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, action := range actions {
+			permissions = append(permissions, entity+":"+action)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) == 0 {
+		return nil, errInvalidMacaroon
+	}
+	return permissions, nil
+}
+
+// forEachField 遍历 protobuf 消息中 bytes 类型的字段
+func forEachField(b []byte, fn func(protowire.Number, []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return errInvalidMacaroon
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return errInvalidMacaroon
+			}
+			b = b[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return errInvalidMacaroon
+		}
+		b = b[n:]
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type getInfoResponse struct {
+	Alias          string `json:"alias"`
+	IdentityPubkey string `json:"identity_pubkey"`
+	NumActiveChans int    `json:"num_active_channels"`
+}
+
+// verifyMacaroon 调用 REST 接口 /v1/getinfo 验证 macaroon
+func verifyMacaroon(ctx context.Context, client *http.Client, nodeURL, macaroonHex string) (bool, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nodeURL+"/v1/getinfo", nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Grpc-Metadata-macaroon", macaroonHex)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return false, nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK:
+		var info getInfoResponse
+		if err := json.Unmarshal(body, &info); err != nil {
+			return false, nil, err
+		}
+		return true, map[string]string{
+			"alias":               info.Alias,
+			"identity_pubkey":     info.IdentityPubkey,
+			"num_active_channels": fmt.Sprintf("%d", info.NumActiveChans),
+		}, nil
+	case strings.Contains(string(body), "permission denied"):
+		// 签名校验通过, 只是没有 info:read 权限, 例如 invoice macaroon
+		return true, nil, nil
+	case strings.Contains(string(body), "verification failed"), strings.Contains(string(body), "cannot get macaroon"):
+		return false, nil, nil
+	case res.StatusCode == http.StatusUnauthorized, res.StatusCode == http.StatusForbidden:
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_LNDMacaroon
+}
+
+func (s Scanner) Description() string {
+	return "LND macaroons are bearer credentials for Lightning Network nodes. An admin macaroon together with the node address allows opening channels and sending all funds held by the node."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// macaroon 可以通过删除 root key 撤销, 不需要转移节点资金
+func (s Scanner) Remediation() string {
+	return "Revoke the macaroon by deleting its root key with `lncli deletemacaroonid <root key id>` (0 for the " +
+		"default admin, readonly and invoice macaroons, which lnd bakes again on restart) and rotate the node's TLS " +
+		"certificate if it leaked too. Check the node's payments and channels for activity you don't recognize."
+}
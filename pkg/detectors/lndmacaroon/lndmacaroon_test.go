@@ -0,0 +1,101 @@
+package lndmacaroon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	// admin 权限, 带 ipaddr caveat
+	testAdminHex = "0201036c6e64029701030a10000102030405060708090a0b0c0d0e0f1201301a160a0761646472657373120472656164120577726974651a130a04696e666f120472656164120577726974651a210a086d616361726f6f6e120867656e6572617465120472656164120577726974651a170a086f6666636861696e120472656164120577726974651a160a076f6e636861696e12047265616412057772697465000212697061646472203230332e302e3131332e37000006206465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f80818283"
+	// info:read 和 offchain:read 权限
+	testReadonlyHex       = "0201036c6e640236030a10000102030405060708090a0b0c0d0e0f1201301a0c0a04696e666f1204726561641a100a086f6666636861696e120472656164000006206465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f80818283"
+	testReadonlyBase64    = "AgEDbG5kAjYDChAAAQIDBAUGBwgJCgsMDQ4PEgEwGgwKBGluZm8SBHJlYWQaEAoIb2ZmY2hhaW4SBHJlYWQAAAYgZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXp7fH1+f4CBgoM="
+	testReadonlyBase64URL = "AgEDbG5kAjYDChAAAQIDBAUGBwgJCgsMDQ4PEgEwGgwKBGluZm8SBHJlYWQaEAoIb2ZmY2hhaW4SBHJlYWQAAAYgZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXp7fH1-f4CBgoM"
+)
+
+func TestLNDMacaroon_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "valid hex admin macaroon",
+			input: `
+				LND_REST_URL=https://node.example.com:8080
+				LND_MACAROON=` + testAdminHex + `
+			`,
+			want: []string{testAdminHex},
+		},
+		{
+			name:  "valid base64 macaroon",
+			input: `macaroon: "` + testReadonlyBase64 + `"`,
+			want:  []string{testReadonlyHex},
+		},
+		{
+			name:  "valid lndconnect uri",
+			input: `lndconnect://node.example.com:10009?cert=MIICJzCCAc2gAwIBAgIRAK&macaroon=` + testReadonlyBase64URL,
+			want:  []string{"https://node.example.com:8080:" + testReadonlyHex},
+		},
+		{
+			name:  "invalid macaroon - truncated",
+			input: `macaroon: ` + testAdminHex[:150],
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestLNDMacaroon_Permissions(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(testAdminHex))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "admin", results[0].ExtraData["macaroon_type"])
+	require.Equal(t, "ipaddr 203.0.113.7", results[0].ExtraData["caveats"])
+	require.Contains(t, results[0].ExtraData["permissions"], "onchain:write")
+}
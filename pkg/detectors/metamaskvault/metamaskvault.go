@@ -0,0 +1,227 @@
+package metamaskvault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+var _ detectors.PasswordDecrypter = (*Scanner)(nil)
+
+var (
+	// MetaMask 浏览器插件的 vault 结构 (browser-passworder):
+	// {"data":"<base64>","iv":"<base64>","keyMetadata":{...},"salt":"<base64>"}
+	// 在 LevelDB 备份或导出的 state 中通常是转义后的 JSON 字符串, 因此允许引号前带反斜杠
+	dataPat       = regexp.MustCompile(`\\?"data\\?"\s*:\s*\\?"([A-Za-z0-9+/]{32,}={0,2})\\?"`)
+	ivPat         = regexp.MustCompile(`\\?"iv\\?"\s*:\s*\\?"([A-Za-z0-9+/]{16,24}={0,2})\\?"`)
+	saltPat       = regexp.MustCompile(`\\?"salt\\?"\s*:\s*\\?"([A-Za-z0-9+/]{16,64}={0,2})\\?"`)
+	iterationsPat = regexp.MustCompile(`\\?"iterations\\?"\s*:\s*([0-9]{1,7})`)
+)
+
+const (
+	// iv 和 salt 与 data 的距离上限
+	fieldWindow = 512
+	// 旧版本 vault 没有 keyMetadata, 使用固定的迭代次数
+	legacyIterations = 10000
+	// 每个 vault 最多尝试的密码数量, PBKDF2 的迭代次数很高
+	maxPasswordAttempts = 10
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"vault", "keyMetadata", "salt"}
+}
+
+// FromData will find MetaMask vaults in a given set of bytes, and optionally try to decrypt them with passwords found
+// in the same data.
+func (s Scanner) FromData(_ context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	var passwords []string
+	if verify {
+		passwords = detectors.CollectPasswords("", data)
+	}
+
+	for _, v := range findVaults(string(data)) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_MetaMaskVault,
+			Raw:          []byte(v.key()),
+			Redacted:     "salt=" + v.salt,
+			ExtraData: map[string]string{
+				"data_present": "true",
+				"data_size":    strconv.Itoa(len(v.data)),
+				"iterations":   strconv.Itoa(v.iterations),
+			},
+		}
+
+		if verify {
+			v.tryPasswords(&s1, passwords)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// TryDecrypt tries to decrypt the vault behind result with passwords collected from the rest of the source unit.
+func (s Scanner) TryDecrypt(_ context.Context, data []byte, result *detectors.Result, candidates []string) bool {
+	// FromData 已经尝试过同一段数据中的密码
+	tried := detectors.CollectPasswords("", data)
+	candidates = slices.DeleteFunc(slices.Clone(candidates), func(c string) bool {
+		return slices.Contains(tried, c)
+	})
+	if len(candidates) == 0 {
+		return false
+	}
+
+	for _, v := range findVaults(string(data)) {
+		if v.key() == string(result.Raw) {
+			return v.tryPasswords(result, candidates)
+		}
+	}
+	return false
+}
+
+type vault struct {
+	data       []byte
+	iv         string
+	salt       string
+	iterations int
+}
+
+func (v vault) key() string {
+	return v.salt + ":" + v.iv
+}
+
+// findVaults 查找数据中的 vault, 按 salt 和 iv 去重
+func findVaults(dataStr string) []vault {
+	var vaults []vault
+	seen := make(map[string]struct{})
+
+	for _, idx := range dataPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		cipherText := dataStr[idx[2]:idx[3]]
+		window := dataStr[max(0, idx[0]-fieldWindow):min(len(dataStr), idx[1]+fieldWindow)]
+
+		ivMatch := ivPat.FindStringSubmatch(window)
+		saltMatch := saltPat.FindStringSubmatch(window)
+		if ivMatch == nil || saltMatch == nil {
+			continue
+		}
+
+		rawCipherText, err := base64.StdEncoding.DecodeString(cipherText)
+		if err != nil {
+			continue
+		}
+
+		v := vault{data: rawCipherText, iv: ivMatch[1], salt: saltMatch[1], iterations: legacyIterations}
+		if _, ok := seen[v.key()]; ok {
+			continue
+		}
+		seen[v.key()] = struct{}{}
+
+		if m := iterationsPat.FindStringSubmatch(window); m != nil {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+				v.iterations = n
+			}
+		}
+		vaults = append(vaults, v)
+	}
+	return vaults
+}
+
+// tryPasswords 依次尝试候选密码, 成功时把 vault 中各 keyring 的类型记录到 result 中
+func (v vault) tryPasswords(result *detectors.Result, passwords []string) bool {
+	for i, password := range passwords {
+		if i >= maxPasswordAttempts {
+			break
+		}
+		if keyrings, ok := v.decrypt(password); ok {
+			// 成功解密即说明 vault 和密码一起泄露了, 助记词可以被直接恢复
+			result.Verified = true
+			result.ExtraData["decrypted"] = "true"
+			result.ExtraData["keyring_types"] = strings.Join(keyrings, ",")
+			return true
+		}
+	}
+	return false
+}
+
+// decrypt 使用 PBKDF2-SHA256 派生 AES-GCM 密钥并解密 vault
+func (v vault) decrypt(password string) ([]string, bool) {
+	salt, err := base64.StdEncoding.DecodeString(v.salt)
+	if err != nil {
+		return nil, false
+	}
+	iv, err := base64.StdEncoding.DecodeString(v.iv)
+	if err != nil {
+		return nil, false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, v.iterations, 32)
+	if err != nil {
+		return nil, false
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, false
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, false
+	}
+	plainText, err := gcm.Open(nil, iv, v.data, nil)
+	if err != nil {
+		return nil, false
+	}
+
+	var keyrings []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(plainText, &keyrings); err != nil {
+		return nil, false
+	}
+	types := make([]string, 0, len(keyrings))
+	for _, k := range keyrings {
+		types = append(types, k.Type)
+	}
+	return types, true
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_MetaMaskVault
+}
+
+func (s Scanner) Description() string {
+	return "MetaMask stores wallet secret recovery phrases and private keys in an encrypted vault. A leaked vault can be brute-forced offline, and together with its password it gives full control over the wallet."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// vault 可以离线暴力破解, 即使密码足够强也应视为泄露
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("MetaMask wallet") +
+		" Treat the vault as leaked even if its password is strong, as it can be brute-forced offline indefinitely."
+}
@@ -0,0 +1,111 @@
+package metamaskvault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testData = "UEFu4j/h6X2tfuN5Khx2XSroM3gKn1GU0KUzcI+WsJ95BXDtzYMtbHNj+DytYg/WmTDpxFc0clRW2BiToXXPwc9pc1J1Rq5fyPUd0wQ3hnO8drAlVDr4NA=="
+	testIV   = "ZmVkY2JhOTg3NjU0MzIxMA=="
+	testSalt = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+)
+
+func TestMetaMaskVault_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid vault",
+			input: `{"vault":{"data":"` + testData + `","iv":"` + testIV + `","keyMetadata":{"algorithm":"PBKDF2","params":{"iterations":1000}},"salt":"` + testSalt + `"}}`,
+			want:  []string{testSalt + ":" + testIV},
+		},
+		{
+			name:  "valid escaped vault from leveldb",
+			input: `KeyringController{"vault":"{\"data\":\"` + testData + `\",\"iv\":\"` + testIV + `\",\"salt\":\"` + testSalt + `\"}"}`,
+			want:  []string{testSalt + ":" + testIV},
+		},
+		{
+			name:  "invalid vault - missing salt",
+			input: `{"vault":{"data":"` + testData + `","iv":"` + testIV + `"}}`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestMetaMaskVault_Decrypt(t *testing.T) {
+	input := `
+		{"vault":{"data":"` + testData + `","iv":"` + testIV + `","keyMetadata":{"algorithm":"PBKDF2","params":{"iterations":1000}},"salt":"` + testSalt + `"}}
+		METAMASK_PASSWORD=not-the-password
+		metamask_password: correct-horse-battery
+	`
+
+	results, err := Scanner{}.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.True(t, results[0].Verified)
+	require.Equal(t, "HD Key Tree", results[0].ExtraData["keyring_types"])
+	require.Equal(t, "88", results[0].ExtraData["data_size"])
+}
+
+func TestMetaMaskVault_TryDecrypt(t *testing.T) {
+	input := `{"vault":{"data":"` + testData + `","iv":"` + testIV + `","keyMetadata":{"algorithm":"PBKDF2","params":{"iterations":1000}},"salt":"` + testSalt + `"}}`
+
+	d := Scanner{}
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.False(t, results[0].Verified)
+
+	require.False(t, d.TryDecrypt(context.Background(), []byte(input), &results[0], []string{"not-the-password"}))
+	require.True(t, d.TryDecrypt(context.Background(), []byte(input), &results[0], []string{"not-the-password", "correct-horse-battery"}))
+	require.True(t, results[0].Verified)
+	require.Equal(t, "HD Key Tree", results[0].ExtraData["keyring_types"])
+}
@@ -0,0 +1,128 @@
+package neon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Neon API key 以 napi_ 开头, 后接小写字母和数字
+	keyPat = regexp.MustCompile(`\b(napi_[a-z0-9]{56,64})\b`)
+)
+
+const projectsURL = "https://console.neon.tech/api/v2/projects"
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"napi_"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Neon API keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+
+	for _, key := range detectors.SortedKeys(uniqueKeys) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Neon,
+			Raw:          []byte(key),
+		}
+
+		if verify {
+			isVerified, projects, verificationErr := verifyKey(ctx, s.getClient(), projectsURL, key)
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, key)
+			if len(projects) > 0 {
+				s1.ExtraData = map[string]string{"projects": strings.Join(projects, ",")}
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// docs: https://api-docs.neon.tech/reference/listprojects
+func verifyKey(ctx context.Context, client *http.Client, url, key string) (bool, []string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var body struct {
+			Projects []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"projects"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			return true, nil, nil
+		}
+		projects := make([]string, 0, len(body.Projects))
+		for _, p := range body.Projects {
+			if p.Name != "" {
+				projects = append(projects, p.Name)
+			} else {
+				projects = append(projects, p.ID)
+			}
+		}
+		return true, projects, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Neon
+}
+
+func (s Scanner) Description() string {
+	return "Neon is a serverless Postgres platform. Neon API keys can be used to manage projects, branches, databases and roles, including reading connection passwords."
+}
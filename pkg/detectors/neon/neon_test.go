@@ -0,0 +1,110 @@
+package neon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const validKey = "napi_ujzde8gxd6ncf10epf91dhodzdoc9is0j8ht9lgmxg9edn581u33xtplpft7"
+
+func TestNeon_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern",
+			input: "NEON_API_KEY=" + validKey,
+			want:  []string{validKey},
+		},
+		{
+			name:  "invalid pattern - too short",
+			input: "NEON_API_KEY=napi_ujzde8gxd6ncf10epf91dhodzdoc9is0j8",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestNeon_VerifyKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projects":[{"id":"bold-sun-123456","name":"billing"},{"id":"quiet-lake-654321","name":""}]}`))
+	}))
+	defer server.Close()
+
+	verified, projects, err := verifyKey(context.Background(), server.Client(), server.URL, validKey)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, []string{"billing", "quiet-lake-654321"}, projects)
+
+	verified, _, err = verifyKey(context.Background(), server.Client(), server.URL, "napi_revoked")
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
+
+func TestNeon_FromData_Order(t *testing.T) {
+	keys := []string{
+		"napi_ujzde8gxd6ncf10epf91dhodzdoc9is0j8ht9lgmxg9edn581u33xtplpft7",
+		"napi_0000e8gxd6ncf10epf91dhodzdoc9is0j8ht9lgmxg9edn581u33xtplpft7",
+		"napi_zzzze8gxd6ncf10epf91dhodzdoc9is0j8ht9lgmxg9edn581u33xtplpft7",
+	}
+	input := "NEON_API_KEY=" + keys[0] + "\nNEON_API_KEY=" + keys[1] + "\nNEON_API_KEY=" + keys[2]
+
+	// Results must come in the same order on every run, not in map order.
+	for i := 0; i < 20; i++ {
+		results, err := Scanner{}.FromData(context.Background(), false, []byte(input))
+		require.NoError(t, err)
+		got := make([]string, 0, len(results))
+		for _, r := range results {
+			got = append(got, string(r.Raw))
+		}
+		assert.Equal(t, []string{keys[1], keys[0], keys[2]}, got)
+	}
+}
@@ -0,0 +1,195 @@
+package neteaseyidun
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 易盾的 secretId, secretKey 和 businessId 都是 32 位十六进制
+	secretIDPat   = regexp.MustCompile(`(?i)secret[_-]?id["'\s:=>]+["']?([a-f0-9]{32})\b`)
+	secretKeyPat  = regexp.MustCompile(`(?i)secret[_-]?key["'\s:=>]+["']?([a-f0-9]{32})\b`)
+	businessIDPat = regexp.MustCompile(`(?i)business[_-]?id["'\s:=>]+["']?([a-f0-9]{32})\b`)
+)
+
+const (
+	checkURL = "https://as.dun.163.com/v5/text/check"
+
+	// 易盾通用返回码
+	codeOK              = 200
+	codeBadRequest      = 400
+	codeInvalidBusiness = 401
+	codeBadSignature    = 413
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"yidun", "dun.163", "易盾"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Netease Yidun secretId/secretKey pairs in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueIDs := make(map[string]struct{})
+	for _, match := range secretIDPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueIDs[match[1]] = struct{}{}
+	}
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range secretKeyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+	var businessID string
+	if match := businessIDPat.FindStringSubmatch(dataStr); match != nil {
+		businessID = match[1]
+	}
+
+	for _, secretID := range detectors.SortedKeys(uniqueIDs) {
+		for _, secretKey := range detectors.SortedKeys(uniqueKeys) {
+			s1 := detectors.Result{
+				DetectorType: detector_typepb.DetectorType_NeteaseYidun,
+				Raw:          []byte(secretID),
+				RawV2:        []byte(secretID + ":" + secretKey),
+				Redacted:     secretID,
+			}
+			if businessID != "" {
+				s1.ExtraData = map[string]string{"business_id": businessID}
+			}
+
+			// 所有接口都要求 businessId, 缺少时无法验证
+			if verify && businessID != "" {
+				isVerified, verificationErr := verifySecret(ctx, s.getClient(), checkURL, secretID, secretKey, businessID)
+				s1.Verified = isVerified
+				s1.SetVerificationError(verificationErr, secretKey)
+			}
+
+			results = append(results, s1)
+		}
+	}
+
+	return results, nil
+}
+
+// verifySecret 调用文本检测接口但不传待检测内容, 易盾没有只读的账户信息接口.
+// 易盾先校验 secretId, businessId 和签名, 通过后才校验业务参数, 因此凭证有效时返回 400 参数错误, 不会产生检测计费
+func verifySecret(ctx context.Context, client *http.Client, endpoint, secretID, secretKey, businessID string) (bool, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, err
+	}
+	params := map[string]string{
+		"secretId":        secretID,
+		"businessId":      businessID,
+		"version":         "v5.2",
+		"timestamp":       strconv.FormatInt(time.Now().UnixMilli(), 10),
+		"nonce":           hex.EncodeToString(nonce),
+		"signatureMethod": "MD5",
+	}
+	form := url.Values{}
+	for k, v := range params {
+		form.Set(k, v)
+	}
+	form.Set("signature", sign(params, secretKey))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	var body struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, err
+	}
+
+	switch body.Code {
+	case codeOK, codeBadRequest:
+		return true, nil
+	case codeInvalidBusiness, codeBadSignature:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected error code %d: %s", body.Code, body.Msg)
+	}
+}
+
+// sign 按参数名排序后拼接参数名和参数值, 末尾追加 secretKey, 取 MD5
+func sign(params map[string]string, secretKey string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteString(params[k])
+	}
+	sb.WriteString(secretKey)
+	sum := md5.Sum([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagChinaCloud} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_NeteaseYidun
+}
+
+func (s Scanner) Description() string {
+	return "Netease Yidun is a content moderation, CAPTCHA and SMS verification service. A Yidun secretId and secretKey can be used to call its APIs on the account's quota."
+}
@@ -0,0 +1,123 @@
+package neteaseyidun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	validSecretID   = "4e7c1a9d2b5f48e0a6c3d1f9b2e7a504"
+	validSecretKey  = "b19f0d6e3a2c4857e9d1f0a4c6b3e28d"
+	validBusinessID = "a3c5e7f9b1d24680ace13579bdf02468"
+)
+
+func TestNeteaseYidun_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "valid pattern - java constants",
+			input: `// 易盾文本检测
+private static final String SECRETID = "` + validSecretID + `";
+private static final String SECRETKEY = "` + validSecretKey + `";
+private static final String BUSINESSID = "` + validBusinessID + `";
+private static final String API_URL = "http://as.dun.163.com/v5/text/check";`,
+			want: []string{validSecretID + ":" + validSecretKey},
+		},
+		{
+			name:  "valid pattern - yaml",
+			input: "yidun:\n  secret-id: " + validSecretID + "\n  secret-key: " + validSecretKey,
+			want:  []string{validSecretID + ":" + validSecretKey},
+		},
+		{
+			name:  "invalid pattern - no secret key",
+			input: "yidun:\n  secret_id: " + validSecretID,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestNeteaseYidun_VerifySecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		params := make(map[string]string)
+		for k := range r.PostForm {
+			if k != "signature" {
+				params[k] = r.PostForm.Get(k)
+			}
+		}
+		switch {
+		case r.PostForm.Get("businessId") != validBusinessID:
+			_, _ = w.Write([]byte(`{"code":401,"msg":"businessId invalid"}`))
+		case r.PostForm.Get("signature") != sign(params, validSecretKey):
+			_, _ = w.Write([]byte(`{"code":413,"msg":"signature verify failed"}`))
+		default:
+			// Valid credentials, but no content to check.
+			_, _ = w.Write([]byte(`{"code":400,"msg":"dataId is empty"}`))
+		}
+	}))
+	defer server.Close()
+
+	verified, err := verifySecret(context.Background(), server.Client(), server.URL, validSecretID, validSecretKey, validBusinessID)
+	require.NoError(t, err)
+	assert.True(t, verified)
+
+	verified, err = verifySecret(context.Background(), server.Client(), server.URL, validSecretID, "00000000000000000000000000000000", validBusinessID)
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
+
+func TestSign(t *testing.T) {
+	params := map[string]string{"secretId": "id", "businessId": "biz", "nonce": "n", "timestamp": "1", "version": "v5.2"}
+	// md5("businessIdbiznoncensecretIdidtimestamp1versionv5.2key")
+	assert.Equal(t, "aa30419c29099f36e550a591a41c9970", sign(params, "key"))
+}
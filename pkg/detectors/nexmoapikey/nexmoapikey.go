@@ -2,10 +2,16 @@ package nexmoapikey
 
 import (
 	"context"
-	regexp "github.com/wasilibs/go-re2"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
+	regexp "github.com/wasilibs/go-re2"
+
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
@@ -13,26 +19,47 @@ import (
 
 type Scanner struct {
 	detectors.DefaultMultiPartCredentialProvider
+	client *http.Client
+	// apiURL overrides the Vonage REST API in tests.
+	apiURL string
 }
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const defaultAPIURL = "https://rest.nexmo.com"
 
 var (
-	client = common.SaneHttpClient()
+	defaultClient = common.SaneHttpClient()
 
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
-	keyPat    = regexp.MustCompile(detectors.PrefixRegex([]string{"nexmo"}) + `\b([A-Za-z0-9_-]{8})\b`)
-	secretPat = regexp.MustCompile(detectors.PrefixRegex([]string{"nexmo"}) + `\b([A-Za-z0-9_-]{16})\b`)
+	// Nexmo was renamed to Vonage; both names are used in configurations, e.g. VONAGE_API_KEY and NEXMO_API_SECRET.
+	keyPat    = regexp.MustCompile(detectors.PrefixRegex([]string{"nexmo", "vonage"}) + `\b([A-Za-z0-9_-]{8})\b`)
+	secretPat = regexp.MustCompile(detectors.PrefixRegex([]string{"nexmo", "vonage"}) + `\b([A-Za-z0-9_-]{16})\b`)
 )
 
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"nexmo"}
+	return []string{"nexmo", "vonage"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getAPIURL() string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return defaultAPIURL
 }
 
-// FromData will find and optionally verify NexmoApiKey secrets in a given set of bytes.
+// FromData will find and optionally verify Vonage (formerly Nexmo) API keys and secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
@@ -51,17 +78,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 
 			if verify {
-				req, err := http.NewRequestWithContext(ctx, "GET", "https://rest.nexmo.com/account/get-balance?api_key="+resMatch+"&api_secret="+resSecret+"", nil)
-				if err != nil {
-					continue
-				}
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					if res.StatusCode >= 200 && res.StatusCode < 300 {
-						s1.Verified = true
-					}
-				}
+				isVerified, extraData, verificationErr := verifyBalance(ctx, s.getClient(), s.getAPIURL(), resMatch, resSecret)
+				s1.Verified = isVerified
+				s1.ExtraData = extraData
+				s1.SetVerificationError(verificationErr, resSecret)
 			}
 
 			results = append(results, s1)
@@ -72,10 +92,55 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+// verifyBalance gets the balance of the account, which the account API allows for any valid key and secret. The
+// balance and whether it is automatically reloaded tell how much fraudulent traffic the account can pay for.
+func verifyBalance(ctx context.Context, client *http.Client, apiURL, key, secret string) (bool, map[string]string, error) {
+	params := url.Values{"api_key": {key}, "api_secret": {secret}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/account/get-balance?"+params.Encode(), http.NoBody)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var balance struct {
+			Value      float64 `json:"value"`
+			AutoReload bool    `json:"autoReload"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&balance); err != nil {
+			return true, nil, nil
+		}
+		return true, map[string]string{
+			"balance":     strconv.FormatFloat(balance.Value, 'f', 2, 64),
+			"auto_reload": strconv.FormatBool(balance.AutoReload),
+		}, nil
+	case http.StatusUnauthorized:
+		// The secret is determinately not verified (nothing to do)
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_NexmoApiKey
 }
 
 func (s Scanner) Description() string {
-	return "Nexmo provides APIs for SMS, voice, phone verifications, and more. Nexmo API keys can be used to access and manage these services."
+	return "Vonage, formerly Nexmo, provides APIs for SMS, voice, phone verifications, and more. Vonage API keys and secrets can be used to access and manage these services."
 }
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCommunications} }
@@ -3,9 +3,13 @@ package nexmoapikey
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
@@ -81,3 +85,28 @@ func TestNexmoApiKey_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestNexmoApiKey_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != validKey || r.URL.Query().Get("api_secret") != validSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"value":312.5,"autoReload":true}`)
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), apiURL: server.URL}
+
+	results, err := d.FromData(context.Background(), true, []byte(fmt.Sprintf("VONAGE_API_KEY=%s\nVONAGE_API_SECRET=%s\n", validKey, validSecret)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, map[string]string{"balance": "312.50", "auto_reload": "true"}, results[0].ExtraData)
+
+	results, err = d.FromData(context.Background(), true, []byte(fmt.Sprintf("VONAGE_API_KEY=%s\nVONAGE_API_SECRET=%s\n", validKey, "hngKGCWbmK0COTtS")))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
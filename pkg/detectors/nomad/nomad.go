@@ -0,0 +1,199 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// Nomad 地址来自扫描内容, 不允许访问内网地址
+	defaultClient = detectors.DetectorHttpClientWithNoLocalAddresses
+
+	// NOMAD_TOKEN=..., X-Nomad-Token: ..., nomad_acl_token = "..."
+	tokenPat = regexp.MustCompile(`(?i)nomad[_-]?(?:acl[_-]?|bootstrap[_-]?|management[_-]?)?(?:token|secret(?:[_-]?id)?)["']?\s*[:=]?\s*["']?([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`)
+	// nomad acl bootstrap / nomad acl token create 的输出:
+	// Secret ID    = 9184ec35-65d4-9258-61e3-0c066d0a45c5
+	secretIDPat = regexp.MustCompile(`(?m)^\s*Secret ID\s+=\s+([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`)
+
+	// NOMAD_ADDR=https://nomad.example.com:4646, nomad_address = "...", nomad -address=...
+	addrPat = regexp.MustCompile(`(?i)(?:nomad[_-]?addr(?:ess)?["']?\s*[:=]\s*["']?|-address[= ]["']?)(https?://[a-z0-9.-]+(?::[0-9]{1,5})?)`)
+	// 未标注的地址只认 Nomad 的默认 HTTP 端口
+	defaultPortPat = regexp.MustCompile(`(?i)\b(https?://[a-z0-9.-]+:4646)\b`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"nomad"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Nomad ACL tokens in a given set of bytes. Tokens are verified against the
+// Nomad address found in the same data, if there is one.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueTokens := make(map[string]struct{})
+	for _, pat := range []*regexp.Regexp{tokenPat, secretIDPat} {
+		for _, match := range pat.FindAllStringSubmatch(dataStr, -1) {
+			uniqueTokens[strings.ToLower(match[1])] = struct{}{}
+		}
+	}
+	if len(uniqueTokens) == 0 {
+		return nil, nil
+	}
+	addresses := findAddresses(dataStr)
+
+	for _, token := range detectors.SortedKeys(uniqueTokens) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Nomad,
+			Raw:          []byte(token),
+			Redacted:     token[:8] + "-****",
+		}
+
+		// 没有地址时无法验证
+		if verify && len(addresses) > 0 {
+			s.verify(ctx, &s1, token, addresses)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// findAddresses returns the Nomad addresses in data, labeled ones first.
+func findAddresses(data string) []string {
+	var addresses []string
+	seen := make(map[string]struct{})
+	for _, pat := range []*regexp.Regexp{addrPat, defaultPortPat} {
+		for _, match := range pat.FindAllStringSubmatch(data, -1) {
+			addr := strings.ToLower(match[1])
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+func (s Scanner) verify(ctx context.Context, result *detectors.Result, token string, addresses []string) {
+	client := s.getClient()
+
+	// 同一段数据中可能有多个集群的地址, 依次尝试
+	var lastErr error
+	for _, addr := range addresses {
+		self, isVerified, err := verifyToken(ctx, client, addr, token)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		lastErr = nil
+		if !isVerified {
+			continue
+		}
+
+		result.Verified = true
+		result.RawV2 = []byte(addr + "/" + token)
+		result.ExtraData = map[string]string{
+			"address":     addr,
+			"accessor_id": self.AccessorID,
+			"name":        self.Name,
+			"type":        self.Type,
+			"policies":    strings.Join(self.Policies, ","),
+			"global":      strconv.FormatBool(self.Global),
+		}
+		if self.ExpirationTime != nil {
+			result.SetExpiresAt(*self.ExpirationTime)
+		}
+		// management token 拥有集群的全部权限
+		if self.Type == "management" {
+			result.Severity = detectors.SeverityCritical
+		}
+		return
+	}
+	result.SetVerificationError(lastErr, token)
+}
+
+// tokenSelf 是 /v1/acl/token/self 的响应
+type tokenSelf struct {
+	AccessorID     string     `json:"AccessorID"`
+	Name           string     `json:"Name"`
+	Type           string     `json:"Type"`
+	Policies       []string   `json:"Policies"`
+	Global         bool       `json:"Global"`
+	ExpirationTime *time.Time `json:"ExpirationTime"`
+}
+
+func verifyToken(ctx context.Context, client *http.Client, addr, token string) (*tokenSelf, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/v1/acl/token/self", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("X-Nomad-Token", token)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var self tokenSelf
+		if err := json.NewDecoder(res.Body).Decode(&self); err != nil {
+			return nil, false, err
+		}
+		return &self, true, nil
+	case http.StatusForbidden:
+		// ACL token not found
+		return nil, false, nil
+	default:
+		// ACL 未启用时返回 400 "ACL support disabled", token 无法验证
+		return nil, false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Nomad
+}
+
+func (s Scanner) Description() string {
+	return "HashiCorp Nomad ACL tokens authenticate to the Nomad API. Depending on their policies they can read job definitions and variables, run jobs, and exec into running allocations; management tokens control the whole cluster."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagInfrastructure} }
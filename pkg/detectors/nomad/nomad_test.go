@@ -0,0 +1,117 @@
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	managementToken = "9184ec35-65d4-9258-61e3-0c066d0a45c5"
+	revokedToken    = "3c8bd8e1-4e0b-1f77-2a6d-5d8b1e0c9a41"
+)
+
+func TestNomad_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "environment",
+			input: `export NOMAD_ADDR=https://nomad.example.com:4646
+export NOMAD_TOKEN=` + managementToken,
+			want: []string{managementToken},
+		},
+		{
+			name: "acl bootstrap output",
+			input: `$ nomad acl bootstrap
+Accessor ID  = 5b7fd453-d3f7-6814-81dc-fcfe6daedea5
+Secret ID    = ` + managementToken + `
+Name         = Bootstrap Token
+Type         = management`,
+			want: []string{managementToken},
+		},
+		{
+			name:  "invalid pattern - accessor id",
+			input: "nomad acl token info -accessor 5b7fd453-d3f7-6814-81dc-fcfe6daedea5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestNomad_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/acl/token/self" || r.Header.Get("X-Nomad-Token") != managementToken {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("ACL token not found"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"AccessorID":"5b7fd453-d3f7-6814-81dc-fcfe6daedea5","Name":"Bootstrap Token","Type":"management","Policies":null,"Global":true,"ExpirationTime":null}`))
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client()}
+	input := "NOMAD_ADDR=" + server.URL + "\nNOMAD_TOKEN=" + managementToken + "\nOLD_NOMAD_TOKEN=" + revokedToken
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	byToken := make(map[string]detectors.Result)
+	for _, r := range results {
+		byToken[string(r.Raw)] = r
+	}
+
+	valid := byToken[managementToken]
+	assert.True(t, valid.Verified)
+	assert.NoError(t, valid.VerificationError())
+	assert.Equal(t, "management", valid.ExtraData["type"])
+	assert.Equal(t, "Bootstrap Token", valid.ExtraData["name"])
+	assert.Equal(t, server.URL, valid.ExtraData["address"])
+	assert.Equal(t, detectors.SeverityCritical, valid.Severity)
+
+	revoked := byToken[revokedToken]
+	assert.False(t, revoked.Verified)
+	assert.NoError(t, revoked.VerificationError())
+}
@@ -0,0 +1,161 @@
+package detectors
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// zeroWidthReplacer removes invisible characters that split a secret pasted from chat, documents or web pages.
+var zeroWidthReplacer = strings.NewReplacer(
+	"\u200b", "", // zero width space
+	"\u200c", "", // zero width non-joiner
+	"\u200d", "", // zero width joiner
+	"\u2060", "", // word joiner
+	"\ufeff", "", // zero width no-break space (BOM)
+)
+
+// StripZeroWidth removes zero-width characters from s.
+func StripZeroWidth(s string) string {
+	return zeroWidthReplacer.Replace(s)
+}
+
+// NormalizeCandidate returns the canonical form of a candidate secret, used to tell whether two candidates are the
+// same secret written down differently. It strips zero-width characters, surrounding whitespace and quotes, resolves
+// JSON and YAML string escapes, and lowercases hex strings. Results keep the secret as it was found; only comparisons
+// use the canonical form.
+func NormalizeCandidate(s string) string {
+	s = StripZeroWidth(s)
+	s = trimQuotes(strings.TrimSpace(s))
+	s = trimQuotes(UnescapeString(s))
+	if isHexString(s) {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// trimQuotes removes matching quotes around s, including escaped ones such as \"...\".
+func trimQuotes(s string) string {
+	for {
+		trimmed := s
+		for _, q := range []string{`\"`, `\'`, `"`, `'`, "`"} {
+			if len(trimmed) >= 2*len(q) && strings.HasPrefix(trimmed, q) && strings.HasSuffix(trimmed, q) {
+				trimmed = strings.TrimSpace(trimmed[len(q) : len(trimmed)-len(q)])
+				break
+			}
+		}
+		if trimmed == s {
+			return s
+		}
+		s = trimmed
+	}
+}
+
+// isHexString reports whether s is a hex string of at least 16 digits, optionally prefixed with 0x.
+func isHexString(s string) bool {
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	if len(s) < 16 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// UnescapeString resolves the escape sequences of JSON and YAML double-quoted strings in s. Sequences that are not
+// valid escapes are left as they are.
+func UnescapeString(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return string(unescape([]byte(s)))
+}
+
+// UnescapeSpan prepares a matched span for a second detection pass: it resolves string escapes, which hide secrets
+// inside stringified JSON, and removes zero-width characters. It returns nil if the span has neither.
+func UnescapeSpan(data []byte) []byte {
+	hasEscape := bytes.IndexByte(data, '\\') >= 0
+	hasZeroWidth := containsZeroWidth(data)
+	if !hasEscape && !hasZeroWidth {
+		return nil
+	}
+	out := data
+	if hasEscape {
+		out = unescape(out)
+	}
+	if hasZeroWidth {
+		out = []byte(zeroWidthReplacer.Replace(string(out)))
+	}
+	if bytes.Equal(out, data) {
+		return nil
+	}
+	return out
+}
+
+func containsZeroWidth(data []byte) bool {
+	// All zero-width characters are encoded as three bytes starting with 0xE2 or 0xEF.
+	if bytes.IndexByte(data, 0xe2) < 0 && bytes.IndexByte(data, 0xef) < 0 {
+		return false
+	}
+	return zeroWidthReplacer.Replace(string(data)) != string(data)
+}
+
+var simpleEscapes = map[byte]byte{
+	'"':  '"',
+	'\'': '\'',
+	'\\': '\\',
+	'/':  '/',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'b':  '\b',
+	'f':  '\f',
+	'0':  0,
+}
+
+func unescape(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '\\' || i+1 == len(data) {
+			out = append(out, c)
+			continue
+		}
+
+		next := data[i+1]
+		if r, ok := simpleEscapes[next]; ok {
+			out = append(out, r)
+			i++
+			continue
+		}
+
+		// \xXX (YAML), \uXXXX (JSON and YAML, with UTF-16 surrogate pairs) and \UXXXXXXXX (YAML).
+		digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[next]
+		if digits == 0 || i+2+digits > len(data) {
+			out = append(out, c)
+			continue
+		}
+		code, err := strconv.ParseUint(string(data[i+2:i+2+digits]), 16, 32)
+		if err != nil {
+			out = append(out, c)
+			continue
+		}
+		end := i + 2 + digits
+		if next == 'u' && code >= 0xd800 && code < 0xdc00 && end+6 <= len(data) && data[end] == '\\' && data[end+1] == 'u' {
+			if low, err := strconv.ParseUint(string(data[end+2:end+6]), 16, 32); err == nil && low >= 0xdc00 && low < 0xe000 {
+				code = 0x10000 + (code-0xd800)<<10 + (low - 0xdc00)
+				end += 6
+			}
+		}
+		out = utf8.AppendRune(out, rune(code))
+		i = end - 1
+	}
+	return out
+}
@@ -0,0 +1,451 @@
+package oauthtokencache
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Entra ID 的租户是 GUID 或域名, 拼接到 token 端点的路径中
+	tenantPat = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.-]{0,253}$`)
+)
+
+const (
+	// 令牌缓存文件通常只有几 KB, MSAL 缓存中每个账号还带有 access token 和 id token
+	maxSecretSize = 64 * 1024
+
+	providerGoogle = "google"
+	providerAzure  = "azure"
+	providerAliyun = "aliyun"
+
+	formatGcloudADC = "gcloud_adc"
+	formatMSAL      = "msal_token_cache"
+	formatAliyunCLI = "aliyun_cli"
+
+	googleTokenURL     = "https://oauth2.googleapis.com/token"
+	googleTokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+	// defaultAzureScope 缓存中没有该账号的 access token 时请求的 scope, 所有公共客户端都可以申请
+	defaultAzureScope = "openid profile offline_access"
+)
+
+// azureAuthorities 是 MSAL 缓存中 environment 字段的合法取值, 只向这些主机发送刷新请求
+var azureAuthorities = map[string]struct{}{
+	"login.microsoftonline.com":        {},
+	"login.windows.net":                {},
+	"login.microsoft.com":              {},
+	"sts.windows.net":                  {},
+	"login.chinacloudapi.cn":           {},
+	"login.partner.microsoftonline.cn": {},
+	"login.microsoftonline.us":         {},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+func (s Scanner) Keywords() []string {
+	// gcloud ADC 和 aliyun CLI 使用 refresh_token / oauth_refresh_token, MSAL 缓存使用 RefreshToken
+	return []string{"refresh_token", "refreshtoken"}
+}
+
+// MaxSecretSize returns the maximum size of a secret that this detector can find.
+func (Scanner) MaxSecretSize() int64 { return maxSecretSize }
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCloud} }
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// credential 是令牌缓存中的一个 refresh token 及刷新它所需的上下文
+type credential struct {
+	provider, format string
+	clientID         string
+	clientSecret     string
+	refreshToken     string
+	// account 是缓存中记录的账号, 验证时会用授权服务器返回的账号覆盖
+	account string
+	// tokenURL 是刷新请求的端点, 为空表示无法验证
+	tokenURL string
+	// scope 是刷新时申请的 scope, 只用于 Entra ID
+	scope string
+	extra map[string]string
+}
+
+// gcloudADC 是 gcloud auth application-default login 写入的 application_default_credentials.json
+type gcloudADC struct {
+	Type           string `json:"type"`
+	ClientID       string `json:"client_id"`
+	ClientSecret   string `json:"client_secret"`
+	RefreshToken   string `json:"refresh_token"`
+	Account        string `json:"account"`
+	QuotaProjectID string `json:"quota_project_id"`
+}
+
+// msalCache 是 Azure CLI 等 MSAL 客户端的 msal_token_cache.json
+type msalCache struct {
+	RefreshToken map[string]struct {
+		HomeAccountID string `json:"home_account_id"`
+		Environment   string `json:"environment"`
+		ClientID      string `json:"client_id"`
+		Secret        string `json:"secret"`
+	} `json:"RefreshToken"`
+	Account map[string]struct {
+		HomeAccountID string `json:"home_account_id"`
+		Environment   string `json:"environment"`
+		Realm         string `json:"realm"`
+		Username      string `json:"username"`
+	} `json:"Account"`
+	AccessToken map[string]struct {
+		HomeAccountID string `json:"home_account_id"`
+		ClientID      string `json:"client_id"`
+		Realm         string `json:"realm"`
+		Target        string `json:"target"`
+	} `json:"AccessToken"`
+}
+
+// aliyunConfig 是 aliyun CLI 的 ~/.aliyun/config.json
+type aliyunConfig struct {
+	Profiles []struct {
+		Name              string `json:"name"`
+		Mode              string `json:"mode"`
+		OAuthRefreshToken string `json:"oauth_refresh_token"`
+		OAuthSiteType     string `json:"oauth_site_type"`
+	} `json:"profiles"`
+}
+
+// FromData will find and optionally verify refresh tokens persisted in OAuth token cache files.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	for _, cred := range parseCache(data) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_OAuthTokenCache,
+			Raw:          []byte(cred.refreshToken),
+			RawV2:        []byte(cred.clientID + ":" + cred.refreshToken),
+			Redacted:     redact(cred.refreshToken),
+			ExtraData: map[string]string{
+				"provider": cred.provider,
+				"format":   cred.format,
+			},
+		}
+		if cred.clientID != "" {
+			s1.ExtraData["client_id"] = cred.clientID
+		}
+		for k, v := range cred.extra {
+			s1.ExtraData[k] = v
+		}
+
+		account := cred.account
+		if verify && cred.tokenURL != "" {
+			var verified bool
+			var grant tokenGrant
+			var verificationErr error
+			if cred.provider == providerGoogle {
+				verified, grant, verificationErr = verifyGoogle(ctx, s.getClient(), cred, googleTokenInfoURL)
+			} else {
+				verified, grant, verificationErr = refresh(ctx, s.getClient(), cred)
+			}
+			s1.Verified = verified
+			s1.SetVerificationError(verificationErr, cred.refreshToken, cred.clientSecret)
+			if verified {
+				if grant.Scope != "" {
+					s1.ExtraData["scopes"] = grant.Scope
+				}
+				if grant.account != "" {
+					account = grant.account
+				}
+			}
+		}
+		if account != "" {
+			s1.ExtraData["account"] = account
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// parseCache 把 chunk 当作 JSON 文档解析, 返回其中的 refresh token. 令牌缓存文件很小, 通常整个文件就是一个 chunk
+func parseCache(data []byte) []credential {
+	start := bytes.IndexByte(data, '{')
+	if start < 0 {
+		return nil
+	}
+	// 只解码开头的第一个 JSON 值, 忽略其后的内容
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(data[start:])).Decode(&raw); err != nil {
+		return nil
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil
+	}
+
+	switch {
+	case doc["RefreshToken"] != nil:
+		var cache msalCache
+		if json.Unmarshal(raw, &cache) != nil {
+			return nil
+		}
+		return msalCredentials(cache)
+	case doc["profiles"] != nil:
+		var cfg aliyunConfig
+		if json.Unmarshal(raw, &cfg) != nil {
+			return nil
+		}
+		return aliyunCredentials(cfg)
+	case doc["refresh_token"] != nil:
+		var adc gcloudADC
+		if json.Unmarshal(raw, &adc) != nil {
+			return nil
+		}
+		return googleCredentials(adc)
+	}
+	return nil
+}
+
+func googleCredentials(adc gcloudADC) []credential {
+	// service_account 类型的私钥由 GCP 检测器处理
+	if adc.Type != "authorized_user" || adc.RefreshToken == "" || !strings.HasSuffix(adc.ClientID, ".apps.googleusercontent.com") {
+		return nil
+	}
+	cred := credential{
+		provider:     providerGoogle,
+		format:       formatGcloudADC,
+		clientID:     adc.ClientID,
+		clientSecret: adc.ClientSecret,
+		refreshToken: adc.RefreshToken,
+		account:      adc.Account,
+		tokenURL:     googleTokenURL,
+	}
+	if adc.QuotaProjectID != "" {
+		cred.extra = map[string]string{"quota_project_id": adc.QuotaProjectID}
+	}
+	return []credential{cred}
+}
+
+func msalCredentials(cache msalCache) []credential {
+	var creds []credential
+	for _, key := range detectors.SortedKeys(cache.RefreshToken) {
+		rt := cache.RefreshToken[key]
+		if rt.Secret == "" || rt.ClientID == "" {
+			continue
+		}
+		cred := credential{
+			provider:     providerAzure,
+			format:       formatMSAL,
+			clientID:     rt.ClientID,
+			refreshToken: rt.Secret,
+			scope:        defaultAzureScope,
+		}
+
+		// 账号的 realm 即租户; 个人账号和多租户刷新令牌可以在 organizations 端点刷新
+		tenant := "organizations"
+		for _, accountKey := range detectors.SortedKeys(cache.Account) {
+			account := cache.Account[accountKey]
+			if account.HomeAccountID == rt.HomeAccountID {
+				cred.account = account.Username
+				if account.Realm != "" {
+					tenant = account.Realm
+				}
+				break
+			}
+		}
+		// 申请缓存中该账号已有的 scope, 避免因为申请了未授权的资源而失败
+		for _, atKey := range detectors.SortedKeys(cache.AccessToken) {
+			at := cache.AccessToken[atKey]
+			if at.HomeAccountID == rt.HomeAccountID && at.ClientID == rt.ClientID && at.Target != "" {
+				cred.scope = at.Target + " offline_access"
+				break
+			}
+		}
+		cred.extra = map[string]string{"tenant": tenant}
+
+		if _, ok := azureAuthorities[rt.Environment]; ok && tenantPat.MatchString(tenant) {
+			cred.tokenURL = fmt.Sprintf("https://%s/%s/oauth2/v2.0/token", rt.Environment, tenant)
+		}
+		creds = append(creds, cred)
+	}
+	return creds
+}
+
+func aliyunCredentials(cfg aliyunConfig) []credential {
+	var creds []credential
+	for _, profile := range cfg.Profiles {
+		if !strings.EqualFold(profile.Mode, "OAuth") || profile.OAuthRefreshToken == "" {
+			continue
+		}
+		// aliyun CLI 的 OAuth 应用 ID 不写入配置文件, 无法构造刷新请求, 只报告不验证
+		extra := map[string]string{"profile": profile.Name}
+		if profile.OAuthSiteType != "" {
+			extra["site"] = profile.OAuthSiteType
+		}
+		creds = append(creds, credential{
+			provider:     providerAliyun,
+			format:       formatAliyunCLI,
+			refreshToken: profile.OAuthRefreshToken,
+			extra:        extra,
+		})
+	}
+	return creds
+}
+
+func redact(token string) string {
+	if len(token) <= 12 {
+		return token[:min(len(token), 4)] + "..."
+	}
+	return token[:8] + "..." + token[len(token)-4:]
+}
+
+// tokenGrant 是 refresh grant 的响应
+type tokenGrant struct {
+	AccessToken string `json:"access_token"`
+	Scope       string `json:"scope"`
+	IDToken     string `json:"id_token"`
+	Error       string `json:"error"`
+	// account 是授权服务器返回的账号
+	account string
+}
+
+// refresh 用 refresh grant 换取 access token. invalid_grant 等错误说明令牌已被撤销或过期
+// docs: https://datatracker.ietf.org/doc/html/rfc6749#section-6
+func refresh(ctx context.Context, client *http.Client, cred credential) (bool, tokenGrant, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {cred.refreshToken},
+		"client_id":     {cred.clientID},
+	}
+	if cred.clientSecret != "" {
+		form.Set("client_secret", cred.clientSecret)
+	}
+	if cred.scope != "" {
+		form.Set("scope", cred.scope)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cred.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, tokenGrant{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, tokenGrant{}, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	var grant tokenGrant
+	if err := json.NewDecoder(res.Body).Decode(&grant); err != nil && res.StatusCode == http.StatusOK {
+		return false, tokenGrant{}, err
+	}
+	switch {
+	case res.StatusCode == http.StatusOK && grant.AccessToken != "":
+		grant.account = idTokenAccount(grant.IDToken)
+		return true, grant, nil
+	case res.StatusCode == http.StatusBadRequest || res.StatusCode == http.StatusUnauthorized:
+		switch grant.Error {
+		case "invalid_grant", "invalid_client", "unauthorized_client":
+			return false, tokenGrant{}, nil
+		}
+		return false, tokenGrant{}, fmt.Errorf("refresh grant failed: %s", grant.Error)
+	default:
+		return false, tokenGrant{}, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+// verifyGoogle 刷新 gcloud ADC 的令牌, 并通过 tokeninfo 查询授权的账号
+// docs: https://developers.google.com/identity/protocols/oauth2/native-app#offline
+func verifyGoogle(ctx context.Context, client *http.Client, cred credential, tokenInfoURL string) (bool, tokenGrant, error) {
+	verified, grant, err := refresh(ctx, client, cred)
+	if !verified || grant.account != "" {
+		return verified, grant, err
+	}
+
+	// 账号只是补充信息, 查询失败不影响验证结果
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenInfoURL+"?access_token="+url.QueryEscape(grant.AccessToken), nil)
+	if err != nil {
+		return true, grant, nil
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return true, grant, nil
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	var info struct {
+		Email string `json:"email"`
+	}
+	if res.StatusCode == http.StatusOK && json.NewDecoder(res.Body).Decode(&info) == nil {
+		grant.account = info.Email
+	}
+	return true, grant, nil
+}
+
+// idTokenAccount 从 id token 中取出账号, 不校验签名
+func idTokenAccount(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		PreferredUsername string `json:"preferred_username"`
+		Email             string `json:"email"`
+		UPN               string `json:"upn"`
+	}
+	if json.Unmarshal(payload, &claims) != nil {
+		return ""
+	}
+	for _, account := range []string{claims.PreferredUsername, claims.Email, claims.UPN} {
+		if account != "" {
+			return account
+		}
+	}
+	return ""
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_OAuthTokenCache
+}
+
+func (s Scanner) Description() string {
+	return "OAuth token cache files, such as gcloud application default credentials, the MSAL token cache of the Azure CLI and the aliyun CLI config, persist refresh tokens that keep issuing access tokens for the signed-in account until they are revoked."
+}
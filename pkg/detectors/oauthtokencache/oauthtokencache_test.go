@@ -0,0 +1,213 @@
+package oauthtokencache
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	googleRefreshToken = "1//0gdXb5Kq3xRvZCgYIARAAGBASNwF-L9IrQp8mWc7nT2hVYs4uJeK6oAz3bLxD1fG5iH0jN9kM"
+	azureRefreshToken  = "0.AVEAv3F5sNk1qE2hT8yW4zXcP6dR9mL2bJ7gK0aQ5uH3nY8eZ1tC4fS6wV2xB9oI7pM3lD5kA"
+	aliyunRefreshToken = "6f2c9a1e8b7d4035a9e1c2f3d4b5a6c7"
+
+	googleADC = `{
+  "account": "",
+  "client_id": "764086051850-6qr4p6gpi6hn506pt8ejuq83di341hur.apps.googleusercontent.com",
+  "client_secret": "d-FL95Q19q7MQmFpd7hHD0Ty",
+  "quota_project_id": "billing-prod",
+  "refresh_token": "` + googleRefreshToken + `",
+  "type": "authorized_user"
+}`
+
+	msalTokenCache = `{
+  "Account": {
+    "7c1f.72f9-login.microsoftonline.com-72f988bf-86f1-41af-91ab-2d7cd011db47": {
+      "home_account_id": "7c1f.72f9",
+      "environment": "login.microsoftonline.com",
+      "realm": "72f988bf-86f1-41af-91ab-2d7cd011db47",
+      "username": "ops@contoso.com"
+    }
+  },
+  "AccessToken": {
+    "7c1f.72f9-login.microsoftonline.com-accesstoken-04b07795-8ddb-461a-bbee-02f9e1bf7b46": {
+      "home_account_id": "7c1f.72f9",
+      "client_id": "04b07795-8ddb-461a-bbee-02f9e1bf7b46",
+      "realm": "72f988bf-86f1-41af-91ab-2d7cd011db47",
+      "target": "https://management.core.windows.net//user_impersonation https://management.core.windows.net//.default"
+    }
+  },
+  "RefreshToken": {
+    "7c1f.72f9-login.microsoftonline.com-refreshtoken-04b07795-8ddb-461a-bbee-02f9e1bf7b46--": {
+      "home_account_id": "7c1f.72f9",
+      "environment": "login.microsoftonline.com",
+      "credential_type": "RefreshToken",
+      "client_id": "04b07795-8ddb-461a-bbee-02f9e1bf7b46",
+      "secret": "` + azureRefreshToken + `"
+    }
+  }
+}`
+
+	aliyunConfigJSON = `{
+  "current": "default",
+  "profiles": [
+    {"name": "ak", "mode": "AK", "access_key_id": "", "access_key_secret": ""},
+    {"name": "default", "mode": "OAuth", "oauth_refresh_token": "` + aliyunRefreshToken + `", "oauth_site_type": "CN"}
+  ]
+}`
+)
+
+func TestOAuthTokenCache_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "gcloud adc",
+			input: googleADC,
+			want:  []string{googleRefreshToken},
+		},
+		{
+			name:  "msal token cache",
+			input: msalTokenCache,
+			want:  []string{azureRefreshToken},
+		},
+		{
+			name:  "aliyun cli config",
+			input: aliyunConfigJSON,
+			want:  []string{aliyunRefreshToken},
+		},
+		{
+			name:  "adc with leading text",
+			input: "$ cat ~/.config/gcloud/application_default_credentials.json\n" + googleADC + "\n$ ",
+			want:  []string{googleRefreshToken},
+		},
+		{
+			name:  "invalid - service account",
+			input: `{"type": "service_account", "client_id": "1234.apps.googleusercontent.com", "refresh_token": "x"}`,
+			want:  nil,
+		},
+		{
+			name:  "invalid - not json",
+			input: `refresh_token = "` + googleRefreshToken + `"`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestOAuthTokenCache_Context(t *testing.T) {
+	creds := parseCache([]byte(msalTokenCache))
+	require.Len(t, creds, 1)
+	assert.Equal(t, "https://login.microsoftonline.com/72f988bf-86f1-41af-91ab-2d7cd011db47/oauth2/v2.0/token", creds[0].tokenURL)
+	assert.Equal(t, "ops@contoso.com", creds[0].account)
+	assert.Contains(t, creds[0].scope, "user_impersonation")
+
+	// 未知的 environment 不会被请求
+	creds = parseCache([]byte(`{"RefreshToken": {"k": {"environment": "attacker.example", "client_id": "c", "secret": "s"}}}`))
+	require.Len(t, creds, 1)
+	assert.Empty(t, creds[0].tokenURL)
+
+	creds = parseCache([]byte(aliyunConfigJSON))
+	require.Len(t, creds, 1)
+	assert.Empty(t, creds[0].tokenURL)
+	assert.Equal(t, map[string]string{"profile": "default", "site": "CN"}, creds[0].extra)
+}
+
+func TestOAuthTokenCache_Verify(t *testing.T) {
+	idToken := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(`{"preferred_username":"dev@contoso.com"}`)) + "."
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("refresh_token") {
+		case googleRefreshToken:
+			_, _ = w.Write([]byte(`{"access_token":"ya29.a0","scope":"openid https://www.googleapis.com/auth/cloud-platform"}`))
+		case azureRefreshToken:
+			assert.Contains(t, r.PostForm.Get("scope"), "offline_access")
+			_, _ = w.Write([]byte(`{"access_token":"eyJ0","scope":"https://management.core.windows.net//user_impersonation","id_token":"` + idToken + `"}`))
+		case "unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`))
+		}
+	})
+	mux.HandleFunc("/tokeninfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ya29.a0", r.URL.Query().Get("access_token"))
+		_, _ = w.Write([]byte(`{"email":"dev@example.com","scope":"openid"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client()
+
+	google := credential{provider: providerGoogle, clientID: "c", clientSecret: "s", refreshToken: googleRefreshToken, tokenURL: server.URL + "/token"}
+	verified, grant, err := verifyGoogle(ctx, client, google, server.URL+"/tokeninfo")
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, "openid https://www.googleapis.com/auth/cloud-platform", grant.Scope)
+	assert.Equal(t, "dev@example.com", grant.account)
+
+	azure := credential{provider: providerAzure, clientID: "c", refreshToken: azureRefreshToken, scope: defaultAzureScope, tokenURL: server.URL + "/token"}
+	verified, grant, err = refresh(ctx, client, azure)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, "dev@contoso.com", grant.account)
+
+	revoked := credential{clientID: "c", refreshToken: "revoked", tokenURL: server.URL + "/token"}
+	verified, _, err = refresh(ctx, client, revoked)
+	require.NoError(t, err)
+	assert.False(t, verified)
+
+	unavailable := credential{clientID: "c", refreshToken: "unavailable", tokenURL: server.URL + "/token"}
+	verified, _, err = refresh(ctx, client, unavailable)
+	require.Error(t, err)
+	assert.False(t, verified)
+}
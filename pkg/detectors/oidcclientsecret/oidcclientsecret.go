@@ -0,0 +1,412 @@
+package oidcclientsecret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const (
+	sourceRealmExport   = "keycloak_realm_export"
+	sourceAdapterConfig = "keycloak_adapter_config"
+	sourceOIDCConfig    = "oidc_config"
+)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Keycloak 的 realm 导出: 顶层的 "realm" 和 "clients" 数组中的 clientId/secret
+	realmPat        = regexp.MustCompile(`(?i)["']?\brealm["']?[ \t]*[:=][ \t]*["']?([A-Za-z0-9._-]+)`)
+	exportClientPat = regexp.MustCompile(`"clientId"\s*:\s*"([^"\n]+)"[^{}]*?"secret"\s*:\s*"([^"\n]+)"`)
+	// Keycloak 服务地址: 任何 realm 地址 (<base>/realms/<realm>), 以及 realm 导出中的 frontendUrl
+	realmURLPat    = regexp.MustCompile(`(https?://[A-Za-z0-9.:-]+(?:/[A-Za-z0-9._-]+)*?)/realms/[A-Za-z0-9._-]+`)
+	frontendURLPat = regexp.MustCompile(`"frontendUrl"\s*:\s*"(https?://[^"\s]+)"`)
+
+	// Keycloak 适配器配置 (keycloak.json 或 keycloak.* 属性): auth-server-url, realm, resource 和 credentials.secret
+	authServerPat        = regexp.MustCompile(`(?i)auth[-_]?server[-_]?url["']?[ \t]*[:=][ \t]*["']?(https?://[^\s"',]+)`)
+	resourcePat          = regexp.MustCompile(`(?i)["']?\bresource["']?[ \t]*[:=][ \t]*["']?([A-Za-z0-9._:-]+)`)
+	credentialsSecretPat = regexp.MustCompile(`(?i)credentials["']?\s*[:=.]\s*\{?\s*["']?secret["']?[ \t]*[:=][ \t]*["']?([^\s"',}]+)`)
+
+	// 通用的 OIDC 客户端配置, 例如 Spring 的 issuer-uri/client-id/client-secret, 或 OIDC_ISSUER 这样的环境变量
+	issuerPat       = regexp.MustCompile(`(?i)issuer(?:[-_]?ur[il])?["']?[ \t]*[:=][ \t]*["']?(https?://[^\s"',]+)`)
+	clientIDPat     = regexp.MustCompile(`(?i)client[-_]?id["']?[ \t]*[:=][ \t]*["']?([A-Za-z0-9._:@/-]{2,128})`)
+	clientSecretPat = regexp.MustCompile(`(?i)client[-_]?secret["']?[ \t]*[:=][ \t]*["']?([^\s"',;]{8,256})`)
+)
+
+// 文档和模板中常见的占位值
+var placeholders = map[string]struct{}{
+	"secret":             {},
+	"changeme":           {},
+	"client-secret":      {},
+	"client_secret":      {},
+	"your-client-secret": {},
+	"your_client_secret": {},
+	"yourclientsecret":   {},
+	"mysecret":           {},
+	"placeholder":        {},
+	"redacted":           {},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"clientid", "client_secret", "client-secret", "clientsecret", "auth-server-url"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// candidate 是一个 OIDC 客户端凭据, issuer 为空表示数据中没有找到可以验证的地址
+type candidate struct {
+	source   string
+	issuer   string
+	realm    string
+	clientID string
+	secret   string
+}
+
+// FromData will find and optionally verify OIDC client secrets, in Keycloak realm exports, Keycloak adapter
+// configurations and generic OIDC client configurations, in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var candidates []candidate
+	candidates = append(candidates, realmExportCandidates(dataStr)...)
+	candidates = append(candidates, adapterCandidates(dataStr)...)
+	candidates = append(candidates, oidcConfigCandidates(dataStr)...)
+
+	seen := make(map[string]struct{})
+	for _, c := range candidates {
+		if isPlaceholder(c.secret) {
+			continue
+		}
+		key := c.issuer + "\x00" + c.clientID + "\x00" + c.secret
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_OIDCClientSecret,
+			Raw:          []byte(c.secret),
+			RawV2:        []byte(key),
+			Redacted:     c.clientID,
+			ExtraData: map[string]string{
+				"source":    c.source,
+				"client_id": c.clientID,
+			},
+		}
+		if c.issuer != "" {
+			s1.ExtraData["issuer"] = c.issuer
+		}
+		if c.realm != "" {
+			s1.ExtraData["realm"] = c.realm
+		}
+		s1.SetPrimarySecretValue(c.secret)
+
+		if verify && c.issuer != "" {
+			isVerified, extraData, verificationErr := verifyClient(ctx, s.getClient(), c.issuer, c.clientID, c.secret)
+			s1.Verified = isVerified
+			for k, v := range extraData {
+				s1.ExtraData[k] = v
+			}
+			s1.SetVerificationError(verificationErr, c.secret)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// realmExportCandidates 返回 realm 导出中的客户端. 导出中没有 Keycloak 的地址, 只有在同一数据中找到
+// realm 地址或 frontendUrl 时才能验证.
+func realmExportCandidates(data string) []candidate {
+	clients := exportClientPat.FindAllStringSubmatch(data, -1)
+	if len(clients) == 0 {
+		return nil
+	}
+	realm := firstSubmatch(realmPat, data)
+	issuer := ""
+	if base := keycloakBaseURL(data); base != "" && realm != "" {
+		issuer = base + "/realms/" + realm
+	}
+
+	candidates := make([]candidate, 0, len(clients))
+	for _, m := range clients {
+		candidates = append(candidates, candidate{
+			source:   sourceRealmExport,
+			issuer:   issuer,
+			realm:    realm,
+			clientID: m[1],
+			secret:   m[2],
+		})
+	}
+	return candidates
+}
+
+// adapterCandidates 返回 Keycloak 适配器配置中的客户端, 其 issuer 是 <auth-server-url>/realms/<realm>.
+func adapterCandidates(data string) []candidate {
+	server := firstSubmatch(authServerPat, data)
+	realm := firstSubmatch(realmPat, data)
+	clientID := firstSubmatch(resourcePat, data)
+	if server == "" || realm == "" || clientID == "" {
+		return nil
+	}
+	issuer := strings.TrimSuffix(server, "/") + "/realms/" + realm
+
+	var candidates []candidate
+	for _, m := range credentialsSecretPat.FindAllStringSubmatch(data, -1) {
+		candidates = append(candidates, candidate{
+			source:   sourceAdapterConfig,
+			issuer:   issuer,
+			realm:    realm,
+			clientID: clientID,
+			secret:   m[1],
+		})
+	}
+	return candidates
+}
+
+// oidcConfigCandidates 返回通用 OIDC 配置中的客户端. 一个配置文件可以有多个客户端, 每个 client secret
+// 与距离最近的 client id 和 issuer 配对.
+func oidcConfigCandidates(data string) []candidate {
+	secrets := clientSecretPat.FindAllStringSubmatchIndex(data, -1)
+	if len(secrets) == 0 {
+		return nil
+	}
+	clientIDs := clientIDPat.FindAllStringSubmatchIndex(data, -1)
+	issuers := issuerPat.FindAllStringSubmatchIndex(data, -1)
+	if len(clientIDs) == 0 || len(issuers) == 0 {
+		return nil
+	}
+
+	candidates := make([]candidate, 0, len(secrets))
+	for _, m := range secrets {
+		clientID := nearest(data, clientIDs, m[0])
+		issuer := normalizeIssuer(nearest(data, issuers, m[0]))
+		candidates = append(candidates, candidate{
+			source:   sourceOIDCConfig,
+			issuer:   issuer,
+			realm:    keycloakRealm(issuer),
+			clientID: clientID,
+			secret:   data[m[2]:m[3]],
+		})
+	}
+	return candidates
+}
+
+// nearest 返回 matches 中离 pos 最近的匹配的第一个分组
+func nearest(data string, matches [][]int, pos int) string {
+	best, bestDist := -1, 0
+	for i, m := range matches {
+		dist := m[0] - pos
+		if dist < 0 {
+			dist = -dist
+		}
+		if best < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return data[matches[best][2]:matches[best][3]]
+}
+
+func firstSubmatch(pat *regexp.Regexp, data string) string {
+	if m := pat.FindStringSubmatch(data); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// keycloakBaseURL 返回数据中的 Keycloak 服务地址, 例如 https://sso.example.com 或 https://sso.example.com/auth
+func keycloakBaseURL(data string) string {
+	if base := firstSubmatch(frontendURLPat, data); base != "" {
+		return strings.TrimSuffix(base, "/")
+	}
+	return firstSubmatch(realmURLPat, data)
+}
+
+// keycloakRealm 返回 Keycloak issuer 中的 realm
+func keycloakRealm(issuer string) string {
+	if _, realm, ok := strings.Cut(issuer, "/realms/"); ok {
+		realm, _, _ = strings.Cut(realm, "/")
+		return realm
+	}
+	return ""
+}
+
+func normalizeIssuer(issuer string) string {
+	issuer = strings.TrimSuffix(issuer, "/.well-known/openid-configuration")
+	return strings.TrimSuffix(issuer, "/")
+}
+
+func isPlaceholder(secret string) bool {
+	if strings.Trim(secret, "*xX") == "" || strings.ContainsAny(secret[:1], "$<%{") {
+		return true
+	}
+	_, ok := placeholders[strings.ToLower(secret)]
+	return ok
+}
+
+// tokenResponse 是 token 端点的成功或错误响应
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Scope            string `json:"scope"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// verifyClient 通过 issuer 的 discovery 文档找到 token 端点, 再以 client_credentials 授权换取 token.
+// 客户端认证通过但不允许 client_credentials 授权时 (unauthorized_client), 凭据同样有效.
+func verifyClient(ctx context.Context, client *http.Client, issuer, clientID, secret string) (bool, map[string]string, error) {
+	tokenEndpoint, err := discoverTokenEndpoint(ctx, client, issuer)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// 先用 client_secret_basic, 客户端只接受 client_secret_post 时再重试
+	status, token, err := requestToken(ctx, client, tokenEndpoint, clientID, secret, true)
+	if err == nil && token.Error == "invalid_client" {
+		status, token, err = requestToken(ctx, client, tokenEndpoint, clientID, secret, false)
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	extraData := map[string]string{"token_endpoint": tokenEndpoint}
+	switch {
+	case status == http.StatusOK && token.AccessToken != "":
+		scope := token.Scope
+		if scope == "" {
+			scope = jwtScope(token.AccessToken)
+		}
+		if scope != "" {
+			extraData["scopes"] = strings.Join(strings.Fields(scope), ", ")
+		}
+		return true, extraData, nil
+	case token.Error == "unauthorized_client":
+		extraData["client_credentials_grant"] = "disabled"
+		return true, extraData, nil
+	case token.Error == "invalid_client" || status == http.StatusUnauthorized:
+		// The secret is determinately not verified (nothing to do)
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d %s", status, token.Error)
+	}
+}
+
+func discoverTokenEndpoint(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected HTTP response status %d for the discovery document", res.StatusCode)
+	}
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(discovery.TokenEndpoint, "https://") && !strings.HasPrefix(discovery.TokenEndpoint, "http://") {
+		return "", fmt.Errorf("invalid token endpoint %q", discovery.TokenEndpoint)
+	}
+	return discovery.TokenEndpoint, nil
+}
+
+func requestToken(ctx context.Context, client *http.Client, tokenEndpoint, clientID, secret string, basic bool) (int, tokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if !basic {
+		form.Set("client_id", clientID)
+		form.Set("client_secret", secret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, tokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basic {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(secret))
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, tokenResponse{}, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	var token tokenResponse
+	// 错误响应不一定是 JSON, 此时只看状态码
+	_ = json.NewDecoder(res.Body).Decode(&token)
+	return res.StatusCode, token, nil
+}
+
+// jwtScope 返回 JWT 格式的 access token 中的 scope 声明, 如 Keycloak 签发的 token
+func jwtScope(accessToken string) string {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Scope string `json:"scope"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Scope
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_OIDCClientSecret
+}
+
+func (s Scanner) Description() string {
+	return "OIDC client secrets, such as those of Keycloak clients, authenticate confidential clients to the token endpoint of an identity provider. With the client_credentials grant, they obtain access tokens for the service account of the client."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagIdentity} }
@@ -0,0 +1,229 @@
+package oidcclientsecret
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const validSecret = "Qm4tVq8ZcR2nWx7pLk5sJd3hYf9gTb6e"
+
+var (
+	realmExport = `{
+  "id": "acme",
+  "realm": "acme",
+  "attributes": {
+    "frontendUrl": "https://sso.acme.io/auth"
+  },
+  "clients": [
+    {
+      "id": "0e2f9c6a-9d0b-4c44-b7a1-4c9a1d1e7f10",
+      "clientId": "billing-service",
+      "enabled": true,
+      "clientAuthenticatorType": "client-secret",
+      "secret": "` + validSecret + `",
+      "redirectUris": ["https://billing.acme.io/*"],
+      "serviceAccountsEnabled": true
+    },
+    {
+      "id": "6f0c1b8e-37a5-4d8e-9d55-0a3d0b7c3e21",
+      "clientId": "admin-cli",
+      "secret": "**********",
+      "publicClient": true
+    }
+  ]
+}`
+	adapterConfig = `{
+  "realm": "acme",
+  "auth-server-url": "https://sso.acme.io/auth/",
+  "ssl-required": "external",
+  "resource": "orders-api",
+  "credentials": {
+    "secret": "9f1c3b7e-52d4-4a6e-8b0f-2c7d5e1a9b34"
+  }
+}`
+	springConfig = `spring:
+  security:
+    oauth2:
+      client:
+        registration:
+          keycloak:
+            client-id: reporting
+            client-secret: Tr8xKp2mVb6nQz4wLs9dHf3j
+            authorization-grant-type: client_credentials
+        provider:
+          keycloak:
+            issuer-uri: https://sso.acme.io/realms/acme/
+`
+	envConfig = `OIDC_ISSUER=https://login.example.com
+OIDC_CLIENT_ID=0oa1b2c3d4e5f6g7h8i9
+OIDC_CLIENT_SECRET=${OIDC_CLIENT_SECRET}
+`
+)
+
+func TestOIDCClientSecret_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name:  "keycloak realm export",
+			input: realmExport,
+			want: []map[string]string{{
+				"source":    sourceRealmExport,
+				"client_id": "billing-service",
+				"issuer":    "https://sso.acme.io/auth/realms/acme",
+				"realm":     "acme",
+			}},
+		},
+		{
+			name:  "realm export without server URL",
+			input: strings.Replace(realmExport, `"frontendUrl"`, `"displayName"`, 1),
+			want: []map[string]string{{
+				"source":    sourceRealmExport,
+				"client_id": "billing-service",
+				"realm":     "acme",
+			}},
+		},
+		{
+			name:  "keycloak adapter config",
+			input: adapterConfig,
+			want: []map[string]string{{
+				"source":    sourceAdapterConfig,
+				"client_id": "orders-api",
+				"issuer":    "https://sso.acme.io/auth/realms/acme",
+				"realm":     "acme",
+			}},
+		},
+		{
+			name:  "spring oauth2 client",
+			input: springConfig,
+			want: []map[string]string{{
+				"source":    sourceOIDCConfig,
+				"client_id": "reporting",
+				"issuer":    "https://sso.acme.io/realms/acme",
+				"realm":     "acme",
+			}},
+		},
+		{
+			name:  "placeholder secret",
+			input: envConfig,
+			want:  nil,
+		},
+		{
+			name:  "client secret without issuer",
+			input: "CLIENT_ID=reporting\nCLIENT_SECRET=Tr8xKp2mVb6nQz4wLs9dHf3j\n",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []map[string]string
+			for _, r := range results {
+				got = append(got, r.ExtraData)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestOIDCClientSecret_Verify(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"scope":"profile billing:read"}`))
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/acme/.well-known/openid-configuration":
+			fmt.Fprintf(w, `{"issuer":"%[1]s/realms/acme","token_endpoint":"%[1]s/realms/acme/protocol/openid-connect/token"}`, server.URL)
+		case "/realms/acme/protocol/openid-connect/token":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			clientID, secret, _ := r.BasicAuth()
+			switch {
+			case clientID == "billing-service" && secret == validSecret:
+				fmt.Fprintf(w, `{"access_token":"eyJhbGciOiJSUzI1NiJ9.%s.c2ln","token_type":"Bearer"}`, claims)
+			case clientID == "frontend" && secret == validSecret:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"unauthorized_client","error_description":"Client not enabled to retrieve service account"}`)
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_client","error_description":"Invalid client or Invalid client credentials"}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client()}
+	export := strings.Replace(realmExport, "https://sso.acme.io/auth", server.URL, 1)
+
+	tests := []struct {
+		name      string
+		input     string
+		verified  bool
+		extraData map[string]string
+	}{
+		{
+			name:     "verified with scopes",
+			input:    export,
+			verified: true,
+			extraData: map[string]string{
+				"scopes":         "profile, billing:read",
+				"token_endpoint": server.URL + "/realms/acme/protocol/openid-connect/token",
+			},
+		},
+		{
+			name:     "verified without client_credentials grant",
+			input:    strings.Replace(export, "billing-service", "frontend", 1),
+			verified: true,
+			extraData: map[string]string{
+				"client_credentials_grant": "disabled",
+			},
+		},
+		{
+			name:     "invalid secret",
+			input:    strings.Replace(export, validSecret, "Zm4tVq8ZcR2nWx7pLk5sJd3hYf9gTb6e", 1),
+			verified: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := d.FromData(context.Background(), true, []byte(test.input))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, test.verified, results[0].Verified)
+			assert.NoError(t, results[0].VerificationError())
+			for k, v := range test.extraData {
+				assert.Equal(t, v, results[0].ExtraData[k], k)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ type Scanner struct {
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.ContextHintProvider = (*Scanner)(nil)
 
 var (
 	defaultClient = common.SaneHttpClient()
@@ -40,6 +41,11 @@ func (s Scanner) Keywords() []string {
 	return []string{"T3BlbkFJ"}
 }
 
+// ContextHints implements detectors.ContextHintProvider, sk- 前缀也被其他 AI 厂商使用
+func (s Scanner) ContextHints() []string {
+	return []string{"openai", "api.openai.com", "chatgpt"}
+}
+
 // FromData will find and optionally verify OpenAI secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -49,7 +55,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		uniqueMatches[match[1]] = struct{}{}
 	}
 
-	for token := range uniqueMatches {
+	for _, token := range detectors.SortedKeys(uniqueMatches) {
 		s1 := detectors.Result{
 			DetectorType: detector_typepb.DetectorType_OpenAI,
 			Redacted:     token[:3] + "..." + token[min(len(token)-1, 47):],
@@ -67,6 +73,12 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			s1.ExtraData = extraData
 			s1.SetVerificationError(verificationErr)
 			s1.AnalysisInfo = map[string]string{"key": token}
+			// Attribute the key to its organization and project so analyzers can enumerate the right scopes.
+			for _, k := range []string{"org_id", "project_id", "is_admin"} {
+				if v, ok := extraData[k]; ok {
+					s1.AnalysisInfo[k] = v
+				}
+			}
 		}
 
 		results = append(results, s1)
@@ -111,6 +123,17 @@ func verifyToken(ctx context.Context, client *http.Client, token string) (bool,
 			extraData["is_personal"] = strconv.FormatBool(resData.Orgs.Data[0].Personal)
 			extraData["is_default"] = strconv.FormatBool(resData.Orgs.Data[0].IsDefault)
 		}
+		if org, ok := defaultOrg(resData.Orgs.Data); ok {
+			extraData["org_id"] = org.ID
+			extraData["org_name"] = org.Title
+			extraData["org_role"] = org.Role
+			// Owners can manage members, billing and admin keys of the organization.
+			extraData["is_admin"] = strconv.FormatBool(org.Role == "owner")
+		}
+		// Project keys are bound to a single project, which the API reports in a response header.
+		if project := res.Header.Get("openai-project"); project != "" {
+			extraData["project_id"] = project
+		}
 		return true, extraData, nil
 	case 401:
 		// Invalid
@@ -120,6 +143,19 @@ func verifyToken(ctx context.Context, client *http.Client, token string) (bool,
 	}
 }
 
+// defaultOrg returns the organization requests made with the key are billed to.
+func defaultOrg(orgs []data) (data, bool) {
+	for _, org := range orgs {
+		if org.IsDefault {
+			return org, true
+		}
+	}
+	if len(orgs) > 0 {
+		return orgs[0], true
+	}
+	return data{}, false
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_OpenAI
 }
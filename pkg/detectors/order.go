@@ -0,0 +1,13 @@
+package detectors
+
+import (
+	"maps"
+	"slices"
+)
+
+// SortedKeys returns the keys of a map of candidate secrets in ascending order. Detectors that deduplicate their
+// candidates in a map iterate them in this order, so that they return results in the same order on every run
+// rather than in Go's randomized map order, which makes test expectations and report baselines flaky.
+func SortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,12 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedKeys(t *testing.T) {
+	assert.Empty(t, SortedKeys(map[string]struct{}{}))
+	assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(map[string]int{"c": 1, "a": 2, "b": 3}))
+}
@@ -0,0 +1,67 @@
+package detectors
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// OwnedWallets recognizes leaked keys that control one of the organization's own wallets, as opposed to random keys
+// found in the wild. Detectors that derive an address from a key during verification report it in
+// ExtraData["address"], which is compared against the list. A nil *OwnedWallets matches nothing.
+type OwnedWallets struct {
+	// addresses maps lowercase addresses to their optional description.
+	addresses map[string]string
+}
+
+// ReadOwnedWallets parses a list of owned wallet addresses. Each line holds an address, optionally followed by
+// whitespace and a description. Blank lines and lines starting with '#' are ignored.
+func ReadOwnedWallets(r io.Reader) (*OwnedWallets, error) {
+	wallets := &OwnedWallets{addresses: make(map[string]string)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		address, description, _ := strings.Cut(line, " ")
+		wallets.addresses[strings.ToLower(address)] = strings.TrimSpace(description)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+// Check reports whether the result's derived address is an owned wallet, and its description.
+func (w *OwnedWallets) Check(res *Result) (string, bool) {
+	if w == nil {
+		return "", false
+	}
+	address := strings.ToLower(res.ExtraData["address"])
+	if address == "" {
+		return "", false
+	}
+	description, ok := w.addresses[address]
+	return description, ok
+}
+
+// Tag marks the result as controlling an owned wallet and raises its severity to critical, overriding a canary tag.
+// It returns whether the result was tagged.
+func (w *OwnedWallets) Tag(res *Result) bool {
+	description, ok := w.Check(res)
+	if !ok {
+		return false
+	}
+	res.CloneExtraData()
+	// The organization's own wallet is not bait, even if it looks like it.
+	delete(res.ExtraData, "suspected_canary")
+	delete(res.ExtraData, "canary_reason")
+	res.ExtraData["owned_asset"] = "true"
+	if description != "" {
+		res.ExtraData["owned_asset_label"] = description
+	}
+	res.Severity = SeverityCritical
+	return true
+}
@@ -0,0 +1,54 @@
+package detectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnedWallets_Tag(t *testing.T) {
+	input := `
+# Production wallets.
+0x70997970C51812dc3A010C7d01b50e0d17dc79C8 treasury
+1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH
+`
+	wallets, err := ReadOwnedWallets(strings.NewReader(input))
+	require.NoError(t, err)
+
+	res := Result{
+		Raw:      []byte("secret"),
+		Severity: SeverityLow,
+		ExtraData: map[string]string{
+			"address":          "0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+			"suspected_canary": "true",
+			"canary_reason":    "empty wallet that only ever received dust",
+		},
+	}
+	extraData := res.ExtraData
+	assert.True(t, wallets.Tag(&res))
+	assert.Equal(t, SeverityCritical, res.Severity)
+	assert.Equal(t, map[string]string{
+		"address":           "0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+		"owned_asset":       "true",
+		"owned_asset_label": "treasury",
+	}, res.ExtraData)
+	assert.Equal(t, "true", extraData["suspected_canary"], "the original map must not be modified")
+
+	res = Result{Raw: []byte("secret"), ExtraData: map[string]string{"address": "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"}}
+	assert.True(t, wallets.Tag(&res))
+	assert.Equal(t, map[string]string{"address": "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", "owned_asset": "true"}, res.ExtraData)
+
+	for _, res := range []Result{
+		{Raw: []byte("secret")},
+		{Raw: []byte("secret"), ExtraData: map[string]string{"address": "0x3c44cdddb6a900fa2b585dd299e03d12fa4293bc"}},
+	} {
+		assert.False(t, wallets.Tag(&res))
+		assert.Equal(t, SeverityUnspecified, res.Severity)
+	}
+
+	var none *OwnedWallets
+	res = Result{ExtraData: map[string]string{"address": "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"}}
+	assert.False(t, none.Tag(&res))
+}
@@ -0,0 +1,140 @@
+package paddle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Paddle Billing API key: pdl_<live|sdbx>_apikey_<ulid>_<secret>_<checksum>
+	keyPat = regexp.MustCompile(`\b(pdl_(live|sdbx)_apikey_[0-9a-z]{26}_[A-Za-z0-9]{22}_[A-Za-z0-9]{3})\b`)
+	// 旧版 API key 为 50 位十六进制, 没有前缀, 需要 paddle 上下文
+	legacyKeyPat = regexp.MustCompile(`(?i)paddle[\w.-]{0,20}(?:api[_-]?key|secret|token)["'\s:=]+["']?([a-f0-9]{50})\b`)
+)
+
+// environments 是 Paddle Billing 的正式环境和沙箱环境
+var environments = map[string]string{
+	"live": "https://api.paddle.com",
+	"sdbx": "https://sandbox-api.paddle.com",
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"pdl_", "paddle"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Paddle API keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// key -> 所属环境, 旧版 key 无法从格式判断环境
+	uniqueKeys := make(map[string]string)
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = match[2]
+	}
+	for _, match := range legacyKeyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = ""
+	}
+
+	for _, key := range detectors.SortedKeys(uniqueKeys) {
+		env := uniqueKeys[key]
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Paddle,
+			Raw:          []byte(key),
+			ExtraData:    map[string]string{},
+			Severity:     detectors.SeverityCritical,
+		}
+		if env != "" {
+			s1.ExtraData["environment"] = env
+		}
+		if env == "sdbx" {
+			// 沙箱环境不涉及真实资金
+			s1.Severity = detectors.SeverityLow
+		}
+
+		if verify {
+			candidates := []string{env}
+			if env == "" {
+				candidates = []string{"live", "sdbx"}
+			}
+			for _, e := range candidates {
+				isVerified, verificationErr := verifyKey(ctx, s.getClient(), environments[e], key)
+				s1.Verified = isVerified
+				s1.SetVerificationError(verificationErr, key)
+				if isVerified {
+					s1.ExtraData["environment"] = e
+					if e == "sdbx" {
+						s1.Severity = detectors.SeverityLow
+					}
+					break
+				}
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// verifyKey 列出事件类型, 任何有效的 API key 都可以调用该接口
+// docs: https://developer.paddle.com/api-reference/event-types/list-event-types
+func verifyKey(ctx context.Context, client *http.Client, baseURL, key string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/event-types", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusForbidden:
+		// 403 表示 key 有效但没有该接口的权限
+		return true, nil
+	case http.StatusUnauthorized:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Paddle
+}
+
+func (s Scanner) Description() string {
+	return "Paddle is a payment platform and merchant of record for software companies. Paddle API keys can be used to read customers and transactions, issue refunds and manage subscriptions."
+}
@@ -0,0 +1,98 @@
+package paddle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+var (
+	liveKey    = "pdl_live_apikey_01gtgztp8f4kek3yd4g1wrksa3_q6TGTJyvoIz7LDtXT65bX7_AQO"
+	sandboxKey = "pdl_sdbx_apikey_01j8r4mq5v2bnx7c3d9ehtkw6p_Zr4LmQ8vN2xT7bC1kD5hJ9_X2a"
+	legacyKey  = "3f9c1b7e5a2d8f4c6b0e9a1d3c5f7b2e4a6c8d0f1e3b5a7c9d"
+)
+
+func TestPaddle_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - live key",
+			input: "PADDLE_API_KEY=" + liveKey,
+			want:  []string{liveKey},
+		},
+		{
+			name:  "valid pattern - sandbox key",
+			input: "apiKey: '" + sandboxKey + "'",
+			want:  []string{sandboxKey},
+		},
+		{
+			name:  "valid pattern - legacy key with context",
+			input: "paddle_api_key = \"" + legacyKey + "\"",
+			want:  []string{legacyKey},
+		},
+		{
+			name:  "invalid pattern - legacy key without key name",
+			input: "paddle vendor checksum " + legacyKey,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestPaddle_FromData_Order(t *testing.T) {
+	input := "PADDLE_API_KEY=" + sandboxKey + "\nPADDLE_LIVE_KEY=" + liveKey + "\npaddle_api_key = \"" + legacyKey + "\""
+
+	// 多次运行结果顺序必须一致, 不能依赖 map 的遍历顺序
+	for i := 0; i < 20; i++ {
+		results, err := Scanner{}.FromData(context.Background(), false, []byte(input))
+		require.NoError(t, err)
+		got := make([]string, 0, len(results))
+		for _, r := range results {
+			got = append(got, string(r.Raw))
+		}
+		if diff := cmp.Diff([]string{legacyKey, liveKey, sandboxKey}, got); diff != "" {
+			t.Fatalf("run %d: result order diff: (-want +got)\n%s", i, diff)
+		}
+	}
+}
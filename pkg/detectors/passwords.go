@@ -0,0 +1,132 @@
+package detectors
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// PasswordDecrypter is an optional interface that a detector can implement if the secret material it finds is
+// encrypted with a password, such as keystores, wallet vaults, PGP keys or PKCS#12 bundles. The engine collects
+// candidate passwords from the rest of the source unit and offers them to the detector for its unverified results.
+type PasswordDecrypter interface {
+	// TryDecrypt tries to decrypt the material behind result, which was found in data, with each of the candidate
+	// passwords. On success it updates result (typically setting Verified) and returns true.
+	TryDecrypt(ctx context.Context, data []byte, result *Result, candidates []string) bool
+}
+
+var (
+	// passwordFieldPat matches config fields whose name contains a password-like word, e.g. db_password: "..." or
+	// "keystorePassphrase": "...", including the Chinese 密码 and 口令 with full-width punctuation. Quotes may be
+	// escaped when the config is embedded in a JSON string.
+	passwordFieldPat = regexp.MustCompile(`(?i)(?:password|passwd|pwd|passphrase|密码|口令)[a-z_]*\\?["'”]?[\s\x{3000}]*[:=：＝]\s*\\?["'“「]?([^\s"'\\,;“”「」，；]{6,64})`)
+	// envValuePat matches KEY=value assignments in .env files.
+	envValuePat = regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?[A-Za-z_][A-Za-z0-9_.]*[ \t]*=[ \t]*["']?([^\s"'#]{6,64})["']?[ \t]*(?:#.*)?$`)
+)
+
+const (
+	// maxPasswordsPerUnit bounds the candidates kept for a single source unit. Decryption attempts are usually
+	// expensive (PBKDF2, scrypt), so there is no point in keeping more than a handful.
+	maxPasswordsPerUnit = 32
+	// defaultPasswordUnits is the number of source units whose candidates are kept in memory at the same time.
+	defaultPasswordUnits = 1024
+)
+
+// CollectPasswords returns the candidate passwords found in data, in order of appearance and without duplicates.
+// Values of password-like config fields are always collected. If file is a .env file, every assigned value is
+// collected as well, and if it is a vault password file, its first line.
+func CollectPasswords(file string, data []byte) []string {
+	var passwords []string
+	seen := make(map[string]struct{})
+	add := func(matches [][][]byte) {
+		for _, m := range matches {
+			password := string(m[1])
+			if _, ok := seen[password]; ok {
+				continue
+			}
+			seen[password] = struct{}{}
+			passwords = append(passwords, password)
+		}
+	}
+
+	add(passwordFieldPat.FindAllSubmatch(data, -1))
+	if isEnvFile(file) {
+		add(envValuePat.FindAllSubmatch(data, -1))
+	}
+	if isVaultPasswordFile(file) {
+		line, _, _ := strings.Cut(string(data), "\n")
+		if line = strings.TrimSpace(line); line != "" {
+			add([][][]byte{{nil, []byte(line)}})
+		}
+	}
+	return passwords
+}
+
+// isEnvFile reports whether file looks like a dotenv file: .env, .env.local, production.env, ...
+func isEnvFile(file string) bool {
+	base := strings.ToLower(filepath.Base(file))
+	return strings.HasPrefix(base, ".env") || strings.HasSuffix(base, ".env")
+}
+
+// isVaultPasswordFile reports whether file looks like an Ansible vault password file, which holds nothing but the
+// password: .vault_pass, .vault_pass.txt, vault-password.txt, ...
+func isVaultPasswordFile(file string) bool {
+	base := strings.TrimPrefix(strings.ToLower(filepath.Base(file)), ".")
+	return strings.HasPrefix(base, "vault_pass") || strings.HasPrefix(base, "vault-pass")
+}
+
+// PasswordPool collects candidate passwords per source unit (e.g. a repository or a scanned directory), so that
+// encrypted material found in one file can be tried with passwords found in another. It is safe for concurrent use.
+type PasswordPool struct {
+	mu    sync.Mutex
+	units *lru.Cache[string, []string]
+}
+
+// NewPasswordPool creates a PasswordPool that keeps the candidates of the most recently seen source units.
+func NewPasswordPool() *PasswordPool {
+	units, _ := lru.New[string, []string](defaultPasswordUnits)
+	return &PasswordPool{units: units}
+}
+
+// Add collects the candidate passwords in data, found in file, for the given source unit.
+func (p *PasswordPool) Add(unit, file string, data []byte) {
+	if p == nil {
+		return
+	}
+	passwords := CollectPasswords(file, data)
+	if len(passwords) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	existing, _ := p.units.Get(unit)
+	if len(existing) >= maxPasswordsPerUnit {
+		return
+	}
+	merged := append([]string(nil), existing...)
+	for _, password := range passwords {
+		if len(merged) >= maxPasswordsPerUnit {
+			break
+		}
+		if !slices.Contains(merged, password) {
+			merged = append(merged, password)
+		}
+	}
+	p.units.Add(unit, merged)
+}
+
+// Candidates returns the candidate passwords collected so far for the given source unit.
+func (p *PasswordPool) Candidates(unit string) []string {
+	if p == nil {
+		return nil
+	}
+	passwords, _ := p.units.Get(unit)
+	return passwords
+}
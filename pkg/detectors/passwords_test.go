@@ -0,0 +1,88 @@
+package detectors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectPasswords(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []string
+	}{
+		{
+			name: "password fields",
+			file: "config/database.yml",
+			data: "username: admin\npassword: s3cr3t-pass\nkeystore_passphrase = \"hunter22\"\n",
+			want: []string{"s3cr3t-pass", "hunter22"},
+		},
+		{
+			name: "escaped json",
+			file: "state.json",
+			data: `{"config":"{\"walletPassword\":\"correct-horse\"}"}`,
+			want: []string{"correct-horse"},
+		},
+		{
+			name: "chinese labels",
+			file: "部署说明.md",
+			data: "数据库密码：“s3cr3t-pass”\n钱包口令 ＝ hunter22，请勿外传\n",
+			want: []string{"s3cr3t-pass", "hunter22"},
+		},
+		{
+			name: "env values",
+			file: "app/.env.production",
+			data: "# comment\nexport WALLET_SECRET=correct-horse\nAPP_NAME=\"my-app-name\"\nDEBUG=1\nDB_PASSWORD=correct-horse\n",
+			want: []string{"correct-horse", "my-app-name"},
+		},
+		{
+			name: "env values ignored outside env files",
+			file: "scripts/run.sh",
+			data: "WALLET_SECRET=correct-horse\n",
+			want: nil,
+		},
+		{
+			name: "vault password file",
+			file: "ansible/.vault_pass.txt",
+			data: "correct-horse-battery\n",
+			want: []string{"correct-horse-battery"},
+		},
+		{
+			name: "too short",
+			file: "config.ini",
+			data: "password=abc\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CollectPasswords(tt.file, []byte(tt.data)))
+		})
+	}
+}
+
+func TestPasswordPool(t *testing.T) {
+	pool := NewPasswordPool()
+
+	pool.Add("repo-a", ".env", []byte("SECRET=first-password\n"))
+	pool.Add("repo-a", "config.yml", []byte("password: second-password\n"))
+	pool.Add("repo-a", "other.yml", []byte("password: first-password\n"))
+	pool.Add("repo-b", "config.yml", []byte("password: other-password\n"))
+
+	assert.Equal(t, []string{"first-password", "second-password"}, pool.Candidates("repo-a"))
+	assert.Equal(t, []string{"other-password"}, pool.Candidates("repo-b"))
+	assert.Nil(t, pool.Candidates("repo-c"))
+
+	for i := range maxPasswordsPerUnit + 10 {
+		pool.Add("repo-c", "config.yml", []byte(fmt.Sprintf("password: password-%d\n", i)))
+	}
+	assert.Len(t, pool.Candidates("repo-c"), maxPasswordsPerUnit)
+
+	var nilPool *PasswordPool
+	nilPool.Add("repo-a", ".env", []byte("SECRET=first-password\n"))
+	assert.Nil(t, nilPool.Candidates("repo-a"))
+}
@@ -2,8 +2,12 @@ package planetscale
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -71,6 +75,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 							"id":    username,
 							"token": password,
 						}
+						s1.ExtraData = listDatabases(ctx, client, credentials, res.Body)
 					} else if res.StatusCode == 401 {
 						// The secret is determinately not verified
 						s1.Verified = false
@@ -90,6 +95,52 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+type namedList struct {
+	Data []struct {
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
+// listDatabases reports the organizations the token can access, read from the body of the organizations response,
+// and the databases in each of them. Errors are ignored, as the token has already been verified.
+func listDatabases(ctx context.Context, client *http.Client, credentials string, orgsBody io.Reader) map[string]string {
+	var orgs namedList
+	if err := json.NewDecoder(orgsBody).Decode(&orgs); err != nil || len(orgs.Data) == 0 {
+		return nil
+	}
+
+	var orgNames, databases []string
+	for _, org := range orgs.Data {
+		orgNames = append(orgNames, org.Name)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "https://api.planetscale.com/v1/organizations/"+url.PathEscape(org.Name)+"/databases", nil)
+		if err != nil {
+			continue
+		}
+		req.Header.Set("Authorization", credentials)
+		req.Header.Set("accept", "application/json")
+		res, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		var dbs namedList
+		if res.StatusCode == http.StatusOK {
+			_ = json.NewDecoder(res.Body).Decode(&dbs)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		for _, db := range dbs.Data {
+			databases = append(databases, org.Name+"/"+db.Name)
+		}
+	}
+
+	extraData := map[string]string{"organizations": strings.Join(orgNames, ",")}
+	if len(databases) > 0 {
+		extraData["databases"] = strings.Join(databases, ",")
+	}
+	return extraData
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_PlanetScale
 }
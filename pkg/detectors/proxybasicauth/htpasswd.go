@@ -0,0 +1,78 @@
+package proxybasicauth
+
+import (
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+)
+
+// 哈希强度, 从易到难破解
+const (
+	// strengthBroken 的哈希可以直接查表或在几分钟内暴力破解: DES crypt 只取密码的前 8 个字符, {SHA} 不加盐
+	strengthBroken = "broken"
+	// strengthWeak 的哈希加了盐但迭代次数固定且很少, GPU 每秒可以尝试数十亿次
+	strengthWeak = "weak"
+	// strengthModerate 是 cost 低于 10 的 bcrypt, htpasswd -B 默认的 cost 为 5
+	strengthModerate = "moderate"
+	strengthStrong   = "strong"
+)
+
+// bcrypt 建议的最低 cost
+const minBcryptCost = 10
+
+var (
+	apr1Pat   = regexp.MustCompile(`^\$apr1\$[./0-9A-Za-z]{0,8}\$[./0-9A-Za-z]{22}$`)
+	md5Pat    = regexp.MustCompile(`^\$1\$[./0-9A-Za-z]{0,8}\$[./0-9A-Za-z]{22}$`)
+	bcryptPat = regexp.MustCompile(`^\$2[aby]\$([0-9]{2})\$[./0-9A-Za-z]{53}$`)
+	sha2Pat   = regexp.MustCompile(`^\$([56])\$(?:rounds=[0-9]+\$)?[./0-9A-Za-z]{0,16}\$([./0-9A-Za-z]{43}|[./0-9A-Za-z]{86})$`)
+	sha1Pat   = regexp.MustCompile(`^\{SHA\}[A-Za-z0-9+/]{27}=$`)
+	desPat    = regexp.MustCompile(`^[./0-9A-Za-z]{13}$`)
+)
+
+// hashScheme 是 htpasswd 条目或 haproxy userlist 密码的哈希算法
+type hashScheme struct {
+	name     string
+	strength string
+	// cost 是 bcrypt 的 cost, 其他算法为 0
+	cost int
+}
+
+// classifyHash 识别 htpasswd (Apache, nginx) 和 crypt(3) (haproxy) 支持的哈希格式
+func classifyHash(hash string) (hashScheme, bool) {
+	switch {
+	case apr1Pat.MatchString(hash):
+		return hashScheme{name: "apr1-md5", strength: strengthWeak}, true
+	case md5Pat.MatchString(hash):
+		return hashScheme{name: "md5-crypt", strength: strengthWeak}, true
+	case sha1Pat.MatchString(hash):
+		return hashScheme{name: "sha1", strength: strengthBroken}, true
+	case desPat.MatchString(hash):
+		return hashScheme{name: "des-crypt", strength: strengthBroken}, true
+	}
+	if m := bcryptPat.FindStringSubmatch(hash); m != nil {
+		cost, _ := strconv.Atoi(m[1])
+		scheme := hashScheme{name: "bcrypt", strength: strengthStrong, cost: cost}
+		if cost < minBcryptCost {
+			scheme.strength = strengthModerate
+		}
+		return scheme, true
+	}
+	if m := sha2Pat.FindStringSubmatch(hash); m != nil {
+		// $5$ 的哈希为 43 个字符, $6$ 为 86 个字符
+		if (m[1] == "5") != (len(m[2]) == 43) {
+			return hashScheme{}, false
+		}
+		name := "sha256-crypt"
+		if m[1] == "6" {
+			name = "sha512-crypt"
+		}
+		return hashScheme{name: name, strength: strengthStrong}, true
+	}
+	return hashScheme{}, false
+}
+
+// isHtpasswd 判断 chunk 是否像 htpasswd 文件或生成它的脚本, DES crypt 的哈希只是 13 个字母数字, 只在这种情况下报告
+func isHtpasswd(data string, entries int) bool {
+	return entries > 0 || strings.Contains(strings.ToLower(data), "htpasswd")
+}
@@ -0,0 +1,350 @@
+package proxybasicauth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+const (
+	credentialHtpasswd         = "htpasswd"
+	credentialProxyHeader      = "proxy_authorization"
+	credentialHaproxyPassword  = "haproxy_password"
+	credentialHaproxyInsecure  = "haproxy_insecure_password"
+	credentialHaproxyStatsAuth = "haproxy_stats_auth"
+)
+
+const (
+	// maxUpstreamDistance 是认证头与 upstream 指令之间的最大距离, 超出时不关联
+	maxUpstreamDistance = 4096
+	maxBodyBytes        = 1 << 16
+)
+
+var (
+	// upstream 通常是内网服务, 与 URI 检测器一样不连接本地和内网地址
+	defaultClient = detectors.DetectorHttpClientWithNoLocalAddresses
+
+	// htpasswd 条目 user:hash, 也可能在 echo 'admin:$apr1$...' >> .htpasswd 这样的脚本中; 结尾不允许 ':' 以排除 /etc/shadow
+	htpasswdPat = regexp.MustCompile(`(?m)(?:^|[\s"'])([A-Za-z0-9_.@-]{1,64}):(\$apr1\$[./0-9A-Za-z]{0,8}\$[./0-9A-Za-z]{22}|\$1\$[./0-9A-Za-z]{0,8}\$[./0-9A-Za-z]{22}|\$2[aby]\$[0-9]{2}\$[./0-9A-Za-z]{53}|\$[56]\$(?:rounds=[0-9]+\$)?[./0-9A-Za-z]{0,16}\$[./0-9A-Za-z]{43,86}|\{SHA\}[A-Za-z0-9+/]{27}=)(?:[\s"']|$)`)
+	desEntryPat = regexp.MustCompile(`(?m)^[ \t]*([A-Za-z0-9_.@-]{1,64}):([./0-9A-Za-z]{13})[ \t]*$`)
+
+	// nginx proxy_set_header, Apache RequestHeader 和 haproxy http-request set-header 设置的 Basic 认证头
+	authHeaderPat = regexp.MustCompile(`(?i)(proxy_set_header|RequestHeader[ \t]+(?:set|append|add|merge)|http-request[ \t]+(?:set|add)-header)[ \t]+["']?Authorization["']?[ \t]+["']?Basic[ \t]+([A-Za-z0-9+/]{4,512}={0,2})`)
+
+	// 认证头发往的 upstream: nginx proxy_pass (可以是 upstream 块的名字), Apache ProxyPass 和 haproxy backend 的 server
+	proxyPassPat       = regexp.MustCompile(`(?m)^[ \t]*proxy_pass[ \t]+(https?)://([^\s;/$]+)([^\s;]*)[ \t]*;`)
+	apacheProxyPassPat = regexp.MustCompile(`(?mi)^[ \t]*ProxyPass(?:Match)?[ \t]+\S+[ \t]+(https?)://([^\s/"]+)([^\s"]*)`)
+	haproxyServerPat   = regexp.MustCompile(`(?m)^[ \t]*server[ \t]+\S+[ \t]+([A-Za-z0-9.-]+:[0-9]{1,5})\b`)
+	upstreamBlockPat   = regexp.MustCompile(`(?s)\bupstream[ \t]+([\w.-]+)[ \t]*\{(.*?)\}`)
+	upstreamServerPat  = regexp.MustCompile(`(?m)^[ \t]*server[ \t]+([A-Za-z0-9.-]+(?::[0-9]{1,5})?)[\s;]`)
+
+	// haproxy userlist 中的用户, password 为 crypt(3) 哈希, insecure-password 为明文; 以及 stats 页面的 stats auth
+	userlistPat  = regexp.MustCompile(`(?m)^[ \t]*userlist[ \t]+(\S+)`)
+	userPat      = regexp.MustCompile(`(?m)^[ \t]*user[ \t]+(\S+)[ \t]+(insecure-password|password)[ \t]+(\S+)`)
+	statsAuthPat = regexp.MustCompile(`(?m)^[ \t]*stats[ \t]+auth[ \t]+([^\s:]+):(\S+)`)
+)
+
+// 文档和模板中常见的占位密码
+var placeholders = map[string]struct{}{
+	"password":      {},
+	"passwd":        {},
+	"pass":          {},
+	"secret":        {},
+	"changeme":      {},
+	"yourpassword":  {},
+	"your_password": {},
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+// sha-crypt 的 $5$ 和 $6$ 短于关键词的最小长度, 这些条目只在 chunk 因其他关键词分发时报告
+func (s Scanner) Keywords() []string {
+	return []string{
+		"htpasswd", "$apr1$", "$2y$", "$2a$", "$2b$", "{sha}",
+		"proxy_set_header", "requestheader", "set-header",
+		"userlist", "insecure-password", "stats auth",
+	}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify basic-auth credentials in reverse proxy configs and htpasswd files in a
+// given set of bytes. Authorization headers set by the proxy are decoded and attributed to the upstream they are sent
+// to, and verified when the upstream rejects requests without them and accepts requests with them. Password hashes
+// are reported with the strength of their scheme.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	seen := make(map[string]struct{})
+	isNew := func(raw string) bool {
+		if _, ok := seen[raw]; ok {
+			return false
+		}
+		seen[raw] = struct{}{}
+		return true
+	}
+
+	entries := htpasswdPat.FindAllStringSubmatch(dataStr, -1)
+	if isHtpasswd(dataStr, len(entries)) {
+		entries = append(entries, desEntryPat.FindAllStringSubmatch(dataStr, -1)...)
+	}
+	for _, match := range entries {
+		user, hash := match[1], match[2]
+		scheme, ok := classifyHash(hash)
+		if !ok || !isNew(user+":"+hash) {
+			continue
+		}
+		results = append(results, hashResult(credentialHtpasswd, user, hash, scheme))
+	}
+
+	for _, loc := range authHeaderPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		user, password, ok := decodeBasic(dataStr[loc[4]:loc[5]])
+		if !ok || !isNew(user+":"+password) {
+			continue
+		}
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_ProxyBasicAuth,
+			Raw:          []byte(user + ":" + password),
+			Redacted:     user,
+			ExtraData: map[string]string{
+				"credential_type": credentialProxyHeader,
+				"directive":       strings.Join(strings.Fields(strings.ToLower(dataStr[loc[2]:loc[3]])), " "),
+				"username":        user,
+			},
+		}
+		upstream := findUpstream(dataStr, loc[0])
+		if upstream != "" {
+			s1.RawV2 = []byte(user + ":" + password + "@" + upstream)
+			s1.ExtraData["upstream"] = upstream
+		}
+
+		if verify && upstream != "" {
+			isVerified, verificationErr := verifyUpstream(ctx, s.getClient(), upstream, dataStr[loc[4]:loc[5]])
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, password)
+		}
+		results = append(results, s1)
+	}
+
+	userlists := userlistPat.FindAllStringSubmatchIndex(dataStr, -1)
+	for _, loc := range userPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		user, kind, secret := dataStr[loc[2]:loc[3]], dataStr[loc[4]:loc[5]], dataStr[loc[6]:loc[7]]
+		var s1 detectors.Result
+		if kind == "password" {
+			scheme, ok := classifyHash(secret)
+			if !ok || !isNew(user+":"+secret) {
+				continue
+			}
+			s1 = hashResult(credentialHaproxyPassword, user, secret, scheme)
+		} else {
+			if isPlaceholder(secret) || !isNew(user+":"+secret) {
+				continue
+			}
+			s1 = plaintextResult(credentialHaproxyInsecure, user, secret)
+		}
+		// 用户属于之前最近的 userlist
+		for _, l := range userlists {
+			if l[0] < loc[0] {
+				s1.ExtraData["userlist"] = dataStr[l[2]:l[3]]
+			}
+		}
+		results = append(results, s1)
+	}
+
+	for _, match := range statsAuthPat.FindAllStringSubmatch(dataStr, -1) {
+		user, password := match[1], match[2]
+		if isPlaceholder(password) || !isNew(user+":"+password) {
+			continue
+		}
+		results = append(results, plaintextResult(credentialHaproxyStatsAuth, user, password))
+	}
+
+	return results, nil
+}
+
+// hashResult 报告密码哈希, 哈希需要离线破解, 严重程度取决于哈希算法
+func hashResult(credentialType, user, hash string, scheme hashScheme) detectors.Result {
+	s1 := detectors.Result{
+		DetectorType: detector_typepb.DetectorType_ProxyBasicAuth,
+		Raw:          []byte(user + ":" + hash),
+		Redacted:     user,
+		ExtraData: map[string]string{
+			"credential_type": credentialType,
+			"username":        user,
+			"hash_scheme":     scheme.name,
+			"hash_strength":   scheme.strength,
+		},
+	}
+	if scheme.cost > 0 {
+		s1.ExtraData["bcrypt_cost"] = strconv.Itoa(scheme.cost)
+	}
+	switch scheme.strength {
+	case strengthBroken:
+		s1.Severity = detectors.SeverityHigh
+	case strengthStrong:
+		s1.Severity = detectors.SeverityLow
+	default:
+		s1.Severity = detectors.SeverityMedium
+	}
+	return s1
+}
+
+func plaintextResult(credentialType, user, password string) detectors.Result {
+	return detectors.Result{
+		DetectorType: detector_typepb.DetectorType_ProxyBasicAuth,
+		Raw:          []byte(user + ":" + password),
+		Redacted:     user,
+		ExtraData: map[string]string{
+			"credential_type": credentialType,
+			"username":        user,
+		},
+	}
+}
+
+// decodeBasic 解码 Basic 认证头的 user:password, 只接受可打印的文本
+func decodeBasic(encoded string) (string, string, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !utf8.Valid(decoded) {
+		return "", "", false
+	}
+	user, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || password == "" || isPlaceholder(password) {
+		return "", "", false
+	}
+	for _, r := range user + password {
+		if !unicode.IsPrint(r) {
+			return "", "", false
+		}
+	}
+	return user, password, true
+}
+
+func isPlaceholder(password string) bool {
+	_, ok := placeholders[strings.ToLower(password)]
+	return ok
+}
+
+// findUpstream 返回认证头发往的 upstream URL, 即距离最近的 proxy_pass, ProxyPass 或 haproxy server.
+// proxy_pass 指向 upstream 块时使用块中的第一个 server
+func findUpstream(data string, pos int) string {
+	upstreams := make(map[string]string)
+	for _, match := range upstreamBlockPat.FindAllStringSubmatch(data, -1) {
+		if server := upstreamServerPat.FindStringSubmatch(match[2]); server != nil {
+			upstreams[match[1]] = server[1]
+		}
+	}
+
+	best, bestDistance := "", maxUpstreamDistance+1
+	consider := func(start int, u string) {
+		distance := start - pos
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < bestDistance {
+			best, bestDistance = u, distance
+		}
+	}
+	for _, pat := range []*regexp.Regexp{proxyPassPat, apacheProxyPassPat} {
+		for _, loc := range pat.FindAllStringSubmatchIndex(data, -1) {
+			scheme, host, path := data[loc[2]:loc[3]], data[loc[4]:loc[5]], data[loc[6]:loc[7]]
+			if server, ok := upstreams[host]; ok {
+				host = server
+			}
+			consider(loc[0], scheme+"://"+host+path)
+		}
+	}
+	for _, loc := range haproxyServerPat.FindAllStringSubmatchIndex(data, -1) {
+		consider(loc[0], "http://"+data[loc[2]:loc[3]])
+	}
+	return best
+}
+
+// verifyUpstream 先不带认证请求 upstream, 只有返回 401 的 upstream 才能说明认证头是否有效
+func verifyUpstream(ctx context.Context, client *http.Client, upstream, encoded string) (bool, error) {
+	status, err := fetchStatus(ctx, client, upstream, "")
+	if err != nil {
+		return false, err
+	}
+	if status != http.StatusUnauthorized {
+		return false, nil
+	}
+
+	status, err = fetchStatus(ctx, client, upstream, "Basic "+encoded)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return false, nil
+	case status < http.StatusBadRequest:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", status)
+	}
+}
+
+func fetchStatus(ctx context.Context, client *http.Client, upstream, authorization string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream, http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxBodyBytes))
+		_ = res.Body.Close()
+	}()
+	return res.StatusCode, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_ProxyBasicAuth
+}
+
+func (s Scanner) Description() string {
+	return "Basic-auth credentials hardcoded in nginx, Apache and HAProxy configs, and password hashes in .htpasswd files and HAProxy userlists. Reverse proxies often guard internal admin interfaces, so these credentials give access to them."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+// 明文密码为 high, 哈希按算法的强度调整
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagInfrastructure} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return "Change the password at the upstream service and in the proxy config, and remove the old one from the source " +
+		"and its history. Keep basic-auth users in an htpasswd file outside the repository, hashed with bcrypt at cost 10 " +
+		"or more (htpasswd -B -C 10)."
+}
@@ -2,63 +2,68 @@ package pulumi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	regexp "github.com/wasilibs/go-re2"
+	"io"
 	"net/http"
 	"strings"
 
+	regexp "github.com/wasilibs/go-re2"
+
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 )
 
-type Scanner struct{}
+type Scanner struct {
+	client *http.Client
+}
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
-	client = common.SaneHttpClient()
+	defaultClient = common.SaneHttpClient()
 
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
 	keyPat = regexp.MustCompile(`\b(pul-[a-z0-9]{40})\b`)
 )
 
+const userURL = "https://api.pulumi.com/api/user"
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
 	return []string{"pul-"}
 }
 
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
 // FromData will find and optionally verify Pulumi secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
 
-	matches := keyPat.FindAllStringSubmatch(dataStr, -1)
-
-	for _, match := range matches {
-		resMatch := strings.TrimSpace(match[1])
+	uniqueTokens := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueTokens[strings.TrimSpace(match[1])] = struct{}{}
+	}
 
+	for _, token := range detectors.SortedKeys(uniqueTokens) {
 		s1 := detectors.Result{
 			DetectorType: detector_typepb.DetectorType_Pulumi,
-			Raw:          []byte(resMatch),
+			Raw:          []byte(token),
 		}
 
 		if verify {
-			req, err := http.NewRequestWithContext(ctx, "GET", "https://api.pulumi.com/api/user/stacks", nil)
-			if err != nil {
-				continue
-			}
-			req.Header.Add("Accept", "application/vnd.pulumi+8")
-			req.Header.Add("Content-Type", "application/json")
-			req.Header.Add("Authorization", fmt.Sprintf("token %s", resMatch))
-			res, err := client.Do(req)
-			if err == nil {
-				defer res.Body.Close()
-				if res.StatusCode >= 200 && res.StatusCode < 300 {
-					s1.Verified = true
-				}
-			}
+			isVerified, extraData, verificationErr := verifyToken(ctx, s.getClient(), userURL, token)
+			s1.Verified = isVerified
+			s1.ExtraData = extraData
+			s1.SetVerificationError(verificationErr, token)
 		}
 
 		results = append(results, s1)
@@ -67,6 +72,65 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+type userResponse struct {
+	Name          string `json:"name"`
+	GithubLogin   string `json:"githubLogin"`
+	Email         string `json:"email"`
+	Organizations []struct {
+		GithubLogin string `json:"githubLogin"`
+	} `json:"organizations"`
+}
+
+// verifyToken calls the "me" endpoint, which reports the user or organization the token belongs to.
+func verifyToken(ctx context.Context, client *http.Client, endpoint, token string) (bool, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Add("Accept", "application/vnd.pulumi+8")
+	req.Header.Add("Authorization", fmt.Sprintf("token %s", token))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var user userResponse
+		if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+			return false, nil, err
+		}
+
+		extraData := map[string]string{}
+		if user.GithubLogin != "" {
+			extraData["user"] = user.GithubLogin
+		}
+		if user.Name != "" {
+			extraData["name"] = user.Name
+		}
+		if user.Email != "" {
+			extraData["email"] = user.Email
+		}
+		orgs := make([]string, 0, len(user.Organizations))
+		for _, org := range user.Organizations {
+			orgs = append(orgs, org.GithubLogin)
+		}
+		if len(orgs) > 0 {
+			extraData["organizations"] = strings.Join(orgs, ", ")
+		}
+		return true, extraData, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_Pulumi
 }
@@ -50,6 +50,7 @@ func TestPulumi_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Pulumi,
 					Verified:     true,
+					ExtraData:    map[string]string{"user": "", "name": "", "email": ""},
 				},
 			},
 			wantErr: false,
@@ -95,6 +96,10 @@ func TestPulumi_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				// The identity of the test account may change, so only its keys are compared.
+				for k := range got[i].ExtraData {
+					got[i].ExtraData[k] = ""
+				}
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Pulumi.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
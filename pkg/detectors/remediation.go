@@ -0,0 +1,10 @@
+package detectors
+
+// WalletRemediation returns the remediation of a leaked key or seed of a wallet, named like "Bitcoin wallet". Unlike
+// API credentials, wallet keys can't be revoked: whoever has seen the key controls the funds until they are moved, so
+// the remediation is a sweep rather than a rotation.
+func WalletRemediation(wallet string) string {
+	return "Sweep the funds of the " + wallet + " to a new wallet immediately; the key can't be revoked, so anyone who " +
+		"has seen it can move the funds at any time. Then retire the old wallet and remove the key from the source " +
+		"and its history."
+}
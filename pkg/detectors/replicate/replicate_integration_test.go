@@ -52,6 +52,7 @@ func TestReplicate_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Replicate,
 					Verified:     true,
+					ExtraData:    map[string]string{"write_access": "true"},
 				},
 			},
 			wantErr:             false,
@@ -103,7 +104,11 @@ func TestReplicate_FromChunk(t *testing.T) {
 				}
 			}
 			ignoreOpts := cmpopts.IgnoreFields(detectors.Result{}, "Raw", "verificationError")
-			if diff := cmp.Diff(got, tt.want, ignoreOpts); diff != "" {
+			// The test account may be renamed or change type.
+			ignoreAccount := cmpopts.IgnoreMapEntries(func(k, _ string) bool {
+				return k == "username" || k == "account_type"
+			})
+			if diff := cmp.Diff(got, tt.want, ignoreOpts, ignoreAccount); diff != "" {
 				t.Errorf("Replicate.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
 			}
 		})
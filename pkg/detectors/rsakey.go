@@ -0,0 +1,38 @@
+package detectors
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+)
+
+// ParseRSAPrivateKey parses an RSA private key given either as a PEM block or as the bare base64 body of one, as
+// payment SDK configurations usually store it. Whitespace and escaped newlines inside the key are ignored. It returns
+// the key and its format, "pkcs1" or "pkcs8".
+func ParseRSAPrivateKey(s string) (*rsa.PrivateKey, string, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-----BEGIN") {
+		if i := strings.Index(s[len("-----BEGIN"):], "-----"); i >= 0 {
+			s = s[len("-----BEGIN")+i+len("-----"):]
+		}
+		if i := strings.Index(s, "-----END"); i >= 0 {
+			s = s[:i]
+		}
+	}
+	s = strings.NewReplacer(`\r`, "", `\n`, "", "\r", "", "\n", "", " ", "", "\t", "", `"`, "", `\`, "").Replace(s)
+
+	der, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, "", false
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, "pkcs1", true
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+			return rsaKey, "pkcs8", true
+		}
+	}
+	return nil, "", false
+}
@@ -0,0 +1,54 @@
+package detectors
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRSAPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs1 := x509.MarshalPKCS1PrivateKey(key)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pkcs1PEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: pkcs1}))
+
+	tests := []struct {
+		name       string
+		input      string
+		wantFormat string
+	}{
+		{name: "PKCS#1 PEM", input: pkcs1PEM, wantFormat: "pkcs1"},
+		{name: "PKCS#8 PEM", input: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})), wantFormat: "pkcs8"},
+		{name: "bare base64", input: base64.StdEncoding.EncodeToString(pkcs8), wantFormat: "pkcs8"},
+		{name: "PEM with escaped newlines", input: strings.ReplaceAll(pkcs1PEM, "\n", `\n`), wantFormat: "pkcs1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, format, ok := ParseRSAPrivateKey(test.input)
+			require.True(t, ok)
+			assert.Equal(t, test.wantFormat, format)
+			assert.True(t, key.Equal(got))
+		})
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+	_, _, ok := ParseRSAPrivateKey(base64.StdEncoding.EncodeToString(ecDER))
+	assert.False(t, ok, "EC keys are not RSA keys")
+
+	_, _, ok = ParseRSAPrivateKey("MIIEvQIBADANBgkqhkiG9w0BAQEFAASCBKcwggSjAgEAAoIBAQ")
+	assert.False(t, ok, "truncated key")
+}
@@ -0,0 +1,227 @@
+package sealedsecrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// Scanner 查找与 SealedSecret 同名的明文 Secret 清单. 两者在同一文件中时直接报告;
+// 由 New 创建的 Scanner 还会记住扫描中已见过的清单, 以发现分别提交在不同文件中的情况.
+type Scanner struct {
+	index *manifestIndex
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+// defaultIndexedManifests 是跨文件关联时记住的清单数量
+const defaultIndexedManifests = 10000
+
+// New creates a Scanner that also pairs manifests found in different chunks of the same scan.
+func New() *Scanner {
+	return &Scanner{index: newManifestIndex(defaultIndexedManifests)}
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"sealedsecret", "kind: secret"}
+}
+
+type manifest struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+// id 返回 namespace/name, 未指定 namespace 时视为 default
+func (m manifest) id() string {
+	namespace := m.Metadata.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	return namespace + "/" + m.Metadata.Name
+}
+
+// values 返回 Secret 中解码后的明文值, 以键名为键
+func (m manifest) values() map[string]string {
+	values := make(map[string]string, len(m.Data)+len(m.StringData))
+	for k, v := range m.Data {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+		if err != nil || len(decoded) == 0 {
+			continue
+		}
+		values[k] = string(decoded)
+	}
+	for k, v := range m.StringData {
+		if v != "" {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// FromData will find plaintext Secret manifests committed alongside a SealedSecret of the same name in a given set
+// of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	sealed := make(map[string]struct{})
+	secrets := make(map[string]map[string]string)
+	for _, m := range parseManifests(data) {
+		if m.Metadata.Name == "" {
+			continue
+		}
+		switch m.Kind {
+		case "SealedSecret":
+			sealed[m.id()] = struct{}{}
+		case "Secret":
+			if values := m.values(); len(values) > 0 {
+				secrets[m.id()] = values
+			}
+		}
+	}
+
+	for _, id := range detectors.SortedKeys(secrets) {
+		location := ""
+		if _, ok := sealed[id]; ok {
+			location = "same file"
+		} else if s.index.hasSealed(id) {
+			location = "elsewhere in the scan"
+		} else {
+			s.index.addSecret(id, secrets[id])
+			continue
+		}
+		if s.index.markReported(id) {
+			results = append(results, newResults(id, secrets[id], location)...)
+		}
+	}
+	for _, id := range detectors.SortedKeys(sealed) {
+		s.index.addSealed(id)
+		if _, ok := secrets[id]; ok {
+			continue
+		}
+		// 明文 Secret 在之前扫描的文件中
+		if values, ok := s.index.secret(id); ok && s.index.markReported(id) {
+			results = append(results, newResults(id, values, "elsewhere in the scan")...)
+		}
+	}
+
+	return results, nil
+}
+
+func newResults(id string, values map[string]string, location string) []detectors.Result {
+	results := make([]detectors.Result, 0, len(values))
+	for _, key := range detectors.SortedKeys(values) {
+		results = append(results, detectors.Result{
+			DetectorType: detector_typepb.DetectorType_SealedSecretPlaintext,
+			Raw:          []byte(values[key]),
+			RawV2:        []byte(id + "/" + key + "=" + values[key]),
+			Redacted:     id + "/" + key,
+			ExtraData: map[string]string{
+				"secret":              id,
+				"key":                 key,
+				"sealed_secret_found": location,
+			},
+		})
+	}
+	return results
+}
+
+// parseManifests 解码 YAML 多文档流, 遇到无法解析的文档时返回已解析的部分
+func parseManifests(data []byte) []manifest {
+	var manifests []manifest
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var m manifest
+		err := decoder.Decode(&m)
+		if errors.Is(err, io.EOF) || (err != nil && len(manifests) > 0) {
+			return manifests
+		}
+		if err != nil {
+			return nil
+		}
+		manifests = append(manifests, m)
+	}
+}
+
+// manifestIndex 记住扫描中见过的 SealedSecret 和明文 Secret. 它可以并发使用, nil 表示不做跨文件关联
+type manifestIndex struct {
+	mu       sync.Mutex
+	sealed   *lru.Cache[string, struct{}]
+	secrets  *lru.Cache[string, map[string]string]
+	reported *lru.Cache[string, struct{}]
+}
+
+func newManifestIndex(size int) *manifestIndex {
+	sealed, _ := lru.New[string, struct{}](size)
+	secrets, _ := lru.New[string, map[string]string](size)
+	reported, _ := lru.New[string, struct{}](size)
+	return &manifestIndex{sealed: sealed, secrets: secrets, reported: reported}
+}
+
+func (i *manifestIndex) addSealed(id string) {
+	if i == nil {
+		return
+	}
+	i.sealed.Add(id, struct{}{})
+}
+
+func (i *manifestIndex) hasSealed(id string) bool {
+	return i != nil && i.sealed.Contains(id)
+}
+
+func (i *manifestIndex) addSecret(id string, values map[string]string) {
+	if i == nil {
+		return
+	}
+	i.secrets.Add(id, values)
+}
+
+func (i *manifestIndex) secret(id string) (map[string]string, bool) {
+	if i == nil {
+		return nil, false
+	}
+	return i.secrets.Get(id)
+}
+
+// markReported 返回该 Secret 是否是第一次报告, 避免重叠的 chunk 重复报告
+func (i *manifestIndex) markReported(id string) bool {
+	if i == nil {
+		return true
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.reported.Contains(id) {
+		return false
+	}
+	i.reported.Add(id, struct{}{})
+	return true
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagKubernetes} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SealedSecretPlaintext
+}
+
+func (s Scanner) Description() string {
+	return "Sealed Secrets encrypt Kubernetes Secrets so they can be committed safely. A plaintext Secret manifest committed next to the SealedSecret of the same name exposes the values the seal was meant to protect."
+}
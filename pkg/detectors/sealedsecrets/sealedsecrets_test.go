@@ -0,0 +1,158 @@
+package sealedsecrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	sealedSecretYAML = `apiVersion: bitnami.com/v1alpha1
+kind: SealedSecret
+metadata:
+  name: db-credentials
+  namespace: orders
+spec:
+  encryptedData:
+    password: AgBy3i4OJSWK+PiTySYZZA9rO43cGDEq0x4m2nRk7E1bT...
+`
+	secretYAML = `apiVersion: v1
+kind: Secret
+metadata:
+  name: db-credentials
+  namespace: orders
+type: Opaque
+data:
+  password: UGctMHJkZXJzITIwMjQ=
+stringData:
+  username: orders
+`
+)
+
+func TestSealedSecrets_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "secret committed next to sealed secret",
+			input: sealedSecretYAML + "---\n" + secretYAML,
+			want: []string{
+				"orders/db-credentials/password=Pg-0rders!2024",
+				"orders/db-credentials/username=orders",
+			},
+		},
+		{
+			name: "default namespace",
+			input: `kind: SealedSecret
+metadata:
+  name: api
+---
+kind: Secret
+metadata:
+  name: api
+  namespace: default
+stringData:
+  token: tok-3f9a1c
+`,
+			want: []string{"default/api/token=tok-3f9a1c"},
+		},
+		{
+			name:  "invalid pattern - secret without sealed secret",
+			input: secretYAML,
+		},
+		{
+			name: "invalid pattern - different namespace",
+			input: sealedSecretYAML + "---\n" + `kind: Secret
+metadata:
+  name: db-credentials
+  namespace: billing
+stringData:
+  password: hunter22
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.RawV2)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestSealedSecrets_AcrossChunks(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+	}{
+		{name: "sealed secret first", chunks: []string{sealedSecretYAML, secretYAML}},
+		{name: "secret first", chunks: []string{secretYAML, sealedSecretYAML}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := New()
+			var results []detectors.Result
+			for _, chunk := range test.chunks {
+				found, err := d.FromData(context.Background(), false, []byte(chunk))
+				require.NoError(t, err)
+				results = append(results, found...)
+			}
+
+			require.Len(t, results, 2)
+			assert.Equal(t, "orders/db-credentials/password", results[0].Redacted)
+			assert.Equal(t, "elsewhere in the scan", results[0].ExtraData["sealed_secret_found"])
+
+			// Chunks seen again, e.g. in a later commit, are not reported twice.
+			for _, chunk := range test.chunks {
+				found, err := d.FromData(context.Background(), false, []byte(chunk))
+				require.NoError(t, err)
+				assert.Empty(t, found)
+			}
+		})
+	}
+}
+
+func TestSealedSecrets_WithoutIndex(t *testing.T) {
+	d := Scanner{}
+	for _, chunk := range []string{sealedSecretYAML, secretYAML} {
+		results, err := d.FromData(context.Background(), false, []byte(chunk))
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	}
+}
@@ -0,0 +1,52 @@
+package detectors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SecretHasher replaces raw secrets in results with their keyed hash, so results can be matched across systems that
+// share the key without the plaintext ever leaving the scanner.
+type SecretHasher struct {
+	key []byte
+}
+
+// NewSecretHasher returns a SecretHasher that computes HMAC-SHA256 under key.
+func NewSecretHasher(key []byte) *SecretHasher {
+	return &SecretHasher{key: key}
+}
+
+// Hash returns the hex-encoded HMAC-SHA256 of secret.
+func (h *SecretHasher) Hash(secret []byte) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write(secret)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Apply replaces Raw and RawV2 with their hashes, and drops the fields that only exist to carry plaintext secrets to
+// later stages. A nil *SecretHasher leaves the result unchanged.
+func (h *SecretHasher) Apply(r *ResultWithMetadata) {
+	if h == nil {
+		return
+	}
+	if len(r.Raw) > 0 {
+		r.Raw = []byte(h.Hash(r.Raw))
+	}
+	if len(r.RawV2) > 0 {
+		r.RawV2 = []byte(h.Hash(r.RawV2))
+	}
+	r.AnalysisInfo = nil
+	r.ChunkData = nil
+}
+
+// ZeroizeSecrets overwrites Raw and RawV2 with zeros and drops the fields that carry plaintext secrets, so that an
+// output result does not linger in memory, or in a core dump of the scanner. The result must own its Raw and RawV2
+// buffers, as anything sharing them is wiped as well. Secrets held in strings, such as ExtraData, cannot be wiped.
+func ZeroizeSecrets(r *ResultWithMetadata) {
+	clear(r.Raw)
+	clear(r.RawV2)
+	r.Raw, r.RawV2 = nil, nil
+	r.AnalysisInfo = nil
+	r.ChunkData = nil
+}
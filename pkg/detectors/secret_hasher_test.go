@@ -0,0 +1,55 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretHasher_Hash(t *testing.T) {
+	// RFC 4231 test case 2.
+	h := NewSecretHasher([]byte("Jefe"))
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", h.Hash([]byte("what do ya want for nothing?")))
+}
+
+func TestSecretHasher_Apply(t *testing.T) {
+	h := NewSecretHasher([]byte("key"))
+	r := ResultWithMetadata{
+		Result: Result{
+			Raw:          []byte("id"),
+			RawV2:        []byte("id:secret"),
+			Redacted:     "id",
+			AnalysisInfo: map[string]string{"key": "secret"},
+		},
+		ChunkData: []byte("id:secret"),
+	}
+
+	h.Apply(&r)
+	assert.Equal(t, h.Hash([]byte("id")), string(r.Raw))
+	assert.Equal(t, h.Hash([]byte("id:secret")), string(r.RawV2))
+	assert.Equal(t, "id", r.Redacted)
+	assert.Nil(t, r.AnalysisInfo)
+	assert.Nil(t, r.ChunkData)
+
+	var nilHasher *SecretHasher
+	r = ResultWithMetadata{Result: Result{Raw: []byte("id")}}
+	nilHasher.Apply(&r)
+	assert.Equal(t, "id", string(r.Raw))
+}
+
+func TestZeroizeSecrets(t *testing.T) {
+	raw, rawV2 := []byte("id"), []byte("id:secret")
+	r := ResultWithMetadata{
+		Result:    Result{Raw: raw, RawV2: rawV2, Redacted: "id", AnalysisInfo: map[string]string{"key": "secret"}},
+		ChunkData: []byte("id:secret"),
+	}
+
+	ZeroizeSecrets(&r)
+	assert.Equal(t, make([]byte, 2), raw)
+	assert.Equal(t, make([]byte, 9), rawV2)
+	assert.Nil(t, r.Raw)
+	assert.Nil(t, r.RawV2)
+	assert.Nil(t, r.AnalysisInfo)
+	assert.Nil(t, r.ChunkData)
+	assert.Equal(t, "id", r.Redacted)
+}
@@ -0,0 +1,69 @@
+package detectors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// seedPhrasePat matches runs of at least 12 lowercase words of 3 to 8 letters, the shape of BIP39, Electrum and
+// Monero mnemonics. It deliberately errs on the side of redacting ordinary prose over leaking a phrase.
+var seedPhrasePat = regexp.MustCompile(`\b[a-z]{3,8}(?:[ \t]+[a-z]{3,8}){11,}\b`)
+
+// RedactSeedPhrases replaces every seed phrase in s with its first word and the SHA-256 of the whitespace-normalized
+// phrase, so findings can still be correlated without exposing key material. It reports whether anything was replaced.
+func RedactSeedPhrases(s string) (string, bool) {
+	redacted := false
+	s = seedPhrasePat.ReplaceAllStringFunc(s, func(phrase string) string {
+		redacted = true
+		words := strings.Fields(phrase)
+		sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+		return fmt.Sprintf("%s [seed phrase redacted: %d words, sha256:%s]", words[0], len(words), hex.EncodeToString(sum[:]))
+	})
+	return s, redacted
+}
+
+// SanitizeSeedPhrases redacts seed phrases from every field of a result that can end up in a report, log or webhook.
+// It is applied centrally by the engine, so it holds regardless of how a detector fills in its result. It reports
+// whether anything was redacted.
+func SanitizeSeedPhrases(r *ResultWithMetadata) bool {
+	redacted := false
+	redact := func(s string) string {
+		s, ok := RedactSeedPhrases(s)
+		redacted = redacted || ok
+		return s
+	}
+	redactBytes := func(b []byte) []byte {
+		if len(b) == 0 {
+			return b
+		}
+		s, ok := RedactSeedPhrases(string(b))
+		if !ok {
+			return b
+		}
+		redacted = true
+		return []byte(s)
+	}
+
+	// Maps are copied rather than modified in place, as they may be shared with cached verification results.
+	redactMap := func(m map[string]string) map[string]string {
+		if m == nil {
+			return nil
+		}
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = redact(v)
+		}
+		return out
+	}
+
+	r.Raw = redactBytes(r.Raw)
+	r.RawV2 = redactBytes(r.RawV2)
+	r.Redacted = redact(r.Redacted)
+	r.ExtraData = redactMap(r.ExtraData)
+	r.AnalysisInfo = redactMap(r.AnalysisInfo)
+	r.ChunkData = redactBytes(r.ChunkData)
+	return redacted
+}
@@ -0,0 +1,69 @@
+package detectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestRedactSeedPhrases(t *testing.T) {
+	got, ok := RedactSeedPhrases("mnemonic: " + testMnemonic + "\nnext line")
+	assert.True(t, ok)
+	assert.Equal(t, "mnemonic: abandon [seed phrase redacted: 12 words, sha256:"+
+		"c557eec878dfd852ba3f88087c4f350f09c55537ab5e549c3cd14320ec3cef38]\nnext line", got)
+}
+
+func TestRedactSeedPhrases_NoPhrase(t *testing.T) {
+	input := "the quick brown fox jumps over the lazy dog"
+	got, ok := RedactSeedPhrases(input)
+	assert.False(t, ok)
+	assert.Equal(t, input, got)
+}
+
+func TestSanitizeSeedPhrases(t *testing.T) {
+	extraData := map[string]string{"kind": "mnemonic", "phrase": testMnemonic}
+	r := ResultWithMetadata{
+		Result: Result{
+			Raw:       []byte(testMnemonic),
+			Redacted:  "abandon ...",
+			ExtraData: extraData,
+		},
+		ChunkData: []byte(`HDWalletProvider("` + testMnemonic + `", url)`),
+	}
+
+	assert.True(t, SanitizeSeedPhrases(&r))
+	for _, s := range []string{string(r.Raw), r.ExtraData["phrase"], string(r.ChunkData)} {
+		assert.NotContains(t, s, testMnemonic)
+		assert.True(t, strings.HasPrefix(strings.TrimPrefix(s, `HDWalletProvider("`), "abandon [seed phrase redacted: 12 words"))
+	}
+	assert.Equal(t, "abandon ...", r.Redacted)
+	assert.Equal(t, "mnemonic", r.ExtraData["kind"])
+	// The original map is left untouched.
+	assert.Equal(t, testMnemonic, extraData["phrase"])
+}
+
+func FuzzRedactSeedPhrases(f *testing.F) {
+	for _, seed := range []string{
+		"mnemonic: " + testMnemonic + "\nnext line",
+		"the quick brown fox jumps over the lazy dog",
+		testMnemonic + " " + testMnemonic,
+		strings.ToUpper(testMnemonic),
+		"abandon\tabandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"助记词: " + testMnemonic + "。",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, ok := RedactSeedPhrases(s)
+		if !ok && got != s {
+			t.Errorf("RedactSeedPhrases(%q) changed the input without reporting it", s)
+		}
+		if ok && !strings.Contains(got, "[seed phrase redacted: ") {
+			t.Errorf("RedactSeedPhrases(%q) = %q reported a redaction without making one", s, got)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package sentrydsn
+
+import (
+	"context"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	// Sentry DSN 格式: https://<public_key>[:<secret_key>]@<host>[/<path>]/<project_id>
+	// SaaS 的 host 一般为 o<org_id>.ingest[.<region>].sentry.io, 自建实例为任意域名
+	dsnPat = regexp.MustCompile(`\b(https?://([a-f0-9]{32})(?::([a-f0-9]{32}))?@([a-zA-Z0-9.-]+(?::[0-9]{1,5})?)/(?:[a-zA-Z0-9._-]+/)*([0-9]+))\b`)
+	// 从 SaaS host 中提取组织 ID
+	orgIDPat = regexp.MustCompile(`^o([0-9]+)\.ingest\.`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"sentry"}
+}
+
+// FromData will find Sentry DSNs in a given set of bytes.
+//
+// DSNs are designed to be embedded in client applications and only allow submitting events to a single project, so
+// they are not verified; the result policy reports them with a low severity.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueDSNs := make(map[string][]string)
+	for _, match := range dsnPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueDSNs[match[1]] = match
+	}
+
+	for _, dsn := range detectors.SortedKeys(uniqueDSNs) {
+		match := uniqueDSNs[dsn]
+		publicKey, secretKey, host, projectID := match[2], match[3], match[4], match[5]
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_SentryDSN,
+			Raw:          []byte(publicKey),
+			RawV2:        []byte(dsn),
+			Redacted:     publicKey + "@" + host,
+			ExtraData: map[string]string{
+				"host":       host,
+				"project_id": projectID,
+			},
+		}
+
+		if orgID := orgIDPat.FindStringSubmatch(host); orgID != nil {
+			s1.ExtraData["org_id"] = orgID[1]
+		}
+
+		// 旧版 DSN 中带有 secret key, 泄露后可以伪造服务端事件
+		if secretKey != "" {
+			s1.ExtraData["legacy_secret_key"] = "true"
+			s1.Redacted = strings.Replace(dsn, ":"+secretKey, ":********", 1)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SentryDSN
+}
+
+func (s Scanner) Description() string {
+	return "Sentry is an error tracking service. A Sentry DSN tells an SDK where to send events and embeds the project's public key, which allows submitting events to that project."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityLow }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagMonitoring} }
@@ -2,9 +2,12 @@ package sentryorgtoken
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -19,6 +22,7 @@ type Scanner struct {
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
 
 var (
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
@@ -39,6 +43,12 @@ func (s Scanner) Description() string {
 	return "Sentry is an error tracking service that helps developers monitor and fix crashes in real time. Sentry Organization Auth Tokens can be used in many places to interact with Sentry programmatically. For example, they can be used for sentry-cli, bundler plugins, or similar use cases."
 }
 
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagMonitoring} }
+
 // FromData will find and optionally verify SentryToken secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -50,10 +60,20 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		uniqueOrgTokens[orgToken[1]] = struct{}{}
 	}
 
-	for orgToken := range uniqueOrgTokens {
+	for _, orgToken := range detectors.SortedKeys(uniqueOrgTokens) {
 		s1 := detectors.Result{
 			DetectorType: detector_typepb.DetectorType_SentryOrgToken,
 			Raw:          []byte(orgToken),
+			ExtraData:    make(map[string]string),
+		}
+
+		if payload, ok := parseTokenPayload(orgToken); ok {
+			if payload.Org != "" {
+				s1.ExtraData["org"] = payload.Org
+			}
+			if payload.URL != "" {
+				s1.ExtraData["url"] = payload.URL
+			}
 		}
 
 		if verify {
@@ -61,9 +81,17 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				s.client = common.SaneHttpClient()
 			}
 
-			isVerified, verificationErr := verifySentryOrgToken(ctx, s.client, orgToken)
+			isVerified, orgs, verificationErr := verifySentryOrgToken(ctx, s.client, orgToken)
 			s1.Verified = isVerified
 			s1.SetVerificationError(verificationErr, orgToken)
+
+			if len(orgs) > 0 {
+				slugs := make([]string, 0, len(orgs))
+				for _, org := range orgs {
+					slugs = append(slugs, org.Slug)
+				}
+				s1.ExtraData["organizations"] = strings.Join(slugs, ",")
+			}
 		}
 
 		results = append(results, s1)
@@ -72,18 +100,51 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-// docs: https://docs.sentry.io/account/auth-tokens/#organization-auth-tokens
-func verifySentryOrgToken(ctx context.Context, client *http.Client, token string) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://sentry.io/api/0/auth/validate", nil)
+// tokenPayload is the base64 encoded JSON document embedded between the `sntrys_` prefix and the token secret.
+type tokenPayload struct {
+	URL       string `json:"url"`
+	RegionURL string `json:"region_url"`
+	Org       string `json:"org"`
+}
+
+// parseTokenPayload decodes the metadata embedded in an org auth token. Tokens whose payload cannot be decoded are
+// still reported; they simply carry no extra data.
+func parseTokenPayload(token string) (*tokenPayload, bool) {
+	encoded, _, found := strings.Cut(strings.TrimPrefix(token, "sntrys_"), "_")
+	if !found {
+		return nil, false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+
+	var payload tokenPayload
+	if err := json.Unmarshal(decoded, &payload); err != nil {
+		return nil, false
+	}
+
+	return &payload, true
+}
+
+type organization struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// docs: https://docs.sentry.io/api/organizations/list-your-organizations/
+func verifySentryOrgToken(ctx context.Context, client *http.Client, token string) (bool, []organization, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://sentry.io/api/0/organizations/", nil)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	defer func() {
 		_, _ = io.Copy(io.Discard, resp.Body)
@@ -92,10 +153,14 @@ func verifySentryOrgToken(ctx context.Context, client *http.Client, token string
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		return true, nil
+		var orgs []organization
+		if err := json.NewDecoder(resp.Body).Decode(&orgs); err != nil {
+			return false, nil, err
+		}
+		return true, orgs, nil
 	case http.StatusForbidden, http.StatusUnauthorized:
-		return false, nil
+		return false, nil, nil
 	default:
-		return false, fmt.Errorf("unexpected HTTP response status %d", resp.StatusCode)
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", resp.StatusCode)
 	}
 }
@@ -27,6 +27,18 @@ func TestSentryOrgToken_FromChunk(t *testing.T) {
 	secret := testSecrets.MustGetField("SENTRY_ORG_TOKEN")
 	inactiveSecret := testSecrets.MustGetField("SENTRY_ORG_TOKEN_INACTIVE")
 
+	// orgData returns the organization data embedded in an org auth token.
+	orgData := func(token string) map[string]string {
+		payload, ok := parseTokenPayload(token)
+		if !ok {
+			t.Fatalf("could not decode the payload of %s", token)
+		}
+		return map[string]string{"org": payload.Org, "url": payload.URL}
+	}
+	// An org auth token only has access to its own organization.
+	verifiedData := orgData(secret)
+	verifiedData["organizations"] = verifiedData["org"]
+
 	type args struct {
 		ctx    context.Context
 		data   []byte
@@ -52,6 +64,7 @@ func TestSentryOrgToken_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_SentryOrgToken,
 					Verified:     true,
+					ExtraData:    verifiedData,
 				},
 			},
 			wantErr: false,
@@ -68,6 +81,7 @@ func TestSentryOrgToken_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_SentryOrgToken,
 					Verified:     false,
+					ExtraData:    orgData(inactiveSecret),
 				},
 			},
 			wantErr: false,
@@ -26,6 +26,7 @@ type Organization struct {
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
 var _ detectors.Versioner = (*Scanner)(nil)
 
 var (
@@ -125,3 +126,9 @@ func (s Scanner) Type() detector_typepb.DetectorType {
 func (s Scanner) Description() string {
 	return "Sentry is an error tracking service that helps developers monitor and fix crashes in real time. Sentry tokens can be used to access and manage projects and organizations within Sentry."
 }
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagMonitoring} }
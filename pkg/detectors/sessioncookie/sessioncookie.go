@@ -0,0 +1,261 @@
+package sessioncookie
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// replay 验证时用 cookie 或 token 请求所属站点, 需要通过 --replay-session-cookies 显式开启
+	replay bool
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.ReplayVerifier = (*Scanner)(nil)
+var _ detectors.StartOffsetProvider = (*Scanner)(nil)
+
+var (
+	// 站点地址来自扫描内容, 不允许访问内网地址; 不跟随跳转, 跳转到登录页说明会话无效
+	defaultClient = detectors.NewDetectorHttpClient(detectors.WithNoLocalIP(), detectors.WithNoFollowRedirects())
+
+	// 日志中的 Set-Cookie 头, 以及 HAR 中的 {"name": "Set-Cookie", "value": "..."}
+	setCookiePat = regexp.MustCompile(`(?i)set-cookie["']?\s*[:,]\s*(?:"value"\s*:\s*)?["']?([A-Za-z0-9_.-]{1,64})=([^;\s"'\\]{16,1000})((?:;[^"'\n]*)?)`)
+	// HAR 中 request.cookies / response.cookies 的条目
+	harCookiePat = regexp.MustCompile(`\{\s*"name"\s*:\s*"([A-Za-z0-9_.-]{1,64})"\s*,\s*"value"\s*:\s*"([^"\\]{16,1000})"([^}]*)\}`)
+	// 日志中的 Authorization 头, 以及 HAR 中的 {"name": "Authorization", "value": "Bearer ..."}
+	bearerPat = regexp.MustCompile(`(?i)authorization["']?\s*[:,]\s*(?:"value"\s*:\s*)?["']?bearer\s+([A-Za-z0-9._~+/-]{20,1000}=*)`)
+
+	// 只关注会话相关的 cookie 名称, 例如 sessionid, JSESSIONID, PHPSESSID, connect.sid, admin_token
+	cookieNamePat = regexp.MustCompile(`(?i)admin|session|sess|token|sid`)
+
+	// 站点归属: HAR 中的 "url", 日志中的 Host 头或完整 URL
+	harURLPat = regexp.MustCompile(`"url"\s*:\s*"(https?://[^"\s]+)"`)
+	hostPat   = regexp.MustCompile(`(?im)^\s*host:\s*([a-z0-9.-]+(?::[0-9]{1,5})?)\s*$`)
+	rawURLPat = regexp.MustCompile(`https?://[a-zA-Z0-9.-]+(?::[0-9]{1,5})?`)
+	// cookie 的有效期: Set-Cookie 的 Max-Age / Expires 属性, HAR cookie 条目的 "expires" 字段
+	expiresPat    = regexp.MustCompile(`(?i);\s*(max-age=[0-9]+|expires=[^;"]+)`)
+	harExpiresPat = regexp.MustCompile(`"expires"\s*:\s*"([^"]+)"`)
+)
+
+const (
+	// 低于该熵值的 cookie 值通常是 "deleted"、语言偏好等, 不是会话标识
+	minEntropy = 3.5
+	// 向前查找站点归属的字节数
+	hostLookback = 4096
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"set-cookie", "\"cookies\"", "bearer"}
+}
+
+// StartOffset implements detectors.StartOffsetProvider, 使匹配范围包含之前的请求 URL 或 Host 头
+func (s Scanner) StartOffset() int64 {
+	return hostLookback
+}
+
+// SetReplayVerification implements detectors.ReplayVerifier.
+func (s *Scanner) SetReplayVerification(enabled bool) {
+	s.replay = enabled
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+type candidate struct {
+	kind    string
+	name    string
+	value   string
+	expires string
+	origin  string
+}
+
+// FromData will find session cookies and bearer tokens in logs and HAR files in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var candidates []candidate
+	addCookies := func(pat, attrPat *regexp.Regexp) {
+		for _, m := range pat.FindAllStringSubmatchIndex(dataStr, -1) {
+			name, value := dataStr[m[2]:m[3]], dataStr[m[4]:m[5]]
+			if !cookieNamePat.MatchString(name) {
+				continue
+			}
+			c := candidate{kind: "cookie", name: name, value: value, origin: originBefore(dataStr, m[0])}
+			if attrs := attrPat.FindStringSubmatch(dataStr[m[6]:m[7]]); attrs != nil {
+				c.expires = attrs[1]
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	addCookies(setCookiePat, expiresPat)
+	addCookies(harCookiePat, harExpiresPat)
+	for _, m := range bearerPat.FindAllStringSubmatchIndex(dataStr, -1) {
+		candidates = append(candidates, candidate{kind: "bearer", name: "Authorization", value: dataStr[m[2]:m[3]], origin: originBefore(dataStr, m[0])})
+	}
+
+	seen := make(map[string]struct{})
+	for _, c := range candidates {
+		if detectors.StringShannonEntropy(c.value) < minEntropy {
+			continue
+		}
+		key := c.origin + "\x00" + c.name + "\x00" + c.value
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_SessionCookie,
+			Raw:          []byte(c.value),
+			RawV2:        []byte(c.origin + " " + c.name + "=" + c.value),
+			Redacted:     c.name + "=" + redact(c.value),
+			ExtraData: map[string]string{
+				"kind": c.kind,
+				"name": c.name,
+			},
+			Severity: detectors.SeverityMedium,
+		}
+		if strings.Contains(strings.ToLower(c.name), "admin") {
+			s1.Severity = detectors.SeverityHigh
+		}
+		if c.origin != "" {
+			s1.ExtraData["origin"] = c.origin
+		}
+		if c.expires != "" {
+			s1.ExtraData["expires"] = c.expires
+		}
+		if expiresAt, ok := expiry(c); ok {
+			s1.SetExpiresAt(expiresAt)
+		}
+
+		if verify && s.replay && c.origin != "" {
+			isVerified, verificationErr := replay(ctx, s.getClient(), c)
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, c.value)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// originBefore 返回 pos 之前最近出现的站点, 格式为 scheme://host[:port]
+func originBefore(data string, pos int) string {
+	window := data[max(pos-hostLookback, 0):pos]
+
+	best, bestPos := "", -1
+	if ms := harURLPat.FindAllStringSubmatchIndex(window, -1); len(ms) > 0 {
+		m := ms[len(ms)-1]
+		if u, err := url.Parse(window[m[2]:m[3]]); err == nil && u.Host != "" {
+			best, bestPos = u.Scheme+"://"+u.Host, m[0]
+		}
+	}
+	if ms := hostPat.FindAllStringSubmatchIndex(window, -1); len(ms) > 0 {
+		if m := ms[len(ms)-1]; m[0] > bestPos {
+			best, bestPos = "https://"+strings.ToLower(window[m[2]:m[3]]), m[0]
+		}
+	}
+	if ms := rawURLPat.FindAllStringIndex(window, -1); len(ms) > 0 && bestPos < 0 {
+		m := ms[len(ms)-1]
+		best = window[m[0]:m[1]]
+	}
+	return best
+}
+
+// expiry 解析 cookie 的 Expires 属性或 HAR 中的 expires 字段, 以及 JWT 形式 token 的 exp; Max-Age 是相对时间, 无法确定
+func expiry(c candidate) (time.Time, bool) {
+	if c.kind == "bearer" {
+		return detectors.JWTExpiry(c.value)
+	}
+	expires := c.expires
+	if len(expires) > len("expires=") && strings.EqualFold(expires[:len("expires=")], "expires=") {
+		expires = expires[len("expires="):]
+	}
+	if t, err := http.ParseTime(expires); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, expires); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func redact(value string) string {
+	if len(value) <= 12 {
+		return value[:4] + "..."
+	}
+	return value[:6] + "..." + value[len(value)-4:]
+}
+
+// replay 分别带凭证和不带凭证请求站点首页, 只有带凭证时成功而不带凭证时被拒绝或跳转, 才认为会话有效
+func replay(ctx context.Context, client *http.Client, c candidate) (bool, error) {
+	withCred, err := get(ctx, client, c)
+	if err != nil {
+		return false, err
+	}
+	without, err := get(ctx, client, candidate{origin: c.origin})
+	if err != nil {
+		return false, err
+	}
+
+	if withCred/100 != 2 {
+		return false, nil
+	}
+	switch without {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect:
+		return true, nil
+	default:
+		// 站点不需要登录, 无法判断会话是否有效
+		return false, nil
+	}
+}
+
+func get(ctx context.Context, client *http.Client, c candidate) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.origin+"/", nil)
+	if err != nil {
+		return 0, err
+	}
+	switch c.kind {
+	case "cookie":
+		req.AddCookie(&http.Cookie{Name: c.name, Value: c.value})
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+c.value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to request %s: %w", c.origin, err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	return res.StatusCode, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SessionCookie
+}
+
+func (s Scanner) Description() string {
+	return "Session cookies and bearer tokens captured in logs and HAR files. Until they expire or are revoked, they let anyone act as the logged-in user, often an administrator, without knowing the password or passing MFA."
+}
@@ -0,0 +1,50 @@
+package detectors
+
+import (
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Severity describes how damaging a leaked secret is likely to be. It is used to rank results in reports and by
+// the engine's result policy; it says nothing about whether the secret is live (see Result.Verified).
+type Severity int
+
+const (
+	SeverityUnspecified Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnspecified: "unspecified",
+	SeverityLow:         "low",
+	SeverityMedium:      "medium",
+	SeverityHigh:        "high",
+	SeverityCritical:    "critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return severityNames[SeverityUnspecified]
+}
+
+// Proto converts the severity to its protobuf representation. The values of both enums match.
+func (s Severity) Proto() detectorspb.Severity {
+	return detectorspb.Severity(s)
+}
+
+// ParseSeverity converts a severity name (case-insensitive) into a Severity. Unknown names return false.
+func ParseSeverity(name string) (Severity, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for s, n := range severityNames {
+		if n == name {
+			return s, true
+		}
+	}
+	return SeverityUnspecified, false
+}
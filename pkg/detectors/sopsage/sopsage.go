@@ -0,0 +1,184 @@
+package sopsage
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	// SOPS 加密后的 YAML/JSON/dotenv/INI 中, 每个值都是 ENC[AES256_GCM,data:...,iv:...,tag:...,type:...],
+	// 元数据块 (YAML/JSON 的 sops 字段, dotenv 的 sops_ 前缀) 记录加密数据密钥所用的 KMS 和接收者
+	encValuePat = regexp.MustCompile(`ENC\[AES256_GCM,data:[A-Za-z0-9+/=]*,iv:[A-Za-z0-9+/=]+,tag:[A-Za-z0-9+/=]+,type:\w+\]`)
+	// 元数据中的 mac 对每个文件唯一, 用于标识文件
+	sopsMacPat     = regexp.MustCompile(`(?:\bmac|sops_mac)["']?\s*[:=]\s*["']?(ENC\[AES256_GCM,data:[A-Za-z0-9+/=]+,iv:[A-Za-z0-9+/=]+,tag:[A-Za-z0-9+/=]+,type:str\])`)
+	sopsVersionPat = regexp.MustCompile(`(?:\bversion|sops_version)["']?\s*[:=]\s*["']?(\d+\.\d+\.\d+)`)
+
+	awsKMSPat   = regexp.MustCompile(`arn:aws[\w-]*:kms:[\w-]+:\d{12}:(?:key|alias)/[\w/-]+`)
+	gcpKMSPat   = regexp.MustCompile(`projects/[\w.:-]+/locations/[\w-]+/keyRings/[\w-]+/cryptoKeys/[\w-]+`)
+	azureKVPat  = regexp.MustCompile(`https://[\w-]+\.vault\.azure\.net`)
+	hcVaultPat  = regexp.MustCompile(`vault_address["']?\s*[:=]\s*["']?(https?://[^\s"',]+)`)
+	ageRecipPat = regexp.MustCompile(`\bage1[02-9ac-hj-np-z]{58}\b`)
+	pgpFPPat    = regexp.MustCompile(`(?:\bfp|_fp)["']?\s*[:=]\s*["']?([A-Fa-f0-9]{40})\b`)
+
+	// age 文件头: age-encryption.org/v1, 每个接收者一个 "-> 类型 参数" 节, 以 "--- mac" 结束
+	ageHeaderPat  = regexp.MustCompile(`age-encryption\.org/v1\n((?:-> [^\n]*\n(?:[A-Za-z0-9+/]*\n)*)+)--- ([A-Za-z0-9+/]{43})`)
+	ageArmoredPat = regexp.MustCompile(`-----BEGIN AGE ENCRYPTED FILE-----\s+([A-Za-z0-9+/=\s]+?)\s*-----END AGE ENCRYPTED FILE-----`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+func (s Scanner) Keywords() []string {
+	return []string{"ENC[AES256_GCM", "age-encryption.org", "BEGIN AGE ENCRYPTED FILE"}
+}
+
+// FromData will find SOPS-encrypted files and age-encrypted files in a given set of bytes, and report the keys
+// and recipients that can decrypt them.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 一个文件只有一个 SOPS 元数据块;
+	// SOPS 用 age 接收者时, 元数据中的 enc 字段本身就是 armored age 文件, 已经包含在 SOPS 结果里
+	if match := sopsMacPat.FindStringSubmatch(dataStr); match != nil {
+		return []detectors.Result{sopsResult(dataStr, match[1])}, nil
+	}
+
+	seen := make(map[string]struct{})
+	addAge := func(header, mac string, armored bool) {
+		if _, ok := seen[mac]; ok {
+			return
+		}
+		seen[mac] = struct{}{}
+		results = append(results, ageResult(header, mac, armored))
+	}
+	for _, match := range ageHeaderPat.FindAllStringSubmatch(dataStr, -1) {
+		addAge(match[1], match[2], false)
+	}
+	for _, match := range ageArmoredPat.FindAllStringSubmatch(dataStr, -1) {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(match[1]), ""))
+		if err != nil {
+			continue
+		}
+		if m := ageHeaderPat.FindStringSubmatch(string(decoded)); m != nil {
+			addAge(m[1], m[2], true)
+		}
+	}
+
+	return results, nil
+}
+
+// sopsResult 汇总 SOPS 元数据中的加密密钥和接收者
+func sopsResult(dataStr, mac string) detectors.Result {
+	extraData := map[string]string{
+		"format":           "sops",
+		"encrypted_values": strconv.Itoa(len(encValuePat.FindAllStringIndex(dataStr, -1)) - 1),
+	}
+	version := ""
+	if m := sopsVersionPat.FindStringSubmatch(dataStr); m != nil {
+		version = m[1]
+		extraData["version"] = version
+	}
+	setList(extraData, detectors.AWSKMSKeysKey, awsKMSPat.FindAllString(dataStr, -1))
+	setList(extraData, detectors.GCPKMSKeysKey, gcpKMSPat.FindAllString(dataStr, -1))
+	setList(extraData, "azure_kv_vaults", azureKVPat.FindAllString(dataStr, -1))
+	setList(extraData, "hc_vault_addresses", submatches(hcVaultPat, dataStr))
+	setList(extraData, "age_recipients", ageRecipPat.FindAllString(dataStr, -1))
+	setList(extraData, "pgp_fingerprints", submatches(pgpFPPat, dataStr))
+
+	redacted := "sops"
+	if version != "" {
+		redacted += " " + version
+	}
+	return detectors.Result{
+		DetectorType: detector_typepb.DetectorType_SopsAgeFile,
+		Raw:          []byte(mac),
+		Redacted:     redacted,
+		// 数据密钥由 KMS 或接收者的私钥保护, 单独泄露时无法离线解密
+		Severity:  detectors.SeverityLow,
+		ExtraData: extraData,
+	}
+}
+
+// ageResult 汇总 age 文件头中各接收者节的类型
+func ageResult(header, mac string, armored bool) detectors.Result {
+	extraData := map[string]string{"format": "age", "armored": strconv.FormatBool(armored)}
+	severity := detectors.SeverityLow
+
+	var types, sshTags []string
+	for _, line := range strings.Split(header, "\n") {
+		args := strings.Fields(strings.TrimPrefix(line, "-> "))
+		if !strings.HasPrefix(line, "-> ") || len(args) == 0 {
+			continue
+		}
+		types = appendUnique(types, args[0])
+		switch args[0] {
+		case "scrypt":
+			// 口令加密的文件可以离线爆破
+			severity = detectors.SeverityMedium
+			if len(args) == 3 {
+				extraData["scrypt_work_factor"] = args[2]
+			}
+		case "ssh-ed25519", "ssh-rsa":
+			// 第一个参数是接收者 SSH 公钥指纹的前 4 字节
+			if len(args) > 1 {
+				sshTags = appendUnique(sshTags, args[1])
+			}
+		}
+	}
+	setList(extraData, "recipient_types", types)
+	setList(extraData, "ssh_key_tags", sshTags)
+
+	return detectors.Result{
+		DetectorType: detector_typepb.DetectorType_SopsAgeFile,
+		Raw:          []byte("age:" + mac),
+		Redacted:     "age " + strings.Join(types, ","),
+		Severity:     severity,
+		ExtraData:    extraData,
+	}
+}
+
+func submatches(pat *regexp.Regexp, s string) []string {
+	var out []string
+	for _, m := range pat.FindAllStringSubmatch(s, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// setList 去重后以逗号分隔写入 extraData, 空列表不写入
+func setList(extraData map[string]string, key string, values []string) {
+	var unique []string
+	for _, v := range values {
+		unique = appendUnique(unique, v)
+	}
+	if len(unique) > 0 {
+		extraData[key] = strings.Join(unique, ",")
+	}
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SopsAgeFile
+}
+
+func (s Scanner) Description() string {
+	return "Files encrypted with SOPS or age. They are safe on their own, but the reported KMS keys and recipients show who can decrypt them; if credentials for one of those keys are leaked as well, the secrets inside are exposed."
+}
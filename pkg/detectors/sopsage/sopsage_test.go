@@ -0,0 +1,195 @@
+package sopsage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	kmsArn       = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	ageRecipient = "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"
+	sopsMac      = "ENC[AES256_GCM,data:6mIv5BtNAVmD8JG3zPdQ7SoYQ4hFuZx0kRZ4+Qb9xWA=,iv:yN0HZ4wMq3Ye0l1nA0sVKpPqAYB4tuVxg/6jM9vQbVY=,tag:k7pY4P1m9lZcHhHx1Pf5Yw==,type:str]"
+
+	sopsYAML = `database:
+    password: ENC[AES256_GCM,data:Tr7o1xk=,iv:1=,tag:/LgGs8Ni2FtqKtoYAz+3Tw==,type:str]
+    port: ENC[AES256_GCM,data:MzQ=,iv:2=,tag:Xl8qQYb/3E6e5W7XJ+Ii8A==,type:int]
+sops:
+    kms:
+        - arn: ` + kmsArn + `
+          created_at: "2024-03-11T09:12:44Z"
+          enc: AQICAHhzb3BzLWttcy1lbmNyeXB0ZWQtZGF0YS1rZXk=
+          aws_profile: ""
+    age:
+        - recipient: ` + ageRecipient + `
+          enc: |
+            -----BEGIN AGE ENCRYPTED FILE-----
+            YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSBTVnJ6ZEZma1B4ZjBMUEhP
+            -----END AGE ENCRYPTED FILE-----
+    lastmodified: "2024-03-11T09:12:45Z"
+    mac: ` + sopsMac + `
+    unencrypted_suffix: _unencrypted
+    version: 3.8.1
+`
+	sopsJSON = `{
+	"api_token": "ENC[AES256_GCM,data:cGxhY2Vob2xkZXI=,iv:3=,tag:b2WmUKVv1fTr9QyqGm6cFQ==,type:str]",
+	"sops": {
+		"gcp_kms": [
+			{
+				"resource_id": "projects/acme-prod/locations/global/keyRings/sops/cryptoKeys/app",
+				"enc": "CiQAaW1wb3J0YW50LWtleS1tYXRlcmlhbA=="
+			}
+		],
+		"pgp": [
+			{
+				"fp": "FBC7B9E2A4F9289AC0C1D4843D16CEE4A27381B4",
+				"enc": "-----BEGIN PGP MESSAGE-----\n\nhQEMA...\n-----END PGP MESSAGE-----"
+			}
+		],
+		"mac": "` + sopsMac + `",
+		"version": "3.7.3"
+	}
+}`
+	sopsDotenv = "API_TOKEN=ENC[AES256_GCM,data:cGxhY2Vob2xkZXI=,iv:3=,tag:b2WmUKVv1fTr9QyqGm6cFQ==,type:str]\n" +
+		"sops_hc_vault__list_0__map_vault_address=https://vault.example.com:8200\n" +
+		"sops_mac=" + sopsMac + "\n" +
+		"sops_version=3.8.1\n"
+
+	ageFile = "age-encryption.org/v1\n" +
+		"-> X25519 SVrzdFfkPxf0LPHOUGB1gNb9E5Vr8EUDa9kxk04iQ0o\n" +
+		"0OrTkKHpE7klNLd0k+9Uam5hkQkzMxaqKcIPRIO1sNE\n" +
+		"-> scrypt 7Xq1lmEvJ2VgK4pRsYbWZg 18\n" +
+		"Y4mDhrnhafVMYw5ZClmVoOaX8GZT1WnDYgU1z2VfE7I\n" +
+		"--- gxhoSa5BciRDt8lOpYNcx4EYtKpS0CJ06F3ZwN82VaM\n" +
+		"\x8f\x02\xa1\x13binary payload"
+	// 以下 armored 文件解码后的文件头包含一个 X25519 节和一个 ssh-ed25519 节
+	ageArmored = `-----BEGIN AGE ENCRYPTED FILE-----
+YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSBTVnJ6ZEZma1B4ZjBMUEhP
+VUdCMWdOYjlFNVZyOEVVRGE5a3hrMDRpUTBvCjBPclRrS0hwRTdrbE5MZDBrKzlV
+YW01aGtRa3pNeGFxS2NJUFJJTzFzTkUKLT4gc3NoLWVkMjU1MTkgWHlnMDZBIHJI
+MjR6dUZrQUZZVHI3TTBaeVBvR2dKbExEbU5uTHJDVmJJaUszY0tsRHMKWTRtRGhy
+bmhhZlZNWXc1WkNsbVZvT2FYOEdaVDFXbkRZZ1UxejJWZkU3SQotLS0gZ3hob1Nh
+NUJjaVJEdDhsT3BZTmN4NEVZdEtwUzBDSjA2RjNad044MlZhTQoAAQIDBAUGBwgJ
+CgsMDQ4PEBESExQVFhcYGRobHB0eHyAhIiMkJSYn
+-----END AGE ENCRYPTED FILE-----`
+)
+
+func TestSopsAge_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - sops yaml",
+			input: sopsYAML,
+			want:  []string{"sops 3.8.1"},
+		},
+		{
+			name:  "valid pattern - sops json",
+			input: sopsJSON,
+			want:  []string{"sops 3.7.3"},
+		},
+		{
+			name:  "valid pattern - sops dotenv",
+			input: sopsDotenv,
+			want:  []string{"sops 3.8.1"},
+		},
+		{
+			name:  "valid pattern - binary age file",
+			input: ageFile,
+			want:  []string{"age X25519,scrypt"},
+		},
+		{
+			name:  "valid pattern - armored age file",
+			input: "secrets.txt.age:\n" + ageArmored + "\n",
+			want:  []string{"age X25519,ssh-ed25519"},
+		},
+		{
+			name:  "invalid pattern - encrypted value without sops metadata",
+			input: "password: ENC[AES256_GCM,data:Tr7o1xk=,iv:1=,tag:/LgGs8Ni2FtqKtoYAz+3Tw==,type:str]",
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - age header without recipients",
+			input: "The format starts with age-encryption.org/v1 followed by recipient stanzas.",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Redacted)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestSopsAge_ExtraData(t *testing.T) {
+	s := Scanner{}
+
+	results, err := s.FromData(context.Background(), false, []byte(sopsYAML))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, sopsMac, string(results[0].Raw))
+	assert.Equal(t, map[string]string{
+		"format":                "sops",
+		"version":               "3.8.1",
+		"encrypted_values":      "2",
+		detectors.AWSKMSKeysKey: kmsArn,
+		"age_recipients":        ageRecipient,
+	}, results[0].ExtraData)
+	assert.Equal(t, detectors.SeverityLow, results[0].Severity)
+
+	results, err = s.FromData(context.Background(), false, []byte(sopsJSON))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "projects/acme-prod/locations/global/keyRings/sops/cryptoKeys/app", results[0].ExtraData[detectors.GCPKMSKeysKey])
+	assert.Equal(t, "FBC7B9E2A4F9289AC0C1D4843D16CEE4A27381B4", results[0].ExtraData["pgp_fingerprints"])
+
+	results, err = s.FromData(context.Background(), false, []byte(sopsDotenv))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "https://vault.example.com:8200", results[0].ExtraData["hc_vault_addresses"])
+
+	results, err = s.FromData(context.Background(), false, []byte(ageFile))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]string{
+		"format":             "age",
+		"armored":            "false",
+		"recipient_types":    "X25519,scrypt",
+		"scrypt_work_factor": "18",
+	}, results[0].ExtraData)
+	assert.Equal(t, detectors.SeverityMedium, results[0].Severity)
+
+	results, err = s.FromData(context.Background(), false, []byte(ageArmored))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "true", results[0].ExtraData["armored"])
+	assert.Equal(t, "Xyg06A", results[0].ExtraData["ssh_key_tags"])
+	assert.Equal(t, detectors.SeverityLow, results[0].Severity)
+}
@@ -0,0 +1,307 @@
+package springconfig
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alibaba"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cozetoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/jdbc"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+// target 是属性值交给哪个 detector 验证
+type target int
+
+const (
+	targetNone target = iota
+	targetJDBC
+	targetAlibaba
+	targetCoze
+)
+
+// downstream 是各目标对应的 detector
+var downstream = map[target]detectors.Detector{
+	targetJDBC:    jdbc.New(),
+	targetAlibaba: alibaba.Scanner{},
+	targetCoze:    cozetoken.Scanner{},
+}
+
+// property 描述一个已知的敏感属性
+type property struct {
+	// label 是属性的中英文说明, 写入 ExtraData
+	label  string
+	target target
+}
+
+// knownProperties 以规范化后的属性名为键, 规范化规则见 normalizeKey.
+// 这些属性名本身就说明了值的含义, 因此不做熵值和格式检查
+var knownProperties = map[string]property{
+	"spring.datasource.password":        {label: "数据源密码 (datasource password)", target: targetJDBC},
+	"spring.datasource.druid.password":  {label: "Druid 数据源密码 (Druid datasource password)", target: targetJDBC},
+	"spring.datasource.hikari.password": {label: "Hikari 数据源密码 (Hikari datasource password)", target: targetJDBC},
+	"spring.redis.password":             {label: "Redis 密码 (Redis password)"},
+	"spring.data.redis.password":        {label: "Redis 密码 (Redis password)"},
+	"spring.rabbitmq.password":          {label: "RabbitMQ 密码 (RabbitMQ password)"},
+	"spring.mail.password":              {label: "邮箱密码 (mail password)"},
+	"spring.cloud.nacos.password":       {label: "Nacos 密码 (Nacos password)"},
+	"jasypt.encryptor.password":         {label: "Jasypt 加密口令 (Jasypt encryptor password)"},
+	"jwt.secret":                        {label: "JWT 签名密钥 (JWT signing secret)"},
+	"aliyun.accesskeysecret":            {label: "阿里云 AccessKey Secret (Alibaba Cloud AccessKey secret)", target: targetAlibaba},
+	"aliyun.oss.accesskeysecret":        {label: "阿里云 OSS AccessKey Secret (Alibaba Cloud OSS AccessKey secret)", target: targetAlibaba},
+	"alibaba.cloud.secretkey":           {label: "阿里云 AccessKey Secret (Alibaba Cloud AccessKey secret)", target: targetAlibaba},
+	"spring.cloud.alicloud.secretkey":   {label: "阿里云 AccessKey Secret (Alibaba Cloud AccessKey secret)", target: targetAlibaba},
+	"coze.apitoken":                     {label: "扣子 API 令牌 (Coze API token)", target: targetCoze},
+	"coze.token":                        {label: "扣子 API 令牌 (Coze API token)", target: targetCoze},
+}
+
+// accessKeyIDs 是阿里云 AccessKey Secret 属性对应的 AccessKey ID 属性
+var accessKeyIDs = map[string]string{
+	"aliyun.accesskeysecret":          "aliyun.accesskeyid",
+	"aliyun.oss.accesskeysecret":      "aliyun.oss.accesskeyid",
+	"alibaba.cloud.secretkey":         "alibaba.cloud.accesskey",
+	"spring.cloud.alicloud.secretkey": "spring.cloud.alicloud.accesskey",
+}
+
+var (
+	// properties 文件的一行: key=value, key: value 或 key value
+	propertyLinePat = regexp.MustCompile(`^\s*([A-Za-z0-9_.\-\[\]]+)\s*[=:\s]\s*(.*?)\s*$`)
+	// 环境变量形式, 如 SPRING_DATASOURCE_PASSWORD
+	envKeyPat = regexp.MustCompile(`^[A-Z0-9_]+$`)
+	indexPat  = regexp.MustCompile(`\[\d+\]`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"spring", "jasypt", "jwt.", "jwt:", "jwt_", "aliyun", "alibaba", "coze"}
+}
+
+// entry 是配置中的一个属性
+type entry struct {
+	// key 是配置中原本的属性名
+	key   string
+	value string
+}
+
+// FromData will find well-known secret properties of Spring application.yml and application.properties files in a
+// given set of bytes, and optionally verify them with the detector of the service they belong to.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	props := parse(data)
+
+	for _, name := range detectors.SortedKeys(props) {
+		prop, ok := knownProperties[name]
+		if !ok {
+			continue
+		}
+		e := props[name]
+		value, ok := secretValue(e.value)
+		if !ok {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_SpringConfig,
+			Raw:          []byte(value),
+			RawV2:        []byte(name + "=" + value),
+			Redacted:     e.key,
+			ExtraData: map[string]string{
+				"property": e.key,
+				"label":    prop.label,
+			},
+		}
+		// 属性名直接说明了值是密钥, 不依赖格式或熵值判断
+		s1.AddEvidence("spring property "+name, 0.9)
+
+		text, ok := downstreamText(prop.target, name, value, props)
+		if ok {
+			s1.ExtraData["detector"] = detector_typepb.DetectorType_name[int32(downstream[prop.target].Type())]
+		}
+		if verify && ok {
+			isVerified, verificationErr := detectors.Downstream{downstream[prop.target]}.Verify(ctx, []byte(text))
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, value)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// downstreamText 按目标 detector 能识别的格式拼出待验证的文本, 缺少必要属性时返回 false
+func downstreamText(t target, name, value string, props map[string]entry) (string, bool) {
+	if downstream[t] == nil {
+		return "", false
+	}
+	switch t {
+	case targetJDBC:
+		prefix := strings.TrimSuffix(name, "password")
+		conn := props[prefix+"url"].value
+		if conn == "" {
+			// Hikari 使用 jdbc-url
+			conn = props[prefix+"jdbcurl"].value
+		}
+		return withCredentials(conn, props[prefix+"username"].value, value)
+	case targetAlibaba:
+		id := props[accessKeyIDs[name]].value
+		if id == "" {
+			return "", false
+		}
+		return id + "\n" + value, true
+	case targetCoze:
+		return value, true
+	default:
+		return "", false
+	}
+}
+
+// withCredentials 把用户名和密码写入 JDBC URL, Spring 通常将它们配置为单独的属性
+func withCredentials(conn, user, password string) (string, bool) {
+	lower := strings.ToLower(conn)
+	if !strings.HasPrefix(lower, "jdbc:") {
+		return "", false
+	}
+	if strings.HasPrefix(lower, "jdbc:sqlserver:") {
+		conn = strings.TrimSuffix(conn, ";")
+		if user != "" {
+			conn += ";user=" + user
+		}
+		return conn + ";password=" + password, true
+	}
+
+	before, after, ok := strings.Cut(conn, "//")
+	if !ok {
+		return "", false
+	}
+	host, _, _ := strings.Cut(after, "/")
+	if strings.Contains(host, "@") {
+		// URL 中已经带有凭证
+		return conn, true
+	}
+	return fmt.Sprintf("%s//%s@%s", before, url.UserPassword(user, password).String(), after), true
+}
+
+// secretValue 去掉引号并跳过占位符和加密值. ${DB_PASSWORD:secret} 的默认值 secret 会被保留
+func secretValue(value string) (string, bool) {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		_, def, ok := strings.Cut(value[2:len(value)-1], ":")
+		if !ok || strings.Contains(def, "${") {
+			return "", false
+		}
+		value = def
+	}
+	switch {
+	case value == "", strings.Trim(value, "*") == "":
+		return "", false
+	case strings.HasPrefix(value, "ENC(") && strings.HasSuffix(value, ")"):
+		// Jasypt 加密值, 没有口令无法使用
+		return "", false
+	}
+	return value, true
+}
+
+// parse 把 YAML 或 properties 格式的配置展开成以规范化属性名为键的扁平映射
+func parse(data []byte) map[string]entry {
+	props := make(map[string]entry)
+	if parseYAML(data, props) {
+		return props
+	}
+	parseProperties(data, props)
+	return props
+}
+
+func parseYAML(data []byte, props map[string]entry) bool {
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	parsed := false
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); err != nil {
+			// 单个属性行 "a.b: c" 也是合法的 YAML, 只有解析到映射时才算 YAML
+			return parsed && errors.Is(err, io.EOF)
+		}
+		if doc == nil {
+			continue
+		}
+		flatten("", doc, props)
+		parsed = true
+	}
+}
+
+func flatten(prefix string, node any, props map[string]entry) {
+	switch v := node.(type) {
+	case map[string]any:
+		for k, child := range v {
+			flatten(join(prefix, k), child, props)
+		}
+	case map[any]any:
+		// 含有非字符串键的映射, 如 0: value
+		for k, child := range v {
+			flatten(join(prefix, fmt.Sprint(k)), child, props)
+		}
+	case []any:
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), child, props)
+		}
+	case nil:
+	default:
+		props[normalizeKey(prefix)] = entry{key: prefix, value: fmt.Sprint(v)}
+	}
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func parseProperties(data []byte, props map[string]entry) {
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		}
+		match := propertyLinePat.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		props[normalizeKey(match[1])] = entry{key: match[1], value: match[2]}
+	}
+}
+
+// normalizeKey 按 Spring 的宽松绑定规则规范化属性名: 忽略大小写, '-' 和 '_', 以及列表下标.
+// 环境变量形式的 SPRING_DATASOURCE_PASSWORD 规范化为 spring.datasource.password
+func normalizeKey(key string) string {
+	if envKeyPat.MatchString(key) {
+		key = strings.ReplaceAll(key, "_", ".")
+	}
+	key = indexPat.ReplaceAllString(key, "")
+	key = strings.ToLower(key)
+	return strings.NewReplacer("-", "", "_", "").Replace(key)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SpringConfig
+}
+
+func (s Scanner) Description() string {
+	return "Spring application.yml and application.properties files configure datasource, cache, mail and cloud credentials under well-known property names. These values grant access to the application's databases and services."
+}
@@ -0,0 +1,73 @@
+package sqldumpcredential
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sqldump"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// 只匹配 sqldump handler 输出的数据行, 原始 INSERT 语句中的值由其他 detector 按格式识别
+func (s Scanner) Keywords() []string {
+	return []string{strings.TrimSpace(sqldump.HeaderPrefix)}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+// private_key 等列可能保存完整的 PEM 私钥
+func (s Scanner) MaxSecretSize() int64 { return 16 * 1024 }
+
+// FromData will find the values of credential columns, like api_key or client_secret, among the rows of SQL dumps
+// in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	seen := make(map[string]struct{})
+	for _, row := range sqldump.ParseRendered(string(data)) {
+		rowContext := row.Context()
+		for _, col := range row.Secrets() {
+			label := row.Table + "." + col.Name
+			// 同一列的相同值在多行中重复出现时只报告一次, 例如多个集成共用的密钥
+			if _, ok := seen[label+"="+col.Value]; ok {
+				continue
+			}
+			seen[label+"="+col.Value] = struct{}{}
+
+			extraData := map[string]string{
+				"table":  row.Table,
+				"column": col.Name,
+				"row":    strconv.Itoa(row.Number),
+			}
+			if rowContext != "" {
+				extraData["row_context"] = rowContext
+			}
+
+			results = append(results, detectors.Result{
+				DetectorType: detector_typepb.DetectorType_SQLDumpCredential,
+				Raw:          []byte(col.Value),
+				RawV2:        []byte(label + "=" + col.Value),
+				Redacted:     label + " row " + strconv.Itoa(row.Number),
+				// 数据库备份中的凭证是明文, 能读取备份的人即可使用
+				Severity:  detectors.SeverityHigh,
+				ExtraData: extraData,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SQLDumpCredential
+}
+
+func (s Scanner) Description() string {
+	return "Credentials stored in database tables and exposed by SQL dumps, such as API keys, client secrets and plain-text passwords in columns named after them. Database backups left in object storage or committed to git give anyone who can read them the credentials of every user and integration in the table."
+}
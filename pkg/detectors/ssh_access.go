@@ -0,0 +1,101 @@
+package detectors
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// SSHAccessReporter is an optional interface that a detector of SSH private keys can implement. The engine collects
+// the authorized_keys, known_hosts and ssh config files of each source unit and hands them to the detector, which
+// reports the hosts its keys grant access to.
+type SSHAccessReporter interface {
+	// ReportSSHAccess adds to result the hosts that the key behind it grants access to according to files. keyFile is
+	// the file the key was found in, if known. It returns false if the key grants access to none of them, in which
+	// case the result is dropped.
+	ReportSSHAccess(result *Result, keyFile string, files []SSHFile) bool
+}
+
+// SSHFile is an OpenSSH file found in a source unit.
+type SSHFile struct {
+	Name string
+	Data []byte
+}
+
+const (
+	// maxSSHFilesPerUnit bounds the files kept for a single source unit.
+	maxSSHFilesPerUnit = 32
+	// maxSSHFileSize bounds the size of a kept file. Real authorized_keys, known_hosts and config files are small.
+	maxSSHFileSize = 256 * 1024
+	// defaultSSHFileUnits is the number of source units whose files are kept in memory at the same time.
+	defaultSSHFileUnits = 1024
+)
+
+// IsSSHAccessFile reports whether file is an OpenSSH file that lists the keys or hosts a private key grants access
+// to: authorized_keys, known_hosts, or a client config (~/.ssh/config, ssh_config).
+func IsSSHAccessFile(file string) bool {
+	switch strings.ToLower(filepath.Base(file)) {
+	case "authorized_keys", "authorized_keys2", "known_hosts", "ssh_known_hosts", "ssh_config":
+		return true
+	case "config":
+		return filepath.Base(filepath.Dir(file)) == ".ssh"
+	default:
+		return false
+	}
+}
+
+// SSHFilePool collects the OpenSSH files of each source unit (e.g. a repository or a scanned directory), so that a
+// private key found in one file can be matched against the authorized_keys, known_hosts and config files next to
+// it. It is safe for concurrent use.
+type SSHFilePool struct {
+	mu    sync.Mutex
+	units *lru.Cache[string, []SSHFile]
+}
+
+// NewSSHFilePool creates an SSHFilePool that keeps the files of the most recently seen source units.
+func NewSSHFilePool() *SSHFilePool {
+	units, _ := lru.New[string, []SSHFile](defaultSSHFileUnits)
+	return &SSHFilePool{units: units}
+}
+
+// Add keeps data, read from file, for the given source unit if file is an OpenSSH file. A file read in several
+// chunks is kept whole.
+func (p *SSHFilePool) Add(unit, file string, data []byte) {
+	if p == nil || !IsSSHAccessFile(file) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	existing, _ := p.units.Get(unit)
+	files := append([]SSHFile(nil), existing...)
+	for i, f := range files {
+		if f.Name != file {
+			continue
+		}
+		if len(f.Data)+len(data) > maxSSHFileSize {
+			return
+		}
+		merged := append(append(make([]byte, 0, len(f.Data)+len(data)), f.Data...), data...)
+		files[i] = SSHFile{Name: file, Data: merged}
+		p.units.Add(unit, files)
+		return
+	}
+	if len(files) >= maxSSHFilesPerUnit || len(data) > maxSSHFileSize {
+		return
+	}
+	files = append(files, SSHFile{Name: file, Data: append([]byte(nil), data...)})
+	p.units.Add(unit, files)
+}
+
+// Files returns the OpenSSH files collected so far for the given source unit.
+func (p *SSHFilePool) Files(unit string) []SSHFile {
+	if p == nil {
+		return nil
+	}
+	files, _ := p.units.Get(unit)
+	return files
+}
@@ -0,0 +1,174 @@
+package sshkeyaccess
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// newKey generates an OpenSSH private key and returns it with its authorized_keys line.
+func newKey(t *testing.T, passphrase string) (privateKey, authorizedKey string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(priv, "deploy@ci")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, "deploy@ci", []byte(passphrase))
+	}
+	require.NoError(t, err)
+
+	pub, err := ssh.NewPublicKey(priv.Public())
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(block)), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub)))
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n"+prefix)
+}
+
+func TestSSHKeyAccess_FromData(t *testing.T) {
+	key, authorized := newKey(t, "")
+	encryptedKey, encryptedAuthorized := newKey(t, "hunter22")
+	_, otherAuthorized := newKey(t, "")
+
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name: "kubernetes secret",
+			input: `apiVersion: v1
+kind: Secret
+metadata:
+  name: git-sync
+stringData:
+  authorized_keys: |
+` + indent(otherAuthorized, "    ") + `
+` + indent(`no-pty,from="10.0.0.0/8" `+authorized, "    ") + `
+  known_hosts: |
+    github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl
+  config: |
+    Host prod bastion-prod
+      HostName 10.0.0.5
+      User deploy
+      Port 2222
+      IdentityFile /etc/git-secret/id_deploy
+    Host staging
+      IdentityFile ~/.ssh/id_other
+  id_deploy: |
+` + indent(key, "    "),
+			want: []map[string]string{{
+				authorizedKey:  "same file",
+				configHostsKey: "prod bastion-prod (deploy@10.0.0.5:2222)",
+			}},
+		},
+		{
+			name: "host key in provisioning script",
+			input: `cat > /etc/ssh/ssh_host_ed25519_key <<EOF
+` + key + `EOF
+echo "git.internal.example.com,10.1.2.3 ` + authorized + `" >> /etc/ssh/ssh_known_hosts
+`,
+			want: []map[string]string{{
+				hostKeyOfKey: "git.internal.example.com, 10.1.2.3",
+			}},
+		},
+		{
+			name: "encrypted key",
+			input: encryptedKey + `
+echo "` + encryptedAuthorized + `" >> ~/.ssh/authorized_keys
+`,
+			want: []map[string]string{{
+				authorizedKey: "same file",
+				"encrypted":   "true",
+			}},
+		},
+		{
+			name:  "key without access",
+			input: key,
+			want:  []map[string]string{{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []map[string]string
+			for _, r := range results {
+				assert.True(t, strings.HasPrefix(r.ExtraData["fingerprint"], "SHA256:"))
+				extraData := make(map[string]string)
+				for k, v := range r.ExtraData {
+					if k != "fingerprint" {
+						extraData[k] = v
+					}
+				}
+				got = append(got, extraData)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestSSHKeyAccess_ReportSSHAccess(t *testing.T) {
+	key, authorized := newKey(t, "")
+	_, otherAuthorized := newKey(t, "")
+
+	files := []detectors.SSHFile{
+		{
+			Name: "home/deploy/.ssh/authorized_keys",
+			Data: []byte("# managed by ansible\n" + otherAuthorized + "\ncommand=\"/usr/bin/backup\" " + authorized + "\n"),
+		},
+		{
+			Name: "etc/ssh/ssh_known_hosts",
+			Data: []byte("|1|F1E1KeoE/eEWhi10WpGv4OdiO6Y=|3988QV0VE8wmZL7suNrYQLITLCg= " + authorized + "\n@revoked old.example.com " + authorized + "\n"),
+		},
+		{
+			Name: "home/deploy/.ssh/config",
+			Data: []byte("IdentityFile ~/.ssh/id_ed25519\n\nHost db-*\n  User postgres\n  IdentityFile ~/.ssh/id_deploy\n\nHost backup\n  HostName backup.example.com\n  IdentityFile ~/.ssh/id_other\n"),
+		},
+	}
+
+	d := Scanner{}
+	results, err := d.FromData(context.Background(), false, []byte(key))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	res := results[0]
+	require.True(t, d.ReportSSHAccess(&res, "home/deploy/.ssh/id_deploy", files))
+	assert.Equal(t, "home/deploy/.ssh/authorized_keys:3", res.ExtraData[authorizedKey])
+	assert.Equal(t, "hashed host", res.ExtraData[hostKeyOfKey])
+	assert.Equal(t, "db-*", res.ExtraData[configHostsKey])
+
+	res = results[0]
+	res.ExtraData = map[string]string{}
+	assert.False(t, d.ReportSSHAccess(&res, "home/deploy/.ssh/id_unused", files[2:]))
+	assert.True(t, d.ReportSSHAccess(&res, "home/deploy/.ssh/id_ed25519", files[2:]))
+	assert.Equal(t, "*", res.ExtraData[configHostsKey])
+}
@@ -2,8 +2,11 @@ package stripe
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -12,22 +15,35 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 )
 
-type Scanner struct{}
+type Scanner struct {
+	client *http.Client
+}
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
 
 var (
+	defaultClient = common.SaneHttpClient()
+
 	// doesn't include test keys with "sk_test"
 	secretKey = regexp.MustCompile(`[rs]k_live_[a-zA-Z0-9]{20,247}`)
 )
 
+const accountURL = "https://api.stripe.com/v1/account"
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
 	return []string{"k_live"}
 }
 
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
 // FromData will find and optionally verify Stripe secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 
@@ -40,31 +56,22 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		result := detectors.Result{
 			DetectorType: detector_typepb.DetectorType_Stripe,
 			Raw:          []byte(match),
+			// Live secret keys can move money; restricted keys only have the permissions they were created with.
+			Severity: detectors.SeverityCritical,
 		}
 		result.ExtraData = map[string]string{
 			"rotation_guide": "https://howtorotate.com/docs/tutorials/stripe/",
 		}
+		if strings.HasPrefix(match, "rk_") {
+			result.Severity = detectors.SeverityHigh
+		}
 
 		if verify {
-
-			baseURL := "https://api.stripe.com/v1/charges"
-
-			client := common.SaneHttpClient()
-
-			// test `read_user` scope
-			req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
-			if err != nil {
-				continue
-			}
-			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", match))
-			req.Header.Add("Content-Type", "application/json")
-			res, err := client.Do(req)
-			if err == nil {
-				res.Body.Close() // The request body is unused.
-
-				if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusForbidden {
-					result.Verified = true
-				}
+			isVerified, account, verificationErr := verifyAccount(ctx, s.getClient(), accountURL, match)
+			result.Verified = isVerified
+			result.SetVerificationError(verificationErr, match)
+			for k, v := range account {
+				result.ExtraData[k] = v
 			}
 			result.AnalysisInfo = map[string]string{"key": match}
 		}
@@ -75,6 +82,67 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
+type account struct {
+	ID              string `json:"id"`
+	Email           string `json:"email"`
+	Country         string `json:"country"`
+	BusinessProfile struct {
+		Name string `json:"name"`
+	} `json:"business_profile"`
+	Settings struct {
+		Dashboard struct {
+			DisplayName string `json:"display_name"`
+		} `json:"dashboard"`
+	} `json:"settings"`
+}
+
+// verifyAccount retrieves the account the key belongs to. Restricted keys without the permission to read the account
+// are rejected with 403, which still proves the key is valid.
+// docs: https://docs.stripe.com/api/accounts/retrieve
+func verifyAccount(ctx context.Context, client *http.Client, url, key string) (bool, map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", key))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var acct account
+		if err := json.NewDecoder(res.Body).Decode(&acct); err != nil || acct.ID == "" {
+			return true, nil, nil
+		}
+		extraData := map[string]string{"account_id": acct.ID}
+		if name := acct.Settings.Dashboard.DisplayName; name != "" {
+			extraData["account_name"] = name
+		} else if acct.BusinessProfile.Name != "" {
+			extraData["account_name"] = acct.BusinessProfile.Name
+		}
+		if acct.Email != "" {
+			extraData["account_email"] = acct.Email
+		}
+		if acct.Country != "" {
+			extraData["account_country"] = acct.Country
+		}
+		return true, extraData, nil
+	case http.StatusForbidden:
+		return true, nil, nil
+	case http.StatusUnauthorized:
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
 func (s Scanner) Type() detector_typepb.DetectorType {
 	return detector_typepb.DetectorType_Stripe
 }
@@ -49,6 +49,7 @@ func TestStripe_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Stripe,
 					Verified:     true,
+					Severity:     detectors.SeverityCritical,
 					ExtraData: map[string]string{
 						"rotation_guide": "https://howtorotate.com/docs/tutorials/stripe/",
 					},
@@ -68,6 +69,7 @@ func TestStripe_FromChunk(t *testing.T) {
 				{
 					DetectorType: detector_typepb.DetectorType_Stripe,
 					Verified:     false,
+					Severity:     detectors.SeverityCritical,
 					ExtraData: map[string]string{
 						"rotation_guide": "https://howtorotate.com/docs/tutorials/stripe/",
 					},
@@ -101,6 +103,10 @@ func TestStripe_FromChunk(t *testing.T) {
 				}
 				got[i].Raw = nil
 				got[i].AnalysisInfo = nil
+				// Account details depend on the test account.
+				for _, k := range []string{"account_id", "account_name", "account_email", "account_country"} {
+					delete(got[i].ExtraData, k)
+				}
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Stripe.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
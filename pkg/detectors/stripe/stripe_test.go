@@ -3,9 +3,13 @@ package stripe
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
@@ -79,3 +83,36 @@ func TestStripe_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestStripe_VerifyAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer sk_live_valid":
+			_, _ = w.Write([]byte(`{"id":"acct_1Nv0FGQ9RKHgCVdK","email":"billing@example.com","country":"SG","settings":{"dashboard":{"display_name":"Example Shop"}}}`))
+		case "Bearer rk_live_restricted":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	verified, extraData, err := verifyAccount(context.Background(), server.Client(), server.URL, "sk_live_valid")
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{
+		"account_id":      "acct_1Nv0FGQ9RKHgCVdK",
+		"account_name":    "Example Shop",
+		"account_email":   "billing@example.com",
+		"account_country": "SG",
+	}, extraData)
+
+	verified, extraData, err = verifyAccount(context.Background(), server.Client(), server.URL, "rk_live_restricted")
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Nil(t, extraData)
+
+	verified, _, err = verifyAccount(context.Background(), server.Client(), server.URL, "sk_live_revoked")
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
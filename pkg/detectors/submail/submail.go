@@ -0,0 +1,150 @@
+package submail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 赛邮的 appid 是 5 位左右的数字, appkey 是 32 位十六进制; 官方 PHP SDK 的配置写成 'appid' => '12345'
+	appIDPat  = regexp.MustCompile(`(?i)app[_-]?id[\]"'\s:=>]+["']?([0-9]{5,6})\b`)
+	appKeyPat = regexp.MustCompile(`(?i)(?:app[_-]?key|signature)[\]"'\s:=>]+["']?([a-f0-9]{32})\b`)
+)
+
+const balanceURL = "https://api-v4.mysubmail.com/balance/sms"
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"submail", "赛邮"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Submail appid/appkey pairs in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueIDs := make(map[string]struct{})
+	for _, match := range appIDPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueIDs[match[1]] = struct{}{}
+	}
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range appKeyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+
+	for _, appID := range detectors.SortedKeys(uniqueIDs) {
+		for _, appKey := range detectors.SortedKeys(uniqueKeys) {
+			s1 := detectors.Result{
+				DetectorType: detector_typepb.DetectorType_Submail,
+				Raw:          []byte(appID),
+				RawV2:        []byte(appID + ":" + appKey),
+				Redacted:     appID,
+			}
+
+			if verify {
+				isVerified, extraData, verificationErr := verifyApp(ctx, s.getClient(), balanceURL, appID, appKey)
+				s1.Verified = isVerified
+				s1.ExtraData = extraData
+				s1.SetVerificationError(verificationErr, appKey)
+			}
+
+			results = append(results, s1)
+		}
+	}
+
+	return results, nil
+}
+
+type balanceResponse struct {
+	Status               string      `json:"status"`
+	Balance              json.Number `json:"balance"`
+	TransactionalBalance json.Number `json:"transactional_balance"`
+	Code                 json.Number `json:"code"`
+	Msg                  string      `json:"msg"`
+}
+
+// verifyApp 查询短信余额, 该接口只读. 明文模式下 signature 直接传 appkey, 不需要计算摘要
+func verifyApp(ctx context.Context, client *http.Client, endpoint, appID, appKey string) (bool, map[string]string, error) {
+	form := url.Values{"appid": {appID}, "signature": {appKey}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	var body balanceResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, nil, err
+	}
+
+	// 赛邮无论成功与否都返回 200, 错误码在 code 中, 例如 101 表示 appid 不存在
+	switch body.Status {
+	case "success":
+		extraData := map[string]string{}
+		if body.Balance != "" {
+			extraData["balance"] = body.Balance.String()
+		}
+		if body.TransactionalBalance != "" {
+			extraData["transactional_balance"] = body.TransactionalBalance.String()
+		}
+		return true, extraData, nil
+	case "error":
+		return false, map[string]string{"error": strings.TrimSpace(body.Code.String() + " " + body.Msg)}, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected response status %q", body.Status)
+	}
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagChinaCloud} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Submail
+}
+
+func (s Scanner) Description() string {
+	return "Submail is a Chinese SMS, email and voice messaging platform. A Submail appid and appkey can be used to send messages billed to the account."
+}
@@ -0,0 +1,106 @@
+package submail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	validAppID  = "10254"
+	validAppKey = "6b0e3f5a2c9d47e1b8a4f2c0d9e7b315"
+)
+
+func TestSubmail_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "valid pattern - php sdk config",
+			input: `// submail
+$message_configs['appid'] = '` + validAppID + `';
+$message_configs['appkey'] = '` + validAppKey + `';`,
+			want: []string{validAppID + ":" + validAppKey},
+		},
+		{
+			name:  "valid pattern - yaml",
+			input: "submail:\n  app_id: " + validAppID + "\n  app_key: " + validAppKey,
+			want:  []string{validAppID + ":" + validAppKey},
+		},
+		{
+			name:  "invalid pattern - no appid",
+			input: "submail:\n  app_key: " + validAppKey,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestSubmail_VerifyApp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("appid") != validAppID || r.PostForm.Get("signature") != validAppKey {
+			_, _ = w.Write([]byte(`{"status":"error","code":101,"msg":"Incorrect APPID"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","balance":"1200","transactional_balance":"300"}`))
+	}))
+	defer server.Close()
+
+	verified, extraData, err := verifyApp(context.Background(), server.Client(), server.URL, validAppID, validAppKey)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{"balance": "1200", "transactional_balance": "300"}, extraData)
+
+	verified, extraData, err = verifyApp(context.Background(), server.Client(), server.URL, "99999", validAppKey)
+	require.NoError(t, err)
+	assert.False(t, verified)
+	assert.Equal(t, "101 Incorrect APPID", extraData["error"])
+}
@@ -0,0 +1,166 @@
+package supabaseservicekey
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// baseURL 仅用于测试, 默认为 https://<project_ref>.supabase.co
+	baseURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// Supabase 的 anon key 和 service_role key 都是 HS256 签名的 JWT, 只能通过 payload 中的 role 区分
+	keyPat = regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]{10,}\.(eyJ[A-Za-z0-9_-]{20,})\.[A-Za-z0-9_-]{20,})`)
+	// project ref 为 20 位小写字母
+	refPat = regexp.MustCompile(`^[a-z]{20}$`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	// {"iss":"supabase", 的 base64 编码
+	return []string{"supabase", "eyJpc3MiOiJzdXBhYmFzZSIs"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// claims 是 Supabase 项目 API key 的 JWT payload
+type claims struct {
+	Iss  string `json:"iss"`
+	Ref  string `json:"ref"`
+	Role string `json:"role"`
+}
+
+// FromData will find and optionally verify Supabase service_role keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueKeys := make(map[string]claims)
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		c, ok := parseClaims(match[2])
+		// anon key 本来就会下发到浏览器, 受 RLS 限制; 只有 service_role key 能绕过 RLS
+		if !ok || c.Iss != "supabase" || c.Role != "service_role" || !refPat.MatchString(c.Ref) {
+			continue
+		}
+		uniqueKeys[match[1]] = c
+	}
+
+	for _, key := range detectors.SortedKeys(uniqueKeys) {
+		c := uniqueKeys[key]
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_SupabaseServiceKey,
+			Raw:          []byte(key),
+			ExtraData: map[string]string{
+				"project_ref": c.Ref,
+				"role":        c.Role,
+			},
+			Severity: detectors.SeverityCritical,
+		}
+		if expiresAt, ok := detectors.JWTExpiry(key); ok {
+			s1.SetExpiresAt(expiresAt)
+		}
+
+		if verify {
+			baseURL := s.baseURL
+			if baseURL == "" {
+				baseURL = "https://" + c.Ref + ".supabase.co"
+			}
+			isVerified, tables, verificationErr := verifyServiceKey(ctx, s.getClient(), baseURL, key)
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, key)
+			if len(tables) > 0 {
+				s1.ExtraData["tables"] = strings.Join(tables, ",")
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+func parseClaims(payload string) (claims, bool) {
+	var c claims
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(payload, "="))
+	if err != nil {
+		return c, false
+	}
+	if err := json.Unmarshal(decoded, &c); err != nil {
+		return c, false
+	}
+	return c, true
+}
+
+// verifyServiceKey 请求项目的 PostgREST 根路径, 返回的 OpenAPI 文档中列出了 key 可以访问的表
+// docs: https://supabase.com/docs/guides/api
+func verifyServiceKey(ctx context.Context, client *http.Client, baseURL, key string) (bool, []string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/rest/v1/", nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("apikey", key)
+	req.Header.Set("Authorization", "Bearer "+key)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		var spec struct {
+			Definitions map[string]json.RawMessage `json:"definitions"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&spec); err != nil {
+			// key 有效, 只是无法列出表
+			return true, nil, nil
+		}
+		tables := make([]string, 0, len(spec.Definitions))
+		for table := range spec.Definitions {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+		return true, tables, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_SupabaseServiceKey
+}
+
+func (s Scanner) Description() string {
+	return "Supabase is an open source Firebase alternative. The service_role key of a Supabase project bypasses Row Level Security and gives full read and write access to the project's database and storage."
+}
@@ -0,0 +1,51 @@
+package detectors
+
+// Tags shared across detectors. Results may carry several of them.
+const (
+	// TagCrypto marks secrets of cryptocurrency wallets, nodes and exchanges.
+	TagCrypto = "crypto"
+	// TagWallet marks secrets that control funds directly, such as private keys and seed phrases.
+	TagWallet = "wallet"
+	// TagCloud marks credentials of cloud providers.
+	TagCloud = "cloud"
+	// TagChinaCloud marks credentials of cloud providers operating in mainland China.
+	TagChinaCloud = "china-cloud"
+	// TagAI marks keys of AI model APIs.
+	TagAI = "ai"
+	// TagMonitoring marks credentials of error tracking and monitoring services.
+	TagMonitoring = "monitoring"
+	// TagMFA marks second factors, such as TOTP seeds.
+	TagMFA = "mfa"
+	// TagNetwork marks credentials of VPNs and network devices.
+	TagNetwork = "network"
+	// TagKubernetes marks secrets found in Kubernetes manifests and Helm charts.
+	TagKubernetes = "kubernetes"
+	// TagSupplyChain marks secrets that let attackers publish or sign software as its owner.
+	TagSupplyChain = "supply-chain"
+	// TagInfrastructure marks credentials of cluster schedulers, service meshes and key-value stores.
+	TagInfrastructure = "infrastructure"
+	// TagIdentity marks credentials of identity providers, such as OIDC client secrets.
+	TagIdentity = "identity"
+	// TagCommunications marks credentials of SMS, voice and video APIs, which can be abused for toll fraud.
+	TagCommunications = "communications"
+)
+
+// AddTags appends the tags that the result does not carry yet.
+func (r *Result) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if !r.HasTag(tag) {
+			// Never append in place, as the slice may be shared with cached verification results.
+			r.Tags = append(r.Tags[:len(r.Tags):len(r.Tags)], tag)
+		}
+	}
+}
+
+// HasTag reports whether the result carries the tag.
+func (r *Result) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
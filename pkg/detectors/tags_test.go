@@ -0,0 +1,30 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestResult_AddTags(t *testing.T) {
+	shared := make([]string, 1, 4)
+	shared[0] = TagCrypto
+
+	res := Result{Tags: shared}
+	res.AddTags(TagWallet, TagCrypto, TagWallet)
+	assert.Equal(t, []string{TagCrypto, TagWallet}, res.Tags)
+	assert.True(t, res.HasTag(TagWallet))
+	assert.False(t, res.HasTag(TagCloud))
+
+	// The original slice's backing array is not written to.
+	assert.Equal(t, []string{TagCrypto, ""}, shared[:2])
+}
+
+func TestSeverity_Proto(t *testing.T) {
+	assert.Equal(t, detectorspb.Severity_SEVERITY_UNSPECIFIED, SeverityUnspecified.Proto())
+	assert.Equal(t, detectorspb.Severity_SEVERITY_LOW, SeverityLow.Proto())
+	assert.Equal(t, detectorspb.Severity_SEVERITY_CRITICAL, SeverityCritical.Proto())
+	assert.Equal(t, "SEVERITY_CRITICAL", SeverityCritical.Proto().String())
+}
@@ -0,0 +1,82 @@
+package terraformstate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/tfstate"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+
+// minSecretLength 过短的值多为占位符或默认值
+const minSecretLength = 6
+
+// Keywords are used for efficiently pre-filtering chunks.
+// 只匹配 tfstate handler 输出的资源块, 原始 JSON 中的属性由其他 detector 按格式识别
+func (s Scanner) Keywords() []string {
+	return []string{strings.TrimSpace(tfstate.HeaderPrefix)}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+// kube_config_raw 等属性包含完整的 kubeconfig
+func (s Scanner) MaxSecretSize() int64 { return 16 * 1024 }
+
+// FromData will find secrets among the resource attributes of Terraform state files in a given set of bytes.
+// Attributes are reported if Terraform marked them as sensitive or if they are known to hold a secret, like the
+// result of a random_password or the keys of a storage account.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	seen := make(map[string]struct{})
+	for _, attr := range tfstate.ParseRendered(string(data)) {
+		resourceType := tfstate.ResourceType(attr.Address)
+		if !attr.Sensitive && !tfstate.IsSecret(resourceType, attr.Name) {
+			continue
+		}
+		if len(attr.Value) < minSecretLength || strings.HasPrefix(attr.Value, "arn:") {
+			continue
+		}
+
+		label := attr.Address + "." + attr.Name
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+
+		extraData := map[string]string{
+			"resource_address": attr.Address,
+			"attribute":        attr.Name,
+		}
+		if resourceType != "" {
+			extraData["resource_type"] = resourceType
+		}
+		if attr.Sensitive {
+			extraData["sensitive"] = "true"
+		}
+
+		results = append(results, detectors.Result{
+			DetectorType: detector_typepb.DetectorType_TerraformStateSecret,
+			Raw:          []byte(attr.Value),
+			RawV2:        []byte(label + "=" + attr.Value),
+			Redacted:     label,
+			// state 文件中的值是明文, 能读取 state 的人即可使用这些凭证
+			Severity:  detectors.SeverityHigh,
+			ExtraData: extraData,
+		})
+	}
+
+	return results, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_TerraformStateSecret
+}
+
+func (s Scanner) Description() string {
+	return "Secrets stored in plain text in Terraform state files, such as generated passwords, access keys and connection strings. Anyone who can read the state, for example because it was committed to git, can use them."
+}
@@ -0,0 +1,90 @@
+package terraformstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// 与 tfstate handler 输出的格式相同
+const renderedState = `# terraform state: module.db.aws_db_instance.main
+module.db.aws_db_instance.main.identifier = "prod-db"
+module.db.aws_db_instance.main.password = "Xk9#mQ2vL7pR4wZt"  # sensitive
+# terraform state: random_password.api[0]
+random_password.api[0].length = "32"
+random_password.api[0].result = "q7Vn_3kRz!8LwPe2Tj5YbHx0"
+# terraform state: azurerm_storage_account.logs
+azurerm_storage_account.logs.name = "prodlogs"
+azurerm_storage_account.logs.primary_connection_string = "DefaultEndpointsProtocol=https;AccountName=prodlogs;AccountKey=c2VjcmV0a2V5Zm9ydGVzdGluZ29ubHkxMjM0NTY3ODkw;EndpointSuffix=core.windows.net"
+azurerm_storage_account.logs.id = "arn:aws:iam::123456789012:user/ci"
+# terraform state: output
+output.db_password = "Xk9#mQ2vL7pR4wZt"  # sensitive
+`
+
+func TestTerraformState_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern",
+			input: renderedState,
+			want: []string{
+				"module.db.aws_db_instance.main.password",
+				"random_password.api[0].result",
+				"azurerm_storage_account.logs.primary_connection_string",
+				"output.db_password",
+			},
+		},
+		{
+			name:  "invalid pattern - raw HCL",
+			input: "# terraform state: see backend.tf\nresource \"aws_db_instance\" \"main\" {\n  password = \"Xk9#mQ2vL7pR4wZt\"\n}",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Redacted)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformState_ExtraData(t *testing.T) {
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(renderedState))
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+
+	assert.Equal(t, "Xk9#mQ2vL7pR4wZt", string(results[0].Raw))
+	assert.Equal(t, map[string]string{
+		"resource_address": "module.db.aws_db_instance.main",
+		"attribute":        "password",
+		"resource_type":    "aws_db_instance",
+		"sensitive":        "true",
+	}, results[0].ExtraData)
+}
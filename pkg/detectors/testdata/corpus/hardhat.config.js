@@ -0,0 +1,39 @@
+require("@nomicfoundation/hardhat-toolbox");
+require("dotenv").config();
+
+const { PRIVATE_KEY, ALCHEMY_API_KEY, ETHERSCAN_API_KEY } = process.env;
+
+// Deployer used for the staging fork. Rotate before mainnet!
+const STAGING_DEPLOYER_KEY = "0xc962bcbd8a2f9ea44dffd93ff8d05ed3c77779b6a538da5934d61560a0e99d84";
+
+/** @type import('hardhat/config').HardhatUserConfig */
+module.exports = {
+  solidity: {
+    version: "0.8.24",
+    settings: { optimizer: { enabled: true, runs: 200 } },
+  },
+  networks: {
+    hardhat: {
+      forking: {
+        url: `https://eth-mainnet.g.alchemy.com/v2/${ALCHEMY_API_KEY}`,
+        blockNumber: 19876543,
+      },
+    },
+    staging: {
+      url: "https://rpc.sepolia.org",
+      chainId: 11155111,
+      accounts: [STAGING_DEPLOYER_KEY],
+    },
+    mainnet: {
+      url: `https://eth-mainnet.g.alchemy.com/v2/${ALCHEMY_API_KEY}`,
+      accounts: PRIVATE_KEY ? [PRIVATE_KEY] : [],
+    },
+  },
+  namedAccounts: {
+    deployer: { default: 0 },
+    treasury: "0x2a14cd027e63f10e1d72995de1ff4df795d80888",
+  },
+  etherscan: { apiKey: ETHERSCAN_API_KEY },
+  // Storage slot of the EIP-1967 implementation pointer, used by the upgrade checks.
+  upgradeSlot: "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc",
+};
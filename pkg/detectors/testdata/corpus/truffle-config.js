@@ -0,0 +1,27 @@
+const HDWalletProvider = require("@truffle/hdwallet-provider");
+
+const mnemonic = "pencil flame arrow caution stable bunker motor decade hip rival drum fatigue";
+
+module.exports = {
+  networks: {
+    development: {
+      host: "127.0.0.1",
+      port: 8545,
+      network_id: "*",
+    },
+    goerli: {
+      provider: () =>
+        new HDWalletProvider(mnemonic, "https://goerli.infura.io/v3/9aa3d95b3bc440fa88ea12eaa4456161"),
+      network_id: 5,
+      gas: 5500000,
+    },
+    bsc: {
+      provider: () =>
+        new HDWalletProvider("dea2243444abfdb9c809e9338264d3353fa61a4000266f0ddbc5602031a96c79", "https://bsc-dataseed1.binance.org"),
+      network_id: 56,
+    },
+  },
+  compilers: {
+    solc: { version: "0.8.19" },
+  },
+};
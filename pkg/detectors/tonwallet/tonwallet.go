@@ -0,0 +1,244 @@
+package tonwallet
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// toncenterURL 覆盖 toncenter API 地址, 用于测试
+	toncenterURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+var _ detectors.ContextHintProvider = (*Scanner)(nil)
+
+const (
+	credentialMnemonic  = "mnemonic"
+	credentialSecretKey = "secret_key"
+	credentialSeed      = "private_key"
+)
+
+// 公共 toncenter API, 不带 API key 时限速 1 次/秒
+const defaultToncenterURL = "https://toncenter.com/api/v2"
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 24 个单词, 以空格分隔, 或者是 JS/JSON 数组 ["word", "word", ...], mnemonicNew() 的输出通常以后者出现在代码中
+	mnemonicPat = regexp.MustCompile(`\b[a-z]{3,8}(?:(?:[ \t]+|["']?[ \t]*,\s*["']?)[a-z]{3,8}){23,}\b`)
+	mnemonicSep = regexp.MustCompile(`[^a-z]+`)
+
+	// ed25519 私钥: 32 字节种子, 或者 tonweb / @ton/crypto 的 64 字节 secretKey (种子 + 公钥), 十六进制或 base64
+	keyPat = regexp.MustCompile(`(?i)\bton[\w.-]{0,20}?(?:private|secret)[_-]?key["'\s:=]+(?:0x)?([0-9a-f]{128}|[0-9a-f]{64}|[a-z0-9+/]{86}==|[a-z0-9+/]{43}=)(?:[^0-9a-z+/=]|$)`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+// 助记词本身没有标识, "ton" 的 shim 要求其后是钱包相关的变量名或 TON 的库名
+func (s Scanner) Keywords() []string {
+	return []string{"ton"}
+}
+
+// ContextHints implements detectors.ContextHintProvider, 十六进制私钥与其他链的私钥格式相同
+func (s Scanner) ContextHints() []string {
+	return []string{"toncenter", "tonweb", "@ton/", "tonkeeper", "mnemonicToPrivateKey", "mnemonicToWalletKey"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getToncenterURL() string {
+	if s.toncenterURL != "" {
+		return s.toncenterURL
+	}
+	return defaultToncenterURL
+}
+
+// FromData will find and optionally verify TON wallet mnemonics and ed25519 private keys in a given set of bytes.
+// Credentials are verified when one of the wallets derived from them holds a balance.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// raw -> 凭据类型
+	uniqueKeys := make(map[string]string)
+	keys := make(map[string]ed25519.PrivateKey)
+	for _, match := range mnemonicPat.FindAllString(dataStr, -1) {
+		words := mnemonicSep.Split(match, -1)
+		if !isValidMnemonic(words) {
+			continue
+		}
+		mnemonic := strings.Join(words, " ")
+		if _, ok := uniqueKeys[mnemonic]; ok {
+			continue
+		}
+		key, err := mnemonicPrivateKey(words)
+		if err != nil {
+			continue
+		}
+		uniqueKeys[mnemonic] = credentialMnemonic
+		keys[mnemonic] = key
+	}
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		raw := match[1]
+		key, credentialType, ok := decodeKey(raw)
+		if !ok {
+			continue
+		}
+		uniqueKeys[raw] = credentialType
+		keys[raw] = key
+	}
+
+	for _, raw := range detectors.SortedKeys(uniqueKeys) {
+		publicKey := keys[raw].Public().(ed25519.PublicKey)
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_TONWallet,
+			Raw:          []byte(raw),
+			ExtraData: map[string]string{
+				"credential_type": uniqueKeys[raw],
+				"public_key":      hex.EncodeToString(publicKey),
+			},
+		}
+		addresses := make(map[string][]byte, len(walletVersions))
+		for _, v := range walletVersions {
+			addresses[v.name] = walletAddress(v, publicKey)
+			s1.ExtraData["wallet_"+v.name+"_address"] = friendlyAddress(addresses[v.name])
+		}
+		s1.Redacted = s1.ExtraData["wallet_v4r2_address"]
+
+		if verify {
+			var verificationErr error
+			for _, v := range walletVersions {
+				balance, err := fetchBalance(ctx, s.getClient(), s.getToncenterURL(), rawAddress(addresses[v.name]))
+				if err != nil {
+					verificationErr = err
+					continue
+				}
+				if balance != "" && balance != "0" {
+					s1.Verified = true
+					s1.ExtraData["wallet_"+v.name+"_balance"] = balance + " nanoton"
+				}
+			}
+			if !s1.Verified {
+				s1.SetVerificationError(verificationErr, raw)
+			}
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// isValidMnemonic 只接受 24 个单词且通过 TON 校验的助记词, 并排除占位符, 例如同一个单词重复多次
+func isValidMnemonic(words []string) bool {
+	if len(words) != 24 {
+		return false
+	}
+	unique := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		unique[w] = struct{}{}
+	}
+	return len(unique) >= len(words)/2 && isBasicSeed(words)
+}
+
+// decodeKey 解码十六进制或 base64 的私钥. 64 字节的 secretKey 后半部分是公钥, 与种子派生出的公钥不一致的不是 ed25519 私钥
+func decodeKey(raw string) (ed25519.PrivateKey, string, bool) {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		if b, err = base64.StdEncoding.DecodeString(raw); err != nil {
+			return nil, "", false
+		}
+	}
+	switch len(b) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(b), credentialSeed, true
+	case ed25519.PrivateKeySize:
+		key := ed25519.NewKeyFromSeed(b[:ed25519.SeedSize])
+		if !key.Public().(ed25519.PublicKey).Equal(ed25519.PublicKey(b[ed25519.SeedSize:])) {
+			return nil, "", false
+		}
+		return key, credentialSecretKey, true
+	default:
+		return nil, "", false
+	}
+}
+
+// balanceResponse 是 /getAddressBalance 的响应, result 为 nanoton 余额
+type balanceResponse struct {
+	OK     bool   `json:"ok"`
+	Result string `json:"result"`
+	Error  string `json:"error"`
+}
+
+// fetchBalance 返回地址的 nanoton 余额, 未部署的钱包余额为 "0"
+func fetchBalance(ctx context.Context, client *http.Client, toncenterURL, address string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, toncenterURL+"/getAddressBalance?address="+url.QueryEscape(address), http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	var balance balanceResponse
+	if err := json.NewDecoder(res.Body).Decode(&balance); err != nil {
+		return "", err
+	}
+	if !balance.OK {
+		return "", fmt.Errorf("toncenter error: %s", balance.Error)
+	}
+	return balance.Result, nil
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_TONWallet
+}
+
+func (s Scanner) Description() string {
+	return "TON wallet mnemonics and ed25519 private keys control wallets on The Open Network, the blockchain used by Telegram wallets and mini apps. Anyone with the mnemonic or key can transfer all Toncoin, jettons and NFTs of the wallet."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityCritical }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+func (s Scanner) Remediation() string {
+	return detectors.WalletRemediation("TON wallet") +
+		" The key controls a wallet under every wallet contract version, such as v3r2 and v4r2, so sweep each of them."
+}
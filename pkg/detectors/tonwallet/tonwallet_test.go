@@ -0,0 +1,139 @@
+package tonwallet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testMnemonic  = "rocket slam cover useful march velvet banner ticket hire width gospel orbit foil almond mouse ladder pepper shrimp random crane canyon inner attract truly"
+	testSeed      = "7e58f7e777171b0f3dcd78e68cf98b2169a114a9314743c7f96b39a4311ac412"
+	testPublicKey = "6f30215b719e4ca63ff3c1725abef3300a6b9126f74c3f7a54acb835a87a7709"
+	testV3R2      = "UQC3ns43JxVjZhEipwQRZz2WiQ_440rn7BvbNi4TAF5gGtCg"
+	testV4R2      = "UQDZ3g3GVVlRvJdU8vmMbiBuVn3S4GOuoMSpFSPYOdKb9wx2"
+
+	// The same words in an order that doesn't pass the TON checksum, like a BIP39 mnemonic of another chain.
+	invalidMnemonic = "march hire attract inner cave slam truly mandate cover foil mouse width orbit velvet almond crane ladder pepper ticket random useful banner gospel shrimp"
+)
+
+func TestTONWallet_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	wallet := func(credentialType string) map[string]string {
+		return map[string]string{
+			"credential_type":     credentialType,
+			"public_key":          testPublicKey,
+			"wallet_v3r2_address": testV3R2,
+			"wallet_v4r2_address": testV4R2,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name:  "mnemonic in env file",
+			input: fmt.Sprintf("TON_NETWORK=mainnet\nTON_WALLET_MNEMONIC=%q\n", testMnemonic),
+			want:  []map[string]string{wallet(credentialMnemonic)},
+		},
+		{
+			name: "mnemonic array",
+			input: fmt.Sprintf("import { mnemonicToPrivateKey } from '@ton/crypto';\n\nconst mnemonic = [\n  '%s',\n];\n",
+				strings.ReplaceAll(testMnemonic, " ", "',\n  '")),
+			want: []map[string]string{wallet(credentialMnemonic)},
+		},
+		{
+			name:  "hex seed",
+			input: "TON_PRIVATE_KEY=" + testSeed,
+			want:  []map[string]string{wallet(credentialSeed)},
+		},
+		{
+			name:  "hex secret key",
+			input: `{"tonSecretKey": "` + testSeed + testPublicKey + `"}`,
+			want:  []map[string]string{wallet(credentialSecretKey)},
+		},
+		{
+			name:  "invalid pattern - secret key with another public key",
+			input: `{"tonSecretKey": "` + testSeed + strings.Repeat("ab", 32) + `"}`,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - checksum",
+			input: "ton wallet mnemonic: " + invalidMnemonic,
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - placeholder",
+			input: "TON_MNEMONIC=" + strings.TrimSpace(strings.Repeat("word ", 24)),
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []map[string]string
+			for _, r := range results {
+				got = append(got, r.ExtraData)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestTONWallet_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("address") {
+		case "0:d9de0dc6555951bc9754f2f98c6e206e567dd2e063aea0c4a91523d839d29bf7":
+			fmt.Fprint(w, `{"ok":true,"result":"2500000000"}`)
+		case "0:b79ece37271563661122a70411673d96890ff8e34ae7ec1bdb362e13005e601a":
+			fmt.Fprint(w, `{"ok":true,"result":"0"}`)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"ok":false,"error":"Ratelimit exceed","code":429}`)
+		}
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), toncenterURL: server.URL}
+
+	results, err := d.FromData(context.Background(), true, []byte("TON_MNEMONIC="+testMnemonic))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+	assert.Equal(t, testV4R2, results[0].Redacted)
+	assert.Equal(t, "2500000000 nanoton", results[0].ExtraData["wallet_v4r2_balance"])
+	assert.NotContains(t, results[0].ExtraData, "wallet_v3r2_balance")
+
+	// Any other key hits the rate limit, so its verification is indeterminate.
+	results, err = d.FromData(context.Background(), true, []byte("TON_PRIVATE_KEY="+strings.Repeat("1f", 32)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.Error(t, results[0].VerificationError())
+}
@@ -0,0 +1,125 @@
+package tonwallet
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+// TON 助记词不是 BIP39: 没有校验和位, 而是要求熵派生出的种子首字节为 0, 所以每 256 个随机组合中只有一个有效
+const (
+	pbkdfIterations    = 100000
+	seedVersionSalt    = "TON seed version"
+	defaultSeedSalt    = "TON default seed"
+	defaultWalletID    = 698983191
+	basechainWorkchain = 0
+)
+
+// walletVersion 是一个钱包合约版本, 地址是 StateInit (合约代码 + 初始数据) 的 cell 哈希, 同一个公钥在每个版本上对应不同的地址
+type walletVersion struct {
+	name string
+	// codeHash 和 codeDepth 是合约代码 cell 的哈希和深度
+	codeHash  string
+	codeDepth uint16
+	// plugins 为 true 时初始数据末尾有一个空的插件字典
+	plugins bool
+}
+
+// 钱包 App 默认创建的版本, v4r2 为 Tonkeeper 等钱包多年来的默认版本
+var walletVersions = []walletVersion{
+	{name: "v3r2", codeHash: "84dafa449f98a6987789ba232358072bc0f76dc4524002a5d0918b9a75d2d599", codeDepth: 0},
+	{name: "v4r2", codeHash: "feb5ff6820e2ff0d9483e7e0d62c817d846789fb4ae580c878866d959dabd5c0", codeDepth: 7, plugins: true},
+}
+
+// mnemonicEntropy 返回助记词 (不带密码) 的熵
+func mnemonicEntropy(words []string) []byte {
+	mac := hmac.New(sha512.New, []byte(strings.Join(words, " ")))
+	return mac.Sum(nil)
+}
+
+// isBasicSeed 判断助记词是否为不带密码的 TON 助记词
+func isBasicSeed(words []string) bool {
+	seed, err := pbkdf2.Key(sha512.New, string(mnemonicEntropy(words)), []byte(seedVersionSalt), pbkdfIterations/256, 64)
+	return err == nil && seed[0] == 0
+}
+
+// mnemonicPrivateKey 返回助记词对应的 ed25519 私钥
+func mnemonicPrivateKey(words []string) (ed25519.PrivateKey, error) {
+	seed, err := pbkdf2.Key(sha512.New, string(mnemonicEntropy(words)), []byte(defaultSeedSalt), pbkdfIterations, 64)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.NewKeyFromSeed(seed[:ed25519.SeedSize]), nil
+}
+
+// cellRef 是 cell 引用的子 cell
+type cellRef struct {
+	hash  []byte
+	depth uint16
+}
+
+// cellHash 返回一个普通 cell 的表示哈希, bits 为数据的位数, 不足一个字节的部分已带上补齐标记位
+func cellHash(data []byte, bits int, refs ...cellRef) []byte {
+	h := sha256.New()
+	h.Write([]byte{byte(len(refs)), byte(bits/8 + (bits+7)/8)})
+	h.Write(data)
+	for _, r := range refs {
+		h.Write(binary.BigEndian.AppendUint16(nil, r.depth))
+	}
+	for _, r := range refs {
+		h.Write(r.hash)
+	}
+	return h.Sum(nil)
+}
+
+// walletAddress 返回公钥在 basechain 上对应版本钱包的地址哈希
+func walletAddress(v walletVersion, publicKey ed25519.PublicKey) []byte {
+	// seqno:uint32 subwallet_id:uint32 public_key:bits256 [plugins:(HashmapE 267 int1)]
+	data := binary.BigEndian.AppendUint32(make([]byte, 4), defaultWalletID)
+	data = append(data, publicKey...)
+	bits := len(data) * 8
+	if v.plugins {
+		// 空字典的 0 位, 之后是补齐标记位
+		data = append(data, 0x40)
+		bits++
+	}
+	codeHash, _ := hex.DecodeString(v.codeHash)
+
+	// StateInit: split_depth:(Maybe) special:(Maybe) code:(Maybe ^Cell) data:(Maybe ^Cell) library:(HashmapE), 即 00110
+	return cellHash([]byte{0x34}, 5, cellRef{hash: codeHash, depth: v.codeDepth}, cellRef{hash: cellHash(data, bits)})
+}
+
+// rawAddress 返回 "0:<hex>" 格式的地址
+func rawAddress(hash []byte) string {
+	return "0:" + hex.EncodeToString(hash)
+}
+
+// friendlyAddress 返回钱包 App 显示的 base64url 地址, 未部署的钱包使用 non-bounceable 地址 (UQ 开头)
+func friendlyAddress(hash []byte) string {
+	const nonBounceable = 0x51
+	b := append([]byte{nonBounceable, basechainWorkchain}, hash...)
+	b = binary.BigEndian.AppendUint16(b, crc16(b))
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// crc16 是 CRC-16/XMODEM
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
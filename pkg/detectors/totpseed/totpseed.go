@@ -0,0 +1,196 @@
+package totpseed
+
+import (
+	"context"
+	"encoding/base32"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const (
+	// RFC 4226 要求种子至少 128 位, 但很多服务 (包括 Google Authenticator 的示例) 使用 80 位
+	minSeedBytes = 10
+	maxSeedBytes = 64
+)
+
+var (
+	// otpauth://totp/Issuer:account?secret=...&issuer=...
+	uriPat = regexp.MustCompile(`(?i)\botpauth://(?:totp|hotp)/[^\s"'<>]*`)
+
+	// 配置或代码中 2fa/totp/google_authenticator 附近的 base32 种子, 例如
+	// TOTP_SECRET=JBSWY3DPEHPK3PXPJBSWY3DP
+	// 也支持 Google Authenticator 等应用显示的按 4 个字符分组的小写形式
+	contextPat = regexp.MustCompile(`(?i)(?:2fa|totp|google[_-]?authenticator)[\w.-]{0,20}?["'\s:=]+((?:[a-z2-7]{4} ){3,15}[a-z2-7]{4}\b|[a-z2-7]{16,103}={0,6})`)
+
+	// 文档和教程中常见的示例种子: Google Authenticator wiki, pyotp 文档和 RFC 6238 的测试种子
+	exampleSeeds = map[string]struct{}{
+		"JBSWY3DPEHPK3PXP":                 {},
+		"BASE32SECRET3232":                 {},
+		"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ": {},
+	}
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"otpauth://", "2fa", "totp", "google_authenticator", "google-authenticator", "googleauthenticator"}
+}
+
+// FromData will find TOTP/HOTP seeds in a given set of bytes.
+// 种子没有可以在线验证的接口, 因此只做 base32 和长度校验.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 用于去重, URI 中的种子优先, 因为带有 issuer 和账户
+	seen := make(map[string]struct{})
+
+	for _, match := range uriPat.FindAllString(dataStr, -1) {
+		key, ok := parseURI(match)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[key.seed]; ok {
+			continue
+		}
+		seen[key.seed] = struct{}{}
+		results = append(results, key.result())
+	}
+
+	for _, match := range contextPat.FindAllStringSubmatch(dataStr, -1) {
+		// 没有 URI 的结构时, 要求至少包含一个数字, 以排除由单词组成的配置值
+		seed, ok := normalizeSeed(match[1])
+		if !ok || !strings.ContainsAny(seed, "234567") {
+			continue
+		}
+		if _, ok := seen[seed]; ok {
+			continue
+		}
+		seen[seed] = struct{}{}
+		results = append(results, otpKey{seed: seed, kind: "totp", format: "context"}.result())
+	}
+
+	return results, nil
+}
+
+// otpKey 是从 otpauth URI 或上下文中提取的种子
+type otpKey struct {
+	seed    string
+	kind    string
+	format  string
+	issuer  string
+	account string
+	params  map[string]string
+}
+
+func (k otpKey) result() detectors.Result {
+	s1 := detectors.Result{
+		DetectorType: detector_typepb.DetectorType_TOTPSeed,
+		Raw:          []byte(k.seed),
+		Redacted:     k.seed[:4] + "...",
+		ExtraData: map[string]string{
+			"type":   k.kind,
+			"format": k.format,
+		},
+	}
+	if k.issuer != "" {
+		s1.ExtraData["issuer"] = k.issuer
+	}
+	if k.account != "" {
+		s1.ExtraData["account"] = k.account
+	}
+	for name, v := range k.params {
+		s1.ExtraData[name] = v
+	}
+	return s1
+}
+
+// parseURI 解析 otpauth URI, 格式见
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func parseURI(uri string) (otpKey, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || !strings.EqualFold(u.Scheme, "otpauth") {
+		return otpKey{}, false
+	}
+	query := u.Query()
+	seed, ok := normalizeSeed(query.Get("secret"))
+	if !ok {
+		return otpKey{}, false
+	}
+
+	key := otpKey{
+		seed:   seed,
+		kind:   strings.ToLower(u.Host),
+		format: "otpauth_uri",
+		issuer: query.Get("issuer"),
+		params: map[string]string{},
+	}
+	// 标签的格式为 "Issuer:account", issuer 是可选的
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, found := strings.Cut(label, ":"); found {
+		if key.issuer == "" {
+			key.issuer = strings.TrimSpace(issuer)
+		}
+		key.account = strings.TrimSpace(account)
+	} else {
+		key.account = strings.TrimSpace(label)
+	}
+	for _, name := range []string{"algorithm", "digits", "period", "counter"} {
+		if v := query.Get(name); v != "" {
+			key.params[name] = v
+		}
+	}
+	return key, true
+}
+
+// normalizeSeed 去掉分组的空格和填充, 转为大写, 并校验 base32 编码和种子长度
+func normalizeSeed(raw string) (string, bool) {
+	seed := strings.ToUpper(strings.ReplaceAll(raw, " ", ""))
+	seed = strings.TrimRight(seed, "=")
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(seed)
+	if err != nil || len(decoded) < minSeedBytes || len(decoded) > maxSeedBytes {
+		return "", false
+	}
+	if _, ok := exampleSeeds[seed]; ok {
+		return "", false
+	}
+	// 排除占位符, 例如 AAAAAAAAAAAAAAAA 或 ABCDEFGHIJKLMNOP
+	unique := make(map[rune]struct{})
+	for _, r := range seed {
+		unique[r] = struct{}{}
+	}
+	if len(unique) < min(len(seed)/3, 12) || isSequential(seed) {
+		return "", false
+	}
+	return seed, true
+}
+
+// isSequential 判断种子是否是 base32 字母表中连续的字符
+func isSequential(seed string) bool {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	return strings.Contains(alphabet+alphabet, seed)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_TOTPSeed
+}
+
+func (s Scanner) Description() string {
+	return "TOTP/HOTP seeds of two-factor authentication, in otpauth:// URIs or next to 2FA settings. A leaked seed generates valid one-time codes forever, and often sits next to the password or exchange API key it protects."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagMFA} }
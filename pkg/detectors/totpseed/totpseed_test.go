@@ -0,0 +1,121 @@
+package totpseed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testSeed     = "KZXW6YTPNZSXE4DP"
+	testLongSeed = "R7MQ2VJXK4LPWN6TZC3HYD5BGF2SAEUQ"
+)
+
+func TestTOTPSeed_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid otpauth uri",
+			input: `qr = "otpauth://totp/Binance:trader%40example.com?secret=` + testSeed + `&issuer=Binance&digits=6"`,
+			want:  []string{testSeed},
+		},
+		{
+			name: "valid seed in trading bot config",
+			input: `
+BINANCE_API_KEY=placeholder
+BINANCE_2FA_SECRET=` + testLongSeed + `
+`,
+			want: []string{testLongSeed},
+		},
+		{
+			name:  "valid grouped lowercase seed",
+			input: `GOOGLE_AUTHENTICATOR_KEY = "q3vm 7hrx 2lpc w5nd"`,
+			want:  []string{"Q3VM7HRX2LPCW5ND"},
+		},
+		{
+			name:  "valid seed once for uri and context",
+			input: `totp_uri: otpauth://totp/GitHub:octocat?secret=` + testSeed + "\ntotp_secret: " + testSeed,
+			want:  []string{testSeed},
+		},
+		{
+			name:  "invalid - example seed",
+			input: `otpauth://totp/Example:alice@google.com?secret=JBSWY3DPEHPK3PXP&issuer=Example`,
+			want:  nil,
+		},
+		{
+			name:  "invalid - not base32",
+			input: `TOTP_SECRET=KZXW6YTPNZSXE4D1`,
+			want:  nil,
+		},
+		{
+			name:  "invalid - words in config",
+			input: `totp: requiredforadministrators`,
+			want:  nil,
+		},
+		{
+			name:  "invalid - placeholder",
+			input: `TOTP_SECRET=ABCDEFGHIJKLMNOP`,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestTOTPSeed_URIMetadata(t *testing.T) {
+	input := `otpauth://totp/Binance:trader%40example.com?secret=` + testSeed + `&algorithm=SHA1&period=30`
+	results, err := Scanner{}.FromData(context.Background(), false, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, map[string]string{
+		"type":      "totp",
+		"format":    "otpauth_uri",
+		"issuer":    "Binance",
+		"account":   "trader@example.com",
+		"algorithm": "SHA1",
+		"period":    "30",
+	}, results[0].ExtraData)
+	assert.Equal(t, "KZXW...", results[0].Redacted)
+}
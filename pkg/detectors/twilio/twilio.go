@@ -17,10 +17,15 @@ import (
 type Scanner struct {
 	detectors.DefaultMultiPartCredentialProvider
 	client *http.Client
+	// apiURL overrides the Twilio REST API in tests.
+	apiURL string
 }
 
 // Ensure the Scanner satisfies the interface at compile time.
 var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const defaultAPIURL = "https://api.twilio.com"
 
 var (
 	defaultClient = common.RetryableHTTPClient()
@@ -28,14 +33,12 @@ var (
 	keyPat        = regexp.MustCompile(`\b[0-9a-f]{32}\b`)
 )
 
-type serviceResponse struct {
-	Services []service `json:"services"`
-}
-
-type service struct {
-	FriendlyName string `json:"friendly_name"` // friendly name of a service
-	SID          string `json:"sid"`           // object id of service
-	AccountSID   string `json:"account_sid"`   // account sid
+// account is the account resource of the Twilio REST API.
+type account struct {
+	SID          string `json:"sid"`
+	FriendlyName string `json:"friendly_name"`
+	Status       string `json:"status"` // active, suspended or closed
+	Type         string `json:"type"`   // Trial or Full
 }
 
 func (s Scanner) getClient() *http.Client {
@@ -46,6 +49,13 @@ func (s Scanner) getClient() *http.Client {
 	return defaultClient
 }
 
+func (s Scanner) getAPIURL() string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return defaultAPIURL
+}
+
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
@@ -73,9 +83,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 
 			if verify {
-				extraData, isVerified, verificationErr := verifyTwilio(ctx, s.getClient(), key, sid)
+				extraData, isVerified, verificationErr := verifyTwilio(ctx, s.getClient(), s.getAPIURL(), key, sid)
 				s1.Verified = isVerified
-				s1.SetVerificationError(verificationErr)
+				s1.SetVerificationError(verificationErr, key)
 
 				for key, value := range extraData {
 					s1.ExtraData[key] = value
@@ -101,18 +111,19 @@ func (s Scanner) Description() string {
 	return "Twilio is a cloud communications platform that allows software developers to programmatically make and receive phone calls, send and receive text messages, and perform other communication functions using its web service APIs."
 }
 
-func verifyTwilio(ctx context.Context, client *http.Client, key, sid string) (map[string]string, bool, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://verify.twilio.com/v2/Services", nil)
+// verifyTwilio fetches the account of the SID with the auth token. A valid pair reports the name, status and type of
+// the account; trial accounts can only message verified numbers, full accounts can run up charges.
+func verifyTwilio(ctx context.Context, client *http.Client, apiURL, key, sid string) (map[string]string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/2010-04-01/Accounts/"+sid+".json", http.NoBody)
 	if err != nil {
-		return nil, false, nil
+		return nil, false, err
 	}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add("Accept", "*/*")
+	req.Header.Add("Accept", "application/json")
 	req.SetBasicAuth(sid, key)
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, false, nil
+		return nil, false, err
 	}
 	defer func() {
 		_, _ = io.Copy(io.Discard, resp.Body)
@@ -121,15 +132,16 @@ func verifyTwilio(ctx context.Context, client *http.Client, key, sid string) (ma
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		extraData := make(map[string]string)
-		var serviceResponse serviceResponse
-
-		if err := json.NewDecoder(resp.Body).Decode(&serviceResponse); err == nil && len(serviceResponse.Services) > 0 { // no error in parsing and have at least one service
-			service := serviceResponse.Services[0]
-			extraData["friendly_name"] = service.FriendlyName
-			extraData["account_sid"] = service.AccountSID
+		var acct account
+		if err := json.NewDecoder(resp.Body).Decode(&acct); err != nil {
+			return nil, true, nil
+		}
+		extraData := map[string]string{
+			"account_sid":    acct.SID,
+			"friendly_name":  acct.FriendlyName,
+			"account_status": acct.Status,
+			"account_type":   acct.Type,
 		}
-
 		return extraData, true, nil
 	case http.StatusUnauthorized, http.StatusForbidden:
 		return nil, false, nil
@@ -137,3 +149,9 @@ func verifyTwilio(ctx context.Context, client *http.Client, key, sid string) (ma
 		return nil, false, fmt.Errorf("unexpected HTTP response status %d", resp.StatusCode)
 	}
 }
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCommunications} }
@@ -56,7 +56,7 @@ func TestTwilio_FromChunk(t *testing.T) {
 					Redacted:     id,
 					RawV2:        []byte(id + secret),
 					ExtraData: map[string]string{
-						"account_sid":    "ACa5b6165773490f33f226d71e7ffacff5",
+						"account_sid":    id,
 						"friendly_name":  "MyServiceName",
 						"rotation_guide": "https://howtorotate.com/docs/tutorials/twilio/",
 					},
@@ -155,9 +155,16 @@ func TestTwilio_FromChunk(t *testing.T) {
 				if (got[i].VerificationError() != nil) != tt.wantVerificationErr {
 					t.Fatalf("wantVerificationError = %v, verification error = %v", tt.wantVerificationErr, got[i].VerificationError())
 				}
+				if got[i].Verified && (got[i].ExtraData["account_status"] == "" || got[i].ExtraData["account_type"] == "") {
+					t.Fatalf("no account status or type for verified credentials: \n %+v", got[i])
+				}
 			}
 			ignoreOpts := cmpopts.IgnoreFields(detectors.Result{}, "Raw", "verificationError", "AnalysisInfo")
-			if diff := cmp.Diff(got, tt.want, ignoreOpts); diff != "" {
+			// The status and type of the test account may change; their presence is checked above.
+			ignoreAccount := cmpopts.IgnoreMapEntries(func(k, _ string) bool {
+				return k == "account_status" || k == "account_type"
+			})
+			if diff := cmp.Diff(got, tt.want, ignoreOpts, ignoreAccount); diff != "" {
 				t.Errorf("Twilio.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
 			}
 		})
@@ -3,9 +3,13 @@ package twilio
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
@@ -81,3 +85,35 @@ func TestTwilio_Pattern(t *testing.T) {
 		})
 	}
 }
+
+func TestTwilio_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sid, key, _ := r.BasicAuth()
+		if r.URL.Path != "/2010-04-01/Accounts/"+validSid+".json" || sid != validSid || key != validKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"sid":%q,"friendly_name":"Acme notifications","status":"active","type":"Full"}`, validSid)
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), apiURL: server.URL}
+
+	results, err := d.FromData(context.Background(), true, []byte(fmt.Sprintf("TWILIO_ACCOUNT_SID=%s\nTWILIO_AUTH_TOKEN=%s\n", validSid, validKey)))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, map[string]string{
+		"account_sid":    validSid,
+		"friendly_name":  "Acme notifications",
+		"account_status": "active",
+		"account_type":   "Full",
+		"rotation_guide": "https://howtorotate.com/docs/tutorials/twilio/",
+	}, results[0].ExtraData)
+
+	results, err = d.FromData(context.Background(), true, []byte(fmt.Sprintf("TWILIO_ACCOUNT_SID=%s\nTWILIO_AUTH_TOKEN=%s\n", validSid, "1af7b3d34b9787f1212316eea62ba186")))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+}
@@ -0,0 +1,275 @@
+package vpncredential
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+const (
+	kindWireGuard     = "wireguard_private_key"
+	kindOpenVPNKey    = "openvpn_private_key"
+	kindOpenVPNStatic = "openvpn_static_key"
+	kindIPsecPSK      = "ipsec_psk"
+
+	// OpenVPN 的 static key (tls-auth/tls-crypt) 是 2048 位
+	staticKeyBytes = 256
+	minPSKLength   = 6
+)
+
+var (
+	// WireGuard 配置 [Interface] 中的 PrivateKey, 32 字节的 base64
+	wgKeyPat      = regexp.MustCompile(`(?im)^[ \t]*PrivateKey[ \t]*=[ \t]*([A-Za-z0-9+/]{42}[AEIMQUYcgkosw048]=)[ \t]*$`)
+	wgEndpointPat = regexp.MustCompile(`(?im)^[ \t]*Endpoint[ \t]*=[ \t]*(\S+)`)
+
+	// .ovpn 中内联的 <key> 私钥, 以及 <tls-auth>/<tls-crypt> 的 static key
+	ovpnKeyPat       = regexp.MustCompile(`(?s)<key>\s*(-----BEGIN [A-Z ]*PRIVATE KEY-----.+?-----END [A-Z ]*PRIVATE KEY-----)\s*</key>`)
+	ovpnStaticKeyPat = regexp.MustCompile(`(?s)<(tls-auth|tls-crypt|secret)>.*?-----BEGIN OpenVPN Static key V1-----(.+?)-----END OpenVPN Static key V1-----`)
+	ovpnRemotePat    = regexp.MustCompile(`(?im)^[ \t]*remote[ \t]+([\w.:-]+)(?:[ \t]+(\d{1,5}))?`)
+
+	// strongSwan/Libreswan ipsec.secrets: <ids> : PSK "secret"
+	ipsecSecretsPat = regexp.MustCompile(`(?im)^[ \t]*([^\n:#]*?)[ \t]*:[ \t]*PSK[ \t]+("[^"\n]+"|'[^'\n]+'|\S+)`)
+	// Cisco IOS: crypto isakmp key <psk> address <peer>
+	ciscoISAKMPPat = regexp.MustCompile(`(?im)\bcrypto isakmp key[ \t]+(?:0[ \t]+)?(\S+)[ \t]+address[ \t]+([0-9a-f.:]+)`)
+	// Cisco IOS keyring: pre-shared-key address <peer> [mask] key <psk>
+	// Cisco ASA tunnel-group: ikev1 pre-shared-key <psk>
+	ciscoKeyringPat = regexp.MustCompile(`(?im)\bpre-shared-key[ \t]+address[ \t]+([0-9a-f.:]+)(?:[ \t]+[0-9.]+)?[ \t]+key[ \t]+(?:0[ \t]+)?(\S+)`)
+	ciscoTunnelPat  = regexp.MustCompile(`(?im)\bikev[12][ \t]+(?:local-authentication[ \t]+|remote-authentication[ \t]+)?pre-shared-key[ \t]+(?:0[ \t]+)?(\S+)[ \t]*$`)
+	ciscoGroupPat   = regexp.MustCompile(`(?im)^[ \t]*tunnel-group[ \t]+([0-9a-f.:]+)[ \t]+ipsec-attributes`)
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"privatekey", "<key>", "openvpn static key", ": psk", "isakmp", "pre-shared-key"}
+}
+
+// FromData will find WireGuard private keys, inline OpenVPN keys and IPsec pre-shared keys in a given set of bytes.
+// 这些凭据没有可以在线验证的接口, 因此只校验密钥的长度和曲线, 并报告同一配置中的对端地址.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	// 用于去重
+	seen := make(map[string]struct{})
+	newResult := func(kind, secret, redacted string, endpoints []string, extra map[string]string) {
+		if _, ok := seen[kind+":"+secret]; ok {
+			return
+		}
+		seen[kind+":"+secret] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_VPNCredential,
+			Raw:          []byte(secret),
+			Redacted:     redacted,
+			ExtraData:    map[string]string{"kind": kind},
+		}
+		if len(endpoints) > 0 {
+			s1.ExtraData["endpoints"] = strings.Join(endpoints, ",")
+		}
+		for k, v := range extra {
+			s1.ExtraData[k] = v
+		}
+		results = append(results, s1)
+	}
+
+	if matches := wgKeyPat.FindAllStringSubmatch(dataStr, -1); len(matches) > 0 {
+		endpoints := collect(wgEndpointPat, dataStr, func(m []string) string { return m[1] })
+		for _, match := range matches {
+			publicKey, ok := wireGuardPublicKey(match[1])
+			if !ok {
+				continue
+			}
+			newResult(kindWireGuard, match[1], match[1][:6]+"...", endpoints, map[string]string{"public_key": publicKey})
+		}
+	}
+
+	ovpnKeys := ovpnKeyPat.FindAllStringSubmatch(dataStr, -1)
+	ovpnStaticKeys := ovpnStaticKeyPat.FindAllStringSubmatch(dataStr, -1)
+	if len(ovpnKeys) > 0 || len(ovpnStaticKeys) > 0 {
+		remotes := collect(ovpnRemotePat, dataStr, func(m []string) string {
+			if m[2] == "" {
+				return m[1]
+			}
+			return net.JoinHostPort(m[1], m[2])
+		})
+		for _, match := range ovpnKeys {
+			keyType, ok := privateKeyType(match[1])
+			if !ok {
+				continue
+			}
+			newResult(kindOpenVPNKey, match[1], keyType+" private key", remotes, map[string]string{"key_type": keyType})
+		}
+		for _, match := range ovpnStaticKeys {
+			key, ok := staticKey(match[2])
+			if !ok {
+				continue
+			}
+			newResult(kindOpenVPNStatic, key, key[:8]+"...", remotes, map[string]string{"block": match[1]})
+		}
+	}
+
+	for _, match := range ipsecSecretsPat.FindAllStringSubmatch(dataStr, -1) {
+		psk, ok := presharedKey(match[2])
+		if !ok {
+			continue
+		}
+		newResult(kindIPsecPSK, psk, redactPSK(psk), strings.Fields(match[1]), map[string]string{"format": "ipsec.secrets"})
+	}
+	for _, match := range ciscoISAKMPPat.FindAllStringSubmatch(dataStr, -1) {
+		if psk, ok := presharedKey(match[1]); ok {
+			newResult(kindIPsecPSK, psk, redactPSK(psk), []string{match[2]}, map[string]string{"format": "cisco"})
+		}
+	}
+	for _, match := range ciscoKeyringPat.FindAllStringSubmatch(dataStr, -1) {
+		if psk, ok := presharedKey(match[2]); ok {
+			newResult(kindIPsecPSK, psk, redactPSK(psk), []string{match[1]}, map[string]string{"format": "cisco"})
+		}
+	}
+	if matches := ciscoTunnelPat.FindAllStringSubmatch(dataStr, -1); len(matches) > 0 {
+		// ASA 的 tunnel-group 名称通常就是对端地址
+		peers := collect(ciscoGroupPat, dataStr, func(m []string) string { return m[1] })
+		for _, match := range matches {
+			if psk, ok := presharedKey(match[1]); ok {
+				newResult(kindIPsecPSK, psk, redactPSK(psk), peers, map[string]string{"format": "cisco"})
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// collect 返回所有匹配去重后的值, 保持出现的顺序
+func collect(pat *regexp.Regexp, data string, value func([]string) string) []string {
+	var values []string
+	seen := make(map[string]struct{})
+	for _, match := range pat.FindAllStringSubmatch(data, -1) {
+		v := value(match)
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	return values
+}
+
+// wireGuardPublicKey 校验 Curve25519 私钥并推导出公钥, 公钥可以在对端的 [Peer] 中找到
+func wireGuardPublicKey(encoded string) (string, bool) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return "", false
+	}
+	// 排除全零的占位符
+	if strings.Count(encoded, "A") >= 42 {
+		return "", false
+	}
+	priv, err := ecdh.X25519().NewPrivateKey(key)
+	if err != nil {
+		return "", false
+	}
+	return base64.StdEncoding.EncodeToString(priv.PublicKey().Bytes()), true
+}
+
+// privateKeyType 解析 PEM 私钥, 返回密钥类型, 例如 rsa-2048
+func privateKeyType(block string) (string, bool) {
+	p, _ := pem.Decode([]byte(block))
+	if p == nil {
+		return "", false
+	}
+	var key any
+	var err error
+	switch p.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(p.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(p.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(p.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		// 加密的私钥仍然需要口令才能使用
+		return "encrypted", true
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("rsa-%d", k.N.BitLen()), true
+	case *ecdsa.PrivateKey:
+		return "ecdsa-" + k.Curve.Params().Name, true
+	case ed25519.PrivateKey:
+		return "ed25519", true
+	default:
+		return "", false
+	}
+}
+
+// staticKey 校验 OpenVPN static key 的十六进制内容, 返回规范化的密钥
+func staticKey(body string) (string, bool) {
+	var sb strings.Builder
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sb.WriteString(line)
+	}
+	key := strings.ToLower(sb.String())
+	decoded, err := hex.DecodeString(key)
+	if err != nil || len(decoded) != staticKeyBytes {
+		return "", false
+	}
+	return key, true
+}
+
+// presharedKey 去掉引号, 并排除过短的值, 掩码和模板占位符
+func presharedKey(raw string) (string, bool) {
+	psk := strings.Trim(raw, `"'`)
+	if len(psk) < minPSKLength {
+		return "", false
+	}
+	if strings.Trim(psk, "*") == "" || strings.ContainsAny(psk, "<>{}$") {
+		return "", false
+	}
+	return psk, true
+}
+
+func redactPSK(psk string) string {
+	return psk[:2] + strings.Repeat("*", len(psk)-2)
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_VPNCredential
+}
+
+func (s Scanner) Description() string {
+	return "VPN and network device credentials: WireGuard private keys, OpenVPN keys inlined in .ovpn profiles and IPsec pre-shared keys. They grant access to the internal network behind the reported endpoints."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityHigh }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagNetwork} }
@@ -0,0 +1,166 @@
+package watchlist
+
+import (
+	"context"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// Scanner 报告配置中关注的地址或账号 ID 在扫描内容中出现的位置, 例如已泄露钱包的地址或已泄露 AK 的 ID,
+// 便于应急响应时追踪它们还出现在哪些代码和基础设施中. 结果不是密钥本身, 也不会被验证.
+type Scanner struct {
+	entries []entry
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.CustomFalsePositiveChecker = (*Scanner)(nil)
+var _ detectors.KeywordOptionsProvider = (*Scanner)(nil)
+
+type entry struct {
+	// value 是规范化后的值, EVM 地址统一为小写
+	value string
+	label string
+	kind  string
+	// caseSensitive 为 false 时忽略大小写匹配 (EVM 地址有 EIP-55 大小写校验和)
+	caseSensitive bool
+}
+
+var (
+	// ETH / BSC 等 EVM 链地址
+	evmAddressPat = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	// Tron 地址: T 开头的 34 位 Base58
+	tronAddressPat = regexp.MustCompile(`^T[1-9A-HJ-NP-Za-km-z]{33}$`)
+	// 云厂商 AK ID, 例如 AWS AKIA..., 阿里云 LTAI..., 腾讯云 AKID..., 百度云 ALTAK...
+	accessKeyIDPat = regexp.MustCompile(`^(?:AKIA|ASIA|LTAI|AKID|ALTAK)[0-9A-Za-z]{12,}$`)
+)
+
+// New 根据配置创建 Scanner, 空值会被忽略. 没有任何条目时返回 nil.
+func New(values []*configpb.WatchedValue) *Scanner {
+	s := &Scanner{}
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		value := strings.TrimSpace(v.GetValue())
+		if value == "" {
+			continue
+		}
+		e := entry{value: value, label: strings.TrimSpace(v.GetLabel()), kind: kindOf(value), caseSensitive: true}
+		if e.kind == "evm_address" {
+			e.value = strings.ToLower(value)
+			e.caseSensitive = false
+		}
+		if _, ok := seen[e.value]; ok {
+			continue
+		}
+		seen[e.value] = struct{}{}
+		s.entries = append(s.entries, e)
+	}
+	if len(s.entries) == 0 {
+		return nil
+	}
+	return s
+}
+
+// kindOf 识别关注值的类型, 写入结果的 ExtraData
+func kindOf(value string) string {
+	switch {
+	case evmAddressPat.MatchString(value):
+		return "evm_address"
+	case tronAddressPat.MatchString(value):
+		return "tron_address"
+	case accessKeyIDPat.MatchString(value):
+		return "access_key_id"
+	default:
+		return "identifier"
+	}
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	keywords := make([]string, 0, len(s.entries))
+	for _, e := range s.entries {
+		keywords = append(keywords, e.value)
+	}
+	return keywords
+}
+
+// KeywordOptions implements detectors.KeywordOptionsProvider. 除 EVM 地址外的关注值区分大小写.
+func (s Scanner) KeywordOptions() map[string]detectors.KeywordOption {
+	options := make(map[string]detectors.KeywordOption, len(s.entries))
+	for _, e := range s.entries {
+		if e.caseSensitive {
+			options[e.value] = detectors.KeywordCaseSensitive
+		}
+	}
+	return options
+}
+
+// FromData will find occurrences of the watched values in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+	lowerData := strings.ToLower(dataStr)
+
+	for _, e := range s.entries {
+		haystack := dataStr
+		if !e.caseSensitive {
+			haystack = lowerData
+		}
+		if !containsToken(haystack, e.value) {
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Watchlist,
+			Raw:          []byte(e.value),
+			Redacted:     e.value,
+			ExtraData: map[string]string{
+				"kind": e.kind,
+			},
+		}
+		if e.label != "" {
+			s1.ExtraData["label"] = e.label
+		}
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// containsToken 检查 value 是否作为完整的 token 出现, 避免匹配到更长地址或 ID 的一部分
+func containsToken(data, value string) bool {
+	for offset := 0; ; {
+		i := strings.Index(data[offset:], value)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(value)
+		if (start == 0 || !isTokenChar(data[start-1])) && (end == len(data) || !isTokenChar(data[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isTokenChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// IsFalsePositive implements detectors.CustomFalsePositiveChecker. 关注值是精确匹配, 不做误报过滤.
+func (s Scanner) IsFalsePositive(_ detectors.Result) (bool, string) {
+	return false, ""
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Watchlist
+}
+
+func (s Scanner) Description() string {
+	return "Occurrences of watched addresses and account IDs, such as the address of a compromised wallet or the ID of a leaked access key. They are not secrets themselves, but show where a compromised credential is referenced."
+}
@@ -0,0 +1,93 @@
+package watchlist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
+)
+
+var watched = []*configpb.WatchedValue{
+	{Value: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", Label: "drained hot wallet"},
+	{Value: "TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"},
+	{Value: "LTAI5tBcc9cZxXfRo2rN7Uqm", Label: "leaked CI key"},
+	{Value: "  "},
+}
+
+func TestWatchlist_Pattern(t *testing.T) {
+	d := New(watched)
+	require.NotNil(t, d)
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "evm address with different case",
+			input: `treasury: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"`,
+			want:  []string{"0x70997970c51812dc3a010c7d01b50e0d17dc79c8"},
+		},
+		{
+			name:  "tron address and access key id",
+			input: "TRON_PAYOUT=TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7\nALIYUN_AK=LTAI5tBcc9cZxXfRo2rN7Uqm",
+			want:  []string{"TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7", "LTAI5tBcc9cZxXfRo2rN7Uqm"},
+		},
+		{
+			name:  "tron address is case-sensitive",
+			input: "TRON_PAYOUT=tla2f6vpqdgre67v1736s7bj8ray5wyju7",
+			want:  nil,
+		},
+		{
+			name:  "part of a longer identifier",
+			input: "ALIYUN_AK=LTAI5tBcc9cZxXfRo2rN7UqmX",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 && len(test.want) > 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				actual[string(r.Raw)] = struct{}{}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestWatchlist_ExtraData(t *testing.T) {
+	results, err := New(watched).FromData(context.Background(), false, []byte("0x70997970C51812DC3A010C7D01B50E0D17DC79C8 LTAI5tBcc9cZxXfRo2rN7Uqm"))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, map[string]string{"kind": "evm_address", "label": "drained hot wallet"}, results[0].ExtraData)
+	assert.Equal(t, map[string]string{"kind": "access_key_id", "label": "leaked CI key"}, results[1].ExtraData)
+}
+
+func TestWatchlist_Empty(t *testing.T) {
+	assert.Nil(t, New(nil))
+	assert.Nil(t, New([]*configpb.WatchedValue{{Value: " "}}))
+}
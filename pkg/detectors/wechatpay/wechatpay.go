@@ -0,0 +1,208 @@
+package wechatpay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// baseURL 仅用于测试, 默认为微信支付 APIv3 域名
+	baseURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.StartOffsetProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// APIv3 密钥: 商户自行设置的 32 位字符串, 用于解密回调通知和平台证书
+	apiV3KeyPat = regexp.MustCompile(`(?i)api[_-]?v3[_-]?(?:key|secret)["'\s:=]+["']?([A-Za-z0-9]{32})\b`)
+	// 商户 API 私钥 (apiclient_key.pem)
+	privateKeyPat = regexp.MustCompile(`-----BEGIN (?:RSA )?PRIVATE KEY-----[A-Za-z0-9+/=\s\\"]{800,}?-----END (?:RSA )?PRIVATE KEY-----`)
+	// 私钥附近必须出现微信支付相关的上下文
+	contextPat = regexp.MustCompile(`(?i)wechat[_-]?pay|wxpay|mch[_-]?id|apiclient_key|微信支付`)
+	// 商户号, 以及商户 API 证书序列号
+	mchIDPat  = regexp.MustCompile(`(?i)mch[_-]?id["'\s:=]+["']?([0-9]{8,10})\b`)
+	serialPat = regexp.MustCompile(`(?i)(?:serial[_-]?no|serial[_-]?number|cert[_-]?serial)["'\s:=]+["']?([0-9A-Fa-f]{40})\b`)
+)
+
+const (
+	defaultBaseURL = "https://api.mch.weixin.qq.com"
+	// 在私钥前后查找微信支付上下文的字节数
+	contextWindow = 1024
+	maxKeySize    = 4096
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"apiv3", "api_v3", "api-v3", "wechatpay", "wechat_pay", "wxpay", "mchid", "mch_id", "mch-id", "apiclient_key", "微信支付"}
+}
+
+// MaxSecretSize implements detectors.MaxSecretSizeProvider.
+func (s Scanner) MaxSecretSize() int64 { return maxKeySize + contextWindow }
+
+// StartOffset implements detectors.StartOffsetProvider, 私钥可能出现在商户号等关键字之前
+func (s Scanner) StartOffset() int64 { return maxKeySize + contextWindow }
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find WeChat Pay APIv3 keys and merchant private keys in a given set of bytes, and optionally verify
+// the private keys.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	var mchID, serialNo string
+	if m := mchIDPat.FindStringSubmatch(dataStr); m != nil {
+		mchID = m[1]
+	}
+	if m := serialPat.FindStringSubmatch(dataStr); m != nil {
+		serialNo = m[1]
+	}
+
+	seenV3 := make(map[string]struct{})
+	for _, m := range apiV3KeyPat.FindAllStringSubmatch(dataStr, -1) {
+		if _, ok := seenV3[m[1]]; ok {
+			continue
+		}
+		seenV3[m[1]] = struct{}{}
+		// APIv3 密钥是对称密钥, 没有可以调用的接口, 无法验证
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_WeChatPay,
+			Raw:          []byte(m[1]),
+			ExtraData:    map[string]string{"kind": "apiv3_key"},
+			Severity:     detectors.SeverityHigh,
+		}
+		if mchID != "" {
+			s1.ExtraData["mch_id"] = mchID
+		}
+		results = append(results, s1)
+	}
+
+	seenKeys := make(map[string]struct{})
+	for _, loc := range privateKeyPat.FindAllStringIndex(dataStr, -1) {
+		window := dataStr[max(loc[0]-contextWindow, 0):min(loc[1]+contextWindow, len(dataStr))]
+		if !contextPat.MatchString(window) {
+			continue
+		}
+		raw := dataStr[loc[0]:loc[1]]
+		key, _, ok := detectors.ParseRSAPrivateKey(raw)
+		if !ok {
+			continue
+		}
+		if _, ok := seenKeys[raw]; ok {
+			continue
+		}
+		seenKeys[raw] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_WeChatPay,
+			Raw:          []byte(raw),
+			Redacted:     raw[:min(len(raw), 40)] + "...",
+			ExtraData:    map[string]string{"kind": "merchant_private_key"},
+			Severity:     detectors.SeverityCritical,
+		}
+		if mchID != "" {
+			s1.ExtraData["mch_id"] = mchID
+		}
+		if serialNo != "" {
+			s1.ExtraData["serial_no"] = serialNo
+		}
+
+		// 签名需要商户号和证书序列号
+		if verify && mchID != "" && serialNo != "" {
+			baseURL := s.baseURL
+			if baseURL == "" {
+				baseURL = defaultBaseURL
+			}
+			isVerified, verificationErr := verifyKey(ctx, s.getClient(), baseURL, mchID, serialNo, key)
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, raw)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// verifyKey 用商户私钥签名一次下载平台证书的请求, 签名通过时返回 200, 否则返回 401.
+// docs: https://pay.weixin.qq.com/docs/merchant/development/interface-rules/signature-generation.html
+func verifyKey(ctx context.Context, client *http.Client, baseURL, mchID, serialNo string, key *rsa.PrivateKey) (bool, error) {
+	const path = "/v3/certificates"
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return false, err
+	}
+	nonceStr := hex.EncodeToString(nonce)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// 签名串: 请求方法\nURL\n时间戳\n随机串\n请求报文主体\n
+	message := http.MethodGet + "\n" + path + "\n" + timestamp + "\n" + nonceStr + "\n\n"
+	digest := sha256.Sum256([]byte(message))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf(`WECHATPAY2-SHA256-RSA2048 mchid="%s",nonce_str="%s",signature="%s",timestamp="%s",serial_no="%s"`,
+		mchID, nonceStr, base64.StdEncoding.EncodeToString(sig), timestamp, serialNo))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusUnauthorized:
+		// SIGN_ERROR: 私钥与证书序列号不匹配, 或商户号错误
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_WeChatPay
+}
+
+func (s Scanner) Description() string {
+	return "WeChat Pay is a Chinese payment platform. The merchant API private key signs all WeChat Pay API calls, which allows issuing refunds and transfers on behalf of the merchant; the APIv3 key decrypts payment notifications and platform certificates."
+}
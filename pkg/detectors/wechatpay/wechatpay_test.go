@@ -0,0 +1,111 @@
+package wechatpay
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	apiV3Key = "Qm8vT3kLp2Xz7Nc4Rw9Ys1Hd6Jf0Gb5A"
+	serialNo = "5157F09EFDC096DE15EBE81A47057A7232F1B8E1"
+)
+
+func newKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestWeChatPay_Pattern(t *testing.T) {
+	_, pemKey := newKey(t)
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - APIv3 key",
+			input: "wechat:\n  pay:\n    mch-id: 1900009191\n    api-v3-key: " + apiV3Key,
+			want:  []string{"apiv3_key"},
+		},
+		{
+			name:  "valid pattern - merchant private key",
+			input: "// apiclient_key.pem\nmchId = \"1900009191\"\nmerchantSerialNumber = \"" + serialNo + "\"\nprivateKey = `" + pemKey + "`",
+			want:  []string{"merchant_private_key"},
+		},
+		{
+			name:  "invalid pattern - short APIv3 key",
+			input: "apiV3Key: changeme",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var kinds []string
+			for _, r := range results {
+				kinds = append(kinds, r.ExtraData["kind"])
+			}
+			assert.Equal(t, test.want, kinds)
+		})
+	}
+}
+
+func TestWeChatPay_Verify(t *testing.T) {
+	key, pemKey := newKey(t)
+	authPat := regexp.MustCompile(`nonce_str="([^"]+)",signature="([^"]+)",timestamp="([^"]+)"`)
+
+	// 微信支付用商户 API 证书中的公钥校验签名
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := authPat.FindStringSubmatch(r.Header.Get("Authorization"))
+		require.NotNil(t, m)
+		digest := sha256.Sum256([]byte("GET\n/v3/certificates\n" + m[3] + "\n" + m[1] + "\n\n"))
+		sig, _ := base64.StdEncoding.DecodeString(m[2])
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), baseURL: server.URL}
+	input := "wechatpay:\n  mch_id: 1900009191\n  serial_no: " + serialNo + "\n  private_key: |\n" + pemKey
+	results, err := d.FromData(context.Background(), true, []byte(input))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+	assert.Equal(t, map[string]string{"kind": "merchant_private_key", "mch_id": "1900009191", "serial_no": serialNo}, results[0].ExtraData)
+}
@@ -0,0 +1,338 @@
+package xrpseed
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/bitcoin"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/secp256k1"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+	// apiURL 是 rippled JSON-RPC 地址, 为空时使用公共的 XRPL 集群
+	apiURL string
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+var _ detectors.RemediationProvider = (*Scanner)(nil)
+
+const (
+	defaultAPIURL = "https://xrplcluster.com"
+
+	keyTypeSecp256k1 = "secp256k1"
+	keyTypeEd25519   = "ed25519"
+
+	// seed 的版本前缀: secp256k1 为 0x21 ("s..."), ed25519 为 0x01E14B ("sEd...")
+	secp256k1SeedVersion = 0x21
+	// 账户地址的版本前缀 ("r...")
+	accountIDVersion = 0x00
+
+	entropySize = 16
+)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	ed25519SeedVersion = []byte{0x01, 0xE1, 0x4B}
+
+	// XRP Ledger 使用自己的 Base58 字母表, 与比特币的字母表一一对应
+	rippleAlphabet  = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+	bitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	toBitcoin       = strings.NewReplacer(pairs(rippleAlphabet, bitcoinAlphabet)...)
+	toRipple        = strings.NewReplacer(pairs(bitcoinAlphabet, rippleAlphabet)...)
+
+	// secp256k1 seed 为 29 位, ed25519 seed 为 31 位 (sEd...)
+	seedPat = regexp.MustCompile(`\b(s[rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz]{28}|sEd[rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz]{28})\b`)
+	// rippled wallet_propose 输出的 master_seed_hex, 以及配置中的十六进制 seed
+	hexSeedPat = regexp.MustCompile(`(?i)seed[_-]?hex["']?\s*[:=]\s*["']?([0-9a-f]{32})\b`)
+
+	errInvalidSeed = errors.New("invalid XRP seed")
+)
+
+func pairs(from, to string) []string {
+	out := make([]string, 0, 2*len(from))
+	for i := range from {
+		out = append(out, from[i:i+1], to[i:i+1])
+	}
+	return out
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"xrp", "ripple", "xrpl", "seed_hex", "seed-hex", "seedhex"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+func (s Scanner) getAPIURL() string {
+	if s.apiURL != "" {
+		return s.apiURL
+	}
+	return defaultAPIURL
+}
+
+// key 是一个 seed 派生出的账户
+type key struct {
+	keyType string
+	address string
+}
+
+// FromData will find and optionally verify XRP Ledger secret seeds in a given set of bytes. A seed is verified when
+// the account derived from it exists on the ledger.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueSeeds := make(map[string][]key)
+	for _, match := range seedPat.FindAllStringSubmatch(dataStr, -1) {
+		keyType, entropy, err := decodeSeed(match[1])
+		if err != nil {
+			continue
+		}
+		k, err := deriveKey(keyType, entropy)
+		if err != nil {
+			continue
+		}
+		uniqueSeeds[match[1]] = []key{k}
+	}
+	// 十六进制 seed 不记录密钥类型, 两种账户都要检查
+	for _, match := range hexSeedPat.FindAllStringSubmatch(dataStr, -1) {
+		entropy, _ := hex.DecodeString(match[1])
+		var keys []key
+		for _, keyType := range []string{keyTypeSecp256k1, keyTypeEd25519} {
+			if k, err := deriveKey(keyType, entropy); err == nil {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) > 0 {
+			uniqueSeeds[match[1]] = keys
+		}
+	}
+
+	for _, seed := range detectors.SortedKeys(uniqueSeeds) {
+		keys := uniqueSeeds[seed]
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_XRPSeed,
+			Raw:          []byte(seed),
+			Redacted:     keys[0].address,
+			ExtraData: map[string]string{
+				"address":  keys[0].address,
+				"key_type": keys[0].keyType,
+			},
+		}
+		if len(keys) > 1 {
+			s1.ExtraData["key_type"] = "unknown"
+			s1.ExtraData["ed25519_address"] = keys[1].address
+		}
+
+		if verify {
+			var verificationErr error
+			for _, k := range keys {
+				isVerified, extraData, err := verifyAccount(ctx, s.getClient(), s.getAPIURL(), k.address)
+				if err != nil {
+					verificationErr = err
+					continue
+				}
+				if isVerified {
+					s1.Verified = true
+					s1.Redacted = k.address
+					s1.ExtraData["address"] = k.address
+					s1.ExtraData["key_type"] = k.keyType
+					delete(s1.ExtraData, "ed25519_address")
+					for key, value := range extraData {
+						s1.ExtraData[key] = value
+					}
+					verificationErr = nil
+					break
+				}
+			}
+			s1.SetVerificationError(verificationErr, seed)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// decodeSeed 解码 Base58 编码的 seed, 返回密钥类型和 16 字节的熵
+func decodeSeed(seed string) (string, []byte, error) {
+	payload, err := bitcoin.DecodeBase58Check(toBitcoin.Replace(seed))
+	if err != nil {
+		return "", nil, err
+	}
+	switch {
+	case len(payload) == 1+entropySize && payload[0] == secp256k1SeedVersion:
+		return keyTypeSecp256k1, payload[1:], nil
+	case len(payload) == len(ed25519SeedVersion)+entropySize && bytes.HasPrefix(payload, ed25519SeedVersion):
+		return keyTypeEd25519, payload[len(ed25519SeedVersion):], nil
+	default:
+		return "", nil, errInvalidSeed
+	}
+}
+
+// deriveKey 由 seed 的熵派生账户的公钥和经典地址 (r...)
+func deriveKey(keyType string, entropy []byte) (key, error) {
+	var pub []byte
+	switch keyType {
+	case keyTypeEd25519:
+		// ed25519 私钥是熵的 SHA-512Half, 公钥加 0xED 前缀
+		priv := sha512Half(entropy)
+		pub = append([]byte{0xED}, ed25519.NewKeyFromSeed(priv).Public().(ed25519.PublicKey)...)
+	default:
+		var err error
+		pub, err = secp256k1AccountPublicKey(entropy)
+		if err != nil {
+			return key{}, err
+		}
+	}
+	payload := append([]byte{accountIDVersion}, bitcoin.Hash160(pub)...)
+	return key{keyType: keyType, address: toRipple.Replace(bitcoin.EncodeBase58Check(payload))}, nil
+}
+
+// secp256k1AccountPublicKey 按 rippled 的 family generator 派生第 0 个账户的公钥:
+// 根私钥是 SHA-512Half(熵 || seq), 账户私钥是根私钥加上 SHA-512Half(根公钥 || 0 || seq)
+func secp256k1AccountPublicKey(entropy []byte) ([]byte, error) {
+	root, err := deriveScalar(entropy)
+	if err != nil {
+		return nil, err
+	}
+	rootPub, err := secp256k1.CompressedPublicKey(root)
+	if err != nil {
+		return nil, err
+	}
+	intermediate, err := deriveScalar(append(rootPub, 0, 0, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	priv, err := secp256k1.AddPrivateKeys(root, intermediate)
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.CompressedPublicKey(priv)
+}
+
+// deriveScalar 返回第一个是有效私钥的 SHA-512Half(prefix || seq), seq 从 0 开始
+func deriveScalar(prefix []byte) ([]byte, error) {
+	buf := append(prefix[:len(prefix):len(prefix)], 0, 0, 0, 0)
+	for seq := uint32(0); seq < 128; seq++ {
+		binary.BigEndian.PutUint32(buf[len(prefix):], seq)
+		if k := sha512Half(buf); secp256k1.ValidPrivateKey(k) {
+			return k, nil
+		}
+	}
+	return nil, secp256k1.ErrInvalidPrivateKey
+}
+
+func sha512Half(data []byte) []byte {
+	sum := sha512.Sum512(data)
+	return sum[:32]
+}
+
+// accountInfoResponse 是 rippled account_info 方法的响应
+type accountInfoResponse struct {
+	Result struct {
+		AccountData struct {
+			Balance  string `json:"Balance"`
+			Sequence int64  `json:"Sequence"`
+		} `json:"account_data"`
+		Error  string `json:"error"`
+		Status string `json:"status"`
+	} `json:"result"`
+}
+
+// verifyAccount 查询账户是否存在于已验证的账本中. 账户需要存入储备金才会创建, 存在的账户就是在使用中的钱包
+func verifyAccount(ctx context.Context, client *http.Client, apiURL, address string) (bool, map[string]string, error) {
+	body, err := json.Marshal(map[string]any{
+		"method": "account_info",
+		"params": []map[string]string{{"account": address, "ledger_index": "validated"}},
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+	var info accountInfoResponse
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return false, nil, err
+	}
+
+	switch {
+	case info.Result.Status == "success":
+		extraData := map[string]string{
+			"sequence": fmt.Sprintf("%d", info.Result.AccountData.Sequence),
+		}
+		if drops, ok := new(big.Int).SetString(info.Result.AccountData.Balance, 10); ok {
+			extraData["balance_xrp"] = new(big.Rat).SetFrac(drops, big.NewInt(1_000_000)).FloatString(6)
+		}
+		return true, extraData, nil
+	case info.Result.Error == "actNotFound":
+		// The secret is determinately not verified (nothing to do)
+		return false, nil, nil
+	default:
+		return false, nil, fmt.Errorf("unexpected account_info error %q", info.Result.Error)
+	}
+}
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_XRPSeed
+}
+
+func (s Scanner) Description() string {
+	return "XRP Ledger secret seeds derive the key pair of an XRP account. Anyone with the seed can sign transactions and transfer all funds of the account."
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityCritical }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagCrypto, detectors.TagWallet} }
+
+// Remediation implements detectors.RemediationProvider.
+// XRP Ledger 支持禁用主密钥, 不转移资产也可以让泄露的 seed 失效
+func (s Scanner) Remediation() string {
+	return "Assign a new regular key to the XRP account with SetRegularKey and disable the master key with " +
+		"AccountSet asfDisableMaster, which makes a leaked master seed useless without moving the funds; a leaked " +
+		"regular key is replaced with SetRegularKey. If that isn't possible, sweep the funds to a new account " +
+		"immediately."
+}
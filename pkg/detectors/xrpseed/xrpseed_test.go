@@ -0,0 +1,148 @@
+package xrpseed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	// The genesis account of the XRP Ledger, derived from the passphrase "masterpassphrase".
+	genesisSeed    = "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"
+	genesisAddress = "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+	genesisHexSeed = "DEDCE9CE67B451D852FD4E846FCDE31C"
+
+	ed25519Seed    = "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"
+	ed25519Address = "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD"
+)
+
+func TestXRPSeed_Derive(t *testing.T) {
+	tests := []struct {
+		seed    string
+		keyType string
+		address string
+	}{
+		{genesisSeed, keyTypeSecp256k1, genesisAddress},
+		{"sp5fghtJtpUorTwvof1NpDXAzNwf5", keyTypeSecp256k1, "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"},
+		{ed25519Seed, keyTypeEd25519, ed25519Address},
+	}
+	for _, tt := range tests {
+		t.Run(tt.seed, func(t *testing.T) {
+			keyType, entropy, err := decodeSeed(tt.seed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.keyType, keyType)
+
+			k, err := deriveKey(keyType, entropy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.address, k.address)
+		})
+	}
+}
+
+func TestXRPSeed_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d}, ahocorasick.WithMinKeywordLength(ahocorasick.DefaultMinKeywordLength))
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "family seed",
+			input: fmt.Sprintf("XRPL_WALLET_SECRET=%s", genesisSeed),
+			want:  []string{genesisAddress},
+		},
+		{
+			name:  "xrp keyword",
+			input: fmt.Sprintf(`XRP_SEED="%s"`, genesisSeed),
+			want:  []string{genesisAddress},
+		},
+		{
+			name:  "ed25519 seed",
+			input: fmt.Sprintf(`const wallet = xrpl.Wallet.fromSeed("%s")`, ed25519Seed),
+			want:  []string{ed25519Address},
+		},
+		{
+			name:  "wallet_propose output",
+			input: fmt.Sprintf(`{"result": {"account_id": "%s", "key_type": "secp256k1", "master_seed_hex": "%s"}}`, genesisAddress, genesisHexSeed),
+			want:  []string{genesisAddress},
+		},
+		{
+			name:  "invalid checksum",
+			input: "ripple secret: snoPBrXtMeMyMHUVTgbuqAfg1SUTc",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, r.ExtraData["address"])
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestXRPSeed_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string              `json:"method"`
+			Params []map[string]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "account_info", req.Method)
+
+		if req.Params[0]["account"] == ed25519Address {
+			fmt.Fprintf(w, `{"result":{"account_data":{"Account":%q,"Balance":"25000123","Sequence":7},"status":"success","validated":true}}`, ed25519Address)
+			return
+		}
+		fmt.Fprintf(w, `{"result":{"account":%q,"error":"actNotFound","status":"error","validated":true}}`, req.Params[0]["account"])
+	}))
+	defer server.Close()
+
+	d := Scanner{client: server.Client(), apiURL: server.URL}
+
+	results, err := d.FromData(context.Background(), true, []byte(fmt.Sprintf("seed: %s\nseed: %s\n", ed25519Seed, genesisSeed)))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	for _, r := range results {
+		assert.NoError(t, r.VerificationError())
+		switch string(r.Raw) {
+		case ed25519Seed:
+			assert.True(t, r.Verified)
+			assert.Equal(t, map[string]string{
+				"address":     ed25519Address,
+				"key_type":    keyTypeEd25519,
+				"balance_xrp": "25.000123",
+				"sequence":    "7",
+			}, r.ExtraData)
+		case genesisSeed:
+			assert.False(t, r.Verified)
+		}
+	}
+}
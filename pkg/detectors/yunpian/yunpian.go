@@ -0,0 +1,145 @@
+package yunpian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.MetadataProvider = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	// 云片 apikey 是 32 位十六进制, 需要 yunpian 上下文
+	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"yunpian"}) + `\b([a-f0-9]{32})\b`)
+)
+
+const userURL = "https://sms.yunpian.com/v2/user/get.json"
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"yunpian", "云片"}
+}
+
+func (s Scanner) getClient() *http.Client {
+	if s.client != nil {
+		return s.client
+	}
+	return defaultClient
+}
+
+// FromData will find and optionally verify Yunpian API keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueKeys := make(map[string]struct{})
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueKeys[match[1]] = struct{}{}
+	}
+
+	for _, key := range detectors.SortedKeys(uniqueKeys) {
+		s1 := detectors.Result{
+			DetectorType: detector_typepb.DetectorType_Yunpian,
+			Raw:          []byte(key),
+		}
+
+		if verify {
+			isVerified, extraData, verificationErr := verifyKey(ctx, s.getClient(), userURL, key)
+			s1.Verified = isVerified
+			s1.ExtraData = extraData
+			s1.SetVerificationError(verificationErr, key)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+type userResponse struct {
+	Nick    string      `json:"nick"`
+	Balance json.Number `json:"balance"`
+	Mobile  string      `json:"mobile"`
+	// 出错时返回 code 和 msg, apikey 无效时 code 为 -1
+	Code *int   `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// verifyKey 查询账户信息, 该接口只读且不消耗短信条数
+// docs: https://www.yunpian.com/official/document/sms/zh_CN/user_get
+func verifyKey(ctx context.Context, client *http.Client, endpoint, key string) (bool, map[string]string, error) {
+	form := url.Values{"apikey": {key}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+	req.Header.Set("Accept", "application/json;charset=utf-8")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	var body userResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil && res.StatusCode == http.StatusOK {
+		return false, nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK && body.Code == nil:
+		extraData := map[string]string{}
+		if body.Nick != "" {
+			extraData["nick"] = body.Nick
+		}
+		if body.Balance != "" {
+			extraData["balance"] = body.Balance.String()
+		}
+		if body.Mobile != "" {
+			extraData["mobile"] = body.Mobile
+		}
+		return true, extraData, nil
+	case res.StatusCode == http.StatusUnauthorized, body.Code != nil && *body.Code == -1:
+		return false, nil, nil
+	case body.Code != nil:
+		return false, nil, fmt.Errorf("unexpected error code %d: %s", *body.Code, body.Msg)
+	default:
+		return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+// DefaultSeverity implements detectors.MetadataProvider.
+func (s Scanner) DefaultSeverity() detectors.Severity { return detectors.SeverityMedium }
+
+// Tags implements detectors.MetadataProvider.
+func (s Scanner) Tags() []string { return []string{detectors.TagChinaCloud} }
+
+func (s Scanner) Type() detector_typepb.DetectorType {
+	return detector_typepb.DetectorType_Yunpian
+}
+
+func (s Scanner) Description() string {
+	return "Yunpian is a Chinese SMS and voice verification platform. Yunpian API keys can be used to send SMS messages billed to the account and to read its templates and delivery records."
+}
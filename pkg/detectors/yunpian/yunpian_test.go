@@ -0,0 +1,106 @@
+package yunpian
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const validKey = "0f3c2a8e5b7d41e6a9c2d8f04b61e7a3"
+
+func TestYunpian_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "valid pattern - properties",
+			input: "yunpian.sms.apikey=" + validKey,
+			want:  []string{validKey},
+		},
+		{
+			name:  "valid pattern - php sdk",
+			input: "$clnt = YunpianClient::create('" + validKey + "');",
+			want:  []string{validKey},
+		},
+		{
+			name:  "invalid pattern - too short",
+			input: "yunpian_enabled=true\nmd5=" + validKey[:20],
+			want:  nil,
+		},
+		{
+			name:  "invalid pattern - no context",
+			input: "# yunpian is configured in sms.yml, not here\n# build cache\nmd5=" + validKey,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("test %q failed: expected keywords %v to be found in the input", test.name, d.Keywords())
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			if len(results) != len(test.want) {
+				t.Errorf("mismatch in result count: expected %d, got %d", len(test.want), len(results))
+				return
+			}
+
+			actual := make(map[string]struct{}, len(results))
+			for _, r := range results {
+				if len(r.RawV2) > 0 {
+					actual[string(r.RawV2)] = struct{}{}
+				} else {
+					actual[string(r.Raw)] = struct{}{}
+				}
+			}
+			expected := make(map[string]struct{}, len(test.want))
+			for _, v := range test.want {
+				expected[v] = struct{}{}
+			}
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestYunpian_VerifyKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("apikey") != validKey {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"http_status_code":400,"code":-1,"msg":"非法的apikey","detail":"请检查的apikey是否正确"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"nick":"shop","gmt_created":"2019-03-01 10:00:00","mobile":"13800000000","email":"ops@example.com","balance":1024.5}`))
+	}))
+	defer server.Close()
+
+	verified, extraData, err := verifyKey(context.Background(), server.Client(), server.URL, validKey)
+	require.NoError(t, err)
+	assert.True(t, verified)
+	assert.Equal(t, map[string]string{"nick": "shop", "balance": "1024.5", "mobile": "13800000000"}, extraData)
+
+	verified, _, err = verifyKey(context.Background(), server.Client(), server.URL, "ffffffffffffffffffffffffffffffff")
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
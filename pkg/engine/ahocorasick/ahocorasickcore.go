@@ -2,6 +2,9 @@ package ahocorasick
 
 import (
 	"bytes"
+	"cmp"
+	"regexp"
+	"slices"
 	"strings"
 
 	ahocorasick "github.com/BobuSumisu/aho-corasick"
@@ -118,6 +121,16 @@ func WithSpanCalculator(spanCalculator spanCalculator) CoreOption {
 	return func(ac *Core) { ac.spanCalculator = spanCalculator }
 }
 
+// WithKeywordPacks enables the named keyword packs for the detectors that use them. See
+// detectors.KeywordPackProvider.
+func WithKeywordPacks(names ...string) CoreOption {
+	return func(ac *Core) {
+		for _, name := range names {
+			ac.keywordPacks[name] = struct{}{}
+		}
+	}
+}
+
 // Core encapsulates the operations and data structures used for keyword matching via the
 // Aho-Corasick algorithm. It is responsible for constructing and managing the trie for efficient
 // substring searches, as well as mapping keywords to their associated detectors for rapid lookups.
@@ -125,48 +138,128 @@ type Core struct {
 	// prefilter is a ahocorasick struct used for doing efficient string
 	// matching given a set of words. (keywords from the rules in the config)
 	prefilter ahocorasick.Trie
+	// caseSensitivePrefilter matches the keywords declared with
+	// detectors.KeywordCaseSensitive against the chunk as-is. It is nil if
+	// there are none.
+	caseSensitivePrefilter *ahocorasick.Trie
 	// Maps for efficient lookups during detection.
 	// (This implementation maps in two layers: from keywords to detector
 	// type and then again from detector type to detector. We could
 	// go straight from keywords to detectors but doing it this way makes
 	// some consuming code a little cleaner.)
-	keywordsToDetectors map[string][]DetectorKey
-	detectorsByKey      map[DetectorKey]detectors.Detector
-	spanCalculator      spanCalculator // Strategy for calculating match spans
+	keywordsToDetectors              map[string][]keywordTarget
+	caseSensitiveKeywordsToDetectors map[string][]keywordTarget
+	detectorsByKey                   map[DetectorKey]detectors.Detector
+	spanCalculator                   spanCalculator // Strategy for calculating match spans
+
+	minKeywordLength     int
+	allowedShortKeywords map[string]struct{}
+	rejectedKeywords     map[string][]DetectorKey
+	keywordPacks         map[string]struct{}
+}
+
+// keywordTarget is a detector that a keyword dispatches to, along with
+// whether that detector only wants the keyword as a whole word.
+type keywordTarget struct {
+	key       DetectorKey
+	wholeWord bool
+	// shim, if set, must match at the keyword's position for it to dispatch.
+	shim *regexp.Regexp
 }
 
 // NewAhoCorasickCore allocates and initializes a new instance of AhoCorasickCore. It uses the
 // provided detector slice to create a map from keywords to detectors and build the Aho-Corasick
 // prefilter trie.
 func NewAhoCorasickCore(allDetectors []detectors.Detector, opts ...CoreOption) *Core {
-	keywordsToDetectors := make(map[string][]DetectorKey)
-	detectorsByKey := make(map[DetectorKey]detectors.Detector, len(allDetectors))
-	var keywords []string
-	for _, d := range allDetectors {
-		key := CreateDetectorKey(d)
-		detectorsByKey[key] = d
-		for _, kw := range d.Keywords() {
-			kwLower := strings.ToLower(kw)
-			keywords = append(keywords, kwLower)
-			keywordsToDetectors[kwLower] = append(keywordsToDetectors[kwLower], key)
-		}
-	}
-
 	const defaultOffsetRadius int64 = 512
 	core := &Core{
-		keywordsToDetectors: keywordsToDetectors,
-		detectorsByKey:      detectorsByKey,
-		prefilter:           *ahocorasick.NewTrieBuilder().AddStrings(keywords).Build(),
-		spanCalculator:      newAdjustableSpanCalculator(defaultOffsetRadius), // Default span calculator
+		keywordsToDetectors:              make(map[string][]keywordTarget),
+		caseSensitiveKeywordsToDetectors: make(map[string][]keywordTarget),
+		detectorsByKey:                   make(map[DetectorKey]detectors.Detector, len(allDetectors)),
+		spanCalculator:                   newAdjustableSpanCalculator(defaultOffsetRadius), // Default span calculator
+		allowedShortKeywords:             make(map[string]struct{}, len(defaultAllowedShortKeywords)),
+		rejectedKeywords:                 make(map[string][]DetectorKey),
+		keywordPacks:                     make(map[string]struct{}),
+	}
+	for _, kw := range defaultAllowedShortKeywords {
+		core.allowedShortKeywords[kw] = struct{}{}
 	}
 
 	for _, opt := range opts {
 		opt(core)
 	}
 
+	for _, d := range allDetectors {
+		key := CreateDetectorKey(d)
+		core.detectorsByKey[key] = d
+
+		var options map[string]detectors.KeywordOption
+		if provider, ok := d.(detectors.KeywordOptionsProvider); ok {
+			options = provider.KeywordOptions()
+		}
+		for _, kw := range core.detectorKeywords(d) {
+			shim, ok := core.keywordPolicy(d, kw)
+			if !ok {
+				core.rejectedKeywords[kw] = append(core.rejectedKeywords[kw], key)
+				continue
+			}
+			option := options[kw]
+			target := keywordTarget{key: key, wholeWord: option&detectors.KeywordWholeWord != 0, shim: shim}
+			if option&detectors.KeywordCaseSensitive != 0 {
+				addKeywordTarget(core.caseSensitiveKeywordsToDetectors, kw, target)
+			} else {
+				addKeywordTarget(core.keywordsToDetectors, strings.ToLower(kw), target)
+			}
+		}
+	}
+
+	core.prefilter = *buildTrie(core.keywordsToDetectors)
+	if len(core.caseSensitiveKeywordsToDetectors) > 0 {
+		core.caseSensitivePrefilter = buildTrie(core.caseSensitiveKeywordsToDetectors)
+	}
+
 	return core
 }
 
+// detectorKeywords returns the keywords of a detector, including those of the enabled keyword packs it uses.
+func (ac *Core) detectorKeywords(d detectors.Detector) []string {
+	keywords := d.Keywords()
+	provider, ok := d.(detectors.KeywordPackProvider)
+	if !ok {
+		return keywords
+	}
+	for _, name := range provider.KeywordPacks() {
+		if _, enabled := ac.keywordPacks[name]; !enabled {
+			continue
+		}
+		packKeywords, _ := detectors.KeywordPack(name)
+		keywords = append(slices.Clip(keywords), packKeywords...)
+	}
+	return keywords
+}
+
+// addKeywordTarget maps a keyword to a detector. Keywords a detector declares
+// more than once, such as case variants, only map to it once. If any of the
+// declarations matches anywhere, the keyword matches anywhere for the detector.
+func addKeywordTarget(m map[string][]keywordTarget, keyword string, target keywordTarget) {
+	targets := m[keyword]
+	for i, t := range targets {
+		if t.key == target.key {
+			targets[i].wholeWord = t.wholeWord && target.wholeWord
+			return
+		}
+	}
+	m[keyword] = append(targets, target)
+}
+
+func buildTrie(keywordsToDetectors map[string][]keywordTarget) *ahocorasick.Trie {
+	keywords := make([]string, 0, len(keywordsToDetectors))
+	for kw := range keywordsToDetectors {
+		keywords = append(keywords, kw)
+	}
+	return ahocorasick.NewTrieBuilder().AddStrings(keywords).Build()
+}
+
 // DetectorMatch represents a detected pattern's metadata in a data chunk.
 // It encapsulates the key identifying a specific detector, the detector instance itself,
 // the start and end offsets of the matched keyword in the chunk, and the matched portions of the chunk data.
@@ -239,17 +332,63 @@ func (d *DetectorMatch) Matches() [][]byte { return d.matches }
 //
 // The matches field contains the actual byte slices of the matched portions from the chunk data.
 func (ac *Core) FindDetectorMatches(chunkData []byte) []*DetectorMatch {
-	matches := ac.prefilter.Match(bytes.ToLower(chunkData))
+	detectorMatches := make(map[DetectorKey]*DetectorMatch)
+
+	lowerChunkData := bytes.ToLower(chunkData)
+	ac.addDetectorMatches(detectorMatches, chunkData, lowerChunkData, ac.prefilter.Match(lowerChunkData), ac.keywordsToDetectors)
+	if ac.caseSensitivePrefilter != nil {
+		ac.addDetectorMatches(detectorMatches, chunkData, chunkData, ac.caseSensitivePrefilter.Match(chunkData), ac.caseSensitiveKeywordsToDetectors)
+	}
 
-	matchCount := len(matches)
-	if matchCount == 0 {
+	if len(detectorMatches) == 0 {
 		return nil
 	}
 
-	detectorMatches := make(map[DetectorKey]*DetectorMatch)
+	uniqueDetectors := make([]*DetectorMatch, 0, len(detectorMatches))
+	for _, detectorMatch := range detectorMatches {
+		// Spans are appended per prefilter, so they are only in order if a
+		// single prefilter matched.
+		slices.SortFunc(detectorMatch.matchSpans, func(a, b matchSpan) int {
+			return cmp.Compare(a.startOffset, b.startOffset)
+		})
+		// Merge overlapping or adjacent match spans.
+		detectorMatch.mergeMatches()
+		detectorMatch.extractMatches(chunkData)
+
+		uniqueDetectors = append(uniqueDetectors, detectorMatch)
+	}
+
+	return uniqueDetectors
+}
 
+// addDetectorMatches records a match span for every detector the prefilter
+// matches dispatch to. matchedData is the data the prefilter ran on, which
+// is used to check word boundaries.
+func (ac *Core) addDetectorMatches(
+	detectorMatches map[DetectorKey]*DetectorMatch,
+	chunkData, matchedData []byte,
+	matches []*ahocorasick.Match,
+	keywordsToDetectors map[string][]keywordTarget,
+) {
 	for _, m := range matches {
-		for _, k := range ac.keywordsToDetectors[m.MatchString()] {
+		startIdx := m.Pos()
+		var isWholeWord, checkedWholeWord bool
+		for _, target := range keywordsToDetectors[m.MatchString()] {
+			if target.wholeWord {
+				if !checkedWholeWord {
+					isWholeWord = isWordBoundary(matchedData, startIdx-1) &&
+						isWordBoundary(matchedData, startIdx+int64(len(m.Match())))
+					checkedWholeWord = true
+				}
+				if !isWholeWord {
+					continue
+				}
+			}
+			if target.shim != nil && !confirmShim(target.shim, matchedData, startIdx) {
+				continue
+			}
+
+			k := target.key
 			if _, exists := detectorMatches[k]; !exists {
 				detector := ac.detectorsByKey[k]
 				detectorMatches[k] = &DetectorMatch{
@@ -260,7 +399,6 @@ func (ac *Core) FindDetectorMatches(chunkData []byte) []*DetectorMatch {
 			}
 
 			detectorMatch := detectorMatches[k]
-			startIdx := m.Pos()
 			span := ac.spanCalculator.calculateSpan(
 				spanCalculationParams{
 					keywordIdx: startIdx,
@@ -271,17 +409,54 @@ func (ac *Core) FindDetectorMatches(chunkData []byte) []*DetectorMatch {
 			detectorMatch.addMatchSpan(span)
 		}
 	}
+}
 
-	uniqueDetectors := make([]*DetectorMatch, 0, len(detectorMatches))
-	for _, detectorMatch := range detectorMatches {
-		// Merge overlapping or adjacent match spans.
-		detectorMatch.mergeMatches()
-		detectorMatch.extractMatches(chunkData)
+// MatchedKeywords returns the keywords in data that dispatch to the detector
+// identified by key, sorted and without duplicates. It is used to attribute
+// results to the keywords that triggered them, so it rescans data rather than
+// having FindDetectorMatches keep track of the keywords of every span.
+func (ac *Core) MatchedKeywords(key DetectorKey, data []byte) []string {
+	var keywords []string
+	collect := func(matchedData []byte, matches []*ahocorasick.Match, keywordsToDetectors map[string][]keywordTarget) {
+		for _, m := range matches {
+			for _, target := range keywordsToDetectors[m.MatchString()] {
+				if target.key != key || !dispatches(target, matchedData, m.Pos(), int64(len(m.Match()))) {
+					continue
+				}
+				keywords = append(keywords, m.MatchString())
+			}
+		}
+	}
 
-		uniqueDetectors = append(uniqueDetectors, detectorMatch)
+	lowerData := bytes.ToLower(data)
+	collect(lowerData, ac.prefilter.Match(lowerData), ac.keywordsToDetectors)
+	if ac.caseSensitivePrefilter != nil {
+		collect(data, ac.caseSensitivePrefilter.Match(data), ac.caseSensitiveKeywordsToDetectors)
 	}
 
-	return uniqueDetectors
+	slices.Sort(keywords)
+	return slices.Compact(keywords)
+}
+
+// dispatches reports whether a keyword match of the given length at startIdx
+// in matchedData dispatches to target.
+func dispatches(target keywordTarget, matchedData []byte, startIdx, length int64) bool {
+	if target.wholeWord && !(isWordBoundary(matchedData, startIdx-1) && isWordBoundary(matchedData, startIdx+length)) {
+		return false
+	}
+	return target.shim == nil || confirmShim(target.shim, matchedData, startIdx)
+}
+
+// isWordBoundary reports whether the byte at idx does not continue a word,
+// either because it is out of range or because it is not an ASCII letter,
+// digit or underscore.
+func isWordBoundary(data []byte, idx int64) bool {
+	if idx < 0 || idx >= int64(len(data)) {
+		return true
+	}
+	c := data[idx]
+	isWordChar := c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+	return !isWordChar
 }
 
 // CreateDetectorKey creates a unique key for each detector from its type, version, and, for
@@ -299,6 +474,18 @@ func CreateDetectorKey(d detectors.Detector) DetectorKey {
 	return DetectorKey{detectorType: detectorType, version: version, customDetectorName: customDetectorName}
 }
 
+// KeywordsToDetectors returns the keywords of all detectors and the detectors
+// they dispatch to. Case-insensitive keywords are lowercase.
 func (ac *Core) KeywordsToDetectors() map[string][]DetectorKey {
-	return ac.keywordsToDetectors
+	keywordsToDetectors := make(map[string][]DetectorKey, len(ac.keywordsToDetectors)+len(ac.caseSensitiveKeywordsToDetectors))
+	for _, m := range []map[string][]keywordTarget{ac.keywordsToDetectors, ac.caseSensitiveKeywordsToDetectors} {
+		for kw, targets := range m {
+			for _, target := range targets {
+				if !slices.Contains(keywordsToDetectors[kw], target.key) {
+					keywordsToDetectors[kw] = append(keywordsToDetectors[kw], target.key)
+				}
+			}
+		}
+	}
+	return keywordsToDetectors
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/custom_detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -133,6 +134,30 @@ func (testDetectorV6) Description() string { return "" }
 
 func (testDetectorV6) StartOffset() int64 { return 1 }
 
+var _ detectors.Detector = (*testDetectorV7)(nil)
+var _ detectors.KeywordOptionsProvider = (*testDetectorV7)(nil)
+
+type testDetectorV7 struct{}
+
+func (testDetectorV7) FromData(context.Context, bool, []byte) ([]detectors.Result, error) {
+	return make([]detectors.Result, 0), nil
+}
+
+func (testDetectorV7) Keywords() []string { return []string{"ak", "ALTAK", "secret_key", "SECRET_KEY"} }
+
+func (testDetectorV7) KeywordOptions() map[string]detectors.KeywordOption {
+	return map[string]detectors.KeywordOption{
+		"ak":    detectors.KeywordWholeWord,
+		"ALTAK": detectors.KeywordCaseSensitive | detectors.KeywordWholeWord,
+	}
+}
+
+func (testDetectorV7) Type() detector_typepb.DetectorType { return TestDetectorType }
+
+func (testDetectorV7) Version() int { return 7 }
+
+func (testDetectorV7) Description() string { return "" }
+
 var _ detectors.Detector = (*testDetectorV1)(nil)
 var _ detectors.Detector = (*testDetectorV2)(nil)
 var _ detectors.Versioner = (*testDetectorV1)(nil)
@@ -195,6 +220,120 @@ func TestAhoCorasickCore_NoDuplicateDetectorsMatched(t *testing.T) {
 	assert.ElementsMatch(t, allDetectors, matchingDetectors)
 }
 
+func TestAhoCorasickCore_KeywordOptions(t *testing.T) {
+	ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV7{}})
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "whole word", input: "ak = 123", want: true},
+		{name: "whole word ignores case", input: "AK: 123", want: true},
+		{name: "inside word", input: "break", want: false},
+		{name: "case sensitive", input: "id=ALTAK123", want: false},
+		{name: "case sensitive whole word", input: "id ALTAK 123", want: true},
+		{name: "case sensitive wrong case", input: "id altak 123", want: false},
+		{name: "case variants", input: "Secret_Key", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ac.FindDetectorMatches([]byte(tt.input))
+			assert.Equal(t, tt.want, len(got) > 0)
+		})
+	}
+}
+
+func TestAhoCorasickCore_MatchedKeywords(t *testing.T) {
+	ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV7{}, testDetectorV3{}})
+	key := CreateDetectorKey(testDetectorV7{})
+
+	assert.Equal(t, []string{"ALTAK", "ak", "secret_key"}, ac.MatchedKeywords(key, []byte("ak=ALTAK 1 SECRET_KEY=2 Secret_Key=3 truffle")))
+	assert.Empty(t, ac.MatchedKeywords(key, []byte("break altak")), "keywords that do not dispatch are not reported")
+	assert.Equal(t, []string{"truffle"}, ac.MatchedKeywords(CreateDetectorKey(testDetectorV3{}), []byte("ak truffle")))
+}
+
+var _ detectors.KeywordPackProvider = (*testDetectorV8)(nil)
+
+type testDetectorV8 struct{ testDetectorV3 }
+
+func (testDetectorV8) KeywordPacks() []string { return []string{detectors.KeywordPackChinese} }
+
+func (testDetectorV8) Version() int { return 8 }
+
+func TestAhoCorasickCore_KeywordPacks(t *testing.T) {
+	input := []byte("数据库口令：hunter2")
+
+	ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV8{}})
+	assert.Empty(t, ac.FindDetectorMatches(input), "packs are disabled by default")
+	assert.Len(t, ac.FindDetectorMatches([]byte("truffle")), 1)
+
+	ac = NewAhoCorasickCore([]detectors.Detector{testDetectorV8{}, testDetectorV3{}}, WithKeywordPacks(detectors.KeywordPackChinese))
+	matches := ac.FindDetectorMatches(input)
+	require.Len(t, matches, 1, "only detectors that use the pack dispatch on its keywords")
+	assert.Equal(t, CreateDetectorKey(testDetectorV8{}), matches[0].Key)
+}
+
+func TestAhoCorasickCore_DuplicateKeywordsDeduplicated(t *testing.T) {
+	ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV7{}})
+
+	keywords := ac.KeywordsToDetectors()
+	assert.Len(t, keywords["secret_key"], 1)
+	assert.Contains(t, keywords, "ALTAK")
+	assert.NotContains(t, keywords, "altak")
+}
+
+func TestAhoCorasickCore_MinKeywordLength(t *testing.T) {
+	t.Run("no minimum by default", func(t *testing.T) {
+		ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV1{}})
+
+		assert.Len(t, ac.FindDetectorMatches([]byte("a")), 1)
+		assert.Empty(t, ac.RejectedKeywords())
+	})
+
+	t.Run("short keywords rejected", func(t *testing.T) {
+		ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV1{}}, WithMinKeywordLength(DefaultMinKeywordLength))
+
+		assert.Empty(t, ac.FindDetectorMatches([]byte("a a b b")))
+		assert.Equal(t, map[string][]DetectorKey{
+			"a": {CreateDetectorKey(testDetectorV1{})},
+			"b": {CreateDetectorKey(testDetectorV1{})},
+		}, ac.RejectedKeywords())
+	})
+
+	t.Run("short keyword allowed", func(t *testing.T) {
+		ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV1{}}, WithMinKeywordLength(DefaultMinKeywordLength, "a"))
+
+		assert.Len(t, ac.FindDetectorMatches([]byte("a")), 1)
+		assert.Empty(t, ac.FindDetectorMatches([]byte("b")))
+		assert.Contains(t, ac.RejectedKeywords(), "b")
+	})
+
+	t.Run("custom detector keywords kept", func(t *testing.T) {
+		custom, err := custom_detectors.NewWebhookCustomRegex(&custom_detectorspb.CustomRegex{
+			Name:     "custom detector",
+			Keywords: []string{"ost"},
+			Regex:    map[string]string{"regex": ".*"},
+		})
+		require.NoError(t, err)
+		ac := NewAhoCorasickCore([]detectors.Detector{custom}, WithMinKeywordLength(DefaultMinKeywordLength))
+
+		assert.Len(t, ac.FindDetectorMatches([]byte("ost")), 1)
+		assert.Empty(t, ac.RejectedKeywords())
+	})
+
+	t.Run("short keyword shimmed", func(t *testing.T) {
+		ac := NewAhoCorasickCore([]detectors.Detector{testDetectorV7{}}, WithMinKeywordLength(DefaultMinKeywordLength))
+
+		assert.Len(t, ac.FindDetectorMatches([]byte(`"ak": "0123456789abcdef"`)), 1)
+		assert.Empty(t, ac.FindDetectorMatches([]byte("ak 0123456789abcdef")))
+		assert.Len(t, ac.FindDetectorMatches([]byte("百度 ak：0123456789abcdef")), 1)
+		assert.Len(t, ac.FindDetectorMatches([]byte("“ak”　＝ “0123456789abcdef”")), 1)
+		assert.Empty(t, ac.RejectedKeywords())
+	})
+}
+
 func TestFindDetectorMatches(t *testing.T) {
 	testCases := []struct {
 		name           string
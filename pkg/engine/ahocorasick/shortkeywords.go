@@ -0,0 +1,93 @@
+package ahocorasick
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// DefaultMinKeywordLength is the recommended shortest keyword to dispatch on.
+// Shorter keywords occur in nearly every chunk by chance, so a detector that
+// declares one is invoked on almost everything. The core only enforces a
+// minimum when it is configured with WithMinKeywordLength, which the engine
+// does by default.
+const DefaultMinKeywordLength = 4
+
+// defaultAllowedShortKeywords are keywords below the minimum length that are
+// still specific enough to dispatch on: fixed token prefixes and product names
+// for which there is no longer identifier.
+var defaultAllowedShortKeywords = []string{
+	"-us", "0.a", "1.a", "8x8", "ark", "box", "eyj", "hf_", "ibm", "kty", "lob", "m3o", "mux", "ngc", "npm",
+	"pd-", "pd_", "pk_", "q~", "r8_", "rev", "rpc", "sg.", "sid", "sk-", "sl.", "sql", "tly", "tru", "wif", "wit",
+	"wiz", "xrp",
+}
+
+// keywordShimWindow is how many bytes from the start of a short keyword a
+// shim pattern can look at.
+const keywordShimWindow = 128
+
+// keywordShims are secondary prefilters for short keywords that detectors
+// still declare. A shimmed keyword only dispatches if its pattern matches at
+// the keyword's position, which keeps existing detectors working without
+// being invoked on every chunk that happens to contain the keyword. Patterns
+// are matched against lowercase data for case-insensitive keywords.
+var keywordShims = map[string]*regexp.Regexp{
+	// baidu, baidu2: ak = "...", "ak": "...", and ak：... with the full-width
+	// punctuation of Chinese documents.
+	"ak": regexp.MustCompile(`(?i)^ak["'“”]?[\s\x{3000}]*[:=：＝]`),
+	// ethereumprivatekey: 0x followed by a 256-bit hex key.
+	"0x": regexp.MustCompile(`(?i)^0x[0-9a-f]{64}`),
+	// github v1: ghp_, gho_, ghu_, ghs_ and ghr_ tokens.
+	"gh": regexp.MustCompile(`(?i)^gh[pousr]_`),
+	// dingdoc: A-Tokens start with Mz.
+	"mz": regexp.MustCompile(`(?i)^mz[a-z0-9]{42}`),
+	// voiceflow: VF.<hex> and VF.DM.<hex> API keys.
+	"vf": regexp.MustCompile(`(?i)^vf\.`),
+	"dm": regexp.MustCompile(`(?i)^dm\.[0-9a-f]{24}\.`),
+	// totpseed: 2fa settings, such as 2FA_SECRET= or "2fa": "...".
+	"2fa": regexp.MustCompile(`(?i)^2fa[\w.-]{0,20}?["'\s:=]`),
+	// tonwallet: TON_MNEMONIC=, tonSecretKey, @ton/crypto, tonweb and
+	// toncenter, but not button or singleton.
+	"ton": regexp.MustCompile(`(?i)^ton(?:[\w\s.-]{0,20}?(?:mnemonic|seed|phrase|private|secret|wallet)|center|web|keeper|/)`),
+}
+
+// WithMinKeywordLength sets the shortest keyword the core dispatches on. Keywords below the minimum are dropped
+// unless they are allowed, either by default or in allowed, or have a shim that confirms each match. Dropped keywords
+// are reported by RejectedKeywords. The keywords of custom regex detectors are always used, as they are configured
+// by the user on purpose.
+func WithMinKeywordLength(minLength int, allowed ...string) CoreOption {
+	return func(ac *Core) {
+		ac.minKeywordLength = minLength
+		for _, kw := range allowed {
+			ac.allowedShortKeywords[strings.ToLower(kw)] = struct{}{}
+		}
+	}
+}
+
+// keywordPolicy decides whether a keyword declared by a detector is used for
+// dispatching, and if so, whether it needs a shim.
+func (ac *Core) keywordPolicy(d detectors.Detector, kw string) (shim *regexp.Regexp, ok bool) {
+	if len(kw) >= ac.minKeywordLength || d.Type() == detector_typepb.DetectorType_CustomRegex {
+		return nil, true
+	}
+	if _, allowed := ac.allowedShortKeywords[strings.ToLower(kw)]; allowed {
+		return nil, true
+	}
+	if shim, ok := keywordShims[strings.ToLower(kw)]; ok {
+		return shim, true
+	}
+	return nil, false
+}
+
+// confirmShim reports whether the shim of a keyword matches at the keyword's
+// position in data.
+func confirmShim(shim *regexp.Regexp, data []byte, pos int64) bool {
+	end := min(pos+keywordShimWindow, int64(len(data)))
+	return shim.Match(data[pos:end])
+}
+
+// RejectedKeywords returns the keywords that were dropped for being shorter
+// than the minimum keyword length, and the detectors that declared them.
+func (ac *Core) RejectedKeywords() map[string][]DetectorKey { return ac.rejectedKeywords }
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"runtime"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/ciartifacts"
+)
+
+// ScanCIArtifacts scans the job logs and artifacts of recent GitHub Actions workflow runs and Bitbucket Pipelines.
+func (e *Engine) ScanCIArtifacts(ctx context.Context, c sources.CIArtifactsConfig) (sources.JobProgressRef, error) {
+	sourceName := "trufflehog - ci artifacts"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, ciartifacts.SourceType)
+
+	ciSource := &ciartifacts.Source{
+		GitHubRepos:       c.GitHubRepos,
+		GitHubToken:       c.GitHubToken,
+		GitHubEndpoint:    c.GitHubEndpoint,
+		BitbucketRepos:    c.BitbucketRepos,
+		BitbucketToken:    c.BitbucketToken,
+		BitbucketEndpoint: c.BitbucketEndpoint,
+		MaxRuns:           c.MaxRuns,
+	}
+	if err := ciSource.Init(ctx, sourceName, jobID, sourceID, true, nil, runtime.NumCPU()); err != nil {
+		return sources.JobProgressRef{}, err
+	}
+	return e.sourceManager.EnumerateAndScan(ctx, sourceName, ciSource)
+}
@@ -0,0 +1,246 @@
+package engine
+
+import (
+	"bytes"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// DeepVerification configures the deep verification tier. Detectors that
+// implement detectors.DeepVerifier verify their results cheaply inline, and
+// the expensive checks run afterwards on a bounded queue so they cannot stall
+// the detector workers.
+type DeepVerification struct {
+	// Enabled turns on deep verification of verified results.
+	Enabled bool
+	// Workers is the number of concurrent deep verifications. Default: 4.
+	Workers int
+	// QueueSize is the number of results that may wait for deep
+	// verification. Results that do not fit are dispatched without it.
+	// Default: 256.
+	QueueSize int
+	// Timeout bounds each deep verification. Default: 30s.
+	Timeout time.Duration
+	// FollowUp dispatches results right away and their deep-verified copies as
+	// follow-up events, instead of holding results back until their deep
+	// verification completes.
+	FollowUp bool
+}
+
+const (
+	defaultDeepVerificationWorkers   = 4
+	defaultDeepVerificationQueueSize = 256
+	defaultDeepVerificationTimeout   = 30 * time.Second
+)
+
+// ExtraData keys describing the outcome of a deep verification.
+const (
+	deepVerificationKey      = "deep_verification"
+	deepVerificationErrorKey = "deep_verification_error"
+
+	deepVerificationComplete = "complete"
+	deepVerificationFailed   = "failed"
+	deepVerificationSkipped  = "skipped"
+)
+
+// deepVerifier runs the deep verifications of a scan. A nil *deepVerifier
+// accepts no results.
+type deepVerifier struct {
+	cfg       DeepVerification
+	verifiers map[detector_typepb.DetectorType]detectors.DeepVerifier
+	// emit dispatches a result once its deep verification is done.
+	emit func(ctx context.Context, result detectors.ResultWithMetadata) error
+
+	jobs    chan detectors.ResultWithMetadata
+	wg      sync.WaitGroup
+	skipped atomic.Uint64
+}
+
+// newDeepVerifier returns nil if deep verification is disabled or none of the
+// detectors supports it.
+func newDeepVerifier(
+	cfg DeepVerification,
+	dets []detectors.Detector,
+	emit func(ctx context.Context, result detectors.ResultWithMetadata) error,
+) *deepVerifier {
+	if !cfg.Enabled {
+		return nil
+	}
+	verifiers := make(map[detector_typepb.DetectorType]detectors.DeepVerifier)
+	for _, d := range dets {
+		v, ok := d.(detectors.DeepVerifier)
+		if !ok {
+			continue
+		}
+		// Custom detectors share a type; the first one wins.
+		if _, ok := verifiers[d.Type()]; !ok {
+			verifiers[d.Type()] = v
+		}
+	}
+	if len(verifiers) == 0 {
+		return nil
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultDeepVerificationWorkers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultDeepVerificationQueueSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultDeepVerificationTimeout
+	}
+	return &deepVerifier{
+		cfg:       cfg,
+		verifiers: verifiers,
+		emit:      emit,
+		jobs:      make(chan detectors.ResultWithMetadata, cfg.QueueSize),
+	}
+}
+
+// start launches the deep verification workers.
+func (d *deepVerifier) start(ctx context.Context) {
+	if d == nil {
+		return
+	}
+	ctx.Logger().V(2).Info("starting deep verification workers", "count", d.cfg.Workers)
+	for worker := 0; worker < d.cfg.Workers; worker++ {
+		d.wg.Add(1)
+		go func() {
+			ctx := context.WithValue(ctx, "deep_verification_worker_id", common.RandomID(5))
+			defer common.Recover(ctx)
+			defer d.wg.Done()
+			for result := range d.jobs {
+				d.verify(ctx, result)
+			}
+		}()
+	}
+}
+
+// finish waits for the queued deep verifications to complete. No results may
+// be submitted afterwards.
+func (d *deepVerifier) finish(ctx context.Context) {
+	if d == nil {
+		return
+	}
+	close(d.jobs)
+	d.wg.Wait()
+	if skipped := d.skipped.Load(); skipped > 0 {
+		ctx.Logger().Info("deep verification queue was full, some results were not deep verified", "skipped", skipped)
+	}
+}
+
+// accepts reports whether a result is deep verified.
+func (d *deepVerifier) accepts(result *detectors.ResultWithMetadata) bool {
+	if d == nil || !result.Verified {
+		return false
+	}
+	_, ok := d.verifiers[result.DetectorType]
+	return ok
+}
+
+// submit queues a result without blocking and reports whether it fit.
+func (d *deepVerifier) submit(result detectors.ResultWithMetadata) bool {
+	select {
+	case d.jobs <- result:
+		return true
+	default:
+		d.skipped.Add(1)
+		return false
+	}
+}
+
+// verify deep verifies a result and dispatches it.
+func (d *deepVerifier) verify(ctx context.Context, result detectors.ResultWithMetadata) {
+	verifyCtx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	err := d.verifiers[result.DetectorType].DeepVerify(verifyCtx, &result.Result)
+	cancel()
+
+	if err != nil {
+		result.ExtraData[deepVerificationKey] = deepVerificationFailed
+		result.ExtraData[deepVerificationErrorKey] = err.Error()
+	} else {
+		result.ExtraData[deepVerificationKey] = deepVerificationComplete
+	}
+	result.FollowUp = d.cfg.FollowUp
+
+	if err := d.emit(ctx, result); err != nil {
+		ctx.Logger().Error(err, "error notifying deep verified result")
+	}
+}
+
+// dispatchResult dispatches a result that passed the notifier's filters. Results
+// that are deep verified are held back until their deep verification
+// completes, or with FollowUp, dispatched right away and again once it
+// completes.
+func (e *Engine) dispatchResult(ctx context.Context, result detectors.ResultWithMetadata) error {
+	d := e.deepVerifier
+	if !d.accepts(&result) {
+		return e.emit(ctx, result)
+	}
+
+	// ExtraData is copied rather than modified in place, as it may be shared
+	// with cached verification results and the dispatched result.
+	queued := result
+	queued.ExtraData = make(map[string]string, len(result.ExtraData)+2)
+	maps.Copy(queued.ExtraData, result.ExtraData)
+
+	if d.cfg.FollowUp {
+		if e.zeroizeSecrets {
+			// The dispatched result is wiped, the queued one needs its own
+			// copy of the secrets.
+			queued.Raw, queued.RawV2 = bytes.Clone(result.Raw), bytes.Clone(result.RawV2)
+		}
+		err := e.emit(ctx, result)
+		d.submit(queued)
+		return err
+	}
+	if d.submit(queued) {
+		return nil
+	}
+	queued.ExtraData[deepVerificationKey] = deepVerificationSkipped
+	return e.emit(ctx, queued)
+}
+
+// emit redacts a result and hands it to the dispatcher, or to the sorter if
+// results are sorted.
+func (e *Engine) emit(ctx context.Context, result detectors.ResultWithMetadata) error {
+	// Redaction and hashing replace the plaintext secrets, which are wiped
+	// along with the result.
+	var plaintext [][]byte
+	if e.zeroizeSecrets {
+		plaintext = [][]byte{result.Raw, result.RawV2}
+	}
+	// Redact and hash last, so that nothing downstream of the engine ever sees
+	// a full seed phrase or, with --hash-secrets, any plaintext secret. Deep
+	// verification needs the plaintext secret, so it happens before.
+	if e.sanitizeSeedPhrases {
+		detectors.SanitizeSeedPhrases(&result)
+	}
+	e.secretHasher.Apply(&result)
+	if e.sorter != nil {
+		e.sorter.add(result, plaintext)
+		return nil
+	}
+	err := e.dispatcher.Dispatch(ctx, result)
+	if e.zeroizeSecrets {
+		zeroize(&result, plaintext)
+	}
+	return err
+}
+
+// zeroize wipes a dispatched result, along with the plaintext secrets that
+// redaction or hashing replaced.
+func zeroize(result *detectors.ResultWithMetadata, plaintext [][]byte) {
+	for _, b := range plaintext {
+		clear(b)
+	}
+	detectors.ZeroizeSecrets(result)
+}
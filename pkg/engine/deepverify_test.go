@@ -0,0 +1,144 @@
+package engine
+
+import (
+	aCtx "context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// deepFakeDetector is a fakeDetectorV1 with an expensive check that marks the
+// results it deep verifies.
+type deepFakeDetector struct {
+	fakeDetectorV1
+	// release, if set, blocks DeepVerify until it is closed.
+	release chan struct{}
+	err     error
+}
+
+var _ detectors.DeepVerifier = (*deepFakeDetector)(nil)
+
+func (d deepFakeDetector) DeepVerify(_ aCtx.Context, result *detectors.Result) error {
+	if d.release != nil {
+		<-d.release
+	}
+	result.ExtraData["balance"] = "42"
+	result.Severity = detectors.SeverityCritical
+	return d.err
+}
+
+// collectDispatcher records the dispatched results.
+type collectDispatcher struct {
+	mu      sync.Mutex
+	results []detectors.ResultWithMetadata
+}
+
+func (d *collectDispatcher) Dispatch(_ context.Context, result detectors.ResultWithMetadata) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.results = append(d.results, result)
+	return nil
+}
+
+func newDeepVerificationEngine(cfg DeepVerification, det detectors.Detector) (*Engine, *collectDispatcher) {
+	dispatcher := new(collectDispatcher)
+	e := &Engine{dispatcher: dispatcher}
+	e.deepVerifier = newDeepVerifier(cfg, []detectors.Detector{det}, e.emit)
+	return e, dispatcher
+}
+
+func deepVerificationResult(verified bool) detectors.ResultWithMetadata {
+	return detectors.ResultWithMetadata{Result: detectors.Result{
+		DetectorType: detector_typepb.DetectorType(-1),
+		Verified:     verified,
+		Raw:          []byte("fake secret v1"),
+		ExtraData:    map[string]string{"address": "0xabc"},
+	}}
+}
+
+func TestNewDeepVerifier(t *testing.T) {
+	emit := func(context.Context, detectors.ResultWithMetadata) error { return nil }
+	assert.Nil(t, newDeepVerifier(DeepVerification{}, []detectors.Detector{deepFakeDetector{}}, emit))
+	assert.Nil(t, newDeepVerifier(DeepVerification{Enabled: true}, []detectors.Detector{fakeDetectorV1{}}, emit))
+
+	d := newDeepVerifier(DeepVerification{Enabled: true}, []detectors.Detector{deepFakeDetector{}}, emit)
+	require.NotNil(t, d)
+	assert.Equal(t, defaultDeepVerificationWorkers, d.cfg.Workers)
+	assert.Equal(t, defaultDeepVerificationQueueSize, cap(d.jobs))
+	assert.Equal(t, defaultDeepVerificationTimeout, d.cfg.Timeout)
+}
+
+func TestDeepVerification_Merge(t *testing.T) {
+	ctx := context.Background()
+	e, dispatcher := newDeepVerificationEngine(DeepVerification{Enabled: true}, deepFakeDetector{})
+	e.deepVerifier.start(ctx)
+
+	result := deepVerificationResult(true)
+	require.NoError(t, e.dispatchResult(ctx, result))
+	require.NoError(t, e.dispatchResult(ctx, deepVerificationResult(false)))
+	e.deepVerifier.finish(ctx)
+
+	// The unverified result is dispatched right away, the verified one once it
+	// was deep verified.
+	require.Len(t, dispatcher.results, 2)
+	assert.False(t, dispatcher.results[0].Verified)
+	assert.NotContains(t, dispatcher.results[0].ExtraData, deepVerificationKey)
+
+	deep := dispatcher.results[1]
+	assert.False(t, deep.FollowUp)
+	assert.Equal(t, map[string]string{"address": "0xabc", "balance": "42", deepVerificationKey: deepVerificationComplete}, deep.ExtraData)
+	assert.Equal(t, detectors.SeverityCritical, deep.Severity)
+	// The ExtraData of the original result, which may be cached, is left alone.
+	assert.Equal(t, map[string]string{"address": "0xabc"}, result.ExtraData)
+}
+
+func TestDeepVerification_FollowUp(t *testing.T) {
+	ctx := context.Background()
+	e, dispatcher := newDeepVerificationEngine(
+		DeepVerification{Enabled: true, FollowUp: true},
+		deepFakeDetector{err: errors.New("rpc unavailable")},
+	)
+	e.deepVerifier.start(ctx)
+
+	require.NoError(t, e.dispatchResult(ctx, deepVerificationResult(true)))
+	e.deepVerifier.finish(ctx)
+
+	require.Len(t, dispatcher.results, 2)
+	first, followUp := dispatcher.results[0], dispatcher.results[1]
+	assert.False(t, first.FollowUp)
+	assert.Equal(t, map[string]string{"address": "0xabc"}, first.ExtraData)
+
+	assert.True(t, followUp.FollowUp)
+	assert.Equal(t, deepVerificationFailed, followUp.ExtraData[deepVerificationKey])
+	assert.Equal(t, "rpc unavailable", followUp.ExtraData[deepVerificationErrorKey])
+}
+
+func TestDeepVerification_QueueFull(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	e, dispatcher := newDeepVerificationEngine(
+		DeepVerification{Enabled: true, Workers: 1, QueueSize: 1},
+		deepFakeDetector{release: release},
+	)
+
+	// Without workers, the first result fills the queue.
+	require.NoError(t, e.dispatchResult(ctx, deepVerificationResult(true)))
+	require.NoError(t, e.dispatchResult(ctx, deepVerificationResult(true)))
+
+	require.Len(t, dispatcher.results, 1)
+	assert.Equal(t, deepVerificationSkipped, dispatcher.results[0].ExtraData[deepVerificationKey])
+	assert.Equal(t, uint64(1), e.deepVerifier.skipped.Load())
+
+	e.deepVerifier.start(ctx)
+	close(release)
+	e.deepVerifier.finish(ctx)
+	require.Len(t, dispatcher.results, 2)
+	assert.Equal(t, deepVerificationComplete, dispatcher.results[1].ExtraData[deepVerificationKey])
+}
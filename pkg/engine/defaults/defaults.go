@@ -26,10 +26,12 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alibabaak"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alibabadm"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alienvault"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/alipay"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/allsports"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/amadeus"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ambee"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/amplitudeapikey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ansiblevault"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/anthropic"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/anypoint"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/anypointoauth2"
@@ -52,6 +54,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/artifactory"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/artifactoryreferencetoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/artsy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/arweavewallet"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/asanaoauth"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/asanapersonalaccesstoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/assemblyai"
@@ -101,6 +104,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/billomat"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bingsubscriptionkey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitbar"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitbucketaccesstoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitbucketapppassword"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitcoinaverage"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitcoinwif"
@@ -118,6 +122,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/braintreepayments"
 	brandfetchv1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/brandfetch/v1"
 	brandfetchv2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/brandfetch/v2"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/browsercredentialstore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/browserstack"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/browshot"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bscscan"
@@ -129,7 +134,9 @@ import (
 	buildKitev2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/buildkite/v2"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bulbul"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bulksms"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bundledenv"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/buttercms"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cacheauth"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/caflou"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/calendarific"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/calendlyapikey"
@@ -142,6 +149,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/carboninterface"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cashboard"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/caspio"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cdnauthkey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/censys"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/centralstationcrm"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cexio"
@@ -152,6 +160,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/checklyhq"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/checkout"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/checkvist"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/chiakey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cicero"
 	circleciV1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/circleci/v1"
 	circleciV2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/circleci/v2"
@@ -172,9 +181,11 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudflarecakey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudflareglobalapikey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudimage"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudinit"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudmersive"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudplan"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudsmith"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloudsts"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloverly"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cloze"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/clustdoc"
@@ -183,6 +194,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/codeclimate"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/codemagic"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/codequiry"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/codesigning"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/coinapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/coinbase"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/coinlayer"
@@ -193,12 +205,15 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/commodities"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/companyhub"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/confluent"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/consulconnect"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/contentfulpersonalaccesstoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/contractdeployer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/conversiontools"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/convertapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/convertkit"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/convier"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/copper"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cosmoskey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/couchbase"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/countrylayer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/courier"
@@ -272,8 +287,11 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/enigma"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/envoyapikey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/eraser"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/etcd"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethereumrpc"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/etherscan"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethplorer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ethvalidatorkeystore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/eventbrite"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/everhour"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/exchangerateapi"
@@ -289,6 +307,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/fibery"
 	figmapersonalaccesstokenv1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/figmapersonalaccesstoken/v1"
 	figmapersonalaccesstokenv2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/figmapersonalaccesstoken/v2"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/filecoinprivatekey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/fileio"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/finage"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/financialmodelingprep"
@@ -316,6 +335,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/freshbooks"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/freshdesk"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/front"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/frontendstoragekey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/ftp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/fulcrum"
 	fullstoryv1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/fullstory/v1"
@@ -367,6 +387,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/hashicorpvaultauth"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/hasura"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/hellosign"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/helmvalues"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/helpcrunch"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/helpscout"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/hereapi"
@@ -392,6 +413,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/iexcloud"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/imagekit"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/imagga"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/imdscredential"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/impala"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/infura"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/insightly"
@@ -423,6 +445,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/kickbox"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/klaviyo"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/klipfolio"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/kmskeymaterial"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/knapsackpro"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/kontent"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/kraken"
@@ -446,6 +469,8 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/linkpreview"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/liveagent"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/livestorm"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/llmconfigexamplekey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/lndmacaroon"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/loadmill"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/locationiq"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/loggly"
@@ -481,6 +506,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/messagebird"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/metaapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/metabase"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/metamaskvault"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/metrilo"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/microsoftteamswebhook"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/mindmeister"
@@ -499,6 +525,8 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/mux"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/myfreshworks"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/myintervals"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/neon"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/neteaseyidun"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nethunt"
 	netlifyv1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/netlify/v1"
 	netlifyv2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/netlify/v2"
@@ -514,6 +542,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nicereply"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nightfall"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nimble"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nomad"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/noticeable"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/notion"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nozbeteams"
@@ -525,6 +554,8 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nvapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/nylas"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/oanda"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/oauthtokencache"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/oidcclientsecret"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/okta"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/omnisend"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/onedesk"
@@ -544,6 +575,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/overloop"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/owlbot"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/packagecloud"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/paddle"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/pagarme"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/pagerdutyapikey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/pandadoc"
@@ -598,6 +630,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/prodpad"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/prospectcrm"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/protocolsio"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/proxybasicauth"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/proxycrawl"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/pubnubpublishkey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/pubnubsubscriptionkey"
@@ -664,6 +697,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/screenshotapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/screenshotlayer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/scrutinizerci"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sealedsecrets"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/securitytrails"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/segmentapikey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/selectpdf"
@@ -672,11 +706,13 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sendbirdorganizationapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sendgrid"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sendinbluev2"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentrydsn"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentryorgtoken"
 	sentrytokenv1 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentrytoken/v1"
 	sentrytokenv2 "github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentrytoken/v2"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/serphouse"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/serpstack"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sessioncookie"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sheety"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sherpadesk"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/shipday"
@@ -710,17 +746,21 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/snowflake"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/snykkey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sonarcloud"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sopsage"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sourcegraph"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sourcegraphcody"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/speechtextai"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/splunkobservabilitytoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/spoonacular"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sportsmonk"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/springconfig"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sqldumpcredential"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sqlserver"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/square"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/squareapp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/squarespace"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/squareup"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sshkeyaccess"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sslmate"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/statuscake"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/statuspage"
@@ -739,8 +779,10 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/stripepaymentintent"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/stripo"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/stytch"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/submail"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sugester"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sumologickey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/supabaseservicekey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/supabasetoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/supernotesapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/surveyanyplace"
@@ -766,6 +808,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tencent"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tencentak"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/terraformcloudpersonaltoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/terraformstate"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/testingbot"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/textmagic"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/theoddsapi"
@@ -782,6 +825,8 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tokeet"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tomorrowio"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tomtom"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tonwallet"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/totpseed"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/tradier"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/transferwise"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/travelpayouts"
@@ -832,6 +877,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/voodoosms"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vouchery"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vpnapi"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vpncredential"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vultrapikey"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/vyte"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/walkscore"
@@ -844,6 +890,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/webscraper"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/webscraping"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/websitepulse"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/wechatpay"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/weightsandbiases"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/whoxy"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/wistia"
@@ -854,11 +901,13 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/worldweather"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/wrike"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/xai"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/xrpseed"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/yandex"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/yelp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/youneedabudget"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/yousign"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/youtubeapikey"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/yunpian"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/yuque"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/zendeskapi"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/zenkitapi"
@@ -1432,6 +1481,7 @@ func buildDetectorList() []detectors.Detector {
 		&overloop.Scanner{},
 		&owlbot.Scanner{},
 		&packagecloud.Scanner{},
+		&paddle.Scanner{},
 		&pagarme.Scanner{},
 		&pagerdutyapikey.Scanner{},
 		&pandadoc.Scanner{},
@@ -1786,6 +1836,54 @@ func buildDetectorList() []detectors.Detector {
 		&bitcoinwif.Scanner{},
 		//&ethereumprivatekey.Scanner{},
 		&cozetoken.Scanner{},
+		&sentrydsn.Scanner{},
+		&ethereumrpc.Scanner{},
+		&metamaskvault.Scanner{},
+		&chiakey.Scanner{},
+		&filecoinprivatekey.Scanner{},
+		&arweavewallet.Scanner{},
+		&lndmacaroon.Scanner{},
+		&contractdeployer.Scanner{},
+		&cloudsts.Scanner{},
+		&sessioncookie.Scanner{},
+		&supabaseservicekey.Scanner{},
+		&neon.Scanner{},
+		&alipay.Scanner{},
+		&wechatpay.Scanner{},
+		&frontendstoragekey.Scanner{},
+		&bundledenv.Scanner{},
+		&cloudinit.Scanner{},
+		&terraformstate.Scanner{},
+		&ansiblevault.Scanner{},
+		&sopsage.Scanner{},
+		&kmskeymaterial.Scanner{},
+		&sqldumpcredential.Scanner{},
+		&oauthtokencache.Scanner{},
+		&browsercredentialstore.Scanner{},
+		&totpseed.Scanner{},
+		&vpncredential.Scanner{},
+		&imdscredential.Scanner{},
+		&yunpian.Scanner{},
+		&submail.Scanner{},
+		&neteaseyidun.Scanner{},
+		&springconfig.Scanner{},
+		&helmvalues.Scanner{},
+		sealedsecrets.New(),
+		&codesigning.Scanner{},
+		&nomad.Scanner{},
+		&consulconnect.Scanner{},
+		&etcd.Scanner{},
+		&cdnauthkey.Scanner{},
+		&sshkeyaccess.Scanner{},
+		&cacheauth.Scanner{},
+		&bitbucketaccesstoken.Scanner{},
+		&oidcclientsecret.Scanner{},
+		&xrpseed.Scanner{},
+		&cosmoskey.Scanner{},
+		&llmconfigexamplekey.Scanner{},
+		&tonwallet.Scanner{},
+		&proxybasicauth.Scanner{},
+		&ethvalidatorkeystore.Scanner{},
 	}
 }
 
@@ -65,3 +65,52 @@ func TestDefaultVersionerDetectorsHaveNonZeroVersions(t *testing.T) {
 		)
 	}
 }
+
+// metadataRequired lists the detector types that must declare a default severity and tags. Detectors of
+// cryptocurrency keys and of cloud providers operating in mainland China are covered so far.
+var metadataRequired = []detector_typepb.DetectorType{
+	detector_typepb.DetectorType_BitcoinWIF,
+	detector_typepb.DetectorType_EthereumRPC,
+	detector_typepb.DetectorType_MetaMaskVault,
+	detector_typepb.DetectorType_ChiaKey,
+	detector_typepb.DetectorType_FilecoinPrivateKey,
+	detector_typepb.DetectorType_ArweaveWallet,
+	detector_typepb.DetectorType_LNDMacaroon,
+	detector_typepb.DetectorType_ContractDeployer,
+	detector_typepb.DetectorType_Alibaba,
+	detector_typepb.DetectorType_Alibabaak,
+	detector_typepb.DetectorType_Alibabadm,
+	detector_typepb.DetectorType_Baidu,
+	detector_typepb.DetectorType_Baidu2,
+	detector_typepb.DetectorType_BaiduApiKey,
+	detector_typepb.DetectorType_BaiLian,
+	detector_typepb.DetectorType_Tencent,
+	detector_typepb.DetectorType_TencentAK,
+	detector_typepb.DetectorType_Volcengine,
+	detector_typepb.DetectorType_Huawei,
+	detector_typepb.DetectorType_Doubao,
+	detector_typepb.DetectorType_HunYuan,
+}
+
+func TestDefaultDetectorsDeclareMetadata(t *testing.T) {
+	providers := DefaultDetectorTypesImplementing[detectors.MetadataProvider]()
+	for _, detectorType := range metadataRequired {
+		if _, ok := providers[detectorType]; !ok {
+			t.Errorf("detector %q must implement detectors.MetadataProvider", detector_typepb.DetectorType_name[int32(detectorType)])
+		}
+	}
+
+	for _, detector := range DefaultDetectors() {
+		provider, ok := detector.(detectors.MetadataProvider)
+		if !ok {
+			continue
+		}
+		name := detector_typepb.DetectorType_name[int32(detector.Type())]
+		if provider.DefaultSeverity() == detectors.SeverityUnspecified {
+			t.Errorf("detector %q declares no default severity", name)
+		}
+		if len(provider.Tags()) == 0 {
+			t.Errorf("detector %q declares no tags", name)
+		}
+	}
+}
@@ -5,11 +5,13 @@ package engine
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -50,9 +52,19 @@ type Metrics struct {
 	VerifiedSecretsFound   uint64
 	UnverifiedSecretsFound uint64
 	AvgDetectorTime        map[string]time.Duration
+	// CandidateRuleHits is the number of candidates matched by each configured candidate rule.
+	CandidateRuleHits map[string]uint64
+	// SuppressedFindings is the number of results dropped because the chunk suppressed their detector, e.g. by a
+	// .securityscanignore file.
+	SuppressedFindings uint64
 
 	scanStartTime time.Time
 	ScanDuration  time.Duration
+
+	// Truncated is set if the scan was stopped early by one of its
+	// ScanLimits, in which case the results are partial.
+	Truncated        bool
+	TruncationReason string
 }
 
 // runtimeMetrics for the scan engine for internal use by the engine.
@@ -112,19 +124,74 @@ type Config struct {
 
 	// Verify determines whether the scanner will verify candidate secrets.
 	Verify bool
+	// ReplaySessionCookies enables replay verification for detectors that
+	// implement detectors.ReplayVerifier.
+	ReplaySessionCookies bool
+	// ProbeDatastoreAuth enables auth probes for detectors that implement
+	// detectors.AuthProbeVerifier.
+	ProbeDatastoreAuth bool
 
 	// Defines which results will be notified by the engine
 	// (e.g., verified, unverified, unknown)
 	Results               map[string]struct{}
 	LogFilteredUnverified bool
 
+	// OnlyVerified drops all unverified results before they are dispatched.
+	OnlyVerified bool
+	// IncludeIndeterminate keeps results whose verification failed with an
+	// error when OnlyVerified is set.
+	IncludeIndeterminate bool
+	// MinConfidence drops results rated below the given confidence.
+	MinConfidence detectors.Confidence
+
 	// FilterEntropy filters out unverified results using Shannon entropy.
 	FilterEntropy float64
+	// CandidateRules are global ignore and report rules evaluated against raw
+	// candidates before they are verified.
+	CandidateRules *detectors.CandidateRules
+	// SanitizeSeedPhrases redacts seed phrases from every result before it is
+	// dispatched, leaving only their first word and a hash.
+	SanitizeSeedPhrases bool
+	// SecretHasher, if set, replaces raw secrets in every result with their
+	// HMAC before it is dispatched.
+	SecretHasher *detectors.SecretHasher
+	// ZeroizeSecrets wipes the raw secrets of every result once the dispatcher
+	// has consumed it, and keeps only hashes of secrets in the engine's caches.
+	// The dispatcher must not retain results after Dispatch returns, so it
+	// cannot be combined with a StreamDispatcher.
+	ZeroizeSecrets bool
+	// CanaryList tags results that look like honeypots or canaries. If nil,
+	// only the built-in list and heuristics are used.
+	CanaryList *detectors.CanaryList
+	// OwnedWallets marks keys that control one of these wallets as critical.
+	OwnedWallets *detectors.OwnedWallets
+	// ExpectedOwners tags verified credentials as the organization's own or a
+	// third party's, based on the identity verification returns.
+	ExpectedOwners *detectors.ExpectedOwners
+	// Limits bound the bytes, findings and wall-clock time of the scan.
+	Limits ScanLimits
+	// DeepVerification runs the expensive checks of detectors that support
+	// them on a bounded queue after verification.
+	DeepVerification DeepVerification
+	// SortResults holds results back until the scan finishes and dispatches
+	// them in a deterministic order, for reports that are stable run to run.
+	SortResults bool
 	// FilterUnverified sets the filterUnverified flag on the engine. If set to
 	// true, the engine will only return the first unverified result for a chunk for a detector.
 	FilterUnverified      bool
 	ShouldScanEntireChunk bool
 
+	// MinKeywordLength is the shortest detector keyword that chunks are
+	// dispatched on. Shorter keywords are dropped unless they are in
+	// AllowedShortKeywords or known to the core. Keywords of custom regex
+	// detectors are never dropped. A negative value disables the minimum.
+	// Default: ahocorasick.DefaultMinKeywordLength.
+	MinKeywordLength     int
+	AllowedShortKeywords []string
+	// KeywordPacks are the names of the keyword packs to enable, e.g.
+	// detectors.KeywordPackChinese.
+	KeywordPacks []string
+
 	Dispatcher ResultsDispatcher
 
 	// SourceManager is used to manage the sources and units.
@@ -139,6 +206,10 @@ type Config struct {
 	// and should be avoided unless specified by the user.
 	PrintAvgDetectorTime bool
 
+	// DebugFindings records on every result the keywords that dispatched it
+	// and the patterns that captured it, for reporting false positives.
+	DebugFindings bool
+
 	// VerificationOverlap determines whether the scanner will attempt to verify candidate secrets
 	// that have been detected by multiple detectors.
 	// By default, it is set to true.
@@ -156,6 +227,16 @@ type Config struct {
 	VerificationResultCache  verificationcache.ResultCache
 	VerificationCacheMetrics verificationcache.MetricsReporter
 
+	// ResolveRotatedAfter is the number of consecutive invalid verifications after which a credential that was
+	// verified live before is reported as resolved_rotated. 0 disables it.
+	ResolveRotatedAfter int
+	// ReverifyInterval is how long the cached verification of a live credential is used when ResolveRotatedAfter is
+	// set, before the credential is verified again.
+	ReverifyInterval time.Duration
+	// RotationStateFile, if set with ResolveRotatedAfter, is read when the engine is created and written when it
+	// finishes, so that the verifications of scheduled scans count towards resolving a credential.
+	RotationStateFile string
+
 	// MaxDecodeDepth is the maximum number of iterative decoding passes per chunk.
 	// When a decoder transforms data, all decoders are re-run on the output up to this limit.
 	// 1 = single pass (no chaining), 2+ = chained (e.g., base64 inside UTF-16).
@@ -173,6 +254,8 @@ type Engine struct {
 	decoders          []decoders.Decoder
 	detectors         []detectors.Detector
 	verificationCache *verificationcache.VerificationCache
+	// rotationStateFile is where the rotation state of the verification cache is written when the scan finishes.
+	rotationStateFile string
 	// Any detectors configured to override sources' verification flags
 	detectorVerificationOverrides map[config.DetectorID]bool
 
@@ -188,10 +271,56 @@ type Engine struct {
 	retainFalsePositives    bool
 	verificationOverlap     bool
 	printAvgDetectorTime    bool
+	// debugFindings attributes results to the keywords and patterns that produced them.
+	debugFindings bool
 	// By default, the engine will only scan a subset of the chunk if a detector matches the chunk.
 	// If this flag is set to true, the engine will scan the entire chunk.
 	scanEntireChunk bool
 
+	minKeywordLength     int
+	allowedShortKeywords []string
+	keywordPacks         []string
+	// candidateRules drops ignored candidates before verification and forces
+	// reporting of candidates matching a report rule.
+	candidateRules *detectors.CandidateRules
+	// canaries tags suspected canaries right before results are emitted.
+	canaries *detectors.CanaryList
+	// ownedWallets tags keys of the organization's own wallets right before results are emitted.
+	ownedWallets *detectors.OwnedWallets
+	// expectedOwners tags verified credentials with their owner right before results are emitted.
+	expectedOwners *detectors.ExpectedOwners
+	// detectorMetadata holds the default severity, tags and remediation declared by the configured detectors.
+	detectorMetadata detectorMetadata
+	// keyCorrelator tags encrypted material and cloud credentials that unlock each other right before results are
+	// emitted.
+	keyCorrelator *detectors.KeyCorrelator
+	// limits and limiter stop the scan early once a resource limit is exceeded.
+	limits  ScanLimits
+	limiter *scanLimiter
+	// interruption is set once the scan is interrupted.
+	interruption interruption
+	// deepVerifier runs the expensive checks of verified results. Nil if deep
+	// verification is disabled.
+	deepVerifier *deepVerifier
+	// sorter holds results back to dispatch them in order. Nil unless results
+	// are sorted.
+	sorter *resultSorter
+	// sanitizeSeedPhrases redacts seed phrases from results before they are emitted.
+	sanitizeSeedPhrases bool
+	// secretHasher hashes raw secrets right before results are emitted.
+	secretHasher *detectors.SecretHasher
+	// zeroizeSecrets wipes raw secrets once results are dispatched.
+	zeroizeSecrets bool
+	// outputFilter is applied to every result right before it is dispatched.
+	outputFilter outputFilter
+	// passwords collects candidate passwords per source unit for detectors that
+	// find password-encrypted secret material. Nil if no such detector is enabled.
+	passwords *detectors.PasswordPool
+	// sshFiles collects the authorized_keys, known_hosts and ssh config files
+	// of each source unit for detectors of SSH private keys. Nil if no such
+	// detector is enabled.
+	sshFiles *detectors.SSHFilePool
+
 	// ahoCorasickHandler manages the Aho-Corasick trie and related keyword lookups.
 	AhoCorasickCore *ahocorasick.Core
 
@@ -236,30 +365,63 @@ type Engine struct {
 // NewEngine creates a new Engine instance with the provided configuration.
 func NewEngine(ctx context.Context, cfg *Config) (*Engine, error) {
 	verificationCache := verificationcache.New(cfg.VerificationResultCache, cfg.VerificationCacheMetrics)
+	var rotationStateFile string
+	if cfg.ResolveRotatedAfter > 0 {
+		verificationCache.TrackRotation(cfg.ResolveRotatedAfter, cfg.ReverifyInterval)
+		if cfg.RotationStateFile != "" {
+			if err := verificationCache.ReadRotationState(cfg.RotationStateFile); err != nil {
+				return nil, fmt.Errorf("error reading rotation state: %w", err)
+			}
+			rotationStateFile = cfg.RotationStateFile
+		}
+	}
 
 	engine := &Engine{
 		concurrency:                         cfg.Concurrency,
 		decoders:                            cfg.Decoders,
 		detectors:                           cfg.Detectors,
 		verificationCache:                   verificationCache,
+		rotationStateFile:                   rotationStateFile,
 		dispatcher:                          cfg.Dispatcher,
 		verify:                              cfg.Verify,
 		filterUnverified:                    cfg.FilterUnverified,
 		filterEntropy:                       cfg.FilterEntropy,
+		candidateRules:                      cfg.CandidateRules,
+		canaries:                            cfg.CanaryList,
+		ownedWallets:                        cfg.OwnedWallets,
+		expectedOwners:                      cfg.ExpectedOwners,
+		keyCorrelator:                       detectors.NewKeyCorrelator(),
+		limits:                              cfg.Limits,
+		sorter:                              newResultSorter(cfg.SortResults),
+		sanitizeSeedPhrases:                 cfg.SanitizeSeedPhrases,
+		secretHasher:                        cfg.SecretHasher,
+		zeroizeSecrets:                      cfg.ZeroizeSecrets,
 		printAvgDetectorTime:                cfg.PrintAvgDetectorTime,
+		debugFindings:                       cfg.DebugFindings,
 		retainFalsePositives:                cfg.LogFilteredUnverified,
 		verificationOverlap:                 cfg.VerificationOverlap,
 		sourceManager:                       cfg.SourceManager,
 		scanEntireChunk:                     cfg.ShouldScanEntireChunk,
+		minKeywordLength:                    cfg.MinKeywordLength,
+		allowedShortKeywords:                cfg.AllowedShortKeywords,
+		keywordPacks:                        cfg.KeywordPacks,
 		detectorVerificationOverrides:       cfg.DetectorVerificationOverrides,
 		detectorWorkerMultiplier:            cfg.DetectorWorkerMultiplier,
 		notificationWorkerMultiplier:        cfg.NotificationWorkerMultiplier,
 		verificationOverlapWorkerMultiplier: cfg.VerificationOverlapWorkerMultiplier,
 		maxDecodeDepth:                      cfg.MaxDecodeDepth,
+		outputFilter: outputFilter{
+			onlyVerified:         cfg.OnlyVerified,
+			includeIndeterminate: cfg.IncludeIndeterminate,
+			minConfidence:        cfg.MinConfidence,
+		},
 	}
 	if engine.sourceManager == nil {
 		return nil, fmt.Errorf("source manager is required")
 	}
+	if _, ok := engine.dispatcher.(*StreamDispatcher); ok && engine.zeroizeSecrets {
+		return nil, fmt.Errorf("zeroizing secrets is not supported with a results stream, which retains results")
+	}
 
 	engine.setDefaults(ctx)
 
@@ -316,6 +478,30 @@ func NewEngine(ctx context.Context, cfg *Config) (*Engine, error) {
 	}
 	engine.applyFilters(filters...)
 
+	engine.deepVerifier = newDeepVerifier(cfg.DeepVerification, engine.detectors, engine.emit)
+
+	if cfg.ReplaySessionCookies {
+		for _, d := range engine.detectors {
+			if replayer, ok := d.(detectors.ReplayVerifier); ok {
+				replayer.SetReplayVerification(true)
+			}
+		}
+	}
+	if cfg.ProbeDatastoreAuth {
+		for _, d := range engine.detectors {
+			if prober, ok := d.(detectors.AuthProbeVerifier); ok {
+				prober.SetAuthProbe(true)
+			}
+		}
+	}
+
+	if engine.verify && hasPasswordDecrypters(engine.detectors) {
+		engine.passwords = detectors.NewPasswordPool()
+	}
+	if hasSSHAccessReporters(engine.detectors) {
+		engine.sshFiles = detectors.NewSSHFilePool()
+	}
+
 	if results := cfg.Results; len(results) > 0 {
 		_, ok := results["verified"]
 		engine.notifyVerifiedResults = ok
@@ -542,13 +728,55 @@ func (e *Engine) initialize(ctx context.Context) error {
 		ahoCOptions = append(ahoCOptions, ahocorasick.WithSpanCalculator(new(ahocorasick.EntireChunkSpanCalculator)))
 	}
 
+	if e.minKeywordLength >= 0 {
+		minKeywordLength := e.minKeywordLength
+		if minKeywordLength == 0 {
+			minKeywordLength = ahocorasick.DefaultMinKeywordLength
+		}
+		ahoCOptions = append(ahoCOptions, ahocorasick.WithMinKeywordLength(minKeywordLength, e.allowedShortKeywords...))
+	}
+	if len(e.keywordPacks) > 0 {
+		ahoCOptions = append(ahoCOptions, ahocorasick.WithKeywordPacks(e.keywordPacks...))
+	}
+
 	ctx.Logger().V(4).Info("setting up aho-corasick core")
 	e.AhoCorasickCore = ahocorasick.NewAhoCorasickCore(e.detectors, ahoCOptions...)
 	ctx.Logger().V(4).Info("set up aho-corasick core")
+	e.detectorMetadata = newDetectorMetadata(e.detectors)
+	e.logRejectedKeywords(ctx)
 
 	return nil
 }
 
+// logRejectedKeywords warns about detector keywords that were too short to
+// dispatch on, and about detectors that are left without any keyword.
+func (e *Engine) logRejectedKeywords(ctx context.Context) {
+	rejected := e.AhoCorasickCore.RejectedKeywords()
+	if len(rejected) == 0 {
+		return
+	}
+
+	rejectedByDetector := make(map[ahocorasick.DetectorKey][]string)
+	for kw, keys := range rejected {
+		for _, key := range keys {
+			rejectedByDetector[key] = append(rejectedByDetector[key], kw)
+		}
+	}
+	for _, d := range e.detectors {
+		key := ahocorasick.CreateDetectorKey(d)
+		kws, ok := rejectedByDetector[key]
+		if !ok {
+			continue
+		}
+		if len(kws) == len(d.Keywords()) {
+			ctx.Logger().Info("detector has no keywords long enough to dispatch on and will not run",
+				"detector", key.Loggable(), "keywords", kws)
+			continue
+		}
+		ctx.Logger().V(2).Info("ignoring short detector keywords", "detector", key.Loggable(), "keywords", kws)
+	}
+}
+
 const ignoreTag = "trufflehog:ignore"
 
 // AhoCorasickCoreKeywords returns a set of keywords that the engine's
@@ -562,6 +790,11 @@ func (e *Engine) AhoCorasickCoreKeywords() map[string]struct{} {
 	return keywords
 }
 
+// Detectors returns the detectors the engine runs, after the include and exclude filters.
+func (e *Engine) Detectors() []detectors.Detector {
+	return slices.Clone(e.detectors)
+}
+
 // HasFoundResults returns true if any results are found.
 func (e *Engine) HasFoundResults() bool {
 	return atomic.LoadUint32(&e.numFoundResults) > 0
@@ -586,6 +819,11 @@ func (e *Engine) GetMetrics() Metrics {
 	}
 
 	result.ScanDuration = e.metrics.getScanDuration()
+	result.CandidateRuleHits = e.candidateRules.Hits()
+	result.TruncationReason, result.Truncated = e.limiter.truncation()
+	if !result.Truncated && e.Interrupted() {
+		result.TruncationReason, result.Truncated = e.interruption.reason, true
+	}
 
 	return result
 }
@@ -636,6 +874,10 @@ func (e *Engine) DetectorAvgTime() map[string][]time.Duration {
 // begins processing input data to identify secrets.
 func (e *Engine) Start(ctx context.Context) {
 	e.metrics = runtimeMetrics{Metrics: Metrics{scanStartTime: time.Now()}}
+	e.limiter = newScanLimiter(e.limits, func(reason string) {
+		ctx.Logger().Info("scan limit exceeded, stopping scan with partial results", "reason", reason)
+		e.sourceManager.CancelAll(errScanLimitExceeded)
+	})
 	e.sanityChecks(ctx)
 	e.startWorkers(ctx)
 }
@@ -673,6 +915,9 @@ func (e *Engine) startWorkers(ctx context.Context) {
 	// ResultsDispatcher workers communicate detected issues to the user or any downstream systems.
 	// We want 1/4th of the notifier workers as the number of scanner workers.
 	e.startNotifierWorkers(ctx)
+
+	// Deep verification workers run the expensive checks of verified results off the notifier workers.
+	e.deepVerifier.start(ctx)
 }
 
 func (e *Engine) startScannerWorkers(ctx context.Context) {
@@ -749,11 +994,31 @@ func (e *Engine) Finish(ctx context.Context) error {
 	close(e.detectableChunksChan)
 	e.wgDetectorWorkers.Wait() // Wait for the detector workers to finish detecting chunks.
 
-	close(e.results)    // Detector workers are done, close the results channel and call it a day.
-	e.WgNotifier.Wait() // Wait for the notifier workers to finish notifying results.
+	close(e.results)           // Detector workers are done, close the results channel and call it a day.
+	e.WgNotifier.Wait()        // Wait for the notifier workers to finish notifying results.
+	e.deepVerifier.finish(ctx) // Wait for the results held back for deep verification.
+	e.sorter.flush(ctx, e.dispatcher)
+
+	// Let streaming consumers know that no more findings will be dispatched.
+	if stream, ok := e.dispatcher.(*StreamDispatcher); ok {
+		stream.Close()
+	}
 
 	e.metrics.ScanDuration = time.Since(e.metrics.scanStartTime)
+	e.limiter.stop()
+
+	// Partial scans still verified the credentials they found, so their state is kept.
+	if e.rotationStateFile != "" {
+		if werr := e.verificationCache.WriteRotationState(e.rotationStateFile); werr != nil {
+			ctx.Logger().Error(werr, "error writing rotation state", "path", e.rotationStateFile)
+		}
+	}
 
+	// Sources cancelled because of a scan limit or an interruption are not an
+	// error, the scan is reported as truncated instead.
+	if e.StoppedEarly(err) {
+		err = nil
+	}
 	return err
 }
 
@@ -852,10 +1117,18 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 	var wgVerificationOverlap sync.WaitGroup
 
 	for chunk := range e.ChunksChan() {
+		if e.limiter.exceeded() {
+			// Drain the chunks still in flight from cancelled sources.
+			continue
+		}
 		startTime := time.Now()
 		sourceVerify := chunk.SourceVerify
 
 		chunk.OriginalData = chunk.Data
+		if sourceVerify {
+			e.collectPasswords(chunk)
+		}
+		e.collectSSHFiles(chunk)
 		decoded := iterativeDecode(chunk, e.decoders, e.maxDecodeDepth)
 
 		for _, d := range decoded {
@@ -877,7 +1150,8 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 					chunk:    *d.Chunk,
 					detector: detector,
 					decoder:  d.DecoderType,
-					verify:   e.shouldVerifyChunk(sourceVerify, detector, e.detectorVerificationOverrides),
+					// Suppressed results are only counted, so there is no point in verifying them.
+					verify:   !d.Chunk.Suppressed(detector.Type()) && e.shouldVerifyChunk(sourceVerify, detector, e.detectorVerificationOverrides),
 					wgDoneFn: wgDetect.Done,
 				}
 			}
@@ -897,7 +1171,7 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 		).Inc()
 
 		atomic.AddUint64(&e.metrics.ChunksScanned, 1)
-		atomic.AddUint64(&e.metrics.BytesScanned, uint64(dataSize))
+		e.limiter.addBytes(atomic.AddUint64(&e.metrics.BytesScanned, uint64(dataSize)))
 	}
 
 	wgVerificationOverlap.Wait()
@@ -950,6 +1224,12 @@ type chunkSecretKey struct {
 }
 
 func likelyDuplicate(ctx context.Context, val chunkSecretKey, dupes map[chunkSecretKey]struct{}) bool {
+	_, ok := duplicateOf(ctx, val, dupes)
+	return ok
+}
+
+// duplicateOf returns the secret found by another detector that val is likely a duplicate of.
+func duplicateOf(ctx context.Context, val chunkSecretKey, dupes map[chunkSecretKey]struct{}) (chunkSecretKey, bool) {
 	const similarityThreshold = 0.9
 
 	valStr := val.secret
@@ -970,7 +1250,7 @@ func likelyDuplicate(ctx context.Context, val chunkSecretKey, dupes map[chunkSec
 			ctx.Logger().V(2).Info(
 				"found exact duplicate",
 			)
-			return true
+			return dupeKey, true
 		}
 
 		similarity := strutil.Similarity(valStr, dupe, metrics.NewLevenshtein())
@@ -980,10 +1260,74 @@ func likelyDuplicate(ctx context.Context, val chunkSecretKey, dupes map[chunkSec
 			ctx.Logger().V(2).Info(
 				"found similar duplicate",
 			)
-			return true
+			return dupeKey, true
+		}
+	}
+	return chunkSecretKey{}, false
+}
+
+// secretFinding is a secret found in a verification overlap chunk, and the detector that found it first.
+type secretFinding struct {
+	result   detectors.Result
+	detector *ahocorasick.DetectorMatch
+}
+
+// secretConflict is a secret found by several detectors, at least one of which declares context hints. It is
+// attributed to a single detector, or reported once with all candidates, instead of once per detector.
+type secretConflict struct {
+	finding    secretFinding
+	candidates []*ahocorasick.DetectorMatch
+}
+
+func hasContextHints(d detectors.Detector) bool {
+	_, ok := d.(detectors.ContextHintProvider)
+	return ok
+}
+
+// resolveConflict attributes a secret found by several detectors to the one whose context hints occur in the chunk.
+// The other candidates are not reprocessed, so the secret is only verified against the provider it belongs to. If the
+// context does not tell the candidates apart, the secret is reported once, unverified, listing all candidates.
+func (e *Engine) resolveConflict(
+	ctx context.Context,
+	chunk verificationOverlapChunk,
+	conflict *secretConflict,
+	detectorKeysWithResults map[ahocorasick.DetectorKey]*ahocorasick.DetectorMatch,
+) {
+	candidates := make([]detectors.Detector, len(conflict.candidates))
+	for i, d := range conflict.candidates {
+		candidates[i] = d.Detector
+	}
+	if best, ok := detectors.AttributeSecret(candidates, chunk.chunk.Data); ok {
+		ctx.Logger().V(2).Info("attributed secret found by multiple detectors", "detector", conflict.candidates[best].Key.Loggable())
+		for i, d := range conflict.candidates {
+			if i != best {
+				delete(detectorKeysWithResults, d.Key)
+			}
+		}
+		return
+	}
+
+	names := make([]string, 0, len(conflict.candidates))
+	for _, d := range conflict.candidates {
+		name, ok := detector_typepb.DetectorType_name[int32(d.Key.Type())]
+		if !ok {
+			name = d.Key.Type().String()
 		}
+		names = append(names, name)
+		delete(detectorKeysWithResults, d.Key)
+	}
+	slices.Sort(names)
+
+	res := conflict.finding.result
+	res.CloneExtraData()
+	res.ExtraData[detectors.CandidateDetectorsKey] = strings.Join(slices.Compact(names), ",")
+
+	if e.verificationOverlapTracker != nil {
+		e.verificationOverlapTracker.Add(1)
 	}
-	return false
+	res.SetVerificationError(errOverlap)
+	detector := conflict.finding.detector.Detector
+	e.processResult(ctx, res, chunk.chunk, chunk.decoder, detector.Description(), detectors.GetFalsePositiveCheck(detector))
 }
 
 func (e *Engine) verificationOverlapWorker(ctx context.Context) {
@@ -993,6 +1337,10 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 	const avgSecretsPerDetector = 8
 	detectorKeysWithResults := make(map[ahocorasick.DetectorKey]*ahocorasick.DetectorMatch, avgSecretsPerDetector)
 	chunkSecrets := make(map[chunkSecretKey]struct{}, avgSecretsPerDetector)
+	findings := make(map[chunkSecretKey]secretFinding, avgSecretsPerDetector)
+	// conflicts are keyed by the first finding of the secret, which conflictRoots maps later findings to.
+	conflicts := make(map[chunkSecretKey]*secretConflict)
+	conflictRoots := make(map[chunkSecretKey]chunkSecretKey)
 
 	for chunk := range e.verificationOverlapChunksChan {
 		for _, detector := range chunk.detectors {
@@ -1017,6 +1365,9 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 				if _, ok := detectorKeysWithResults[detector.Key]; !ok {
 					detectorKeysWithResults[detector.Key] = detector
 				}
+				// Ignored candidates take no part in the overlap check. Their rule hits are recorded when the chunk is
+				// detected.
+				results = slices.DeleteFunc(results, func(res detectors.Result) bool { return e.candidateRules.Ignored(&res) })
 
 				// If results filtration eliminates a rotated secret, then that rotation will never be reported. This
 				// problem can theoretically occur for any scan, but we've only actually seen it in practice during
@@ -1044,7 +1395,27 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 						continue
 					}
 
-					if likelyDuplicate(ctx, key, chunkSecrets) {
+					prior, isDuplicate := duplicateOf(ctx, key, chunkSecrets)
+					if isDuplicate && (hasContextHints(detector.Detector) || hasContextHints(findings[prior].detector.Detector)) {
+						// The secret's format is shared by several detectors, which can be told apart by context.
+						root := prior
+						if r, ok := conflictRoots[prior]; ok {
+							root = r
+						}
+						conflict, ok := conflicts[root]
+						if !ok {
+							conflict = &secretConflict{finding: findings[root], candidates: []*ahocorasick.DetectorMatch{findings[root].detector}}
+							conflicts[root] = conflict
+						}
+						if !slices.Contains(conflict.candidates, detector) {
+							conflict.candidates = append(conflict.candidates, detector)
+						}
+						conflictRoots[key] = root
+						chunkSecrets[key] = struct{}{}
+						findings[key] = secretFinding{result: res, detector: detector}
+						continue
+					}
+					if isDuplicate {
 						// This indicates that the same secret was found by multiple detectors.
 						// We should NOT VERIFY this chunk's data.
 						if e.verificationOverlapTracker != nil {
@@ -1066,17 +1437,22 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 						delete(detectorKeysWithResults, detector.Key)
 					}
 					chunkSecrets[key] = struct{}{}
+					findings[key] = secretFinding{result: res, detector: detector}
 				}
 			}
 		}
 
+		for _, conflict := range conflicts {
+			e.resolveConflict(ctx, chunk, conflict, detectorKeysWithResults)
+		}
+
 		for _, detector := range detectorKeysWithResults {
 			wgDetect.Add(1)
 			e.detectableChunksChan <- detectableChunk{
 				chunk:    chunk.chunk,
 				detector: detector,
 				decoder:  chunk.decoder,
-				verify:   e.shouldVerifyChunk(chunk.chunk.SourceVerify, detector, e.detectorVerificationOverrides),
+				verify:   !chunk.chunk.Suppressed(detector.Type()) && e.shouldVerifyChunk(chunk.chunk.SourceVerify, detector, e.detectorVerificationOverrides),
 				wgDoneFn: wgDetect.Done,
 			}
 		}
@@ -1085,6 +1461,9 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 		for k := range chunkSecrets {
 			delete(chunkSecrets, k)
 		}
+		clear(findings)
+		clear(conflicts)
+		clear(conflictRoots)
 		for k := range detectorKeysWithResults {
 			delete(detectorKeysWithResults, k)
 		}
@@ -1132,22 +1511,27 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 		matchCount++
 		detectBytesPerMatch.Observe(float64(len(matchBytes)))
 
+		span, verify := matchBytes, data.verify
+		if verify && e.candidateRules.MayIgnore(matchBytes) {
+			if span, verify = e.withoutIgnoredCandidates(ctx, data.detector, matchBytes); span == nil {
+				continue
+			}
+		}
+
 		ctx, cancel := context.WithTimeout(ctx, detectionTimeout)
 		t := time.AfterFunc(detectionTimeout+1*time.Second, func() {
 			ctx.Logger().Error(nil, "a detector ignored the context timeout")
 		})
-		results, err := e.verificationCache.FromData(
-			ctx,
-			data.detector.Detector,
-			data.verify,
-			data.chunk.SecretID != 0,
-			matchBytes)
+		results, err := e.detectSpan(ctx, data.detector.Detector, verify, data.chunk.SecretID != 0, span)
 		t.Stop()
 		cancel()
 		if err != nil {
 			ctx.Logger().Error(err, "error finding results in chunk")
 			continue
 		}
+		if e.debugFindings {
+			e.attributeResults(data.detector, matchBytes, results)
+		}
 
 		detectorExecutionCount.WithLabelValues(
 			data.detector.Type().String(),
@@ -1173,11 +1557,17 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 			e.metrics.detectorAvgTime.Store(detectorName, avgTime)
 		}
 
+		if data.verify {
+			e.tryDecrypt(ctx, data.detector.Detector, &data.chunk, matchBytes, results)
+		}
+		results = e.reportSSHAccess(data.detector.Detector, &data.chunk, results)
+
 		// If results filtration eliminates a rotated secret, then that rotation will never be reported. This problem
 		// can theoretically occur for any scan, but we've only actually seen it in practice during targeted scans. (The
 		// reason for this discrepancy is unclear.) The simplest fix is therefore to disable filtration for targeted
 		// scans, but if you're here because this problem surfaced for a non-targeted scan then we'll have to solve it
 		// correctly.
+		results = e.candidateRules.Apply(results)
 		if data.chunk.SecretID == 0 {
 			results = e.filterResults(ctx, data.detector, results)
 		}
@@ -1194,11 +1584,150 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	data.wgDoneFn()
 }
 
+// attributeResults records on each result the keywords that dispatched matchBytes to the detector and, if the
+// detector names its patterns, the capture groups that matched the secret.
+func (e *Engine) attributeResults(detector *ahocorasick.DetectorMatch, matchBytes []byte, results []detectors.Result) {
+	keywords := e.AhoCorasickCore.MatchedKeywords(detector.Key, matchBytes)
+	var patterns map[string]detectors.Pattern
+	if provider, ok := detector.Detector.(detectors.PatternProvider); ok {
+		patterns = provider.Patterns()
+	}
+	for i := range results {
+		debug := &detectors.FindingDebug{Keywords: keywords}
+		if patterns != nil {
+			debug.Patterns = detectors.AttributePatterns(patterns, matchBytes, &results[i])
+			// Results found in the unescaped span are attributed within it.
+			if unescaped := detectors.UnescapeSpan(matchBytes); len(debug.Patterns) == 0 && unescaped != nil {
+				debug.Patterns = detectors.AttributePatterns(patterns, unescaped, &results[i])
+			}
+		}
+		results[i].Debug = debug
+	}
+}
+
+// detectSpan runs the detector on matchBytes through the verification cache. Secrets inside stringified JSON or pasted
+// with zero-width characters only match once the span is unescaped, so the unescaped span is searched too, without
+// verification. The original span is still scanned first so detectors that parse the raw format keep working. Only if
+// the unescaped span holds candidates the original one does not is it verified, and only those candidates are kept.
+func (e *Engine) detectSpan(
+	ctx context.Context,
+	detector detectors.Detector,
+	verify bool,
+	forceCacheUpdate bool,
+	matchBytes []byte,
+) ([]detectors.Result, error) {
+	results, err := e.verificationCache.FromData(ctx, detector, verify, forceCacheUpdate, matchBytes)
+	if err != nil {
+		return nil, err
+	}
+	unescaped := detectors.UnescapeSpan(matchBytes)
+	if unescaped == nil {
+		return results, nil
+	}
+
+	candidates, err := detector.FromData(ctx, false, unescaped)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeNormalizedResults(results, candidates)
+	if !verify || len(merged) == len(results) {
+		return merged, nil
+	}
+	verified, err := e.verificationCache.FromData(ctx, detector, true, forceCacheUpdate, unescaped)
+	if err != nil {
+		return nil, err
+	}
+	return mergeNormalizedResults(results, verified), nil
+}
+
+// mergeNormalizedResults appends the results found in an unescaped span to those found in the original span, skipping
+// any whose canonical form was already reported.
+func mergeNormalizedResults(results, unescaped []detectors.Result) []detectors.Result {
+	if len(unescaped) == 0 {
+		return results
+	}
+	seen := make(map[string]struct{}, len(results))
+	key := func(r detectors.Result) string {
+		return r.DetectorType.String() + "\x00" + detectors.NormalizeCandidate(string(r.Raw)) + "\x00" + detectors.NormalizeCandidate(string(r.RawV2))
+	}
+	for _, r := range results {
+		seen[key(r)] = struct{}{}
+	}
+	for _, r := range unescaped {
+		k := key(r)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		results = append(results, r)
+	}
+	return results
+}
+
+// withoutIgnoredCandidates runs the detector on matchBytes without verification and removes the candidates matching
+// an ignore rule from the span, so they are never sent to a verification endpoint. It returns the span to verify, or
+// nil if every candidate was ignored. If an ignored candidate cannot be located in the span, e.g. because it was only
+// found once the span was unescaped, the span is returned unchanged with verification disabled.
+func (e *Engine) withoutIgnoredCandidates(
+	ctx context.Context,
+	detector *ahocorasick.DetectorMatch,
+	matchBytes []byte,
+) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(ctx, detectionTimeout)
+	defer cancel()
+
+	candidates, err := e.detectSpan(ctx, detector.Detector, false, false, matchBytes)
+	if err != nil {
+		return matchBytes, false
+	}
+
+	var ignored []detectors.Result
+	span := matchBytes
+	for i := range candidates {
+		if !e.candidateRules.Ignored(&candidates[i]) {
+			continue
+		}
+		raw := candidates[i].Raw
+		if len(raw) == 0 {
+			raw = candidates[i].RawV2
+		}
+		if !bytes.Contains(matchBytes, raw) {
+			return matchBytes, false
+		}
+		span = bytes.ReplaceAll(span, raw, nil)
+		ignored = append(ignored, candidates[i])
+	}
+	if len(ignored) == 0 {
+		return matchBytes, true
+	}
+
+	// Record the hits for the dropped candidates.
+	e.candidateRules.Apply(ignored)
+	if len(ignored) == len(candidates) {
+		return nil, false
+	}
+	return span, true
+}
+
 func (e *Engine) filterResults(
 	ctx context.Context,
 	detector *ahocorasick.DetectorMatch,
 	results []detectors.Result,
 ) []detectors.Result {
+	// Results forced by a report rule bypass filtering.
+	var forced []detectors.Result
+	if e.candidateRules != nil {
+		kept := results[:0]
+		for _, res := range results {
+			if res.MatchedReportRule != "" {
+				forced = append(forced, res)
+				continue
+			}
+			kept = append(kept, res)
+		}
+		results = kept
+	}
+
 	clean := detectors.CleanResults
 	ignoreConfig := false
 	if cleaner, ok := detector.Detector.(detectors.CustomResultsCleaner); ok {
@@ -1213,7 +1742,7 @@ func (e *Engine) filterResults(
 		results = detectors.FilterResultsWithEntropy(ctx, results, e.filterEntropy, e.retainFalsePositives)
 	}
 
-	return results
+	return append(results, forced...)
 }
 
 // processResult generates a detectors.ResultWithMetadata from the provided chunk and result and puts it on the results
@@ -1226,6 +1755,14 @@ func (e *Engine) processResult(
 	detectorDescription string,
 	isFalsePositive func(detectors.Result) (bool, string),
 ) {
+	if decoderType == detectorspb.DecoderType_PLAIN {
+		if outsideReportLines(&chunk, &res) {
+			return
+		}
+	} else if e.decodedOutsideReportLines(&chunk, &res) {
+		return
+	}
+
 	ignoreLinePresent := false
 	if SupportsLineNumbers(chunk.SourceType) {
 		copyChunk := chunk
@@ -1244,24 +1781,43 @@ func (e *Engine) processResult(
 	if ignoreLinePresent {
 		return
 	}
+	if chunk.Suppressed(res.DetectorType) {
+		atomic.AddUint64(&e.metrics.SuppressedFindings, 1)
+		return
+	}
+
+	annotateResult(&res, chunk.Annotations)
+	applyResultPolicy(&res, e.detectorMetadata)
+	e.canaries.Tag(&res)
+	e.ownedWallets.Tag(&res)
+	e.expectedOwners.Tag(&res)
+	e.keyCorrelator.Tag(&res)
 
 	secret := detectors.CopyMetadata(&chunk, res)
 	secret.DecoderType = decoderType
 	secret.DetectorDescription = detectorDescription
+	if e.zeroizeSecrets {
+		// Detectors may return slices of the chunk, which other detectors are
+		// still scanning. Copy the secrets so that wiping them touches nothing
+		// else.
+		secret.Raw, secret.RawV2 = bytes.Clone(secret.Raw), bytes.Clone(secret.RawV2)
+	}
 
-	if !res.Verified && res.Raw != nil {
+	if !res.Verified && res.Raw != nil && res.MatchedReportRule == "" {
 		isFp, _ := isFalsePositive(res)
 		secret.IsWordlistFalsePositive = isFp
 	}
 
+	// Seed phrases are redacted and secrets hashed when the result is emitted.
 	e.results <- secret
 }
 
 func (e *Engine) notifierWorker(ctx context.Context) {
 	for result := range e.ResultsChan() {
 		startTime := time.Now()
-		// Filter unwanted results, based on `--results`.
-		if !result.Verified {
+		// Filter unwanted results, based on `--results`. Results matching a
+		// report rule bypass this filter, as they are always notified.
+		if result.MatchedReportRule == "" && !result.Verified {
 			if result.IsWordlistFalsePositive && !e.retainFalsePositives {
 				// Skip false positives
 				continue
@@ -1274,11 +1830,15 @@ func (e *Engine) notifierWorker(ctx context.Context) {
 				// Skip unverified results.
 				continue
 			}
-		} else if !e.notifyVerifiedResults {
+		} else if result.MatchedReportRule == "" && !e.notifyVerifiedResults {
 			// Skip verified results.
 			// TODO: Is this a legitimate use case?
 			continue
 		}
+		// Filter results based on `--only-verified` and `--min-confidence`.
+		if result.MatchedReportRule == "" && !e.outputFilter.allows(&result.Result) {
+			continue
+		}
 		atomic.AddUint32(&e.numFoundResults, 1)
 
 		// Dedupe results by comparing the detector type, raw result, and source metadata.
@@ -1287,20 +1847,24 @@ func (e *Engine) notifierWorker(ctx context.Context) {
 		// Duplicate results with the same decoder type SHOULD have their own entry in the
 		// results list, this would happen if the same secret is found multiple times.
 		// Note: If the source type is postman, we dedupe the results regardless of decoder type.
-		key := fmt.Sprintf("%s%s%s%+v", result.DetectorType.String(), result.Raw, result.RawV2, result.SourceMetadata)
+		key := e.dedupeKey(&result)
 		if val, ok := e.dedupeCache.Get(key); ok && (val != result.DecoderType ||
 			result.SourceType == sourcespb.SourceType_SOURCE_TYPE_POSTMAN) {
 			continue
 		}
 		e.dedupeCache.Add(key, result.DecoderType)
 
+		if !e.limiter.allowFinding() {
+			continue
+		}
+
 		if result.Verified {
 			atomic.AddUint64(&e.metrics.VerifiedSecretsFound, 1)
 		} else {
 			atomic.AddUint64(&e.metrics.UnverifiedSecretsFound, 1)
 		}
 
-		if err := e.dispatcher.Dispatch(ctx, result); err != nil {
+		if err := e.dispatchResult(ctx, result); err != nil {
 			ctx.Logger().Error(err, "error notifying result")
 		}
 
@@ -1308,6 +1872,18 @@ func (e *Engine) notifierWorker(ctx context.Context) {
 	}
 }
 
+// dedupeKey returns the key results are deduplicated by. When secrets are
+// zeroized, the cache only holds a hash of the key, so that it does not retain
+// plaintext secrets for the rest of the scan.
+func (e *Engine) dedupeKey(result *detectors.ResultWithMetadata) string {
+	if !e.zeroizeSecrets {
+		return fmt.Sprintf("%s%s%s%+v", result.DetectorType.String(), result.Raw, result.RawV2, result.SourceMetadata)
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s%s%s%+v", result.DetectorType.String(), result.Raw, result.RawV2, result.SourceMetadata)
+	return string(h.Sum(nil))
+}
+
 // SupportsLineNumbers determines if a line number can be found for a source type.
 func SupportsLineNumbers(sourceType sourcespb.SourceType) bool {
 	switch sourceType {
@@ -1352,6 +1928,76 @@ func FragmentLineOffset(chunk *sources.Chunk, result *detectors.Result) (int64,
 	return lineNumber, false
 }
 
+// outsideReportLines reports whether a chunk restricts results to some of its lines, and the secret of the result
+// only appears on the others, which just give context to detectors.
+func outsideReportLines(chunk *sources.Chunk, result *detectors.Result) bool {
+	if len(chunk.ReportLines) == 0 {
+		return false
+	}
+	secret := reportedSecret(result)
+	if secret == "" {
+		return false
+	}
+
+	// Secrets spanning several lines, like PEM keys, are reported if any of their lines is.
+	span := strings.Count(secret, "\n")
+	found := false
+	data, line := chunk.Data, 0
+	for {
+		before, after, ok := bytes.Cut(data, []byte(secret))
+		if !ok {
+			break
+		}
+		found = true
+		line += bytes.Count(before, []byte("\n"))
+		for _, reported := range chunk.ReportLines {
+			if reported >= line && reported <= line+span {
+				return false
+			}
+		}
+		line += span
+		data = after
+	}
+	// Secrets that were decoded or normalized by the detector cannot be located, so they are kept.
+	return found
+}
+
+// decodedOutsideReportLines is outsideReportLines for results found in decoded data. The lines of decoded data don't
+// match the lines of the source, so the reported lines of the source are decoded on their own instead, and the result
+// is kept if its secret is found in them, i.e. if its encoded form lies on a reported line.
+func (e *Engine) decodedOutsideReportLines(chunk *sources.Chunk, result *detectors.Result) bool {
+	if len(chunk.ReportLines) == 0 || chunk.OriginalData == nil {
+		return false
+	}
+	secret := reportedSecret(result)
+	if secret == "" || !bytes.Contains(chunk.Data, []byte(secret)) {
+		return false
+	}
+
+	lines := bytes.Split(chunk.OriginalData, []byte("\n"))
+	var reported [][]byte
+	for _, i := range chunk.ReportLines {
+		if i >= 0 && i < len(lines) {
+			reported = append(reported, lines[i])
+		}
+	}
+	src := &sources.Chunk{Data: bytes.Join(reported, []byte("\n"))}
+	for _, decoded := range iterativeDecode(src, e.decoders, e.maxDecodeDepth) {
+		if bytes.Contains(decoded.Chunk.Data, []byte(secret)) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportedSecret returns the secret of a result as it appears in the scanned data.
+func reportedSecret(result *detectors.Result) string {
+	if secret := result.GetPrimarySecretValue(); secret != "" {
+		return secret
+	}
+	return string(result.Raw)
+}
+
 // FragmentFirstLineAndLink extracts the first line number and the link from the chunk metadata.
 // It returns:
 //   - The first line number of the fragment.
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gitlab/v2"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/custom_detectorspb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -216,6 +218,35 @@ func TestFragmentLineOffsetWithPrimarySecret(t *testing.T) {
 	}
 }
 
+func TestOutsideReportLines(t *testing.T) {
+	data := []byte("# wallet\nOLD_KEY=deadbeef\nNEW_KEY=cafebabe\n-----BEGIN KEY-----\nabc\n-----END KEY-----\n")
+
+	tests := []struct {
+		name        string
+		reportLines []int
+		raw         string
+		want        bool
+	}{
+		{name: "no restriction", raw: "deadbeef", want: false},
+		{name: "added line", reportLines: []int{2}, raw: "cafebabe", want: false},
+		{name: "context line", reportLines: []int{2}, raw: "deadbeef", want: true},
+		{name: "multiline secret with an added line", reportLines: []int{4}, raw: "-----BEGIN KEY-----\nabc\n-----END KEY-----", want: false},
+		{name: "multiline secret in context", reportLines: []int{2}, raw: "-----BEGIN KEY-----\nabc\n-----END KEY-----", want: true},
+		{name: "secret not in the chunk", reportLines: []int{2}, raw: "decoded", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := &sources.Chunk{Data: data, ReportLines: tt.reportLines}
+			assert.Equal(t, tt.want, outsideReportLines(chunk, &detectors.Result{Raw: []byte(tt.raw)}))
+		})
+	}
+
+	// A secret repeated on a context line and an added line is reported.
+	chunk := &sources.Chunk{Data: []byte("KEY=cafebabe\nKEY=cafebabe\n"), ReportLines: []int{1}}
+	assert.False(t, outsideReportLines(chunk, &detectors.Result{Raw: []byte("cafebabe")}))
+}
+
 func TestFragmentLineOffsetWithPrimarySecretMultiline(t *testing.T) {
 	result := &detectors.Result{
 		Raw: []byte("secret here"),
@@ -1562,6 +1593,41 @@ func TestEngine_DetectChunk_UsesVerifyFlag(t *testing.T) {
 	}
 }
 
+func TestEngine_DetectChunk_DebugFindings(t *testing.T) {
+	ctx := context.Background()
+
+	detector, err := custom_detectors.NewWebhookCustomRegex(&custom_detectorspb.CustomRegex{
+		Name:     "debug",
+		Keywords: []string{"token", "auth"},
+		Regex:    map[string]string{"token": `token=([a-z0-9]{8})`},
+	})
+	require.NoError(t, err)
+
+	e := &Engine{
+		results:           make(chan detectors.ResultWithMetadata, 1),
+		verificationCache: verificationcache.New(nil, &verificationcache.InMemoryMetrics{}),
+		debugFindings:     true,
+	}
+	e.AhoCorasickCore = ahocorasick.NewAhoCorasickCore([]detectors.Detector{detector})
+	data := []byte("auth token=abcd1234")
+	detectorMatches := e.AhoCorasickCore.FindDetectorMatches(data)
+	require.Len(t, detectorMatches, 1)
+
+	e.detectChunk(ctx, detectableChunk{
+		chunk:    sources.Chunk{Data: data},
+		detector: detectorMatches[0],
+		wgDoneFn: func() {},
+	})
+	close(e.results)
+
+	result, ok := <-e.results
+	require.True(t, ok)
+	assert.Equal(t, &detectors.FindingDebug{
+		Keywords: []string{"auth", "token"},
+		Patterns: []detectors.PatternCapture{{Pattern: "token", Group: 1, Offset: 11}},
+	}, result.Result.Debug)
+}
+
 // TestEngine_ScannerWorker_DetectableChunkHasCorrectVerifyFlag validates that scannerWorker generates detectableChunk
 // structs that have the correct verify flag set. It also validates that the original chunks' SourceVerify flags are
 // unchanged.
@@ -1742,6 +1808,100 @@ func TestEngine_VerificationOverlapWorker_DetectableChunkHasCorrectVerifyFlag(t
 	})
 }
 
+// hintedDetector is a passthroughDetector that declares context hints.
+type hintedDetector struct {
+	passthroughDetector
+	hints []string
+}
+
+func (h hintedDetector) ContextHints() []string { return h.hints }
+
+func TestEngine_VerificationOverlapWorker_ContextHints(t *testing.T) {
+	ctx := context.Background()
+
+	deepseek := hintedDetector{
+		passthroughDetector: passthroughDetector{detectorType: detector_typepb.DetectorType_DeepSeek, keywords: []string{"sk-"}, secret: "sk-0123456789abcdef0123456789abcdef"},
+		hints:               []string{"deepseek"},
+	}
+	bailian := hintedDetector{
+		passthroughDetector: passthroughDetector{detectorType: detector_typepb.DetectorType_BaiLian, keywords: []string{"sk-0"}, secret: "sk-0123456789abcdef0123456789abcdef"},
+		hints:               []string{"dashscope"},
+	}
+
+	tests := []struct {
+		name           string
+		data           string
+		wantDetector   detector_typepb.DetectorType
+		wantCandidates string
+	}{
+		{
+			name:         "attributed by context",
+			data:         "DEEPSEEK_API_KEY=sk-0123456789abcdef0123456789abcdef",
+			wantDetector: detector_typepb.DetectorType_DeepSeek,
+		},
+		{
+			name:           "ambiguous context",
+			data:           "API_KEY=sk-0123456789abcdef0123456789abcdef",
+			wantCandidates: "BaiLian,DeepSeek",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{
+				detectableChunksChan:          make(chan detectableChunk, 2),
+				results:                       make(chan detectors.ResultWithMetadata, 2),
+				retainFalsePositives:          true,
+				verificationOverlapChunksChan: make(chan verificationOverlapChunk, 1),
+				verify:                        true,
+			}
+			processedDetectableChunks := make(chan detectableChunk, 2)
+			go func() {
+				for chunk := range e.detectableChunksChan {
+					chunk.wgDoneFn()
+					processedDetectableChunks <- chunk
+				}
+				close(processedDetectableChunks)
+			}()
+
+			chunk := sources.Chunk{Data: []byte(tt.data), SourceVerify: true}
+			ahcore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{deepseek, bailian})
+			detectorMatches := ahcore.FindDetectorMatches(chunk.Data)
+			require.Len(t, detectorMatches, 2)
+
+			e.verificationOverlapChunksChan <- verificationOverlapChunk{
+				chunk:                       chunk,
+				detectors:                   detectorMatches,
+				verificationOverlapWgDoneFn: func() { close(e.verificationOverlapChunksChan) },
+			}
+			e.verificationOverlapWorker(ctx)
+			close(e.results)
+			close(e.detectableChunksChan)
+
+			var reprocessed []detector_typepb.DetectorType
+			for detectableChunk := range processedDetectableChunks {
+				reprocessed = append(reprocessed, detectableChunk.detector.Key.Type())
+			}
+			var results []detectors.ResultWithMetadata
+			for result := range e.results {
+				results = append(results, result)
+			}
+
+			if tt.wantCandidates == "" {
+				// Only the attributed detector verifies the secret, and nothing is reported as a duplicate.
+				assert.Equal(t, []detector_typepb.DetectorType{tt.wantDetector}, reprocessed)
+				assert.Empty(t, results)
+				return
+			}
+			// The secret is reported once, unverified, with all candidates.
+			assert.Empty(t, reprocessed)
+			require.Len(t, results, 1)
+			assert.False(t, results[0].Result.Verified)
+			assert.Equal(t, tt.wantCandidates, results[0].Result.ExtraData[detectors.CandidateDetectorsKey])
+		})
+	}
+}
+
 func TestEngine_IterativeDecoding(t *testing.T) {
 	t.Parallel()
 
@@ -1896,3 +2056,207 @@ func TestEngine_IterativeDecoding(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeNormalizedResults(t *testing.T) {
+	results := []detectors.Result{
+		{DetectorType: detector_typepb.DetectorType_Github, Raw: []byte(`\"0xABCDEF0123456789\"`)},
+	}
+	unescaped := []detectors.Result{
+		{DetectorType: detector_typepb.DetectorType_Github, Raw: []byte("0xabcdef0123456789")},
+		{DetectorType: detector_typepb.DetectorType_Github, Raw: []byte("ghp_other")},
+		{DetectorType: detector_typepb.DetectorType_AWS, Raw: []byte("0xabcdef0123456789")},
+	}
+
+	merged := mergeNormalizedResults(results, unescaped)
+	var raws []string
+	for _, r := range merged {
+		raws = append(raws, r.DetectorType.String()+":"+string(r.Raw))
+	}
+	assert.Equal(t, []string{`Github:\"0xABCDEF0123456789\"`, "Github:ghp_other", "AWS:0xabcdef0123456789"}, raws)
+}
+
+// tokenDetector finds tok_ tokens and records the spans it was asked to verify.
+type tokenDetector struct {
+	passthroughDetector
+	verified []string
+}
+
+var tokenPat = regexp.MustCompile(`tok_[a-z]+`)
+
+func (d *tokenDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	if verify {
+		d.verified = append(d.verified, string(data))
+	}
+	var results []detectors.Result
+	for _, match := range tokenPat.FindAll(data, -1) {
+		results = append(results, detectors.Result{DetectorType: d.Type(), Raw: match, Verified: verify})
+	}
+	return results, nil
+}
+
+func TestEngine_DetectSpan(t *testing.T) {
+	ctx := context.Background()
+	e := &Engine{verificationCache: verificationcache.New(nil, &verificationcache.InMemoryMetrics{})}
+
+	t.Run("unescaped span without new candidates is not verified", func(t *testing.T) {
+		detector := &tokenDetector{passthroughDetector: passthroughDetector{detectorType: detector_typepb.DetectorType_Github}}
+		results, err := e.detectSpan(ctx, detector, true, false, []byte(`tok_one\n`))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Verified)
+		assert.Equal(t, []string{`tok_one\n`}, detector.verified)
+	})
+
+	t.Run("unescaped span with new candidates is verified", func(t *testing.T) {
+		detector := &tokenDetector{passthroughDetector: passthroughDetector{detectorType: detector_typepb.DetectorType_Github}}
+		results, err := e.detectSpan(ctx, detector, true, false, []byte(`tok_one tok_\u0074wo`))
+		require.NoError(t, err)
+		var raws []string
+		for _, r := range results {
+			assert.True(t, r.Verified)
+			raws = append(raws, string(r.Raw))
+		}
+		assert.Equal(t, []string{"tok_one", "tok_two"}, raws)
+		assert.Equal(t, []string{`tok_one tok_\u0074wo`, "tok_one tok_two"}, detector.verified)
+	})
+
+	t.Run("without verification", func(t *testing.T) {
+		detector := &tokenDetector{passthroughDetector: passthroughDetector{detectorType: detector_typepb.DetectorType_Github}}
+		results, err := e.detectSpan(ctx, detector, false, false, []byte(`tok_one tok_\u0074wo`))
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Empty(t, detector.verified)
+	})
+}
+
+func TestEngine_DetectChunk_CandidateRules(t *testing.T) {
+	ctx := context.Background()
+
+	newEngine := func(t *testing.T, detector detectors.Detector) *Engine {
+		t.Helper()
+		rules, err := detectors.NewCandidateRules(&configpb.CandidateRules{
+			Ignore: []*configpb.CandidateRule{{Name: "examples", Regex: []string{`tok_(example|other)`}}},
+			Report: []*configpb.CandidateRule{{Name: "keep-other", Literal: []string{"other"}}},
+		})
+		require.NoError(t, err)
+		e := &Engine{
+			results:           make(chan detectors.ResultWithMetadata, 8),
+			verificationCache: verificationcache.New(nil, &verificationcache.InMemoryMetrics{}),
+			candidateRules:    rules,
+		}
+		e.AhoCorasickCore = ahocorasick.NewAhoCorasickCore([]detectors.Detector{detector})
+		return e
+	}
+	detect := func(t *testing.T, e *Engine, data string) []detectors.Result {
+		t.Helper()
+		detectorMatches := e.AhoCorasickCore.FindDetectorMatches([]byte(data))
+		require.Len(t, detectorMatches, 1)
+		e.detectChunk(ctx, detectableChunk{
+			chunk:    sources.Chunk{Data: []byte(data)},
+			detector: detectorMatches[0],
+			verify:   true,
+			wgDoneFn: func() {},
+		})
+		close(e.results)
+		var results []detectors.Result
+		for res := range e.results {
+			results = append(results, res.Result)
+		}
+		return results
+	}
+
+	t.Run("ignored candidates are not verified", func(t *testing.T) {
+		detector := &tokenDetector{passthroughDetector: passthroughDetector{
+			detectorType: detector_typepb.DetectorType_Github,
+			keywords:     []string{"tok_"},
+		}}
+		e := newEngine(t, detector)
+
+		results := detect(t, e, "tok_example tok_live tok_other")
+		assert.Equal(t, []string{" tok_live tok_other"}, detector.verified)
+		require.Len(t, results, 2)
+		assert.Equal(t, "tok_live", string(results[0].Raw))
+		assert.True(t, results[0].Verified)
+		assert.Empty(t, results[0].MatchedReportRule)
+		assert.Equal(t, "tok_other", string(results[1].Raw))
+		assert.True(t, results[1].Verified)
+		assert.Equal(t, "keep-other", results[1].MatchedReportRule)
+		assert.Equal(t, map[string]uint64{"examples": 1, "keep-other": 1}, e.GetMetrics().CandidateRuleHits)
+	})
+
+	t.Run("span with only ignored candidates is skipped", func(t *testing.T) {
+		detector := &tokenDetector{passthroughDetector: passthroughDetector{
+			detectorType: detector_typepb.DetectorType_Github,
+			keywords:     []string{"tok_"},
+		}}
+		e := newEngine(t, detector)
+
+		results := detect(t, e, "tok_example")
+		assert.Empty(t, detector.verified)
+		assert.Empty(t, results)
+		assert.Equal(t, map[string]uint64{"examples": 1, "keep-other": 0}, e.GetMetrics().CandidateRuleHits)
+	})
+}
+
+func TestEngine_ZeroizeSecrets(t *testing.T) {
+	ctx := context.Background()
+	hasher := detectors.NewSecretHasher([]byte("key"))
+	dispatcher := new(collectDispatcher)
+	e := &Engine{dispatcher: dispatcher, secretHasher: hasher, zeroizeSecrets: true}
+
+	result := fileResult("a.env", 1, detector_typepb.DetectorType_AWS, "AKIA1")
+	raw := result.Raw
+	assert.NotContains(t, e.dedupeKey(&result), "AKIA1")
+	require.NoError(t, e.emit(ctx, result))
+	// The plaintext secret and the hash the dispatcher consumed are wiped.
+	assert.Equal(t, make([]byte, len(raw)), raw)
+	require.Len(t, dispatcher.results, 1)
+	assert.Equal(t, make([]byte, len(hasher.Hash(raw))), dispatcher.results[0].Raw)
+
+	// Sorted results are wiped once they are flushed.
+	e.sorter = newResultSorter(true)
+	result = fileResult("a.env", 2, detector_typepb.DetectorType_AWS, "AKIA2")
+	raw = result.Raw
+	require.NoError(t, e.emit(ctx, result))
+	assert.Equal(t, "AKIA2", string(raw))
+	e.sorter.flush(ctx, dispatcher)
+	assert.Equal(t, make([]byte, len(raw)), raw)
+}
+
+func TestEngine_MinKeywordLength(t *testing.T) {
+	ctx := context.Background()
+	short := passthroughDetector{keywords: []string{"ab"}, detectorType: detector_typepb.DetectorType_Github}
+	custom, err := custom_detectors.NewWebhookCustomRegex(&custom_detectorspb.CustomRegex{
+		Name:     "custom detector",
+		Keywords: []string{"cd"},
+		Regex:    map[string]string{"regex": ".*"},
+	})
+	require.NoError(t, err)
+
+	newEngine := func(t *testing.T, minKeywordLength int) *Engine {
+		t.Helper()
+		e, err := NewEngine(ctx, &Config{
+			Concurrency:      1,
+			Detectors:        []detectors.Detector{short, custom},
+			MinKeywordLength: minKeywordLength,
+			SourceManager:    sources.NewManager(),
+			Dispatcher:       NewPrinterDispatcher(new(discardPrinter)),
+		})
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("default minimum", func(t *testing.T) {
+		e := newEngine(t, 0)
+		assert.Contains(t, e.AhoCorasickCore.RejectedKeywords(), "ab")
+		assert.Empty(t, e.AhoCorasickCore.FindDetectorMatches([]byte("ab")))
+		// Custom regex detectors are configured on purpose, so their keywords are kept.
+		assert.Len(t, e.AhoCorasickCore.FindDetectorMatches([]byte("cd")), 1)
+	})
+
+	t.Run("minimum disabled", func(t *testing.T) {
+		e := newEngine(t, -1)
+		assert.Empty(t, e.AhoCorasickCore.RejectedKeywords())
+		assert.Len(t, e.AhoCorasickCore.FindDetectorMatches([]byte("ab")), 1)
+	})
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"runtime"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -36,3 +37,29 @@ func (e *Engine) ScanFileSystem(ctx context.Context, c sources.FilesystemConfig)
 	}
 	return e.sourceManager.EnumerateAndScan(ctx, sourceName, fileSystemSource)
 }
+
+// WatchFileSystem watches the files and directories of the config and scans files as they are created or modified,
+// until ctx is canceled. Files are scanned once they have not changed for debounce.
+func (e *Engine) WatchFileSystem(ctx context.Context, c sources.FilesystemConfig, debounce time.Duration) (sources.JobProgressRef, error) {
+	connection := &sourcespb.Filesystem{
+		Paths:            c.Paths,
+		IncludePathsFile: c.IncludePathsFile,
+		ExcludePathsFile: c.ExcludePathsFile,
+		MaxSymlinkDepth:  c.MaxSymlinkDepth,
+	}
+	var conn anypb.Any
+	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
+	if err != nil {
+		ctx.Logger().Error(err, "failed to marshal filesystem connection")
+		return sources.JobProgressRef{}, err
+	}
+
+	sourceName := "trufflehog - filesystem watch"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, filesystem.SourceType)
+
+	watchSource := &filesystem.WatchSource{Debounce: debounce}
+	if err := watchSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, runtime.NumCPU()); err != nil {
+		return sources.JobProgressRef{}, err
+	}
+	return e.sourceManager.EnumerateAndScan(ctx, sourceName, watchSource)
+}
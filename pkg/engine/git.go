@@ -1,14 +1,18 @@
 package engine
 
 import (
+	"fmt"
+	"os"
 	"runtime"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cleantemp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/filesystem"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
 )
 
@@ -46,3 +50,78 @@ func (e *Engine) ScanGit(ctx context.Context, c sources.GitConfig) (sources.JobP
 
 	return e.sourceManager.EnumerateAndScan(ctx, sourceName, gitSource)
 }
+
+// ScanStaged scans the staged changes of a local git repository, reporting only secrets on added lines.
+func (e *Engine) ScanStaged(ctx context.Context, c sources.StagedConfig) (sources.JobProgressRef, error) {
+	connection := &sourcespb.Git{Directories: []string{c.Path}}
+
+	var conn anypb.Any
+	if err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{}); err != nil {
+		ctx.Logger().Error(err, "failed to marshal git connection")
+		return sources.JobProgressRef{}, err
+	}
+
+	sourceName := "trufflehog - staged"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, git.SourceType)
+
+	gitSource := &git.Source{}
+	gitSource.WithStagedOnly(c.ContextLines)
+	if err := gitSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, runtime.NumCPU()); err != nil {
+		return sources.JobProgressRef{}, err
+	}
+
+	return e.sourceManager.EnumerateAndScan(ctx, sourceName, gitSource)
+}
+
+// ScanGitTree scans the files of the tree at c.HeadRef of the repository at c.URI, rather than the history reachable
+// from it. The tree is extracted to a temporary directory, scanned with the filesystem source and removed once the
+// job is done. Files are reported with their paths in the tree. The other fields of c, except ClonePath and TrustLocalGitConfig, are ignored.
+func (e *Engine) ScanGitTree(ctx context.Context, c sources.GitConfig) (sources.JobProgressRef, error) {
+	repoPath, _, err := git.PrepareRepo(ctx, c.URI, c.ClonePath, c.TrustLocalGitConfig, false)
+	if err != nil {
+		return sources.JobProgressRef{}, err
+	}
+	if !c.TrustLocalGitConfig {
+		// The clone is only needed to extract the tree.
+		defer os.RemoveAll(repoPath)
+	}
+
+	dir, err := cleantemp.MkdirTemp()
+	if err != nil {
+		return sources.JobProgressRef{}, fmt.Errorf("failed to create temporary tree path: %w", err)
+	}
+	if err := git.ExtractTree(ctx, repoPath, c.HeadRef, dir); err != nil {
+		os.RemoveAll(dir)
+		return sources.JobProgressRef{}, err
+	}
+
+	ref, err := e.scanTree(ctx, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return ref, err
+	}
+	go func() {
+		<-ref.Done()
+		os.RemoveAll(dir)
+	}()
+	return ref, nil
+}
+
+// scanTree scans the files under dir with the filesystem source, reporting their paths relative to dir.
+func (e *Engine) scanTree(ctx context.Context, dir string) (sources.JobProgressRef, error) {
+	var conn anypb.Any
+	if err := anypb.MarshalFrom(&conn, &sourcespb.Filesystem{Paths: []string{dir}}, proto.MarshalOptions{}); err != nil {
+		ctx.Logger().Error(err, "failed to marshal filesystem connection")
+		return sources.JobProgressRef{}, err
+	}
+
+	sourceName := "trufflehog - git tree"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, filesystem.SourceType)
+
+	fileSystemSource := &filesystem.Source{}
+	fileSystemSource.WithRelativePaths(dir)
+	if err := fileSystemSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, runtime.NumCPU()); err != nil {
+		return sources.JobProgressRef{}, err
+	}
+	return e.sourceManager.EnumerateAndScan(ctx, sourceName, fileSystemSource)
+}
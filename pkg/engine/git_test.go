@@ -1,22 +1,27 @@
 package engine
 
 import (
+	"encoding/base64"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scandiff"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
 )
@@ -34,6 +39,134 @@ func (p *discardPrinter) Print(context.Context, *detectors.ResultWithMetadata) e
 	return nil
 }
 
+// collectPrinter collects the raw secrets of the results it is given.
+type collectPrinter struct {
+	mu   sync.Mutex
+	raws []string
+}
+
+func (p *collectPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.raws = append(p.raws, string(r.Raw))
+	return nil
+}
+
+func TestStagedEngine(t *testing.T) {
+	ctx := context.Background()
+
+	repoPath := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "test")
+	file := filepath.Join(repoPath, "config.env")
+	// The encoded note decodes to several lines, so the lines of the decoded file don't match the staged diff.
+	committed := "plain: tok_qzvkjwprx\nencoded: " + encode("token=tok_xjqvzkwmb") + "\n" +
+		"note: " + encode("first line\nsecond line\nthird line") + "\n"
+	require.NoError(t, os.WriteFile(file, []byte(committed), 0o644))
+	runGit("add", "config.env")
+	runGit("commit", "-q", "-m", "initial")
+
+	added := "added: " + encode("token=tok_wkzqjxvhn") + "\n"
+	require.NoError(t, os.WriteFile(file, []byte(committed+added), 0o644))
+	runGit("add", "config.env")
+
+	printer := new(collectPrinter)
+	conf := Config{
+		Concurrency: 1,
+		Decoders:    decoders.DefaultDecoders(),
+		Detectors: []detectors.Detector{&tokenDetector{passthroughDetector: passthroughDetector{
+			keywords:     []string{"tok_"},
+			detectorType: detector_typepb.DetectorType_Github,
+		}}},
+		Verify:        false,
+		Results:       map[string]struct{}{"unverified": {}},
+		SourceManager: sources.NewManager(sources.WithSourceUnits(), sources.WithBufferedOutput(64)),
+		Dispatcher:    NewPrinterDispatcher(printer),
+	}
+	e, err := NewEngine(ctx, &conf)
+	require.NoError(t, err)
+
+	e.Start(ctx)
+	_, err = e.ScanStaged(ctx, sources.StagedConfig{Path: repoPath, ContextLines: 3})
+	require.NoError(t, err)
+	require.NoError(t, e.Finish(ctx))
+
+	// The secrets on the unchanged lines only give context, whether they are encoded or not.
+	assert.Equal(t, []string{"tok_wkzqjxvhn"}, printer.raws)
+}
+
+func TestScanGitTree(t *testing.T) {
+	ctx := context.Background()
+
+	repoPath := t.TempDir()
+	runGit := func(args ...string) {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(tag, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, "config.env"), []byte(content), 0o644))
+		runGit("add", "config.env")
+		runGit("commit", "-q", "-m", tag)
+		runGit("tag", tag)
+	}
+
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "test")
+	commit("v1", "kept: tok_qzvkjwprx\ndeleted: tok_xjqvzkwmb\n")
+	commit("v2", "kept: tok_qzvkjwprx\nadded: tok_wkzqjxvhn\n")
+
+	scanTree := func(ref string) *scandiff.Set {
+		t.Helper()
+		set := scandiff.NewSet()
+		conf := Config{
+			Concurrency: 1,
+			Decoders:    decoders.DefaultDecoders(),
+			Detectors: []detectors.Detector{&tokenDetector{passthroughDetector: passthroughDetector{
+				keywords:     []string{"tok_"},
+				detectorType: detector_typepb.DetectorType_Github,
+			}}},
+			Verify:        false,
+			Results:       map[string]struct{}{"unverified": {}},
+			SourceManager: sources.NewManager(sources.WithSourceUnits(), sources.WithBufferedOutput(64)),
+			Dispatcher:    set,
+		}
+		e, err := NewEngine(ctx, &conf)
+		require.NoError(t, err)
+
+		e.Start(ctx)
+		_, err = e.ScanGitTree(ctx, sources.GitConfig{URI: "file://" + filepath.ToSlash(repoPath), HeadRef: ref})
+		require.NoError(t, err)
+		require.NoError(t, e.Finish(ctx))
+		return set
+	}
+
+	// v1 is in the history of v2, but the secret deleted in v2 is not in its tree.
+	d := scandiff.Compare(scanTree("v1"), scanTree("v2"))
+	locations := func(findings []scandiff.Finding) []string {
+		var locs []string
+		for _, f := range findings {
+			locs = append(locs, f.Locations...)
+		}
+		return locs
+	}
+	assert.Equal(t, []string{"config.env:2"}, locations(d.Removed))
+	assert.Equal(t, []string{"config.env:2"}, locations(d.Added))
+	assert.Equal(t, []string{"config.env:1"}, locations(d.Persisting))
+	assert.NotEqual(t, d.Removed[0].ID, d.Added[0].ID)
+}
+
 func TestGitEngine(t *testing.T) {
 	ctx := context.Background()
 	repoUrl := "https://github.com/dustin-decker/secretsandstuff.git"
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	aCtx "context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// errScanInterrupted is the cause with which sources are cancelled when the
+// scan is interrupted.
+var errScanInterrupted = errors.New("scan interrupted")
+
+// interruption records that a scan was interrupted.
+type interruption struct {
+	interrupted atomic.Bool
+	once        sync.Once
+	reason      string
+}
+
+// Interrupt stops the scan early, e.g. on SIGINT. Unlike a scan limit, which
+// discards the chunks still in flight, an interruption only cancels the
+// running sources: the chunks they already produced are scanned, and their
+// results, as well as results held back for deep verification or sorting,
+// are dispatched before Finish returns. The resume information of the sources
+// therefore never points past data that was not scanned. The scan is reported
+// as truncated. Later calls have no effect.
+func (e *Engine) Interrupt(ctx context.Context, reason string) {
+	e.interruption.once.Do(func() {
+		e.interruption.reason = reason
+		e.interruption.interrupted.Store(true)
+		ctx.Logger().Info("scan interrupted, finishing with partial results", "reason", reason)
+		e.sourceManager.CancelAll(errScanInterrupted)
+	})
+}
+
+// Interrupted reports whether the scan was interrupted.
+func (e *Engine) Interrupted() bool {
+	return e.interruption.interrupted.Load()
+}
+
+// StoppedEarly reports whether err is the result of the engine cancelling the
+// running sources, because a scan limit was exceeded or the scan was
+// interrupted.
+func (e *Engine) StoppedEarly(err error) bool {
+	if e.StoppedByLimit(err) {
+		return true
+	}
+	if err == nil || !e.Interrupted() {
+		return false
+	}
+	return errors.Is(err, errScanInterrupted) || errors.Is(err, aCtx.Canceled)
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	aCtx "context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ScanLimits bound the resources a single scan may use. When a limit is
+// exceeded, the running sources are cancelled, the remaining chunks are
+// discarded, and the scan finishes with partial results. Zero values mean no
+// limit.
+type ScanLimits struct {
+	// MaxBytes is the number of chunk bytes after which the scan stops.
+	MaxBytes uint64
+	// MaxFindings is the number of results the scan may report. The scan
+	// stops once a result beyond MaxFindings is found, which is not
+	// dispatched.
+	MaxFindings uint64
+	// MaxDuration is the wall-clock budget of the scan.
+	MaxDuration time.Duration
+}
+
+// errScanLimitExceeded is the cause with which sources are cancelled when a
+// scan limit is exceeded.
+var errScanLimitExceeded = errors.New("scan limit exceeded")
+
+// scanLimiter tracks a scan against its ScanLimits. A nil *scanLimiter never
+// trips.
+type scanLimiter struct {
+	limits   ScanLimits
+	findings atomic.Uint64
+	tripped  atomic.Bool
+	timer    *time.Timer
+
+	once   sync.Once
+	reason string
+	// onTrip is called once, with the reason, when the first limit is exceeded.
+	onTrip func(reason string)
+}
+
+// newScanLimiter returns nil if no limit is set.
+func newScanLimiter(limits ScanLimits, onTrip func(reason string)) *scanLimiter {
+	if limits == (ScanLimits{}) {
+		return nil
+	}
+	l := &scanLimiter{limits: limits, onTrip: onTrip}
+	if limits.MaxDuration > 0 {
+		l.timer = time.AfterFunc(limits.MaxDuration, func() {
+			l.trip(fmt.Sprintf("scan duration exceeded %s", limits.MaxDuration))
+		})
+	}
+	return l
+}
+
+// trip records the first exceeded limit. Later calls have no effect.
+func (l *scanLimiter) trip(reason string) {
+	l.once.Do(func() {
+		l.reason = reason
+		l.tripped.Store(true)
+		if l.onTrip != nil {
+			l.onTrip(reason)
+		}
+	})
+}
+
+// exceeded reports whether a limit has been exceeded, after which no more
+// chunks should be scanned.
+func (l *scanLimiter) exceeded() bool {
+	return l != nil && l.tripped.Load()
+}
+
+// addBytes checks the total number of bytes scanned so far.
+func (l *scanLimiter) addBytes(total uint64) {
+	if l == nil || l.limits.MaxBytes == 0 || total < l.limits.MaxBytes {
+		return
+	}
+	l.trip(fmt.Sprintf("scanned bytes exceeded %d", l.limits.MaxBytes))
+}
+
+// allowFinding counts a result about to be dispatched and reports whether it
+// is within MaxFindings. The first result beyond MaxFindings trips the limit.
+func (l *scanLimiter) allowFinding() bool {
+	if l == nil || l.limits.MaxFindings == 0 {
+		return true
+	}
+	if n := l.findings.Add(1); n > l.limits.MaxFindings {
+		l.trip(fmt.Sprintf("findings exceeded %d", l.limits.MaxFindings))
+		return false
+	}
+	return true
+}
+
+// stop releases the wall-clock timer once the scan is finished.
+func (l *scanLimiter) stop() {
+	if l != nil && l.timer != nil {
+		l.timer.Stop()
+	}
+}
+
+// truncation returns the reason the scan was truncated, if it was.
+func (l *scanLimiter) truncation() (string, bool) {
+	if !l.exceeded() {
+		return "", false
+	}
+	// reason is written before tripped is set.
+	return l.reason, true
+}
+
+// StoppedByLimit reports whether err is the result of the engine cancelling
+// the running sources because a scan limit was exceeded.
+func (e *Engine) StoppedByLimit(err error) bool {
+	if err == nil || !e.limiter.exceeded() {
+		return false
+	}
+	return errors.Is(err, errScanLimitExceeded) || errors.Is(err, aCtx.Canceled)
+}
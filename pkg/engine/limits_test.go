@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestScanLimiter_NoLimits(t *testing.T) {
+	l := newScanLimiter(ScanLimits{}, nil)
+	assert.Nil(t, l)
+
+	// A nil limiter never trips.
+	l.addBytes(1 << 40)
+	assert.True(t, l.allowFinding())
+	assert.False(t, l.exceeded())
+	_, truncated := l.truncation()
+	assert.False(t, truncated)
+}
+
+func TestScanLimiter_MaxBytes(t *testing.T) {
+	var reasons []string
+	l := newScanLimiter(ScanLimits{MaxBytes: 100}, func(reason string) { reasons = append(reasons, reason) })
+
+	l.addBytes(99)
+	assert.False(t, l.exceeded())
+	l.addBytes(100)
+	assert.True(t, l.exceeded())
+	l.addBytes(200)
+
+	reason, truncated := l.truncation()
+	assert.True(t, truncated)
+	assert.Equal(t, "scanned bytes exceeded 100", reason)
+	assert.Equal(t, []string{reason}, reasons)
+}
+
+func TestScanLimiter_MaxFindings(t *testing.T) {
+	l := newScanLimiter(ScanLimits{MaxFindings: 2}, nil)
+
+	assert.True(t, l.allowFinding())
+	assert.False(t, l.exceeded())
+	assert.True(t, l.allowFinding())
+	// Exactly MaxFindings results exceed nothing.
+	assert.False(t, l.exceeded())
+	_, truncated := l.truncation()
+	assert.False(t, truncated)
+
+	assert.False(t, l.allowFinding())
+	assert.True(t, l.exceeded())
+	assert.False(t, l.allowFinding())
+
+	reason, _ := l.truncation()
+	assert.Equal(t, "findings exceeded 2", reason)
+}
+
+func TestScanLimiter_MaxDuration(t *testing.T) {
+	tripped := make(chan string, 1)
+	l := newScanLimiter(ScanLimits{MaxDuration: 10 * time.Millisecond}, func(reason string) { tripped <- reason })
+	defer l.stop()
+
+	select {
+	case reason := <-tripped:
+		assert.Equal(t, "scan duration exceeded 10ms", reason)
+	case <-time.After(5 * time.Second):
+		t.Fatal("wall-clock limit did not trip")
+	}
+	assert.True(t, l.exceeded())
+}
+
+func TestEngine_MaxFindings(t *testing.T) {
+	ctx := context.Background()
+
+	scan := func(t *testing.T, content string) (Metrics, []string) {
+		t.Helper()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tokens.txt"), []byte(content), 0o644))
+
+		printer := new(collectPrinter)
+		conf := Config{
+			Concurrency: 1,
+			Decoders:    decoders.DefaultDecoders(),
+			Detectors: []detectors.Detector{&tokenDetector{passthroughDetector: passthroughDetector{
+				keywords:     []string{"tok_"},
+				detectorType: detector_typepb.DetectorType_Github,
+			}}},
+			Results:       map[string]struct{}{"unverified": {}},
+			Limits:        ScanLimits{MaxFindings: 2},
+			SourceManager: sources.NewManager(sources.WithSourceUnits(), sources.WithBufferedOutput(64)),
+			Dispatcher:    NewPrinterDispatcher(printer),
+		}
+		e, err := NewEngine(ctx, &conf)
+		require.NoError(t, err)
+
+		e.Start(ctx)
+		_, err = e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{dir}})
+		require.NoError(t, err)
+		require.NoError(t, e.Finish(ctx))
+		return e.GetMetrics(), printer.raws
+	}
+
+	t.Run("exactly the limit", func(t *testing.T) {
+		m, raws := scan(t, "tok_qzvkjwprx\ntok_xjqvzkwmb\n")
+		assert.False(t, m.Truncated)
+		assert.Len(t, raws, 2)
+	})
+
+	t.Run("beyond the limit", func(t *testing.T) {
+		m, raws := scan(t, "tok_qzvkjwprx\ntok_xjqvzkwmb\ntok_wkzqjxvhn\n")
+		assert.True(t, m.Truncated)
+		assert.Equal(t, "findings exceeded 2", m.TruncationReason)
+		assert.Len(t, raws, 2)
+	})
+}
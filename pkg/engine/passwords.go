@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// hasPasswordDecrypters reports whether any of the given detectors can make use of candidate passwords.
+func hasPasswordDecrypters(dets []detectors.Detector) bool {
+	return slices.ContainsFunc(dets, func(d detectors.Detector) bool {
+		_, ok := d.(detectors.PasswordDecrypter)
+		return ok
+	})
+}
+
+// sourceUnit returns the source unit a chunk belongs to, used to group candidate passwords and OpenSSH files, and the
+// file the chunk was read from. Chunks carry no source unit ID, so the unit is approximated by the source and job, plus the
+// repository for sources that scan several repositories.
+func sourceUnit(chunk *sources.Chunk) (unit, file string) {
+	var repository string
+	if md := chunk.SourceMetadata; md != nil {
+		m := md.ProtoReflect()
+		if oneof := m.Descriptor().Oneofs().ByName("data"); oneof != nil {
+			if field := m.WhichOneof(oneof); field != nil {
+				data := m.Get(field).Message().Interface()
+				if d, ok := data.(interface{ GetRepository() string }); ok {
+					repository = d.GetRepository()
+				}
+				if d, ok := data.(interface{ GetFile() string }); ok {
+					file = d.GetFile()
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%d/%s", chunk.SourceID, chunk.JobID, repository), file
+}
+
+// collectPasswords records the candidate passwords found in a chunk for its source unit.
+func (e *Engine) collectPasswords(chunk *sources.Chunk) {
+	if e.passwords == nil {
+		return
+	}
+	unit, file := sourceUnit(chunk)
+	e.passwords.Add(unit, file, chunk.Data)
+}
+
+// tryDecrypt offers the candidate passwords collected for the chunk's source unit to a detector that can decrypt
+// the material behind its unverified results.
+func (e *Engine) tryDecrypt(
+	ctx context.Context,
+	detector detectors.Detector,
+	chunk *sources.Chunk,
+	data []byte,
+	results []detectors.Result,
+) {
+	if e.passwords == nil {
+		return
+	}
+	decrypter, ok := detector.(detectors.PasswordDecrypter)
+	if !ok {
+		return
+	}
+	unit, _ := sourceUnit(chunk)
+	candidates := e.passwords.Candidates(unit)
+	if len(candidates) == 0 {
+		return
+	}
+
+	for i := range results {
+		if results[i].Verified {
+			continue
+		}
+		if decrypter.TryDecrypt(ctx, data, &results[i], candidates) {
+			ctx.Logger().V(3).Info("decrypted secret material with a collected password",
+				"detector", detector.Type().String())
+		}
+	}
+}
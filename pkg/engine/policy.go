@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// detectorMetadata maps detector types to the detectors that declare defaults for their results through
+// detectors.MetadataProvider or detectors.RemediationProvider. Detector types that are absent get no defaults.
+type detectorMetadata map[detector_typepb.DetectorType]detectors.Detector
+
+// newDetectorMetadata collects the metadata declared by the given detectors. Versions of a detector type are
+// expected to declare the same defaults; the first one wins.
+func newDetectorMetadata(dets []detectors.Detector) detectorMetadata {
+	metadata := make(detectorMetadata)
+	for _, d := range dets {
+		_, isMetadataProvider := d.(detectors.MetadataProvider)
+		_, isRemediationProvider := d.(detectors.RemediationProvider)
+		if !isMetadataProvider && !isRemediationProvider {
+			continue
+		}
+		if _, ok := metadata[d.Type()]; !ok {
+			metadata[d.Type()] = d
+		}
+	}
+	return metadata
+}
+
+// policyNow returns the scan time, which may be pinned with detectors.SetScanTime; tests replace it.
+var policyNow = detectors.Now
+
+// applyResultPolicy fills in policy-controlled fields of a result before it is emitted.
+func applyResultPolicy(res *detectors.Result, metadata detectorMetadata) {
+	d := metadata[res.DetectorType]
+	if provider, ok := d.(detectors.MetadataProvider); ok {
+		if res.Severity == detectors.SeverityUnspecified {
+			res.Severity = provider.DefaultSeverity()
+		}
+		res.AddTags(provider.Tags()...)
+	}
+	if provider, ok := d.(detectors.RemediationProvider); ok && res.Remediation == "" {
+		res.Remediation = provider.Remediation()
+	}
+
+	// A credential that has already expired is no longer usable, unless verification shows otherwise.
+	if expiresAt, ok := res.ExpiresAt(); ok && !res.Verified && expiresAt.Before(policyNow()) {
+		res.CloneExtraData()
+		res.ExtraData["expired"] = "true"
+		res.Severity = detectors.SeverityLow
+	}
+
+	switch {
+	case res.Verified:
+		// A successful verification is the strongest evidence available.
+		res.Confidence = detectors.ConfidenceHigh
+	case res.Confidence != detectors.ConfidenceUnspecified:
+		// Keep the detector's own rating.
+	case res.VerificationError() != nil:
+		// Indeterminate: the candidate could not be checked either way.
+		res.Confidence = detectors.ConfidenceMedium
+	default:
+		res.Confidence = detectors.ConfidenceLow
+	}
+}
+
+// annotateResult adds the annotations of the chunk a result was found in to its extra data. Keys the detector
+// set itself take precedence.
+func annotateResult(res *detectors.Result, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+	res.CloneExtraData()
+	for k, v := range annotations {
+		if _, ok := res.ExtraData[k]; !ok {
+			res.ExtraData[k] = v
+		}
+	}
+}
+
+// outputFilter decides which results are emitted, independent of the output format.
+type outputFilter struct {
+	// onlyVerified drops every result that was not verified.
+	onlyVerified bool
+	// includeIndeterminate keeps results whose verification failed with an error when onlyVerified is set.
+	includeIndeterminate bool
+	// minConfidence drops results rated below it.
+	minConfidence detectors.Confidence
+}
+
+// allows reports whether the result should be emitted.
+func (f outputFilter) allows(res *detectors.Result) bool {
+	if f.onlyVerified && !res.Verified {
+		if !f.includeIndeterminate || res.VerificationError() == nil {
+			return false
+		}
+	}
+	return res.Confidence >= f.minConfidence
+}
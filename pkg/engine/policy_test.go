@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/bitcoinwif"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/cozetoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentrydsn"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentryorgtoken"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+func TestApplyResultPolicy_Severity(t *testing.T) {
+	metadata := newDetectorMetadata([]detectors.Detector{sentrydsn.Scanner{}, sentryorgtoken.Scanner{}})
+
+	tests := []struct {
+		name   string
+		result detectors.Result
+		want   detectors.Severity
+	}{
+		{
+			name:   "sentry dsn defaults to low",
+			result: detectors.Result{DetectorType: detector_typepb.DetectorType_SentryDSN},
+			want:   detectors.SeverityLow,
+		},
+		{
+			name:   "sentry org token defaults to high",
+			result: detectors.Result{DetectorType: detector_typepb.DetectorType_SentryOrgToken},
+			want:   detectors.SeverityHigh,
+		},
+		{
+			name: "detector severity is kept",
+			result: detectors.Result{
+				DetectorType: detector_typepb.DetectorType_SentryDSN,
+				Severity:     detectors.SeverityMedium,
+			},
+			want: detectors.SeverityMedium,
+		},
+		{
+			name:   "unknown detector stays unspecified",
+			result: detectors.Result{DetectorType: detector_typepb.DetectorType_AWS},
+			want:   detectors.SeverityUnspecified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.result
+			applyResultPolicy(&res, metadata)
+			assert.Equal(t, tt.want, res.Severity)
+		})
+	}
+}
+
+func TestApplyResultPolicy_Tags(t *testing.T) {
+	metadata := newDetectorMetadata([]detectors.Detector{sentrydsn.Scanner{}})
+
+	res := detectors.Result{DetectorType: detector_typepb.DetectorType_SentryDSN, Tags: []string{"custom"}}
+	applyResultPolicy(&res, metadata)
+	assert.Equal(t, []string{"custom", detectors.TagMonitoring}, res.Tags)
+
+	// Applying the policy again does not duplicate tags.
+	applyResultPolicy(&res, metadata)
+	assert.Equal(t, []string{"custom", detectors.TagMonitoring}, res.Tags)
+
+	res = detectors.Result{DetectorType: detector_typepb.DetectorType_AWS}
+	applyResultPolicy(&res, metadata)
+	assert.Empty(t, res.Tags)
+}
+
+func TestApplyResultPolicy_Remediation(t *testing.T) {
+	metadata := newDetectorMetadata([]detectors.Detector{cozetoken.Scanner{}, bitcoinwif.Scanner{}, sentrydsn.Scanner{}})
+
+	res := detectors.Result{DetectorType: detector_typepb.DetectorType_CozeToken}
+	applyResultPolicy(&res, metadata)
+	assert.Equal(t, cozetoken.Scanner{}.Remediation(), res.Remediation)
+
+	res = detectors.Result{DetectorType: detector_typepb.DetectorType_BitcoinWIF}
+	applyResultPolicy(&res, metadata)
+	assert.Equal(t, detectors.WalletRemediation("Bitcoin wallet"), res.Remediation)
+
+	// A remediation set by the detector for the result is kept.
+	res = detectors.Result{DetectorType: detector_typepb.DetectorType_BitcoinWIF, Remediation: "Testnet key, no action needed."}
+	applyResultPolicy(&res, metadata)
+	assert.Equal(t, "Testnet key, no action needed.", res.Remediation)
+
+	res = detectors.Result{DetectorType: detector_typepb.DetectorType_SentryDSN}
+	applyResultPolicy(&res, metadata)
+	assert.Empty(t, res.Remediation)
+}
+
+func TestApplyResultPolicy_Expired(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	policyNow = func() time.Time { return now }
+	t.Cleanup(func() { policyNow = detectors.Now })
+
+	expired := detectors.Result{Severity: detectors.SeverityHigh, ExtraData: map[string]string{"provider": "aliyun"}}
+	expired.SetExpiresAt(now.Add(-time.Hour))
+	extraData := expired.ExtraData
+	applyResultPolicy(&expired, nil)
+	assert.Equal(t, detectors.SeverityLow, expired.Severity)
+	assert.Equal(t, "true", expired.ExtraData["expired"])
+	assert.NotContains(t, extraData, "expired", "the original map must not be modified")
+
+	valid := detectors.Result{Severity: detectors.SeverityHigh}
+	valid.SetExpiresAt(now.Add(time.Hour))
+	applyResultPolicy(&valid, nil)
+	assert.Equal(t, detectors.SeverityHigh, valid.Severity)
+	assert.NotContains(t, valid.ExtraData, "expired")
+
+	verified := detectors.Result{Severity: detectors.SeverityHigh, Verified: true}
+	verified.SetExpiresAt(now.Add(-time.Hour))
+	applyResultPolicy(&verified, nil)
+	assert.Equal(t, detectors.SeverityHigh, verified.Severity)
+}
+
+func TestApplyResultPolicy_Confidence(t *testing.T) {
+	indeterminate := detectors.Result{}
+	indeterminate.SetVerificationError(errors.New("timeout"))
+
+	tests := []struct {
+		name   string
+		result detectors.Result
+		want   detectors.Confidence
+	}{
+		{
+			name:   "verified is high",
+			result: detectors.Result{Verified: true, Confidence: detectors.ConfidenceLow},
+			want:   detectors.ConfidenceHigh,
+		},
+		{
+			name:   "indeterminate is medium",
+			result: indeterminate,
+			want:   detectors.ConfidenceMedium,
+		},
+		{
+			name:   "unverified is low",
+			result: detectors.Result{},
+			want:   detectors.ConfidenceLow,
+		},
+		{
+			name:   "detector confidence is kept",
+			result: detectors.Result{Confidence: detectors.ConfidenceMedium},
+			want:   detectors.ConfidenceMedium,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.result
+			applyResultPolicy(&res, nil)
+			assert.Equal(t, tt.want, res.Confidence)
+		})
+	}
+}
+
+func TestAnnotateResult(t *testing.T) {
+	res := detectors.Result{ExtraData: map[string]string{"notebook_cell": "detector", "account": "123"}}
+	extraData := res.ExtraData
+	annotateResult(&res, map[string]string{"notebook_cell": "3", "notebook_output": "true"})
+	assert.Equal(t, map[string]string{"notebook_cell": "detector", "notebook_output": "true", "account": "123"}, res.ExtraData)
+	assert.NotContains(t, extraData, "notebook_output", "the original map must not be modified")
+
+	res = detectors.Result{}
+	annotateResult(&res, nil)
+	assert.Nil(t, res.ExtraData)
+}
+
+func TestOutputFilter_Allows(t *testing.T) {
+	verified := detectors.Result{Verified: true, Confidence: detectors.ConfidenceHigh}
+	unverified := detectors.Result{Confidence: detectors.ConfidenceLow}
+	indeterminate := detectors.Result{Confidence: detectors.ConfidenceMedium}
+	indeterminate.SetVerificationError(errors.New("timeout"))
+
+	tests := []struct {
+		name   string
+		filter outputFilter
+		result detectors.Result
+		want   bool
+	}{
+		{name: "no filter", filter: outputFilter{}, result: unverified, want: true},
+		{name: "only verified keeps verified", filter: outputFilter{onlyVerified: true}, result: verified, want: true},
+		{name: "only verified drops unverified", filter: outputFilter{onlyVerified: true}, result: unverified, want: false},
+		{name: "only verified drops indeterminate", filter: outputFilter{onlyVerified: true}, result: indeterminate, want: false},
+		{
+			name:   "include indeterminate",
+			filter: outputFilter{onlyVerified: true, includeIndeterminate: true},
+			result: indeterminate,
+			want:   true,
+		},
+		{
+			name:   "include indeterminate still drops unverified",
+			filter: outputFilter{onlyVerified: true, includeIndeterminate: true},
+			result: unverified,
+			want:   false,
+		},
+		{name: "min confidence drops lower", filter: outputFilter{minConfidence: detectors.ConfidenceMedium}, result: unverified, want: false},
+		{name: "min confidence keeps equal", filter: outputFilter{minConfidence: detectors.ConfidenceMedium}, result: indeterminate, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.allows(&tt.result))
+		})
+	}
+}
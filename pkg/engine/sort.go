@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// resultSorter holds results back until the scan finishes and then dispatches
+// them in a deterministic order, so that reports of the same input compare
+// equal from run to run. The notifier workers otherwise dispatch results in
+// the order the concurrent detector workers happen to find them. A nil
+// *resultSorter holds nothing back.
+type resultSorter struct {
+	mu      sync.Mutex
+	results []sortedResult
+}
+
+// sortedResult is a result and its source metadata, serialized once for
+// comparison.
+type sortedResult struct {
+	detectors.ResultWithMetadata
+	metadata []byte
+	// plaintext holds the secrets to wipe once the result is dispatched. Nil
+	// unless secrets are zeroized.
+	plaintext [][]byte
+}
+
+func newResultSorter(enabled bool) *resultSorter {
+	if !enabled {
+		return nil
+	}
+	return new(resultSorter)
+}
+
+// add holds a result back until flush. If plaintext is not nil, the result
+// and plaintext are wiped once dispatched.
+func (s *resultSorter) add(result detectors.ResultWithMetadata, plaintext [][]byte) {
+	// Deterministic marshaling orders map fields, unlike the text format,
+	// whose whitespace is randomized on purpose.
+	metadata, _ := proto.MarshalOptions{Deterministic: true}.Marshal(result.SourceMetadata)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, sortedResult{ResultWithMetadata: result, metadata: metadata, plaintext: plaintext})
+}
+
+// flush dispatches the held back results in order.
+func (s *resultSorter) flush(ctx context.Context, dispatcher ResultsDispatcher) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	results := s.results
+	s.results = nil
+	s.mu.Unlock()
+
+	slices.SortStableFunc(results, compareResults)
+	for _, result := range results {
+		if err := dispatcher.Dispatch(ctx, result.ResultWithMetadata); err != nil {
+			ctx.Logger().Error(err, "error notifying result")
+		}
+		if result.plaintext != nil {
+			zeroize(&result.ResultWithMetadata, result.plaintext)
+		}
+	}
+}
+
+// compareResults orders results by where they were found, then by what was
+// found.
+func compareResults(a, b sortedResult) int {
+	return cmp.Or(
+		cmp.Compare(a.SourceType, b.SourceType),
+		cmp.Compare(a.SourceName, b.SourceName),
+		bytes.Compare(a.metadata, b.metadata),
+		cmp.Compare(a.DetectorType, b.DetectorType),
+		cmp.Compare(a.DecoderType, b.DecoderType),
+		bytes.Compare(a.Raw, b.Raw),
+		bytes.Compare(a.RawV2, b.RawV2),
+		cmp.Compare(a.Redacted, b.Redacted),
+		compareBool(a.FollowUp, b.FollowUp),
+	)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
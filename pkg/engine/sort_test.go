@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+func fileResult(file string, line int64, detector detector_typepb.DetectorType, raw string) detectors.ResultWithMetadata {
+	return detectors.ResultWithMetadata{
+		SourceMetadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Filesystem{
+			Filesystem: &source_metadatapb.Filesystem{File: file, Line: line},
+		}},
+		Result: detectors.Result{DetectorType: detector, Raw: []byte(raw)},
+	}
+}
+
+func TestResultSorter(t *testing.T) {
+	assert.Nil(t, newResultSorter(false))
+
+	want := []detectors.ResultWithMetadata{
+		fileResult("a.env", 1, detector_typepb.DetectorType_AWS, "AKIA1"),
+		fileResult("a.env", 1, detector_typepb.DetectorType_AWS, "AKIA2"),
+		fileResult("a.env", 1, detector_typepb.DetectorType_Github, "ghp_1"),
+		fileResult("a.env", 7, detector_typepb.DetectorType_AWS, "AKIA1"),
+		fileResult("b.env", 1, detector_typepb.DetectorType_AWS, "AKIA1"),
+	}
+
+	ctx := context.Background()
+	sorter := newResultSorter(true)
+	for _, i := range []int{3, 0, 4, 2, 1} {
+		sorter.add(want[i], nil)
+	}
+	dispatcher := new(collectDispatcher)
+	sorter.flush(ctx, dispatcher)
+
+	got := make([]string, 0, len(dispatcher.results))
+	for _, r := range dispatcher.results {
+		got = append(got, r.SourceMetadata.GetFilesystem().GetFile()+" "+string(r.Raw))
+	}
+	assert.Equal(t, []string{"a.env AKIA1", "a.env AKIA2", "a.env ghp_1", "a.env AKIA1", "b.env AKIA1"}, got)
+	assert.Equal(t, int64(7), dispatcher.results[3].SourceMetadata.GetFilesystem().GetLine())
+
+	// Flushing empties the sorter.
+	sorter.flush(ctx, dispatcher)
+	assert.Len(t, dispatcher.results, len(want))
+}
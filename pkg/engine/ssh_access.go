@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"slices"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// hasSSHAccessReporters reports whether any of the given detectors reports the hosts its SSH keys grant access to.
+func hasSSHAccessReporters(dets []detectors.Detector) bool {
+	return slices.ContainsFunc(dets, func(d detectors.Detector) bool {
+		_, ok := d.(detectors.SSHAccessReporter)
+		return ok
+	})
+}
+
+// collectSSHFiles keeps the chunk for its source unit if it was read from an OpenSSH file.
+func (e *Engine) collectSSHFiles(chunk *sources.Chunk) {
+	if e.sshFiles == nil {
+		return
+	}
+	unit, file := sourceUnit(chunk)
+	e.sshFiles.Add(unit, file, chunk.Data)
+}
+
+// reportSSHAccess matches the keys found by a detector of SSH private keys against the OpenSSH files collected for
+// the chunk's source unit, and drops the keys that grant access to nothing. Files scanned after the key are not
+// taken into account.
+func (e *Engine) reportSSHAccess(
+	detector detectors.Detector,
+	chunk *sources.Chunk,
+	results []detectors.Result,
+) []detectors.Result {
+	reporter, ok := detector.(detectors.SSHAccessReporter)
+	if !ok || len(results) == 0 {
+		return results
+	}
+	unit, file := sourceUnit(chunk)
+	files := e.sshFiles.Files(unit)
+
+	kept := results[:0]
+	for _, res := range results {
+		if reporter.ReportSSHAccess(&res, file, files) {
+			kept = append(kept, res)
+		}
+	}
+	return kept
+}
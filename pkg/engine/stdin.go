@@ -25,7 +25,7 @@ func (e *Engine) ScanStdinInput(ctx context.Context, c sources.StdinConfig) (sou
 	sourceName := "trufflehog - stdin"
 	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, stdin.SourceType)
 
-	stdinSource := &stdin.Source{}
+	stdinSource := &stdin.Source{Stream: c.Stream, IdleFlush: c.IdleFlush}
 	if err := stdinSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, runtime.NumCPU()); err != nil {
 		return sources.JobProgressRef{}, err
 	}
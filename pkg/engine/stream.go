@@ -0,0 +1,97 @@
+package engine
+
+import (
+	aCtx "context"
+	"errors"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// Finding is a result delivered to embedded consumers through a StreamDispatcher.
+type Finding = detectors.ResultWithMetadata
+
+// defaultStreamBufferSize is the number of findings a StreamDispatcher buffers when no size is given.
+const defaultStreamBufferSize = 64
+
+// ErrStreamClosed is returned when dispatching to a StreamDispatcher that has been closed.
+var ErrStreamClosed = errors.New("results stream closed")
+
+// StreamDispatcher adapts the ResultsDispatcher interface to a channel of findings for consumers that embed the
+// engine as a library. Findings are buffered up to a fixed size; once the buffer is full, Dispatch blocks until
+// the consumer catches up or the context is cancelled, which in turn slows down the notifier workers and,
+// through the results channel, the detectors. The engine closes the stream in Finish.
+type StreamDispatcher struct {
+	findings chan Finding
+	done     chan struct{}
+
+	mu        sync.RWMutex
+	closeOnce sync.Once
+}
+
+// NewStreamDispatcher creates a StreamDispatcher buffering up to bufferSize findings. A non-positive bufferSize
+// uses a default.
+func NewStreamDispatcher(bufferSize int) *StreamDispatcher {
+	if bufferSize <= 0 {
+		bufferSize = defaultStreamBufferSize
+	}
+	return &StreamDispatcher{
+		findings: make(chan Finding, bufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+// Findings returns the channel findings are delivered on. It is closed once the engine has finished, or when
+// Close is called.
+func (s *StreamDispatcher) Findings() <-chan Finding { return s.findings }
+
+// Dispatch sends the result to the stream, blocking while the buffer is full.
+func (s *StreamDispatcher) Dispatch(ctx context.Context, result detectors.ResultWithMetadata) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	select {
+	case <-s.done:
+		return ErrStreamClosed
+	default:
+	}
+
+	select {
+	case s.findings <- result:
+		return nil
+	case <-s.done:
+		return ErrStreamClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the findings channel. Pending and later calls to Dispatch return ErrStreamClosed. It is safe to
+// call Close more than once and concurrently with Dispatch.
+func (s *StreamDispatcher) Close() {
+	s.closeOnce.Do(func() {
+		// Unblock pending dispatches before waiting for them to release the lock.
+		close(s.done)
+		s.mu.Lock()
+		close(s.findings)
+		s.mu.Unlock()
+	})
+}
+
+// Collect reads findings until the channel is closed and returns them. If the context is cancelled first, it
+// returns the findings read so far along with the context's error.
+func Collect(ctx aCtx.Context, findings <-chan Finding) ([]Finding, error) {
+	var collected []Finding
+	for {
+		select {
+		case finding, ok := <-findings:
+			if !ok {
+				return collected, nil
+			}
+			collected = append(collected, finding)
+		case <-ctx.Done():
+			return collected, ctx.Err()
+		}
+	}
+}
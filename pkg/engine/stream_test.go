@@ -0,0 +1,119 @@
+package engine
+
+import (
+	aCtx "context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestStreamDispatcher_Backpressure(t *testing.T) {
+	ctx := context.Background()
+	stream := NewStreamDispatcher(1)
+
+	require.NoError(t, stream.Dispatch(ctx, Finding{Result: detectors.Result{Raw: []byte("first")}}))
+
+	// The buffer is full, so the next dispatch blocks until the consumer reads.
+	dispatched := make(chan error, 1)
+	go func() {
+		dispatched <- stream.Dispatch(ctx, Finding{Result: detectors.Result{Raw: []byte("second")}})
+	}()
+	select {
+	case <-dispatched:
+		t.Fatal("dispatch did not block on a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Equal(t, "first", string((<-stream.Findings()).Raw))
+	require.NoError(t, <-dispatched)
+	assert.Equal(t, "second", string((<-stream.Findings()).Raw))
+}
+
+func TestStreamDispatcher_Cancel(t *testing.T) {
+	stream := NewStreamDispatcher(1)
+	require.NoError(t, stream.Dispatch(context.Background(), Finding{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, stream.Dispatch(ctx, Finding{}), aCtx.DeadlineExceeded)
+}
+
+func TestStreamDispatcher_Close(t *testing.T) {
+	stream := NewStreamDispatcher(1)
+	require.NoError(t, stream.Dispatch(context.Background(), Finding{}))
+
+	// Closing unblocks a pending dispatch.
+	dispatched := make(chan error, 1)
+	go func() { dispatched <- stream.Dispatch(context.Background(), Finding{}) }()
+	time.Sleep(10 * time.Millisecond)
+	stream.Close()
+	stream.Close()
+	assert.ErrorIs(t, <-dispatched, ErrStreamClosed)
+	assert.ErrorIs(t, stream.Dispatch(context.Background(), Finding{}), ErrStreamClosed)
+
+	// Findings buffered before closing are still delivered.
+	findings, err := Collect(context.Background(), stream.Findings())
+	require.NoError(t, err)
+	assert.Len(t, findings, 1)
+}
+
+func TestCollect_Cancel(t *testing.T) {
+	stream := NewStreamDispatcher(2)
+	require.NoError(t, stream.Dispatch(context.Background(), Finding{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	findings, err := Collect(ctx, stream.Findings())
+	assert.ErrorIs(t, err, aCtx.DeadlineExceeded)
+	assert.Len(t, findings, 1)
+}
+
+func TestEngine_StreamDispatcher(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	absPath, err := filepath.Abs("./testdata/secrets.txt")
+	require.NoError(t, err)
+
+	// A single-slot buffer: the scan can only finish if the consumer keeps reading.
+	stream := NewStreamDispatcher(1)
+	conf := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     defaults.DefaultDetectors(),
+		Verify:        false,
+		SourceManager: sources.NewManager(sources.WithSourceUnits()),
+		Dispatcher:    stream,
+	}
+	e, err := NewEngine(ctx, &conf)
+	require.NoError(t, err)
+	e.Start(ctx)
+
+	type collected struct {
+		findings []Finding
+		err      error
+	}
+	done := make(chan collected, 1)
+	go func() {
+		findings, err := Collect(ctx, stream.Findings())
+		done <- collected{findings, err}
+	}()
+
+	_, err = e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{absPath}})
+	require.NoError(t, err)
+	require.NoError(t, e.Finish(ctx))
+
+	// Finish closes the stream, which ends Collect.
+	got := <-done
+	require.NoError(t, got.err)
+	assert.Len(t, got.findings, 2)
+}
@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"runtime"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/web"
+)
+
+// ScanWeb crawls the configured URLs and scans the pages, scripts, source maps and configuration endpoints found.
+func (e *Engine) ScanWeb(ctx context.Context, c sources.WebConfig) (sources.JobProgressRef, error) {
+	sourceName := "trufflehog - web"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, web.SourceType)
+
+	webSource := &web.Source{
+		URLs:         c.URLs,
+		MaxDepth:     c.MaxDepth,
+		MaxRequests:  c.MaxRequests,
+		AllowedHosts: c.AllowedHosts,
+		IgnoreRobots: c.IgnoreRobots,
+	}
+	if err := webSource.Init(ctx, sourceName, jobID, sourceID, true, nil, runtime.NumCPU()); err != nil {
+		return sources.JobProgressRef{}, err
+	}
+	return e.sourceManager.EnumerateAndScan(ctx, sourceName, webSource)
+}
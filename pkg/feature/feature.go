@@ -16,6 +16,7 @@ var (
 	GitlabProjectsPerPage          atomic.Int64
 	UseGithubGraphQLAPI            atomic.Bool // use github graphql api to fetch issues, pr's and comments
 	HTMLDecoderEnabled             atomic.Bool
+	PrioritizeFiles                atomic.Bool // chunk env and config files first and vendored files last
 )
 
 type AtomicString struct {
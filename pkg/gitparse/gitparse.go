@@ -60,10 +60,15 @@ type Diff struct {
 	PathB     string
 	LineStart int
 	IsBinary  bool
+	// AddedLines holds the offsets from LineStart of the lines the diff adds. It is only recorded for staged diffs
+	// parsed with WithStagedContext, whose content also holds the unchanged lines around them.
+	AddedLines []int
 
 	Commit *Commit
 
 	contentWriter contentWriter
+	// lines counts the lines written to the content.
+	lines int
 }
 
 type diffOption func(*Diff)
@@ -101,6 +106,7 @@ func (d *Diff) ReadCloser() (io.ReadCloser, error) { return d.contentWriter.Read
 
 // write delegates to the contentWriter.
 func (d *Diff) write(p []byte) error {
+	d.lines++
 	_, err := d.contentWriter.Write(p)
 	return err
 }
@@ -128,6 +134,8 @@ type Parser struct {
 	maxCommitSize int64
 	dateFormat    string
 	waitDelay     time.Duration
+	// stagedContext is the number of unchanged lines kept around staged changes.
+	stagedContext int
 
 	useCustomContentWriter bool
 }
@@ -216,6 +224,14 @@ func WithWaitDelay(waitDelay time.Duration) Option {
 	}
 }
 
+// WithStagedContext keeps the given number of unchanged lines around each staged change, for detectors that look at
+// the lines around a secret, and records which lines the diff adds. By default, unchanged lines are blanked.
+func WithStagedContext(lines int) Option {
+	return func(parser *Parser) {
+		parser.stagedContext = lines
+	}
+}
+
 // Option is used for adding options to Config.
 type Option func(*Parser)
 
@@ -293,6 +309,9 @@ func (c *Parser) RepoPath(
 func (c *Parser) Staged(ctx context.Context, source string) (chan *Diff, error) {
 	// Provide the --cached flag to diff to get the diff of the staged changes.
 	args := []string{"-C", source, "diff", "-p", "--cached", "--full-history", "--diff-filter=AM", "--date=iso-strict"}
+	if c.stagedContext > 0 {
+		args = append(args, fmt.Sprintf("--unified=%d", c.stagedContext))
+	}
 
 	cmd := exec.CommandContext(ctx, "git", args...)
 
@@ -356,6 +375,7 @@ func (c *Parser) FromReader(ctx context.Context, stdOut io.Reader, diffChan chan
 		totalLogSize int
 	)
 	var latestState = Initial
+	keepContext := isStaged && c.stagedContext > 0
 
 	diff := func(c *Commit, opts ...diffOption) *Diff {
 		opts = append(opts, withCustomContentWriter(bufferwriter.New()))
@@ -551,7 +571,11 @@ func (c *Parser) FromReader(ctx context.Context, stdOut io.Reader, diffChan chan
 				latestState = HunkContentLine
 			}
 			// TODO: Why do we care about this? It creates empty lines in the diff. If there are no plusLines, it's just newlines.
-			if err := currentDiff.write([]byte("\n")); err != nil {
+			content := []byte("\n")
+			if keepContext {
+				content = line[1:]
+			}
+			if err := currentDiff.write(content); err != nil {
 				ctx.Logger().Error(err, "failed to write to diff")
 			}
 		case isHunkPlusLine(latestState, line):
@@ -559,6 +583,9 @@ func (c *Parser) FromReader(ctx context.Context, stdOut io.Reader, diffChan chan
 				latestState = HunkContentLine
 			}
 
+			if keepContext {
+				currentDiff.AddedLines = append(currentDiff.AddedLines, currentDiff.lines)
+			}
 			if err := currentDiff.write(line[1:]); err != nil {
 				ctx.Logger().Error(err, "failed to write to diff")
 			}
@@ -937,6 +937,43 @@ func TestStagedDiffParsing(t *testing.T) {
 	}
 }
 
+func TestStagedDiffParsingWithContext(t *testing.T) {
+	const stagedDiff = `diff --git a/.env b/.env
+index 239b415..2ee133b 100644
+--- a/.env
++++ b/.env
+@@ -1,4 +1,4 @@
+ # wallet
+-PRIVATE_KEY=
++PRIVATE_KEY=4c0883a69102937d6231471b5dbb6204fe512961708279f2e3e8a5d4b8e3e5b1
+ RPC_URL=https://rpc.example.com
+ CHAIN_ID=1
+@@ -9,2 +9,1 @@
+ DEBUG=false
+-LOG_LEVEL=debug
+`
+
+	diffChan := make(chan *Diff)
+	parser := NewParser(WithStagedContext(3))
+	go parser.FromReader(context.Background(), strings.NewReader(stagedDiff), diffChan, true)
+
+	var diffs []*Diff
+	for diff := range diffChan {
+		diffs = append(diffs, diff)
+	}
+	require.Len(t, diffs, 2)
+
+	content, err := diffs[0].contentWriter.String()
+	require.NoError(t, err)
+	assert.Equal(t, "# wallet\nPRIVATE_KEY=4c0883a69102937d6231471b5dbb6204fe512961708279f2e3e8a5d4b8e3e5b1\nRPC_URL=https://rpc.example.com\nCHAIN_ID=1\n", content)
+	assert.Equal(t, 1, diffs[0].LineStart)
+	assert.Equal(t, []int{1}, diffs[0].AddedLines)
+
+	// A hunk that only removes lines adds nothing.
+	assert.Equal(t, 9, diffs[1].LineStart)
+	assert.Empty(t, diffs[1].AddedLines)
+}
+
 func TestStagedDiffParsingBufferedFileWriter(t *testing.T) {
 	expected := []*Diff{
 		{
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+)
+
+// Annotation keys set on chunks from browser extensions and desktop app bundles.
+const (
+	appBundleKey        = "app_bundle"
+	appBundleIDKey      = "app_id"
+	appBundleNameKey    = "app_name"
+	appBundleVersionKey = "app_version"
+	appBundleFileKey    = "app_file"
+)
+
+// Kinds of app bundles.
+const (
+	chromeExtensionBundle = "chrome_extension"
+	electronAppBundle     = "electron_app"
+)
+
+// maxBundleManifestSize bounds the manifest.json or package.json read to attribute a bundle.
+const maxBundleManifestSize = 1 << 20
+
+// appBundle is a packaged browser extension or desktop app.
+type appBundle struct {
+	kind string
+	// id identifies the extension or app: the extension ID of Chrome extensions, and the appId or package name of
+	// Electron apps. Empty if unknown.
+	id      string
+	name    string
+	version string
+	entries []bundleEntry
+}
+
+// bundleEntry is a file packed in an app bundle.
+type bundleEntry struct {
+	name string
+	size int64
+	open func() (io.ReadCloser, error)
+}
+
+// appBundleHandler handles packed Chrome extensions (.crx) and Electron app archives (.asar). Wallet extensions and
+// desktop trading apps ship provider keys and sometimes signing material in their JavaScript and configuration, so
+// every packed file is scanned, and chunks are annotated with the extension or app and the file they came from.
+type appBundleHandler struct {
+	*defaultHandler
+	parse func(io.ReaderAt, int64) (*appBundle, error)
+}
+
+// newCRXHandler creates an appBundleHandler for packed Chrome extensions.
+func newCRXHandler() *appBundleHandler {
+	return &appBundleHandler{defaultHandler: newDefaultHandler(crxHandlerType), parse: parseCRX}
+}
+
+// newASARHandler creates an appBundleHandler for Electron app archives.
+func newASARHandler() *appBundleHandler {
+	return &appBundleHandler{defaultHandler: newDefaultHandler(asarHandlerType), parse: parseASAR}
+}
+
+// HandleFile processes app bundles and returns a channel of DataOrErr. Bundles that cannot be parsed are handled
+// like any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Panics during processing (recovered and returned as fatal errors)
+//
+// Non-fatal errors that will be logged but allow processing to continue include:
+// - Errors opening or handling individual packed files
+func (h *appBundleHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	if feature.ForceSkipArchives.Load() {
+		close(dataOrErrChan)
+		return dataOrErrChan
+	}
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		// Defer a panic recovery to handle any panics that occur while parsing the bundle.
+		defer func() {
+			if r := recover(); r != nil {
+				var panicErr error
+				if e, ok := r.(error); ok {
+					panicErr = e
+				} else {
+					panicErr = fmt.Errorf("panic occurred: %v", r)
+				}
+				dataOrErrChan <- DataOrErr{
+					Err: fmt.Errorf("%w: panic error: %v", ErrProcessingFatal, panicErr),
+				}
+			}
+		}()
+
+		start := time.Now()
+		err := h.processBundle(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *appBundleHandler) processBundle(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	size, err := input.Size()
+	if err != nil {
+		return fmt.Errorf("%w: error getting bundle size: %v", ErrProcessingFatal, err)
+	}
+	bundle, err := h.parse(input, size)
+	if err != nil {
+		ctx.Logger().V(4).Info("unable to parse app bundle, handling as regular file", "error", err)
+		if _, err := input.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("%w: error resetting reader: %v", ErrProcessingFatal, err)
+		}
+		return h.handleNonArchiveContent(ctx, newMimeTypeReaderFromFileReader(input), dataOrErrChan)
+	}
+	bundle.readManifest()
+
+	ctx = logContext.WithValues(ctx, "app_bundle", bundle.kind, "app_id", bundle.id)
+	for _, entry := range bundle.entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fileCtx := logContext.WithValues(ctx, "filename", entry.name, "size", entry.size)
+		if err := h.processEntry(fileCtx, bundle, entry, dataOrErrChan); err != nil {
+			if isFatal(err) {
+				return err
+			}
+			fileCtx.Logger().V(2).Info("failed to process packed file", "error", err)
+			h.metrics.incErrors()
+			continue
+		}
+		h.metrics.observeFileSize(entry.size)
+	}
+	return nil
+}
+
+// processEntry scans a packed file and annotates its chunks with the bundle and file they came from.
+func (h *appBundleHandler) processEntry(
+	ctx logContext.Context,
+	bundle *appBundle,
+	entry bundleEntry,
+	dataOrErrChan chan DataOrErr,
+) error {
+	if entry.size == 0 || common.SkipFile(entry.name) || common.IsBinary(entry.name) {
+		return nil
+	}
+	rc, err := entry.open()
+	if err != nil {
+		return fmt.Errorf("%w: error opening packed file: %v", ErrProcessingWarning, err)
+	}
+	defer rc.Close()
+
+	mimeReader, err := newMimeTypeReader(rc)
+	if err != nil {
+		return fmt.Errorf("%w: error creating mime-type reader: %v", ErrProcessingWarning, err)
+	}
+
+	annotations := bundle.annotations(entry.name)
+	fileChan := make(chan DataOrErr, defaultBufferSize)
+	errChan := make(chan error, 1)
+	go func() {
+		defer close(fileChan)
+		errChan <- h.handleNonArchiveContent(ctx, mimeReader, fileChan)
+	}()
+	for dataOrErr := range fileChan {
+		if dataOrErr.Err == nil {
+			dataOrErr.Annotations = annotations
+		}
+		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+			// Unblock the file's reader, which also stops on the cancelled context.
+			for range fileChan {
+			}
+			return err
+		}
+	}
+	return <-errChan
+}
+
+// annotations returns the annotations of chunks from a packed file.
+func (b *appBundle) annotations(file string) map[string]string {
+	annotations := map[string]string{
+		appBundleKey:     b.kind,
+		appBundleFileKey: file,
+	}
+	for key, value := range map[string]string{
+		appBundleIDKey:      b.id,
+		appBundleNameKey:    b.name,
+		appBundleVersionKey: b.version,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// readManifest attributes the bundle from the manifest.json of an extension or the package.json of an app. Electron
+// apps built with electron-builder record their application ID under build.appId.
+func (b *appBundle) readManifest() {
+	manifest := "manifest.json"
+	if b.kind == electronAppBundle {
+		manifest = "package.json"
+	}
+	for _, entry := range b.entries {
+		if entry.name != manifest || entry.size > maxBundleManifestSize {
+			continue
+		}
+		rc, err := entry.open()
+		if err != nil {
+			return
+		}
+		defer rc.Close()
+
+		var m struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Build   struct {
+				AppID string `json:"appId"`
+			} `json:"build"`
+		}
+		if err := json.NewDecoder(io.LimitReader(rc, maxBundleManifestSize)).Decode(&m); err != nil {
+			return
+		}
+		b.name, b.version = m.Name, m.Version
+		if b.id == "" {
+			b.id = m.Build.AppID
+		}
+		if b.id == "" && b.kind == electronAppBundle {
+			b.id = m.Name
+		}
+		return
+	}
+}
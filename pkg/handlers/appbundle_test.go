@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const testBundleJS = `const INFURA_KEY = "9aa3d95b3bc440fa88ea12eaa4456161";`
+
+var testCRXID = []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0xfe, 0xdc, 0xba, 0x98, 0x76, 0x54, 0x32, 0x10}
+
+// buildCRX3 packs files into a CRX3 file whose signed data records testCRXID.
+func buildCRX3(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	signedData := protowire.AppendTag(nil, crxIDField, protowire.BytesType)
+	signedData = protowire.AppendBytes(signedData, testCRXID)
+	header := protowire.AppendTag(nil, crxSignedDataField, protowire.BytesType)
+	header = protowire.AppendBytes(header, signedData)
+
+	crx := []byte(crxMagic)
+	crx = binary.LittleEndian.AppendUint32(crx, 3)
+	crx = binary.LittleEndian.AppendUint32(crx, uint32(len(header)))
+	crx = append(crx, header...)
+	return append(crx, archive.Bytes()...)
+}
+
+// buildASAR packs files, given by their path in the archive, into an ASAR archive.
+func buildASAR(t *testing.T, files [][2]string) []byte {
+	t.Helper()
+
+	root := map[string]any{}
+	var data []byte
+	for _, file := range files {
+		dir := root
+		parts := bytes.Split([]byte(file[0]), []byte("/"))
+		for _, part := range parts[:len(parts)-1] {
+			sub, ok := dir[string(part)].(map[string]any)
+			if !ok {
+				sub = map[string]any{"files": map[string]any{}}
+				dir[string(part)] = sub
+			}
+			dir = sub["files"].(map[string]any)
+		}
+		dir[string(parts[len(parts)-1])] = map[string]any{
+			"offset": strconv.Itoa(len(data)),
+			"size":   len(file[1]),
+		}
+		data = append(data, file[1]...)
+	}
+	index, err := json.Marshal(map[string]any{"files": root})
+	require.NoError(t, err)
+
+	// The index is pickled: the pickle payload size, the string length, and the string padded to 4 bytes.
+	padded := (len(index) + 3) &^ 3
+	asar := binary.LittleEndian.AppendUint32(nil, 4)
+	asar = binary.LittleEndian.AppendUint32(asar, uint32(8+padded))
+	asar = binary.LittleEndian.AppendUint32(asar, uint32(4+padded))
+	asar = binary.LittleEndian.AppendUint32(asar, uint32(len(index)))
+	asar = append(asar, index...)
+	asar = append(asar, make([]byte, padded-len(index))...)
+	return append(asar, data...)
+}
+
+func TestHandleCRXFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	crx := buildCRX3(t, map[string]string{
+		"manifest.json":    `{"name": "Example Wallet", "version": "1.2.3", "manifest_version": 3}`,
+		"js/background.js": testBundleJS,
+	})
+
+	rdr, err := newFileReader(ctx, bytes.NewReader(crx), withFileExtension(".crx"))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, crxHandlerType, rdr.handler)
+
+	var got []DataOrErr
+	for dataOrErr := range newCRXHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.Len(t, got, 2)
+
+	js := got[0]
+	if js.Annotations[appBundleFileKey] != "js/background.js" {
+		js = got[1]
+	}
+	assert.Equal(t, testBundleJS, string(js.Data))
+	assert.Equal(t, map[string]string{
+		appBundleKey:        chromeExtensionBundle,
+		appBundleIDKey:      "abcdefghijklmnopponmlkjihgfedcba",
+		appBundleNameKey:    "Example Wallet",
+		appBundleVersionKey: "1.2.3",
+		appBundleFileKey:    "js/background.js",
+	}, js.Annotations)
+}
+
+func TestHandleASARFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	asar := buildASAR(t, [][2]string{
+		{"package.json", `{"name": "trader", "version": "4.0.1", "build": {"appId": "com.example.trader"}}`},
+		{"dist/main.js", testBundleJS},
+		{"assets/logo.png", "\x89PNG\r\n\x1a\n"},
+	})
+
+	rdr, err := newFileReader(ctx, bytes.NewReader(asar), withFileExtension(asarExt))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, asarHandlerType, rdr.handler)
+
+	var got []DataOrErr
+	for dataOrErr := range newASARHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	// Packed files are scanned in path order, and images are skipped.
+	require.Len(t, got, 2)
+	assert.Equal(t, testBundleJS, string(got[0].Data))
+	assert.Equal(t, map[string]string{
+		appBundleKey:        electronAppBundle,
+		appBundleIDKey:      "com.example.trader",
+		appBundleNameKey:    "trader",
+		appBundleVersionKey: "4.0.1",
+		appBundleFileKey:    "dist/main.js",
+	}, got[0].Annotations)
+	assert.Equal(t, "package.json", got[1].Annotations[appBundleFileKey])
+}
+
+func TestIsASARFile(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		input []byte
+		ext   string
+		want  bool
+	}{
+		{name: "asar archive", input: buildASAR(t, [][2]string{{"index.js", testBundleJS}}), ext: asarExt, want: true},
+		{name: "asar archive without extension", input: buildASAR(t, [][2]string{{"index.js", testBundleJS}}), ext: ".bin"},
+		{name: "other file with extension", input: []byte("not an asar archive at all"), ext: asarExt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, err := newFileReader(ctx, bytes.NewReader(tt.input), withFileExtension(tt.ext))
+			require.NoError(t, err)
+			defer rdr.Close()
+			assert.Equal(t, tt.want, rdr.handler == asarHandlerType)
+		})
+	}
+}
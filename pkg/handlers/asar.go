@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+)
+
+const (
+	asarExt = ".asar"
+
+	// maxASARHeaderSize bounds the JSON index of an ASAR archive. Apps with large node_modules trees have indexes of
+	// a few megabytes.
+	maxASARHeaderSize = 64 << 20
+	// maxASARDepth bounds the nesting of directories in the index.
+	maxASARDepth = 64
+)
+
+// asarNode is a file or directory in the index of an ASAR archive.
+type asarNode struct {
+	Files map[string]*asarNode `json:"files"`
+	// Offset is relative to the end of the header. It is a string, as it may not fit in a double.
+	Offset   string      `json:"offset"`
+	Size     json.Number `json:"size"`
+	Unpacked bool        `json:"unpacked"`
+	Link     string      `json:"link"`
+}
+
+// parseASAR parses an Electron app archive: a pickled JSON index of the packed files, followed by their contents.
+// The index starts with the size of the pickle that holds it, which is always 4 bytes long.
+func parseASAR(r io.ReaderAt, size int64) (*appBundle, error) {
+	head := make([]byte, 16)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, fmt.Errorf("error reading ASAR header: %w", err)
+	}
+	if binary.LittleEndian.Uint32(head) != 4 {
+		return nil, errors.New("not an ASAR archive")
+	}
+	headerSize := int64(binary.LittleEndian.Uint32(head[4:]))
+	jsonLen := int64(binary.LittleEndian.Uint32(head[12:]))
+	if jsonLen > maxASARHeaderSize || 16+jsonLen > 8+headerSize || 8+headerSize > size {
+		return nil, errors.New("invalid ASAR header size")
+	}
+
+	index := make([]byte, jsonLen)
+	if _, err := r.ReadAt(index, 16); err != nil {
+		return nil, fmt.Errorf("error reading ASAR index: %w", err)
+	}
+	var root asarNode
+	if err := json.Unmarshal(index, &root); err != nil {
+		return nil, fmt.Errorf("error parsing ASAR index: %w", err)
+	}
+
+	bundle := &appBundle{kind: electronAppBundle}
+	dataOffset := 8 + headerSize
+	var walk func(dir string, node *asarNode, depth int)
+	walk = func(dir string, node *asarNode, depth int) {
+		if depth > maxASARDepth {
+			return
+		}
+		names := make([]string, 0, len(node.Files))
+		for name := range node.Files {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			child := node.Files[name]
+			if child == nil {
+				continue
+			}
+			name = path.Join(dir, name)
+			if child.Files != nil {
+				walk(name, child, depth+1)
+				continue
+			}
+			// Unpacked files live next to the archive, in app.asar.unpacked, and are scanned as regular files.
+			if child.Unpacked || child.Link != "" {
+				continue
+			}
+			offset, err := strconv.ParseInt(child.Offset, 10, 64)
+			if err != nil {
+				continue
+			}
+			fileSize, err := child.Size.Int64()
+			if err != nil || offset < 0 || fileSize < 0 || dataOffset+offset+fileSize > size {
+				continue
+			}
+			start := dataOffset + offset
+			bundle.entries = append(bundle.entries, bundleEntry{
+				name: name,
+				size: fileSize,
+				open: func() (io.ReadCloser, error) {
+					return io.NopCloser(io.NewSectionReader(r, start, fileSize)), nil
+				},
+			})
+		}
+	}
+	walk("", &root, 0)
+	return bundle, nil
+}
+
+// isASARFile reports whether a file is an ASAR archive, from its header. ASAR archives have no magic number, so only
+// files with the .asar extension are checked.
+func isASARFile(cfg readerConfig, head []byte) bool {
+	return cfg.fileExtension == asarExt && len(head) >= 17 && binary.LittleEndian.Uint32(head) == 4 && head[16] == '{'
+}
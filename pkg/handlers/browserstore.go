@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/browserstore"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// maxBrowserStoreSize is the largest SQLite database that is checked for being a browser store. Saved passwords
+// and cookies rarely take more than a few MB; larger databases are handled like any other file.
+const maxBrowserStoreSize = 256 * 1024 * 1024
+
+// browserStoreHandler handles SQLite databases. Databases in which browsers keep saved passwords or cookies are
+// reported as a rendered summary of the store and its entry count, which the browser store detector picks up.
+// Every database, browser store or not, is then scanned as it is, as before.
+type browserStoreHandler struct{ *defaultHandler }
+
+// newBrowserStoreHandler creates a browserStoreHandler.
+func newBrowserStoreHandler() *browserStoreHandler {
+	return &browserStoreHandler{defaultHandler: newDefaultHandler(browserStoreHandlerType)}
+}
+
+// HandleFile processes SQLite databases and returns a channel of DataOrErr.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the database
+func (h *browserStoreHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processDatabase(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *browserStoreHandler) processDatabase(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(io.LimitReader(input, maxBrowserStoreSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: error reading sqlite database: %v", ErrProcessingFatal, err)
+	}
+
+	if len(data) <= maxBrowserStoreSize {
+		store, err := browserstore.Identify(data)
+		switch {
+		case err != nil:
+			ctx.Logger().V(4).Info("unable to read sqlite database, handling as regular file", "error", err)
+		case store != nil:
+			dataOrErr := DataOrErr{Data: []byte(store.Render())}
+			select {
+			case dataOrErrChan <- dataOrErr:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return h.handleNonArchiveContent(ctx, mimeTypeReader{
+		mimeExt:  input.mime.Extension(),
+		mimeName: mimeType(input.mime.String()),
+		Reader:   io.MultiReader(bytes.NewReader(data), input),
+	}, dataOrErrChan)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestHandleBrowserStoreFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	file, err := os.Open("../browserstore/testdata/login_data.sqlite")
+	require.NoError(t, err)
+	defer file.Close()
+
+	rdr, err := newFileReader(ctx, file)
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, browserStoreHandlerType, rdr.handler)
+
+	var got []DataOrErr
+	for dataOrErr := range newBrowserStoreHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.NotEmpty(t, got)
+
+	assert.Equal(t, "# browser store: chromium passwords\ntable = \"logins\"\nentries = 3\n", string(got[0].Data))
+	// The database itself is still scanned as it is.
+	require.Len(t, got, 2)
+	assert.True(t, strings.HasPrefix(string(got[1].Data), "SQLite format 3\x00"))
+}
+
+func TestHandleSQLiteFile_NotBrowserStore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	file, err := os.Open("../browserstore/testdata/other.sqlite")
+	require.NoError(t, err)
+	defer file.Close()
+
+	rdr, err := newFileReader(ctx, file)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	var got []DataOrErr
+	for dataOrErr := range newBrowserStoreHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.Len(t, got, 1)
+	assert.NotContains(t, string(got[0].Data), "# browser store: ")
+}
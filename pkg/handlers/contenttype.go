@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// contentClass is the coarse kind of content of a file. It is determined once, from the magic bytes sniffed by the
+// mimetype package, the file header and the handler picked from it, and routes files the default handler would
+// otherwise read as flat text: JSON to a parser of its string values, PEM to a splitter of its blocks, and compiled
+// code to a pass over its printable strings. Images are left to the default handler, which skips them: secrets in them
+// are rendered in pixels, which only OCR could read.
+type contentClass string
+
+const (
+	textContent    contentClass = "text"
+	jsonContent    contentClass = "json"
+	pemContent     contentClass = "pem"
+	archiveContent contentClass = "archive"
+	sqliteContent  contentClass = "sqlite"
+	binaryContent  contentClass = "binary"
+)
+
+// compiledMimeTypes are executables, libraries and object files. Their MIME extension is not always one of
+// common.IsBinary's extensions, e.g. ELF files have none.
+var compiledMimeTypes = map[mimeType]struct{}{
+	"application/x-elf":                             {},
+	"application/x-executable":                      {},
+	"application/x-sharedlib":                       {},
+	"application/x-object":                          {},
+	"application/x-coredump":                        {},
+	"application/x-mach-binary":                     {},
+	"application/vnd.microsoft.portable-executable": {},
+	"application/x-java-applet":                     {},
+	"application/wasm":                              {},
+}
+
+// classifyContent classifies the content of a file routed to the given handler from its MIME type and header.
+// Generic archives are only identified afterwards, so the caller reclassifies those.
+func classifyContent(mime *mimetype.MIME, head []byte, handler handlerType) contentClass {
+	switch handler {
+	case arHandlerType, rpmHandlerType, apkHandlerType, crxHandlerType, asarHandlerType:
+		return archiveContent
+	case browserStoreHandlerType:
+		return sqliteContent
+	default:
+		return classify(mime, head)
+	}
+}
+
+// pemBoundary starts the encapsulation boundary of a PEM block.
+var pemBoundary = []byte("-----BEGIN ")
+
+// classify classifies content by its MIME type and header.
+func classify(mime *mimetype.MIME, head []byte) contentClass {
+	if hasAncestor(mime, jsonMime) {
+		return jsonContent
+	}
+	if hasAncestor(mime, "text/plain") {
+		if bytes.Contains(head, pemBoundary) {
+			return pemContent
+		}
+		return textContent
+	}
+	return binaryContent
+}
+
+// contentHandler returns the handler for a file of the given class and MIME type that no specialized handler was
+// picked for from its header.
+func contentHandler(class contentClass, mimeT mimeType) handlerType {
+	switch {
+	case class == jsonContent:
+		return jsonHandlerType
+	case class == pemContent:
+		return pemHandlerType
+	case class == binaryContent && isCompiled(mimeT):
+		// Compiled code can't be read as text, but its printable strings can.
+		return stringsHandlerType
+	default:
+		return defaultHandlerType
+	}
+}
+
+// hasAncestor reports whether mime is the given MIME type or one of its descendants, e.g. application/json for
+// text/plain.
+func hasAncestor(mime *mimetype.MIME, ancestor mimeType) bool {
+	for m := mime; m != nil; m = m.Parent() {
+		if m.Is(string(ancestor)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompiled reports whether a file of the given MIME type is compiled code, which the default handler skips as
+// it cannot be read as text.
+func isCompiled(mimeT mimeType) bool {
+	if _, ok := compiledMimeTypes[mimeT]; ok {
+		return true
+	}
+	if mime := mimetype.Lookup(string(mimeT)); mime != nil {
+		return common.IsBinary(mime.Extension())
+	}
+	return false
+}
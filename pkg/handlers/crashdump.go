@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// Annotation keys set on chunks of a crash dump.
+const (
+	crashDumpFormatKey  = "crash_dump_format"
+	crashDumpSectionKey = "crash_dump_section"
+)
+
+// Crash dump formats.
+const (
+	elfCoreFormat   = "elf_core"
+	machOCoreFormat = "macho_core"
+	minidumpFormat  = "minidump"
+)
+
+// Sections of a crash dump that are reported separately.
+const (
+	crashDumpEnvironment = "environment"
+	crashDumpStrings     = "strings"
+)
+
+const (
+	// minEnvBlockVars is the fewest consecutive variables that make up an environment block. Fewer could be any
+	// NUL-separated strings that happen to contain an equals sign.
+	minEnvBlockVars = 3
+	// maxEnvVarLength bounds a single environment variable.
+	maxEnvVarLength = 32 * 1024
+	// maxEnvVars bounds the distinct environment variables collected from a dump.
+	maxEnvVars = 8192
+)
+
+// crashDumpHandler handles core files and minidumps. A crashing process dumps its memory, including its environment,
+// and these dumps end up attached to issues and uploaded to buckets. Besides the printable strings of the dump, which
+// are scanned like those of any binary, the environment blocks found in it are scanned as `NAME=value` lines, once per
+// distinct variable, so that detectors see the variable names, like PRIVATE_KEY or SECRET_KEY, next to their values.
+type crashDumpHandler struct{ *defaultHandler }
+
+// newCrashDumpHandler creates a crashDumpHandler.
+func newCrashDumpHandler() *crashDumpHandler {
+	return &crashDumpHandler{defaultHandler: newDefaultHandler(crashDumpHandlerType)}
+}
+
+// HandleFile processes crash dumps and returns a channel of DataOrErr.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the dump
+//
+// Non-fatal errors that will be logged but allow processing to continue include:
+// - Errors reading individual chunks from the input (wrapped as ErrProcessingWarning)
+func (h *crashDumpHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processDump(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *crashDumpHandler) processDump(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	env, err := extractEnvironment(input)
+	if err != nil {
+		return fmt.Errorf("%w: error reading crash dump: %v", ErrProcessingFatal, err)
+	}
+	if _, err := input.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("%w: error resetting reader after environment extraction: %v", ErrProcessingFatal, err)
+	}
+
+	if len(env) > 0 {
+		if err := h.handleSection(ctx, bytes.NewReader(env), input.crashDumpFormat, crashDumpEnvironment, dataOrErrChan); err != nil {
+			return err
+		}
+	}
+	// Line numbers are meaningless for binary content, so none are reported.
+	return h.handleSection(ctx, newStringsReader(input), input.crashDumpFormat, crashDumpStrings, dataOrErrChan)
+}
+
+// handleSection chunks a section of the dump and annotates its chunks with the dump format and section.
+func (h *crashDumpHandler) handleSection(ctx logContext.Context, r io.Reader, format, section string, dataOrErrChan chan DataOrErr) error {
+	for chunkResult := range h.chunkReader(ctx, r) {
+		dataOrErr := DataOrErr{}
+		if err := chunkResult.Error(); err != nil {
+			h.metrics.incErrors()
+			dataOrErr.Err = fmt.Errorf("%w: error reading chunk: %v", ErrProcessingWarning, err)
+			if writeErr := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); writeErr != nil {
+				return fmt.Errorf("%w: error writing to data channel: %v", ErrProcessingFatal, writeErr)
+			}
+			continue
+		}
+
+		dataOrErr.Data = chunkResult.Bytes()
+		dataOrErr.Annotations = map[string]string{
+			crashDumpFormatKey:  format,
+			crashDumpSectionKey: section,
+		}
+		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+			return err
+		}
+		h.metrics.incBytesProcessed(len(dataOrErr.Data))
+	}
+	return nil
+}
+
+// extractEnvironment returns the distinct variables of the environment blocks in a dump, one `NAME=value` line each,
+// in the order they were first found. Environment blocks are runs of NUL-terminated variables, in ASCII on Unix
+// stacks and in UTF-16LE in Windows process environment blocks.
+func extractEnvironment(r io.Reader) ([]byte, error) {
+	var out bytes.Buffer
+	seen := make(map[string]struct{})
+	emit := func(block [][]byte) {
+		for _, v := range block {
+			if len(seen) >= maxEnvVars {
+				return
+			}
+			if _, ok := seen[string(v)]; ok {
+				continue
+			}
+			seen[string(v)] = struct{}{}
+			out.Write(v)
+			out.WriteByte('\n')
+		}
+	}
+
+	ascii := envBlockScanner{emit: emit}
+	// UTF-16LE strings are scanned at both alignments.
+	wide := [2]envBlockScanner{{emit: emit}, {emit: emit}}
+	var lo [2]byte
+
+	src := bufio.NewReader(r)
+	buf := make([]byte, 32*1024)
+	var offset int
+	for {
+		n, err := src.Read(buf)
+		for _, b := range buf[:n] {
+			ascii.add(uint16(b))
+			align := offset % 2
+			if offset >= 1 {
+				// The character that ends at this byte started at the previous offset, which has the other alignment.
+				wide[1-align].add(uint16(lo[1-align]) | uint16(b)<<8)
+			}
+			lo[align] = b
+			offset++
+		}
+		if err == io.EOF {
+			ascii.endBlock()
+			wide[0].endBlock()
+			wide[1].endBlock()
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// envBlockScanner finds environment blocks in a stream of characters.
+type envBlockScanner struct {
+	// cur is the string read since the last NUL.
+	cur []byte
+	// overflow is set if cur grew too long to be a variable.
+	overflow bool
+	// block holds the variables of the current block.
+	block [][]byte
+	emit  func(block [][]byte)
+}
+
+func (s *envBlockScanner) add(c uint16) {
+	switch {
+	case c == 0:
+		switch {
+		case len(s.cur) == 0 || s.overflow || !isEnvVar(s.cur):
+			// A double NUL terminates Windows environment blocks, and anything but a variable ends a block.
+			s.endBlock()
+		default:
+			s.block = append(s.block, bytes.Clone(s.cur))
+		}
+		s.cur, s.overflow = s.cur[:0], false
+	case c < 0x80 && (c >= 0x20 && c < 0x7f || c == '\t'):
+		if len(s.cur) >= maxEnvVarLength {
+			s.overflow = true
+			return
+		}
+		s.cur = append(s.cur, byte(c))
+	default:
+		s.cur, s.overflow = s.cur[:0], false
+		s.endBlock()
+	}
+}
+
+func (s *envBlockScanner) endBlock() {
+	if len(s.block) >= minEnvBlockVars {
+		s.emit(s.block)
+	}
+	s.block = s.block[:0]
+}
+
+// isEnvVar reports whether s looks like a `NAME=value` environment variable. Windows keeps the working directory of
+// each drive in variables whose name starts with an equals sign, like `=C:=C:\app`, so the name may start with one.
+func isEnvVar(s []byte) bool {
+	i := bytes.IndexByte(s[1:], '=') + 1
+	if i <= 0 {
+		return false
+	}
+	if c := s[0]; !(c == '_' || c == '=' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+		return false
+	}
+	for _, c := range s[1:i] {
+		if c == ' ' || c == '\t' || c == '"' || c == '\'' || c == '=' {
+			return false
+		}
+	}
+	return true
+}
+
+// crashDumpFormat returns the format of a core file or minidump from its first bytes, or "" if it is neither.
+func crashDumpFormat(head []byte) string {
+	switch {
+	case len(head) >= 8 && bytes.HasPrefix(head, []byte("MDMP")) && binary.LittleEndian.Uint16(head[4:6]) == 0xa793:
+		// MINIDUMP_HEADER: the signature, then the version, whose low word is MINIDUMP_VERSION.
+		return minidumpFormat
+	case len(head) >= 18 && bytes.HasPrefix(head, []byte("\x7fELF")):
+		// e_type is ET_CORE, in the byte order given by EI_DATA.
+		var order binary.ByteOrder = binary.LittleEndian
+		if head[5] == 2 {
+			order = binary.BigEndian
+		}
+		if order.Uint16(head[16:18]) == 4 {
+			return elfCoreFormat
+		}
+	case len(head) >= 16 && (bytes.HasPrefix(head, []byte{0xcf, 0xfa, 0xed, 0xfe}) || bytes.HasPrefix(head, []byte{0xce, 0xfa, 0xed, 0xfe})):
+		// A little-endian Mach-O file whose filetype is MH_CORE.
+		if binary.LittleEndian.Uint32(head[12:16]) == 4 {
+			return machOCoreFormat
+		}
+	}
+	return ""
+}
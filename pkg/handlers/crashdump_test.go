@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// elfCore returns a little-endian ELF core file header followed by data.
+func elfCore(data []byte) []byte {
+	header := make([]byte, 64)
+	copy(header, "\x7fELF\x02\x01\x01")
+	binary.LittleEndian.PutUint16(header[16:], 4) // ET_CORE
+	binary.LittleEndian.PutUint16(header[18:], 62)
+	return append(header, data...)
+}
+
+// utf16le encodes s as UTF-16LE.
+func utf16le(s string) []byte {
+	var out []byte
+	for _, c := range utf16.Encode([]rune(s)) {
+		out = binary.LittleEndian.AppendUint16(out, c)
+	}
+	return out
+}
+
+func TestHandleCrashDumpFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// The stack of a Linux process: argv, then envp, then the executable's path, between non-printable data.
+	stack := "\x01\x02\x03/usr/bin/billing\x00--port=8080\x00" +
+		"PATH=/usr/bin:/bin\x00HOME=/root\x00AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\x00LANG=C\x00" +
+		"/usr/bin/billing\x00\x00\xff\xfe"
+	// The same environment again, as in the copy a crashing process keeps on its heap, is only reported once.
+	data := elfCore([]byte(stack + "\x90\x90" + stack))
+
+	rdr, err := newFileReader(ctx, bytes.NewReader(data))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, elfCoreFormat, rdr.crashDumpFormat)
+
+	var got []DataOrErr
+	for dataOrErr := range newCrashDumpHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "PATH=/usr/bin:/bin\n"+
+		"HOME=/root\n"+
+		"AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\n"+
+		"LANG=C\n", string(got[0].Data))
+	assert.Equal(t, map[string]string{crashDumpFormatKey: elfCoreFormat, crashDumpSectionKey: crashDumpEnvironment}, got[0].Annotations)
+
+	assert.Contains(t, string(got[1].Data), "/usr/bin/billing\n")
+	assert.Equal(t, crashDumpStrings, got[1].Annotations[crashDumpSectionKey])
+}
+
+func TestExtractEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{
+			name:  "windows environment block",
+			input: append([]byte{0x00, 0x13, 0x37}, utf16le("=C:=C:\\app\x00ALLUSERSPROFILE=C:\\ProgramData\x00API_TOKEN=ghp_0123456789abcdef\x00OS=Windows_NT\x00\x00")...),
+			want:  "=C:=C:\\app\nALLUSERSPROFILE=C:\\ProgramData\nAPI_TOKEN=ghp_0123456789abcdef\nOS=Windows_NT\n",
+		},
+		{
+			name:  "too few variables",
+			input: []byte("\x00a=1\x00b=2\x00\x00"),
+			want:  "",
+		},
+		{
+			name:  "not variables",
+			input: []byte("\x00SELECT 1 WHERE a = b\x00x == y\x001=2\x00'a'=b\x00\x00"),
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractEnvironment(bytes.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCrashDumpFormat(t *testing.T) {
+	minidump := []byte("MDMP\x93\xa7\x00\x00\x0d\x00\x00\x00")
+	machOCore := []byte{0xcf, 0xfa, 0xed, 0xfe, 0x0c, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00}
+	elfExecutable := elfCore(nil)
+	elfExecutable[16] = 2 // ET_EXEC
+
+	assert.Equal(t, elfCoreFormat, crashDumpFormat(elfCore(nil)))
+	assert.Equal(t, minidumpFormat, crashDumpFormat(minidump))
+	assert.Equal(t, machOCoreFormat, crashDumpFormat(machOCore))
+	assert.Empty(t, crashDumpFormat(elfExecutable))
+	assert.Empty(t, crashDumpFormat([]byte(strings.Repeat("MDMP", 4))))
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	crxMagic = "Cr24"
+
+	// Fields of the CRX3 header, see
+	// https://chromium.googlesource.com/chromium/src/+/main/components/crx_file/crx3.proto
+	crxSHA256WithRSAField = 2
+	crxSignedDataField    = 10000
+	crxPublicKeyField     = 1
+	crxIDField            = 1
+
+	// maxCRXHeaderSize bounds the header of a CRX file, which only holds keys and signatures.
+	maxCRXHeaderSize = 1 << 20
+)
+
+// parseCRX parses a packed Chrome extension: a header with the extension's public keys and signatures, followed by
+// a ZIP archive of its files. The extension ID is derived from the header.
+func parseCRX(r io.ReaderAt, size int64) (*appBundle, error) {
+	head := make([]byte, 16)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, fmt.Errorf("error reading CRX header: %w", err)
+	}
+	if string(head[:4]) != crxMagic {
+		return nil, errors.New("not a CRX file")
+	}
+
+	var (
+		id        string
+		zipOffset int64
+	)
+	switch version := binary.LittleEndian.Uint32(head[4:]); version {
+	case 2:
+		keyLen, sigLen := int64(binary.LittleEndian.Uint32(head[8:])), int64(binary.LittleEndian.Uint32(head[12:]))
+		if keyLen > maxCRXHeaderSize || sigLen > maxCRXHeaderSize {
+			return nil, errors.New("CRX header too large")
+		}
+		key := make([]byte, keyLen)
+		if _, err := r.ReadAt(key, 16); err != nil {
+			return nil, fmt.Errorf("error reading CRX public key: %w", err)
+		}
+		id = crxIDFromKey(key)
+		zipOffset = 16 + keyLen + sigLen
+	case 3:
+		headerLen := int64(binary.LittleEndian.Uint32(head[8:]))
+		if headerLen > maxCRXHeaderSize {
+			return nil, errors.New("CRX header too large")
+		}
+		header := make([]byte, headerLen)
+		if _, err := r.ReadAt(header, 12); err != nil {
+			return nil, fmt.Errorf("error reading CRX header: %w", err)
+		}
+		id = crx3ID(header)
+		zipOffset = 12 + headerLen
+	default:
+		return nil, fmt.Errorf("unsupported CRX version %d", version)
+	}
+	if zipOffset >= size {
+		return nil, errors.New("CRX file has no archive")
+	}
+
+	zipReader, err := zip.NewReader(io.NewSectionReader(r, zipOffset, size-zipOffset), size-zipOffset)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CRX archive: %w", err)
+	}
+	bundle := &appBundle{kind: chromeExtensionBundle, id: id}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		bundle.entries = append(bundle.entries, bundleEntry{
+			name: file.Name,
+			size: int64(file.UncompressedSize64),
+			open: file.Open,
+		})
+	}
+	return bundle, nil
+}
+
+// isCRXFile reports whether a file is a packed Chrome extension, from its header. The mimetype package only
+// recognizes the CRX2 header, while extensions are packed as CRX3 since Chrome 64.
+func isCRXFile(head []byte) bool {
+	if len(head) < 8 || string(head[:4]) != crxMagic {
+		return false
+	}
+	version := binary.LittleEndian.Uint32(head[4:])
+	return version == 2 || version == 3
+}
+
+// crx3ID returns the extension ID recorded in the signed data of a CRX3 header, or derived from its first RSA key.
+func crx3ID(header []byte) string {
+	var signedData, firstKey []byte
+	forEachProtoBytes(header, func(num protowire.Number, value []byte) {
+		switch {
+		case num == crxSignedDataField:
+			signedData = value
+		case num == crxSHA256WithRSAField && firstKey == nil:
+			forEachProtoBytes(value, func(num protowire.Number, value []byte) {
+				if num == crxPublicKeyField {
+					firstKey = value
+				}
+			})
+		}
+	})
+
+	var id []byte
+	forEachProtoBytes(signedData, func(num protowire.Number, value []byte) {
+		if num == crxIDField {
+			id = value
+		}
+	})
+	if len(id) == 16 {
+		return crxIDString(id)
+	}
+	if firstKey != nil {
+		return crxIDFromKey(firstKey)
+	}
+	return ""
+}
+
+// forEachProtoBytes calls fn for each length-delimited field of a serialized protobuf message, and stops at the
+// first malformed field.
+func forEachProtoBytes(msg []byte, fn func(protowire.Number, []byte)) {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return
+		}
+		msg = msg[n:]
+		if typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(msg)
+			if n < 0 {
+				return
+			}
+			fn(num, value)
+			msg = msg[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, msg)
+		if n < 0 {
+			return
+		}
+		msg = msg[n:]
+	}
+}
+
+// crxIDFromKey derives an extension ID from its public key: the first 128 bits of its SHA-256.
+func crxIDFromKey(key []byte) string {
+	sum := sha256.Sum256(key)
+	return crxIDString(sum[:16])
+}
+
+// crxIDString encodes an extension ID the way Chrome displays it, one letter from a to p per nibble.
+func crxIDString(id []byte) string {
+	out := make([]byte, 0, len(id)*2)
+	for _, b := range id {
+		out = append(out, 'a'+b>>4, 'a'+b&0x0f)
+	}
+	return string(out)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/mholt/archives"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/browserstore"
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
@@ -39,6 +40,15 @@ type fileReader struct {
 	format           archives.Format
 	mime             *mimetype.MIME
 	isGenericArchive bool
+	// handler is the type of the handler the file is routed to.
+	handler handlerType
+	// llmConfigName is the name of an LLM application config, like .env.example or config.yaml, or empty for other
+	// files.
+	llmConfigName string
+	// crashDumpFormat is the format of a core file or minidump, or empty for other files.
+	crashDumpFormat string
+	// class is the kind of content, which routes the file to a handler.
+	class contentClass
 
 	*iobuf.BufferedReadSeeker
 }
@@ -54,7 +64,10 @@ var (
 	ErrProcessingWarning = errors.New("error processing file")
 )
 
-type readerConfig struct{ fileExtension string }
+type readerConfig struct {
+	fileExtension string
+	fileName      string
+}
 
 type readerOption func(*readerConfig)
 
@@ -62,6 +75,10 @@ func withFileExtension(ext string) readerOption {
 	return func(c *readerConfig) { c.fileExtension = ext }
 }
 
+func withFileName(name string) readerOption {
+	return func(c *readerConfig) { c.fileName = name }
+}
+
 // mimeTypeReader wraps an io.Reader with MIME type information.
 // This type is used to pass content through the processing pipeline
 // while carrying its detected MIME type, avoiding redundant type detection.
@@ -147,6 +164,29 @@ func newFileReader(ctx context.Context, r io.Reader, options ...readerOption) (f
 		}
 	}
 
+	// Read the header once; it is all that picking a handler and classifying the content need.
+	head := make([]byte, headerSniffLen)
+	n, err := io.ReadFull(fReader, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fReader, fmt.Errorf("error reading file header: %w", err)
+	}
+	if _, err = fReader.Seek(0, io.SeekStart); err != nil {
+		return fReader, fmt.Errorf("error resetting reader after reading file header: %w", err)
+	}
+	head = head[:n]
+
+	fReader.handler = sniffHandler(cfg, mime, head)
+	switch fReader.handler {
+	case llmConfigHandlerType:
+		fReader.llmConfigName = cfg.fileName
+	case crashDumpHandlerType:
+		fReader.crashDumpFormat = crashDumpFormat(head)
+	}
+	fReader.class = classifyContent(mime, head, fReader.handler)
+	if fReader.handler == defaultHandlerType {
+		fReader.handler = contentHandler(fReader.class, mimeType(mime.String()))
+	}
+
 	// If a MIME type is known to not be an archive type, we might as well return here rather than
 	// paying the I/O penalty of an archiver.Identify() call that won't identify anything.
 	if _, ok := skipArchiverMimeTypes[mimeType(mime.String())]; ok {
@@ -159,6 +199,10 @@ func newFileReader(ctx context.Context, r io.Reader, options ...readerOption) (f
 	case err == nil:
 		fReader.isGenericArchive = true
 		fReader.format = format
+		fReader.class = archiveContent
+		if fReader.handler == defaultHandlerType || fReader.handler == stringsHandlerType {
+			fReader.handler = archiveHandlerType
+		}
 
 	case errors.Is(err, archives.NoMatch):
 		// Not an archive handled by archiver.
@@ -187,6 +231,9 @@ type DataOrErr struct {
 	Err  error
 	// LineNumber is the 1-indexed starting line of this data within the file.
 	LineNumber int64
+	// Annotations describe where within the file the data came from, e.g. a notebook cell. They are set on the
+	// chunk and reported with its findings.
+	Annotations map[string]string
 }
 
 // FileHandler represents a handler for files.
@@ -219,12 +266,24 @@ func WithSkipArchives(skip bool) func(*fileHandlingConfig) {
 type handlerType string
 
 const (
-	archiveHandlerType handlerType = "archive"
-	arHandlerType      handlerType = "ar"
-	rpmHandlerType     handlerType = "rpm"
-	apkHandlerType     handlerType = "apk"
-	defaultHandlerType handlerType = "default"
-	apkExt                         = ".apk"
+	archiveHandlerType      handlerType = "archive"
+	arHandlerType           handlerType = "ar"
+	rpmHandlerType          handlerType = "rpm"
+	apkHandlerType          handlerType = "apk"
+	sourceMapHandlerType    handlerType = "sourcemap"
+	tfstateHandlerType      handlerType = "tfstate"
+	notebookHandlerType     handlerType = "notebook"
+	sqlDumpHandlerType      handlerType = "sqldump"
+	llmConfigHandlerType    handlerType = "llmconfig"
+	browserStoreHandlerType handlerType = "browserstore"
+	crxHandlerType          handlerType = "crx"
+	asarHandlerType         handlerType = "asar"
+	stringsHandlerType      handlerType = "strings"
+	jsonHandlerType         handlerType = "json"
+	pemHandlerType          handlerType = "pem"
+	crashDumpHandlerType    handlerType = "crashdump"
+	defaultHandlerType      handlerType = "default"
+	apkExt                              = ".apk"
 )
 
 type mimeType string
@@ -303,27 +362,103 @@ var skipArchiverMimeTypes = map[mimeType]struct{}{
 	docMime:      {},
 }
 
-// selectHandler dynamically selects and configures a FileHandler based on the provided |mimetype| type and archive flag.
-// The fileReader contains information about the MIME type and whether the file is an archive.
+// headerSniffLen is the number of bytes read from the start of a file to pick its handler. Most formats are told
+// apart by their first few hundred bytes, but provider variables are often preceded by many unrelated ones in the
+// .env files of LLM applications.
+const headerSniffLen = 4096
+
+// sniffHandler picks the type of handler for a file from its name, its MIME type and its header. Formats needing a
+// specialized handler are checked in order of precedence; other files are routed to the default handler, which the
+// caller may still replace with the archive or strings handler.
+func sniffHandler(cfg readerConfig, mime *mimetype.MIME, head []byte) handlerType {
+	mimeT := mimeType(mime.String())
+	switch {
+	case isSourceMap(cfg, mimeT, head):
+		return sourceMapHandlerType
+	case isTFState(cfg, mimeT, head):
+		return tfstateHandlerType
+	case isNotebook(cfg, mimeT, head):
+		return notebookHandlerType
+	case isSQLDump(cfg, mimeT, head):
+		return sqlDumpHandlerType
+	case isLLMConfig(cfg, mimeT, head):
+		return llmConfigHandlerType
+	case browserstore.Sniff(head):
+		return browserStoreHandlerType
+	case isCRXFile(head):
+		return crxHandlerType
+	case isASARFile(cfg, head):
+		return asarHandlerType
+	case crashDumpFormat(head) != "":
+		return crashDumpHandlerType
+	}
+
+	switch mimeT {
+	case arMime, unixArMime, debMime:
+		return arHandlerType
+	case rpmMime, cpioMime:
+		return rpmHandlerType
+	case apkMime:
+		return apkHandlerType
+	default:
+		return defaultHandlerType
+	}
+}
+
+// selectHandler returns a FileHandler of the given type.
 // This method uses specialized handlers for specific file types:
 // - arHandler is used for Unix archives and Debian packages ('arMime', 'unixArMime', and 'debMime').
 // - rpmHandler is used for RPM and CPIO archives ('rpmMime' and 'cpioMime').
 // - apkHandler is used for APK archives ('apkMime').
 // - archiveHandler is used for common archive formats supported by the archiver library (.zip, .tar, .gz, etc.).
-// - defaultHandler is used for non-archive files.
+// - sourceMapHandler is used for JavaScript source maps.
+// - tfstateHandler is used for Terraform state files.
+// - notebookHandler is used for Jupyter notebooks.
+// - sqlDumpHandler is used for SQL dumps.
+// - llmConfigHandler is used for LLM application configs.
+// - browserStoreHandler is used for SQLite databases.
+// - appBundleHandler is used for packed Chrome extensions and Electron app archives.
+// - crashDumpHandler is used for core files and minidumps.
+// - stringsHandler is used for compiled code, whose printable strings are scanned.
+// - jsonHandler is used for other JSON files.
+// - pemHandler is used for other files starting with PEM blocks.
+// - defaultHandler is used for other non-archive files: text and binary documents.
 // The selected handler is then returned, ready to handle the file according to its specific format and requirements.
-func selectHandler(mimeT mimeType, isGenericArchive bool) FileHandler {
-	switch mimeT {
-	case arMime, unixArMime, debMime:
+func selectHandler(handler handlerType) FileHandler {
+	switch handler {
+	case arHandlerType:
 		return newARHandler()
-	case rpmMime, cpioMime:
+	case rpmHandlerType:
 		return newRPMHandler()
-	case apkMime:
+	case apkHandlerType:
 		return newAPKHandler()
+	case archiveHandlerType:
+		return newArchiveHandler()
+	case sourceMapHandlerType:
+		return newSourceMapHandler()
+	case tfstateHandlerType:
+		return newTFStateHandler()
+	case notebookHandlerType:
+		return newNotebookHandler()
+	case sqlDumpHandlerType:
+		return newSQLDumpHandler()
+	case llmConfigHandlerType:
+		return newLLMConfigHandler()
+	case browserStoreHandlerType:
+		return newBrowserStoreHandler()
+	case crxHandlerType:
+		return newCRXHandler()
+	case asarHandlerType:
+		return newASARHandler()
+	case crashDumpHandlerType:
+		return newCrashDumpHandler()
+	case stringsHandlerType:
+		return newStringsHandler()
+	case jsonHandlerType:
+		return newJSONHandler()
+	case pemHandlerType:
+		return newPEMHandler()
 	default:
-		if isGenericArchive {
-			return newArchiveHandler()
-		}
 		return newDefaultHandler(defaultHandlerType)
 	}
 }
@@ -359,8 +494,8 @@ func HandleFile(
 		return errors.New("reader is nil")
 	}
 
-	readerOption := withFileExtension(getFileExtension(chunkSkel))
-	rdr, err := newFileReader(ctx, reader, readerOption)
+	fileName := getFileName(chunkSkel)
+	rdr, err := newFileReader(ctx, reader, withFileExtension(filepath.Ext(fileName)), withFileName(filepath.Base(fileName)))
 	if err != nil {
 		if errors.Is(err, ErrEmptyReader) {
 			ctx.Logger().V(5).Info("empty reader, skipping file")
@@ -379,11 +514,11 @@ func HandleFile(
 		}
 	}()
 
-	ctx = logContext.WithValues(ctx, "mime", rdr.mime.String())
+	ctx = logContext.WithValues(ctx, "mime", rdr.mime.String(), "content", rdr.class)
 
 	mimeT := mimeType(rdr.mime.String())
 	config := newFileHandlingConfig(options...)
-	if config.skipArchives && rdr.isGenericArchive {
+	if config.skipArchives && (rdr.isGenericArchive || rdr.handler == crxHandlerType || rdr.handler == asarHandlerType) {
 		ctx.Logger().V(5).Info("skipping archive file", "mime", mimeT)
 		return nil
 	}
@@ -391,7 +526,7 @@ func HandleFile(
 	processingCtx, cancel := logContext.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
-	handler := selectHandler(mimeT, rdr.isGenericArchive)
+	handler := selectHandler(rdr.handler)
 	dataOrErrChan := handler.HandleFile(processingCtx, rdr) // Delegate to the specific handler to process the file.
 
 	return handleChunksWithError(processingCtx, dataOrErrChan, chunkSkel, reporter)
@@ -432,6 +567,7 @@ func handleChunksWithError(
 				chunk := *chunkSkel
 				chunk.Data = dataOrErr.Data
 				populateChunkLineNumber(&chunk, dataOrErr.LineNumber)
+				chunk.Annotations = dataOrErr.Annotations
 
 				if err := reporter.ChunkOk(ctx, chunk); err != nil {
 					return fmt.Errorf("error reporting chunk: %w", err)
@@ -492,11 +628,11 @@ func isFatal(err error) bool {
 	}
 }
 
-// getFileExtension extracts the file extension from the chunk's SourceMetadata.
+// getFileName extracts the file name, or the link for sources without files, from the chunk's SourceMetadata.
 // It considers all sources defined in the MetaData message.
 // Note: Probably should add this as a method to the source_metadatapb object.
-// then it'd just be chunkSkel.SourceMetadata.GetFileExtension()
-func getFileExtension(chunkSkel *sources.Chunk) string {
+// then it'd just be chunkSkel.SourceMetadata.GetFileName()
+func getFileName(chunkSkel *sources.Chunk) string {
 	if chunkSkel == nil || chunkSkel.SourceMetadata == nil {
 		return ""
 	}
@@ -565,9 +701,7 @@ func getFileExtension(chunkSkel *sources.Chunk) string {
 		return ""
 	}
 
-	// Use filepath.Ext to extract the file extension from the file name
-	ext := filepath.Ext(fileName)
-	return ext
+	return fileName
 }
 
 // shouldHandleAsAPK checks if the file should be handled as an APK based on config and MIME type.
@@ -612,6 +746,8 @@ func handleAPKFile(fReader *fileReader) (fileReader, error) {
 	// Extend the MIME type to recognize APK files
 	mimetype.Lookup("application/zip").Extend(func(r []byte, l uint32) bool { return false }, string(apkMime), ".apk")
 	fReader.mime = mimetype.Lookup(string(apkMime))
+	fReader.handler = apkHandlerType
+	fReader.class = archiveContent
 
 	// Reset reader for further handling
 	if _, err := fReader.Seek(0, io.SeekStart); err != nil {
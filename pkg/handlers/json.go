@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// jsonPathKey is the annotation key of the path of a JSON string value, like credentials.private_key.
+const jsonPathKey = "json_path"
+
+// maxStructuredJSONSize bounds the size of a JSON file whose string values are parsed. Larger files are only scanned
+// as text, so a huge export is never held in memory twice.
+const maxStructuredJSONSize = 64 * 1024 * 1024
+
+// maxEmbeddedJSONDepth bounds how deep JSON documents stored as string values of other documents are parsed.
+const maxEmbeddedJSONDepth = 3
+
+// jsonHandler handles JSON files. The file is scanned as text, and each string value that is escaped in the file,
+// like a private key with \n line breaks or a URL with \/ slashes, is scanned again decoded. String values that are
+// themselves JSON documents are parsed the same way. Decoded values are labeled with their path in the document.
+type jsonHandler struct{ *defaultHandler }
+
+// newJSONHandler creates a jsonHandler.
+func newJSONHandler() *jsonHandler {
+	return &jsonHandler{defaultHandler: newDefaultHandler(jsonHandlerType)}
+}
+
+// HandleFile processes JSON files and returns a channel of DataOrErr. Files that cannot be parsed are handled like
+// any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the file
+func (h *jsonHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processJSON(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *jsonHandler) processJSON(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(io.LimitReader(input, maxStructuredJSONSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: error reading JSON: %v", ErrProcessingFatal, err)
+	}
+
+	text := mimeTypeReader{
+		mimeExt:  input.mime.Extension(),
+		mimeName: mimeType(input.mime.String()),
+		Reader:   io.MultiReader(bytes.NewReader(data), input),
+	}
+	if err := h.handleNonArchiveContent(ctx, text, dataOrErrChan); err != nil {
+		return err
+	}
+	if len(data) > maxStructuredJSONSize {
+		ctx.Logger().V(4).Info("JSON file too large to parse, handled as regular file")
+		return nil
+	}
+
+	err = walkJSONStrings(data, func(v jsonString) error {
+		var buf bytes.Buffer
+		if v.path != "" {
+			buf.WriteString(v.path)
+			buf.WriteString(": ")
+		}
+		buf.WriteString(v.value)
+		buf.WriteByte('\n')
+		select {
+		case dataOrErrChan <- DataOrErr{
+			Data:        buf.Bytes(),
+			LineNumber:  v.line,
+			Annotations: map[string]string{jsonPathKey: v.path},
+		}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		ctx.Logger().V(4).Info("unable to parse JSON, handled as regular file", "error", err)
+		return nil
+	}
+	return err
+}
+
+// jsonString is a string value of a JSON document that is worth scanning decoded.
+type jsonString struct {
+	path  string
+	value string
+	// line is the 1-indexed line of the value in the file.
+	line int64
+}
+
+// walkJSONStrings calls fn for each string value of the JSON document in data that is escaped in data, and for
+// each such value of the documents stored as string values, in document order.
+func walkJSONStrings(data []byte, fn func(jsonString) error) error {
+	return walkJSON(data, "", 0, nil, fn)
+}
+
+// walkJSON walks the JSON document in data, whose values have paths starting with prefix. lineOf is nil for the
+// top-level document; values of a document embedded in a string value are reported at the line of that value.
+func walkJSON(data []byte, prefix string, depth int, lineOf func() int64, fn func(jsonString) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// Lines are counted incrementally as the decoder moves forward.
+	var (
+		line    int64 = 1
+		counted int64
+	)
+	currentLine := func() int64 {
+		if lineOf != nil {
+			return lineOf()
+		}
+		offset := dec.InputOffset()
+		line += int64(bytes.Count(data[counted:offset], []byte{'\n'}))
+		counted = offset
+		return line
+	}
+
+	// frame is an object or array being decoded.
+	type frame struct {
+		path    string
+		array   bool
+		index   int
+		key     string
+		wantKey bool
+	}
+	var stack []*frame
+	// valuePath returns the path of the next value of the innermost object or array.
+	valuePath := func() string {
+		if len(stack) == 0 {
+			return prefix
+		}
+		top := stack[len(stack)-1]
+		if top.array {
+			return top.path + "[" + strconv.Itoa(top.index) + "]"
+		}
+		if top.path == "" {
+			return top.key
+		}
+		return top.path + "." + top.key
+	}
+	// advance moves the innermost object or array past a value.
+	advance := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		if top.array {
+			top.index++
+		} else {
+			top.wantKey = true
+		}
+	}
+
+	for {
+		before := dec.InputOffset()
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if len(stack) > 0 {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(stack) > 0 {
+			if top := stack[len(stack)-1]; !top.array && top.wantKey {
+				if key, ok := tok.(string); ok {
+					top.key = key
+					top.wantKey = false
+					continue
+				}
+			}
+		}
+
+		switch tok := tok.(type) {
+		case json.Delim:
+			switch tok {
+			case '{', '[':
+				stack = append(stack, &frame{path: valuePath(), array: tok == '[', wantKey: tok == '{'})
+			default:
+				stack = stack[:len(stack)-1]
+				advance()
+			}
+		case string:
+			path := valuePath()
+			advance()
+			// Values of an embedded document are escaped in the file as a whole; other values are only worth
+			// scanning again if they are escaped.
+			if lineOf == nil && !bytes.ContainsRune(data[before:dec.InputOffset()], '\\') {
+				continue
+			}
+			if depth < maxEmbeddedJSONDepth && isJSONDocument(tok) {
+				line := currentLine()
+				if err := walkJSON([]byte(tok), path, depth+1, func() int64 { return line }, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := fn(jsonString{path: path, value: tok, line: currentLine()}); err != nil {
+				return err
+			}
+		default:
+			advance()
+		}
+	}
+}
+
+// isJSONDocument reports whether s is a JSON object or array.
+func isJSONDocument(s string) bool {
+	return len(s) > 1 && (s[0] == '{' || s[0] == '[') && json.Valid([]byte(s))
+}
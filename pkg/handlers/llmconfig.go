@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/llmconfig"
+)
+
+// Annotation keys set on chunks holding a provider block of an LLM application config.
+const (
+	llmProviderKey = "llm_provider"
+	llmBlockKey    = "llm_config_block"
+)
+
+// llmConfigHandler handles the configs of LLM applications, like .env files and config.yaml files with a block per
+// model provider. Each provider block is scanned as a chunk of its own, with its settings named like the environment
+// variables of the provider's SDK, so that keys of providers that share a key format, like DashScope and DeepSeek,
+// are attributed to the provider of their block rather than to whichever provider the file mentions. The rest of the
+// config is scanned as it is.
+type llmConfigHandler struct{ *defaultHandler }
+
+// newLLMConfigHandler creates an llmConfigHandler.
+func newLLMConfigHandler() *llmConfigHandler {
+	return &llmConfigHandler{defaultHandler: newDefaultHandler(llmConfigHandlerType)}
+}
+
+// HandleFile processes LLM application configs and returns a channel of DataOrErr. Configs that cannot be parsed are
+// handled like any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the config
+func (h *llmConfigHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processConfig(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *llmConfigHandler) processConfig(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("%w: error reading llm config: %v", ErrProcessingFatal, err)
+	}
+
+	mimeReader := func(data []byte) mimeTypeReader {
+		return mimeTypeReader{
+			mimeExt:  input.mime.Extension(),
+			mimeName: mimeType(input.mime.String()),
+			Reader:   bytes.NewReader(data),
+		}
+	}
+
+	cfg, err := llmconfig.Parse(input.llmConfigName, data)
+	if err != nil || len(cfg.Blocks) == 0 {
+		ctx.Logger().V(4).Info("no provider blocks in llm config, handling as regular file", "error", err)
+		return h.handleNonArchiveContent(ctx, mimeReader(data), dataOrErrChan)
+	}
+
+	for _, block := range cfg.Blocks {
+		dataOrErr := DataOrErr{
+			Data:        []byte(block.Render(cfg.Example)),
+			LineNumber:  int64(block.Line()),
+			Annotations: map[string]string{llmProviderKey: block.Provider, llmBlockKey: block.Path},
+		}
+		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+			return err
+		}
+	}
+
+	rest := cfg.Rest(data)
+	if len(bytes.TrimSpace(rest)) == 0 {
+		return nil
+	}
+	return h.handleNonArchiveContent(ctx, mimeReader(rest), dataOrErrChan)
+}
+
+// isLLMConfig reports whether a file is an LLM application config, from its name and header.
+func isLLMConfig(cfg readerConfig, mimeT mimeType, head []byte) bool {
+	return cfg.fileName != "" && mimeT == textMime && llmconfig.Sniff(cfg.fileName, head)
+}
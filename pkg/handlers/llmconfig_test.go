@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const testLLMConfig = `# copy to .env
+OPENAI_API_KEY=sk-4f9a2c7e1b8d3065a9e2f7c41d6b0e53
+OPENAI_BASE_URL=https://dashscope.aliyuncs.com/compatible-mode/v1
+DB_PASSWORD=hunter2
+`
+
+func TestHandleLLMConfigFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rdr, err := newFileReader(ctx, strings.NewReader(testLLMConfig), withFileName(".env.example"))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, ".env.example", rdr.llmConfigName)
+
+	var got []DataOrErr
+	for dataOrErr := range newLLMConfigHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.Len(t, got, 2)
+
+	// The OpenAI variables point to DashScope, so they are scanned as a DashScope block.
+	assert.Equal(t, "# llm config: OPENAI\n# provider: dashscope\n# example file\n"+
+		"DASHSCOPE_API_KEY=sk-4f9a2c7e1b8d3065a9e2f7c41d6b0e53\n"+
+		"DASHSCOPE_BASE_URL=https://dashscope.aliyuncs.com/compatible-mode/v1\n", string(got[0].Data))
+	assert.Equal(t, int64(2), got[0].LineNumber)
+	assert.Equal(t, map[string]string{llmProviderKey: "dashscope", llmBlockKey: "OPENAI"}, got[0].Annotations)
+
+	assert.Equal(t, "# copy to .env\n\n\nDB_PASSWORD=hunter2\n", string(got[1].Data))
+	assert.Equal(t, int64(1), got[1].LineNumber)
+}
+
+func TestDetectLLMConfig(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		fileName string
+		input    string
+		want     string
+	}{
+		{name: "env file", fileName: ".env", input: testLLMConfig, want: ".env"},
+		{name: "yaml config", fileName: "config.yaml", input: "llm:\n  deepseek:\n    api_key: sk-\n", want: "config.yaml"},
+		{name: "other file", fileName: "main.py", input: testLLMConfig, want: ""},
+		{name: "env without providers", fileName: ".env", input: "DB_PASSWORD=hunter2\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, err := newFileReader(ctx, strings.NewReader(tt.input), withFileName(tt.fileName))
+			require.NoError(t, err)
+			defer rdr.Close()
+			assert.Equal(t, tt.want, rdr.llmConfigName)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/notebook"
+)
+
+// Annotation keys set on chunks from Jupyter notebooks.
+const (
+	notebookCellKey        = "notebook_cell"
+	notebookCellTypeKey    = "notebook_cell_type"
+	notebookOutputKey      = "notebook_output"
+	notebookOutputIndexKey = "notebook_output_index"
+	notebookOutputTypeKey  = "notebook_output_type"
+)
+
+// notebookHandler handles Jupyter notebooks. It scans the source and each saved output of every cell separately,
+// so findings can be attributed to a cell and to whether they are in its code or only in its output.
+type notebookHandler struct{ *defaultHandler }
+
+// newNotebookHandler creates a notebookHandler.
+func newNotebookHandler() *notebookHandler {
+	return &notebookHandler{defaultHandler: newDefaultHandler(notebookHandlerType)}
+}
+
+// HandleFile processes Jupyter notebooks and returns a channel of DataOrErr. Notebooks that cannot be parsed are
+// handled like any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the notebook
+func (h *notebookHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processNotebook(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *notebookHandler) processNotebook(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("%w: error reading notebook: %v", ErrProcessingFatal, err)
+	}
+
+	nb, err := notebook.Parse(data)
+	if err != nil {
+		ctx.Logger().V(4).Info("unable to parse notebook, handling as regular file", "error", err)
+		return h.handleNonArchiveContent(ctx, mimeTypeReader{
+			mimeExt:  input.mime.Extension(),
+			mimeName: mimeType(input.mime.String()),
+			Reader:   bytes.NewReader(data),
+		}, dataOrErrChan)
+	}
+
+	send := func(dataOrErr DataOrErr) error {
+		select {
+		case dataOrErrChan <- dataOrErr:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	locs := locateCells(data, len(nb.Cells))
+	for i, cell := range nb.Cells {
+		loc := locs[i]
+		if cell.Source != "" {
+			if err := send(DataOrErr{
+				Data:       []byte(cell.Source),
+				LineNumber: loc.source,
+				Annotations: map[string]string{
+					notebookCellKey:     strconv.Itoa(cell.Index),
+					notebookCellTypeKey: cell.Type,
+					notebookOutputKey:   "false",
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		for _, out := range cell.Outputs {
+			if out.Text == "" {
+				continue
+			}
+			var lineNumber int64
+			if out.Index < len(loc.outputs) {
+				lineNumber = loc.outputs[out.Index]
+			}
+			if err := send(DataOrErr{
+				Data:       []byte(out.Text),
+				LineNumber: lineNumber,
+				Annotations: map[string]string{
+					notebookCellKey:        strconv.Itoa(cell.Index),
+					notebookCellTypeKey:    cell.Type,
+					notebookOutputKey:      "true",
+					notebookOutputIndexKey: strconv.Itoa(out.Index),
+					notebookOutputTypeKey:  out.Type,
+				},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cellLocation holds the 1-indexed lines of a cell's source and outputs within the notebook file, or 0 where they
+// could not be located.
+type cellLocation struct {
+	source  int64
+	outputs []int64
+}
+
+// locateCells finds the lines of the first n cells. Cells are listed in file order, so the n-th "cell_type" key
+// starts the n-th cell; within it, "source" is the last key Jupyter writes and the k-th "output_type" key belongs
+// to the k-th output. A key cannot occur unescaped inside a JSON string, so matches are always keys.
+func locateCells(data []byte, n int) []cellLocation {
+	var (
+		cellTypeKey   = []byte(`"cell_type"`)
+		sourceKey     = []byte(`"source"`)
+		outputTypeKey = []byte(`"output_type"`)
+	)
+	lineAt := func(offset int) int64 { return int64(bytes.Count(data[:offset], []byte("\n")) + 1) }
+
+	var starts []int
+	for offset := 0; len(starts) < n; {
+		i := bytes.Index(data[offset:], cellTypeKey)
+		if i < 0 {
+			break
+		}
+		starts = append(starts, offset+i)
+		offset += i + len(cellTypeKey)
+	}
+
+	locs := make([]cellLocation, n)
+	for i, start := range starts {
+		end := len(data)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		region := data[start:end]
+
+		if j := bytes.LastIndex(region, sourceKey); j >= 0 {
+			locs[i].source = lineAt(start + j)
+			// Jupyter writes the source as a list with one line per element, starting on the next line.
+			if rest := bytes.TrimLeft(region[j+len(sourceKey):], " \t:"); bytes.HasPrefix(rest, []byte("[\n")) {
+				locs[i].source++
+			}
+		}
+		for offset := 0; ; {
+			j := bytes.Index(region[offset:], outputTypeKey)
+			if j < 0 {
+				break
+			}
+			locs[i].outputs = append(locs[i].outputs, lineAt(start+offset+j))
+			offset += j + len(outputTypeKey)
+		}
+	}
+	return locs
+}
+
+// isNotebook reports whether a file is a Jupyter notebook, from its header.
+func isNotebook(cfg readerConfig, mimeT mimeType, head []byte) bool {
+	if cfg.fileExtension != ".ipynb" {
+		return false
+	}
+	switch mimeT {
+	case jsonMime, textMime:
+		return notebook.Sniff(head)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// Annotation keys set on chunks from PEM files.
+const (
+	pemBlockKey     = "pem_block"
+	pemBlockTypeKey = "pem_block_type"
+)
+
+// maxPEMFileSize bounds the size of a file split into PEM blocks. Key and certificate files are small, larger files
+// are only scanned as text.
+const maxPEMFileSize = 16 * 1024 * 1024
+
+// pemHandler handles files starting with PEM blocks, like keys, certificates and bundles of both. Each block is
+// scanned as a chunk of its own, re-encoded with standard line breaks, so a key is never split across chunks of a
+// large bundle and CRLF or unusually wrapped files still match the detectors. Text between the blocks, like the bag
+// attributes written by openssl, is scanned separately.
+type pemHandler struct{ *defaultHandler }
+
+// newPEMHandler creates a pemHandler.
+func newPEMHandler() *pemHandler {
+	return &pemHandler{defaultHandler: newDefaultHandler(pemHandlerType)}
+}
+
+// HandleFile splits a PEM file into its blocks and returns a channel of DataOrErr.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the file
+func (h *pemHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processPEM(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *pemHandler) processPEM(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(io.LimitReader(input, maxPEMFileSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: error reading PEM file: %v", ErrProcessingFatal, err)
+	}
+	if len(data) > maxPEMFileSize {
+		ctx.Logger().V(4).Info("PEM file too large to split, handled as regular file")
+		return h.handleNonArchiveContent(ctx, mimeTypeReader{
+			mimeExt:  input.mime.Extension(),
+			mimeName: mimeType(input.mime.String()),
+			Reader:   io.MultiReader(bytes.NewReader(data), input),
+		}, dataOrErrChan)
+	}
+
+	send := func(dataOrErr DataOrErr) error {
+		if len(bytes.TrimSpace(dataOrErr.Data)) == 0 {
+			return nil
+		}
+		select {
+		case dataOrErrChan <- dataOrErr:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for _, seg := range splitPEM(data) {
+		dataOrErr := DataOrErr{Data: seg.data, LineNumber: seg.line}
+		if seg.block != nil {
+			dataOrErr.Annotations = map[string]string{
+				pemBlockKey:     strconv.Itoa(seg.index),
+				pemBlockTypeKey: seg.block.Type,
+			}
+		}
+		if err := send(dataOrErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pemSegment is a PEM block of a file or the text before, between or after its blocks.
+type pemSegment struct {
+	data []byte
+	// line is the 1-indexed line the segment starts at in the file.
+	line int64
+	// block is the decoded block, or nil for text outside the blocks.
+	block *pem.Block
+	// index is the 0-indexed position of the block in the file.
+	index int
+}
+
+// splitPEM splits data into its PEM blocks and the text around them. Blocks are re-encoded; blocks that do not
+// decode, like truncated ones, are left as text. Text is split at line breaks into segments of at most
+// sources.DefaultChunkSize bytes.
+func splitPEM(data []byte) []pemSegment {
+	var (
+		segs  []pemSegment
+		line  int64 = 1
+		index int
+	)
+	appendText := func(text []byte) {
+		for len(text) > 0 {
+			n := len(text)
+			if n > sources.DefaultChunkSize {
+				n = bytes.LastIndexByte(text[:sources.DefaultChunkSize], '\n') + 1
+				if n == 0 {
+					n = sources.DefaultChunkSize
+				}
+			}
+			segs = append(segs, pemSegment{data: text[:n], line: line})
+			line += int64(bytes.Count(text[:n], []byte{'\n'}))
+			text = text[n:]
+		}
+	}
+
+	var pos, textStart int
+	for {
+		i := bytes.Index(data[pos:], pemBoundary)
+		if i < 0 {
+			break
+		}
+		start := pos + i
+		pos = start + len(pemBoundary)
+
+		// pem.Decode skips blocks it can't decode, so only take a block that starts at this boundary.
+		block, rest := pem.Decode(data[start:])
+		if block == nil {
+			break
+		}
+		end := len(data) - len(rest)
+		if bytes.Contains(data[pos:end], pemBoundary) {
+			continue
+		}
+
+		appendText(data[textStart:start])
+		segs = append(segs, pemSegment{data: pem.EncodeToMemory(block), line: line, block: block, index: index})
+		line += int64(bytes.Count(data[start:end], []byte{'\n'}))
+		index++
+		pos, textStart = end, end
+	}
+	appendText(data[textStart:])
+	return segs
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sourcemap"
+)
+
+const sourceMapExt = ".map"
+
+// sourceMapHandler handles JavaScript source maps. It scans the original sources embedded in a source map instead of
+// the source map itself, so detectors see secrets with the labels and line breaks of the original code.
+type sourceMapHandler struct{ *defaultHandler }
+
+// newSourceMapHandler creates a sourceMapHandler.
+func newSourceMapHandler() *sourceMapHandler {
+	return &sourceMapHandler{defaultHandler: newDefaultHandler(sourceMapHandlerType)}
+}
+
+// HandleFile processes source maps and returns a channel of DataOrErr. Source maps without embedded sources are
+// handled like any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the source map
+//
+// Non-fatal errors that will be logged but allow processing to continue include:
+// - Errors creating mime-type readers for individual sources
+// - Errors handling the content of individual sources
+func (h *sourceMapHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processSourceMap(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *sourceMapHandler) processSourceMap(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("%w: error reading source map: %v", ErrProcessingFatal, err)
+	}
+
+	sm, err := sourcemap.Parse(data)
+	if err != nil || len(sm.Files) == 0 {
+		ctx.Logger().V(4).Info("no sources embedded in source map, handling as regular file")
+		return h.handleNonArchiveContent(ctx, mimeTypeReader{
+			mimeExt:  input.mime.Extension(),
+			mimeName: mimeType(input.mime.String()),
+			Reader:   bytes.NewReader(data),
+		}, dataOrErrChan)
+	}
+
+	for _, file := range sm.Files {
+		fileCtx := logContext.WithValues(ctx, "source", file.Path)
+		rdr, err := newMimeTypeReader(strings.NewReader(file.Content))
+		if err != nil {
+			fileCtx.Logger().Error(err, "error creating mime-type reader for source")
+			continue
+		}
+		if err := h.handleNonArchiveContent(fileCtx, rdr, dataOrErrChan); err != nil {
+			if isFatal(err) {
+				return err
+			}
+			fileCtx.Logger().Error(err, "error handling source")
+		}
+	}
+	return nil
+}
+
+// isSourceMap reports whether a file with a ".map" extension is a JavaScript source map, from its header.
+func isSourceMap(cfg readerConfig, mimeT mimeType, head []byte) bool {
+	if cfg.fileExtension != sourceMapExt {
+		return false
+	}
+	switch mimeT {
+	case jsonMime, textMime:
+		return sourcemap.Sniff(head)
+	default:
+		return false
+	}
+}
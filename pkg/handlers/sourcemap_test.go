@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const testSourceMap = `{"version":3,"file":"main.js","mappings":"AAAA,IAAMA","names":["privateKey"],` +
+	`"sources":["webpack://app/src/config.ts","webpack://app/src/index.ts"],` +
+	`"sourcesContent":["export const privateKey = \"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318\";\n","console.log(1);\n"]}`
+
+func TestHandleSourceMapFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rdr, err := newFileReader(ctx, strings.NewReader(testSourceMap), withFileExtension(sourceMapExt))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, sourceMapHandlerType, rdr.handler)
+
+	var got []string
+	for dataOrErr := range newSourceMapHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, string(dataOrErr.Data))
+	}
+	assert.Equal(t, []string{
+		"export const privateKey = \"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318\";\n",
+		"console.log(1);\n",
+	}, got)
+}
+
+func TestHandleFileSourceMap(t *testing.T) {
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{
+				Filesystem: &source_metadatapb.Filesystem{File: "dist/static/js/main.js.map"},
+			},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 10)
+	reporter := sources.ChanReporter{Ch: chunkCh}
+	require.NoError(t, HandleFile(context.Background(), strings.NewReader(testSourceMap), chunkSkel, reporter))
+	close(chunkCh)
+
+	var got []string
+	for chunk := range chunkCh {
+		got = append(got, string(chunk.Data))
+	}
+	require.Len(t, got, 2)
+	assert.True(t, strings.HasPrefix(got[0], `export const privateKey = "0x4c08`))
+}
+
+func TestIsSourceMap(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		ext   string
+		input string
+		want  bool
+	}{
+		{name: "source map", ext: sourceMapExt, input: testSourceMap, want: true},
+		{name: "other extension", ext: ".json", input: testSourceMap, want: false},
+		{name: "other JSON", ext: sourceMapExt, input: `{"name":"app","version":"1.0.0"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, err := newFileReader(ctx, strings.NewReader(tt.input), withFileExtension(tt.ext))
+			require.NoError(t, err)
+			defer rdr.Close()
+			assert.Equal(t, tt.want, rdr.handler == sourceMapHandlerType)
+		})
+	}
+}
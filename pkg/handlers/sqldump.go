@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sqldump"
+)
+
+// Annotation keys set on chunks holding a row of a credential table.
+const (
+	sqlTableKey      = "sql_table"
+	sqlRowKey        = "sql_row"
+	sqlRowContextKey = "sql_row_context"
+)
+
+// maxSQLTextBuffer is the amount of text between credential rows that is collected before it is chunked.
+const maxSQLTextBuffer = 1024 * 1024
+
+// sqlDumpHandler handles SQL dumps. Rows inserted into tables with credential columns, like api_key or
+// client_secret, are scanned as labeled `table.column = "value"` lines instead of the raw INSERT statements, so
+// every detector sees the column name next to the value and findings can be attributed to a table and row. The rest
+// of the dump is scanned as it is.
+type sqlDumpHandler struct{ *defaultHandler }
+
+// newSQLDumpHandler creates a sqlDumpHandler.
+func newSQLDumpHandler() *sqlDumpHandler {
+	return &sqlDumpHandler{defaultHandler: newDefaultHandler(sqlDumpHandlerType)}
+}
+
+// HandleFile processes SQL dumps and returns a channel of DataOrErr.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the dump
+func (h *sqlDumpHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processDump(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *sqlDumpHandler) processDump(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	var (
+		text     []byte
+		textLine int64
+		// rendered collects rows of credential tables that hold no usable credential, so that their other
+		// columns are still scanned without a chunk per row.
+		rendered     []byte
+		renderedLine int64
+	)
+	flushText := func() error {
+		err := h.handleText(ctx, text, textLine, dataOrErrChan)
+		text = text[:0]
+		return err
+	}
+	flushRendered := func() error {
+		if len(rendered) == 0 {
+			return nil
+		}
+		err := common.CancellableWrite(ctx, dataOrErrChan, DataOrErr{Data: rendered, LineNumber: renderedLine})
+		rendered = nil
+		return err
+	}
+
+	rdr := sqldump.NewReader(input)
+	for {
+		seg, err := rdr.Next()
+		if errors.Is(err, io.EOF) {
+			if err := flushText(); err != nil {
+				return err
+			}
+			return flushRendered()
+		}
+		if err != nil {
+			return fmt.Errorf("%w: error reading sql dump: %v", ErrProcessingFatal, err)
+		}
+
+		if len(seg.Text) > 0 {
+			if len(text) == 0 {
+				textLine = seg.Line
+			}
+			text = append(text, seg.Text...)
+			if len(text) >= maxSQLTextBuffer {
+				if err := flushText(); err != nil {
+					return err
+				}
+			}
+		}
+		if len(seg.Rows) > 0 {
+			// The text is chunked with line numbers counted from its start, so it must not span the rows.
+			if err := flushText(); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range seg.Rows {
+			if len(row.Secrets()) == 0 {
+				if len(rendered) == 0 {
+					renderedLine = row.Line
+				}
+				rendered = append(rendered, row.Render()...)
+				if len(rendered) >= sources.DefaultChunkSize {
+					if err := flushRendered(); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			annotations := map[string]string{
+				sqlTableKey: row.Table,
+				sqlRowKey:   strconv.Itoa(row.Number),
+			}
+			if rowContext := row.Context(); rowContext != "" {
+				annotations[sqlRowContextKey] = rowContext
+			}
+			dataOrErr := DataOrErr{Data: []byte(row.Render()), LineNumber: row.Line, Annotations: annotations}
+			if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleText chunks text of the dump that starts at the given line, like handleNonArchiveContent does for whole
+// files.
+func (h *sqlDumpHandler) handleText(ctx logContext.Context, text []byte, line int64, dataOrErrChan chan DataOrErr) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		return nil
+	}
+
+	for chunkResult := range h.chunkReader(ctx, bytes.NewReader(text)) {
+		if err := chunkResult.Error(); err != nil {
+			h.metrics.incErrors()
+			dataOrErr := DataOrErr{Err: fmt.Errorf("%w: error reading chunk: %v", ErrProcessingWarning, err)}
+			if writeErr := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); writeErr != nil {
+				return fmt.Errorf("%w: error writing to data channel: %v", ErrProcessingFatal, writeErr)
+			}
+			continue
+		}
+
+		chunkBytes := chunkResult.Bytes()
+		if err := common.CancellableWrite(ctx, dataOrErrChan, DataOrErr{Data: chunkBytes, LineNumber: line}); err != nil {
+			return err
+		}
+		h.metrics.incBytesProcessed(len(chunkBytes))
+
+		// Count newlines in the content portion only, as the peek data is repeated at the start of the next chunk.
+		line += int64(bytes.Count(chunkBytes[:chunkResult.ContentSize()], []byte{'\n'}))
+	}
+	return nil
+}
+
+// isSQLDump reports whether a file is a SQL dump, from its header.
+func isSQLDump(cfg readerConfig, mimeT mimeType, head []byte) bool {
+	return cfg.fileExtension == ".sql" && mimeT == textMime && sqldump.Sniff(head)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// minStringLength is the minimum length of a run of printable characters extracted from binary content. Shorter
+// runs are mostly noise, and shorter secrets are not found by the detectors anyway.
+const minStringLength = 8
+
+// maxStringLength bounds a single extracted run, so that text embedded in a binary is still chunked.
+const maxStringLength = 64 * 1024
+
+// stringsHandler handles compiled code. Instead of skipping it, it extracts the runs of printable ASCII and UTF-16LE
+// characters, like the strings utility, and scans those. This finds credentials compiled into executables and
+// libraries.
+type stringsHandler struct{ *defaultHandler }
+
+// newStringsHandler creates a stringsHandler.
+func newStringsHandler() *stringsHandler {
+	return &stringsHandler{defaultHandler: newDefaultHandler(stringsHandlerType)}
+}
+
+// HandleFile extracts the printable strings of a binary file and returns a channel of DataOrErr.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors writing to the data channel
+//
+// Non-fatal errors that will be logged but allow processing to continue include:
+// - Errors reading individual chunks from the input (wrapped as ErrProcessingWarning)
+func (h *stringsHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.handleStrings(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *stringsHandler) handleStrings(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	if mimeExt := input.mime.Extension(); common.SkipFile(mimeExt) {
+		// Audio, video and fonts.
+		ctx.Logger().V(4).Info("skipping file: extension is ignored", "ext", mimeExt)
+		h.metrics.incFilesSkipped()
+		_, _ = io.Copy(io.Discard, input)
+		return nil
+	}
+
+	// Line numbers are meaningless for binary content, so none are reported.
+	for chunkResult := range h.chunkReader(ctx, newStringsReader(input)) {
+		dataOrErr := DataOrErr{}
+		if err := chunkResult.Error(); err != nil {
+			h.metrics.incErrors()
+			dataOrErr.Err = fmt.Errorf("%w: error reading chunk: %v", ErrProcessingWarning, err)
+			if writeErr := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); writeErr != nil {
+				return fmt.Errorf("%w: error writing to data channel: %v", ErrProcessingFatal, writeErr)
+			}
+			continue
+		}
+
+		dataOrErr.Data = chunkResult.Bytes()
+		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+			return err
+		}
+		h.metrics.incBytesProcessed(len(dataOrErr.Data))
+	}
+	return nil
+}
+
+// stringsReader reads the printable strings of the underlying reader, one per line.
+type stringsReader struct {
+	src *bufio.Reader
+	buf []byte
+	out bytes.Buffer
+	eof bool
+
+	// ascii is the current run of printable ASCII characters.
+	ascii []byte
+	// wide is the current run of printable UTF-16LE characters, without their zero bytes. wideChar holds the
+	// low byte of a character whose zero high byte has not been read yet.
+	wide     []byte
+	wideChar byte
+	wideOdd  bool
+}
+
+func newStringsReader(r io.Reader) *stringsReader {
+	return &stringsReader{src: bufio.NewReader(r), buf: make([]byte, 32*1024)}
+}
+
+func (r *stringsReader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 && !r.eof {
+		n, err := r.src.Read(r.buf)
+		for _, b := range r.buf[:n] {
+			r.add(b)
+		}
+		if err == io.EOF {
+			r.flushASCII()
+			r.flushWide()
+			r.eof = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	if r.out.Len() == 0 {
+		return 0, io.EOF
+	}
+	return r.out.Read(p)
+}
+
+func (r *stringsReader) add(b byte) {
+	if isPrintableRunByte(b) {
+		r.ascii = append(r.ascii, b)
+		if len(r.ascii) >= maxStringLength {
+			r.flushASCII()
+		}
+	} else {
+		r.flushASCII()
+	}
+
+	// UTF-16LE: a printable byte followed by a zero byte. A run may start at an odd offset, in which case it
+	// realigns on the first printable byte that does not pair up.
+	switch {
+	case !r.wideOdd && isPrintableRunByte(b):
+		r.wideChar, r.wideOdd = b, true
+	case r.wideOdd && b == 0:
+		r.wide = append(r.wide, r.wideChar)
+		r.wideOdd = false
+		if len(r.wide) >= maxStringLength {
+			r.flushWide()
+		}
+	case r.wideOdd && isPrintableRunByte(b):
+		r.flushWide()
+		r.wideChar = b
+	default:
+		r.flushWide()
+		r.wideOdd = false
+	}
+}
+
+func (r *stringsReader) flushASCII() {
+	if len(r.ascii) >= minStringLength {
+		r.out.Write(r.ascii)
+		r.out.WriteByte('\n')
+	}
+	r.ascii = r.ascii[:0]
+}
+
+func (r *stringsReader) flushWide() {
+	if len(r.wide) >= minStringLength {
+		r.out.Write(r.wide)
+		r.out.WriteByte('\n')
+	}
+	r.wide = r.wide[:0]
+}
+
+// isPrintableRunByte reports whether b can be part of an extracted string. Line breaks and tabs are included, so
+// that multi-line text, such as PEM encoded keys, is kept together.
+func isPrintableRunByte(b byte) bool {
+	return (b >= 0x20 && b <= 0x7e) || b == '\t' || b == '\n' || b == '\r'
+}
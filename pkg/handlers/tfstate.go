@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/tfstate"
+)
+
+// tfstateExts are the extensions of Terraform state files and their backups (terraform.tfstate.backup).
+var tfstateExts = map[string]struct{}{".tfstate": {}, ".backup": {}, ".json": {}}
+
+// tfstateHandler handles Terraform state files. It scans each resource instance as a block of labeled attributes
+// instead of the raw JSON, so findings can be attributed to the resource address and attribute name.
+type tfstateHandler struct{ *defaultHandler }
+
+// newTFStateHandler creates a tfstateHandler.
+func newTFStateHandler() *tfstateHandler {
+	return &tfstateHandler{defaultHandler: newDefaultHandler(tfstateHandlerType)}
+}
+
+// HandleFile processes Terraform state files and returns a channel of DataOrErr. State files that cannot be parsed
+// are handled like any other file.
+//
+// Fatal errors that will terminate processing include:
+// - Context cancellation
+// - Context deadline exceeded
+// - Errors reading the state file
+func (h *tfstateHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	dataOrErrChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(dataOrErrChan)
+
+		start := time.Now()
+		err := h.processState(ctx, input, dataOrErrChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		// Update the metrics for the file processing and handle any errors.
+		h.measureLatencyAndHandleErrors(ctx, start, err, dataOrErrChan)
+	}()
+
+	return dataOrErrChan
+}
+
+func (h *tfstateHandler) processState(ctx logContext.Context, input fileReader, dataOrErrChan chan DataOrErr) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("%w: error reading terraform state: %v", ErrProcessingFatal, err)
+	}
+
+	st, err := tfstate.Parse(data)
+	if err != nil {
+		ctx.Logger().V(4).Info("unable to parse terraform state, handling as regular file", "error", err)
+		return h.handleNonArchiveContent(ctx, mimeTypeReader{
+			mimeExt:  input.mime.Extension(),
+			mimeName: mimeType(input.mime.String()),
+			Reader:   bytes.NewReader(data),
+		}, dataOrErrChan)
+	}
+
+	// Instances are listed in file order, so the n-th "attributes" object belongs to the n-th instance. Outputs
+	// come last and have no such object.
+	offset := 0
+	for _, inst := range st.Instances {
+		var lineNumber int64
+		if i := bytes.Index(data[offset:], []byte(`"attributes"`)); i >= 0 && inst.Address != "output" {
+			offset += i
+			lineNumber = int64(bytes.Count(data[:offset], []byte("\n")) + 1)
+			offset += len(`"attributes"`)
+		}
+
+		select {
+		case dataOrErrChan <- DataOrErr{Data: []byte(inst.Render()), LineNumber: lineNumber}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// isTFState reports whether a file is a Terraform state file, from its header.
+func isTFState(cfg readerConfig, mimeT mimeType, head []byte) bool {
+	if _, ok := tfstateExts[cfg.fileExtension]; !ok {
+		return false
+	}
+	switch mimeT {
+	case jsonMime, textMime:
+		return tfstate.Sniff(head)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const testTFState = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "serial": 3,
+  "lineage": "3f9c2b1e-8d4a-4c1e-9f0b-2a6d7e5c4b3a",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "random_password",
+      "name": "db",
+      "provider": "provider[\"registry.terraform.io/hashicorp/random\"]",
+      "instances": [
+        {
+          "schema_version": 3,
+          "attributes": {"length": 24, "result": "q7Vn_3kRz!8LwPe2Tj5YbHx0"},
+          "sensitive_attributes": []
+        }
+      ]
+    }
+  ]
+}`
+
+func TestHandleTFStateFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rdr, err := newFileReader(ctx, strings.NewReader(testTFState), withFileExtension(".tfstate"))
+	require.NoError(t, err)
+	defer rdr.Close()
+	assert.Equal(t, tfstateHandlerType, rdr.handler)
+
+	var got []DataOrErr
+	for dataOrErr := range newTFStateHandler().HandleFile(ctx, rdr) {
+		require.NoError(t, dataOrErr.Err)
+		got = append(got, dataOrErr)
+	}
+	require.Len(t, got, 1)
+	assert.Equal(t, "# terraform state: random_password.db\nrandom_password.db.result = \"q7Vn_3kRz!8LwPe2Tj5YbHx0\"\n", string(got[0].Data))
+	assert.Equal(t, int64(16), got[0].LineNumber)
+}
+
+func TestIsTFState(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		ext   string
+		input string
+		want  bool
+	}{
+		{name: "state file", ext: ".tfstate", input: testTFState, want: true},
+		{name: "state backup", ext: ".backup", input: testTFState, want: true},
+		{name: "other extension", ext: ".txt", input: testTFState, want: false},
+		{name: "other JSON", ext: ".json", input: `{"name":"app","version":"1.0.0"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdr, err := newFileReader(ctx, strings.NewReader(tt.input), withFileExtension(tt.ext))
+			require.NoError(t, err)
+			defer rdr.Close()
+			assert.Equal(t, tt.want, rdr.handler == tfstateHandlerType)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package llmconfig
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// parseDotenv groups the variables of a .env file into provider blocks. Variables that name a provider, like
+// OPENAI_API_KEY or QWEN_MODEL, belong to its block. Other variables with a role, like LLM_API_KEY and LLM_BASE_URL,
+// are grouped by their prefix and belong to the provider of their base URL or key format.
+func parseDotenv(data []byte) []Block {
+	var (
+		named   = make(map[string]*Block)
+		generic = make(map[string]*Block)
+		order   []*Block
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		name, value, ok := parseDotenvLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		r, prefix := role(name)
+		f := Field{Name: name, Value: value, Role: r, Line: lineNum}
+
+		if p := providerByName(name); p != "" {
+			b, ok := named[p]
+			if !ok {
+				provider, _ := LookupProvider(p)
+				b = &Block{Path: provider.EnvPrefix}
+				named[p] = b
+				order = append(order, b)
+			}
+			b.Fields = append(b.Fields, f)
+			continue
+		}
+		if r == "" {
+			continue
+		}
+		b, ok := generic[prefix]
+		if !ok {
+			b = &Block{Path: prefix}
+			generic[prefix] = b
+			order = append(order, b)
+		}
+		b.Fields = append(b.Fields, f)
+	}
+
+	var blocks []Block
+	for _, b := range order {
+		if !hasKey(b.Fields) {
+			continue
+		}
+		// The base URL may point the variables of one provider, like OPENAI_BASE_URL, to another.
+		if b.Provider = resolve(b.Path, b.Fields); b.Provider == "" {
+			continue
+		}
+		blocks = append(blocks, *b)
+	}
+	return blocks
+}
+
+// parseDotenvLine parses a NAME=value line, with an optional export keyword, quotes and trailing comment.
+func parseDotenvLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	line = strings.TrimPrefix(line, "export ")
+	name, value, ok := strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", false
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return name, value[1 : end+1], true
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return name, value, true
+}
@@ -0,0 +1,73 @@
+package llmconfig
+
+import (
+	"regexp"
+	"strings"
+)
+
+// keyFormats are the formats of the API keys of each provider, as matched by their detectors.
+var keyFormats = map[string]*regexp.Regexp{
+	"openai":    regexp.MustCompile(`^sk-(?:(?:proj|svcacct|service)-[A-Za-z0-9_-]+|[a-zA-Z0-9]+)T3BlbkFJ[A-Za-z0-9_-]+$`),
+	"dashscope": regexp.MustCompile(`^sk-(?:sp-)?[a-z0-9]{32}$`),
+	"anthropic": regexp.MustCompile(`^sk-ant-(?:admin01|api03)-[\w-]{93}AA$`),
+	"coze":      regexp.MustCompile(`^(?:pat|sat)_[A-Za-z0-9]{64}$`),
+	"deepseek":  regexp.MustCompile(`^sk-[a-z0-9]{32}$`),
+	"hunyuan":   regexp.MustCompile(`^sk-[A-Za-z0-9]{48}$`),
+}
+
+// providerByKey returns the provider whose key format the key has, if only one provider uses that format.
+func providerByKey(key string) string {
+	found := ""
+	for _, p := range providers {
+		if keyFormats[p.Name].MatchString(key) {
+			if found != "" {
+				return ""
+			}
+			found = p.Name
+		}
+	}
+	return found
+}
+
+// placeholderMarkers occur in the keys of example files that were not filled in.
+var placeholderMarkers = []string{
+	"xxxx", "your", "example", "placeholder", "changeme", "replace", "dummy", "fake", "test",
+	"1234567", "abcdefg", "0000", "****", "...", "<", ">",
+}
+
+// minDistinctChars is the number of distinct characters below which a key is taken for a placeholder, like sk-aaaa.
+const minDistinctChars = 10
+
+// LooksReal reports whether key has the key format of the provider and isn't a placeholder, like
+// sk-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx or sk-your-key-here. Keys of unknown providers may have any provider's format.
+func LooksReal(provider, key string) bool {
+	if format, ok := keyFormats[provider]; ok {
+		if !format.MatchString(key) {
+			return false
+		}
+	} else if !matchesAnyFormat(key) {
+		return false
+	}
+
+	lower := strings.ToLower(key)
+	for _, marker := range placeholderMarkers {
+		if strings.Contains(lower, marker) {
+			return false
+		}
+	}
+
+	distinct := make(map[rune]struct{})
+	for _, r := range key {
+		distinct[r] = struct{}{}
+	}
+	return len(distinct) >= minDistinctChars
+}
+
+func matchesAnyFormat(key string) bool {
+	for _, format := range keyFormats {
+		if format.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+// Package llmconfig parses the configuration files of LLM applications, like .env files and config.yaml files with a
+// block per model provider, into provider blocks. Applications often configure several providers side by side, and
+// many providers share a key format, such as the sk- keys of DashScope and DeepSeek, so a key can only be attributed to
+// its provider through the block that configures it.
+package llmconfig
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Roles of the fields of a provider block.
+const (
+	RoleAPIKey  = "api_key"
+	RoleBaseURL = "base_url"
+	RoleModel   = "model"
+)
+
+// Provider is a model provider.
+type Provider struct {
+	// Name is the canonical name of the provider.
+	Name string
+	// EnvPrefix is the prefix of the environment variables of the provider's SDK, like DASHSCOPE in DASHSCOPE_API_KEY.
+	EnvPrefix string
+	// aliases are names by which configs refer to the provider, matched against words of block names, variable names
+	// and model names.
+	aliases []string
+	// hosts are the API hosts of the provider. Providers with an OpenAI compatible API are often configured through an
+	// OpenAI block whose base URL points to them.
+	hosts []string
+}
+
+var providers = []Provider{
+	{Name: "openai", EnvPrefix: "OPENAI", aliases: []string{"openai", "chatgpt", "gpt"}, hosts: []string{"api.openai.com"}},
+	{Name: "dashscope", EnvPrefix: "DASHSCOPE", aliases: []string{"dashscope", "bailian", "qwen", "tongyi", "aliyun"}, hosts: []string{"dashscope.aliyuncs.com", "dashscope-intl.aliyuncs.com"}},
+	{Name: "anthropic", EnvPrefix: "ANTHROPIC", aliases: []string{"anthropic", "claude"}, hosts: []string{"api.anthropic.com"}},
+	{Name: "coze", EnvPrefix: "COZE", aliases: []string{"coze"}, hosts: []string{"api.coze.cn", "api.coze.com"}},
+	{Name: "deepseek", EnvPrefix: "DEEPSEEK", aliases: []string{"deepseek"}, hosts: []string{"api.deepseek.com"}},
+	{Name: "hunyuan", EnvPrefix: "HUNYUAN", aliases: []string{"hunyuan"}, hosts: []string{"api.hunyuan.cloud.tencent.com"}},
+}
+
+// LookupProvider returns the provider with the given canonical name.
+func LookupProvider(name string) (Provider, bool) {
+	for _, p := range providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Provider{}, false
+}
+
+// Field is a single-line setting of a provider block.
+type Field struct {
+	Name  string
+	Value string
+	// Role is RoleAPIKey, RoleBaseURL, RoleModel or empty for other settings.
+	Role string
+	// Line is the 1-based line of the field in the file.
+	Line int
+}
+
+// Block is the configuration of a single provider.
+type Block struct {
+	// Path locates the block in the file, like providers.dashscope or model_list[0].litellm_params. Blocks of .env
+	// files are named by the prefix of their variables, like OPENAI.
+	Path string
+	// Provider is the canonical name of the provider the block configures.
+	Provider string
+	Fields   []Field
+}
+
+// Line returns the line of the first field of the block.
+func (b Block) Line() int {
+	if len(b.Fields) == 0 {
+		return 0
+	}
+	return b.Fields[0].Line
+}
+
+// Config is a parsed LLM application config.
+type Config struct {
+	// Example is set for example files, like .env.example or config.sample.yaml, that are meant to be copied and
+	// filled in, and so are committed and shared.
+	Example bool
+	Blocks  []Block
+}
+
+// Parse parses a config into the blocks of the providers it configures. The format is chosen by the file name.
+// Settings that don't belong to a known provider are left out.
+func Parse(name string, data []byte) (*Config, error) {
+	cfg := &Config{Example: IsExample(name)}
+	var err error
+	switch formatOf(name) {
+	case formatDotenv:
+		cfg.Blocks = parseDotenv(data)
+	case formatYAML:
+		cfg.Blocks, err = parseYAML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Rest returns data without the lines of the fields of the blocks, which are scanned through the blocks. Lines are
+// emptied rather than removed, so that line numbers are kept.
+func (c *Config) Rest(data []byte) []byte {
+	consumed := make(map[int]struct{})
+	for _, b := range c.Blocks {
+		for _, f := range b.Fields {
+			consumed[f.Line] = struct{}{}
+		}
+	}
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	rest := make([]byte, 0, len(data))
+	for i, line := range lines {
+		if _, ok := consumed[i+1]; ok {
+			if bytes.HasSuffix(line, []byte("\n")) {
+				rest = append(rest, '\n')
+			}
+			continue
+		}
+		rest = append(rest, line...)
+	}
+	return rest
+}
+
+const (
+	formatDotenv = "dotenv"
+	formatYAML   = "yaml"
+)
+
+// formatOf returns the format of a config by its file name, or "" if it isn't a supported config.
+func formatOf(name string) string {
+	base := strings.ToLower(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	ws := words(base)
+	switch {
+	case slices.Contains(ws, "yaml") || slices.Contains(ws, "yml"):
+		return formatYAML
+	case slices.Contains(ws, "env"):
+		// .env, .env.example, prod.env
+		return formatDotenv
+	}
+	return ""
+}
+
+// exampleWords mark the names of example files.
+var exampleWords = []string{"example", "examples", "sample", "template", "tpl", "dist"}
+
+// IsExample reports whether a file name is that of an example config, like .env.example, config.sample.yaml or
+// settings.yaml.dist.
+func IsExample(name string) bool {
+	base := strings.ToLower(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	for _, w := range words(base) {
+		if slices.Contains(exampleWords, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sniff reports whether the start of a file named name looks like an LLM application config.
+func Sniff(name string, head []byte) bool {
+	if formatOf(name) == "" {
+		return false
+	}
+	lower := strings.ToLower(string(head))
+	if !strings.Contains(lower, "key") && !strings.Contains(lower, "token") {
+		return false
+	}
+	for _, p := range providers {
+		for _, s := range slices.Concat(p.aliases, p.hosts) {
+			if strings.Contains(lower, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// words splits s into its lowercase alphanumeric words.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+}
+
+// providerByName returns the provider one of whose aliases starts a word of s, like qwen in qwen-plus. Words are
+// tried from last to first, so that the innermost block of a path wins.
+func providerByName(s string) string {
+	ws := words(s)
+	for i := len(ws) - 1; i >= 0; i-- {
+		for _, p := range providers {
+			for _, alias := range p.aliases {
+				if strings.HasPrefix(ws[i], alias) {
+					return p.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// providerByURL returns the provider whose API is served at u.
+func providerByURL(u string) string {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, p := range providers {
+		if slices.Contains(p.hosts, host) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// roleSuffixes map the endings of setting names to their role, longest first.
+var roleSuffixes = []struct {
+	suffix string
+	role   string
+}{
+	{"api_key", RoleAPIKey}, {"apikey", RoleAPIKey}, {"access_token", RoleAPIKey}, {"api_token", RoleAPIKey},
+	{"secret_key", RoleAPIKey}, {"key", RoleAPIKey}, {"token", RoleAPIKey},
+	{"base_url", RoleBaseURL}, {"api_base", RoleBaseURL}, {"baseurl", RoleBaseURL}, {"endpoint", RoleBaseURL},
+	{"api_url", RoleBaseURL}, {"url", RoleBaseURL},
+	{"model_name", RoleModel}, {"model", RoleModel},
+}
+
+// role returns the role of a setting and its name without the role suffix, like OPENAI for OPENAI_API_KEY.
+func role(name string) (string, string) {
+	lower := strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	for _, rs := range roleSuffixes {
+		if strings.HasSuffix(lower, rs.suffix) {
+			prefix := strings.TrimRight(name[:len(name)-len(rs.suffix)], "_-")
+			return rs.role, prefix
+		}
+	}
+	return "", name
+}
+
+// isReference reports whether a value refers to a secret stored elsewhere, like ${OPENAI_API_KEY}, instead of holding
+// it.
+func isReference(v string) bool {
+	return strings.HasPrefix(v, "$") || strings.HasPrefix(v, "os.environ/") || strings.HasPrefix(v, "{{")
+}
+
+// hasKey reports whether fields hold an API key.
+func hasKey(fields []Field) bool {
+	for _, f := range fields {
+		if f.Role == RoleAPIKey && f.Value != "" && !isReference(f.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the provider of a block. The base URL is the most specific, as OpenAI blocks are reused for
+// compatible APIs; then come explicit provider settings, the block name, the model and finally the format of the
+// key.
+func resolve(blockName string, fields []Field) string {
+	for _, f := range fields {
+		if f.Role == RoleBaseURL {
+			if p := providerByURL(f.Value); p != "" {
+				return p
+			}
+		}
+	}
+	for _, f := range fields {
+		switch strings.ToLower(f.Name) {
+		case "provider", "type", "api_type", "vendor":
+			if p := providerByName(f.Value); p != "" {
+				return p
+			}
+		}
+	}
+	if p := providerByName(blockName); p != "" {
+		return p
+	}
+	for _, f := range fields {
+		if f.Role == RoleModel {
+			if p := providerByName(f.Value); p != "" {
+				return p
+			}
+		}
+	}
+	for _, f := range fields {
+		if f.Role == RoleAPIKey {
+			if p := providerByKey(f.Value); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
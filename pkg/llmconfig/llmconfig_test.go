@@ -0,0 +1,172 @@
+package llmconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	dashscopeKey = "sk-4f9a2c7e1b8d3065a9e2f7c41d6b0e53"
+	deepseekKey  = "sk-8e3b1f6a9c2d4e705b1a3f8c6d9e2b47"
+	cozeKey      = "pat_Qm7xK2vR9pL4tN8wZ3cF6hJ1yB5gD0sEQm7xK2vR9pL4tN8wZ3cF6hJ1yB5gD0sE"
+	openaiKey    = "sk-proj-Ab3dE5fG7hJ9kL1mN2pQ4rS6tU8vW0xYT3BlbkFJZz9yX8wV7uT6sR5qP4oN3mL2kJ1iH0gF"
+)
+
+const testDotenv = `# LLM settings
+export OPENAI_API_KEY="` + dashscopeKey + `"
+OPENAI_BASE_URL=https://dashscope.aliyuncs.com/compatible-mode/v1
+DEEPSEEK_API_KEY=` + deepseekKey + ` # production
+DB_PASSWORD=hunter2
+LLM_API_KEY=${OPENAI_API_KEY}
+COZE_API_TOKEN=` + cozeKey + `
+`
+
+func TestParse_Dotenv(t *testing.T) {
+	cfg, err := Parse(".env.example", []byte(testDotenv))
+	require.NoError(t, err)
+	assert.True(t, cfg.Example)
+	assert.Equal(t, []Block{
+		{
+			// The OpenAI variables point to DashScope's compatible API.
+			Path:     "OPENAI",
+			Provider: "dashscope",
+			Fields: []Field{
+				{Name: "OPENAI_API_KEY", Value: dashscopeKey, Role: RoleAPIKey, Line: 2},
+				{Name: "OPENAI_BASE_URL", Value: "https://dashscope.aliyuncs.com/compatible-mode/v1", Role: RoleBaseURL, Line: 3},
+			},
+		},
+		{
+			Path:     "DEEPSEEK",
+			Provider: "deepseek",
+			Fields:   []Field{{Name: "DEEPSEEK_API_KEY", Value: deepseekKey, Role: RoleAPIKey, Line: 4}},
+		},
+		{
+			Path:     "COZE",
+			Provider: "coze",
+			Fields:   []Field{{Name: "COZE_API_TOKEN", Value: cozeKey, Role: RoleAPIKey, Line: 7}},
+		},
+	}, cfg.Blocks)
+
+	assert.Equal(t, "# LLM settings\n\n\n\nDB_PASSWORD=hunter2\nLLM_API_KEY=${OPENAI_API_KEY}\n\n", string(cfg.Rest([]byte(testDotenv))))
+}
+
+const testYAML = `app:
+  name: assistant
+providers:
+  qwen:
+    api_key: ` + dashscopeKey + `
+    model: qwen-plus
+  deepseek:
+    api_key: ` + deepseekKey + `
+    temperature: 0.7
+  anthropic:
+    api_key: ${ANTHROPIC_API_KEY}
+model_list:
+  - model_name: gpt-4o
+    litellm_params:
+      model: openai/gpt-4o
+      api_key: ` + openaiKey + `
+coze_token: ` + cozeKey + `
+`
+
+func TestParse_YAML(t *testing.T) {
+	cfg, err := Parse("config/config.yaml", []byte(testYAML))
+	require.NoError(t, err)
+	assert.False(t, cfg.Example)
+	assert.Equal(t, []Block{
+		{
+			Path:     "coze",
+			Provider: "coze",
+			Fields:   []Field{{Name: "coze_token", Value: cozeKey, Role: RoleAPIKey, Line: 17}},
+		},
+		{
+			Path:     "providers.qwen",
+			Provider: "dashscope",
+			Fields: []Field{
+				{Name: "api_key", Value: dashscopeKey, Role: RoleAPIKey, Line: 5},
+				{Name: "model", Value: "qwen-plus", Role: RoleModel, Line: 6},
+			},
+		},
+		{
+			Path:     "providers.deepseek",
+			Provider: "deepseek",
+			Fields: []Field{
+				{Name: "api_key", Value: deepseekKey, Role: RoleAPIKey, Line: 8},
+				{Name: "temperature", Value: "0.7", Line: 9},
+			},
+		},
+		{
+			Path:     "model_list[0].litellm_params",
+			Provider: "openai",
+			Fields: []Field{
+				{Name: "model", Value: "openai/gpt-4o", Role: RoleModel, Line: 15},
+				{Name: "api_key", Value: openaiKey, Role: RoleAPIKey, Line: 16},
+			},
+		},
+	}, cfg.Blocks)
+
+	_, err = Parse("config.yaml", []byte("providers: [unclosed"))
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	b := Block{
+		Path:     "providers.openai",
+		Provider: "deepseek",
+		Fields: []Field{
+			{Name: "key", Value: deepseekKey, Role: RoleAPIKey, Line: 3},
+			{Name: "api-base", Value: "https://api.deepseek.com", Role: RoleBaseURL, Line: 4},
+			{Name: "max-tokens", Value: "1024", Line: 5},
+		},
+	}
+	rendered := b.Render(true)
+	assert.Equal(t, "# llm config: providers.openai\n# provider: deepseek\n# example file\n"+
+		"DEEPSEEK_API_KEY="+deepseekKey+"\nDEEPSEEK_BASE_URL=https://api.deepseek.com\nDEEPSEEK_MAX_TOKENS=1024\n", rendered)
+
+	assert.Equal(t, []Rendered{{
+		Block: Block{
+			Path:     "providers.openai",
+			Provider: "deepseek",
+			Fields: []Field{
+				{Name: "DEEPSEEK_API_KEY", Value: deepseekKey, Role: RoleAPIKey},
+				{Name: "DEEPSEEK_BASE_URL", Value: "https://api.deepseek.com", Role: RoleBaseURL},
+				{Name: "DEEPSEEK_MAX_TOKENS", Value: "1024"},
+			},
+		},
+		Example: true,
+	}}, ParseRendered("unrelated\n"+rendered+"OTHER=1\n"))
+}
+
+func TestLooksReal(t *testing.T) {
+	tests := []struct {
+		provider string
+		key      string
+		want     bool
+	}{
+		{provider: "dashscope", key: dashscopeKey, want: true},
+		{provider: "coze", key: cozeKey, want: true},
+		{provider: "", key: openaiKey, want: true},
+		{provider: "dashscope", key: "sk-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx", want: false},
+		{provider: "dashscope", key: "sk-1234567890abcdef1234567890abcdef", want: false},
+		{provider: "openai", key: "sk-your-openai-api-key", want: false},
+		{provider: "anthropic", key: dashscopeKey, want: false},
+		{provider: "", key: "hunter2", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, LooksReal(tt.provider, tt.key), "%s %s", tt.provider, tt.key)
+	}
+}
+
+func TestSniff(t *testing.T) {
+	assert.True(t, Sniff(".env", []byte("OPENAI_API_KEY=sk-")))
+	assert.True(t, Sniff("settings.example.yml", []byte("llm:\n  dashscope:\n    api_key: sk-")))
+	assert.False(t, Sniff(".env", []byte("DB_PASSWORD=hunter2")))
+	assert.False(t, Sniff("main.go", []byte("OPENAI_API_KEY")))
+
+	assert.True(t, IsExample(".env.example"))
+	assert.True(t, IsExample("deploy/config.sample.yaml"))
+	assert.False(t, IsExample("config.yaml"))
+	assert.False(t, IsExample("examples/.env"))
+}
@@ -0,0 +1,102 @@
+package llmconfig
+
+import "strings"
+
+const (
+	// HeaderPrefix starts the first line of a rendered block, followed by the block path.
+	HeaderPrefix = "# llm config: "
+	// providerPrefix starts the line naming the provider of a rendered block.
+	providerPrefix = "# provider: "
+	// exampleLine marks blocks of example files.
+	exampleLine = "# example file"
+)
+
+// Render writes a block as a header followed by one NAME=value line per field, named like the environment variables
+// of the provider's SDK: the API key of a DashScope block is written as DASHSCOPE_API_KEY whatever the config called
+// it. Detectors of providers that share a key format tell their keys apart by these names and the base URL.
+func (b Block) Render(example bool) string {
+	provider, _ := LookupProvider(b.Provider)
+
+	var s strings.Builder
+	s.WriteString(HeaderPrefix + b.Path + "\n")
+	s.WriteString(providerPrefix + b.Provider + "\n")
+	if example {
+		s.WriteString(exampleLine + "\n")
+	}
+	for _, f := range b.Fields {
+		s.WriteString(provider.EnvPrefix + "_" + envName(f) + "=" + f.Value + "\n")
+	}
+	return s.String()
+}
+
+// envName returns the name of a field without the provider prefix.
+func envName(f Field) string {
+	switch f.Role {
+	case RoleAPIKey:
+		return "API_KEY"
+	case RoleBaseURL:
+		return "BASE_URL"
+	case RoleModel:
+		return "MODEL"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		}
+		return '_'
+	}, f.Name)
+}
+
+// Rendered is a block read back from its rendering.
+type Rendered struct {
+	Block
+	Example bool
+}
+
+// ParseRendered reads back the blocks written by Render. The fields are named as rendered, like DASHSCOPE_API_KEY,
+// and have no line. Lines that are not part of a rendered block are ignored.
+func ParseRendered(text string) []Rendered {
+	var (
+		blocks []Rendered
+		// cur is the index of the block being read, or -1 outside blocks.
+		cur    = -1
+		prefix string
+	)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		switch {
+		case strings.HasPrefix(line, HeaderPrefix):
+			blocks = append(blocks, Rendered{Block: Block{Path: strings.TrimPrefix(line, HeaderPrefix)}})
+			cur, prefix = len(blocks)-1, ""
+		case cur < 0:
+		case strings.HasPrefix(line, providerPrefix):
+			blocks[cur].Provider = strings.TrimPrefix(line, providerPrefix)
+			if provider, ok := LookupProvider(blocks[cur].Provider); ok {
+				prefix = provider.EnvPrefix + "_"
+			}
+		case line == exampleLine:
+			blocks[cur].Example = true
+		case prefix != "" && strings.HasPrefix(line, prefix):
+			name, value, ok := strings.Cut(line, "=")
+			if !ok {
+				continue
+			}
+			f := Field{Name: name, Value: value}
+			switch strings.TrimPrefix(name, prefix) {
+			case "API_KEY":
+				f.Role = RoleAPIKey
+			case "BASE_URL":
+				f.Role = RoleBaseURL
+			case "MODEL":
+				f.Role = RoleModel
+			}
+			blocks[cur].Fields = append(blocks[cur].Fields, f)
+		default:
+			cur = -1
+		}
+	}
+	return blocks
+}
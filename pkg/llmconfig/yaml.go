@@ -0,0 +1,102 @@
+package llmconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAML returns a block for every mapping of a YAML config that holds an API key, like providers.dashscope in
+//
+//	providers:
+//	  dashscope:
+//	    api_key: sk-...
+//	    base_url: https://dashscope.aliyuncs.com/compatible-mode/v1
+func parseYAML(data []byte) ([]Block, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing yaml: %w", err)
+	}
+	var blocks []Block
+	walkYAML(&doc, "", &blocks)
+	return blocks, nil
+}
+
+func walkYAML(n *yaml.Node, path string, blocks *[]Block) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			walkYAML(c, path, blocks)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			walkYAML(c, path+"["+strconv.Itoa(i)+"]", blocks)
+		}
+	case yaml.MappingNode:
+		var (
+			fields   []Field
+			children []int
+		)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				children = append(children, i)
+				continue
+			}
+			if strings.Contains(value.Value, "\n") {
+				continue
+			}
+			r, _ := role(key.Value)
+			fields = append(fields, Field{Name: key.Value, Value: value.Value, Role: r, Line: value.Line})
+		}
+		*blocks = append(*blocks, mappingBlocks(path, fields)...)
+
+		for _, i := range children {
+			walkYAML(n.Content[i+1], joinPath(path, n.Content[i].Value), blocks)
+		}
+	}
+}
+
+// mappingBlocks returns the blocks of the fields of a mapping. Fields named after a provider, like deepseek_api_key
+// in a flat config, form a block of their own; the other fields form the block of the mapping.
+func mappingBlocks(path string, fields []Field) []Block {
+	var (
+		named = make(map[string]*Block)
+		order []*Block
+		rest  = &Block{Path: path}
+	)
+	for _, f := range fields {
+		_, prefix := role(f.Name)
+		if f.Role == "" || prefix == "" || providerByName(prefix) == "" {
+			rest.Fields = append(rest.Fields, f)
+			continue
+		}
+		b, ok := named[prefix]
+		if !ok {
+			b = &Block{Path: joinPath(path, prefix)}
+			named[prefix] = b
+			order = append(order, b)
+		}
+		b.Fields = append(b.Fields, f)
+	}
+
+	var blocks []Block
+	for _, b := range append([]*Block{rest}, order...) {
+		if !hasKey(b.Fields) {
+			continue
+		}
+		if b.Provider = resolve(b.Path, b.Fields); b.Provider != "" {
+			blocks = append(blocks, *b)
+		}
+	}
+	return blocks
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
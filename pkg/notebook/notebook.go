@@ -0,0 +1,144 @@
+// Package notebook extracts cell sources and outputs from Jupyter notebooks.
+//
+// A notebook is a JSON document in which every line of code is a separate JSON string, and every output a cell
+// produced is saved next to it. Outputs often echo credentials a cell printed or a library logged, even after the
+// cell's source has been cleaned up. Scanned as plain JSON, a finding says neither which cell it is in nor whether
+// it is still in the code or only in a saved output. This package splits a notebook into its cells and their
+// outputs, so each can be scanned and attributed on its own.
+package notebook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Output is the text of one output of a code cell.
+type Output struct {
+	// Index is the position of the output within the cell's outputs.
+	Index int
+	// Type is the output type: stream, execute_result, display_data or error.
+	Type string
+	Text string
+}
+
+// Cell is one cell of a notebook.
+type Cell struct {
+	// Index is the position of the cell within the notebook.
+	Index int
+	// Type is the cell type: code, markdown or raw.
+	Type    string
+	Source  string
+	Outputs []Output
+}
+
+// Notebook holds the cells of a notebook, in file order.
+type Notebook struct {
+	Cells []Cell
+}
+
+var errNotNotebook = errors.New("not a jupyter notebook")
+
+// ansiPat matches the color escape sequences IPython writes into tracebacks.
+var ansiPat = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// rawNotebook covers nbformat 4, written by Jupyter since 2015.
+type rawNotebook struct {
+	NBFormat *int `json:"nbformat"`
+	Cells    []struct {
+		CellType string    `json:"cell_type"`
+		Source   multiline `json:"source"`
+		Outputs  []struct {
+			OutputType string               `json:"output_type"`
+			Text       multiline            `json:"text"`
+			Data       map[string]multiline `json:"data"`
+			EName      string               `json:"ename"`
+			EValue     string               `json:"evalue"`
+			Traceback  []string             `json:"traceback"`
+		} `json:"outputs"`
+	} `json:"cells"`
+}
+
+// multiline is a string nbformat may store either whole or split into a list of lines. Structured mime bundle
+// entries, like application/json, are kept as their JSON encoding.
+type multiline string
+
+func (m *multiline) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*m = multiline(s)
+		return nil
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*m = multiline(strings.Join(lines, ""))
+		return nil
+	}
+	*m = multiline(data)
+	return nil
+}
+
+// Parse parses an nbformat 4 notebook.
+func Parse(data []byte) (*Notebook, error) {
+	var raw rawNotebook
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", errNotNotebook, err)
+	}
+	if raw.NBFormat == nil || *raw.NBFormat != 4 {
+		return nil, errNotNotebook
+	}
+
+	nb := &Notebook{Cells: make([]Cell, 0, len(raw.Cells))}
+	for i, rc := range raw.Cells {
+		cell := Cell{Index: i, Type: rc.CellType, Source: string(rc.Source)}
+		for j, ro := range rc.Outputs {
+			var text string
+			switch ro.OutputType {
+			case "stream":
+				text = string(ro.Text)
+			case "error":
+				text = ro.EName + ": " + ro.EValue
+				if len(ro.Traceback) > 0 {
+					text += "\n" + ansiPat.ReplaceAllString(strings.Join(ro.Traceback, "\n"), "")
+				}
+			default:
+				text = renderData(ro.Data)
+			}
+			cell.Outputs = append(cell.Outputs, Output{Index: j, Type: ro.OutputType, Text: text})
+		}
+		nb.Cells = append(nb.Cells, cell)
+	}
+	return nb, nil
+}
+
+// renderData joins the textual representations of a mime bundle. Images and other binary representations are
+// base64 encoded and skipped.
+func renderData(data map[string]multiline) string {
+	mimes := make([]string, 0, len(data))
+	for mime := range data {
+		if strings.HasPrefix(mime, "text/") || strings.HasSuffix(mime, "json") || strings.HasSuffix(mime, "javascript") {
+			mimes = append(mimes, mime)
+		}
+	}
+	sort.Strings(mimes)
+
+	var sb strings.Builder
+	for _, mime := range mimes {
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(string(data[mime]))
+	}
+	return sb.String()
+}
+
+// Sniff reports whether the beginning of a file looks like a notebook.
+func Sniff(head []byte) bool {
+	head = bytes.TrimLeft(head, " \t\r\n")
+	return bytes.HasPrefix(head, []byte("{")) &&
+		(bytes.Contains(head, []byte(`"cells"`)) || bytes.Contains(head, []byte(`"nbformat"`)))
+}
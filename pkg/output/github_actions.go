@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
@@ -22,6 +23,7 @@ func (p *GitHubActionsPrinter) Print(_ context.Context, r *detectors.ResultWithM
 		DetectorDescription: r.DetectorDescription,
 		DecoderType:         r.DecoderType.String(),
 		Verified:            r.Result.Verified,
+		Remediation:         r.Result.Remediation,
 	}
 
 	meta, err := structToMap(r.SourceMetadata.Data)
@@ -70,6 +72,11 @@ func (p *GitHubActionsPrinter) Print(_ context.Context, r *detectors.ResultWithM
 		message = fmt.Sprintf("Found %s %s%s result with %s encoding 🐷🔑\n", verifiedStatus, out.DetectorType, name, out.DecoderType)
 	}
 
+	if out.Remediation != "" {
+		// Workflow command messages end at the first newline, so the remediation goes on an escaped one.
+		message = strings.TrimSuffix(message, "\n") + "%0ARemediation: " + escapeWorkflowData(out.Remediation) + "\n"
+	}
+
 	fmt.Printf("::warning file=%s,line=%d,endLine=%d::%s",
 		out.Filename, out.StartLine, out.StartLine, message)
 
@@ -83,4 +90,10 @@ type gitHubActionsOutputFormat struct {
 	Verified            bool
 	StartLine           int64
 	Filename            string
+	Remediation         string
+}
+
+// escapeWorkflowData escapes the message of a GitHub Actions workflow command.
+func escapeWorkflowData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
 }
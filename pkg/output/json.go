@@ -55,6 +55,21 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		Redacted       string
 		ExtraData      map[string]string
 		StructuredData *detectorspb.StructuredData
+		// Severity ranks the impact of the secret leaking.
+		Severity string `json:",omitempty"`
+		// Tags categorize the result, e.g. crypto or china-cloud.
+		Tags []string `json:",omitempty"`
+		// Remediation tells triagers how to contain the leak.
+		Remediation string `json:",omitempty"`
+		// Confidence rates how likely the result is to be a real secret.
+		Confidence string `json:",omitempty"`
+		// Evidence lists the signals that contributed to Confidence.
+		Evidence []detectors.Evidence `json:",omitempty"`
+		// Debug attributes the result to the keywords and patterns that produced it.
+		Debug *detectors.FindingDebug `json:",omitempty"`
+		// FollowUp marks a result that was reported before and is reported again with the findings of its deep
+		// verification.
+		FollowUp bool `json:",omitempty"`
 	}{
 		SourceMetadata:        r.SourceMetadata,
 		SourceID:              r.SourceID,
@@ -72,6 +87,13 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		Redacted:              r.Redacted,
 		ExtraData:             r.ExtraData,
 		StructuredData:        r.StructuredData,
+		Severity:              severityName(r.Severity),
+		Tags:                  r.Tags,
+		Remediation:           r.Remediation,
+		Confidence:            confidenceName(r.Confidence),
+		Evidence:              r.Evidence,
+		Debug:                 r.Debug,
+		FollowUp:              r.FollowUp,
 	}
 	out, err := json.Marshal(v)
 	if err != nil {
@@ -83,3 +105,19 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 	p.mu.Unlock()
 	return nil
 }
+
+// severityName returns the name of s, or an empty string if the result has no severity.
+func severityName(s detectors.Severity) string {
+	if s == detectors.SeverityUnspecified {
+		return ""
+	}
+	return s.String()
+}
+
+// confidenceName returns the name of c, or an empty string if the result has no confidence rating.
+func confidenceName(c detectors.Confidence) string {
+	if c == detectors.ConfidenceUnspecified {
+		return ""
+	}
+	return c.String()
+}
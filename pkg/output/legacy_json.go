@@ -101,6 +101,7 @@ func convertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) (*Leg
 		PrintDiff:    printableDiff,
 		Reason:       r.Result.DetectorType.String(),
 		StringsFound: []string{foundString},
+		Remediation:  r.Result.Remediation,
 	}
 	return output, nil
 }
@@ -226,6 +227,7 @@ type LegacyJSONOutput struct {
 	PrintDiff    string   `json:"printDiff"`
 	Reason       string   `json:"reason"`
 	StringsFound []string `json:"stringsFound"`
+	Remediation  string   `json:"remediation,omitempty"`
 }
 
 type LegacyJSONCompatibleSource interface {
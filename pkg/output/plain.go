@@ -60,6 +60,9 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 	if r.VerificationFromCache {
 		cyanPrinter.Print("(Verification info cached)\n")
 	}
+	if r.FollowUp {
+		cyanPrinter.Print("(Follow-up with deep verification results)\n")
+	}
 	if out.DetectorType == "2026" {
 		out.DetectorType = "dingdoc"
 	}
@@ -97,14 +100,39 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		out.DetectorType = "EthereumPrivateKey"
 	}
 	printer.Printf("Detector Type: %s\n", out.DetectorType)
+	if r.Result.Severity != detectors.SeverityUnspecified {
+		printer.Printf("Severity: %s\n", r.Result.Severity)
+	}
+	if len(r.Result.Tags) > 0 {
+		printer.Printf("Tags: %s\n", strings.Join(r.Result.Tags, ", "))
+	}
+	if r.Result.Remediation != "" {
+		printer.Printf("Remediation: %s\n", r.Result.Remediation)
+	}
+	if r.Result.Confidence != detectors.ConfidenceUnspecified {
+		if len(r.Result.Evidence) > 0 {
+			sources := make([]string, 0, len(r.Result.Evidence))
+			for _, e := range r.Result.Evidence {
+				sources = append(sources, e.Source)
+			}
+			printer.Printf("Confidence: %s (%s)\n", r.Result.Confidence, strings.Join(sources, ", "))
+		} else {
+			printer.Printf("Confidence: %s\n", r.Result.Confidence)
+		}
+	}
 	printer.Printf("Decoder Type: %s\n", out.DecoderType)
 	printer.Printf("Raw result: %s\n", whitePrinter.Sprint(out.Raw))
 
-	for k, v := range r.Result.ExtraData {
+	extraDataKeys := make([]string, 0, len(r.Result.ExtraData))
+	for k := range r.Result.ExtraData {
+		extraDataKeys = append(extraDataKeys, k)
+	}
+	sort.Strings(extraDataKeys)
+	for _, k := range extraDataKeys {
 		printer.Printf(
 			"%s: %v\n",
 			cases.Title(language.AmericanEnglish).String(k),
-			v)
+			r.Result.ExtraData[k])
 	}
 
 	if r.Result.StructuredData != nil {
@@ -137,6 +165,13 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		printer.Printf("%s: %v\n", cases.Title(language.AmericanEnglish).String(k), aggregateData[k])
 	}
 
+	if debug := r.Result.Debug; debug != nil {
+		printer.Printf("Debug Keywords: %s\n", strings.Join(debug.Keywords, ", "))
+		for _, c := range debug.Patterns {
+			printer.Printf("Debug Pattern: %s (group %d, offset %d)\n", c.Pattern, c.Group, c.Offset)
+		}
+	}
+
 	// if analysis info is not nil, means the detector added key for analyzer and result is verified
 	if r.Result.AnalysisInfo != nil && r.Result.Verified {
 		printer.Printf("Analyze: Run `trufflehog analyze` to analyze this key's permissions\n")
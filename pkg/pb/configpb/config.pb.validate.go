@@ -124,6 +124,69 @@ func (m *Config) validate(all bool) error {
 
 	}
 
+	if all {
+		switch v := interface{}(m.GetCandidateRules()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "CandidateRules",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "CandidateRules",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCandidateRules()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "CandidateRules",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetWatchlist() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("Watchlist[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("Watchlist[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigValidationError{
+					field:  fmt.Sprintf("Watchlist[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return ConfigMultiError(errors)
 	}
@@ -200,3 +263,376 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ConfigValidationError{}
+
+// Validate checks the field values on CandidateRules with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CandidateRules) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CandidateRules with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CandidateRulesMultiError,
+// or nil if none found.
+func (m *CandidateRules) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CandidateRules) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetIgnore() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CandidateRulesValidationError{
+						field:  fmt.Sprintf("Ignore[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CandidateRulesValidationError{
+						field:  fmt.Sprintf("Ignore[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CandidateRulesValidationError{
+					field:  fmt.Sprintf("Ignore[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetReport() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CandidateRulesValidationError{
+						field:  fmt.Sprintf("Report[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CandidateRulesValidationError{
+						field:  fmt.Sprintf("Report[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CandidateRulesValidationError{
+					field:  fmt.Sprintf("Report[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CandidateRulesMultiError(errors)
+	}
+
+	return nil
+}
+
+// CandidateRulesMultiError is an error wrapping multiple validation errors
+// returned by CandidateRules.ValidateAll() if the designated constraints
+// aren't met.
+type CandidateRulesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CandidateRulesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CandidateRulesMultiError) AllErrors() []error { return m }
+
+// CandidateRulesValidationError is the validation error returned by
+// CandidateRules.Validate if the designated constraints aren't met.
+type CandidateRulesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CandidateRulesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CandidateRulesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CandidateRulesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CandidateRulesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CandidateRulesValidationError) ErrorName() string { return "CandidateRulesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CandidateRulesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCandidateRules.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CandidateRulesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CandidateRulesValidationError{}
+
+// Validate checks the field values on CandidateRule with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CandidateRule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CandidateRule with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CandidateRuleMultiError, or
+// nil if none found.
+func (m *CandidateRule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CandidateRule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return CandidateRuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// CandidateRuleMultiError is an error wrapping multiple validation errors
+// returned by CandidateRule.ValidateAll() if the designated constraints
+// aren't met.
+type CandidateRuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CandidateRuleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CandidateRuleMultiError) AllErrors() []error { return m }
+
+// CandidateRuleValidationError is the validation error returned by
+// CandidateRule.Validate if the designated constraints aren't met.
+type CandidateRuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CandidateRuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CandidateRuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CandidateRuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CandidateRuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CandidateRuleValidationError) ErrorName() string { return "CandidateRuleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CandidateRuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCandidateRule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CandidateRuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CandidateRuleValidationError{}
+
+// Validate checks the field values on WatchedValue with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchedValue) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchedValue with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchedValueMultiError, or
+// nil if none found.
+func (m *WatchedValue) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchedValue) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Value
+
+	// no validation rules for Label
+
+	if len(errors) > 0 {
+		return WatchedValueMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchedValueMultiError is an error wrapping multiple validation errors
+// returned by WatchedValue.ValidateAll() if the designated constraints aren't met.
+type WatchedValueMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchedValueMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchedValueMultiError) AllErrors() []error { return m }
+
+// WatchedValueValidationError is the validation error returned by
+// WatchedValue.Validate if the designated constraints aren't met.
+type WatchedValueValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchedValueValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchedValueValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchedValueValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchedValueValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchedValueValidationError) ErrorName() string { return "WatchedValueValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchedValueValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchedValue.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchedValueValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchedValueValidationError{}
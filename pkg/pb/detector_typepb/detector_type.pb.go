@@ -170,7 +170,7 @@ const (
 	DetectorType_Zerobounce                    DetectorType = 144
 	DetectorType_Mailboxlayer                  DetectorType = 145
 	DetectorType_Fastspring                    DetectorType = 146 // Not yet implemented
-	DetectorType_Paddle                        DetectorType = 147 // Not yet implemented
+	DetectorType_Paddle                        DetectorType = 147
 	DetectorType_Sellfy                        DetectorType = 148 // Not yet implemented
 	DetectorType_FixerIO                       DetectorType = 149
 	DetectorType_ButterCMS                     DetectorType = 150
@@ -1114,6 +1114,55 @@ const (
 	DetectorType_BitcoinWIF                              DetectorType = 2040
 	DetectorType_EthereumPrivateKey                      DetectorType = 2041
 	DetectorType_CozeToken                               DetectorType = 2042
+	DetectorType_SentryDSN                               DetectorType = 2043
+	DetectorType_EthereumRPC                             DetectorType = 2044
+	DetectorType_MetaMaskVault                           DetectorType = 2045
+	DetectorType_ChiaKey                                 DetectorType = 2046
+	DetectorType_FilecoinPrivateKey                      DetectorType = 2047
+	DetectorType_ArweaveWallet                           DetectorType = 2048
+	DetectorType_LNDMacaroon                             DetectorType = 2049
+	DetectorType_ContractDeployer                        DetectorType = 2050
+	DetectorType_CloudSTS                                DetectorType = 2051
+	DetectorType_Watchlist                               DetectorType = 2052
+	DetectorType_SessionCookie                           DetectorType = 2053
+	DetectorType_SupabaseServiceKey                      DetectorType = 2054
+	DetectorType_Neon                                    DetectorType = 2055
+	DetectorType_AlipayPrivateKey                        DetectorType = 2056
+	DetectorType_WeChatPay                               DetectorType = 2057
+	DetectorType_FrontendStorageKey                      DetectorType = 2058
+	DetectorType_BundledEnvSecret                        DetectorType = 2059
+	DetectorType_CloudInitUserData                       DetectorType = 2060
+	DetectorType_TerraformStateSecret                    DetectorType = 2061
+	DetectorType_AnsibleVault                            DetectorType = 2062
+	DetectorType_SopsAgeFile                             DetectorType = 2063
+	DetectorType_KMSKeyMaterial                          DetectorType = 2064
+	DetectorType_SQLDumpCredential                       DetectorType = 2065
+	DetectorType_OAuthTokenCache                         DetectorType = 2066
+	DetectorType_BrowserCredentialStore                  DetectorType = 2067
+	DetectorType_TOTPSeed                                DetectorType = 2068
+	DetectorType_VPNCredential                           DetectorType = 2069
+	DetectorType_IMDSCredential                          DetectorType = 2070
+	DetectorType_Yunpian                                 DetectorType = 2071
+	DetectorType_Submail                                 DetectorType = 2072
+	DetectorType_NeteaseYidun                            DetectorType = 2073
+	DetectorType_SpringConfig                            DetectorType = 2074
+	DetectorType_HelmValues                              DetectorType = 2075
+	DetectorType_SealedSecretPlaintext                   DetectorType = 2076
+	DetectorType_CodeSigning                             DetectorType = 2077
+	DetectorType_Nomad                                   DetectorType = 2078
+	DetectorType_ConsulConnect                           DetectorType = 2079
+	DetectorType_Etcd                                    DetectorType = 2080
+	DetectorType_CDNAuthKey                              DetectorType = 2081
+	DetectorType_SSHKeyAccess                            DetectorType = 2082
+	DetectorType_CacheAuth                               DetectorType = 2083
+	DetectorType_BitbucketAccessToken                    DetectorType = 2084
+	DetectorType_OIDCClientSecret                        DetectorType = 2085
+	DetectorType_XRPSeed                                 DetectorType = 2086
+	DetectorType_CosmosKey                               DetectorType = 2087
+	DetectorType_LLMConfigExampleKey                     DetectorType = 2088
+	DetectorType_TONWallet                               DetectorType = 2089
+	DetectorType_ProxyBasicAuth                          DetectorType = 2090
+	DetectorType_EthValidatorKeystore                    DetectorType = 2091
 )
 
 // Enum value maps for DetectorType.
@@ -2177,6 +2226,55 @@ var (
 		2040: "BitcoinWIF",
 		2041: "EthereumPrivateKey",
 		2042: "CozeToken",
+		2043: "SentryDSN",
+		2044: "EthereumRPC",
+		2045: "MetaMaskVault",
+		2046: "ChiaKey",
+		2047: "FilecoinPrivateKey",
+		2048: "ArweaveWallet",
+		2049: "LNDMacaroon",
+		2050: "ContractDeployer",
+		2051: "CloudSTS",
+		2052: "Watchlist",
+		2053: "SessionCookie",
+		2054: "SupabaseServiceKey",
+		2055: "Neon",
+		2056: "AlipayPrivateKey",
+		2057: "WeChatPay",
+		2058: "FrontendStorageKey",
+		2059: "BundledEnvSecret",
+		2060: "CloudInitUserData",
+		2061: "TerraformStateSecret",
+		2062: "AnsibleVault",
+		2063: "SopsAgeFile",
+		2064: "KMSKeyMaterial",
+		2065: "SQLDumpCredential",
+		2066: "OAuthTokenCache",
+		2067: "BrowserCredentialStore",
+		2068: "TOTPSeed",
+		2069: "VPNCredential",
+		2070: "IMDSCredential",
+		2071: "Yunpian",
+		2072: "Submail",
+		2073: "NeteaseYidun",
+		2074: "SpringConfig",
+		2075: "HelmValues",
+		2076: "SealedSecretPlaintext",
+		2077: "CodeSigning",
+		2078: "Nomad",
+		2079: "ConsulConnect",
+		2080: "Etcd",
+		2081: "CDNAuthKey",
+		2082: "SSHKeyAccess",
+		2083: "CacheAuth",
+		2084: "BitbucketAccessToken",
+		2085: "OIDCClientSecret",
+		2086: "XRPSeed",
+		2087: "CosmosKey",
+		2088: "LLMConfigExampleKey",
+		2089: "TONWallet",
+		2090: "ProxyBasicAuth",
+		2091: "EthValidatorKeystore",
 	}
 	DetectorType_value = map[string]int32{
 		"Alibaba":                               0,
@@ -3237,6 +3335,55 @@ var (
 		"BitcoinWIF":                        2040,
 		"EthereumPrivateKey":                2041,
 		"CozeToken":                         2042,
+		"SentryDSN":                         2043,
+		"EthereumRPC":                       2044,
+		"MetaMaskVault":                     2045,
+		"ChiaKey":                           2046,
+		"FilecoinPrivateKey":                2047,
+		"ArweaveWallet":                     2048,
+		"LNDMacaroon":                       2049,
+		"ContractDeployer":                  2050,
+		"CloudSTS":                          2051,
+		"Watchlist":                         2052,
+		"SessionCookie":                     2053,
+		"SupabaseServiceKey":                2054,
+		"Neon":                              2055,
+		"AlipayPrivateKey":                  2056,
+		"WeChatPay":                         2057,
+		"FrontendStorageKey":                2058,
+		"BundledEnvSecret":                  2059,
+		"CloudInitUserData":                 2060,
+		"TerraformStateSecret":              2061,
+		"AnsibleVault":                      2062,
+		"SopsAgeFile":                       2063,
+		"KMSKeyMaterial":                    2064,
+		"SQLDumpCredential":                 2065,
+		"OAuthTokenCache":                   2066,
+		"BrowserCredentialStore":            2067,
+		"TOTPSeed":                          2068,
+		"VPNCredential":                     2069,
+		"IMDSCredential":                    2070,
+		"Yunpian":                           2071,
+		"Submail":                           2072,
+		"NeteaseYidun":                      2073,
+		"SpringConfig":                      2074,
+		"HelmValues":                        2075,
+		"SealedSecretPlaintext":             2076,
+		"CodeSigning":                       2077,
+		"Nomad":                             2078,
+		"ConsulConnect":                     2079,
+		"Etcd":                              2080,
+		"CDNAuthKey":                        2081,
+		"SSHKeyAccess":                      2082,
+		"CacheAuth":                         2083,
+		"BitbucketAccessToken":              2084,
+		"OIDCClientSecret":                  2085,
+		"XRPSeed":                           2086,
+		"CosmosKey":                         2087,
+		"LLMConfigExampleKey":               2088,
+		"TONWallet":                         2089,
+		"ProxyBasicAuth":                    2090,
+		"EthValidatorKeystore":              2091,
 	}
 )
 
@@ -3272,7 +3419,7 @@ var File_detector_type_proto protoreflect.FileDescriptor
 var file_detector_type_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x2a, 0xbb, 0x87, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x74, 0x79, 0x70, 0x65, 0x2a, 0x81, 0x91, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
 	0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x6c, 0x69, 0x62, 0x61, 0x62,
 	0x61, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x4d, 0x51, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a,
 	0x03, 0x41, 0x57, 0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x10,
@@ -4356,11 +4503,88 @@ var file_detector_type_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x63, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0x92, 0x08, 0x12, 0x12, 0x0a, 0x0d,
 	0x44, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x41, 0x70, 0x69, 0x6b, 0x65, 0x79, 0x10, 0x93, 0x08,
 	0x12, 0x11, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x70, 0x69, 0x66, 0x79, 0x4f, 0x41, 0x75, 0x74, 0x68,
-	0x10, 0x94, 0x08, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
-	0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68, 0x6f, 0x67, 0x2f, 0x76, 0x33, 0x2f,
-	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x10, 0x94, 0x08, 0x12, 0x0c, 0x0a, 0x07, 0x44, 0x69, 0x6e, 0x67, 0x44, 0x6f, 0x63, 0x10, 0xea,
+	0x0f, 0x12, 0x0a, 0x0a, 0x05, 0x59, 0x75, 0x51, 0x75, 0x65, 0x10, 0xeb, 0x0f, 0x12, 0x0c, 0x0a,
+	0x07, 0x42, 0x61, 0x69, 0x4c, 0x69, 0x61, 0x6e, 0x10, 0xec, 0x0f, 0x12, 0x0a, 0x0a, 0x05, 0x42,
+	0x61, 0x69, 0x64, 0x75, 0x10, 0xed, 0x0f, 0x12, 0x0c, 0x0a, 0x07, 0x54, 0x65, 0x6e, 0x63, 0x65,
+	0x6e, 0x74, 0x10, 0xee, 0x0f, 0x12, 0x0f, 0x0a, 0x0a, 0x56, 0x6f, 0x6c, 0x63, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x10, 0xef, 0x0f, 0x12, 0x0b, 0x0a, 0x06, 0x48, 0x75, 0x61, 0x77, 0x65, 0x69,
+	0x10, 0xf0, 0x0f, 0x12, 0x0b, 0x0a, 0x06, 0x44, 0x6f, 0x75, 0x62, 0x61, 0x6f, 0x10, 0xf1, 0x0f,
+	0x12, 0x10, 0x0a, 0x0b, 0x42, 0x61, 0x69, 0x64, 0x75, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x10,
+	0xf2, 0x0f, 0x12, 0x0c, 0x0a, 0x07, 0x48, 0x75, 0x6e, 0x59, 0x75, 0x61, 0x6e, 0x10, 0xf3, 0x0f,
+	0x12, 0x0e, 0x0a, 0x09, 0x41, 0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x64, 0x6d, 0x10, 0xf4, 0x0f,
+	0x12, 0x0e, 0x0a, 0x09, 0x54, 0x65, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x4b, 0x10, 0xf5, 0x0f,
+	0x12, 0x0e, 0x0a, 0x09, 0x41, 0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x61, 0x6b, 0x10, 0xf6, 0x0f,
+	0x12, 0x0b, 0x0a, 0x06, 0x42, 0x61, 0x69, 0x64, 0x75, 0x32, 0x10, 0xf7, 0x0f, 0x12, 0x0f, 0x0a,
+	0x0a, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x57, 0x49, 0x46, 0x10, 0xf8, 0x0f, 0x12, 0x17,
+	0x0a, 0x12, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x4b, 0x65, 0x79, 0x10, 0xf9, 0x0f, 0x12, 0x0e, 0x0a, 0x09, 0x43, 0x6f, 0x7a, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x10, 0xfa, 0x0f, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x44, 0x53, 0x4e, 0x10, 0xfb, 0x0f, 0x12, 0x10, 0x0a, 0x0b, 0x45, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x52, 0x50, 0x43, 0x10, 0xfc, 0x0f, 0x12, 0x12, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x4d, 0x61, 0x73, 0x6b, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x10, 0xfd, 0x0f, 0x12, 0x0c, 0x0a,
+	0x07, 0x43, 0x68, 0x69, 0x61, 0x4b, 0x65, 0x79, 0x10, 0xfe, 0x0f, 0x12, 0x17, 0x0a, 0x12, 0x46,
+	0x69, 0x6c, 0x65, 0x63, 0x6f, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65,
+	0x79, 0x10, 0xff, 0x0f, 0x12, 0x12, 0x0a, 0x0d, 0x41, 0x72, 0x77, 0x65, 0x61, 0x76, 0x65, 0x57,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x10, 0x80, 0x10, 0x12, 0x10, 0x0a, 0x0b, 0x4c, 0x4e, 0x44, 0x4d,
+	0x61, 0x63, 0x61, 0x72, 0x6f, 0x6f, 0x6e, 0x10, 0x81, 0x10, 0x12, 0x15, 0x0a, 0x10, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x72, 0x10, 0x82,
+	0x10, 0x12, 0x0d, 0x0a, 0x08, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x53, 0x54, 0x53, 0x10, 0x83, 0x10,
+	0x12, 0x0e, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x6c, 0x69, 0x73, 0x74, 0x10, 0x84, 0x10,
+	0x12, 0x12, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6f, 0x6b, 0x69,
+	0x65, 0x10, 0x85, 0x10, 0x12, 0x17, 0x0a, 0x12, 0x53, 0x75, 0x70, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x10, 0x86, 0x10, 0x12, 0x09, 0x0a,
+	0x04, 0x4e, 0x65, 0x6f, 0x6e, 0x10, 0x87, 0x10, 0x12, 0x15, 0x0a, 0x10, 0x41, 0x6c, 0x69, 0x70,
+	0x61, 0x79, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x10, 0x88, 0x10, 0x12,
+	0x0e, 0x0a, 0x09, 0x57, 0x65, 0x43, 0x68, 0x61, 0x74, 0x50, 0x61, 0x79, 0x10, 0x89, 0x10, 0x12,
+	0x17, 0x0a, 0x12, 0x46, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x4b, 0x65, 0x79, 0x10, 0x8a, 0x10, 0x12, 0x15, 0x0a, 0x10, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x64, 0x45, 0x6e, 0x76, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x10, 0x8b, 0x10, 0x12,
+	0x16, 0x0a, 0x11, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x44, 0x61, 0x74, 0x61, 0x10, 0x8c, 0x10, 0x12, 0x19, 0x0a, 0x14, 0x54, 0x65, 0x72, 0x72, 0x61,
+	0x66, 0x6f, 0x72, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x10,
+	0x8d, 0x10, 0x12, 0x11, 0x0a, 0x0c, 0x41, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x75,
+	0x6c, 0x74, 0x10, 0x8e, 0x10, 0x12, 0x10, 0x0a, 0x0b, 0x53, 0x6f, 0x70, 0x73, 0x41, 0x67, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x10, 0x8f, 0x10, 0x12, 0x13, 0x0a, 0x0e, 0x4b, 0x4d, 0x53, 0x4b, 0x65,
+	0x79, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x10, 0x90, 0x10, 0x12, 0x16, 0x0a, 0x11,
+	0x53, 0x51, 0x4c, 0x44, 0x75, 0x6d, 0x70, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x10, 0x91, 0x10, 0x12, 0x14, 0x0a, 0x0f, 0x4f, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x10, 0x92, 0x10, 0x12, 0x1b, 0x0a, 0x16, 0x42, 0x72,
+	0x6f, 0x77, 0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x10, 0x93, 0x10, 0x12, 0x0d, 0x0a, 0x08, 0x54, 0x4f, 0x54, 0x50, 0x53,
+	0x65, 0x65, 0x64, 0x10, 0x94, 0x10, 0x12, 0x12, 0x0a, 0x0d, 0x56, 0x50, 0x4e, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x10, 0x95, 0x10, 0x12, 0x13, 0x0a, 0x0e, 0x49, 0x4d,
+	0x44, 0x53, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x10, 0x96, 0x10, 0x12,
+	0x0c, 0x0a, 0x07, 0x59, 0x75, 0x6e, 0x70, 0x69, 0x61, 0x6e, 0x10, 0x97, 0x10, 0x12, 0x0c, 0x0a,
+	0x07, 0x53, 0x75, 0x62, 0x6d, 0x61, 0x69, 0x6c, 0x10, 0x98, 0x10, 0x12, 0x11, 0x0a, 0x0c, 0x4e,
+	0x65, 0x74, 0x65, 0x61, 0x73, 0x65, 0x59, 0x69, 0x64, 0x75, 0x6e, 0x10, 0x99, 0x10, 0x12, 0x11,
+	0x0a, 0x0c, 0x53, 0x70, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0x9a,
+	0x10, 0x12, 0x0f, 0x0a, 0x0a, 0x48, 0x65, 0x6c, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x10,
+	0x9b, 0x10, 0x12, 0x1a, 0x0a, 0x15, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x50, 0x6c, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x10, 0x9c, 0x10, 0x12, 0x10,
+	0x0a, 0x0b, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x9d, 0x10,
+	0x12, 0x0a, 0x0a, 0x05, 0x4e, 0x6f, 0x6d, 0x61, 0x64, 0x10, 0x9e, 0x10, 0x12, 0x12, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x10, 0x9f, 0x10,
+	0x12, 0x09, 0x0a, 0x04, 0x45, 0x74, 0x63, 0x64, 0x10, 0xa0, 0x10, 0x12, 0x0f, 0x0a, 0x0a, 0x43,
+	0x44, 0x4e, 0x41, 0x75, 0x74, 0x68, 0x4b, 0x65, 0x79, 0x10, 0xa1, 0x10, 0x12, 0x11, 0x0a, 0x0c,
+	0x53, 0x53, 0x48, 0x4b, 0x65, 0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0xa2, 0x10, 0x12,
+	0x0e, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x41, 0x75, 0x74, 0x68, 0x10, 0xa3, 0x10, 0x12,
+	0x19, 0x0a, 0x14, 0x42, 0x69, 0x74, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0xa4, 0x10, 0x12, 0x15, 0x0a, 0x10, 0x4f, 0x49,
+	0x44, 0x43, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x10, 0xa5,
+	0x10, 0x12, 0x0c, 0x0a, 0x07, 0x58, 0x52, 0x50, 0x53, 0x65, 0x65, 0x64, 0x10, 0xa6, 0x10, 0x12,
+	0x0e, 0x0a, 0x09, 0x43, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x4b, 0x65, 0x79, 0x10, 0xa7, 0x10, 0x12,
+	0x18, 0x0a, 0x13, 0x4c, 0x4c, 0x4d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x4b, 0x65, 0x79, 0x10, 0xa8, 0x10, 0x12, 0x0e, 0x0a, 0x09, 0x54, 0x4f, 0x4e,
+	0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x10, 0xa9, 0x10, 0x12, 0x13, 0x0a, 0x0e, 0x50, 0x72, 0x6f,
+	0x78, 0x79, 0x42, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x10, 0xaa, 0x10, 0x12, 0x19,
+	0x0a, 0x14, 0x45, 0x74, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x4b, 0x65,
+	0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x10, 0xab, 0x10, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68,
+	0x6f, 0x67, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -0,0 +1,291 @@
+// Package quarantine preserves evidence of verified secrets for incident response. The files containing verified
+// findings are copied into an OpenPGP encrypted tar.gz bundle, together with a manifest of their hashes and of where
+// the findings were made, before the history they were found in can be rewritten.
+package quarantine
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/version"
+)
+
+// ManifestName is the name of the manifest in the bundle. It is the last entry of the archive.
+const ManifestName = "manifest.json"
+
+// maxFileSize bounds the size of a single file copied into the bundle. Larger files are represented by the chunk the
+// finding was made in.
+const maxFileSize = 256 * 1024 * 1024
+
+// ErrClosed is returned when adding evidence to a closed bundle.
+var ErrClosed = errors.New("evidence bundle is closed")
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Version is the version of trufflehog that created the bundle.
+	Version string  `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a piece of evidence in the bundle.
+type Entry struct {
+	// Name is the path of the evidence in the archive.
+	Name string `json:"name"`
+	// Origin is "file" for a copy of a whole file, or "chunk" for the data the finding was made in when the file itself
+	// could not be read, e.g. for findings in git history.
+	Origin string `json:"origin"`
+	// Path is the path of the file the evidence was copied from, if it was found in a local file.
+	Path     string    `json:"path,omitempty"`
+	SHA256   string    `json:"sha256"`
+	Size     int64     `json:"size"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding locates a verified finding in a piece of evidence. It never contains the raw secret, which can be found in
+// the evidence itself.
+type Finding struct {
+	DetectorName string `json:"detector_name"`
+	Redacted     string `json:"redacted,omitempty"`
+	SourceName   string `json:"source_name"`
+	SourceType   string `json:"source_type"`
+	// SourceMetadata is the location of the finding, in the format of the JSON output.
+	SourceMetadata json.RawMessage `json:"source_metadata,omitempty"`
+}
+
+// Config configures the encryption of a bundle. The bundle is encrypted to Recipients if there are any, or else with
+// Passphrase.
+type Config struct {
+	Recipients openpgp.EntityList
+	Passphrase []byte
+}
+
+// ReadRecipients reads the armored OpenPGP public keys that a bundle is encrypted to.
+func ReadRecipients(r io.Reader) (openpgp.EntityList, error) {
+	recipients, err := openpgp.ReadArmoredKeyRing(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OpenPGP public keys: %w", err)
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("no OpenPGP public keys found")
+	}
+	return recipients, nil
+}
+
+// Bundle is an encrypted evidence bundle. It is safe for concurrent use.
+type Bundle struct {
+	mu       sync.Mutex
+	encrypt  io.WriteCloser
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest Manifest
+	// entries indexes the manifest entries by the path or, for chunks, the hash of their evidence.
+	entries map[string]int
+	closed  bool
+}
+
+// NewBundle creates a bundle that writes its encrypted archive to out. The archive is complete once the bundle is
+// closed.
+func NewBundle(out io.Writer, cfg Config) (*Bundle, error) {
+	var (
+		encrypt io.WriteCloser
+		err     error
+	)
+	hints := &openpgp.FileHints{IsBinary: true, FileName: "evidence.tar.gz"}
+	switch {
+	case len(cfg.Recipients) > 0:
+		encrypt, err = openpgp.Encrypt(out, cfg.Recipients, nil, hints, nil)
+	case len(cfg.Passphrase) > 0:
+		encrypt, err = openpgp.SymmetricallyEncrypt(out, cfg.Passphrase, hints, nil)
+	default:
+		return nil, errors.New("evidence bundle needs recipients or a passphrase")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting evidence bundle: %w", err)
+	}
+
+	gz := gzip.NewWriter(encrypt)
+	return &Bundle{
+		encrypt:  encrypt,
+		gz:       gz,
+		tw:       tar.NewWriter(gz),
+		manifest: Manifest{CreatedAt: time.Now().UTC(), Version: version.BuildVersion},
+		entries:  make(map[string]int),
+	}, nil
+}
+
+// Add adds the evidence of a finding to the bundle. A file with several findings is only copied once.
+func (b *Bundle) Add(ctx context.Context, r detectors.ResultWithMetadata) error {
+	finding := Finding{
+		DetectorName: r.DetectorType.String(),
+		Redacted:     r.Redacted,
+		SourceName:   r.SourceName,
+		SourceType:   r.SourceType.String(),
+	}
+	if r.SourceMetadata != nil {
+		metadata, err := protojson.Marshal(r.SourceMetadata)
+		if err != nil {
+			return fmt.Errorf("error encoding source metadata: %w", err)
+		}
+		finding.SourceMetadata = metadata
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+
+	filePath := r.SourceMetadata.GetFilesystem().GetFile()
+	if filePath != "" {
+		if i, ok := b.entries[filePath]; ok {
+			b.manifest.Entries[i].Findings = append(b.manifest.Entries[i].Findings, finding)
+			return nil
+		}
+		data, err := readFile(filePath)
+		if err == nil {
+			return b.addEntry(filePath, Entry{Origin: "file", Path: filePath}, data, finding)
+		}
+		// The file may have been changed or removed since it was scanned.
+		ctx.Logger().V(2).Info("error copying file into evidence bundle, preserving the chunk instead", "path", filePath, "error", err)
+	}
+
+	if r.ChunkData == nil {
+		return errors.New("no evidence to preserve: the chunk data of the finding is not available")
+	}
+	sum := sha256.Sum256(r.ChunkData)
+	key := hex.EncodeToString(sum[:])
+	if i, ok := b.entries[key]; ok {
+		b.manifest.Entries[i].Findings = append(b.manifest.Entries[i].Findings, finding)
+		return nil
+	}
+	return b.addEntry(key, Entry{Origin: "chunk", Path: filePath}, r.ChunkData, finding)
+}
+
+// addEntry writes data to the archive and records it in the manifest under key. b.mu must be held.
+func (b *Bundle) addEntry(key string, entry Entry, data []byte, finding Finding) error {
+	sum := sha256.Sum256(data)
+	entry.SHA256 = hex.EncodeToString(sum[:])
+	entry.Size = int64(len(data))
+	entry.Findings = []Finding{finding}
+
+	// Names are numbered, so that files of the same name from different directories do not collide.
+	base := "chunk"
+	if entry.Origin == "file" {
+		base = filepath.Base(entry.Path)
+	}
+	entry.Name = path.Join("evidence", fmt.Sprintf("%04d-%s", len(b.manifest.Entries)+1, base))
+
+	if err := b.writeFile(entry.Name, data); err != nil {
+		return err
+	}
+	b.entries[key] = len(b.manifest.Entries)
+	b.manifest.Entries = append(b.manifest.Entries, entry)
+	return nil
+}
+
+func (b *Bundle) writeFile(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: time.Now().UTC(),
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("error writing %s to evidence bundle: %w", name, err)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return fmt.Errorf("error writing %s to evidence bundle: %w", name, err)
+	}
+	return nil
+}
+
+// Close writes the manifest and finishes the encrypted archive. It does not close the underlying writer.
+func (b *Bundle) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	manifest, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding evidence manifest: %w", err)
+	}
+	if err := b.writeFile(ManifestName, manifest); err != nil {
+		return err
+	}
+	if err := b.tw.Close(); err != nil {
+		return fmt.Errorf("error finishing evidence archive: %w", err)
+	}
+	if err := b.gz.Close(); err != nil {
+		return fmt.Errorf("error finishing evidence archive: %w", err)
+	}
+	if err := b.encrypt.Close(); err != nil {
+		return fmt.Errorf("error finishing evidence encryption: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of pieces of evidence in the bundle.
+func (b *Bundle) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.manifest.Entries)
+}
+
+func readFile(filePath string) ([]byte, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("not a regular file")
+	}
+	if info.Size() > maxFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxFileSize)
+	}
+	return os.ReadFile(filePath)
+}
+
+// Dispatcher preserves the evidence of verified results in a bundle and passes all results on to the next
+// dispatcher.
+type Dispatcher struct {
+	next   engine.ResultsDispatcher
+	bundle *Bundle
+}
+
+// NewDispatcher creates a Dispatcher that preserves evidence in bundle before dispatching results to next.
+func NewDispatcher(next engine.ResultsDispatcher, bundle *Bundle) *Dispatcher {
+	return &Dispatcher{next: next, bundle: bundle}
+}
+
+// Dispatch preserves the evidence of a verified result and dispatches it to the next dispatcher. Failing to preserve
+// evidence is logged and does not stop the result from being reported.
+func (d *Dispatcher) Dispatch(ctx context.Context, result detectors.ResultWithMetadata) error {
+	// Follow-ups were preserved when they were first dispatched.
+	if result.Verified && !result.FollowUp {
+		if err := d.bundle.Add(ctx, result); err != nil {
+			ctx.Logger().Error(err, "error preserving evidence of verified result", "detector", result.DetectorType.String())
+		}
+	}
+	return d.next.Dispatch(ctx, result)
+}
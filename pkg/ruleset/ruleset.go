@@ -0,0 +1,216 @@
+// Package ruleset loads rulesets: bundles of custom detectors, candidate rules and watchlists that are published at an
+// HTTPS endpoint and signed with an Ed25519 key, so that detection coverage can be updated without a new release.
+//
+// A ruleset is a configuration file (see --config) without sources. Its signature is published next to it, at the URL
+// of the ruleset with ".sig" appended, as the base64 encoded Ed25519 signature of the ruleset file. A ruleset is only
+// loaded if its signature verifies with the configured public key.
+package ruleset
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/configpb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/protoyaml"
+)
+
+// maxSize limits the size of a ruleset, so that a misconfigured endpoint cannot exhaust memory.
+const maxSize = 16 * 1024 * 1024
+
+// Cache file names within Options.CacheDir.
+const (
+	cacheFile          = "ruleset.yaml"
+	cacheSignatureFile = "ruleset.yaml.sig"
+)
+
+// ErrSignature is returned if the signature of a ruleset does not verify.
+var ErrSignature = errors.New("invalid ruleset signature")
+
+// Options configure where a ruleset is loaded from.
+type Options struct {
+	// URL is the HTTPS URL of the ruleset.
+	URL string
+	// PublicKey verifies the signature of the ruleset.
+	PublicKey ed25519.PublicKey
+	// CacheDir, if set, keeps the last verified ruleset. It is used if the ruleset cannot be fetched.
+	CacheDir string
+	// Client fetches the ruleset. It defaults to common.SaneHttpClient.
+	Client *http.Client
+}
+
+// Ruleset is a verified ruleset.
+type Ruleset struct {
+	*config.Config
+	// SHA256 is the hash of the ruleset file.
+	SHA256 string
+	// Cached is set if the ruleset was loaded from the cache because it could not be fetched.
+	Cached bool
+}
+
+// ParsePublicKey parses a base64 encoded Ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ruleset public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ruleset public key: want %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return key, nil
+}
+
+// Load fetches the ruleset, verifies its signature and parses it. If the ruleset cannot be fetched, the last verified
+// ruleset in the cache is used instead, if there is one. A ruleset with an invalid signature is never used.
+func Load(ctx context.Context, opts Options) (*Ruleset, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ruleset URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid ruleset URL %q: only https is supported", opts.URL)
+	}
+	if len(opts.PublicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("a public key is required to verify the ruleset")
+	}
+	client := opts.Client
+	if client == nil {
+		client = common.SaneHttpClient()
+	}
+
+	data, sig, fetchErr := fetch(ctx, client, opts.URL)
+	if fetchErr != nil {
+		if opts.CacheDir == "" {
+			return nil, fetchErr
+		}
+		ctx.Logger().Error(fetchErr, "failed to fetch ruleset, using the cached ruleset", "url", opts.URL)
+		data, sig, err = readCache(opts.CacheDir)
+		if err != nil {
+			return nil, errors.Join(fetchErr, fmt.Errorf("failed to read cached ruleset: %w", err))
+		}
+	}
+
+	rs, err := Parse(data, sig, opts.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	rs.Cached = fetchErr != nil
+
+	if !rs.Cached && opts.CacheDir != "" {
+		if err := writeCache(opts.CacheDir, data, sig); err != nil {
+			ctx.Logger().Error(err, "failed to cache ruleset", "dir", opts.CacheDir)
+		}
+	}
+	return rs, nil
+}
+
+// Parse verifies the base64 encoded signature of a ruleset and parses it.
+func Parse(data, sig []byte, key ed25519.PublicKey) (*Ruleset, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil || !ed25519.Verify(key, data, decoded) {
+		return nil, ErrSignature
+	}
+
+	var pbConfig configpb.Config
+	if err := protoyaml.UnmarshalStrict(data, &pbConfig); err != nil {
+		return nil, fmt.Errorf("invalid ruleset: %w", err)
+	}
+	if len(pbConfig.GetSources()) > 0 {
+		return nil, errors.New("invalid ruleset: rulesets cannot configure sources")
+	}
+	// Remote detectors must not send the secrets they find to an endpoint chosen by whoever publishes the ruleset.
+	for _, detector := range pbConfig.GetDetectors() {
+		if len(detector.GetVerify()) > 0 {
+			return nil, fmt.Errorf("invalid ruleset: detector %q has verification endpoints, which rulesets cannot configure", detector.GetName())
+		}
+	}
+
+	conf, err := config.NewYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ruleset: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return &Ruleset{Config: conf, SHA256: hex.EncodeToString(sum[:])}, nil
+}
+
+// fetch downloads the ruleset at rulesetURL and its signature.
+func fetch(ctx context.Context, client *http.Client, rulesetURL string) (data, sig []byte, err error) {
+	data, err = get(ctx, client, rulesetURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = get(ctx, client, rulesetURL+".sig")
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, sig, nil
+}
+
+func get(ctx context.Context, client *http.Client, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected HTTP response status %d", endpoint, res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", endpoint, err)
+	}
+	if len(body) > maxSize {
+		return nil, fmt.Errorf("failed to fetch %s: larger than %d bytes", endpoint, maxSize)
+	}
+	return body, nil
+}
+
+func readCache(dir string) (data, sig []byte, err error) {
+	data, err = os.ReadFile(filepath.Join(dir, cacheFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = os.ReadFile(filepath.Join(dir, cacheSignatureFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, sig, nil
+}
+
+// writeCache replaces the cached ruleset and its signature. Each file is replaced atomically; a ruleset and a signature
+// left from different fetches by an interrupted write do not verify, and are not used.
+func writeCache(dir string, data, sig []byte) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{cacheFile, data}, {cacheSignatureFile, sig}} {
+		tmp := filepath.Join(dir, f.name+".tmp")
+		if err := os.WriteFile(tmp, f.data, 0o600); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, filepath.Join(dir, f.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package ruleset
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const testRuleset = `detectors:
+- name: internal-deploy-key
+  keywords:
+  - dpk_
+  regex:
+    key: "\\b(dpk_[a-z0-9]{32})\\b"
+candidate_rules:
+  ignore:
+  - name: test-fixtures
+    literal:
+    - dpk_00000000000000000000000000000000
+`
+
+func newServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoad(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(testRuleset))))
+
+	files := map[string][]byte{
+		"/ruleset.yaml":     []byte(testRuleset),
+		"/ruleset.yaml.sig": sig,
+	}
+	server := newServer(t, files)
+	opts := Options{
+		URL:       server.URL + "/ruleset.yaml",
+		PublicKey: pub,
+		CacheDir:  t.TempDir(),
+		Client:    server.Client(),
+	}
+
+	rs, err := Load(context.Background(), opts)
+	require.NoError(t, err)
+	assert.False(t, rs.Cached)
+	require.Len(t, rs.Detectors, 1)
+	require.NotNil(t, rs.CandidateRules)
+	assert.Len(t, rs.CandidateRules.Ignore, 1)
+
+	// The last verified ruleset is used while the endpoint is unavailable.
+	delete(files, "/ruleset.yaml")
+	rs, err = Load(context.Background(), opts)
+	require.NoError(t, err)
+	assert.True(t, rs.Cached)
+	assert.Len(t, rs.Detectors, 1)
+
+	// A tampered ruleset is rejected, even with a verified one in the cache.
+	files["/ruleset.yaml"] = []byte(testRuleset + "  - name: allow-all\n    regex:\n    - .\n")
+	_, err = Load(context.Background(), opts)
+	assert.ErrorIs(t, err, ErrSignature)
+
+	// A tampered cache is rejected too.
+	delete(files, "/ruleset.yaml")
+	require.NoError(t, os.WriteFile(filepath.Join(opts.CacheDir, cacheFile), files["/ruleset.yaml.sig"], 0o600))
+	_, err = Load(context.Background(), opts)
+	assert.ErrorIs(t, err, ErrSignature)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sign := func(data string) []byte {
+		return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(data))))
+	}
+
+	_, err = Parse([]byte(testRuleset), sign(testRuleset), otherPub)
+	assert.ErrorIs(t, err, ErrSignature)
+
+	withVerifier := `detectors:
+- name: exfiltrate
+  keywords:
+  - key
+  regex:
+    key: "key=(\\w+)"
+  verify:
+  - endpoint: https://example.com/collect
+`
+	_, err = Parse([]byte(withVerifier), sign(withVerifier), pub)
+	assert.ErrorContains(t, err, "verification endpoints")
+
+	withSource := "sources:\n- type: SOURCE_TYPE_FILESYSTEM\n  name: root\n"
+	_, err = Parse([]byte(withSource), sign(withSource), pub)
+	assert.ErrorContains(t, err, "cannot configure sources")
+
+	_, err = Load(context.Background(), Options{URL: "http://rules.example.com/ruleset.yaml", PublicKey: pub})
+	assert.ErrorContains(t, err, "only https")
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	got, err := ParsePublicKey(base64.StdEncoding.EncodeToString(pub) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, pub, got)
+
+	_, err = ParsePublicKey(base64.StdEncoding.EncodeToString(pub[:16]))
+	assert.Error(t, err)
+}
@@ -0,0 +1,264 @@
+// Package scandiff compares the secrets found by two scans, e.g. of two releases, and reports the secrets added,
+// removed and persisting between them. Secrets are matched by detector and raw value, regardless of where they were
+// found, so that a secret that moved to another file is not reported as new.
+package scandiff
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// Change is how a secret changed between two scans.
+type Change string
+
+const (
+	Added      Change = "added"
+	Removed    Change = "removed"
+	Persisting Change = "persisting"
+)
+
+// Finding is a secret found by a scan. It holds no raw secret data.
+type Finding struct {
+	// ID identifies the secret across scans. It is a hash of the detector type and the raw secret.
+	ID           string
+	DetectorType detector_typepb.DetectorType
+	DetectorName string
+	Redacted     string
+	Verified     bool
+	// Locations are where the secret was found, e.g. file:line@commit.
+	Locations []string
+}
+
+// Set is the set of secrets found by a scan. It is safe for concurrent use.
+type Set struct {
+	mu       sync.Mutex
+	findings map[string]*Finding
+}
+
+// NewSet creates an empty Set.
+func NewSet() *Set {
+	return &Set{findings: make(map[string]*Finding)}
+}
+
+// Dispatch adds a result of a scan to the set. It implements engine.ResultsDispatcher, so that a Set can collect the
+// results of an engine directly.
+func (s *Set) Dispatch(_ context.Context, r detectors.ResultWithMetadata) error {
+	raw := r.RawV2
+	if len(raw) == 0 {
+		raw = r.Raw
+	}
+	var meta map[string]any
+	if r.SourceMetadata != nil {
+		// Marshal the metadata the way the JSON output does, so that locations read back from a report match.
+		data, err := json.Marshal(r.SourceMetadata)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return err
+		}
+	}
+	s.add(r.DetectorType, r.DetectorType.String(), raw, r.Redacted, r.Verified, location(meta))
+	return nil
+}
+
+func (s *Set) add(typ detector_typepb.DetectorType, name string, raw []byte, redacted string, verified bool, loc string) {
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(int(typ))))
+	h.Write([]byte{0})
+	h.Write(raw)
+	id := hex.EncodeToString(h.Sum(nil))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.findings[id]
+	if !ok {
+		f = &Finding{ID: id, DetectorType: typ, DetectorName: name, Redacted: redacted}
+		s.findings[id] = f
+	}
+	// A result reported again after deep verification may only then be verified.
+	f.Verified = f.Verified || verified
+	if loc != "" && !slices.Contains(f.Locations, loc) {
+		f.Locations = append(f.Locations, loc)
+	}
+}
+
+// Findings returns the secrets in the set, ordered by detector and redacted value.
+func (s *Set) Findings() []Finding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	findings := make([]Finding, 0, len(s.findings))
+	for _, f := range s.findings {
+		findings = append(findings, *f)
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// reportLine is the part of a line of the JSON output (--json) that identifies a secret.
+type reportLine struct {
+	DetectorType   detector_typepb.DetectorType
+	DetectorName   string
+	Raw            string
+	RawV2          string
+	Redacted       string
+	Verified       bool
+	SourceMetadata map[string]any
+}
+
+// ReadReport reads the secrets in a report written with --json. Lines that are not JSON objects, such as log lines
+// mixed into the output, are skipped. Reports written with --hash-secrets can only be compared with reports and scans
+// hashed with the same key.
+func ReadReport(r io.Reader) (*Set, error) {
+	set := NewSet()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var l reportLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		raw := l.RawV2
+		if raw == "" {
+			raw = l.Raw
+		}
+		name := cmp.Or(l.DetectorName, l.DetectorType.String())
+		set.add(l.DetectorType, name, []byte(raw), l.Redacted, l.Verified, location(l.SourceMetadata))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// location describes where a secret was found from the source metadata of a result, e.g.
+// {"Data": {"Git": {"file": "config.yml", "line": 3, "commit": "..."}}}.
+func location(meta map[string]any) string {
+	data, _ := meta["Data"].(map[string]any)
+	var fields map[string]any
+	for _, v := range data {
+		fields, _ = v.(map[string]any)
+	}
+	if fields == nil {
+		return ""
+	}
+
+	str := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+	loc := cmp.Or(str("file"), str("link"), str("path"))
+	if line, ok := fields["line"].(float64); ok && line > 0 && loc != "" {
+		loc += ":" + strconv.Itoa(int(line))
+	}
+	if commit := str("commit"); commit != "" {
+		loc += "@" + commit[:min(len(commit), 12)]
+	}
+	if image := str("image"); image != "" {
+		if loc == "" {
+			return image
+		}
+		loc = image + ":" + loc
+	}
+	return loc
+}
+
+// Diff is the difference between the secrets found by two scans.
+type Diff struct {
+	Added      []Finding
+	Removed    []Finding
+	Persisting []Finding
+}
+
+// Compare compares the secrets found by an old and a new scan. Added and persisting secrets are reported with their
+// locations in the new scan, removed secrets with their locations in the old one.
+func Compare(old, new *Set) Diff {
+	var d Diff
+	oldFindings := make(map[string]Finding)
+	for _, f := range old.Findings() {
+		oldFindings[f.ID] = f
+	}
+	for _, f := range new.Findings() {
+		if _, ok := oldFindings[f.ID]; ok {
+			d.Persisting = append(d.Persisting, f)
+			delete(oldFindings, f.ID)
+		} else {
+			d.Added = append(d.Added, f)
+		}
+	}
+	for _, f := range oldFindings {
+		d.Removed = append(d.Removed, f)
+	}
+	sortFindings(d.Removed)
+	return d
+}
+
+func sortFindings(findings []Finding) {
+	slices.SortFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.DetectorName, b.DetectorName), cmp.Compare(a.Redacted, b.Redacted), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// WriteJSON writes the diff as JSON lines, one per secret, with the change in the "Change" field.
+func (d Diff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, section := range d.sections() {
+		for _, f := range section.findings {
+			line := struct {
+				Change Change
+				Finding
+			}{section.change, f}
+			if err := enc.Encode(line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteText writes the diff for humans, with a section for each change.
+func (d Diff) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, section := range d.sections() {
+		fmt.Fprintf(bw, "%s secrets: %d\n", section.change, len(section.findings))
+		for _, f := range section.findings {
+			verified := "unverified"
+			if f.Verified {
+				verified = "verified"
+			}
+			fmt.Fprintf(bw, "  %s %s (%s)\n", f.DetectorName, f.Redacted, verified)
+			for _, loc := range f.Locations {
+				fmt.Fprintf(bw, "      %s\n", loc)
+			}
+		}
+		fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
+
+type section struct {
+	change   Change
+	findings []Finding
+}
+
+// sections returns the changes in the order they are reported.
+func (d Diff) sections() []section {
+	return []section{{Added, d.Added}, {Removed, d.Removed}, {Persisting, d.Persisting}}
+}
@@ -0,0 +1,83 @@
+package scandiff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+const oldReport = `{"SourceMetadata":{"Data":{"Git":{"commit":"1b2c3d4e5f60718293a4b5c6d7e8f90123456789","file":"config/prod.yml","line":12}}},"DetectorType":2,"DetectorName":"AWS","Raw":"AKIAOLD","RawV2":"AKIAOLD:secret","Redacted":"AKIAOLD","Verified":true}
+{"SourceMetadata":{"Data":{"Git":{"commit":"1b2c3d4e5f60718293a4b5c6d7e8f90123456789","file":"README.md","line":3}}},"DetectorType":8,"DetectorName":"Github","Raw":"ghp_kept","Redacted":"","Verified":false}
+`
+
+const newReport = `2024-05-01T10:00:00Z	info-0	trufflehog	running source	{"source_manager_worker_id": "abc"}
+{"SourceMetadata":{"Data":{"Git":{"commit":"aabbccddeeff00112233445566778899aabbccdd","file":"docs/setup.md","line":40}}},"DetectorType":8,"DetectorName":"Github","Raw":"ghp_kept","Redacted":"","Verified":false}
+{"SourceMetadata":{"Data":{"Git":{"commit":"aabbccddeeff00112233445566778899aabbccdd","file":"deploy/.env","line":2}}},"DetectorType":17,"DetectorName":"Slack","Raw":"xoxb-new","Redacted":"xoxb-new","Verified":false}
+{"SourceMetadata":{"Data":{"Git":{"commit":"aabbccddeeff00112233445566778899aabbccdd","file":"deploy/.env","line":2}}},"DetectorType":17,"DetectorName":"Slack","Raw":"xoxb-new","Redacted":"xoxb-new","Verified":true,"FollowUp":true}
+`
+
+func TestCompare(t *testing.T) {
+	old, err := ReadReport(strings.NewReader(oldReport))
+	require.NoError(t, err)
+	updated, err := ReadReport(strings.NewReader(newReport))
+	require.NoError(t, err)
+
+	d := Compare(old, updated)
+
+	require.Len(t, d.Added, 1)
+	assert.Equal(t, "Slack", d.Added[0].DetectorName)
+	assert.True(t, d.Added[0].Verified)
+	assert.Equal(t, []string{"deploy/.env:2@aabbccddeeff"}, d.Added[0].Locations)
+
+	require.Len(t, d.Removed, 1)
+	assert.Equal(t, "AWS", d.Removed[0].DetectorName)
+	assert.Equal(t, []string{"config/prod.yml:12@1b2c3d4e5f60"}, d.Removed[0].Locations)
+
+	// The secret moved to another file, which is not a new secret.
+	require.Len(t, d.Persisting, 1)
+	assert.Equal(t, "Github", d.Persisting[0].DetectorName)
+	assert.Equal(t, []string{"docs/setup.md:40@aabbccddeeff"}, d.Persisting[0].Locations)
+
+	var out bytes.Buffer
+	require.NoError(t, d.WriteJSON(&out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], `"Change":"added"`)
+	assert.NotContains(t, out.String(), "xoxb-new:")
+}
+
+func TestSet_Dispatch(t *testing.T) {
+	report, err := ReadReport(strings.NewReader(newReport))
+	require.NoError(t, err)
+
+	set := NewSet()
+	for _, file := range []string{"docs/setup.md", "docs/setup.md"} {
+		require.NoError(t, set.Dispatch(context.Background(), detectors.ResultWithMetadata{
+			SourceMetadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Git{Git: &source_metadatapb.Git{
+				Commit: "aabbccddeeff00112233445566778899aabbccdd",
+				File:   file,
+				Line:   40,
+			}}},
+			Result: detectors.Result{DetectorType: detector_typepb.DetectorType_Github, Raw: []byte("ghp_kept")},
+		}))
+	}
+
+	// A scan matches a report of the same secrets.
+	d := Compare(report, set)
+	assert.Empty(t, d.Added)
+	require.Len(t, d.Persisting, 1)
+	assert.Equal(t, []string{"docs/setup.md:40@aabbccddeeff"}, d.Persisting[0].Locations)
+}
+
+func TestReadReport_Invalid(t *testing.T) {
+	_, err := ReadReport(strings.NewReader("{\"DetectorType\": \"AWS\"}\n"))
+	assert.ErrorContains(t, err, "line 1")
+}
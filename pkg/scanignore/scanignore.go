@@ -0,0 +1,292 @@
+// Package scanignore implements .securityscanignore files, which let the owners of a repository suppress findings
+// in known fixture files without changes to the scanner's configuration.
+//
+// The file uses gitignore syntax. Patterns apply to all detectors, unless they follow a section header naming the
+// detectors they apply to:
+//
+//	# Ignored for every detector.
+//	testdata/
+//	!testdata/README.md
+//
+//	[detectors: AWS, Github]
+//	fixtures/**/*.json
+//
+//	[detectors: all]
+//	docs/examples/
+//
+// As in gitignore, the last matching pattern decides, and a file cannot be re-included if one of its parent
+// directories is ignored.
+package scanignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+// FileName is the name of the ignore file, which is read from the root of the scanned repository or directory.
+const FileName = ".securityscanignore"
+
+// sectionPat matches section headers, e.g. [detectors: AWS, Github].
+var sectionPat = regexp.MustCompile(`^\[\s*detectors\s*:(.*)\]$`)
+
+// detectorTypes maps lowercase detector names to their types. Detectors are named as on the command line, but
+// without versions: the sources that read ignore files cannot depend on the config package.
+var detectorTypes = func() map[string]detector_typepb.DetectorType {
+	types := make(map[string]detector_typepb.DetectorType, len(detector_typepb.DetectorType_value))
+	for name, value := range detector_typepb.DetectorType_value {
+		types[strings.ToLower(name)] = detector_typepb.DetectorType(value)
+	}
+	return types
+}()
+
+// Rules are the patterns of an ignore file.
+type Rules struct {
+	patterns []pattern
+}
+
+type pattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+	// detectors the pattern applies to; nil means all of them.
+	detectors map[detector_typepb.DetectorType]struct{}
+}
+
+// Load reads the ignore file in dir. It returns nil if there is none.
+func Load(dir string) (*Rules, error) {
+	f, err := os.Open(filepath.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads ignore rules in the .securityscanignore format.
+func Parse(r io.Reader) (*Rules, error) {
+	rules := &Rules{}
+	var scope map[detector_typepb.DetectorType]struct{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := trimTrailingSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := sectionPat.FindStringSubmatch(line); match != nil {
+			var err error
+			if scope, err = parseScope(match[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			continue
+		}
+
+		p, err := parsePattern(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNum, line, err)
+		}
+		p.detectors = scope
+		rules.patterns = append(rules.patterns, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// parseScope parses the detector list of a section header. "all" ends the scoping.
+func parseScope(list string) (map[detector_typepb.DetectorType]struct{}, error) {
+	list = strings.TrimSpace(list)
+	if strings.EqualFold(list, "all") {
+		return nil, nil
+	}
+	scope := make(map[detector_typepb.DetectorType]struct{})
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		detectorType, ok := detectorTypes[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		scope[detectorType] = struct{}{}
+	}
+	if len(scope) == 0 {
+		return nil, fmt.Errorf("no detectors in section %q", list)
+	}
+	return scope, nil
+}
+
+// trimTrailingSpace removes trailing spaces, unless they are escaped with a backslash.
+func trimTrailingSpace(line string) string {
+	trimmed := strings.TrimRight(line, " \t\r")
+	if strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(line) {
+		trimmed += " "
+	}
+	return trimmed
+}
+
+func parsePattern(line string) (pattern, error) {
+	var p pattern
+	switch {
+	case strings.HasPrefix(line, "!"):
+		p.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return p, errors.New("empty pattern")
+	}
+
+	// A pattern with a slash other than at the end is relative to the root; otherwise it matches at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+	if err := translateGlob(&expr, line); err != nil {
+		return p, err
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return p, err
+	}
+	p.re = re
+	return p, nil
+}
+
+// translateGlob writes the regular expression equivalent of a gitignore glob.
+func translateGlob(expr *strings.Builder, glob string) error {
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			// Leading or inner "**/" matches zero or more directories.
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case glob[i:] == "**":
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return errors.New("unterminated character class")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return nil
+}
+
+// Ignored reports whether results of the detector type are suppressed for the file at name, a slash-separated path
+// relative to the root of the ignore file.
+func (r *Rules) Ignored(name string, detectorType detector_typepb.DetectorType) bool {
+	if r == nil {
+		return false
+	}
+	name = strings.Trim(path.Clean(name), "/")
+
+	// A file in an ignored directory is ignored, whatever the patterns for the file itself say.
+	for i := strings.IndexByte(name, '/'); i >= 0; i = nextSlash(name, i) {
+		if r.match(name[:i], true, detectorType) {
+			return true
+		}
+	}
+	return r.match(name, false, detectorType)
+}
+
+func nextSlash(name string, i int) int {
+	j := strings.IndexByte(name[i+1:], '/')
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
+
+// match returns the decision of the last pattern for the detector type that matches name.
+func (r *Rules) match(name string, isDir bool, detectorType detector_typepb.DetectorType) bool {
+	for i := len(r.patterns) - 1; i >= 0; i-- {
+		p := r.patterns[i]
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.detectors != nil {
+			if _, ok := p.detectors[detectorType]; !ok {
+				continue
+			}
+		}
+		if p.re.MatchString(name) {
+			return !p.negate
+		}
+	}
+	return false
+}
+
+// Suppressor returns a function that reports whether results of a detector type are suppressed for the file at
+// name, for use as sources.Chunk.Suppress. It returns nil if no pattern matches the file for any detector.
+func (r *Rules) Suppressor(name string) func(detector_typepb.DetectorType) bool {
+	if r == nil || !r.mayMatch(name) {
+		return nil
+	}
+	return func(detectorType detector_typepb.DetectorType) bool {
+		return r.Ignored(name, detectorType)
+	}
+}
+
+// mayMatch reports whether any ignoring pattern matches name or one of its parent directories.
+func (r *Rules) mayMatch(name string) bool {
+	name = strings.Trim(path.Clean(name), "/")
+	for _, p := range r.patterns {
+		if p.negate {
+			continue
+		}
+		if p.re.MatchString(name) {
+			return true
+		}
+		for i := strings.IndexByte(name, '/'); i >= 0; i = nextSlash(name, i) {
+			if p.re.MatchString(name[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
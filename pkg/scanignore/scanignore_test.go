@@ -0,0 +1,110 @@
+package scanignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
+)
+
+func TestRules_Ignored(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`
+# Fixtures for every detector.
+testdata/
+!testdata/README.md
+*.pem
+/config/local.env
+\#notes
+
+[detectors: AWS, github]
+fixtures/**/*.json
+!fixtures/live.json
+
+[detectors: all]
+docs/examples/**
+`))
+	require.NoError(t, err)
+
+	aws := detector_typepb.DetectorType_AWS
+	slack := detector_typepb.DetectorType_Slack
+
+	tests := []struct {
+		name         string
+		path         string
+		detectorType detector_typepb.DetectorType
+		want         bool
+	}{
+		{name: "ignored directory", path: "testdata/keys.txt", detectorType: slack, want: true},
+		{name: "nested ignored directory", path: "pkg/foo/testdata/keys.txt", detectorType: slack, want: true},
+		{name: "no re-include in ignored directory", path: "testdata/README.md", detectorType: slack, want: true},
+		{name: "directory pattern does not match file", path: "testdata", detectorType: slack, want: false},
+		{name: "basename glob", path: "certs/server.pem", detectorType: slack, want: true},
+		{name: "anchored", path: "config/local.env", detectorType: slack, want: true},
+		{name: "anchored elsewhere", path: "app/config/local.env", detectorType: slack, want: false},
+		{name: "escaped hash", path: "#notes", detectorType: slack, want: true},
+		{name: "scoped to detector", path: "fixtures/a/b/keys.json", detectorType: aws, want: true},
+		{name: "scoped directly under", path: "fixtures/keys.json", detectorType: aws, want: true},
+		{name: "scoped to other detector", path: "fixtures/a/keys.json", detectorType: slack, want: false},
+		{name: "scoped negation", path: "fixtures/live.json", detectorType: aws, want: false},
+		{name: "after scope ends", path: "docs/examples/aws.md", detectorType: slack, want: true},
+		{name: "not matched", path: "main.go", detectorType: aws, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Ignored(tt.path, tt.detectorType))
+		})
+	}
+}
+
+func TestRules_Suppressor(t *testing.T) {
+	rules, err := Parse(strings.NewReader("[detectors: AWS]\nfixtures/\n"))
+	require.NoError(t, err)
+
+	assert.Nil(t, rules.Suppressor("main.go"))
+
+	suppress := rules.Suppressor("fixtures/aws.txt")
+	require.NotNil(t, suppress)
+	assert.True(t, suppress(detector_typepb.DetectorType_AWS))
+	assert.False(t, suppress(detector_typepb.DetectorType_Slack))
+
+	var none *Rules
+	assert.Nil(t, none.Suppressor("fixtures/aws.txt"))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "unknown detector", input: "[detectors: NoSuchDetector]\n"},
+		{name: "empty section", input: "[detectors: ]\n"},
+		{name: "unterminated class", input: "fixtures/[ab\n"},
+		{name: "empty negation", input: "!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	rules, err := Load(dir)
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("testdata/\n"), 0o644))
+	rules, err = Load(dir)
+	require.NoError(t, err)
+	assert.True(t, rules.Ignored("testdata/key", detector_typepb.DetectorType_AWS))
+}
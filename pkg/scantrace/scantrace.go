@@ -0,0 +1,434 @@
+// Package scantrace exports a scan as OpenTelemetry traces. The scan is the root span, with a child span for each
+// source, for each unit of a source and for each batch of chunks produced from a unit. Findings are reported as
+// events, so that organizations running an OpenTelemetry pipeline get scan observability and can alert on findings
+// with their existing tooling.
+package scantrace
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/version"
+)
+
+// Names of the spans and events of a scan.
+const (
+	SpanScan       = "trufflehog.scan"
+	SpanSource     = "trufflehog.source"
+	SpanUnit       = "trufflehog.unit"
+	SpanChunkBatch = "trufflehog.chunk_batch"
+	SpanFinding    = "trufflehog.finding"
+	EventFinding   = "trufflehog.finding"
+)
+
+// DefaultChunkBatchSize is the number of chunks in a chunk batch span.
+const DefaultChunkBatchSize = 100
+
+const instrumentationName = "github.com/trufflesecurity/trufflehog/v3/pkg/scantrace"
+
+// Config configures the export of a scan's traces over OTLP/HTTP. Anything that is not set is taken from the
+// standard OTEL_EXPORTER_OTLP_* and OTEL_RESOURCE_ATTRIBUTES environment variables.
+type Config struct {
+	// Endpoint is the URL of the collector, e.g. http://localhost:4318.
+	Endpoint string
+	// Command is the scan command, recorded on the scan span.
+	Command string
+	// ChunkBatchSize is the number of chunks in a chunk batch span, DefaultChunkBatchSize if zero.
+	ChunkBatchSize int
+}
+
+// Tracer records a scan as spans. It implements sources.JobProgressHook to follow the sources, units and chunks of
+// the scan, and records findings passed to a Dispatcher created by NewDispatcher. It is safe for concurrent use.
+type Tracer struct {
+	provider  *sdktrace.TracerProvider
+	tracer    trace.Tracer
+	batchSize int
+
+	// scanCtx carries the scan span, the parent of the source spans.
+	scanCtx stdcontext.Context
+	scan    trace.Span
+
+	mu   sync.Mutex
+	jobs map[sources.JobProgressRef]*jobSpans
+	// sourceSpans is the span of the latest job of each source, the parent of the findings of the source. Findings
+	// are dispatched after their chunk was scanned, which can be after the job ended.
+	sourceSpans map[sourceKey]trace.SpanContext
+	closed      bool
+
+	stats scanStats
+}
+
+type sourceKey struct {
+	sourceID sources.SourceID
+	jobID    sources.JobID
+}
+
+// jobSpans are the open spans of a job.
+type jobSpans struct {
+	ctx  stdcontext.Context
+	span trace.Span
+	// units are keyed by unitKey. Chunks of sources without units are batched under the empty key.
+	units map[string]*unitSpans
+}
+
+// unitSpans are the open spans of a unit.
+type unitSpans struct {
+	// ctx carries the unit span, or the job span for sources without units.
+	ctx  stdcontext.Context
+	span trace.Span
+	// batch is the open chunk batch span, if any.
+	batch       trace.Span
+	batchChunks int
+	batchBytes  int
+}
+
+type scanStats struct {
+	chunks     int64
+	bytes      int64
+	verified   int64
+	unverified int64
+}
+
+// Ensure the Tracer satisfies the interface at compile time.
+var _ sources.JobProgressHook = (*Tracer)(nil)
+
+// New starts exporting a scan as traces. The scan span starts now and ends when the Tracer is closed.
+func New(ctx context.Context, cfg Config) (*Tracer, error) {
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+	}
+
+	// Attributes from the environment take precedence over the defaults.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "trufflehog"),
+			attribute.String("service.version", version.BuildVersion),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTEL resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	return newTracer(ctx, provider, cfg), nil
+}
+
+func newTracer(ctx stdcontext.Context, provider *sdktrace.TracerProvider, cfg Config) *Tracer {
+	t := &Tracer{
+		provider:    provider,
+		tracer:      provider.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.BuildVersion)),
+		batchSize:   cfg.ChunkBatchSize,
+		jobs:        make(map[sources.JobProgressRef]*jobSpans),
+		sourceSpans: make(map[sourceKey]trace.SpanContext),
+	}
+	if t.batchSize <= 0 {
+		t.batchSize = DefaultChunkBatchSize
+	}
+	// The scan span is the root of its trace, even if ctx carries a span.
+	t.scanCtx, t.scan = t.tracer.Start(ctx, SpanScan,
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.String("trufflehog.command", cfg.Command),
+			attribute.String("trufflehog.version", version.BuildVersion),
+		),
+	)
+	return t
+}
+
+// Close ends the spans that are still open, ends the scan span and flushes all spans to the exporter. It must be
+// called once the engine has finished, so that the scan span covers all findings.
+func (t *Tracer) Close(ctx context.Context) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	now := time.Now()
+	for ref, job := range t.jobs {
+		t.endJob(job, now)
+		delete(t.jobs, ref)
+	}
+	t.scan.SetAttributes(
+		attribute.Int64("trufflehog.chunks", t.stats.chunks),
+		attribute.Int64("trufflehog.bytes", t.stats.bytes),
+		attribute.Int64("trufflehog.findings.verified", t.stats.verified),
+		attribute.Int64("trufflehog.findings.unverified", t.stats.unverified),
+	)
+	t.scan.End(trace.WithTimestamp(now))
+	t.mu.Unlock()
+
+	if err := t.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error exporting traces: %w", err)
+	}
+	return nil
+}
+
+// Start starts the span of a job, which runs one source.
+func (t *Tracer) Start(ref sources.JobProgressRef, start time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	ctx, span := t.tracer.Start(t.scanCtx, SpanSource,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("trufflehog.source.name", ref.SourceName),
+			attribute.Int64("trufflehog.source.id", int64(ref.SourceID)),
+			attribute.Int64("trufflehog.job.id", int64(ref.JobID)),
+		),
+	)
+	t.jobs[ref] = &jobSpans{ctx: ctx, span: span, units: make(map[string]*unitSpans)}
+	t.sourceSpans[sourceKey{ref.SourceID, ref.JobID}] = span.SpanContext()
+}
+
+// End ends the span of a job and the spans of its units that did not end, e.g. because the job was cancelled.
+func (t *Tracer) End(ref sources.JobProgressRef, end time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job, ok := t.jobs[ref]
+	if !ok {
+		return
+	}
+	metrics := ref.Snapshot()
+	job.span.SetAttributes(
+		attribute.Int64("trufflehog.units", int64(metrics.TotalUnits)),
+		attribute.Int64("trufflehog.chunks", int64(metrics.TotalChunks)),
+	)
+	if ref.Cancelled() {
+		job.span.SetAttributes(attribute.Bool("trufflehog.cancelled", true))
+	}
+	t.endJob(job, end)
+	delete(t.jobs, ref)
+}
+
+// Finish ends the span of a job that failed before it could end.
+func (t *Tracer) Finish(ref sources.JobProgressRef) { t.End(ref, time.Now()) }
+
+func (t *Tracer) StartEnumerating(sources.JobProgressRef, time.Time)    {}
+func (t *Tracer) EndEnumerating(sources.JobProgressRef, time.Time)      {}
+func (t *Tracer) ReportUnit(sources.JobProgressRef, sources.SourceUnit) {}
+
+// StartUnitChunking starts the span of a unit.
+func (t *Tracer) StartUnitChunking(ref sources.JobProgressRef, unit sources.SourceUnit, start time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job, ok := t.jobs[ref]
+	if !ok {
+		return
+	}
+	id, kind := "", sources.SourceUnitKind("")
+	if unit != nil {
+		id, kind = unit.SourceUnitID()
+	}
+	ctx, span := t.tracer.Start(job.ctx, SpanUnit,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(
+			attribute.String("trufflehog.unit.id", id),
+			attribute.String("trufflehog.unit.kind", string(kind)),
+		),
+	)
+	job.units[unitKey(unit)] = &unitSpans{ctx: ctx, span: span}
+}
+
+// EndUnitChunking ends the span of a unit and its open chunk batch.
+func (t *Tracer) EndUnitChunking(ref sources.JobProgressRef, unit sources.SourceUnit, end time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job, ok := t.jobs[ref]
+	if !ok {
+		return
+	}
+	key := unitKey(unit)
+	if u, ok := job.units[key]; ok {
+		u.end(end)
+		delete(job.units, key)
+	}
+}
+
+// ReportChunk adds a chunk to the open chunk batch of its unit, starting a batch if there is none and ending it once
+// it is full.
+func (t *Tracer) ReportChunk(ref sources.JobProgressRef, unit sources.SourceUnit, chunk *sources.Chunk) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job, ok := t.jobs[ref]
+	if !ok {
+		return
+	}
+	key := unitKey(unit)
+	u, ok := job.units[key]
+	if !ok {
+		// The source does not chunk by unit, its batches are children of the job span.
+		u = &unitSpans{ctx: job.ctx}
+		job.units[key] = u
+	}
+	if u.batch == nil {
+		_, u.batch = t.tracer.Start(u.ctx, SpanChunkBatch)
+	}
+	u.batchChunks++
+	t.stats.chunks++
+	if chunk != nil {
+		u.batchBytes += len(chunk.Data)
+		t.stats.bytes += int64(len(chunk.Data))
+	}
+	if u.batchChunks >= t.batchSize {
+		u.endBatch(time.Now())
+	}
+}
+
+// ReportError records an error on the span of the unit it occurred in, or else on the span of the job. A fatal error
+// fails the job span.
+func (t *Tracer) ReportError(ref sources.JobProgressRef, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	job, ok := t.jobs[ref]
+	if !ok {
+		return
+	}
+	span := job.span
+	var chunkErr sources.ChunkError
+	if errors.As(err, &chunkErr) {
+		if u, ok := job.units[unitKey(chunkErr.Unit)]; ok && u.span != nil {
+			span = u.span
+		}
+	}
+	span.RecordError(err)
+	if errors.As(err, new(sources.Fatal)) {
+		job.span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// RecordFinding records a finding as an event of a finding span. The span is a child of the span of the source the
+// finding was made in, which may have ended by the time the finding is dispatched. The secret itself is never
+// recorded.
+func (t *Tracer) RecordFinding(r detectors.ResultWithMetadata) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	if !r.FollowUp {
+		if r.Verified {
+			t.stats.verified++
+		} else {
+			t.stats.unverified++
+		}
+	}
+
+	parent := t.scanCtx
+	if sc, ok := t.sourceSpans[sourceKey{r.SourceID, r.JobID}]; ok {
+		parent = trace.ContextWithSpanContext(t.scanCtx, sc)
+	}
+	_, span := t.tracer.Start(parent, SpanFinding)
+	span.AddEvent(EventFinding, trace.WithAttributes(findingAttributes(r)...))
+	span.End()
+}
+
+// findingAttributes describes a finding without its secret.
+func findingAttributes(r detectors.ResultWithMetadata) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("trufflehog.detector", r.DetectorType.String()),
+		attribute.String("trufflehog.decoder", r.DecoderType.String()),
+		attribute.Bool("trufflehog.verified", r.Verified),
+		attribute.String("trufflehog.redacted", r.Redacted),
+		attribute.String("trufflehog.source.name", r.SourceName),
+		attribute.String("trufflehog.source.type", r.SourceType.String()),
+		attribute.Int64("trufflehog.source.id", int64(r.SourceID)),
+		attribute.Int64("trufflehog.job.id", int64(r.JobID)),
+	}
+	if r.DetectorName != "" {
+		attrs = append(attrs, attribute.String("trufflehog.detector.name", r.DetectorName))
+	}
+	if r.Severity != detectors.SeverityUnspecified {
+		attrs = append(attrs, attribute.String("trufflehog.severity", r.Severity.String()))
+	}
+	if err := r.VerificationError(); err != nil {
+		attrs = append(attrs, attribute.String("trufflehog.verification_error", err.Error()))
+	}
+	if r.FollowUp {
+		attrs = append(attrs, attribute.Bool("trufflehog.follow_up", true))
+	}
+	if r.SourceMetadata != nil {
+		if metadata, err := protojson.Marshal(r.SourceMetadata); err == nil {
+			attrs = append(attrs, attribute.String("trufflehog.source_metadata", string(metadata)))
+		}
+	}
+	return attrs
+}
+
+// endJob ends the spans of a job. t.mu must be held.
+func (t *Tracer) endJob(job *jobSpans, end time.Time) {
+	for _, u := range job.units {
+		u.end(end)
+	}
+	job.span.End(trace.WithTimestamp(end))
+}
+
+// end ends the open chunk batch and the span of the unit.
+func (u *unitSpans) end(end time.Time) {
+	u.endBatch(end)
+	if u.span != nil {
+		u.span.End(trace.WithTimestamp(end))
+	}
+}
+
+func (u *unitSpans) endBatch(end time.Time) {
+	if u.batch == nil {
+		return
+	}
+	u.batch.SetAttributes(
+		attribute.Int("trufflehog.chunks", u.batchChunks),
+		attribute.Int("trufflehog.bytes", u.batchBytes),
+	)
+	u.batch.End(trace.WithTimestamp(end))
+	u.batch, u.batchChunks, u.batchBytes = nil, 0, 0
+}
+
+// unitKey identifies a unit within its job.
+func unitKey(unit sources.SourceUnit) string {
+	if unit == nil {
+		return ""
+	}
+	id, kind := unit.SourceUnitID()
+	return fmt.Sprintf("%s:%s", kind, id)
+}
+
+// Dispatcher records findings in a Tracer and passes them on to the next dispatcher.
+type Dispatcher struct {
+	next   engine.ResultsDispatcher
+	tracer *Tracer
+}
+
+// NewDispatcher creates a Dispatcher that records results in tracer before dispatching them to next.
+func NewDispatcher(next engine.ResultsDispatcher, tracer *Tracer) *Dispatcher {
+	return &Dispatcher{next: next, tracer: tracer}
+}
+
+// Dispatch records the result and dispatches it to the next dispatcher.
+func (d *Dispatcher) Dispatch(ctx context.Context, result detectors.ResultWithMetadata) error {
+	d.tracer.RecordFinding(result)
+	return d.next.Dispatch(ctx, result)
+}
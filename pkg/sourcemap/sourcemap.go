@@ -0,0 +1,94 @@
+// Package sourcemap extracts the original sources embedded in JavaScript source maps.
+//
+// Minified bundles lose the names and labels that detectors rely on for context, like the `privateKey` in
+// `const privateKey = "0x…"`. When a bundle ships with a source map that embeds its sources, scanning those
+// sources instead of the bundle finds secrets with their original context.
+package sourcemap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// File is an original source file embedded in a source map.
+type File struct {
+	// Path is the path of the file as listed in the source map, e.g. "webpack://app/src/config.ts".
+	Path    string
+	Content string
+}
+
+// Map holds the sources embedded in a source map.
+type Map struct {
+	Files []File
+	// Complete reports whether every source listed in the source map is embedded. If not, the generated file
+	// contains code that is not covered by Files.
+	Complete bool
+}
+
+var errNotSourceMap = errors.New("not a source map")
+
+// rawMap is the JSON format of a source map, as specified in https://tc39.es/ecma426/. An index map has sections
+// with maps of their own instead of sources.
+type rawMap struct {
+	Version        int       `json:"version"`
+	Sources        []*string `json:"sources"`
+	SourcesContent []*string `json:"sourcesContent"`
+	Sections       []struct {
+		Map *rawMap `json:"map"`
+	} `json:"sections"`
+}
+
+// Parse parses a source map and returns the sources embedded in it.
+func Parse(data []byte) (*Map, error) {
+	var raw rawMap
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", errNotSourceMap, err)
+	}
+	if raw.Version == 0 || (raw.Sources == nil && raw.Sections == nil) {
+		return nil, errNotSourceMap
+	}
+
+	m := &Map{Complete: true}
+	m.add(&raw)
+	return m, nil
+}
+
+func (m *Map) add(raw *rawMap) {
+	for _, section := range raw.Sections {
+		if section.Map == nil {
+			// Sections referencing a map by URL aren't resolved.
+			m.Complete = false
+			continue
+		}
+		m.add(section.Map)
+	}
+
+	for i, source := range raw.Sources {
+		if i >= len(raw.SourcesContent) || raw.SourcesContent[i] == nil || *raw.SourcesContent[i] == "" {
+			m.Complete = false
+			continue
+		}
+		path := fmt.Sprintf("source %d", i)
+		if source != nil && *source != "" {
+			path = *source
+		}
+		m.Files = append(m.Files, File{Path: path, Content: *raw.SourcesContent[i]})
+	}
+}
+
+// Sniff reports whether the start of a file looks like a source map. Use Parse to tell for sure.
+func Sniff(head []byte) bool {
+	head = bytes.TrimLeft(head, " \t\r\n")
+	if len(head) == 0 || head[0] != '{' {
+		return false
+	}
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return bytes.Contains(head, []byte(`"version"`)) &&
+		(bytes.Contains(head, []byte(`"mappings"`)) ||
+			bytes.Contains(head, []byte(`"sources"`)) ||
+			bytes.Contains(head, []byte(`"sections"`)))
+}
@@ -0,0 +1,79 @@
+package sourcemap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Map
+		wantErr bool
+	}{
+		{
+			name:  "embedded sources",
+			input: `{"version":3,"file":"main.js","mappings":"AAAA","sources":["webpack://app/src/config.ts","webpack://app/src/index.ts"],"sourcesContent":["export const privateKey = \"0xabc\";\n","import { privateKey } from './config';\n"]}`,
+			want: &Map{
+				Files: []File{
+					{Path: "webpack://app/src/config.ts", Content: "export const privateKey = \"0xabc\";\n"},
+					{Path: "webpack://app/src/index.ts", Content: "import { privateKey } from './config';\n"},
+				},
+				Complete: true,
+			},
+		},
+		{
+			name:  "partially embedded sources",
+			input: `{"version":3,"mappings":"AAAA","sources":["a.js",null,"c.js"],"sourcesContent":["var a;",null]}`,
+			want: &Map{
+				Files:    []File{{Path: "a.js", Content: "var a;"}},
+				Complete: false,
+			},
+		},
+		{
+			name:  "no embedded sources",
+			input: `{"version":3,"mappings":"AAAA","sources":["a.js"]}`,
+			want:  &Map{Complete: false},
+		},
+		{
+			name:  "index map",
+			input: `{"version":3,"sections":[{"offset":{"line":0,"column":0},"map":{"version":3,"mappings":"AAAA","sources":["a.js"],"sourcesContent":["var a;"]}},{"offset":{"line":1,"column":0},"url":"b.js.map"}]}`,
+			want: &Map{
+				Files:    []File{{Path: "a.js", Content: "var a;"}},
+				Complete: false,
+			},
+		},
+		{
+			name:    "not a source map",
+			input:   `{"name":"app","version":"1.0.0"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			input:   `console.log(1)`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.input))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSniff(t *testing.T) {
+	assert.True(t, Sniff([]byte(`{"version":3,"file":"main.js","mappings":";;;AAAA`)))
+	assert.True(t, Sniff([]byte("\n{\n  \"version\": 3,\n  \"sources\": [")))
+	assert.False(t, Sniff([]byte(`{"name":"app","version":"1.0.0","dependencies":{}}`)))
+	assert.False(t, Sniff([]byte(`!function(e){"use strict"}`)))
+}
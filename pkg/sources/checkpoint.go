@@ -0,0 +1,119 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// checkpointVersion is the version of the checkpoint format.
+const checkpointVersion = 1
+
+// Checkpoint records how far the jobs of an interrupted scan got, so a later
+// scan of the same sources can resume where it stopped.
+type Checkpoint struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Jobs      []CheckpointJob `json:"jobs"`
+}
+
+// CheckpointJob is the progress of a job at the time of the checkpoint.
+type CheckpointJob struct {
+	SourceName string `json:"source_name"`
+	// Done is set if the job had finished; it is skipped when resuming.
+	Done bool `json:"done,omitempty"`
+	// ResumeInfo is the encoded resume information of the source. Sources
+	// that record none are scanned from the start when resuming.
+	ResumeInfo    string `json:"resume_info,omitempty"`
+	TotalUnits    uint64 `json:"total_units,omitempty"`
+	FinishedUnits uint64 `json:"finished_units,omitempty"`
+}
+
+// Checkpoint returns the progress of the jobs started by EnumerateAndScan.
+// The resume information is only sound once the chunks the sources produced
+// have been scanned.
+func (s *SourceManager) Checkpoint() Checkpoint {
+	cp := Checkpoint{Version: checkpointVersion, CreatedAt: time.Now().UTC(), Jobs: []CheckpointJob{}}
+	for _, ref := range s.Jobs() {
+		metrics := ref.Snapshot()
+		job := CheckpointJob{
+			SourceName:    ref.SourceName,
+			ResumeInfo:    metrics.SourceEncodedResumeInfo,
+			TotalUnits:    metrics.TotalUnits,
+			FinishedUnits: metrics.FinishedUnits,
+		}
+		select {
+		case <-ref.Done():
+			job.Done = !ref.Cancelled()
+		default:
+		}
+		cp.Jobs = append(cp.Jobs, job)
+	}
+	return cp
+}
+
+// WriteCheckpoint writes a checkpoint to path. The file is replaced
+// atomically, so an earlier checkpoint is never left half written.
+func WriteCheckpoint(path string, cp Checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint.
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint: %w", err)
+	}
+	if cp.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d", cp.Version)
+	}
+	return &cp, nil
+}
+
+// WithCheckpoint resumes the jobs of an interrupted scan. Jobs are matched to
+// the checkpoint by source name, in the order they are started: jobs that
+// had finished are skipped, and the others start from their resume
+// information.
+func WithCheckpoint(cp *Checkpoint) func(*SourceManager) {
+	return func(mgr *SourceManager) {
+		mgr.resume = make(map[string][]CheckpointJob)
+		for _, job := range cp.Jobs {
+			mgr.resume[job.SourceName] = append(mgr.resume[job.SourceName], job)
+		}
+	}
+}
+
+// resumeJob returns the checkpointed progress of the next job of the source,
+// if any.
+func (s *SourceManager) resumeJob(sourceName string) (CheckpointJob, bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	jobs := s.resume[sourceName]
+	if len(jobs) == 0 {
+		return CheckpointJob{}, false
+	}
+	s.resume[sourceName] = jobs[1:]
+	return jobs[0], true
+}
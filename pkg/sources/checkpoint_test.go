@@ -0,0 +1,65 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	cp := Checkpoint{Version: checkpointVersion, Jobs: []CheckpointJob{
+		{SourceName: "git", Done: true, TotalUnits: 3, FinishedUnits: 3},
+		{SourceName: "filesystem", ResumeInfo: "/tmp/a", TotalUnits: 5, FinishedUnits: 2},
+	}}
+	require.NoError(t, WriteCheckpoint(path, cp))
+
+	got, err := ReadCheckpoint(path)
+	require.NoError(t, err)
+	assert.Equal(t, cp.Jobs, got.Jobs)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99}`), 0o600))
+	_, err = ReadCheckpoint(path)
+	assert.Error(t, err)
+}
+
+func TestSourceManagerCheckpoint(t *testing.T) {
+	mgr := NewManager(WithBufferedOutput(8))
+	finished, err := buildDummy(callbackChunker{func(context.Context, chan *Chunk) error { return nil }})
+	require.NoError(t, err)
+	blocking, err := buildDummy(callbackChunker{func(ctx context.Context, _ chan *Chunk) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	require.NoError(t, err)
+
+	ref, err := mgr.EnumerateAndScan(context.Background(), "finished", finished)
+	require.NoError(t, err)
+	<-ref.Done()
+	_, err = mgr.EnumerateAndScan(context.Background(), "blocking", blocking)
+	require.NoError(t, err)
+	mgr.CancelAll(assert.AnError)
+	_ = mgr.Wait()
+
+	cp := mgr.Checkpoint()
+	require.Len(t, cp.Jobs, 2)
+	assert.True(t, cp.Jobs[0].Done)
+	assert.False(t, cp.Jobs[1].Done)
+
+	// Resuming skips the finished job without running the source.
+	resumed := NewManager(WithBufferedOutput(8), WithCheckpoint(&cp))
+	source, err := buildDummy(callbackChunker{func(context.Context, chan *Chunk) error {
+		t.Error("finished source was scanned again")
+		return nil
+	}})
+	require.NoError(t, err)
+	ref, err = resumed.EnumerateAndScan(context.Background(), "finished", source)
+	require.NoError(t, err)
+	<-ref.Done()
+	assert.NoError(t, resumed.Wait())
+}
@@ -0,0 +1,110 @@
+package ciartifacts
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// bitbucketClient lists the step logs of Bitbucket Pipelines and the files published to the Downloads of a
+// repository. The public API doesn't expose the artifacts passed between steps, so pipelines that publish build
+// output do it through Downloads.
+type bitbucketClient struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	maxRuns  int
+}
+
+type bitbucketPipelines struct {
+	Values []struct {
+		UUID        string `json:"uuid"`
+		BuildNumber int    `json:"build_number"`
+	} `json:"values"`
+}
+
+type bitbucketSteps struct {
+	Values []struct {
+		UUID string `json:"uuid"`
+		Name string `json:"name"`
+	} `json:"values"`
+}
+
+type bitbucketDownloads struct {
+	Values []struct {
+		Name  string `json:"name"`
+		Size  int64  `json:"size"`
+		Links struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+	} `json:"values"`
+}
+
+func (c *bitbucketClient) header() http.Header {
+	header := http.Header{"Accept": {"application/json"}}
+	switch {
+	case c.token == "":
+		// Public repositories can be read anonymously.
+	case strings.Contains(c.token, ":"):
+		// username:app_password
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.token)))
+	default:
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	return header
+}
+
+// walk passes the step logs of the most recent pipelines of repo and the files in its Downloads to fn. Logs and files
+// that can't be downloaded are skipped.
+func (c *bitbucketClient) walk(ctx context.Context, repo string, fn func(ciFile) error) error {
+	var pipelines bitbucketPipelines
+	if err := getJSON(ctx, c.client, fmt.Sprintf("%s/repositories/%s/pipelines/?sort=-created_on&pagelen=%d", c.endpoint, repo, c.maxRuns), c.header(), &pipelines); err != nil {
+		return err
+	}
+
+	for _, pipeline := range pipelines.Values {
+		link := fmt.Sprintf("https://bitbucket.org/%s/pipelines/results/%d", repo, pipeline.BuildNumber)
+		pipelineURL := fmt.Sprintf("%s/repositories/%s/pipelines/%s", c.endpoint, repo, url.PathEscape(pipeline.UUID))
+
+		var steps bitbucketSteps
+		if err := getJSON(ctx, c.client, pipelineURL+"/steps/", c.header(), &steps); err != nil {
+			ctx.Logger().Error(err, "error listing pipeline steps", "repo", repo, "pipeline", pipeline.BuildNumber)
+			continue
+		}
+		for _, step := range steps.Values {
+			f := ciFile{name: fmt.Sprintf("%s pipeline #%d %s (log)", repo, pipeline.BuildNumber, step.Name), link: link}
+			if err := download(ctx, c.client, pipelineURL+"/steps/"+url.PathEscape(step.UUID)+"/log", c.header(), f, fn); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				ctx.Logger().V(2).Info("skipping step log", "file", f.name, "error", err)
+			}
+		}
+	}
+
+	var downloads bitbucketDownloads
+	if err := getJSON(ctx, c.client, fmt.Sprintf("%s/repositories/%s/downloads?pagelen=100", c.endpoint, repo), c.header(), &downloads); err != nil {
+		ctx.Logger().Error(err, "error listing downloads", "repo", repo)
+		return nil
+	}
+	for _, d := range downloads.Values {
+		f := ciFile{name: fmt.Sprintf("%s downloads %s (artifact)", repo, d.Name), link: fmt.Sprintf("https://bitbucket.org/%s/downloads/", repo)}
+		if d.Size > maxArtifactSize {
+			ctx.Logger().V(2).Info("skipping artifact", "file", f.name, "size", d.Size)
+			continue
+		}
+		if err := download(ctx, c.client, d.Links.Self.Href, c.header(), f, fn); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			ctx.Logger().V(2).Info("skipping artifact", "file", f.name, "error", err)
+		}
+	}
+	return nil
+}
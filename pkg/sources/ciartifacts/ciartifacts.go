@@ -0,0 +1,252 @@
+package ciartifacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const SourceType = sourcespb.SourceType_SOURCE_TYPE_CI_ARTIFACTS
+
+const (
+	// DefaultMaxRuns is how many of the most recent workflow runs or pipelines of a repository are scanned.
+	DefaultMaxRuns = 10
+	// DefaultGitHubEndpoint is the GitHub REST API.
+	DefaultGitHubEndpoint = "https://api.github.com"
+	// DefaultBitbucketEndpoint is the Bitbucket Cloud REST API.
+	DefaultBitbucketEndpoint = "https://api.bitbucket.org/2.0"
+
+	// maxArtifactSize skips artifacts too large to be worth downloading, like container images and installers.
+	maxArtifactSize = 512 * 1024 * 1024
+	// downloadTimeout bounds a single API request or download.
+	downloadTimeout = 300
+
+	githubUnitPrefix    = "github:"
+	bitbucketUnitPrefix = "bitbucket:"
+)
+
+// Source scans the job logs and artifacts of recent GitHub Actions workflow runs and Bitbucket Pipelines. Build
+// artifacts routinely capture .env files and configuration, and verbose SDK logging prints access keys and secrets
+// into job logs, where they stay readable to everyone with read access to the repository.
+type Source struct {
+	// GitHubRepos are the GitHub repositories to scan, as owner/repo.
+	GitHubRepos []string
+	// GitHubToken authenticates to the GitHub API. Downloading logs and artifacts requires it even for public
+	// repositories.
+	GitHubToken string
+	// GitHubEndpoint is the GitHub API to use. Default: DefaultGitHubEndpoint.
+	GitHubEndpoint string
+	// BitbucketRepos are the Bitbucket repositories to scan, as workspace/repo.
+	BitbucketRepos []string
+	// BitbucketToken authenticates to the Bitbucket API, either an access token or username:app_password.
+	BitbucketToken string
+	// BitbucketEndpoint is the Bitbucket API to use. Default: DefaultBitbucketEndpoint.
+	BitbucketEndpoint string
+	// MaxRuns is how many recent runs of each repository are scanned. Default: DefaultMaxRuns.
+	MaxRuns int
+
+	name     string
+	sourceId sources.SourceID
+	jobId    sources.JobID
+	verify   bool
+	client   *http.Client
+	sources.Progress
+	sources.CommonSourceUnitUnmarshaller
+}
+
+// Ensure the Source satisfies the interfaces at compile time.
+var _ sources.Source = (*Source)(nil)
+var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+var _ sources.SourceUnitEnumChunker = (*Source)(nil)
+
+func (s *Source) Type() sourcespb.SourceType { return SourceType }
+func (s *Source) SourceID() sources.SourceID { return s.sourceId }
+func (s *Source) JobID() sources.JobID       { return s.jobId }
+
+// Init initializes the source. It is configured through its fields, so the connection is unused.
+func (s *Source) Init(_ context.Context, name string, jobId sources.JobID, sourceId sources.SourceID, verify bool, _ *anypb.Any, _ int) error {
+	s.name = name
+	s.jobId = jobId
+	s.sourceId = sourceId
+	s.verify = verify
+	if s.client == nil {
+		s.client = common.RetryableHTTPClientTimeout(downloadTimeout)
+	}
+	if s.GitHubEndpoint == "" {
+		s.GitHubEndpoint = DefaultGitHubEndpoint
+	}
+	if s.BitbucketEndpoint == "" {
+		s.BitbucketEndpoint = DefaultBitbucketEndpoint
+	}
+	if s.MaxRuns <= 0 {
+		s.MaxRuns = DefaultMaxRuns
+	}
+
+	if len(s.GitHubRepos) == 0 && len(s.BitbucketRepos) == 0 {
+		return fmt.Errorf("no GitHub or Bitbucket repositories to scan")
+	}
+	if len(s.GitHubRepos) > 0 && s.GitHubToken == "" {
+		return fmt.Errorf("a GitHub token is required to download workflow logs and artifacts")
+	}
+	for _, repo := range append(append([]string{}, s.GitHubRepos...), s.BitbucketRepos...) {
+		if err := validateRepo(repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Source) units() []sources.CommonSourceUnit {
+	units := make([]sources.CommonSourceUnit, 0, len(s.GitHubRepos)+len(s.BitbucketRepos))
+	for _, repo := range s.GitHubRepos {
+		units = append(units, sources.CommonSourceUnit{ID: githubUnitPrefix + repo})
+	}
+	for _, repo := range s.BitbucketRepos {
+		units = append(units, sources.CommonSourceUnit{ID: bitbucketUnitPrefix + repo})
+	}
+	return units
+}
+
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	reporter := sources.ChanReporter{Ch: chunksChan}
+	units := s.units()
+	for i, unit := range units {
+		s.SetProgressComplete(i, len(units), fmt.Sprintf("Repository: %s", unit.ID), "")
+		if err := s.ChunkUnit(ctx, unit, reporter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Source) Enumerate(ctx context.Context, reporter sources.UnitReporter) error {
+	for _, unit := range s.units() {
+		if err := reporter.UnitOk(ctx, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChunkUnit scans the logs and artifacts of the recent runs of a single repository.
+func (s *Source) ChunkUnit(ctx context.Context, unit sources.SourceUnit, reporter sources.ChunkReporter) error {
+	id, _ := unit.SourceUnitID()
+
+	var p provider
+	var repo string
+	switch {
+	case strings.HasPrefix(id, githubUnitPrefix):
+		repo = strings.TrimPrefix(id, githubUnitPrefix)
+		p = &githubClient{client: s.client, endpoint: s.GitHubEndpoint, token: s.GitHubToken, maxRuns: s.MaxRuns}
+	case strings.HasPrefix(id, bitbucketUnitPrefix):
+		repo = strings.TrimPrefix(id, bitbucketUnitPrefix)
+		p = &bitbucketClient{client: s.client, endpoint: s.BitbucketEndpoint, token: s.BitbucketToken, maxRuns: s.MaxRuns}
+	default:
+		return reporter.ChunkErr(ctx, fmt.Errorf("invalid unit %q", id))
+	}
+
+	ctx.Logger().V(2).Info("scanning CI runs", "unit", id)
+	err := p.walk(ctx, repo, func(f ciFile) error {
+		chunkSkel := &sources.Chunk{
+			SourceType:   s.Type(),
+			SourceName:   s.name,
+			SourceID:     s.SourceID(),
+			JobID:        s.JobID(),
+			SourceVerify: s.verify,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Filesystem{
+					Filesystem: &source_metadatapb.Filesystem{
+						File: f.name,
+						Link: f.link,
+					},
+				},
+			},
+		}
+		if err := handlers.HandleFile(ctx, f.body, chunkSkel, reporter); err != nil {
+			ctx.Logger().Error(err, "error scanning CI file", "file", f.name)
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return reporter.ChunkErr(ctx, fmt.Errorf("error scanning %s: %w", id, err))
+	}
+	return nil
+}
+
+// ciFile is a job log or an artifact. The body is only valid during the callback it is passed to.
+type ciFile struct {
+	// name describes the file, like "owner/repo run #12 build (log)".
+	name string
+	// link is the web page of the run or job that produced the file.
+	link string
+	body io.Reader
+}
+
+// provider lists the logs and artifacts of the recent runs of a repository on a CI service.
+type provider interface {
+	walk(ctx context.Context, repo string, fn func(ciFile) error) error
+}
+
+// download fetches url and passes its body to fn. The client follows the redirects to the storage holding logs and
+// artifacts, and does not forward the Authorization header to other hosts.
+func download(ctx context.Context, client *http.Client, url string, header http.Header, f ciFile, fn func(ciFile) error) error {
+	res, err := get(ctx, client, url, header)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	f.body = res.Body
+	return fn(f)
+}
+
+// get makes a GET request and returns the response if its status is 200.
+func get(ctx context.Context, client *http.Client, url string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP response status %d for %s", res.StatusCode, req.URL.Path)
+	}
+	return res, nil
+}
+
+// getJSON makes a GET request and decodes its JSON response into v.
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header, v any) error {
+	res, err := get(ctx, client, url, header)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding response of %s: %w", res.Request.URL.Path, err)
+	}
+	return nil
+}
+
+func validateRepo(repo string) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid repository %q: expected owner/repo", repo)
+	}
+	return nil
+}
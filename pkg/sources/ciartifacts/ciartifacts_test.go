@@ -0,0 +1,130 @@
+package ciartifacts
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// collect walks repo and returns the contents of the files passed to the callback, by name.
+func collect(t *testing.T, p provider, repo string) map[string]string {
+	t.Helper()
+	got := make(map[string]string)
+	err := p.walk(context.Background(), repo, func(f ciFile) error {
+		data, err := io.ReadAll(f.body)
+		require.NoError(t, err)
+		got[f.name] = string(data)
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func TestGitHubClient_Walk(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ghp_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/acme/api/actions/runs":
+			assert.Equal(t, "5", r.URL.Query().Get("per_page"))
+			fmt.Fprint(w, `{"total_count":1,"workflow_runs":[{"id":42,"name":"CI","run_number":7,"html_url":"https://github.com/acme/api/actions/runs/42"}]}`)
+		case "/repos/acme/api/actions/runs/42/jobs":
+			fmt.Fprint(w, `{"jobs":[{"id":1,"name":"build","html_url":"https://github.com/acme/api/actions/runs/42/job/1"},{"id":2,"name":"deploy","html_url":"https://github.com/acme/api/actions/runs/42/job/2"}]}`)
+		case "/repos/acme/api/actions/jobs/1/logs":
+			// GitHub redirects to the storage holding the log.
+			http.Redirect(w, r, server.URL+"/storage/job-1.txt", http.StatusFound)
+		case "/storage/job-1.txt":
+			fmt.Fprint(w, "2024-05-01T10:00:00Z DEBUG aws: AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\n")
+		case "/repos/acme/api/actions/jobs/2/logs":
+			// The log of a job was deleted.
+			w.WriteHeader(http.StatusGone)
+		case "/repos/acme/api/actions/runs/42/artifacts":
+			fmt.Fprintf(w, `{"artifacts":[
+				{"id":10,"name":"dist","size_in_bytes":11,"expired":false,"archive_download_url":"%[1]s/repos/acme/api/actions/artifacts/10/zip"},
+				{"id":11,"name":"old","size_in_bytes":11,"expired":true,"archive_download_url":"%[1]s/repos/acme/api/actions/artifacts/11/zip"},
+				{"id":12,"name":"image","size_in_bytes":%[2]d,"expired":false,"archive_download_url":"%[1]s/repos/acme/api/actions/artifacts/12/zip"}
+			]}`, server.URL, maxArtifactSize+1)
+		case "/repos/acme/api/actions/artifacts/10/zip":
+			fmt.Fprint(w, "DB_PASSWORD=hunter2")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &githubClient{client: server.Client(), endpoint: server.URL, token: "ghp_test", maxRuns: 5}
+	assert.Equal(t, map[string]string{
+		"acme/api CI #7 build (log)":     "2024-05-01T10:00:00Z DEBUG aws: AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\n",
+		"acme/api CI #7 dist (artifact)": "DB_PASSWORD=hunter2",
+	}, collect(t, c, "acme/api"))
+}
+
+func TestBitbucketClient_Walk(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "builder" || pass != "app-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repositories/acme/api/pipelines/":
+			assert.Equal(t, "-created_on", r.URL.Query().Get("sort"))
+			fmt.Fprint(w, `{"values":[{"uuid":"{6d4e0a3c-1f1e-4a2b-9d2c-1b2c3d4e5f60}","build_number":31}]}`)
+		case "/repositories/acme/api/pipelines/{6d4e0a3c-1f1e-4a2b-9d2c-1b2c3d4e5f60}/steps/":
+			fmt.Fprint(w, `{"values":[{"uuid":"{a1b2c3d4-0000-4000-8000-000000000001}","name":"Build and test"}]}`)
+		case "/repositories/acme/api/pipelines/{6d4e0a3c-1f1e-4a2b-9d2c-1b2c3d4e5f60}/steps/{a1b2c3d4-0000-4000-8000-000000000001}/log":
+			fmt.Fprint(w, "+ env\nALIBABA_CLOUD_ACCESS_KEY_SECRET=example\n")
+		case "/repositories/acme/api/downloads":
+			fmt.Fprintf(w, `{"values":[{"name":"build.env","size":9,"links":{"self":{"href":"%s/repositories/acme/api/downloads/build.env"}}}]}`, server.URL)
+		case "/repositories/acme/api/downloads/build.env":
+			fmt.Fprint(w, "TOKEN=abc")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &bitbucketClient{client: server.Client(), endpoint: server.URL, token: "builder:app-password", maxRuns: 5}
+	assert.Equal(t, map[string]string{
+		"acme/api pipeline #31 Build and test (log)": "+ env\nALIBABA_CLOUD_ACCESS_KEY_SECRET=example\n",
+		"acme/api downloads build.env (artifact)":    "TOKEN=abc",
+	}, collect(t, c, "acme/api"))
+}
+
+func TestSource_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  *Source
+		wantErr bool
+	}{
+		{name: "github", source: &Source{GitHubRepos: []string{"acme/api"}, GitHubToken: "ghp_test"}},
+		{name: "anonymous bitbucket", source: &Source{BitbucketRepos: []string{"acme/api"}}},
+		{name: "no repositories", source: &Source{}, wantErr: true},
+		{name: "github without token", source: &Source{GitHubRepos: []string{"acme/api"}}, wantErr: true},
+		{name: "invalid repository", source: &Source{BitbucketRepos: []string{"acme"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source.Init(context.Background(), "test", 0, 0, false, nil, 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, DefaultMaxRuns, tt.source.MaxRuns)
+		})
+	}
+}
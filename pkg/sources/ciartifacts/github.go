@@ -0,0 +1,99 @@
+package ciartifacts
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// githubClient lists the job logs and artifacts of GitHub Actions workflow runs.
+type githubClient struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	maxRuns  int
+}
+
+type githubRuns struct {
+	WorkflowRuns []struct {
+		ID        int64  `json:"id"`
+		Name      string `json:"name"`
+		RunNumber int    `json:"run_number"`
+		HTMLURL   string `json:"html_url"`
+	} `json:"workflow_runs"`
+}
+
+type githubJobs struct {
+	Jobs []struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		HTMLURL string `json:"html_url"`
+	} `json:"jobs"`
+}
+
+type githubArtifacts struct {
+	Artifacts []struct {
+		ID                 int64  `json:"id"`
+		Name               string `json:"name"`
+		SizeInBytes        int64  `json:"size_in_bytes"`
+		Expired            bool   `json:"expired"`
+		ArchiveDownloadURL string `json:"archive_download_url"`
+	} `json:"artifacts"`
+}
+
+func (c *githubClient) header() http.Header {
+	return http.Header{
+		"Accept":               {"application/vnd.github+json"},
+		"Authorization":        {"Bearer " + c.token},
+		"X-Github-Api-Version": {"2022-11-28"},
+	}
+}
+
+// walk passes the job logs and the artifacts of the most recent workflow runs of repo to fn. Logs and artifacts that
+// can't be downloaded, for example because they were deleted, are skipped.
+func (c *githubClient) walk(ctx context.Context, repo string, fn func(ciFile) error) error {
+	var runs githubRuns
+	if err := getJSON(ctx, c.client, fmt.Sprintf("%s/repos/%s/actions/runs?per_page=%d", c.endpoint, repo, c.maxRuns), c.header(), &runs); err != nil {
+		return err
+	}
+
+	for _, run := range runs.WorkflowRuns {
+		runName := fmt.Sprintf("%s %s #%d", repo, run.Name, run.RunNumber)
+
+		var jobs githubJobs
+		if err := getJSON(ctx, c.client, fmt.Sprintf("%s/repos/%s/actions/runs/%d/jobs?per_page=100", c.endpoint, repo, run.ID), c.header(), &jobs); err != nil {
+			ctx.Logger().Error(err, "error listing workflow jobs", "run", runName)
+		}
+		for _, job := range jobs.Jobs {
+			f := ciFile{name: fmt.Sprintf("%s %s (log)", runName, job.Name), link: job.HTMLURL}
+			url := fmt.Sprintf("%s/repos/%s/actions/jobs/%d/logs", c.endpoint, repo, job.ID)
+			if err := download(ctx, c.client, url, c.header(), f, fn); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				ctx.Logger().V(2).Info("skipping job log", "file", f.name, "error", err)
+			}
+		}
+
+		var artifacts githubArtifacts
+		if err := getJSON(ctx, c.client, fmt.Sprintf("%s/repos/%s/actions/runs/%d/artifacts?per_page=100", c.endpoint, repo, run.ID), c.header(), &artifacts); err != nil {
+			ctx.Logger().Error(err, "error listing workflow artifacts", "run", runName)
+		}
+		for _, artifact := range artifacts.Artifacts {
+			f := ciFile{name: fmt.Sprintf("%s %s (artifact)", runName, artifact.Name), link: run.HTMLURL}
+			if artifact.Expired || artifact.SizeInBytes > maxArtifactSize {
+				ctx.Logger().V(2).Info("skipping artifact", "file", f.name, "expired", artifact.Expired, "size", artifact.SizeInBytes)
+				continue
+			}
+			// The archive is a zip file, which is extracted by the archive handler.
+			if err := download(ctx, c.client, artifact.ArchiveDownloadURL, c.header(), f, fn); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				ctx.Logger().V(2).Info("skipping artifact", "file", f.name, "error", err)
+			}
+		}
+	}
+	return nil
+}
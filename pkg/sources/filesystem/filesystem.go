@@ -17,9 +17,11 @@ import (
 	trContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scanignore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
@@ -35,9 +37,13 @@ type Source struct {
 	log          logr.Logger
 	filter       *common.Filter
 	skipBinaries bool
+	// ignoreRules are the .securityscanignore rules of the scanned directories, keyed by their cleaned path.
+	ignoreRules map[string]*scanignore.Rules
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
 	maxSymlinkDepth int
+	// relativeTo, if set, is the directory the paths of files are reported relative to.
+	relativeTo string
 }
 
 // Ensure the Source satisfies the interfaces at compile time
@@ -48,6 +54,10 @@ var _ sources.SourceUnitEnumChunker = (*Source)(nil)
 // max symlink depth allowed
 const defaultMaxSymlinkDepth = 40
 
+// WithRelativePaths reports the paths of files under root relative to it, e.g. the paths of a git tree extracted to a
+// temporary directory.
+func (s *Source) WithRelativePaths(root string) { s.relativeTo = root }
+
 // Type returns the type of source.
 // It is used for matching source types in configuration and job input.
 func (s *Source) Type() sourcespb.SourceType {
@@ -84,6 +94,7 @@ func (s *Source) Init(aCtx trContext.Context, name string, jobId sources.JobID,
 		return fmt.Errorf("unable to create filter: %w", err)
 	}
 	s.filter = filter
+	s.loadIgnoreRules(aCtx)
 	err = s.setMaxSymlinkDepth(&conn)
 	if err != nil {
 		return err
@@ -91,6 +102,43 @@ func (s *Source) Init(aCtx trContext.Context, name string, jobId sources.JobID,
 	return nil
 }
 
+// loadIgnoreRules reads the .securityscanignore file at the root of each scanned directory. The files belong to the
+// scanned repositories, so an invalid one is logged rather than failing the scan.
+func (s *Source) loadIgnoreRules(ctx trContext.Context) {
+	for _, rootPath := range s.paths {
+		cleanPath := filepath.Clean(rootPath)
+		if fileInfo, err := os.Stat(cleanPath); err != nil || !fileInfo.IsDir() {
+			continue
+		}
+		rules, err := scanignore.Load(cleanPath)
+		if err != nil {
+			ctx.Logger().Error(err, "invalid ignore file, ignoring it", "path", filepath.Join(cleanPath, scanignore.FileName))
+			continue
+		}
+		if rules == nil {
+			continue
+		}
+		if s.ignoreRules == nil {
+			s.ignoreRules = make(map[string]*scanignore.Rules)
+		}
+		s.ignoreRules[cleanPath] = rules
+	}
+}
+
+// suppressor returns the ignore rules that apply to the file at path, if there are any.
+func (s *Source) suppressor(path string) func(detector_typepb.DetectorType) bool {
+	for root, rules := range s.ignoreRules {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if suppress := rules.Suppressor(filepath.ToSlash(rel)); suppress != nil {
+			return suppress
+		}
+	}
+	return nil
+}
+
 func (s *Source) setMaxSymlinkDepth(conn *sourcespb.Filesystem) error {
 	depth := int(conn.GetMaxSymlinkDepth())
 	if depth > defaultMaxSymlinkDepth {
@@ -128,13 +176,15 @@ func (s *Source) Chunks(ctx trContext.Context, chunksChan chan *sources.Chunk, _
 			// if the root path is a symlink we scan the symlink
 			ctx.Logger().V(5).Info("Root path is a symlink", "path", cleanPath)
 			initialDepth := 0
-			err = s.scanSymlink(ctx, chunksChan, rootPath, initialDepth, cleanPath)
-			s.ClearEncodedResumeInfoFor(rootPath)
+			err = s.walkRoot(rootPath, func(resumptionKey string, pass *scanPass) error {
+				return s.scanSymlink(ctx, chunksChan, resumptionKey, initialDepth, cleanPath, pass)
+			})
 		} else if fileInfo.IsDir() {
 			ctx.Logger().V(5).Info("Root path is a dir", "path", cleanPath)
 			initialDepth := 0
-			err = s.scanDir(ctx, chunksChan, rootPath, initialDepth, cleanPath)
-			s.ClearEncodedResumeInfoFor(rootPath)
+			err = s.walkRoot(rootPath, func(resumptionKey string, pass *scanPass) error {
+				return s.scanDir(ctx, chunksChan, resumptionKey, initialDepth, cleanPath, pass)
+			})
 		} else {
 			if !fileInfo.Mode().IsRegular() {
 				logger.Info("skipping non-regular file", "path", cleanPath)
@@ -158,6 +208,7 @@ func (s *Source) scanSymlink(
 	rootPath string,
 	depth int,
 	path string,
+	pass *scanPass,
 ) error {
 	if !s.canFollowSymlinks() {
 		// If the file or directory is a symlink but the followSymlinks is disable ignore the path
@@ -191,7 +242,7 @@ func (s *Source) scanSymlink(
 			"resolvedPath", resolvedPath,
 			"depth", depth,
 		)
-		return s.scanSymlink(ctx, chunksChan, rootPath, depth, resolvedPath)
+		return s.scanSymlink(ctx, chunksChan, rootPath, depth, resolvedPath, pass)
 	}
 
 	if fileInfo.IsDir() {
@@ -202,7 +253,7 @@ func (s *Source) scanSymlink(
 			"depth", depth,
 		)
 
-		return s.scanDir(ctx, chunksChan, rootPath, depth, resolvedPath)
+		return s.scanDir(ctx, chunksChan, rootPath, depth, resolvedPath, pass)
 	}
 	ctx.Logger().V(5).Info(
 		"found symlink to file",
@@ -222,6 +273,9 @@ func (s *Source) scanSymlink(
 		ctx.Logger().V(5).Info("skipping non-regular file", "path", resolvedPath)
 		return nil
 	}
+	if !pass.includes(cleanPath) {
+		return nil
+	}
 	if err := s.scanFile(ctx, chunksChan, resolvedPath); err != nil {
 		ctx.Logger().Error(err, "error scanning file", "path", resolvedPath)
 	}
@@ -235,6 +289,7 @@ func (s *Source) scanDir(
 	rootPath string,
 	depth int,
 	path string,
+	pass *scanPass,
 ) error {
 	// check if the full path is not matching any pattern in include
 	// FilterRuleSet and matching any exclude FilterRuleSet.
@@ -301,7 +356,7 @@ func (s *Source) scanDir(
 			// traverse into it to find where to resume.
 			if entry.IsDir() && strings.HasPrefix(resumeAfter, entryPath+string(filepath.Separator)) {
 				// Recurse into this directory to find the resume point.
-				if err := s.scanDir(ctx, chunksChan, rootPath, depth, entryPath); err != nil {
+				if err := s.scanDir(ctx, chunksChan, rootPath, depth, entryPath, pass); err != nil {
 					ctx.Logger().Error(err, "error scanning directory", "path", entryPath)
 				}
 				// After recursing, clear local resumeAfter. The child scanDir will have
@@ -316,16 +371,16 @@ func (s *Source) scanDir(
 
 		if entry.Type()&os.ModeSymlink != 0 {
 			ctx.Logger().V(5).Info("Entry found is a symlink", "path", entryPath)
-			if err := s.scanSymlink(ctx, chunksChan, rootPath, depth, entryPath); err != nil {
+			if err := s.scanSymlink(ctx, chunksChan, rootPath, depth, entryPath, pass); err != nil {
 				ctx.Logger().Error(err, "error scanning symlink", "path", entryPath)
 			}
 		} else if entry.IsDir() {
 			ctx.Logger().V(5).Info("Entry found is a directory", "path", entryPath)
-			if err := s.scanDir(ctx, chunksChan, rootPath, depth, entryPath); err != nil {
+			if err := s.scanDir(ctx, chunksChan, rootPath, depth, entryPath, pass); err != nil {
 				ctx.Logger().Error(err, "error scanning directory", "path", entryPath)
 			}
 		} else {
-			if !entry.Type().IsRegular() {
+			if !entry.Type().IsRegular() || !pass.includes(entryPath) {
 				continue
 			}
 			ctx.Logger().V(5).Info("Entry found is a file", "path", entryPath)
@@ -344,6 +399,46 @@ func (s *Source) scanDir(
 	return nil
 }
 
+// scanPass restricts a walk of the tree at root to the files of one priority.
+// A nil pass includes every file.
+type scanPass struct {
+	root     string
+	priority int
+}
+
+func (p *scanPass) includes(path string) bool {
+	if p == nil {
+		return true
+	}
+	rel, err := filepath.Rel(p.root, path)
+	if err != nil {
+		rel = path
+	}
+	return sources.PathPriority(rel) == p.priority
+}
+
+// walkRoot calls walk for the tree at rootPath and clears its resume info
+// once the walk is done. With feature.PrioritizeFiles set, the tree is walked
+// once per priority, highest first, so env and config files are chunked
+// before other files and vendored files last. Each pass keeps its own resume
+// info, since the files of a pass are scanned in path order.
+func (s *Source) walkRoot(rootPath string, walk func(resumptionKey string, pass *scanPass) error) error {
+	if !feature.PrioritizeFiles.Load() {
+		defer s.ClearEncodedResumeInfoFor(rootPath)
+		return walk(rootPath, nil)
+	}
+
+	root := filepath.Clean(rootPath)
+	for _, priority := range sources.PriorityPasses {
+		resumptionKey := fmt.Sprintf("%s#%d", rootPath, priority)
+		defer s.ClearEncodedResumeInfoFor(resumptionKey)
+		if err := walk(resumptionKey, &scanPass{root: root, priority: priority}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Source) scanFile(ctx trContext.Context, chunksChan chan *sources.Chunk, path string) error {
 	fileCtx := trContext.WithValues(ctx, "path", path)
 
@@ -374,16 +469,28 @@ func (s *Source) scanFile(ctx trContext.Context, chunksChan chan *sources.Chunk,
 		SourceMetadata: &source_metadatapb.MetaData{
 			Data: &source_metadatapb.MetaData_Filesystem{
 				Filesystem: &source_metadatapb.Filesystem{
-					File: sanitizer.UTF8(path),
+					File: sanitizer.UTF8(s.reportedPath(path)),
 				},
 			},
 		},
+		Suppress:     s.suppressor(path),
 		SourceVerify: s.verify,
 	}
 
 	return handlers.HandleFile(fileCtx, inputFile, chunkSkel, sources.ChanReporter{Ch: chunksChan})
 }
 
+// reportedPath returns the path a file is reported with.
+func (s *Source) reportedPath(path string) string {
+	if s.relativeTo == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(s.relativeTo, path); err == nil && filepath.IsLocal(rel) {
+		return rel
+	}
+	return path
+}
+
 // Enumerate implements SourceUnitEnumerator interface. This implementation simply
 // passes the configured paths as the source unit, whether it be a single
 // filepath or a directory.
@@ -424,15 +531,17 @@ func (s *Source) ChunkUnit(ctx trContext.Context, unit sources.SourceUnit, repor
 			// if the root path is a symlink we scan the symlink
 			ctx.Logger().V(5).Info("Root path is a symlink", "path", cleanPath)
 			initialDepth := 0
-			scanErr = s.scanSymlink(ctx, ch, rootPath, initialDepth, cleanPath)
-			s.ClearEncodedResumeInfoFor(rootPath)
+			scanErr = s.walkRoot(rootPath, func(resumptionKey string, pass *scanPass) error {
+				return s.scanSymlink(ctx, ch, resumptionKey, initialDepth, cleanPath, pass)
+			})
 
 		} else if fileInfo.IsDir() {
 			ctx.Logger().V(5).Info("Root path is a dir", "path", cleanPath)
 			initialDepth := 0
 			// TODO: Finer grain error tracking of individual chunks.
-			scanErr = s.scanDir(ctx, ch, rootPath, initialDepth, cleanPath)
-			s.ClearEncodedResumeInfoFor(rootPath)
+			scanErr = s.walkRoot(rootPath, func(resumptionKey string, pass *scanPass) error {
+				return s.scanDir(ctx, ch, resumptionKey, initialDepth, cleanPath, pass)
+			})
 		} else {
 			ctx.Logger().V(5).Info("Root path is a file", "path", cleanPath)
 			// TODO: Finer grain error tracking of individual
@@ -506,7 +506,7 @@ func TestScanSymlink_NoError(t *testing.T) {
 	chunks := make(chan *sources.Chunk, 10)
 	go func() {
 		path := filepath.Join(baseDir, "A")
-		err := src.scanSymlink(ctx, chunks, path, 0, path)
+		err := src.scanSymlink(ctx, chunks, path, 0, path, nil)
 		require.NoError(t, err)
 		close(chunks)
 	}()
@@ -554,7 +554,7 @@ func TestScanSymlink_MaxDepthExceeded(t *testing.T) {
 	chunks := make(chan *sources.Chunk, 10)
 
 	path := filepath.Join(baseDir, "A")
-	err = src.scanSymlink(ctx, chunks, path, 0, path)
+	err = src.scanSymlink(ctx, chunks, path, 0, path, nil)
 	close(chunks)
 
 	require.Error(t, err)
@@ -587,7 +587,7 @@ func TestScanSymlink_FileTarget(t *testing.T) {
 
 	chunks := make(chan *sources.Chunk, 10)
 
-	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath)
+	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath, nil)
 	require.NoError(t, err)
 	close(chunks)
 	var chunkCount int
@@ -619,7 +619,7 @@ func TestScanSymlink_SelfLoop(t *testing.T) {
 
 	chunks := make(chan *sources.Chunk, 10)
 
-	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath)
+	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath, nil)
 	close(chunks)
 	require.Error(t, err)
 	require.EqualError(t, err, "max symlink depth reached")
@@ -645,7 +645,7 @@ func TestScanSymlink_BrokenSymlink(t *testing.T) {
 
 	chunks := make(chan *sources.Chunk, 10)
 
-	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath)
+	err = src.scanSymlink(ctx, chunks, symlinkPath, 0, symlinkPath, nil)
 	close(chunks)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "lstat error")
@@ -674,7 +674,7 @@ func TestScanSymlink_TwoFileLoop(t *testing.T) {
 
 	chunks := make(chan *sources.Chunk, 10)
 
-	err = src.scanSymlink(ctx, chunks, fileA, 0, fileA)
+	err = src.scanSymlink(ctx, chunks, fileA, 0, fileA, nil)
 	close(chunks)
 	require.Error(t, err)
 	require.EqualError(t, err, "max symlink depth reached")
@@ -18,8 +18,11 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	trContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scanignore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sourcestest"
 )
@@ -375,6 +378,73 @@ func TestSkipDir(t *testing.T) {
 	require.Equal(t, 0, len(reporter.ChunkErrs), "Expected no errors for excluded directory")
 }
 
+func TestScanIgnoreFile(t *testing.T) {
+	t.Parallel()
+	ctx := trContext.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "fixtures"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, scanignore.FileName), []byte("[detectors: AWS]\nfixtures/\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fixtures", "aws.txt"), []byte("fixture"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644))
+
+	conn, err := anypb.New(&sourcespb.Filesystem{Paths: []string{dir}})
+	require.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "ignore file", 0, 0, true, conn, 1)
+	require.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.ChunkUnit(ctx, sources.CommonSourceUnit{ID: dir}, &reporter)
+	require.NoError(t, err)
+
+	suppressed := make(map[string]bool)
+	for _, chunk := range reporter.Chunks {
+		suppressed[filepath.Base(chunk.SourceMetadata.GetFilesystem().GetFile())] = chunk.Suppressed(detector_typepb.DetectorType_AWS)
+		assert.False(t, chunk.Suppressed(detector_typepb.DetectorType_Slack))
+	}
+	assert.Equal(t, map[string]bool{"aws.txt": true, "main.go": false, scanignore.FileName: false}, suppressed)
+}
+
+func TestPrioritizeFiles(t *testing.T) {
+	feature.PrioritizeFiles.Store(true)
+	defer feature.PrioritizeFiles.Store(false)
+	ctx := trContext.Background()
+
+	dir := t.TempDir()
+	files := []string{"a.go", "z.txt", ".env", "config/app.yaml", "vendor/lib/lib.go", "vendor/lib/.env"}
+	for _, name := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+	}
+
+	conn, err := anypb.New(&sourcespb.Filesystem{Paths: []string{dir}})
+	require.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "prioritize files", 0, 0, true, conn, 1)
+	require.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.ChunkUnit(ctx, sources.CommonSourceUnit{ID: dir}, &reporter)
+	require.NoError(t, err)
+
+	var got []string
+	for _, chunk := range reporter.Chunks {
+		rel, err := filepath.Rel(dir, chunk.SourceMetadata.GetFilesystem().GetFile())
+		require.NoError(t, err)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	// Files of the same priority are scanned concurrently with the walk of their sibling directories, so only the
+	// order of the priorities is fixed.
+	require.Len(t, got, len(files))
+	assert.ElementsMatch(t, []string{".env", "config/app.yaml"}, got[:2])
+	assert.ElementsMatch(t, []string{"a.go", "z.txt"}, got[2:4])
+	assert.ElementsMatch(t, []string{"vendor/lib/.env", "vendor/lib/lib.go"}, got[4:])
+}
+
 func TestScanSubDirFile(t *testing.T) {
 	t.Parallel()
 	ctx := trContext.Background()
@@ -0,0 +1,160 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	trContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// DefaultWatchDebounce is how long a file must go without changes before a WatchSource scans it.
+const DefaultWatchDebounce = 2 * time.Second
+
+// WatchSource is a filesystem source for long-running scans of developer workstations and build agents. Instead of
+// scanning its paths once, it watches them and scans files as they are created or modified, until its context is
+// canceled. Findings are reported like those of any other source.
+type WatchSource struct {
+	fs Source
+	// Debounce is how long a file must go without changes before it is scanned, so that a file written in several
+	// steps is only scanned once. Default: DefaultWatchDebounce.
+	Debounce time.Duration
+	sources.Progress
+}
+
+// Ensure the WatchSource satisfies the interface at compile time.
+var _ sources.Source = (*WatchSource)(nil)
+
+func (s *WatchSource) Type() sourcespb.SourceType { return SourceType }
+
+func (s *WatchSource) SourceID() sources.SourceID { return s.fs.SourceID() }
+
+func (s *WatchSource) JobID() sources.JobID { return s.fs.JobID() }
+
+// Init initializes the source from a Filesystem connection, like Source.
+func (s *WatchSource) Init(aCtx trContext.Context, name string, jobId sources.JobID, sourceId sources.SourceID, verify bool, connection *anypb.Any, concurrency int) error {
+	return s.fs.Init(aCtx, name, jobId, sourceId, verify, connection, concurrency)
+}
+
+// Chunks watches the paths of the source and scans files after they were created or modified. It only returns once
+// ctx is canceled.
+func (s *WatchSource) Chunks(ctx trContext.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, rootPath := range s.fs.paths {
+		if err := s.addWatches(watcher, filepath.Clean(rootPath), nil); err != nil {
+			ctx.Logger().Error(err, "unable to watch path", "path", rootPath)
+		}
+	}
+	if len(watcher.WatchList()) == 0 {
+		return fmt.Errorf("none of the paths could be watched")
+	}
+	ctx.Logger().Info("watching for file changes", "paths", s.fs.paths)
+
+	debounce := s.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	ticker := time.NewTicker(max(debounce/4, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	// pending maps files to their last change. Files are scanned once they stay unchanged for the debounce period.
+	pending := make(map[string]time.Time)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			s.handleEvent(ctx, watcher, event, pending)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			ctx.Logger().Error(err, "error watching filesystem")
+		case now := <-ticker.C:
+			for path, changed := range pending {
+				if now.Sub(changed) < debounce {
+					continue
+				}
+				delete(pending, path)
+				if err := s.fs.scanFile(ctx, chunksChan, path); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, fs.ErrNotExist) {
+					ctx.Logger().Error(err, "error scanning file", "path", path)
+				}
+			}
+		}
+	}
+}
+
+// handleEvent records files that were created or written as pending. New directories are watched as well, and the
+// files already in them are recorded, because they may have been created before the watch was in place.
+func (s *WatchSource) handleEvent(ctx trContext.Context, watcher *fsnotify.Watcher, event fsnotify.Event, pending map[string]time.Time) {
+	path := event.Name
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		delete(pending, path)
+		return
+	}
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return
+	}
+	switch {
+	case info.IsDir() && event.Has(fsnotify.Create):
+		if err := s.addWatches(watcher, path, pending); err != nil {
+			ctx.Logger().Error(err, "unable to watch directory", "path", path)
+		}
+	case info.Mode().IsRegular():
+		if s.fs.filter == nil || s.fs.filter.Pass(path) {
+			pending[path] = time.Now()
+		}
+	}
+}
+
+// addWatches watches root and, if it is a directory, every directory below it that is not excluded. If pending is
+// not nil, the files found in them are recorded in it.
+func (s *WatchSource) addWatches(watcher *fsnotify.Watcher, root string, pending map[string]time.Time) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return watcher.Add(root)
+	}
+
+	now := time.Now()
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The directory may have been removed in the meantime.
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && s.fs.filter != nil && s.fs.filter.ShouldExclude(path) {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		if pending != nil && d.Type().IsRegular() && (s.fs.filter == nil || s.fs.filter.Pass(path)) {
+			pending[path] = now
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	trContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestWatchSource_Chunks(t *testing.T) {
+	ctx, cancel := trContext.WithTimeout(trContext.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	conn, err := anypb.New(&sourcespb.Filesystem{Paths: []string{dir}})
+	require.NoError(t, err)
+
+	s := &WatchSource{Debounce: 50 * time.Millisecond}
+	require.NoError(t, s.Init(ctx, "test watch", 0, 0, false, conn, 1))
+
+	chunksChan := make(chan *sources.Chunk, 16)
+	done := make(chan error, 1)
+	go func() { done <- s.Chunks(ctx, chunksChan) }()
+
+	// Give the watcher time to set up before making changes.
+	time.Sleep(200 * time.Millisecond)
+	file := filepath.Join(dir, "app.env")
+	require.NoError(t, os.WriteFile(file, []byte("TOKEN=first"), 0o600))
+	require.NoError(t, os.WriteFile(file, []byte("TOKEN=second"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested", "deeper"), 0o755))
+	nested := filepath.Join(dir, "nested", "deeper", "creds.json")
+	require.NoError(t, os.WriteFile(nested, []byte(`{"secret":"value"}`), 0o600))
+
+	got := make(map[string]string)
+	for len(got) < 2 {
+		select {
+		case chunk := <-chunksChan:
+			got[chunk.SourceMetadata.GetFilesystem().GetFile()] = string(chunk.Data)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for chunks, got %v", got)
+		}
+	}
+	// Writes within the debounce period are scanned once, with the final content.
+	assert.Equal(t, map[string]string{file: "TOKEN=second", nested: `{"secret":"value"}`}, got)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, chunksChan)
+}
@@ -49,6 +49,9 @@ type Source struct {
 	useCustomContentWriter bool
 	git                    *Git
 	scanOptions            *ScanOptions
+	// stagedOnly scans only the staged changes, with stagedContext unchanged lines around each change.
+	stagedOnly    bool
+	stagedContext int
 
 	sources.Progress
 	conn *sourcespb.Git
@@ -57,6 +60,13 @@ type Source struct {
 // WithCustomContentWriter sets the useCustomContentWriter flag on the source.
 func (s *Source) WithCustomContentWriter() { s.useCustomContentWriter = true }
 
+// WithStagedOnly restricts the source to the staged changes of its repositories, scanned with the given number of
+// unchanged lines around each change. Secrets are only reported on added lines.
+func (s *Source) WithStagedOnly(contextLines int) {
+	s.stagedOnly = true
+	s.stagedContext = contextLines
+}
+
 // SourceMetadataInfo contains the metadata fields passed to SourceMetadataFunc.
 // Using a struct allows adding new fields without breaking existing consumers.
 type SourceMetadataInfo struct {
@@ -83,6 +93,7 @@ type Git struct {
 	concurrency        *semaphore.Weighted
 	skipBinaries       bool
 	skipArchives       bool
+	stagedContext      int
 	repoCommitsScanned uint64 // Atomic counter for commits scanned in the current repo
 
 	parser *gitparse.Parser
@@ -107,17 +118,22 @@ type Config struct {
 	UseCustomContentWriter bool
 	// pass authentication embedded in the repository urls
 	AuthInUrl bool
+	// StagedContext is the number of unchanged lines scanned around staged changes. If set, secrets in staged
+	// changes are only reported on added lines.
+	StagedContext int
 }
 
 // NewGit creates a new Git instance with the provided configuration. The Git instance is used to interact with
 // Git repositories.
 func NewGit(config *Config) *Git {
-	var parser *gitparse.Parser
+	var opts []gitparse.Option
 	if config.UseCustomContentWriter {
-		parser = gitparse.NewParser(gitparse.UseCustomContentWriter())
-	} else {
-		parser = gitparse.NewParser()
+		opts = append(opts, gitparse.UseCustomContentWriter())
+	}
+	if config.StagedContext > 0 {
+		opts = append(opts, gitparse.WithStagedContext(config.StagedContext))
 	}
+	parser := gitparse.NewParser(opts...)
 
 	return &Git{
 		sourceType:         config.SourceType,
@@ -130,6 +146,7 @@ func NewGit(config *Config) *Git {
 		concurrency:        semaphore.NewWeighted(int64(config.Concurrency)),
 		skipBinaries:       config.SkipBinaries,
 		skipArchives:       config.SkipArchives,
+		stagedContext:      config.StagedContext,
 		parser:             parser,
 	}
 }
@@ -206,6 +223,9 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	if isBare := conn.GetBare(); isBare {
 		opts = append(opts, ScanOptionBare(isBare))
 	}
+	if s.stagedOnly {
+		opts = append(opts, ScanOptionStagedOnly(true))
+	}
 	s.withScanOptions(NewScanOptions(opts...))
 
 	s.conn = &conn
@@ -243,6 +263,7 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 			}
 		},
 		UseCustomContentWriter: s.useCustomContentWriter,
+		StagedContext:          s.stagedContext,
 	}
 	s.git = NewGit(cfg)
 	return nil
@@ -326,7 +347,7 @@ func (s *Source) scanRepo(ctx context.Context, repoURI string, reporter sources.
 		if err != nil {
 			return err
 		}
-		return s.git.ScanRepo(ctx, repo, path, s.scanOptions, reporter)
+		return s.git.ScanRepo(ctx, repo, path, s.scanOptions, withIgnoreRules(ctx, repo, path, reporter))
 	}()
 	if err != nil {
 		return reporter.ChunkErr(ctx, err)
@@ -377,7 +398,7 @@ func (s *Source) scanDir(ctx context.Context, gitDir string, reporter sources.Ch
 			}
 		}
 
-		return s.git.ScanRepo(ctx, repo, gitDir, s.scanOptions, reporter)
+		return s.git.ScanRepo(ctx, repo, gitDir, s.scanOptions, withIgnoreRules(ctx, repo, gitDir, reporter))
 	}()
 	if err != nil {
 		return reporter.ChunkErr(ctx, err)
@@ -726,6 +747,7 @@ func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string
 	if diffChan == nil {
 		return nil
 	}
+	diffChan = prioritizeDiffs(diffChan)
 
 	logger.Info("scanning repo", logValues...)
 
@@ -1005,6 +1027,7 @@ func (s *Git) ScanStaged(ctx context.Context, repo *git.Repository, path string,
 	if diffChan == nil {
 		return nil
 	}
+	diffChan = prioritizeDiffs(diffChan)
 
 	logger := ctx.Logger()
 	var logValues []any
@@ -1099,6 +1122,11 @@ func (s *Git) ScanStaged(ctx context.Context, repo *git.Repository, path string,
 			continue
 		}
 
+		// With context, a hunk that adds no lines only holds unchanged lines.
+		if s.stagedContext > 0 && len(diff.AddedLines) == 0 {
+			continue
+		}
+
 		chunkData := func(d *gitparse.Diff) error {
 			metadata := s.sourceMetadataFunc(SourceMetadataInfo{
 				File:                fileName,
@@ -1129,6 +1157,7 @@ func (s *Git) ScanStaged(ctx context.Context, repo *git.Repository, path string,
 				SourceType:     s.sourceType,
 				SourceMetadata: metadata,
 				Data:           data,
+				ReportLines:    d.AddedLines,
 				SourceVerify:   s.verify,
 			}
 			return reporter.ChunkOk(ctx, chunk)
@@ -1144,6 +1173,16 @@ func (s *Git) ScanRepo(ctx context.Context, repo *git.Repository, repoPath strin
 	if scanOptions == nil {
 		scanOptions = NewScanOptions()
 	}
+	// Staged changes have no history to resolve refs against, e.g. before the first commit.
+	if scanOptions.StagedOnly {
+		if err := s.ScanStaged(ctx, repo, repoPath, scanOptions, reporter); err != nil {
+			s.metrics.RecordRepoScanned(statusFailure)
+			return err
+		}
+		s.metrics.RecordRepoScanned(statusSuccess)
+		return nil
+	}
+
 	if err := normalizeConfig(scanOptions, repo); err != nil {
 		return err
 	}
@@ -1293,6 +1332,8 @@ func TryAdditionalBaseRefs(repo *git.Repository, base string) (*plumbing.Hash, e
 		"",
 		"refs/heads/",
 		"refs/remotes/origin/",
+		// Branches of repositories cloned with all refs, see executeClone.
+		"refs/remotes/origin/heads/",
 	}
 	for _, prefix := range revisionPrefixes {
 		outHash, err := repo.ResolveRevision(plumbing.Revision(prefix + base))
@@ -0,0 +1,43 @@
+package git
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/gitparse"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// prioritizeDiffs reorders the diffs of each commit by the priority of their
+// file when feature.PrioritizeFiles is set, so env and config files of a
+// commit are chunked before its other files and vendored files last. Commits
+// keep their order, and so do diffs of the same priority.
+func prioritizeDiffs(in chan *gitparse.Diff) chan *gitparse.Diff {
+	if !feature.PrioritizeFiles.Load() {
+		return in
+	}
+
+	out := make(chan *gitparse.Diff, cap(in))
+	go func() {
+		defer close(out)
+		var pending []*gitparse.Diff
+		flush := func() {
+			slices.SortStableFunc(pending, func(a, b *gitparse.Diff) int {
+				return cmp.Compare(sources.PathPriority(b.PathB), sources.PathPriority(a.PathB))
+			})
+			for _, diff := range pending {
+				out <- diff
+			}
+			pending = pending[:0]
+		}
+		for diff := range in {
+			if len(pending) > 0 && diff.Commit != pending[0].Commit {
+				flush()
+			}
+			pending = append(pending, diff)
+		}
+		flush()
+	}()
+	return out
+}
@@ -13,6 +13,8 @@ type ScanOptions struct {
 	Bare         bool
 	ExcludeGlobs []string
 	LogOptions   *git.LogOptions
+	// StagedOnly scans the staged changes without the commit history.
+	StagedOnly bool
 }
 
 type ScanOption func(*ScanOptions)
@@ -59,6 +61,12 @@ func ScanOptionBare(bare bool) ScanOption {
 	}
 }
 
+func ScanOptionStagedOnly(stagedOnly bool) ScanOption {
+	return func(scanOptions *ScanOptions) {
+		scanOptions.StagedOnly = stagedOnly
+	}
+}
+
 func NewScanOptions(options ...ScanOption) *ScanOptions {
 	scanOptions := &ScanOptions{
 		Filter:   common.FilterEmpty(),
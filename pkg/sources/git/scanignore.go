@@ -0,0 +1,70 @@
+package git
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/scanignore"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// ignoreReporter suppresses results in the files matched by a repository's .securityscanignore file. The file is
+// read once, so its current version applies to the whole history.
+type ignoreReporter struct {
+	sources.ChunkReporter
+	rules *scanignore.Rules
+}
+
+func (r ignoreReporter) ChunkOk(ctx context.Context, chunk sources.Chunk) error {
+	if file := chunk.SourceMetadata.GetGit().GetFile(); file != "" {
+		chunk.Suppress = r.rules.Suppressor(file)
+	}
+	return r.ChunkReporter.ChunkOk(ctx, chunk)
+}
+
+// withIgnoreRules wraps reporter to apply the .securityscanignore file of the repository, if it has one. The file
+// belongs to the scanned repository, so an invalid one is logged rather than failing the scan.
+func withIgnoreRules(ctx context.Context, repo *git.Repository, repoPath string, reporter sources.ChunkReporter) sources.ChunkReporter {
+	rules, err := loadIgnoreRules(repo, repoPath)
+	if err != nil {
+		ctx.Logger().Error(err, "invalid ignore file, ignoring it", "file", scanignore.FileName)
+		return reporter
+	}
+	if rules == nil {
+		return reporter
+	}
+	return ignoreReporter{ChunkReporter: reporter, rules: rules}
+}
+
+func loadIgnoreRules(repo *git.Repository, repoPath string) (*scanignore.Rules, error) {
+	if !isRepoBare(repoPath) {
+		return scanignore.Load(repoPath)
+	}
+
+	// A bare repository has no working tree, so the file is read from HEAD.
+	head, err := repo.Head()
+	if err != nil {
+		// The repository has no commits.
+		return nil, nil
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	file, err := commit.File(scanignore.FileName)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return scanignore.Parse(strings.NewReader(contents))
+}
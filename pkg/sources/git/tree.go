@@ -0,0 +1,90 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// ExtractTree writes the files of the tree at ref of the repository at repoPath to dir, which must exist. Unlike a
+// checkout, it leaves the working tree and index of the repository untouched. Symlinks and submodules are skipped.
+func ExtractTree(ctx context.Context, repoPath, ref, dir string) error {
+	repo, err := RepoFromPath(repoPath)
+	if err != nil {
+		return err
+	}
+	hash, err := resolveHash(repo, ref)
+	if err != nil {
+		return fmt.Errorf("unable to resolve ref %q: %w", ref, err)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "archive", "--format=tar", hash)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error running git archive: %w", err)
+	}
+
+	extractErr := extractTar(stdout, dir)
+	// Drain the archive so git can exit if extraction stopped early.
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("error running git archive: %w, %s", err, stderr.String())
+	}
+	return extractErr
+}
+
+// extractTar writes the directories and regular files of a tar archive to dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading git archive: %w", err)
+		}
+		if !filepath.IsLocal(hdr.Name) {
+			return fmt.Errorf("unexpected path in git archive: %q", hdr.Name)
+		}
+		path := filepath.Join(dir, hdr.Name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeTreeFile(path, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeTreeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -75,9 +76,20 @@ func (r *JobProgressRef) CancelRun(cause error) {
 	if r.jobProgress == nil || r.jobProgress.jobCancel == nil {
 		return
 	}
+	select {
+	case <-r.jobProgress.Done():
+	default:
+		r.jobProgress.cancelled.Store(true)
+	}
 	r.jobProgress.jobCancel(cause)
 }
 
+// Cancelled reports whether the job was cancelled by CancelRun before it
+// finished.
+func (r *JobProgressRef) Cancelled() bool {
+	return r.jobProgress != nil && r.jobProgress.cancelled.Load()
+}
+
 // Fatal is a wrapper around error to differentiate non-fatal errors from fatal
 // ones. A fatal error is typically from a finished context or any error
 // returned from a source's Init, Chunks, Enumerate, or ChunkUnit methods.
@@ -113,6 +125,8 @@ type JobProgress struct {
 	cancel context.CancelFunc
 	// Requests to cancel the job.
 	jobCancel context.CancelCauseFunc
+	// Set if the job was cancelled before it finished.
+	cancelled atomic.Bool
 	// Metrics.
 	metrics     JobProgressMetrics
 	metricsLock sync.Mutex
@@ -30,6 +30,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sanitizer"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/sampling"
 )
 
 const (
@@ -41,6 +42,10 @@ const (
 )
 
 type Source struct {
+	// Sampler, if set, scans a sample of the objects of each bucket instead of all of them, for buckets too large
+	// to scan fully.
+	Sampler *sampling.Sampler
+
 	name        string
 	sourceID    sources.SourceID
 	jobID       sources.JobID
@@ -231,8 +236,9 @@ type pageMetadata struct {
 
 // processingState tracks the state of concurrent S3 object processing.
 type processingState struct {
-	errorCount  *sync.Map // Thread-safe map tracking errors per prefix
-	objectCount *uint64   // Total number of objects processed
+	errorCount  *sync.Map      // Thread-safe map tracking errors per prefix
+	objectCount *uint64        // Total number of objects processed
+	sample      *sampling.Unit // Sampling state of the bucket, nil unless the scan is sampled
 }
 
 // resumePosition tracks where to restart scanning S3 buckets and objects after an interruption.
@@ -379,6 +385,18 @@ func (s *Source) scanBucket(
 
 	errorCount := sync.Map{}
 
+	var sample *sampling.Unit
+	if s.Sampler != nil {
+		// Sample by source unit, so that a bucket visited by several roles is counted once per role.
+		sample = s.Sampler.Unit(constructS3SourceUnitID(bucket, role))
+		if sample.NeedsListing() {
+			if err := observeBucket(ctx, regionalClient, bucket, sample); err != nil {
+				ctx.Logger().Error(err, "could not list objects in bucket for sampling")
+				return 0
+			}
+		}
+	}
+
 	input := &s3.ListObjectsV2Input{Bucket: &bucket}
 	if startAfter != nil {
 		input.StartAfter = startAfter
@@ -411,6 +429,7 @@ func (s *Source) scanBucket(
 		processingState := processingState{
 			errorCount:  &errorCount,
 			objectCount: &objectCount,
+			sample:      sample,
 		}
 		s.pageChunker(ctx, pageMetadata, processingState, reporter, checkpointer)
 
@@ -419,6 +438,22 @@ func (s *Source) scanBucket(
 	return objectCount
 }
 
+// observeBucket lists all objects of a bucket for a sampled scan, which needs their modification times to tell the
+// newest objects apart. Listing is cheap compared to downloading, even for buckets too large to scan fully.
+func observeBucket(ctx context.Context, client *s3.Client, bucket string, sample *sampling.Unit) error {
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{Bucket: &bucket})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range output.Contents {
+			sample.Observe(aws.ToTime(obj.LastModified))
+		}
+	}
+	return nil
+}
+
 // Chunks emits chunks of bytes over a channel.
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
 	visitor := func(c context.Context, defaultRegionClient *s3.Client, roleArn string, buckets []string) error {
@@ -508,6 +543,18 @@ func (s *Source) pageChunker(
 			continue
 		}
 
+		// Skip objects left out of a sampled scan.
+		if state.sample != nil {
+			if d := state.sample.Decide(*obj.Key, *obj.Size, aws.ToTime(obj.LastModified)); !d.Scan() {
+				ctx.Logger().V(5).Info("Skipping object left out of the sample")
+				s.metricsCollector.RecordObjectSkipped(metadata.bucket, "sampling", float64(*obj.Size))
+				if err := checkpointer.UpdateObjectCompletion(ctx, objIdx, metadata.bucket, metadata.role, metadata.page.Contents); err != nil {
+					ctx.Logger().Error(err, "could not update progress for object left out of the sample")
+				}
+				continue
+			}
+		}
+
 		s.jobPool.Go(func() error {
 			defer common.RecoverWithExit(ctx)
 			if common.IsDone(ctx) {
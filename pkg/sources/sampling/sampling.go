@@ -0,0 +1,262 @@
+// Package sampling selects a sample of the objects of sources too large to scan fully, such as petabyte-scale
+// buckets, and records how much of each source the sample covered. A sampled scan is a risk assessment rather than
+// an audit: it scans the newest objects and the small text files, where credentials are most likely, fully, and a
+// random sample of everything else.
+package sampling
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+const (
+	// DefaultNewestPercent is the share of the newest objects of each unit that is scanned fully.
+	DefaultNewestPercent = 10
+	// DefaultSmallFileSize is the size up to which text files are scanned fully.
+	DefaultSmallFileSize = 1024 * 1024
+	// DefaultSampleRate is the percentage of the remaining objects that is scanned.
+	DefaultSampleRate = 1
+
+	// reservoirSize is how many modification times are kept to estimate the cutoff of the newest objects. It bounds
+	// the memory of the listing pass regardless of the number of objects.
+	reservoirSize = 10000
+)
+
+// Config is a sampling strategy.
+type Config struct {
+	// NewestPercent is the percentage of the most recently modified objects of each unit that is scanned fully.
+	NewestPercent float64 `json:"newest_percent"`
+	// SmallFileSize is the size in bytes up to which objects that are not binaries are scanned fully.
+	SmallFileSize int64 `json:"small_file_size"`
+	// SampleRate is the percentage of the remaining objects that is scanned.
+	SampleRate float64 `json:"sample_rate"`
+}
+
+// Decision is what a sampled scan does with an object.
+type Decision int
+
+const (
+	// Skip leaves the object out of the sample.
+	Skip Decision = iota
+	// Newest scans the object fully because it is among the newest of its unit.
+	Newest
+	// SmallText scans the object fully because it is a small text file.
+	SmallText
+	// Sampled scans the object because it was drawn into the sample.
+	Sampled
+)
+
+// Scan reports whether the object is scanned.
+func (d Decision) Scan() bool { return d != Skip }
+
+func (d Decision) String() string {
+	switch d {
+	case Newest:
+		return "newest"
+	case SmallText:
+		return "small_text"
+	case Sampled:
+		return "sampled"
+	default:
+		return "skipped"
+	}
+}
+
+// Sampler samples the units of a source, such as the buckets of an account. It is safe for concurrent use.
+type Sampler struct {
+	cfg Config
+
+	mu    sync.Mutex
+	units map[string]*Unit
+	// order is the order in which the units were first sampled.
+	order []string
+}
+
+// New creates a Sampler with the given strategy.
+func New(cfg Config) *Sampler {
+	return &Sampler{cfg: cfg, units: make(map[string]*Unit)}
+}
+
+// Unit returns the sampling state of the unit with the given ID, creating it if needed.
+func (s *Sampler) Unit(id string) *Unit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.units[id]; ok {
+		return u
+	}
+	u := &Unit{
+		cfg:      s.cfg,
+		coverage: UnitCoverage{Unit: id},
+		// A fixed seed keeps the estimated cutoff, and so the sample, stable between scans of the same unit.
+		rng: rand.New(rand.NewPCG(0x7472756666, 0x686f67)),
+	}
+	s.units[id] = u
+	s.order = append(s.order, id)
+	return u
+}
+
+// Unit samples the objects of a single unit. Objects are first passed to Observe in a listing pass, if
+// NeedsListing, and then to Decide.
+type Unit struct {
+	cfg Config
+
+	mu sync.Mutex
+	// reservoir is a uniform sample of the modification times seen by Observe.
+	reservoir []time.Time
+	observed  uint64
+	planned   bool
+	cutoff    time.Time
+	rng       *rand.Rand
+	coverage  UnitCoverage
+}
+
+// NeedsListing reports whether the objects of the unit must be passed to Observe before Decide, to find the
+// modification time that separates the newest objects from the rest.
+func (u *Unit) NeedsListing() bool {
+	return u.cfg.NewestPercent > 0 && u.cfg.NewestPercent < 100
+}
+
+// Observe records the modification time of an object during the listing pass.
+func (u *Unit) Observe(modified time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.observed++
+	if len(u.reservoir) < reservoirSize {
+		u.reservoir = append(u.reservoir, modified)
+		return
+	}
+	if i := u.rng.Uint64N(u.observed); i < reservoirSize {
+		u.reservoir[i] = modified
+	}
+}
+
+// plan estimates the cutoff of the newest objects from the reservoir. Without a listing pass the cutoff stays zero,
+// which precedes all modification times. The caller must hold u.mu.
+func (u *Unit) plan() {
+	if u.planned {
+		return
+	}
+	u.planned = true
+	if u.NeedsListing() && len(u.reservoir) > 0 {
+		slices.SortFunc(u.reservoir, time.Time.Compare)
+		i := int(float64(len(u.reservoir)) * (1 - u.cfg.NewestPercent/100))
+		u.cutoff = u.reservoir[min(i, len(u.reservoir)-1)]
+		u.coverage.ModifiedCutoff = u.cutoff
+	}
+	u.reservoir = nil
+}
+
+// Decide decides whether to scan an object and records the decision in the coverage of the unit. The sample is
+// deterministic: the same object is sampled in every scan.
+func (u *Unit) Decide(key string, size int64, modified time.Time) Decision {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.plan()
+
+	var d Decision
+	switch {
+	case u.cfg.NewestPercent > 0 && !modified.Before(u.cutoff):
+		d = Newest
+	case size <= u.cfg.SmallFileSize && !common.IsBinary(key):
+		d = SmallText
+	case sampled(key, u.cfg.SampleRate):
+		d = Sampled
+	}
+
+	c := &u.coverage
+	c.ObjectsListed++
+	c.BytesListed += uint64(size)
+	switch d {
+	case Newest:
+		c.NewestObjects++
+	case SmallText:
+		c.SmallTextObjects++
+	case Sampled:
+		c.SampledObjects++
+	default:
+		c.SkippedObjects++
+		return d
+	}
+	c.ObjectsSelected++
+	c.BytesSelected += uint64(size)
+	return d
+}
+
+// sampled draws an object into a sample of rate percent by the hash of its key.
+func sampled(key string, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return float64(h.Sum64()%1_000_000) < rate*10_000
+}
+
+// Coverage describes how much of a source a sampled scan covered. Objects that the source skips regardless of
+// sampling, such as empty objects and those above its size limit, are not counted.
+type Coverage struct {
+	Strategy Config         `json:"strategy"`
+	Units    []UnitCoverage `json:"units"`
+
+	ObjectsListed   uint64 `json:"objects_listed"`
+	ObjectsSelected uint64 `json:"objects_selected"`
+	BytesListed     uint64 `json:"bytes_listed"`
+	BytesSelected   uint64 `json:"bytes_selected"`
+	// ObjectCoverage and ByteCoverage are the fractions of the objects and bytes listed that were selected.
+	ObjectCoverage float64 `json:"object_coverage"`
+	ByteCoverage   float64 `json:"byte_coverage"`
+}
+
+// UnitCoverage describes how much of a unit a sampled scan covered. Selected objects that then fail to download are
+// counted as errors of the source unit.
+type UnitCoverage struct {
+	Unit string `json:"unit"`
+	// ModifiedCutoff is the estimated modification time from which objects count as the newest.
+	ModifiedCutoff time.Time `json:"modified_cutoff,omitzero"`
+
+	ObjectsListed   uint64 `json:"objects_listed"`
+	ObjectsSelected uint64 `json:"objects_selected"`
+	BytesListed     uint64 `json:"bytes_listed"`
+	BytesSelected   uint64 `json:"bytes_selected"`
+
+	NewestObjects    uint64 `json:"newest_objects"`
+	SmallTextObjects uint64 `json:"small_text_objects"`
+	SampledObjects   uint64 `json:"sampled_objects"`
+	SkippedObjects   uint64 `json:"skipped_objects"`
+}
+
+// Coverage returns the coverage of the units sampled so far, in the order they were first sampled. It returns nil for
+// a nil Sampler, so that scans without sampling report no coverage.
+func (s *Sampler) Coverage() *Coverage {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := &Coverage{Strategy: s.cfg, Units: make([]UnitCoverage, 0, len(s.order))}
+	for _, id := range s.order {
+		u := s.units[id]
+		u.mu.Lock()
+		unit := u.coverage
+		u.mu.Unlock()
+
+		c.Units = append(c.Units, unit)
+		c.ObjectsListed += unit.ObjectsListed
+		c.ObjectsSelected += unit.ObjectsSelected
+		c.BytesListed += unit.BytesListed
+		c.BytesSelected += unit.BytesSelected
+	}
+	if c.ObjectsListed > 0 {
+		c.ObjectCoverage = float64(c.ObjectsSelected) / float64(c.ObjectsListed)
+	}
+	if c.BytesListed > 0 {
+		c.ByteCoverage = float64(c.BytesSelected) / float64(c.BytesListed)
+	}
+	return c
+}
@@ -0,0 +1,90 @@
+package sampling
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Decide(t *testing.T) {
+	s := New(Config{NewestPercent: 10, SmallFileSize: 1024, SampleRate: 5})
+	u := s.Unit("logs-bucket")
+	require.True(t, u.NeedsListing())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := func(i int) time.Time { return start.Add(time.Duration(i) * time.Hour) }
+	const objects = 1000
+	for i := 0; i < objects; i++ {
+		u.Observe(modified(i))
+	}
+
+	decisions := make(map[Decision]int)
+	for i := 0; i < objects; i++ {
+		key := fmt.Sprintf("archive/%04d.tar.gz", i)
+		size := int64(10 * 1024 * 1024)
+		if i%10 == 0 {
+			key, size = fmt.Sprintf("config/%04d.env", i), 512
+		}
+		d := u.Decide(key, size, modified(i))
+		if i >= 900 {
+			assert.Equal(t, Newest, d, "object %d", i)
+		}
+		decisions[d]++
+	}
+
+	assert.Equal(t, 100, decisions[Newest])
+	assert.Equal(t, 90, decisions[SmallText])
+	// About 5% of the remaining 810 objects.
+	assert.InDelta(t, 40, decisions[Sampled], 20)
+	assert.Equal(t, objects-decisions[Newest]-decisions[SmallText]-decisions[Sampled], decisions[Skip])
+
+	// The sample doesn't change between scans.
+	again := New(Config{NewestPercent: 10, SmallFileSize: 1024, SampleRate: 5}).Unit("logs-bucket")
+	assert.Equal(t, Sampled == u.Decide("archive/0001.tar.gz", 10*1024*1024, modified(1)), Sampled == again.Decide("archive/0001.tar.gz", 10*1024*1024, modified(1)))
+
+	c := s.Coverage()
+	require.Len(t, c.Units, 1)
+	unit := c.Units[0]
+	assert.Equal(t, modified(900), unit.ModifiedCutoff)
+	assert.Equal(t, uint64(objects+1), unit.ObjectsListed)
+	assert.Equal(t, unit.NewestObjects+unit.SmallTextObjects+unit.SampledObjects, unit.ObjectsSelected)
+	assert.Equal(t, unit.ObjectsListed, unit.ObjectsSelected+unit.SkippedObjects)
+	assert.InDelta(t, float64(unit.ObjectsSelected)/float64(unit.ObjectsListed), c.ObjectCoverage, 1e-9)
+	assert.Less(t, c.ByteCoverage, c.ObjectCoverage)
+}
+
+func TestUnit_NoListing(t *testing.T) {
+	// Without newest objects, nothing needs to be listed in advance.
+	u := New(Config{SmallFileSize: 1024, SampleRate: 0}).Unit("bucket")
+	assert.False(t, u.NeedsListing())
+	assert.Equal(t, SmallText, u.Decide("a.json", 100, time.Now()))
+	assert.Equal(t, Skip, u.Decide("a.bin", 100, time.Now()))
+	assert.Equal(t, Skip, u.Decide("b.json", 4096, time.Now()))
+
+	// All objects are the newest.
+	u = New(Config{NewestPercent: 100}).Unit("bucket")
+	assert.False(t, u.NeedsListing())
+	assert.Equal(t, Newest, u.Decide("a.bin", 1<<30, time.Time{}))
+}
+
+func TestSampler_Coverage(t *testing.T) {
+	var s *Sampler
+	assert.Nil(t, s.Coverage())
+
+	s = New(Config{SampleRate: 100})
+	s.Unit("b").Decide("x.bin", 10, time.Now())
+	s.Unit("a").Decide("y.bin", 30, time.Now())
+	c := s.Coverage()
+	assert.Equal(t, []string{"b", "a"}, []string{c.Units[0].Unit, c.Units[1].Unit})
+	assert.Equal(t, uint64(40), c.BytesSelected)
+	assert.Equal(t, 1.0, c.ByteCoverage)
+
+	// Units without a cutoff don't report one.
+	data, err := json.Marshal(c.Units[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "modified_cutoff")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,6 +28,11 @@ type SourceManager struct {
 	wg          sync.WaitGroup
 	// Max number of units to scan concurrently per source.
 	concurrentUnits int
+	// Max number of chunks a unit may buffer ahead of the scanner.
+	unitChunkBuffer int
+	// Orders enumerated units before they are chunked. Nil keeps the
+	// enumeration order.
+	unitPriority UnitPriorityFunc
 	// Run the sources using source unit enumeration / chunking if available.
 	// Checked at runtime to allow feature flagging.
 	useSourceUnitsFunc func() bool
@@ -36,6 +42,11 @@ type SourceManager struct {
 	firstErr chan error
 	waitErr  error
 	done     bool
+	// Jobs started by EnumerateAndScan, for CancelAll.
+	jobsMu sync.Mutex
+	jobs   []JobProgressRef
+	// Checkpointed jobs to resume, by source name.
+	resume map[string][]CheckpointJob
 }
 
 // apiClient is an interface for optionally communicating with an external API.
@@ -100,6 +111,22 @@ func WithConcurrentUnits(n int) func(*SourceManager) {
 	return func(mgr *SourceManager) { mgr.concurrentUnits = n }
 }
 
+// WithUnitChunkBuffer sets the number of chunks a single unit may buffer
+// before it waits for them to be scanned. Lower values keep one large unit
+// from filling the output buffer ahead of the others. It only sets read-ahead:
+// how many chunks of a unit are produced concurrently is up to the source,
+// e.g. the concurrency it was initialized with.
+func WithUnitChunkBuffer(n int) func(*SourceManager) {
+	return func(mgr *SourceManager) { mgr.unitChunkBuffer = n }
+}
+
+// WithUnitPriority chunks enumerated units in the order given by f instead of
+// the order they were enumerated in. Enumeration is no longer throttled by
+// chunking, so all units are held in memory until they are chunked.
+func WithUnitPriority(f UnitPriorityFunc) func(*SourceManager) {
+	return func(mgr *SourceManager) { mgr.unitPriority = f }
+}
+
 // The default channel size for all the channels that are used to transport chunks.
 const defaultChannelSize = 64
 
@@ -107,11 +134,12 @@ const defaultChannelSize = 64
 func NewManager(opts ...func(*SourceManager)) *SourceManager {
 	mgr := SourceManager{
 		// Default to the headless API. Can be overwritten by the WithAPI option.
-		api:          &headlessAPI{},
-		sem:          semaphore.New(runtime.NumCPU()),
-		prioritySem:  semaphore.New(runtime.NumCPU()),
-		outputChunks: make(chan *Chunk, defaultChannelSize),
-		firstErr:     make(chan error, 1),
+		api:             &headlessAPI{},
+		sem:             semaphore.New(runtime.NumCPU()),
+		prioritySem:     semaphore.New(runtime.NumCPU()),
+		outputChunks:    make(chan *Chunk, defaultChannelSize),
+		firstErr:        make(chan error, 1),
+		unitChunkBuffer: defaultChannelSize,
 	}
 	for _, opt := range opts {
 		opt(&mgr)
@@ -136,6 +164,24 @@ func (s *SourceManager) EnumerateAndScan(ctx context.Context, sourceName string,
 			JobID:      jobID,
 		}, err
 	}
+	if len(targets) == 0 {
+		if job, ok := s.resumeJob(sourceName); ok {
+			if job.Done {
+				// The job had finished before the checkpoint was written.
+				ctx.Logger().Info("skipping source finished before the checkpoint", "source_name", sourceName)
+				progress := NewJobProgress(jobID, sourceID, sourceName)
+				progress.Finish()
+				s.jobsMu.Lock()
+				s.jobs = append(s.jobs, progress.Ref())
+				s.jobsMu.Unlock()
+				return progress.Ref(), nil
+			}
+			if progress := source.GetProgress(); progress != nil && job.ResumeInfo != "" {
+				ctx.Logger().Info("resuming source from checkpoint", "source_name", sourceName)
+				progress.SetProgressOngoing("resuming from checkpoint", job.ResumeInfo)
+			}
+		}
+	}
 	// Create a JobProgress object for tracking progress.
 	sem := s.sem
 	if len(targets) > 0 {
@@ -143,10 +189,25 @@ func (s *SourceManager) EnumerateAndScan(ctx context.Context, sourceName string,
 	}
 	ctx, cancel := context.WithCancelCause(ctx)
 	progress := NewJobProgress(jobID, sourceID, sourceName, WithHooks(s.hooks...), WithCancel(cancel))
+	// Register the job before waiting on the pool, so that CancelAll also
+	// cancels the jobs still waiting for a slot.
+	s.jobsMu.Lock()
+	s.jobs = append(s.jobs, progress.Ref())
+	s.jobsMu.Unlock()
 	if err := sem.Acquire(ctx, 1); err != nil {
 		// Context cancelled.
+		if cause := context.Cause(ctx); cause != nil {
+			err = cause
+		}
 		progress.ReportError(Fatal{err})
-		return progress.Ref(), Fatal{err}
+		progress.Finish()
+		ref := progress.Ref()
+		if ref.Cancelled() {
+			// Cancelled by CancelRun or CancelAll before it started, which
+			// is not an error of the caller.
+			return ref, nil
+		}
+		return ref, Fatal{err}
 	}
 	s.wg.Add(1)
 	go func() {
@@ -293,6 +354,23 @@ func (s *SourceManager) Wait() error {
 	return s.waitErr
 }
 
+// CancelAll cancels every job started by EnumerateAndScan with the given
+// cause. Jobs that have already finished are unaffected.
+func (s *SourceManager) CancelAll(cause error) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	for _, ref := range s.jobs {
+		ref.CancelRun(cause)
+	}
+}
+
+// Jobs returns the jobs started by EnumerateAndScan so far, finished or not.
+func (s *SourceManager) Jobs() []JobProgressRef {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	return slices.Clone(s.jobs)
+}
+
 // ScanChunk injects a chunk into the output stream of chunks to be scanned.
 // This method should rarely be used. TODO(THOG-1577): Remove when dependencies
 // no longer rely on this functionality.
@@ -555,10 +633,10 @@ func (s *SourceManager) runWithUnits(ctx context.Context, source SourceUnitEnumC
 		// Negative values indicated no limit.
 		unitPool.SetLimit(s.concurrentUnits)
 	}
-	for unit := range unitReporter.unitCh {
+	for unit := range s.orderUnits(unitReporter.unitCh) {
 		chunkReporter := &mgrChunkReporter{
 			unit:    unit,
-			chunkCh: make(chan *Chunk, defaultChannelSize),
+			chunkCh: make(chan *Chunk, s.unitChunkBuffer),
 			report:  report,
 		}
 		// Consume units and produce chunks.
@@ -600,13 +678,41 @@ func (s *SourceManager) runWithUnits(ctx context.Context, source SourceUnitEnumC
 	}
 }
 
+// orderUnits returns the units from in ordered by the manager's unit priority.
+// The units are buffered as they are enumerated, so whenever a unit slot
+// frees up the highest priority unit seen so far is chunked next.
+func (s *SourceManager) orderUnits(in <-chan SourceUnit) <-chan SourceUnit {
+	if s.unitPriority == nil {
+		return in
+	}
+	queue := newUnitQueue(s.unitPriority)
+	go func() {
+		defer queue.Close()
+		for unit := range in {
+			queue.Push(unit)
+		}
+	}()
+	out := make(chan SourceUnit)
+	go func() {
+		defer close(out)
+		for {
+			unit, ok := queue.Pop()
+			if !ok {
+				return
+			}
+			out <- unit
+		}
+	}()
+	return out
+}
+
 // scanWithUnit produces chunks from a single SourceUnit.
 func (s *SourceManager) scanWithUnit(ctx context.Context, source SourceUnitChunker, report *JobProgress, unit SourceUnit) error {
 	// Create a function that will save the first error encountered (if
 	// any) and discard the rest.
 	chunkReporter := &mgrChunkReporter{
 		unit:    unit,
-		chunkCh: make(chan *Chunk, defaultChannelSize),
+		chunkCh: make(chan *Chunk, s.unitChunkBuffer),
 		report:  report,
 	}
 	// Produce chunks from the given unit.
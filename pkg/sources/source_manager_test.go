@@ -365,6 +365,44 @@ func TestSourceManagerCancelRun(t *testing.T) {
 	assert.True(t, errors.Is(ref.Snapshot().FatalErrors(), cancelErr))
 }
 
+func TestSourceManagerCancelAll(t *testing.T) {
+	mgr := NewManager(WithBufferedOutput(8), WithConcurrentSources(2))
+	started := make(chan struct{}, 3)
+	blocking := callbackChunker{func(ctx context.Context, _ chan *Chunk) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	for i := 0; i < 2; i++ {
+		source, err := buildDummy(blocking)
+		assert.NoError(t, err)
+		_, err = mgr.EnumerateAndScan(context.Background(), "dummy", source)
+		assert.NoError(t, err)
+	}
+	<-started
+	<-started
+
+	// The third source waits for a slot until it is cancelled.
+	queued := make(chan error, 1)
+	go func() {
+		source, err := buildDummy(blocking)
+		assert.NoError(t, err)
+		_, err = mgr.EnumerateAndScan(context.Background(), "dummy", source)
+		queued <- err
+	}()
+	assert.Eventually(t, func() bool { return len(mgr.Jobs()) == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	cancelErr := fmt.Errorf("limit reached")
+	mgr.CancelAll(cancelErr)
+	assert.NoError(t, <-queued)
+	for _, ref := range mgr.Jobs() {
+		<-ref.Done()
+		assert.True(t, ref.Cancelled())
+		assert.True(t, errors.Is(ref.Snapshot().FatalErrors(), cancelErr))
+	}
+	assert.Len(t, started, 0, "the queued source should never start")
+}
+
 func TestSourceManagerAvailableCapacity(t *testing.T) {
 	mgr := NewManager(WithConcurrentSources(1337))
 	start, end := make(chan struct{}), make(chan struct{})
@@ -5,13 +5,16 @@ import (
 	"errors"
 	"runtime"
 	"sync"
+	"time"
 
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detector_typepb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/sampling"
 )
 
 type (
@@ -40,13 +43,28 @@ type Chunk struct {
 
 	// SourceMetadata holds the context of where the Chunk was found.
 	SourceMetadata *source_metadatapb.MetaData
-	// SourceType is the type of Source that produced the chunk.
+	// Annotations locate the chunk within its file where a line number alone does not, e.g. the cell and output
+	// of a Jupyter notebook. They are added to the extra data of every result found in the chunk.
+	Annotations map[string]string
+	// ReportLines, if set, restricts results to secrets found on these lines of Data, counted from 0. The other lines
+	// only give context to detectors, e.g. the unchanged lines around a staged change.
+	ReportLines []int
+	// Suppress, if set, reports whether results of a detector type are suppressed in the chunk, e.g. by the
+	// .securityscanignore file of the scanned repository. Suppressed results are not verified or reported, only
+	// counted.
+	Suppress func(detector_typepb.DetectorType) bool
+	// SourceType is the type of Source that produced the chunk. It is kept next to SourceVerify to avoid padding.
 	SourceType sourcespb.SourceType
 
 	// SourceVerify specifies whether this chunk was generated by a source that has verification enabled in its config.
 	SourceVerify bool
 }
 
+// Suppressed reports whether results of the detector type are suppressed in the chunk.
+func (c *Chunk) Suppressed(detectorType detector_typepb.DetectorType) bool {
+	return c.Suppress != nil && c.Suppress(detectorType)
+}
+
 // ChunkingTarget specifies criteria for a targeted chunking process.
 // Instead of collecting data indiscriminately, this struct allows the caller
 // to specify particular subsets of data they're interested in. This becomes
@@ -293,6 +311,15 @@ type GitConfig struct {
 	TrustLocalGitConfig bool
 }
 
+// StagedConfig defines the configuration for a scan of the staged changes of a git repository.
+type StagedConfig struct {
+	// Path is the path of the repository.
+	Path string
+	// ContextLines is the number of unchanged lines scanned around each staged change. Secrets are only reported on
+	// added lines.
+	ContextLines int
+}
+
 // GithubConfig defines the optional configuration for a github source.
 type GithubConfig struct {
 	// Endpoint is the endpoint of the source.
@@ -411,6 +438,8 @@ type S3Config struct {
 	Roles []string
 	// MaxObjectSize is the maximum object size to scan.
 	MaxObjectSize int64
+	// Sampler, if set, scans a sample of the objects of each bucket instead of all of them.
+	Sampler *sampling.Sampler
 }
 
 // SyslogConfig defines the optional configuration for a syslog source.
@@ -474,7 +503,44 @@ type ElasticsearchConfig struct {
 }
 
 // StdinConfig defines the configuration for a stdin source.
-type StdinConfig struct{}
+type StdinConfig struct {
+	// Stream scans the input line by line as it arrives, for tail-follow pipelines.
+	Stream bool
+	// IdleFlush is how long to wait for more streamed input before scanning the buffered lines.
+	IdleFlush time.Duration
+}
+
+// WebConfig defines the configuration for a web crawl source.
+type WebConfig struct {
+	// URLs are the target URLs to crawl.
+	URLs []string
+	// MaxDepth is how many links deep pages are followed.
+	MaxDepth int
+	// MaxRequests limits the requests made for each target URL.
+	MaxRequests int
+	// AllowedHosts are additional hosts that may be fetched, like a CDN serving the scripts.
+	AllowedHosts []string
+	// IgnoreRobots fetches pages even if robots.txt disallows it.
+	IgnoreRobots bool
+}
+
+// CIArtifactsConfig defines the configuration for a CI artifacts source.
+type CIArtifactsConfig struct {
+	// GitHubRepos are the GitHub repositories whose Actions runs are scanned, as owner/repo.
+	GitHubRepos []string
+	// GitHubToken authenticates to the GitHub API.
+	GitHubToken string
+	// GitHubEndpoint is the GitHub API to use.
+	GitHubEndpoint string
+	// BitbucketRepos are the Bitbucket repositories whose pipelines are scanned, as workspace/repo.
+	BitbucketRepos []string
+	// BitbucketToken authenticates to the Bitbucket API, either an access token or username:app_password.
+	BitbucketToken string
+	// BitbucketEndpoint is the Bitbucket API to use.
+	BitbucketEndpoint string
+	// MaxRuns is how many recent runs of each repository are scanned.
+	MaxRuns int
+}
 
 // JSONEnumeratorConfig defines the configuration for a JSON enumerator source.
 type JSONEnumeratorConfig struct {
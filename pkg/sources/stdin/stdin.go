@@ -1,7 +1,9 @@
 package stdin
 
 import (
+	"io"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -16,6 +18,15 @@ import (
 const SourceType = sourcespb.SourceType_SOURCE_TYPE_STDIN
 
 type Source struct {
+	// Stream scans the input line by line as it arrives instead of handling it as a single file. Use it for
+	// never-ending input like `kubectl logs -f`.
+	Stream bool
+	// IdleFlush is how long a streaming source waits for more input before it scans the lines it has buffered.
+	// Default: DefaultIdleFlush.
+	IdleFlush time.Duration
+
+	// reader replaces os.Stdin in tests.
+	reader   io.Reader
 	name     string
 	sourceId sources.SourceID
 	jobId    sources.JobID
@@ -51,7 +62,10 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 }
 
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
-	stdin := os.Stdin
+	var stdin io.Reader = os.Stdin
+	if s.reader != nil {
+		stdin = s.reader
+	}
 	chunkSkel := &sources.Chunk{
 		SourceType: s.Type(),
 		SourceName: s.name,
@@ -63,6 +77,11 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 		SourceVerify: s.verify,
 	}
 
+	if s.Stream {
+		ctx.Logger().Info("streaming stdin for secrets")
+		return streamChunks(ctx, stdin, s.IdleFlush, chunkSkel, chunksChan)
+	}
+
 	ctx.Logger().Info("scanning stdin for secrets")
 	return handlers.HandleFile(ctx, stdin, chunkSkel, sources.ChanReporter{Ch: chunksChan})
 }
@@ -0,0 +1,134 @@
+package stdin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// DefaultIdleFlush is how long a streaming Source waits for more input before it scans the lines it has buffered.
+const DefaultIdleFlush = time.Second
+
+// streamRead is a piece of input read by the reader goroutine of a stream. data is a complete line including its
+// newline, or a fragment of a line that is longer than a chunk.
+type streamRead struct {
+	data []byte
+	err  error
+}
+
+// streamChunks reads r line by line and sends chunks of at most sources.DefaultChunkSize new bytes to chunksChan.
+// Every chunk is prefixed with the last lines of the previous one, up to sources.DefaultPeekSize bytes, so secrets
+// spanning a chunk boundary are still found. Buffered lines are sent as soon as the input stays idle for idleFlush,
+// which gives tail-follow pipelines near-real-time results. Sends block until the chunk is accepted, so a slow scan
+// stops reading from r and the pipe pushes back on its writer.
+func streamChunks(ctx context.Context, r io.Reader, idleFlush time.Duration, chunkSkel *sources.Chunk, chunksChan chan *sources.Chunk) error {
+	if idleFlush <= 0 {
+		idleFlush = DefaultIdleFlush
+	}
+
+	reads := make(chan streamRead)
+	done := make(chan struct{})
+	defer close(done)
+	go readLines(r, reads, done)
+
+	idle := time.NewTimer(idleFlush)
+	defer idle.Stop()
+
+	var (
+		buf []byte
+		// fresh is the number of bytes at the end of buf that were not scanned yet.
+		fresh int
+	)
+	flush := func() error {
+		if fresh == 0 {
+			return nil
+		}
+		chunk := *chunkSkel
+		chunk.Data = bytes.Clone(buf)
+		select {
+		case chunksChan <- &chunk:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		buf = overlap(buf)
+		fresh = 0
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-idle.C:
+			if err := flush(); err != nil {
+				return nil
+			}
+			idle.Reset(idleFlush)
+		case read := <-reads:
+			if len(read.data) > 0 {
+				buf = append(buf, read.data...)
+				fresh += len(read.data)
+				if fresh >= sources.DefaultChunkSize {
+					if err := flush(); err != nil {
+						return nil
+					}
+				}
+				if !idle.Stop() {
+					select {
+					case <-idle.C:
+					default:
+					}
+				}
+				idle.Reset(idleFlush)
+			}
+			if read.err != nil {
+				if err := flush(); err != nil {
+					return nil
+				}
+				if errors.Is(read.err, io.EOF) {
+					return nil
+				}
+				return fmt.Errorf("error reading stdin: %w", read.err)
+			}
+		}
+	}
+}
+
+// readLines sends the lines of r to reads until r returns an error, which is sent last. Lines longer than a chunk are
+// split into fragments of chunk size.
+func readLines(r io.Reader, reads chan<- streamRead, done <-chan struct{}) {
+	br := bufio.NewReaderSize(r, sources.DefaultChunkSize)
+	for {
+		line, err := br.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			err = nil
+		}
+		select {
+		case reads <- streamRead{data: bytes.Clone(line), err: err}:
+		case <-done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// overlap returns the trailing lines of buf that fit into sources.DefaultPeekSize bytes. If the last line alone is
+// longer, its last sources.DefaultPeekSize bytes are returned.
+func overlap(buf []byte) []byte {
+	if len(buf) <= sources.DefaultPeekSize {
+		return buf
+	}
+	tail := buf[len(buf)-sources.DefaultPeekSize:]
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return bytes.Clone(tail)
+}
@@ -0,0 +1,179 @@
+package sources
+
+import (
+	"container/heap"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Priorities returned by DefaultUnitPriority and PathPriority. Units and
+// files with a higher priority are chunked first.
+const (
+	UnitPriorityLow    = -1
+	UnitPriorityNormal = 0
+	UnitPriorityHigh   = 1
+)
+
+// UnitPriorityFunc ranks a SourceUnit. Units with a higher value are chunked
+// before units with a lower value; ties keep their enumeration order.
+type UnitPriorityFunc func(SourceUnit) int
+
+// vendoredDirs are directories holding third party code. Secrets found there
+// rarely belong to the scanned project.
+var vendoredDirs = map[string]struct{}{
+	"vendor":           {},
+	"node_modules":     {},
+	"bower_components": {},
+	"third_party":      {},
+	"third-party":      {},
+	"thirdparty":       {},
+	"site-packages":    {},
+	".venv":            {},
+	"venv":             {},
+	"pods":             {},
+}
+
+// configFiles are file names that commonly hold credentials.
+var configFiles = map[string]struct{}{
+	".npmrc":              {},
+	".pypirc":             {},
+	".netrc":              {},
+	".git-credentials":    {},
+	".dockercfg":          {},
+	"credentials":         {},
+	"settings.xml":        {},
+	"docker-compose.yml":  {},
+	"docker-compose.yaml": {},
+}
+
+// configExts are file extensions of configuration files.
+var configExts = map[string]struct{}{
+	".env":        {},
+	".properties": {},
+	".yaml":       {},
+	".yml":        {},
+	".toml":       {},
+	".ini":        {},
+	".cfg":        {},
+	".conf":       {},
+	".config":     {},
+	".tfvars":     {},
+	".tfstate":    {},
+}
+
+// DefaultUnitPriority ranks units by their ID, which for most sources is a
+// path, URL or repository name, using PathPriority.
+func DefaultUnitPriority(unit SourceUnit) int {
+	id, _ := unit.SourceUnitID()
+	return PathPriority(id)
+}
+
+// PathPriority ranks a file path. Env and config files are ranked high and
+// anything under a vendored directory is ranked low, so findings in the
+// project's own configuration surface early in a long scan. Sources that walk
+// files pass paths relative to the scanned root, so a vendored directory
+// above the root does not lower every file in it.
+func PathPriority(p string) int {
+	p = strings.ToLower(strings.ReplaceAll(p, "\\", "/"))
+
+	for _, segment := range strings.Split(path.Dir(p), "/") {
+		if _, ok := vendoredDirs[segment]; ok {
+			return UnitPriorityLow
+		}
+	}
+
+	base := path.Base(p)
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return UnitPriorityHigh
+	}
+	if _, ok := configFiles[base]; ok {
+		return UnitPriorityHigh
+	}
+	if _, ok := configExts[path.Ext(base)]; ok {
+		return UnitPriorityHigh
+	}
+	return UnitPriorityNormal
+}
+
+// PriorityPasses are the priorities returned by PathPriority, highest first.
+// Sources that cannot sort their files up front scan them in one pass per
+// priority.
+var PriorityPasses = []int{UnitPriorityHigh, UnitPriorityNormal, UnitPriorityLow}
+
+// unitQueue is an unbounded queue of units ordered by priority, then by the
+// order they were pushed in.
+type unitQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	items    unitHeap
+	seq      int
+	closed   bool
+	priority UnitPriorityFunc
+}
+
+func newUnitQueue(priority UnitPriorityFunc) *unitQueue {
+	q := &unitQueue{priority: priority}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Push adds a unit to the queue.
+func (q *unitQueue) Push(unit SourceUnit) {
+	item := prioritizedUnit{unit: unit, priority: q.priority(unit)}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item.seq = q.seq
+	q.seq++
+	heap.Push(&q.items, item)
+	q.cond.Signal()
+}
+
+// Close marks the queue as complete. Units already in the queue can still be
+// popped.
+func (q *unitQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// Pop blocks until a unit is available and returns the one with the highest
+// priority. It returns false once the queue is closed and empty.
+func (q *unitQueue) Pop() (SourceUnit, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.items) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	return heap.Pop(&q.items).(prioritizedUnit).unit, true
+}
+
+type prioritizedUnit struct {
+	unit     SourceUnit
+	priority int
+	seq      int
+}
+
+// unitHeap implements heap.Interface.
+type unitHeap []prioritizedUnit
+
+func (h unitHeap) Len() int { return len(h) }
+func (h unitHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+func (h unitHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *unitHeap) Push(x any)   { *h = append(*h, x.(prioritizedUnit)) }
+func (h *unitHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
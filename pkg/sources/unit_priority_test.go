@@ -0,0 +1,77 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestDefaultUnitPriority(t *testing.T) {
+	tests := []struct {
+		id   string
+		want int
+	}{
+		{id: ".env", want: UnitPriorityHigh},
+		{id: "deploy/.env.production", want: UnitPriorityHigh},
+		{id: "src/main/resources/application.properties", want: UnitPriorityHigh},
+		{id: `C:\repo\config\settings.YAML`, want: UnitPriorityHigh},
+		{id: "/home/dev/.npmrc", want: UnitPriorityHigh},
+		{id: "infra/prod.tfvars", want: UnitPriorityHigh},
+		{id: "cmd/server/main.go", want: UnitPriorityNormal},
+		{id: "https://github.com/acme/app.git", want: UnitPriorityNormal},
+		{id: "vendor/github.com/acme/lib/config.yaml", want: UnitPriorityLow},
+		{id: "web/node_modules/pkg/.env", want: UnitPriorityLow},
+		{id: "vendor", want: UnitPriorityNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefaultUnitPriority(CommonSourceUnit{ID: tt.id}))
+		})
+	}
+}
+
+func TestUnitQueue(t *testing.T) {
+	queue := newUnitQueue(DefaultUnitPriority)
+	for _, id := range []string{"vendor/a/a.go", "main.go", "vendor/b/.env", ".env", "util.go", "app.yaml"} {
+		queue.Push(CommonSourceUnit{ID: id})
+	}
+	queue.Close()
+
+	var got []string
+	for {
+		unit, ok := queue.Pop()
+		if !ok {
+			break
+		}
+		id, _ := unit.SourceUnitID()
+		got = append(got, id)
+	}
+	assert.Equal(t, []string{".env", "app.yaml", "main.go", "util.go", "vendor/a/a.go", "vendor/b/.env"}, got)
+}
+
+func TestSourceManagerUnitPriority(t *testing.T) {
+	input := []unitChunk{
+		{unit: "vendor/lib/lib.go", output: "lib"},
+		{unit: "main.go", output: "main"},
+		{unit: ".env", output: "env"},
+	}
+	mgr := NewManager(
+		WithBufferedOutput(8),
+		WithSourceUnits(),
+		WithConcurrentUnits(1),
+		WithUnitChunkBuffer(1),
+		WithUnitPriority(DefaultUnitPriority),
+	)
+	source, err := buildDummy(&unitChunker{input})
+	assert.NoError(t, err)
+	ref, err := mgr.EnumerateAndScan(context.Background(), "dummy", source)
+	assert.NoError(t, err)
+	<-ref.Done()
+
+	report := ref.Snapshot()
+	assert.NoError(t, report.FatalError())
+	assert.Equal(t, len(input), int(report.FinishedUnits))
+	assert.Equal(t, len(input), int(report.TotalChunks))
+}
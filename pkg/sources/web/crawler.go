@@ -0,0 +1,403 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sourcemap"
+)
+
+type resourceKind int
+
+const (
+	kindPage resourceKind = iota
+	kindScript
+	kindSourceMap
+	kindConfig
+)
+
+// configPaths are well-known endpoints that single page applications load their runtime configuration from. They
+// are requested relative to the root of every seed URL.
+var configPaths = []string{
+	"/config.json",
+	"/env.js",
+	"/env-config.js",
+	"/runtime-config.js",
+	"/app-config.json",
+	"/assets/config.json",
+	"/static/config.json",
+}
+
+var sourceMappingURLPat = regexp.MustCompile(`(?m)^[ \t]*//[#@][ \t]*sourceMappingURL=(\S+)[ \t]*$`)
+
+// resource is a fetched document to be scanned.
+type resource struct {
+	// url is the URL the document was fetched from.
+	url string
+	// file is the original path of a source file embedded in a source map.
+	file string
+	data []byte
+}
+
+type crawlItem struct {
+	url   *url.URL
+	depth int
+	kind  resourceKind
+	// bundle is the script a source map item belongs to. It is scanned if the source map doesn't embed all of its
+	// sources.
+	bundle *resource
+}
+
+// crawler fetches a target URL and follows its links, scripts and source maps, within the hosts in scope.
+type crawler struct {
+	client      *http.Client
+	maxDepth    int
+	maxRequests int
+	maxBodySize int64
+	// hosts are the hosts that may be fetched.
+	hosts        map[string]struct{}
+	ignoreRobots bool
+
+	// pageClient is a copy of client that doesn't follow redirects. Redirects are followed by fetch instead, so
+	// that their targets are checked against the scope and robots.txt and counted against maxRequests like any
+	// other URL.
+	pageClient *http.Client
+	// robotsClient is a copy of client that only follows redirects within the hosts in scope, e.g. from http to
+	// https.
+	robotsClient *http.Client
+	robots       map[string]*robotsRules
+	seen         map[string]struct{}
+	requests     int
+}
+
+// maxRedirects bounds the redirects followed for a single URL, like the default of net/http.
+const maxRedirects = 10
+
+// response is the successful response to a fetch.
+type response struct {
+	// url is the URL the response was received from, after redirects.
+	url         *url.URL
+	data        []byte
+	contentType string
+	// sourceMap is the source map the response references in its headers.
+	sourceMap string
+}
+
+// crawl fetches seed and everything it links to, breadth first, and calls emit for every document to scan. It stops
+// once maxDepth pages deep or after maxRequests requests.
+func (c *crawler) crawl(ctx context.Context, seed *url.URL, emit func(resource) error) error {
+	if c.robots == nil {
+		c.robots = make(map[string]*robotsRules)
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]struct{})
+	}
+	if c.pageClient == nil {
+		pageClient := *c.client
+		pageClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		c.pageClient = &pageClient
+	}
+	if c.robotsClient == nil {
+		robotsClient := *c.client
+		robotsClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if _, ok := c.hosts[req.URL.Host]; !ok || len(via) >= maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		}
+		c.robotsClient = &robotsClient
+	}
+
+	queue := []crawlItem{{url: seed, kind: kindPage}}
+	for _, p := range configPaths {
+		queue = append(queue, crawlItem{url: seed.ResolveReference(&url.URL{Path: p}), kind: kindConfig})
+	}
+
+	for len(queue) > 0 {
+		if ctx.Err() != nil || c.requests >= c.maxRequests {
+			return nil
+		}
+		item := queue[0]
+		queue = queue[1:]
+
+		res, ok := c.fetch(ctx, item.url, 0)
+		if !ok {
+			if item.bundle != nil {
+				if err := emit(*item.bundle); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		item.url = res.url
+		data, contentType, sourceMapURL := res.data, res.contentType, res.sourceMap
+
+		switch item.kind {
+		case kindPage:
+			if err := emit(resource{url: item.url.String(), data: data}); err != nil {
+				return err
+			}
+			if !isHTML(contentType, data) {
+				continue
+			}
+			scripts, pages := extractLinks(item.url, data)
+			for _, u := range scripts {
+				queue = append(queue, crawlItem{url: u, depth: item.depth, kind: kindScript})
+			}
+			if item.depth < c.maxDepth {
+				for _, u := range pages {
+					queue = append(queue, crawlItem{url: u, depth: item.depth + 1, kind: kindPage})
+				}
+			}
+		case kindScript:
+			// Minified bundles are only scanned if their source map can't be used instead, because detectors find
+			// more in the original sources.
+			bundle := &resource{url: item.url.String(), data: data}
+			if m := sourceMappingURLPat.FindAllSubmatch(data, -1); len(m) > 0 {
+				sourceMapURL = string(m[len(m)-1][1])
+			}
+			if strings.HasPrefix(sourceMapURL, "data:") {
+				if err := emitSourceMap(item.url.String(), decodeDataURL(sourceMapURL), bundle, emit); err != nil {
+					return err
+				}
+				continue
+			}
+			var mapURL *url.URL
+			if sourceMapURL != "" {
+				ref, err := url.Parse(sourceMapURL)
+				if err != nil {
+					if err := emit(*bundle); err != nil {
+						return err
+					}
+					continue
+				}
+				mapURL = item.url.ResolveReference(ref)
+			} else {
+				// Source maps are often deployed next to the bundle even if the reference comment was stripped.
+				guess := *item.url
+				guess.Path += ".map"
+				guess.RawPath = ""
+				guess.RawQuery = ""
+				mapURL = &guess
+			}
+			queue = append(queue, crawlItem{url: mapURL, depth: item.depth, kind: kindSourceMap, bundle: bundle})
+		case kindSourceMap:
+			// A guessed source map URL may be answered with the index page of a single page application.
+			if isHTML(contentType, data) {
+				data = nil
+			}
+			if err := emitSourceMap(item.url.String(), data, item.bundle, emit); err != nil {
+				return err
+			}
+		case kindConfig:
+			// Single page applications answer unknown paths with their index page, which is scanned already.
+			if isHTML(contentType, data) {
+				continue
+			}
+			if err := emit(resource{url: item.url.String(), data: data}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fetch requests u if it is in scope, was not requested before and robots.txt allows it. Redirects are followed
+// under the same conditions, up to maxRedirects. It returns the final response if it is successful.
+func (c *crawler) fetch(ctx context.Context, u *url.URL, redirects int) (response, bool) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return response{}, false
+	}
+	if _, ok := c.hosts[u.Host]; !ok {
+		return response{}, false
+	}
+	key := u.String()
+	if _, ok := c.seen[key]; ok {
+		return response{}, false
+	}
+	c.seen[key] = struct{}{}
+	if !c.ignoreRobots && !c.robotsFor(ctx, u).allowed(u.EscapedPath()) {
+		return response{}, false
+	}
+	if c.requests >= c.maxRequests {
+		return response{}, false
+	}
+
+	c.requests++
+	res, err := c.get(ctx, c.pageClient, key)
+	if err != nil {
+		return response{}, false
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	if isRedirect(res.StatusCode) {
+		location, err := res.Location()
+		if err != nil || redirects >= maxRedirects {
+			return response{}, false
+		}
+		_ = res.Body.Close()
+		return c.fetch(ctx, location, redirects+1)
+	}
+	if res.StatusCode != http.StatusOK {
+		return response{}, false
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, c.maxBodySize))
+	if err != nil || len(data) == 0 {
+		return response{}, false
+	}
+
+	sourceMap := res.Header.Get("SourceMap")
+	if sourceMap == "" {
+		sourceMap = res.Header.Get("X-SourceMap")
+	}
+	return response{url: u, data: data, contentType: res.Header.Get("Content-Type"), sourceMap: sourceMap}, true
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// robotsFor returns the robots.txt rules of the host of u, fetching them on first use. Hosts without a robots.txt,
+// or whose robots.txt can't be fetched, allow everything.
+func (c *crawler) robotsFor(ctx context.Context, u *url.URL) *robotsRules {
+	origin := u.Scheme + "://" + u.Host
+	if rules, ok := c.robots[origin]; ok {
+		return rules
+	}
+
+	var rules *robotsRules
+	res, err := c.get(ctx, c.robotsClient, origin+"/robots.txt")
+	if err == nil {
+		if res.StatusCode == http.StatusOK {
+			rules = parseRobots(io.LimitReader(res.Body, 512*1024))
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+	c.robots[origin] = rules
+	return rules
+}
+
+func (c *crawler) get(ctx context.Context, client *http.Client, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", common.UserAgent())
+	return client.Do(req)
+}
+
+// extractLinks returns the scripts and the pages an HTML document links to.
+func extractLinks(base *url.URL, data []byte) (scripts, pages []*url.URL) {
+	resolve := func(ref string) *url.URL {
+		ref = strings.TrimSpace(ref)
+		if ref == "" || strings.HasPrefix(ref, "#") {
+			return nil
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			return nil
+		}
+		u = base.ResolveReference(u)
+		u.Fragment = ""
+		return u
+	}
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return scripts, pages
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			attrs := make(map[string]string, len(token.Attr))
+			for _, attr := range token.Attr {
+				attrs[strings.ToLower(attr.Key)] = attr.Val
+			}
+			switch token.Data {
+			case "script":
+				if u := resolve(attrs["src"]); u != nil {
+					scripts = append(scripts, u)
+				}
+			case "link":
+				rel := strings.ToLower(attrs["rel"])
+				if rel == "modulepreload" || (rel == "preload" && attrs["as"] == "script") {
+					if u := resolve(attrs["href"]); u != nil {
+						scripts = append(scripts, u)
+					}
+				}
+			case "a", "iframe":
+				ref := attrs["href"]
+				if token.Data == "iframe" {
+					ref = attrs["src"]
+				}
+				if u := resolve(ref); u != nil {
+					pages = append(pages, u)
+				}
+			}
+		}
+	}
+}
+
+// emitSourceMap emits the original sources embedded in a source map. The bundle the source map belongs to is emitted
+// as well, unless the source map embeds all of its sources.
+func emitSourceMap(mapURL string, data []byte, bundle *resource, emit func(resource) error) error {
+	sm, err := sourcemap.Parse(data)
+	if err != nil {
+		sm = &sourcemap.Map{}
+	}
+	for _, file := range sm.Files {
+		if err := emit(resource{url: mapURL, file: file.Path, data: []byte(file.Content)}); err != nil {
+			return err
+		}
+	}
+	if bundle != nil && !sm.Complete {
+		return emit(*bundle)
+	}
+	return nil
+}
+
+// decodeDataURL returns the content of a data URL, as used for inline source maps.
+func decodeDataURL(dataURL string) []byte {
+	header, content, ok := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !ok {
+		return nil
+	}
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return nil
+		}
+		return decoded
+	}
+	decoded, err := url.PathUnescape(content)
+	if err != nil {
+		return nil
+	}
+	return []byte(decoded)
+}
+
+func isHTML(contentType string, data []byte) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+	}
+	return strings.HasPrefix(http.DetectContentType(data), "text/html")
+}
@@ -0,0 +1,161 @@
+package web
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrawler_Crawl(t *testing.T) {
+	inlineMap := base64.StdEncoding.EncodeToString([]byte(`{"version":3,"sources":["src/inline.ts"],"sourcesContent":["const inline = 1;"]}`))
+	site := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"/robots.txt": {"text/plain", "User-agent: *\nDisallow: /private\n"},
+		"/": {"text/html", `<html><head>
+<script src="/static/js/main.js"></script>
+<link rel="modulepreload" href="/static/js/vendor.js">
+<script src="/static/js/plain.js"></script>
+<script src="https://cdn.example.com/lib.js"></script>
+</head><body>
+<a href="/about">About</a><a href="/private/admin">Admin</a><a href="#top">Top</a>
+</body></html>`},
+		"/about":                   {"text/html", `<a href="/about/team">Team</a><script src="/static/js/about.js"></script>`},
+		"/about/team":              {"text/html", `too deep`},
+		"/static/js/main.js":       {"application/javascript", "console.log(1);\n//# sourceMappingURL=main.js.map\n"},
+		"/static/js/main.js.map":   {"application/json", `{"version":3,"sources":["webpack://app/src/config.ts",null],"sourcesContent":["export const key = 'secret';",null]}`},
+		"/static/js/vendor.js":     {"application/javascript", "var v;"},
+		"/static/js/plain.js":      {"application/javascript", "var p;"},
+		"/static/js/vendor.js.map": {"application/json", `{"version":3,"sources":["vendor.ts"],"sourcesContent":["var vendor;"]}`},
+		"/static/js/about.js":      {"application/javascript", "var a;\n//# sourceMappingURL=data:application/json;base64," + inlineMap + "\n"},
+		"/config.json":             {"application/json", `{"apiKey":"abc"}`},
+		"/private/admin":           {"text/html", `private`},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := site[r.URL.Path]
+		if !ok {
+			// Like a single page application, answer unknown paths with the index page.
+			page = site["/"]
+		}
+		w.Header().Set("Content-Type", page.contentType)
+		_, _ = w.Write([]byte(page.body))
+	}))
+	defer server.Close()
+
+	seed, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	c := &crawler{
+		client:      server.Client(),
+		maxDepth:    1,
+		maxRequests: 100,
+		maxBodySize: 1024 * 1024,
+		hosts:       map[string]struct{}{seed.Host: {}},
+	}
+
+	var got []string
+	require.NoError(t, c.crawl(context.Background(), seed, func(res resource) error {
+		name := res.url[len(server.URL):]
+		if res.file != "" {
+			name += " (" + res.file + ")"
+		}
+		got = append(got, name+": "+string(res.data))
+		return nil
+	}))
+	sort.Strings(got)
+
+	want := []string{
+		"/: " + site["/"].body,
+		"/about: " + site["/about"].body,
+		"/config.json: " + site["/config.json"].body,
+		// Bundles are replaced by their sources, unless some of them are missing from the source map.
+		"/static/js/about.js (src/inline.ts): const inline = 1;",
+		"/static/js/main.js.map (webpack://app/src/config.ts): export const key = 'secret';",
+		"/static/js/main.js: " + site["/static/js/main.js"].body,
+		"/static/js/plain.js: " + site["/static/js/plain.js"].body,
+		"/static/js/vendor.js.map (vendor.ts): var vendor;",
+	}
+	sort.Strings(want)
+	assert.Equal(t, want, got)
+}
+
+func TestCrawler_MaxRequests(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<a href="/next` + r.URL.Path + `">next</a>`))
+	}))
+	defer server.Close()
+
+	seed, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c := &crawler{
+		client:       server.Client(),
+		maxDepth:     100,
+		maxRequests:  5,
+		maxBodySize:  1024,
+		hosts:        map[string]struct{}{seed.Host: {}},
+		ignoreRobots: true,
+	}
+	require.NoError(t, c.crawl(context.Background(), seed, func(resource) error { return nil }))
+	assert.Equal(t, 5, requests)
+}
+
+func TestCrawler_Redirects(t *testing.T) {
+	var outOfScopeRequests int
+	outOfScope := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outOfScopeRequests++
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("internal secret"))
+	}))
+	defer outOfScope.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<a href="/out">Out</a><a href="/in">In</a>`))
+		case "/out":
+			http.Redirect(w, r, outOfScope.URL+"/", http.StatusFound)
+		case "/in":
+			http.Redirect(w, r, "/target", http.StatusMovedPermanently)
+		case "/target":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("in scope"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	seed, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	c := &crawler{
+		client:       server.Client(),
+		maxDepth:     1,
+		maxRequests:  100,
+		maxBodySize:  1024,
+		hosts:        map[string]struct{}{seed.Host: {}},
+		ignoreRobots: true,
+	}
+
+	var got []string
+	require.NoError(t, c.crawl(context.Background(), seed, func(res resource) error {
+		got = append(got, res.url+": "+string(res.data))
+		return nil
+	}))
+
+	assert.Equal(t, 0, outOfScopeRequests)
+	assert.Equal(t, []string{
+		server.URL + "/: " + `<a href="/out">Out</a><a href="/in">In</a>`,
+		server.URL + "/target: in scope",
+	}, got)
+}
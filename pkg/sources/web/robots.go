@@ -0,0 +1,133 @@
+package web
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// robotsAgent is the product token matched against the User-agent lines of robots.txt.
+const robotsAgent = "trufflehog"
+
+type robotsRule struct {
+	allow bool
+	path  string
+}
+
+// robotsRules are the rules of the robots.txt group that applies to robotsAgent.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// parseRobots parses a robots.txt file. The group naming robotsAgent is used if there is one, otherwise the group for
+// all agents. A nil result allows everything.
+func parseRobots(r io.Reader) *robotsRules {
+	var (
+		agentRules, wildcardRules []robotsRule
+		foundAgent, foundWildcard bool
+
+		// groupAgents are the agents of the current group. inRules is set once the group has rules, so that the next
+		// User-agent line starts a new group.
+		groupAgents []string
+		inRules     bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				groupAgents = nil
+				inRules = false
+			}
+			agent := strings.ToLower(value)
+			groupAgents = append(groupAgents, agent)
+			if agent == "*" {
+				foundWildcard = true
+			} else if strings.Contains(robotsAgent, agent) {
+				foundAgent = true
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				// An empty Disallow allows everything and an empty Allow has no effect.
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", path: value}
+			for _, agent := range groupAgents {
+				switch {
+				case agent == "*":
+					wildcardRules = append(wildcardRules, rule)
+				case strings.Contains(robotsAgent, agent):
+					agentRules = append(agentRules, rule)
+				}
+			}
+		}
+	}
+
+	switch {
+	case foundAgent:
+		return &robotsRules{rules: agentRules}
+	case foundWildcard:
+		return &robotsRules{rules: wildcardRules}
+	default:
+		return nil
+	}
+}
+
+// allowed reports whether path may be fetched. The longest matching rule wins, and Allow wins over Disallow if both
+// match with the same length.
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	allow, longest := true, -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.path, path) {
+			continue
+		}
+		if len(rule.path) > longest || (len(rule.path) == longest && rule.allow) {
+			allow, longest = rule.allow, len(rule.path)
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches path against a robots.txt path pattern, where "*" matches any sequence of characters and a
+// trailing "$" anchors the pattern at the end of the path.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	if !anchored {
+		return true
+	}
+	if len(parts) == 1 {
+		return rest == ""
+	}
+	// With wildcards the last part has to be at the very end of the path.
+	return strings.HasSuffix(path, parts[len(parts)-1])
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRobots(t *testing.T) {
+	tests := []struct {
+		name    string
+		robots  string
+		allowed map[string]bool
+	}{
+		{
+			name:   "wildcard group",
+			robots: "User-agent: *\nDisallow: /admin\nAllow: /admin/public\n",
+			allowed: map[string]bool{
+				"/":                  true,
+				"/admin":             false,
+				"/admin/users":       false,
+				"/admin/public/a.js": true,
+			},
+		},
+		{
+			name:   "agent group wins over wildcard",
+			robots: "User-agent: *\nDisallow: /\n\nUser-agent: TruffleHog\nDisallow: /private\n",
+			allowed: map[string]bool{
+				"/":          true,
+				"/private/x": false,
+			},
+		},
+		{
+			name:   "other agents only",
+			robots: "User-agent: Googlebot\nDisallow: /\n",
+			allowed: map[string]bool{
+				"/": true,
+			},
+		},
+		{
+			name:   "shared group and patterns",
+			robots: "# comment\nUser-agent: bingbot\nUser-agent: *\nDisallow: /*.map$\nDisallow: /tmp/*/cache\nDisallow:\n",
+			allowed: map[string]bool{
+				"/static/app.js":     true,
+				"/static/app.js.map": false,
+				"/app.js.map?v=1":    true,
+				"/tmp/a/b/cache/x":   false,
+				"/tmp/cache":         true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(tt.robots))
+			for path, want := range tt.allowed {
+				assert.Equal(t, want, rules.allowed(path), path)
+			}
+		})
+	}
+}
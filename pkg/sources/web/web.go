@@ -0,0 +1,165 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const SourceType = sourcespb.SourceType_SOURCE_TYPE_WEB
+
+const (
+	// DefaultMaxDepth is how many links deep pages are followed from a target URL.
+	DefaultMaxDepth = 1
+	// DefaultMaxRequests limits the requests made for a single target URL.
+	DefaultMaxRequests = 200
+
+	maxBodySize = 50 * 1024 * 1024
+)
+
+// Source crawls web applications. For every target URL it fetches the page, the scripts it loads and their source
+// maps, well-known runtime configuration endpoints and the pages it links to, and scans all of them. Frontend
+// bundles of web and dapp applications regularly embed API keys, storage credentials and even wallet keys.
+type Source struct {
+	// URLs are the target URLs to crawl.
+	URLs []string
+	// MaxDepth is how many links deep pages are followed. Default: DefaultMaxDepth.
+	MaxDepth int
+	// MaxRequests limits the requests made for each target URL. Default: DefaultMaxRequests.
+	MaxRequests int
+	// AllowedHosts are hosts besides those of the target URLs that may be fetched, like a CDN serving the scripts.
+	AllowedHosts []string
+	// IgnoreRobots fetches pages even if robots.txt disallows it.
+	IgnoreRobots bool
+
+	name     string
+	sourceId sources.SourceID
+	jobId    sources.JobID
+	verify   bool
+	client   *http.Client
+	sources.Progress
+	sources.CommonSourceUnitUnmarshaller
+}
+
+// Ensure the Source satisfies the interfaces at compile time.
+var _ sources.Source = (*Source)(nil)
+var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+var _ sources.SourceUnitEnumChunker = (*Source)(nil)
+
+func (s *Source) Type() sourcespb.SourceType { return SourceType }
+func (s *Source) SourceID() sources.SourceID { return s.sourceId }
+func (s *Source) JobID() sources.JobID       { return s.jobId }
+
+// Init initializes the source. It is configured through its fields, so the connection is unused.
+func (s *Source) Init(_ context.Context, name string, jobId sources.JobID, sourceId sources.SourceID, verify bool, _ *anypb.Any, _ int) error {
+	s.name = name
+	s.jobId = jobId
+	s.sourceId = sourceId
+	s.verify = verify
+	if s.client == nil {
+		s.client = common.SaneHttpClient()
+	}
+	if s.MaxDepth <= 0 {
+		s.MaxDepth = DefaultMaxDepth
+	}
+	if s.MaxRequests <= 0 {
+		s.MaxRequests = DefaultMaxRequests
+	}
+
+	for _, rawURL := range s.URLs {
+		if _, err := parseTarget(rawURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	reporter := sources.ChanReporter{Ch: chunksChan}
+	for i, rawURL := range s.URLs {
+		s.SetProgressComplete(i, len(s.URLs), fmt.Sprintf("URL: %s", rawURL), "")
+		if err := s.ChunkUnit(ctx, sources.CommonSourceUnit{ID: rawURL}, reporter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Source) Enumerate(ctx context.Context, reporter sources.UnitReporter) error {
+	for _, rawURL := range s.URLs {
+		if err := reporter.UnitOk(ctx, sources.CommonSourceUnit{ID: rawURL}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChunkUnit crawls a single target URL.
+func (s *Source) ChunkUnit(ctx context.Context, unit sources.SourceUnit, reporter sources.ChunkReporter) error {
+	rawURL, _ := unit.SourceUnitID()
+	target, err := parseTarget(rawURL)
+	if err != nil {
+		return reporter.ChunkErr(ctx, err)
+	}
+
+	hosts := map[string]struct{}{target.Host: {}}
+	for _, host := range s.AllowedHosts {
+		hosts[host] = struct{}{}
+	}
+	c := &crawler{
+		client:       s.client,
+		maxDepth:     s.MaxDepth,
+		maxRequests:  s.MaxRequests,
+		maxBodySize:  maxBodySize,
+		hosts:        hosts,
+		ignoreRobots: s.IgnoreRobots,
+	}
+
+	ctx.Logger().V(2).Info("crawling", "url", rawURL)
+	return c.crawl(ctx, target, func(res resource) error {
+		file := res.url
+		if res.file != "" {
+			file = res.url + " (" + res.file + ")"
+		}
+		chunkSkel := &sources.Chunk{
+			SourceType:   s.Type(),
+			SourceName:   s.name,
+			SourceID:     s.SourceID(),
+			JobID:        s.JobID(),
+			SourceVerify: s.verify,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Filesystem{
+					Filesystem: &source_metadatapb.Filesystem{
+						File: file,
+						Link: res.url,
+					},
+				},
+			},
+		}
+		if err := handlers.HandleFile(ctx, bytes.NewReader(res.data), chunkSkel, reporter); err != nil {
+			ctx.Logger().Error(err, "error scanning resource", "url", res.url)
+		}
+		return ctx.Err()
+	})
+}
+
+func parseTarget(rawURL string) (*url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: expected an absolute http or https URL", rawURL)
+	}
+	return target, nil
+}
@@ -0,0 +1,171 @@
+package sqldump
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minSecretLength is the length below which values of credential columns are taken for placeholders.
+const minSecretLength = 6
+
+// secretColumns are the names of columns that hold a credential, after normalization to snake case. Columns whose
+// name ends in one of them, like stripe_secret_key or smtp_password, hold one as well.
+var secretColumns = map[string]struct{}{
+	"api_key":           {},
+	"apikey":            {},
+	"api_secret":        {},
+	"api_token":         {},
+	"secret":            {},
+	"secret_key":        {},
+	"client_secret":     {},
+	"app_secret":        {},
+	"consumer_secret":   {},
+	"webhook_secret":    {},
+	"signing_secret":    {},
+	"access_key":        {},
+	"access_key_secret": {},
+	"secret_access_key": {},
+	"access_token":      {},
+	"refresh_token":     {},
+	"auth_token":        {},
+	"bearer_token":      {},
+	"token":             {},
+	"private_key":       {},
+	"encryption_key":    {},
+	"password":          {},
+	"passwd":            {},
+	"mnemonic":          {},
+	"seed_phrase":       {},
+	"totp_secret":       {},
+	"otp_secret":        {},
+}
+
+// ignoredColumns match secretColumns but hold single-use tokens of web frameworks, which are worthless once used
+// and numerous in user tables.
+var ignoredColumns = map[string]struct{}{
+	"csrf_token":           {},
+	"remember_token":       {},
+	"confirmation_token":   {},
+	"reset_password_token": {},
+	"password_reset_token": {},
+	"unlock_token":         {},
+	"invitation_token":     {},
+}
+
+// contextColumns identify the row a credential belongs to.
+var contextColumns = map[string]struct{}{
+	"id":         {},
+	"uuid":       {},
+	"user_id":    {},
+	"account_id": {},
+	"username":   {},
+	"user":       {},
+	"login":      {},
+	"email":      {},
+	"name":       {},
+	"service":    {},
+	"provider":   {},
+	"label":      {},
+}
+
+// hashPrefixes start password hashes in the formats of crypt(3), Django and LDAP, which cannot be used to log in.
+var hashPrefixes = []string{
+	"$1$", "$2a$", "$2b$", "$2y$", "$5$", "$6$", "$argon2", "$pbkdf2", "$scrypt$",
+	"pbkdf2_sha256$", "pbkdf2_sha1$", "argon2$", "bcrypt$", "bcrypt_sha256$", "scrypt$", "sha1$", "md5$",
+	"{SSHA}", "{SHA}", "{SSHA512}", "{CRYPT}",
+}
+
+// IsSecret reports whether a column is known to hold a credential.
+func IsSecret(column string) bool {
+	name := normalize(column)
+	if strings.HasPrefix(name, "encrypted_") || strings.HasPrefix(name, "hashed_") {
+		return false
+	}
+	if _, ok := ignoredColumns[name]; ok {
+		return false
+	}
+	if _, ok := secretColumns[name]; ok {
+		return true
+	}
+	for secret := range secretColumns {
+		if strings.HasSuffix(name, "_"+secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSecretColumn(columns []string) bool {
+	for _, column := range columns {
+		if IsSecret(column) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize converts a column name to lower snake case, e.g. `apiKey` and `API-Key` to `api_key`.
+func normalize(column string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range column {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteByte('_')
+			prevLower = false
+			continue
+		case unicode.IsUpper(r):
+			if prevLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+			prevLower = false
+		default:
+			prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Secrets returns the values of the row's credential columns, leaving out placeholders and password hashes.
+func (row Row) Secrets() []Column {
+	var secrets []Column
+	for _, col := range row.Columns {
+		if !IsSecret(col.Name) || len(strings.TrimSpace(col.Value)) < minSecretLength || isHash(col.Value) {
+			continue
+		}
+		secrets = append(secrets, col)
+	}
+	return secrets
+}
+
+// Context describes the row by the values of up to three of its identifying columns, e.g. `id=7, email=ops@example.com`.
+func (row Row) Context() string {
+	const maxValueLength = 64
+
+	var parts []string
+	for _, col := range row.Columns {
+		if _, ok := contextColumns[normalize(col.Name)]; !ok || col.Value == "" {
+			continue
+		}
+		value := col.Value
+		if len(value) > maxValueLength {
+			value = value[:maxValueLength] + "..."
+		}
+		parts = append(parts, col.Name+"="+value)
+		if len(parts) == 3 {
+			break
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func isHash(value string) bool {
+	for _, prefix := range hashPrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,431 @@
+package sqldump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// value is a literal of an INSERT statement or a field of COPY data.
+type value struct {
+	text string
+	null bool
+}
+
+// constraintWords start the entries of a CREATE TABLE statement that declare no column.
+var constraintWords = map[string]struct{}{
+	"PRIMARY": {}, "KEY": {}, "UNIQUE": {}, "CONSTRAINT": {}, "INDEX": {}, "FOREIGN": {},
+	"CHECK": {}, "FULLTEXT": {}, "SPATIAL": {}, "EXCLUDE": {}, "LIKE": {}, "PERIOD": {},
+}
+
+// parse records the columns declared by CREATE TABLE statements and returns the rows an INSERT statement inserts
+// into a credential table. A COPY statement into any table switches the reader to its data.
+func (r *Reader) parse(st statement) []Row {
+	c := &cursor{s: st.code, backslashEscapes: r.backslashEscapes}
+	c.space()
+	switch word := strings.ToUpper(c.word()); word {
+	case "CREATE":
+		r.parseCreate(c)
+	case "INSERT", "REPLACE":
+		return r.parseInsert(c, st.line)
+	case "COPY":
+		r.parseCopy(c)
+	case "PRAGMA":
+		// sqlite3 .dump starts with PRAGMA foreign_keys=OFF.
+		r.backslashEscapes = false
+	}
+	return nil
+}
+
+// parseCreate records the columns of a `CREATE [TEMPORARY] TABLE [IF NOT EXISTS] name (...)` statement.
+func (r *Reader) parseCreate(c *cursor) {
+	for i := 0; ; i++ {
+		word := strings.ToUpper(c.word())
+		if word == "TABLE" {
+			break
+		}
+		if word == "" || i == 3 {
+			return
+		}
+	}
+	if c.keyword("IF") && !(c.keyword("NOT") && c.keyword("EXISTS")) {
+		return
+	}
+	table, ok := c.ident()
+	if !ok || !c.consume('(') {
+		return
+	}
+
+	var columns []string
+	for {
+		c.space()
+		if c.done() || c.consume(')') {
+			break
+		}
+		start := c.i
+		name, ok := c.ident()
+		if !ok {
+			return
+		}
+		if _, constraint := constraintWords[strings.ToUpper(name)]; !constraint || c.s[start] == '`' || c.s[start] == '"' {
+			columns = append(columns, name)
+		}
+		c.skipItem()
+		if !c.consume(',') {
+			break
+		}
+	}
+	r.columns[strings.ToLower(table)] = columns
+}
+
+// parseInsert returns the rows of an `INSERT [IGNORE] INTO name [(columns)] VALUES (...), ...` statement if the
+// table has credential columns. Without a column list, the columns declared by CREATE TABLE are used.
+func (r *Reader) parseInsert(c *cursor, line int64) []Row {
+	for i := 0; ; i++ {
+		word := strings.ToUpper(c.word())
+		if word == "INTO" {
+			break
+		}
+		// LOW_PRIORITY, DELAYED, HIGH_PRIORITY, IGNORE or, in SQLite, OR REPLACE and OR IGNORE.
+		if word == "" || i == 3 {
+			return nil
+		}
+	}
+	table, ok := c.ident()
+	if !ok {
+		return nil
+	}
+	columns := r.columns[strings.ToLower(table)]
+	if c.consume('(') {
+		if columns, ok = c.identList(); !ok {
+			return nil
+		}
+	}
+	if !hasSecretColumn(columns) || !(c.keyword("VALUES") || c.keyword("VALUE")) {
+		return nil
+	}
+
+	var rows []Row
+	for c.consume('(') {
+		rowLine := line + int64(bytes.Count(c.s[:c.i], []byte("\n")))
+		var values []value
+		for {
+			v, ok := c.value()
+			if !ok {
+				return rows
+			}
+			values = append(values, v)
+			if c.consume(')') {
+				break
+			}
+			if !c.consume(',') {
+				return rows
+			}
+		}
+		rows = append(rows, r.newRow(table, columns, rowLine, values))
+		if !c.consume(',') {
+			break
+		}
+	}
+	return rows
+}
+
+// parseCopy switches the reader to the data of a `COPY name (columns) FROM stdin` statement.
+func (r *Reader) parseCopy(c *cursor) {
+	table, ok := c.ident()
+	if !ok {
+		return
+	}
+	columns := r.columns[strings.ToLower(table)]
+	if c.consume('(') {
+		if columns, ok = c.identList(); !ok {
+			return
+		}
+	}
+	if !c.keyword("FROM") || !c.keyword("STDIN") {
+		return
+	}
+	r.copying = &copyTarget{table: table, columns: columns, credentials: hasSecretColumn(columns)}
+}
+
+// parseCopyLine splits a line of COPY data in text format into its tab-separated fields.
+func parseCopyLine(line []byte) []value {
+	var values []value
+	for _, field := range bytes.Split(line, []byte("\t")) {
+		if bytes.Equal(field, []byte(`\N`)) {
+			values = append(values, value{null: true})
+			continue
+		}
+		var b strings.Builder
+		for i := 0; i < len(field); i++ {
+			if field[i] != '\\' || i+1 == len(field) {
+				b.WriteByte(field[i])
+				continue
+			}
+			i++
+			switch field[i] {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'v':
+				b.WriteByte('\v')
+			default:
+				b.WriteByte(field[i])
+			}
+		}
+		values = append(values, value{text: b.String()})
+	}
+	return values
+}
+
+// cursor reads the tokens of a statement.
+type cursor struct {
+	s                []byte
+	i                int
+	backslashEscapes bool
+}
+
+func (c *cursor) done() bool { return c.i >= len(c.s) }
+
+func (c *cursor) space() {
+	for !c.done() && isSpace(c.s[c.i]) {
+		c.i++
+	}
+}
+
+// consume skips whitespace and the given character, if it comes next.
+func (c *cursor) consume(ch byte) bool {
+	c.space()
+	if !c.done() && c.s[c.i] == ch {
+		c.i++
+		return true
+	}
+	return false
+}
+
+// word reads an unquoted word.
+func (c *cursor) word() string {
+	c.space()
+	start := c.i
+	for !c.done() && isWordChar(c.s[c.i]) {
+		c.i++
+	}
+	return string(c.s[start:c.i])
+}
+
+// keyword reads the given word, case-insensitively, if it comes next.
+func (c *cursor) keyword(kw string) bool {
+	start := c.i
+	if strings.EqualFold(c.word(), kw) {
+		return true
+	}
+	c.i = start
+	return false
+}
+
+// ident reads a possibly qualified and quoted name, e.g. `public.users` or "main"."users", and returns its last
+// part.
+func (c *cursor) ident() (string, bool) {
+	var name string
+	for {
+		c.space()
+		if c.done() {
+			return "", false
+		}
+		switch q := c.s[c.i]; q {
+		case '`', '"':
+			s, ok := c.quoted(q, false)
+			if !ok {
+				return "", false
+			}
+			name = s
+		case '[':
+			end := bytes.IndexByte(c.s[c.i:], ']')
+			if end < 0 {
+				return "", false
+			}
+			name = string(c.s[c.i+1 : c.i+end])
+			c.i += end + 1
+		default:
+			if name = c.word(); name == "" {
+				return "", false
+			}
+		}
+		if c.done() || c.s[c.i] != '.' {
+			return name, true
+		}
+		c.i++
+	}
+}
+
+// identList reads a comma-separated list of names up to the closing parenthesis.
+func (c *cursor) identList() ([]string, bool) {
+	var names []string
+	for {
+		name, ok := c.ident()
+		if !ok {
+			return nil, false
+		}
+		names = append(names, name)
+		if c.consume(')') {
+			return names, true
+		}
+		if !c.consume(',') {
+			return nil, false
+		}
+	}
+}
+
+// quoted reads a literal or identifier enclosed in q, which starts at the cursor. A doubled quote stands for
+// itself; backslash escapes are decoded if enabled.
+func (c *cursor) quoted(q byte, escapes bool) (string, bool) {
+	var b strings.Builder
+	for c.i++; c.i < len(c.s); c.i++ {
+		ch := c.s[c.i]
+		switch {
+		case ch == '\\' && escapes && c.i+1 < len(c.s):
+			c.i++
+			b.WriteByte(unescape(c.s[c.i]))
+		case ch == q && c.i+1 < len(c.s) && c.s[c.i+1] == q:
+			c.i++
+			b.WriteByte(q)
+		case ch == q:
+			c.i++
+			return b.String(), true
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", false
+}
+
+// unescape returns the character a backslash escape in a MySQL string literal stands for.
+func unescape(ch byte) byte {
+	switch ch {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	default:
+		return ch
+	}
+}
+
+// value reads a literal of a VALUES list.
+func (c *cursor) value() (value, bool) {
+	c.space()
+	if c.done() {
+		return value{}, false
+	}
+
+	ch := c.s[c.i]
+	next := byte(0)
+	if c.i+1 < len(c.s) {
+		next = c.s[c.i+1]
+	}
+	switch {
+	case ch == '\'' || ch == '"':
+		s, ok := c.quoted(ch, ch == '\'' && c.backslashEscapes)
+		return value{text: s}, ok
+	case (ch == 'E' || ch == 'e') && next == '\'':
+		// PostgreSQL escape string.
+		c.i++
+		s, ok := c.quoted('\'', true)
+		return value{text: s}, ok
+	case (ch == 'X' || ch == 'x') && next == '\'':
+		c.i++
+		s, ok := c.quoted('\'', false)
+		return value{text: decodeHex(s)}, ok
+	case ch == '_':
+		// Character set introducer, e.g. _binary 'secret' or _utf8mb4'secret'.
+		start := c.i
+		c.word()
+		c.space()
+		if !c.done() && c.s[c.i] == '\'' {
+			s, ok := c.quoted('\'', c.backslashEscapes)
+			return value{text: s}, ok
+		}
+		c.i = start
+	}
+
+	// Numbers, NULL, booleans, hex numbers and function calls like now() extend to the next comma or closing
+	// parenthesis at the same depth.
+	start := c.i
+	c.skipItem()
+	text := strings.TrimSpace(string(c.s[start:c.i]))
+	switch {
+	case text == "":
+		return value{}, false
+	case strings.EqualFold(text, "NULL"):
+		return value{null: true}, true
+	case strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X"):
+		return value{text: decodeHex(text[2:])}, true
+	}
+	return value{text: text}, true
+}
+
+// skipItem skips to the next comma or closing parenthesis that is not nested in parentheses or quotes.
+func (c *cursor) skipItem() {
+	depth := 0
+	for !c.done() {
+		switch ch := c.s[c.i]; ch {
+		case '\'', '"', '`':
+			if _, ok := c.quoted(ch, ch == '\'' && c.backslashEscapes); !ok {
+				c.i = len(c.s)
+			}
+			continue
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return
+			}
+		}
+		c.i++
+	}
+}
+
+// decodeHex decodes a hex literal that holds text, such as a token stored in a BLOB column. Binary data is kept
+// in hex.
+func decodeHex(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil || !utf8.Valid(b) {
+		return s
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return s
+		}
+	}
+	return string(b)
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func isWordChar(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
@@ -0,0 +1,78 @@
+package sqldump
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderPrefix starts the first line of a rendered row, followed by the table name and the row number.
+const HeaderPrefix = "# sql dump: "
+
+const (
+	rowMarker  = " row "
+	heredocTag = "EOT"
+)
+
+// Render writes a row as a header line followed by one `table.column = "value"` line per column. Values that span
+// several lines, such as PEM keys, are written as heredocs so they keep their line breaks.
+func (row Row) Render() string {
+	var b strings.Builder
+	b.WriteString(HeaderPrefix + row.Table + rowMarker + strconv.Itoa(row.Number) + "\n")
+	for _, col := range row.Columns {
+		b.WriteString(row.Table + "." + col.Name + " = ")
+		if !strings.Contains(col.Value, "\n") {
+			b.WriteString(strconv.Quote(col.Value) + "\n")
+			continue
+		}
+		b.WriteString("<<" + heredocTag + "\n")
+		b.WriteString(strings.TrimSuffix(col.Value, "\n") + "\n")
+		b.WriteString(heredocTag + "\n")
+	}
+	return b.String()
+}
+
+// ParseRendered reads back the rows written by Render. Lines that are not part of a rendered row are ignored.
+func ParseRendered(text string) []Row {
+	var rows []Row
+	var row *Row
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSuffix(lines[i], "\r")
+		if header, ok := strings.CutPrefix(line, HeaderPrefix); ok {
+			row = nil
+			j := strings.LastIndex(header, rowMarker)
+			if j < 0 {
+				continue
+			}
+			number, err := strconv.Atoi(header[j+len(rowMarker):])
+			if err != nil {
+				continue
+			}
+			rows = append(rows, Row{Table: header[:j], Number: number})
+			row = &rows[len(rows)-1]
+			continue
+		}
+		if row == nil || !strings.HasPrefix(line, row.Table+".") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, row.Table+"."), " = ")
+		if !ok {
+			continue
+		}
+
+		col := Column{Name: name}
+		if value == "<<"+heredocTag {
+			var body []string
+			for i++; i < len(lines) && strings.TrimSuffix(lines[i], "\r") != heredocTag; i++ {
+				body = append(body, lines[i])
+			}
+			col.Value = strings.Join(body, "\n")
+		} else if unquoted, err := strconv.Unquote(value); err == nil {
+			col.Value = unquoted
+		} else {
+			continue
+		}
+		row.Columns = append(row.Columns, col)
+	}
+	return rows
+}
@@ -0,0 +1,293 @@
+// Package sqldump extracts the rows of credential tables from SQL dumps.
+//
+// Database backups written by mysqldump, pg_dump or the sqlite3 .dump command regularly end up in object storage
+// buckets and repositories, and with them every API key, token and password an application stored. In a dump, the
+// values of a row are separated from the names of their columns, which are often declared in a CREATE TABLE
+// statement far earlier in the file: a detector sees a value without knowing it is an API key, and a finding says
+// nothing about the table and row it came from. This package reads a dump statement by statement and returns the
+// rows inserted into tables with credential columns, so they can be scanned column by column.
+package sqldump
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+const (
+	// maxStatementSize caps the statements that are parsed. mysqldump keeps its multi-row INSERT statements below
+	// its net_buffer_length, 1MB by default; longer statements are returned as text only.
+	maxStatementSize = 16 * 1024 * 1024
+	// maxSegmentRows and maxSegmentText cap the COPY data returned in one segment.
+	maxSegmentRows = 1000
+	maxSegmentText = 1024 * 1024
+)
+
+// Column is a non-NULL value of a row.
+type Column struct {
+	Name  string
+	Value string
+}
+
+// Row is a row inserted into a table with at least one credential column.
+type Row struct {
+	Table string
+	// Number is the 1-indexed position of the row among the rows of its table.
+	Number int
+	// Line is the 1-indexed line of the dump the row starts at.
+	Line    int64
+	Columns []Column
+}
+
+// Segment is a consecutive part of a dump: either text that inserts no rows into a credential table, such as other
+// statements, comments or the rows of other tables, or the rows inserted into a credential table by one statement.
+type Segment struct {
+	// Line is the 1-indexed line of the dump the segment starts at.
+	Line int64
+	Text []byte
+	Rows []Row
+}
+
+// Reader reads a SQL dump segment by segment.
+type Reader struct {
+	r *bufio.Reader
+	// line is the line of the next byte to read.
+	line int64
+
+	// backslashEscapes reports whether backslashes escape characters in string literals. MySQL dumps rely on them;
+	// PostgreSQL and SQLite dumps do not, and a backslash is an ordinary character there.
+	backslashEscapes bool
+	// quote is the quote character of the string literal or identifier being read, if any.
+	quote byte
+	// truncated is set while skipping the rest of a statement that exceeded maxStatementSize.
+	truncated bool
+
+	// columns maps lower-cased table names to the columns declared by CREATE TABLE.
+	columns map[string][]string
+	// rowCounts maps table names to the number of rows read so far.
+	rowCounts map[string]int
+	// copying is the COPY statement whose data is being read, if any.
+	copying *copyTarget
+}
+
+// copyTarget is a table whose rows are being read from the data of a PostgreSQL COPY statement.
+type copyTarget struct {
+	table   string
+	columns []string
+	// credentials reports whether the table has credential columns; otherwise the data is returned as text.
+	credentials bool
+}
+
+// NewReader creates a Reader for a dump. String literals are read as MySQL does until the dump identifies itself
+// as written by pg_dump or sqlite3.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r:                bufio.NewReader(r),
+		line:             1,
+		backslashEscapes: true,
+		columns:          make(map[string][]string),
+		rowCounts:        make(map[string]int),
+	}
+}
+
+// Next returns the next segment of the dump, or io.EOF once the whole dump has been read.
+func (r *Reader) Next() (Segment, error) {
+	if r.copying != nil {
+		return r.readCopyData()
+	}
+
+	st, err := r.readStatement()
+	if err != nil {
+		return Segment{}, err
+	}
+	if !st.truncated {
+		if rows := r.parse(st); len(rows) > 0 {
+			return Segment{Line: st.line, Rows: rows}, nil
+		}
+	}
+	if r.copying != nil {
+		// The data starts on the line after the COPY statement.
+		rest, _ := r.r.ReadBytes('\n')
+		if bytes.HasSuffix(rest, []byte("\n")) {
+			r.line++
+		}
+		st.raw = append(st.raw, rest...)
+	}
+	return Segment{Line: st.line, Text: st.raw}, nil
+}
+
+// statement is the original text of a statement, including the whitespace and comments before it, and a copy for
+// parsing in which comments are blanked out. Both have the same length, so offsets and line breaks match.
+type statement struct {
+	line      int64
+	raw, code []byte
+	// truncated is set for the parts of a statement that exceeded maxStatementSize.
+	truncated bool
+}
+
+func (st *statement) add(c byte, blank bool) {
+	st.raw = append(st.raw, c)
+	if blank && c != '\n' {
+		c = ' '
+	}
+	st.code = append(st.code, c)
+}
+
+// readStatement reads up to and including the semicolon that ends the next statement.
+func (r *Reader) readStatement() (statement, error) {
+	st := statement{line: r.line, truncated: r.truncated}
+	for {
+		c, err := r.readByte()
+		if err != nil {
+			if err == io.EOF && len(st.raw) > 0 {
+				return st, nil
+			}
+			return st, err
+		}
+
+		switch {
+		case r.quote != 0:
+			st.add(c, false)
+			if c == '\\' && r.quote == '\'' && r.backslashEscapes {
+				if c, err = r.readByte(); err == nil {
+					st.add(c, false)
+				}
+			} else if c == r.quote {
+				r.quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			r.quote = c
+			st.add(c, false)
+		case (c == '-' && r.peek() == '-') || (c == '/' && r.peek() == '*'):
+			st.add(c, true)
+			next, _ := r.readByte()
+			st.add(next, true)
+			end := []byte("\n")
+			if c == '/' {
+				end = []byte("*/")
+			}
+			r.readComment(&st, end)
+		case c == ';':
+			st.add(c, false)
+			r.truncated = false
+			return st, nil
+		default:
+			st.add(c, false)
+		}
+
+		if len(st.raw) >= maxStatementSize {
+			// Return what was read as text and skip the rest of the statement.
+			r.truncated = true
+			st.truncated = true
+			return st, nil
+		}
+	}
+}
+
+// readComment reads the rest of a comment, up to and including its end.
+func (r *Reader) readComment(st *statement, end []byte) {
+	start := len(st.raw)
+	for !bytes.HasSuffix(st.raw[start:], end) {
+		c, err := r.readByte()
+		if err != nil {
+			break
+		}
+		st.add(c, true)
+	}
+
+	comment := st.raw[start:]
+	switch {
+	case bytes.Contains(comment, []byte("PostgreSQL database dump")):
+		r.backslashEscapes = false
+	case bytes.Contains(comment, []byte("MySQL dump")), bytes.Contains(comment, []byte("MariaDB dump")):
+		r.backslashEscapes = true
+	}
+}
+
+func (r *Reader) readByte() (byte, error) {
+	c, err := r.r.ReadByte()
+	if c == '\n' && err == nil {
+		r.line++
+	}
+	return c, err
+}
+
+func (r *Reader) peek() byte {
+	b, err := r.r.Peek(1)
+	if err != nil {
+		return 0
+	}
+	return b[0]
+}
+
+// readCopyData reads the data lines of a COPY statement, up to the `\.` line that ends them.
+func (r *Reader) readCopyData() (Segment, error) {
+	seg := Segment{Line: r.line}
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			r.copying = nil
+			if err == io.EOF && (len(seg.Text) > 0 || len(seg.Rows) > 0) {
+				return seg, nil
+			}
+			return seg, err
+		}
+		lineNumber := r.line
+		if line[len(line)-1] == '\n' {
+			r.line++
+		}
+
+		trimmed := bytes.TrimRight(line, "\r\n")
+		if bytes.Equal(trimmed, []byte(`\.`)) {
+			if !r.copying.credentials {
+				seg.Text = append(seg.Text, line...)
+			}
+			r.copying = nil
+			return seg, nil
+		}
+
+		if r.copying.credentials {
+			seg.Rows = append(seg.Rows, r.newRow(r.copying.table, r.copying.columns, lineNumber, parseCopyLine(trimmed)))
+			if len(seg.Rows) >= maxSegmentRows {
+				return seg, nil
+			}
+			continue
+		}
+		seg.Text = append(seg.Text, line...)
+		if len(seg.Text) >= maxSegmentText {
+			return seg, nil
+		}
+	}
+}
+
+// newRow names the values of a row after the table's columns and numbers it. NULL values are dropped.
+func (r *Reader) newRow(table string, columns []string, line int64, values []value) Row {
+	r.rowCounts[table]++
+	row := Row{Table: table, Number: r.rowCounts[table], Line: line}
+	for i, v := range values {
+		if i >= len(columns) {
+			break
+		}
+		if !v.null {
+			row.Columns = append(row.Columns, Column{Name: columns[i], Value: v.text})
+		}
+	}
+	return row
+}
+
+// Sniff reports whether the beginning of a file looks like a SQL dump.
+func Sniff(head []byte) bool {
+	upper := bytes.ToUpper(head)
+	for _, marker := range [][]byte{
+		[]byte("MYSQL DUMP"),
+		[]byte("MARIADB DUMP"),
+		[]byte("POSTGRESQL DATABASE DUMP"),
+		[]byte("CREATE TABLE"),
+		[]byte("INSERT INTO"),
+	} {
+		if bytes.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
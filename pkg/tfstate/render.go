@@ -0,0 +1,77 @@
+package tfstate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderPrefix starts the first line of a rendered instance, followed by the instance address.
+const HeaderPrefix = "# terraform state: "
+
+const (
+	sensitiveMarker = "  # sensitive"
+	heredocTag      = "EOT"
+)
+
+// Render writes an instance as a header line followed by one `address.name = "value"` line per attribute. Values
+// that span several lines, such as PEM keys, are written as heredocs so they keep their line breaks.
+func (inst Instance) Render() string {
+	var b strings.Builder
+	b.WriteString(HeaderPrefix + inst.Address + "\n")
+	for _, attr := range inst.Attributes {
+		b.WriteString(attr.Address + "." + attr.Name + " = ")
+		marker := ""
+		if attr.Sensitive {
+			marker = sensitiveMarker
+		}
+		if !strings.Contains(attr.Value, "\n") {
+			b.WriteString(strconv.Quote(attr.Value) + marker + "\n")
+			continue
+		}
+		b.WriteString("<<" + heredocTag + marker + "\n")
+		b.WriteString(strings.TrimSuffix(attr.Value, "\n") + "\n")
+		b.WriteString(heredocTag + "\n")
+	}
+	return b.String()
+}
+
+// ParseRendered reads back the attributes of instances written by Render. Lines that are not part of a rendered
+// instance are ignored.
+func ParseRendered(text string) []Attribute {
+	var attrs []Attribute
+	lines := strings.Split(text, "\n")
+	address := ""
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSuffix(lines[i], "\r")
+		if strings.HasPrefix(line, HeaderPrefix) {
+			address = strings.TrimPrefix(line, HeaderPrefix)
+			continue
+		}
+		if address == "" || !strings.HasPrefix(line, address+".") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, address+"."), " = ")
+		if !ok {
+			continue
+		}
+		attr := Attribute{Address: address, Name: name}
+		if cut, found := strings.CutSuffix(value, sensitiveMarker); found {
+			attr.Sensitive = true
+			value = cut
+		}
+
+		if value == "<<"+heredocTag {
+			var body []string
+			for i++; i < len(lines) && strings.TrimSuffix(lines[i], "\r") != heredocTag; i++ {
+				body = append(body, lines[i])
+			}
+			attr.Value = strings.Join(body, "\n")
+		} else if unquoted, err := strconv.Unquote(value); err == nil {
+			attr.Value = unquoted
+		} else {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
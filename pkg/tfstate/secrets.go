@@ -0,0 +1,70 @@
+package tfstate
+
+import "strings"
+
+// secretAttributes are attribute names that hold a secret in any provider, such as the password of a database
+// instance or the keys of an Azure storage account.
+var secretAttributes = map[string]struct{}{
+	"password":                       {},
+	"master_password":                {},
+	"admin_password":                 {},
+	"administrator_login_password":   {},
+	"root_password":                  {},
+	"secret":                         {},
+	"client_secret":                  {},
+	"secret_key":                     {},
+	"secret_access_key":              {},
+	"ses_smtp_password_v4":           {},
+	"private_key":                    {},
+	"private_key_pem":                {},
+	"private_key_openssh":            {},
+	"private_key_pem_pkcs8":          {},
+	"token":                          {},
+	"auth_token":                     {},
+	"api_key":                        {},
+	"access_key":                     {},
+	"primary_access_key":             {},
+	"secondary_access_key":           {},
+	"primary_key":                    {},
+	"secondary_key":                  {},
+	"primary_connection_string":      {},
+	"secondary_connection_string":    {},
+	"primary_blob_connection_string": {},
+	"connection_string":              {},
+	"kube_config_raw":                {},
+	"kube_admin_config_raw":          {},
+}
+
+// resourceSecretAttributes are attributes that only hold a secret for a particular resource type.
+var resourceSecretAttributes = map[string]map[string]struct{}{
+	// Generated passwords have no recognizable format, the state is the only place they are stored.
+	"random_password":                    {"result": {}},
+	"azuread_application_password":       {"value": {}},
+	"azuread_service_principal_password": {"value": {}},
+}
+
+// IsSecret reports whether an attribute of a resource type is known to hold a secret. Only the last element of a
+// nested attribute path is considered, so `site_config.0.connection_string` counts as a connection string.
+func IsSecret(resourceType, name string) bool {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	if _, ok := secretAttributes[name]; ok {
+		return true
+	}
+	_, ok := resourceSecretAttributes[resourceType][name]
+	return ok
+}
+
+// ResourceType returns the resource type of an instance address, e.g. `aws_db_instance` for
+// `module.db.aws_db_instance.main[0]`. It returns an empty string for outputs.
+func ResourceType(address string) string {
+	if i := strings.IndexByte(address, '['); i >= 0 {
+		address = address[:i]
+	}
+	parts := strings.Split(address, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
@@ -0,0 +1,209 @@
+// Package tfstate extracts resource attributes from Terraform state files.
+//
+// A state file records every attribute of every managed resource in plain text, including generated passwords,
+// access keys and connection strings. Scanned as plain JSON, a finding in it says nothing about which resource it
+// belongs to, and secrets like random passwords have no shape a detector could recognize. This package flattens the
+// state into one labeled line per attribute, so findings can be attributed to a resource address and attribute name.
+package tfstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Attribute is a string attribute of a resource instance or a root module output.
+type Attribute struct {
+	// Address is the resource instance address, e.g. `module.db.aws_db_instance.main[0]`, or `output` for outputs.
+	Address string
+	// Name is the attribute path within the instance, e.g. `password` or `site_config.0.connection_string`.
+	Name  string
+	Value string
+	// Sensitive reports whether Terraform marked the attribute or output as sensitive.
+	Sensitive bool
+}
+
+// Instance groups the attributes of one resource instance.
+type Instance struct {
+	Address    string
+	Attributes []Attribute
+}
+
+// State holds the resource instances and outputs of a state file, in file order.
+type State struct {
+	Instances []Instance
+}
+
+var errNotState = errors.New("not a terraform state file")
+
+// rawState covers format version 4 (Terraform 0.12 and later) and version 3 (earlier releases).
+type rawState struct {
+	Version          int    `json:"version"`
+	TerraformVersion string `json:"terraform_version"`
+	Outputs          map[string]struct {
+		Value     any  `json:"value"`
+		Sensitive bool `json:"sensitive"`
+	} `json:"outputs"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey            any                `json:"index_key"`
+			Attributes          map[string]any     `json:"attributes"`
+			SensitiveAttributes [][]map[string]any `json:"sensitive_attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+	Modules []struct {
+		Path      []string `json:"path"`
+		Resources map[string]struct {
+			Primary struct {
+				Attributes map[string]string `json:"attributes"`
+			} `json:"primary"`
+		} `json:"resources"`
+	} `json:"modules"`
+}
+
+// Parse parses a Terraform state file.
+func Parse(data []byte) (*State, error) {
+	var raw rawState
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", errNotState, err)
+	}
+	if raw.Version == 0 || raw.TerraformVersion == "" {
+		return nil, errNotState
+	}
+
+	st := &State{}
+	for _, res := range raw.Resources {
+		base := res.Type + "." + res.Name
+		if res.Mode == "data" {
+			base = "data." + base
+		}
+		if res.Module != "" {
+			base = res.Module + "." + base
+		}
+		for _, inst := range res.Instances {
+			address := base + indexSuffix(inst.IndexKey)
+			sensitive := sensitivePaths(inst.SensitiveAttributes)
+			var attrs []Attribute
+			flatten("", inst.Attributes, func(name, value string) {
+				attrs = append(attrs, Attribute{Address: address, Name: name, Value: value, Sensitive: sensitive[name]})
+			})
+			if len(attrs) > 0 {
+				st.Instances = append(st.Instances, Instance{Address: address, Attributes: attrs})
+			}
+		}
+	}
+
+	for _, mod := range raw.Modules {
+		prefix := ""
+		for _, p := range mod.Path {
+			if p != "root" {
+				prefix += "module." + p + "."
+			}
+		}
+		for _, name := range sortedKeys(mod.Resources) {
+			address := prefix + name
+			var attrs []Attribute
+			for _, attr := range sortedKeys(mod.Resources[name].Primary.Attributes) {
+				if value := mod.Resources[name].Primary.Attributes[attr]; value != "" {
+					attrs = append(attrs, Attribute{Address: address, Name: attr, Value: value})
+				}
+			}
+			if len(attrs) > 0 {
+				st.Instances = append(st.Instances, Instance{Address: address, Attributes: attrs})
+			}
+		}
+	}
+
+	var outputs []Attribute
+	for _, name := range sortedKeys(raw.Outputs) {
+		out := raw.Outputs[name]
+		flatten(name, out.Value, func(path, value string) {
+			outputs = append(outputs, Attribute{Address: "output", Name: path, Value: value, Sensitive: out.Sensitive})
+		})
+	}
+	if len(outputs) > 0 {
+		st.Instances = append(st.Instances, Instance{Address: "output", Attributes: outputs})
+	}
+	return st, nil
+}
+
+// Sniff reports whether the start of a file looks like a Terraform state file.
+func Sniff(head []byte) bool {
+	head = bytes.TrimLeft(head, " \t\r\n")
+	return bytes.HasPrefix(head, []byte("{")) &&
+		bytes.Contains(head, []byte(`"terraform_version"`)) &&
+		bytes.Contains(head, []byte(`"lineage"`))
+}
+
+// flatten calls fn for every non-empty string leaf of v, with its dotted path.
+func flatten(path string, v any, fn func(name, value string)) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch val := v.(type) {
+	case string:
+		if val != "" {
+			fn(path, val)
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(val) {
+			flatten(join(key), val[key], fn)
+		}
+	case []any:
+		for i, elem := range val {
+			flatten(join(strconv.Itoa(i)), elem, fn)
+		}
+	}
+}
+
+// sensitivePaths converts the sensitive_attributes of an instance, lists of path steps like
+// [{"type":"get_attr","value":"password"}], into dotted attribute paths.
+func sensitivePaths(paths [][]map[string]any) map[string]bool {
+	out := make(map[string]bool, len(paths))
+	for _, steps := range paths {
+		var parts []string
+		for _, step := range steps {
+			switch val := step["value"].(type) {
+			case string:
+				parts = append(parts, val)
+			case float64:
+				parts = append(parts, strconv.Itoa(int(val)))
+			}
+		}
+		if len(parts) > 0 {
+			out[strings.Join(parts, ".")] = true
+		}
+	}
+	return out
+}
+
+func indexSuffix(key any) string {
+	switch k := key.(type) {
+	case float64:
+		return "[" + strconv.Itoa(int(k)) + "]"
+	case string:
+		return "[" + strconv.Quote(k) + "]"
+	default:
+		return ""
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
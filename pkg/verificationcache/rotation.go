@@ -0,0 +1,235 @@
+package verificationcache
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// FindingStateResolvedRotated is the finding state of a credential that was verified live before and has since been
+// rejected as invalid by its verification endpoint the configured number of consecutive times.
+const FindingStateResolvedRotated = "resolved_rotated"
+
+// Keys of the extra data set on results of resolved credentials. The times are in RFC 3339 format.
+const (
+	findingStateKey   = "finding_state"
+	lastVerifiedAtKey = "last_verified_at"
+	resolvedAtKey     = "resolved_at"
+)
+
+// rotationTracker follows the verification results of the credentials that were verified live, during a scan and, with
+// a state file, across scans. A credential whose verification fails as invalid, rather than with an error, the
+// configured number of consecutive times is resolved. Failures are counted at most once per interval, so each one is
+// a scheduled re-verification rather than another occurrence of the credential. Verifying it live again reopens it.
+type rotationTracker struct {
+	// failures is the number of consecutive invalid verifications that resolve a credential.
+	failures int
+	// interval is how long the cached verification of a live credential is used before it is verified again.
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	states map[string]*credentialState
+}
+
+type credentialState struct {
+	lastVerifiedAt time.Time
+	lastCheckedAt  time.Time
+	failures       int
+	resolvedAt     time.Time
+}
+
+// rotationStateVersion is the version of the rotation state file format.
+const rotationStateVersion = 1
+
+// rotationStateFile is the format of the rotation state file. Credentials are keyed by their hex-encoded cache key, a
+// hash of the secret, so the file holds no secrets.
+type rotationStateFile struct {
+	Version     int                                `json:"version"`
+	UpdatedAt   time.Time                          `json:"updated_at"`
+	Credentials map[string]credentialStateFileItem `json:"credentials"`
+}
+
+type credentialStateFileItem struct {
+	LastVerifiedAt time.Time  `json:"last_verified_at"`
+	LastCheckedAt  time.Time  `json:"last_checked_at"`
+	Failures       int        `json:"failures,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// TrackRotation makes the cache follow the verification results of live credentials over the scan. Once a credential
+// that was verified live is rejected as invalid failures consecutive times, at least reverifyInterval apart, its
+// results are reported with the resolved_rotated finding state and the times it was last verified and resolved.
+// Cached verifications of live credentials are only used for reverifyInterval, so credentials found again in a
+// long-running scan are verified again. It must be called before the cache is used. ReadRotationState and
+// WriteRotationState carry the states over to later scans.
+func (v *VerificationCache) TrackRotation(failures int, reverifyInterval time.Duration) {
+	v.rotation = &rotationTracker{
+		failures: failures,
+		interval: reverifyInterval,
+		now:      time.Now,
+		states:   make(map[string]*credentialState),
+	}
+}
+
+// record updates the state of the credential with the given cache key from a remote verification result.
+func (t *rotationTracker) record(key string, r *detectors.Result) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	st := t.states[key]
+	switch {
+	case r.Verified:
+		if st == nil {
+			st = &credentialState{}
+			t.states[key] = st
+		}
+		*st = credentialState{lastVerifiedAt: now, lastCheckedAt: now}
+	case st == nil:
+		// Only credentials that were seen live can be rotated.
+	case r.VerificationError() != nil:
+		// An error says nothing about the credential, so it neither counts as a failure nor breaks a run of them.
+		st.lastCheckedAt = now
+	case st.failures > 0 && now.Sub(st.lastCheckedAt) < t.interval:
+		// Another occurrence of a credential that already failed its last re-verification.
+	default:
+		st.lastCheckedAt = now
+		st.failures++
+		if st.failures >= t.failures && st.resolvedAt.IsZero() {
+			st.resolvedAt = now
+		}
+	}
+}
+
+// due reports whether the cached verification of the credential with the given cache key is too old to be used.
+// Resolved credentials are verified again too, so a credential that becomes valid again is reopened.
+func (t *rotationTracker) due(key string) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st := t.states[key]
+	return st != nil && t.now().Sub(st.lastCheckedAt) >= t.interval
+}
+
+// annotate sets the finding state of a resolved credential on its result.
+func (t *rotationTracker) annotate(key string, r *detectors.Result) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	st := t.states[key]
+	var lastVerifiedAt, resolvedAt time.Time
+	if st != nil {
+		lastVerifiedAt, resolvedAt = st.lastVerifiedAt, st.resolvedAt
+	}
+	t.mu.Unlock()
+
+	if resolvedAt.IsZero() || r.Verified {
+		return
+	}
+	r.CloneExtraData()
+	r.ExtraData[findingStateKey] = FindingStateResolvedRotated
+	r.ExtraData[lastVerifiedAtKey] = lastVerifiedAt.UTC().Format(time.RFC3339)
+	r.ExtraData[resolvedAtKey] = resolvedAt.UTC().Format(time.RFC3339)
+}
+
+// ReadRotationState loads the credential states written by WriteRotationState at the end of an earlier scan, so that
+// failures of scheduled scans count towards resolving a credential. A missing file is an empty state. TrackRotation
+// must be called first.
+func (v *VerificationCache) ReadRotationState(path string) error {
+	if v.rotation == nil {
+		return errors.New("rotation tracking is not enabled")
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var f rotationStateFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("error parsing rotation state: %w", err)
+	}
+	if f.Version != rotationStateVersion {
+		return fmt.Errorf("unsupported rotation state version %d", f.Version)
+	}
+
+	t := v.rotation
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for hexKey, item := range f.Credentials {
+		key, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return fmt.Errorf("error parsing rotation state: invalid key %q", hexKey)
+		}
+		st := &credentialState{
+			lastVerifiedAt: item.LastVerifiedAt,
+			lastCheckedAt:  item.LastCheckedAt,
+			failures:       item.Failures,
+		}
+		if item.ResolvedAt != nil {
+			st.resolvedAt = *item.ResolvedAt
+		}
+		t.states[string(key)] = st
+	}
+	return nil
+}
+
+// WriteRotationState writes the credential states to path, to be read by the next scan with ReadRotationState. The
+// file is replaced atomically, so an earlier state is never left half written.
+func (v *VerificationCache) WriteRotationState(path string) error {
+	if v.rotation == nil {
+		return errors.New("rotation tracking is not enabled")
+	}
+	t := v.rotation
+	f := rotationStateFile{Version: rotationStateVersion, Credentials: make(map[string]credentialStateFileItem)}
+	t.mu.Lock()
+	f.UpdatedAt = t.now().UTC()
+	for key, st := range t.states {
+		item := credentialStateFileItem{
+			LastVerifiedAt: st.lastVerifiedAt.UTC(),
+			LastCheckedAt:  st.lastCheckedAt.UTC(),
+			Failures:       st.failures,
+		}
+		if !st.resolvedAt.IsZero() {
+			resolvedAt := st.resolvedAt.UTC()
+			item.ResolvedAt = &resolvedAt
+		}
+		f.Credentials[hex.EncodeToString([]byte(key))] = item
+	}
+	t.mu.Unlock()
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating rotation state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error writing rotation state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing rotation state: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
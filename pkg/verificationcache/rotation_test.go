@@ -0,0 +1,111 @@
+package verificationcache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/simple"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestVerificationCache_TrackRotation(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		resultCache ResultCache
+	}{
+		{name: "with result cache", resultCache: simple.NewCache[detectors.Result]()},
+		{name: "without result cache", resultCache: nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := testDetector{results: []detectors.Result{{Redacted: "hello", Raw: []byte("hello")}}}
+			cache := New(tt.resultCache, nil)
+			cache.TrackRotation(2, time.Hour)
+
+			start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+			now := start
+			cache.rotation.now = func() time.Time { return now }
+
+			verify := func(verified bool, verificationErr error) detectors.Result {
+				t.Helper()
+				detector.results[0] = detectors.Result{Redacted: "hello", Raw: []byte("hello"), Verified: verified}
+				if verificationErr != nil {
+					detector.results[0].SetVerificationError(verificationErr)
+				}
+				results, err := cache.FromData(logContext.Background(), &detector, true, false, nil)
+				require.NoError(t, err)
+				require.Len(t, results, 1)
+				return results[0]
+			}
+
+			assert.True(t, verify(true, nil).Verified)
+
+			now = now.Add(2 * time.Hour)
+			assert.Nil(t, verify(false, nil).ExtraData)
+
+			// Errors don't count.
+			now = now.Add(2 * time.Hour)
+			assert.Nil(t, verify(false, errors.New("timeout")).ExtraData)
+
+			now = now.Add(2 * time.Hour)
+			assert.Equal(t, map[string]string{
+				"finding_state":    FindingStateResolvedRotated,
+				"last_verified_at": "2026-10-01T12:00:00Z",
+				"resolved_at":      "2026-10-01T18:00:00Z",
+			}, verify(false, nil).ExtraData)
+
+			// Found again, the credential is still resolved.
+			assert.Equal(t, FindingStateResolvedRotated, verify(false, nil).ExtraData["finding_state"])
+
+			// Verified live again, it is reopened.
+			now = now.Add(2 * time.Hour)
+			res := verify(true, nil)
+			assert.True(t, res.Verified)
+			assert.Nil(t, res.ExtraData)
+		})
+	}
+}
+
+func TestVerificationCache_RotationState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rotation.json")
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// scan runs a scheduled scan at the given time that finds the credential occurrences times.
+	scan := func(at time.Time, verified bool, occurrences int) detectors.Result {
+		t.Helper()
+		cache := New(nil, nil)
+		cache.TrackRotation(2, time.Hour)
+		cache.rotation.now = func() time.Time { return at }
+		require.NoError(t, cache.ReadRotationState(path))
+
+		var res detectors.Result
+		for range occurrences {
+			detector := testDetector{results: []detectors.Result{{Raw: []byte("hello"), Verified: verified}}}
+			results, err := cache.FromData(logContext.Background(), &detector, true, false, nil)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			res = results[0]
+		}
+		require.NoError(t, cache.WriteRotationState(path))
+		return res
+	}
+
+	assert.True(t, scan(start, true, 1).Verified)
+	// Occurrences of the credential within one scan count as a single re-verification.
+	assert.Nil(t, scan(start.Add(24*time.Hour), false, 3).ExtraData)
+	assert.Equal(t, map[string]string{
+		"finding_state":    FindingStateResolvedRotated,
+		"last_verified_at": "2026-10-01T12:00:00Z",
+		"resolved_at":      "2026-10-03T12:00:00Z",
+	}, scan(start.Add(48*time.Hour), false, 1).ExtraData)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hello")
+}
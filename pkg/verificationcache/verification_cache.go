@@ -19,6 +19,9 @@ type VerificationCache struct {
 
 	hashMu sync.Mutex
 	hasher hasher.Hasher
+
+	// rotation follows the verification results of live credentials. It is nil unless TrackRotation was called.
+	rotation *rotationTracker
 }
 
 // New creates a new verification cache with the provided result cache and metrics reporter. If resultCache is nil, the
@@ -63,7 +66,11 @@ func (v *VerificationCache) FromData(
 			}()
 		}
 
-		return detector.FromData(ctx, verify, data)
+		results, err := detector.FromData(ctx, verify, data)
+		if err == nil && verify {
+			v.trackRotation(ctx, results)
+		}
+		return results, err
 	}
 
 	if !forceCacheUpdate {
@@ -87,7 +94,7 @@ func (v *VerificationCache) FromData(
 				v.metrics.AddResultCacheHitsWasted(cacheHitsInCurrentChunk)
 				break
 			}
-			if cacheHit, ok := v.resultCache.Get(string(cacheKey)); ok {
+			if cacheHit, ok := v.resultCache.Get(string(cacheKey)); ok && !v.rotation.due(string(cacheKey)) {
 				withoutRemoteVerification[i].CopyVerificationInfo(&cacheHit)
 				withoutRemoteVerification[i].VerificationFromCache = true
 				v.metrics.AddResultCacheHits(1)
@@ -102,6 +109,7 @@ func (v *VerificationCache) FromData(
 
 		if isEverythingCached {
 			v.metrics.AddCredentialVerificationsSaved(len(withoutRemoteVerification))
+			v.annotateRotation(ctx, withoutRemoteVerification)
 			return withoutRemoteVerification, nil
 		}
 	}
@@ -115,24 +123,61 @@ func (v *VerificationCache) FromData(
 		return nil, err
 	}
 
-	for _, r := range withRemoteVerification {
+	for i, r := range withRemoteVerification {
 		cacheKey, err := v.getResultCacheKey(r)
 		if err != nil {
 			ctx.Logger().Error(err, "error getting result cache key for verification caching",
 				"operation", "write")
 			continue
 		}
+		if verify {
+			v.rotation.record(string(cacheKey), &r)
+		}
 
 		copyForCaching := r
 		// Do not persist raw secret values in a long-lived cache
 		copyForCaching.Raw = nil
 		copyForCaching.RawV2 = nil
 		v.resultCache.Set(string(cacheKey), copyForCaching)
+
+		v.rotation.annotate(string(cacheKey), &withRemoteVerification[i])
 	}
 
 	return withRemoteVerification, nil
 }
 
+// trackRotation records remotely verified results that did not go through the result cache and sets the finding
+// state of resolved credentials on them.
+func (v *VerificationCache) trackRotation(ctx context.Context, results []detectors.Result) {
+	if v.rotation == nil {
+		return
+	}
+	for i := range results {
+		cacheKey, err := v.getResultCacheKey(results[i])
+		if err != nil {
+			ctx.Logger().Error(err, "error getting result cache key for rotation tracking")
+			continue
+		}
+		v.rotation.record(string(cacheKey), &results[i])
+		v.rotation.annotate(string(cacheKey), &results[i])
+	}
+}
+
+// annotateRotation sets the finding state of resolved credentials on results served from the result cache.
+func (v *VerificationCache) annotateRotation(ctx context.Context, results []detectors.Result) {
+	if v.rotation == nil {
+		return
+	}
+	for i := range results {
+		cacheKey, err := v.getResultCacheKey(results[i])
+		if err != nil {
+			ctx.Logger().Error(err, "error getting result cache key for rotation tracking")
+			continue
+		}
+		v.rotation.annotate(string(cacheKey), &results[i])
+	}
+}
+
 func (v *VerificationCache) getResultCacheKey(result detectors.Result) ([]byte, error) {
 	v.hashMu.Lock()
 	defer v.hashMu.Unlock()